@@ -0,0 +1,292 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var adminCmd = &cobra.Command{
+	Use:   "admin",
+	Short: "Administrative operations (admins only)",
+	Run: func(cmd *cobra.Command, args []string) {
+		cmd.Help()
+	},
+}
+
+// adminTokenHeader must match the gateway's service.AdminTokenHeader.
+const adminTokenHeader = "X-Admin-Token"
+
+// newAdminClient is newGatewayClient for AdminService calls, which must also
+// carry the gateway's admin token.
+func newAdminClient(cmd *cobra.Command) (*GatewayClient, error) {
+	token, _ := cmd.Flags().GetString("admin-token")
+	if token == "" {
+		token = os.Getenv("JENNAH_ADMIN_TOKEN")
+	}
+	if token == "" {
+		return nil, fmt.Errorf("no admin token: pass --admin-token or set JENNAH_ADMIN_TOKEN")
+	}
+	gw, err := newGatewayClient(cmd)
+	if err != nil {
+		return nil, err
+	}
+	gw.http.Transport = adminTokenTransport{token: token, next: http.DefaultTransport}
+	return gw, nil
+}
+
+// adminTokenTransport adds the admin token to every request.
+type adminTokenTransport struct {
+	token string
+	next  http.RoundTripper
+}
+
+func (t adminTokenTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Header.Set(adminTokenHeader, t.token)
+	return t.next.RoundTrip(req)
+}
+
+var orphanActions = map[string]string{
+	"none":   "ORPHAN_ACTION_UNSPECIFIED",
+	"cancel": "ORPHAN_ACTION_CANCEL",
+	"delete": "ORPHAN_ACTION_DELETE",
+}
+
+var adminReconcileCmd = &cobra.Command{
+	Use:   "reconcile",
+	Short: "Find orphaned and missing cloud resources",
+	Long: "jennah admin reconcile [--dry-run] [--orphans none|cancel|delete] [--fail-missing] [--grace 10m]\n\n" +
+		"Compares Jennah's cloud resources with the Jobs table. Orphans are resources\n" +
+		"no job points at; missing resources belong to active jobs but no longer exist.\n" +
+		"Without --orphans or --fail-missing nothing is changed.",
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		orphans, _ := cmd.Flags().GetString("orphans")
+		failMissing, _ := cmd.Flags().GetBool("fail-missing")
+		grace, _ := cmd.Flags().GetDuration("grace")
+
+		action, ok := orphanActions[orphans]
+		if !ok {
+			return fmt.Errorf("invalid --orphans %q: must be none, cancel or delete", orphans)
+		}
+		if grace < 0 {
+			return fmt.Errorf("--grace must not be negative")
+		}
+
+		gw, err := newAdminClient(cmd)
+		if err != nil {
+			return err
+		}
+
+		body := map[string]interface{}{
+			"dryRun":             dryRun,
+			"orphanAction":       action,
+			"failMissing":        failMissing,
+			"gracePeriodSeconds": int64(grace.Seconds()),
+		}
+		var result struct {
+			Orphans []struct {
+				CloudResourcePath string `json:"cloudResourcePath"`
+				AssignedService   string `json:"assignedService"`
+				Status            string `json:"status"`
+				CreateTime        string `json:"createTime"`
+				Action            string `json:"action"`
+				Error             string `json:"error"`
+			} `json:"orphans"`
+			Missing []struct {
+				TenantID          string `json:"tenantId"`
+				JobID             string `json:"jobId"`
+				CloudResourcePath string `json:"cloudResourcePath"`
+				Status            string `json:"status"`
+				Action            string `json:"action"`
+				Error             string `json:"error"`
+			} `json:"missing"`
+			ResourcesScanned int      `json:"resourcesScanned"`
+			JobsScanned      int      `json:"jobsScanned"`
+			Errors           []string `json:"errors"`
+			DryRun           bool     `json:"dryRun"`
+		}
+		if err := gw.post("/jennah.v1.AdminService/ReconcileResources", body, &result); err != nil {
+			return fmt.Errorf("reconcile failed: %w", err)
+		}
+
+		if result.DryRun {
+			fmt.Println("Dry run: no changes made")
+		}
+		fmt.Printf("Scanned %d resource(s) and %d job(s)\n", result.ResourcesScanned, result.JobsScanned)
+		for _, e := range result.Errors {
+			fmt.Printf("⚠️  %s\n", e)
+		}
+
+		fmt.Println()
+		fmt.Printf("Orphaned resources (%d)\n", len(result.Orphans))
+		fmt.Println(strings.Repeat("─", 40))
+		for _, o := range result.Orphans {
+			fmt.Printf("  %s\n", o.CloudResourcePath)
+			fmt.Printf("    service=%s status=%s created=%s action=%s\n", o.AssignedService, o.Status, o.CreateTime, o.Action)
+			if o.Error != "" {
+				fmt.Printf("    ❌ %s\n", o.Error)
+			}
+		}
+
+		fmt.Println()
+		fmt.Printf("Missing resources (%d)\n", len(result.Missing))
+		fmt.Println(strings.Repeat("─", 40))
+		for _, m := range result.Missing {
+			fmt.Printf("  job %s (tenant %s) status=%s action=%s\n", m.JobID, m.TenantID, m.Status, m.Action)
+			fmt.Printf("    %s\n", m.CloudResourcePath)
+			if m.Error != "" {
+				fmt.Printf("    ❌ %s\n", m.Error)
+			}
+		}
+		return nil
+	},
+}
+
+var adminRoutingSimCmd = &cobra.Command{
+	Use:   "routing-sim",
+	Short: "Replay past jobs through candidate routing rules",
+	Long: "jennah admin routing-sim [--rules new.yaml] [--since 30d] [--samples 5] [--max-jobs 10000]\n\n" +
+		"Replans jobs created in the window with the given rules file (the worker's\n" +
+		"current rules when omitted) and reports which jobs would change service,\n" +
+		"with cost and start-up estimates from their observed runtimes. Nothing is\n" +
+		"submitted or changed.",
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		rulesPath, _ := cmd.Flags().GetString("rules")
+		sinceFlag, _ := cmd.Flags().GetString("since")
+		samples, _ := cmd.Flags().GetInt("samples")
+		maxJobs, _ := cmd.Flags().GetInt("max-jobs")
+
+		since, err := parseWindow(sinceFlag)
+		if err != nil {
+			return fmt.Errorf("invalid --since %q: %w", sinceFlag, err)
+		}
+		if samples < 0 || maxJobs < 0 {
+			return fmt.Errorf("--samples and --max-jobs must not be negative")
+		}
+
+		body := map[string]interface{}{
+			"sinceSeconds":     int64(since.Seconds()),
+			"samplesPerChange": samples,
+			"maxJobs":          maxJobs,
+		}
+		if rulesPath != "" {
+			data, err := os.ReadFile(rulesPath)
+			if err != nil {
+				return fmt.Errorf("failed to read rules: %w", err)
+			}
+			body["rules"] = string(data)
+		}
+
+		gw, err := newAdminClient(cmd)
+		if err != nil {
+			return err
+		}
+
+		var result struct {
+			JobsReplayed int `json:"jobsReplayed"`
+			JobsChanged  int `json:"jobsChanged"`
+			JobsSkipped  int `json:"jobsSkipped"`
+			Changes      []struct {
+				FromService            string  `json:"fromService"`
+				ToService              string  `json:"toService"`
+				Jobs                   int     `json:"jobs"`
+				JobsWithRuntime        int     `json:"jobsWithRuntime"`
+				ObservedRuntimeSeconds int64   `json:"observedRuntimeSeconds,string"`
+				CurrentCostUsd         float64 `json:"currentCostUsd"`
+				SimulatedCostUsd       float64 `json:"simulatedCostUsd"`
+				StartupDeltaSeconds    int64   `json:"startupDeltaSeconds,string"`
+				Samples                []struct {
+					TenantID        string `json:"tenantId"`
+					JobID           string `json:"jobId"`
+					Name            string `json:"name"`
+					CreatedAt       string `json:"createdAt"`
+					CurrentReason   string `json:"currentReason"`
+					SimulatedReason string `json:"simulatedReason"`
+				} `json:"samples"`
+			} `json:"changes"`
+			CostDeltaUsd        float64 `json:"costDeltaUsd"`
+			StartupDeltaSeconds int64   `json:"startupDeltaSeconds,string"`
+			Rules               int     `json:"rules"`
+			Since               string  `json:"since"`
+		}
+		if err := gw.post("/jennah.v1.AdminService/SimulateRouting", body, &result); err != nil {
+			return fmt.Errorf("routing simulation failed: %w", err)
+		}
+
+		fmt.Printf("Replayed %d job(s) created since %s against %d rule(s)\n", result.JobsReplayed, result.Since, result.Rules)
+		if result.JobsSkipped > 0 {
+			fmt.Printf("⚠️  %d job(s) skipped: stored spec could not be decoded\n", result.JobsSkipped)
+		}
+		fmt.Printf("%d job(s) would change service\n", result.JobsChanged)
+		if result.JobsChanged == 0 {
+			return nil
+		}
+		fmt.Printf("Estimated cost delta: %+.2f USD, start-up delta: %+ds\n", result.CostDeltaUsd, result.StartupDeltaSeconds)
+
+		for _, c := range result.Changes {
+			fmt.Println()
+			fmt.Printf("%s → %s (%d job(s))\n", c.FromService, c.ToService, c.Jobs)
+			fmt.Println(strings.Repeat("─", 40))
+			if c.JobsWithRuntime > 0 {
+				fmt.Printf("  cost %.2f → %.2f USD over %d job(s), %s observed runtime\n",
+					c.CurrentCostUsd, c.SimulatedCostUsd, c.JobsWithRuntime, time.Duration(c.ObservedRuntimeSeconds)*time.Second)
+			}
+			fmt.Printf("  start-up delta %+ds\n", c.StartupDeltaSeconds)
+			for _, s := range c.Samples {
+				name := s.Name
+				if name == "" {
+					name = "-"
+				}
+				fmt.Printf("  job %s (tenant %s) %s created=%s\n", s.JobID, s.TenantID, name, s.CreatedAt)
+				fmt.Printf("    now: %s\n", s.CurrentReason)
+				fmt.Printf("    sim: %s\n", s.SimulatedReason)
+			}
+		}
+		return nil
+	},
+}
+
+// parseWindow parses a Go duration, also accepting a whole number of days
+// such as "30d".
+func parseWindow(v string) (time.Duration, error) {
+	if days, ok := strings.CutSuffix(v, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil || n < 0 {
+			return 0, fmt.Errorf("want a number of days like 30d or a duration like 12h")
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		return 0, err
+	}
+	if d < 0 {
+		return 0, fmt.Errorf("must not be negative")
+	}
+	return d, nil
+}
+
+func init() {
+	adminCmd.PersistentFlags().String("admin-token", "", "Gateway admin token (or JENNAH_ADMIN_TOKEN env var)")
+
+	adminRoutingSimCmd.Flags().String("rules", "", "Candidate routing rules file (default: the worker's current rules)")
+	adminRoutingSimCmd.Flags().String("since", "30d", "How far back to replay jobs, e.g. 30d or 12h")
+	adminRoutingSimCmd.Flags().Int("samples", 5, "Sample jobs to list per change")
+	adminRoutingSimCmd.Flags().Int("max-jobs", 10000, "Maximum number of jobs to replay")
+	adminCmd.AddCommand(adminRoutingSimCmd)
+
+	adminReconcileCmd.Flags().Bool("dry-run", false, "Report what would be done without changing anything")
+	adminReconcileCmd.Flags().String("orphans", "none", "What to do with orphaned resources: none, cancel or delete")
+	adminReconcileCmd.Flags().Bool("fail-missing", false, "Mark active jobs whose resource no longer exists as FAILED")
+	adminReconcileCmd.Flags().Duration("grace", 0, "Ignore resources and jobs younger than this (server default 10m)")
+	adminCmd.AddCommand(adminReconcileCmd)
+}
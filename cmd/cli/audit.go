@@ -0,0 +1,215 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var auditCmd = &cobra.Command{
+	Use:   "audit",
+	Short: "Inspect the audit log of the active organization (admins only)",
+	Long: "jennah audit\n\nThe gateway records every call that changes something: who made it, on\n" +
+		"what, from where and whether it succeeded. Tokens, keys and env var values\n" +
+		"are redacted. Events are kept after the jobs they refer to are deleted.",
+	Run: func(cmd *cobra.Command, args []string) {
+		cmd.Help()
+	},
+}
+
+type auditEvent struct {
+	EventID      string `json:"eventId"`
+	OccurredAt   string `json:"occurredAt"`
+	Procedure    string `json:"procedure"`
+	ActorType    string `json:"actorType"`
+	ActorID      string `json:"actorId"`
+	ActorEmail   string `json:"actorEmail"`
+	TargetType   string `json:"targetType"`
+	TargetID     string `json:"targetId"`
+	ResultCode   string `json:"resultCode"`
+	ErrorMessage string `json:"errorMessage"`
+	ClientIP     string `json:"clientIp"`
+}
+
+// auditRequest builds a ListAuditEvents request from the shared filter
+// flags.
+func auditRequest(cmd *cobra.Command) (map[string]interface{}, error) {
+	procedure, _ := cmd.Flags().GetString("procedure")
+	actor, _ := cmd.Flags().GetString("actor")
+	target, _ := cmd.Flags().GetString("target")
+	sinceFlag, _ := cmd.Flags().GetString("since")
+	untilFlag, _ := cmd.Flags().GetString("until")
+
+	req := map[string]interface{}{"procedure": procedure, "actorId": actor, "targetId": target}
+	for name, value := range map[string]string{"since": sinceFlag, "until": untilFlag} {
+		if value == "" {
+			continue
+		}
+		t, err := parseAuditTime(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --%s %q: %w", name, value, err)
+		}
+		req[name] = t.UTC().Format(time.RFC3339)
+	}
+	return req, nil
+}
+
+// parseAuditTime accepts an RFC 3339 time or a window back from now such
+// as 7d or 12h.
+func parseAuditTime(v string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, v); err == nil {
+		return t, nil
+	}
+	window, err := parseWindow(v)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("want an RFC 3339 time or a window like 7d or 12h")
+	}
+	return time.Now().Add(-window), nil
+}
+
+// auditPage fetches one page of events, keeping each as the gateway sent it.
+func auditPage(gw *GatewayClient, req map[string]interface{}) ([]json.RawMessage, string, error) {
+	var result struct {
+		Events        []json.RawMessage `json:"events"`
+		NextPageToken string            `json:"nextPageToken"`
+	}
+	if err := gw.post("/jennah.v1.DeploymentService/ListAuditEvents", req, &result); err != nil {
+		return nil, "", fmt.Errorf("failed to list audit events: %w", err)
+	}
+	return result.Events, result.NextPageToken, nil
+}
+
+var auditListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List recent audit events",
+	Long: "jennah audit list [--since 7d] [--procedure CancelJob] [--actor github/123] [--target <job-id>] [--limit 50]\n\n" +
+		"Lists the newest events first.",
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		limit, _ := cmd.Flags().GetInt("limit")
+		req, err := auditRequest(cmd)
+		if err != nil {
+			return err
+		}
+		req["pageSize"] = limit
+		gw, err := newGatewayClient(cmd)
+		if err != nil {
+			return err
+		}
+		raw, _, err := auditPage(gw, req)
+		if err != nil {
+			return err
+		}
+		if len(raw) == 0 {
+			fmt.Println("No audit events.")
+			return nil
+		}
+
+		fmt.Printf("%-20s  %-28s  %-22s  %-30s  %s\n", "TIME", "ACTOR", "CALL", "TARGET", "RESULT")
+		fmt.Println(strings.Repeat("─", 120))
+		for _, r := range raw {
+			var e auditEvent
+			if err := json.Unmarshal(r, &e); err != nil {
+				return fmt.Errorf("failed to parse audit event: %w", err)
+			}
+			actor := e.ActorEmail
+			if actor == "" {
+				actor = e.ActorType + " " + e.ActorID
+			}
+			occurred := e.OccurredAt
+			if t, err := time.Parse(time.RFC3339, e.OccurredAt); err == nil {
+				occurred = t.Local().Format("2006-01-02 15:04:05")
+			}
+			target := ""
+			if e.TargetID != "" {
+				target = e.TargetType + " " + e.TargetID
+			}
+			method := e.Procedure[strings.LastIndex(e.Procedure, "/")+1:]
+			fmt.Printf("%-20s  %-28s  %-22s  %-30s  %s\n", occurred, actor, method, target, e.ResultCode)
+		}
+		return nil
+	},
+}
+
+var auditExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export audit events as JSON Lines",
+	Long: "jennah audit export [--since 30d] [--until <time>] [--output audit.jsonl]\n\n" +
+		"Writes every matching event, newest first, one JSON object per line, to\n" +
+		"--output or standard output. Takes the same filters as 'jennah audit list'.",
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		output, _ := cmd.Flags().GetString("output")
+		req, err := auditRequest(cmd)
+		if err != nil {
+			return err
+		}
+		req["pageSize"] = 1000
+		gw, err := newGatewayClient(cmd)
+		if err != nil {
+			return err
+		}
+
+		var out io.Writer = os.Stdout
+		if output != "" && output != "-" {
+			f, err := os.Create(output)
+			if err != nil {
+				return fmt.Errorf("failed to create %s: %w", output, err)
+			}
+			defer f.Close()
+			out = f
+		}
+		w := bufio.NewWriter(out)
+		count := 0
+		for {
+			raw, next, err := auditPage(gw, req)
+			if err != nil {
+				return err
+			}
+			for _, r := range raw {
+				var line bytes.Buffer
+				if err := json.Compact(&line, r); err != nil {
+					return fmt.Errorf("failed to encode audit event: %w", err)
+				}
+				line.WriteByte('\n')
+				if _, err := w.Write(line.Bytes()); err != nil {
+					return err
+				}
+				count++
+			}
+			if next == "" {
+				break
+			}
+			req["pageToken"] = next
+		}
+		if err := w.Flush(); err != nil {
+			return fmt.Errorf("failed to write audit events: %w", err)
+		}
+		if output != "" && output != "-" {
+			fmt.Fprintf(os.Stderr, "✅ Exported %d audit event(s) to %s\n", count, output)
+		}
+		return nil
+	},
+}
+
+func init() {
+	for _, c := range []*cobra.Command{auditListCmd, auditExportCmd} {
+		c.Flags().String("procedure", "", "Only calls to this RPC, e.g. CancelJob")
+		c.Flags().String("actor", "", "Only calls by this actor: <provider>/<user-id> or an API key ID")
+		c.Flags().String("target", "", "Only calls on this resource ID, e.g. a job ID")
+		c.Flags().String("since", "", "Only events at or after this RFC 3339 time or window, e.g. 7d")
+		c.Flags().String("until", "", "Only events before this RFC 3339 time or window")
+	}
+	auditListCmd.Flags().Int("limit", 50, "Number of events to show (at most 1000)")
+	auditExportCmd.Flags().StringP("output", "o", "", "File to write (default: standard output)")
+
+	auditCmd.AddCommand(auditListCmd)
+	auditCmd.AddCommand(auditExportCmd)
+}
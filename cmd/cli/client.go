@@ -7,6 +7,8 @@ import (
 	"io"
 	"net/http"
 	"os"
+	"strconv"
+	"time"
 
 	"github.com/spf13/cobra"
 )
@@ -16,121 +18,163 @@ const defaultGateway = "https://jennah-gateway-382915581671.asia-northeast1.run.
 // GatewayClient sends requests to the Jennah gateway API.
 type GatewayClient struct {
 	baseURL  string
-	email    string
+	token    string
 	userID   string
 	tenantID string
-	provider string
-	http     *http.Client
+	// org is sent as X-Jennah-Org to pick the organization to act on.
+	org  string
+	http *http.Client
 }
 
-// newGatewayClient builds a GatewayClient from flags, env vars, or saved config.
-func newGatewayClient(cmd *cobra.Command) (*GatewayClient, error) {
-	gateway, _ := cmd.Flags().GetString("gateway")
-	email, _ := cmd.Flags().GetString("email")
-	userID, _ := cmd.Flags().GetString("user-id")
-	provider, _ := cmd.Flags().GetString("provider")
+// refreshBefore is how long before its expiry a saved session is refreshed.
+const refreshBefore = 5 * time.Minute
+
+// Rate-limited requests are retried this many times when the gateway asks
+// to wait at most maxRateLimitWait.
+const (
+	maxRateLimitRetries = 3
+	maxRateLimitWait    = time.Minute
+)
 
+// gatewayURL returns the gateway from the --gateway flag, JENNAH_GATEWAY or
+// the default.
+func gatewayURL(cmd *cobra.Command) string {
+	gateway, _ := cmd.Flags().GetString("gateway")
 	if gateway == "" {
 		gateway = os.Getenv("JENNAH_GATEWAY")
 	}
-	if email == "" {
-		email = os.Getenv("JENNAH_EMAIL")
-	}
-	if userID == "" {
-		userID = os.Getenv("JENNAH_USER_ID")
-	}
-	if provider == "" {
-		provider = os.Getenv("JENNAH_PROVIDER")
-	}
-
-	// Fall back to saved config from `jennah login`
-	tenantID := ""
-	if email == "" || userID == "" {
-		if cfg, err := loadConfig(); err == nil && cfg != nil {
-			if email == "" {
-				email = cfg.Email
-			}
-			if userID == "" {
-				userID = cfg.UserID
-			}
-			if provider == "" && cfg.Provider != "" {
-				provider = cfg.Provider
-			}
-			tenantID = cfg.TenantID
-		}
-	}
-
 	if gateway == "" {
 		gateway = defaultGateway
 	}
-	if provider == "" {
-		provider = "google"
+	return gateway
+}
+
+// activeOrg returns the organization from the --org flag or JENNAH_ORG,
+// falling back to the one saved by `jennah org use`.
+func activeOrg(cmd *cobra.Command, cfg *Config) string {
+	org, _ := cmd.Flags().GetString("org")
+	if org == "" {
+		org = os.Getenv("JENNAH_ORG")
+	}
+	if org == "" && cfg != nil {
+		org = cfg.ActiveOrg
+	}
+	return org
+}
+
+// newGatewayClient builds a GatewayClient from flags, env vars, or saved
+// config, refreshing a saved session that is about to expire.
+func newGatewayClient(cmd *cobra.Command) (*GatewayClient, error) {
+	c := &GatewayClient{baseURL: gatewayURL(cmd), org: activeOrg(cmd, nil), http: &http.Client{}}
+
+	// An API key or session token in the environment takes precedence over
+	// `jennah login`. API keys are meant for CI and other automation.
+	if key := os.Getenv("JENNAH_API_KEY"); key != "" {
+		c.token = key
+		return c, nil
+	}
+	if token := os.Getenv("JENNAH_SESSION_TOKEN"); token != "" {
+		c.token = token
+		return c, nil
+	}
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return nil, err
+	}
+	if cfg == nil {
+		return nil, fmt.Errorf("not logged in: run 'jennah login'")
 	}
-	if email == "" {
-		return nil, fmt.Errorf("not logged in: run 'jennah login --email <email> --user-id <id>'")
+	if cfg.SessionToken == "" {
+		return nil, fmt.Errorf("saved credentials have no session: run 'jennah logout' and 'jennah login'")
 	}
-	if userID == "" {
-		return nil, fmt.Errorf("not logged in: run 'jennah login --email <email> --user-id <id>'")
+	c.token, c.userID, c.tenantID = cfg.SessionToken, cfg.UserID, cfg.TenantID
+	c.org = activeOrg(cmd, cfg)
+
+	if expires, err := time.Parse(time.RFC3339, cfg.SessionExpiresAt); err != nil || time.Until(expires) < refreshBefore {
+		if err := c.refreshSession(cfg); err != nil {
+			return nil, err
+		}
 	}
+	return c, nil
+}
 
-	return &GatewayClient{
-		baseURL:  gateway,
-		email:    email,
-		userID:   userID,
-		tenantID: tenantID,
-		provider: provider,
-		http:     &http.Client{},
-	}, nil
+// refreshSession replaces the session token with a new one from the gateway
+// and saves it.
+func (c *GatewayClient) refreshSession(cfg *Config) error {
+	var result struct {
+		SessionToken string `json:"sessionToken"`
+		ExpiresAt    string `json:"expiresAt"`
+	}
+	if err := c.post("/jennah.v1.AuthService/RefreshSession", map[string]string{"sessionToken": cfg.SessionToken}, &result); err != nil {
+		return fmt.Errorf("session expired, run 'jennah logout' and 'jennah login': %w", err)
+	}
+	c.token = result.SessionToken
+	cfg.SessionToken, cfg.SessionExpiresAt = result.SessionToken, result.ExpiresAt
+	if err := saveConfig(cfg); err != nil {
+		return fmt.Errorf("failed to save refreshed session: %w", err)
+	}
+	return nil
 }
 
 // postRaw sends a JSON POST and returns the HTTP status code and raw body.
+// Requests the gateway rate-limits are retried after the Retry-After delay
+// it sends, up to maxRateLimitRetries times.
 func (c *GatewayClient) postRaw(path string, body interface{}) (int, []byte, error) {
-	var buf bytes.Buffer
-	if err := json.NewEncoder(&buf).Encode(body); err != nil {
-		return 0, nil, err
-	}
-	req, err := http.NewRequest("POST", c.baseURL+path, &buf)
+	payload, err := json.Marshal(body)
 	if err != nil {
 		return 0, nil, err
 	}
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("X-OAuth-Email", c.email)
-	req.Header.Set("X-OAuth-UserId", c.userID)
-	req.Header.Set("X-OAuth-Provider", c.provider)
+	for attempt := 0; ; attempt++ {
+		req, err := http.NewRequest("POST", c.baseURL+path, bytes.NewReader(payload))
+		if err != nil {
+			return 0, nil, err
+		}
+		req.Header.Set("Content-Type", "application/json")
+		if c.token != "" {
+			req.Header.Set("Authorization", "Bearer "+c.token)
+		}
+		if c.org != "" {
+			req.Header.Set("X-Jennah-Org", c.org)
+		}
 
-	resp, err := c.http.Do(req)
-	if err != nil {
-		return 0, nil, fmt.Errorf("request failed: %w", err)
+		resp, err := c.http.Do(req)
+		if err != nil {
+			return 0, nil, fmt.Errorf("request failed: %w", err)
+		}
+		respBody, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+
+		wait, limited := retryAfter(resp)
+		if !limited || attempt == maxRateLimitRetries {
+			return resp.StatusCode, respBody, nil
+		}
+		fmt.Fprintf(os.Stderr, "Rate limited by the gateway; retrying in %s...\n", wait)
+		time.Sleep(wait)
 	}
-	defer resp.Body.Close()
-	respBody, _ := io.ReadAll(resp.Body)
-	return resp.StatusCode, respBody, nil
 }
 
-// post sends a JSON POST to the gateway and decodes the response into out.
-func (c *GatewayClient) post(path string, body interface{}, out interface{}) error {
-	var buf bytes.Buffer
-	if err := json.NewEncoder(&buf).Encode(body); err != nil {
-		return err
+// retryAfter returns how long the gateway asked a rate-limited request to
+// wait, and false for other responses or waits over maxRateLimitWait.
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	if resp.StatusCode != http.StatusTooManyRequests {
+		return 0, false
 	}
-
-	req, err := http.NewRequest("POST", c.baseURL+path, &buf)
-	if err != nil {
-		return err
+	seconds, err := strconv.Atoi(resp.Header.Get("Retry-After"))
+	if err != nil || seconds < 0 {
+		return 0, false
 	}
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("X-OAuth-Email", c.email)
-	req.Header.Set("X-OAuth-UserId", c.userID)
-	req.Header.Set("X-OAuth-Provider", c.provider)
+	wait := time.Duration(seconds) * time.Second
+	return wait, wait <= maxRateLimitWait
+}
 
-	resp, err := c.http.Do(req)
+// post sends a JSON POST to the gateway and decodes the response into out.
+func (c *GatewayClient) post(path string, body interface{}, out interface{}) error {
+	status, respBody, err := c.postRaw(path, body)
 	if err != nil {
-		return fmt.Errorf("request failed: %w", err)
+		return err
 	}
-	defer resp.Body.Close()
-
-	respBody, _ := io.ReadAll(resp.Body)
-	if resp.StatusCode != 200 {
+	if status != 200 {
 		var errResp struct {
 			Code    string `json:"code"`
 			Message string `json:"message"`
@@ -138,7 +182,7 @@ func (c *GatewayClient) post(path string, body interface{}, out interface{}) err
 		if json.Unmarshal(respBody, &errResp) == nil && errResp.Message != "" {
 			return fmt.Errorf("%s: %s", errResp.Code, errResp.Message)
 		}
-		return fmt.Errorf("gateway error %d: %s", resp.StatusCode, string(respBody))
+		return fmt.Errorf("gateway error %d: %s", status, string(respBody))
 	}
 
 	if out != nil {
@@ -78,6 +78,14 @@ var getCmd = &cobra.Command{
 			spotVms = "yes"
 		}
 
+		gpu := "—"
+		if j.Accelerator.Type != "" {
+			gpu = j.Accelerator.Type + " x" + j.Accelerator.Count.String()
+			if j.Accelerator.DriverVersion != "" {
+				gpu += " (driver " + j.Accelerator.DriverVersion + ")"
+			}
+		}
+
 		commands := "—"
 		if len(j.Commands) > 0 {
 			commands = strings.Join(j.Commands, " ")
@@ -104,7 +112,22 @@ var getCmd = &cobra.Command{
 		fmt.Printf("Machine Type:    %s\n", dash(j.MachineType))
 		fmt.Printf("Boot Disk:       %s\n", bootDisk)
 		fmt.Printf("Spot VMs:        %s\n", spotVms)
+		fmt.Printf("GPU:             %s\n", gpu)
+		if len(j.Runnables) > 0 {
+			fmt.Printf("Steps:\n")
+			for i, r := range j.Runnables {
+				fmt.Printf("  %d. %s\n", i+1, r)
+			}
+		}
+		if len(j.Volumes) > 0 {
+			fmt.Printf("Volumes:\n")
+			for _, v := range j.Volumes {
+				fmt.Printf("  %s\n", v)
+			}
+		}
+		fmt.Printf("Preemptions:     %s\n", dashNum(j.PreemptionCount))
 		fmt.Printf("Service Account: %s\n", dash(j.ServiceAccount))
+		fmt.Printf("Region:          %s\n", dash(j.Region))
 		fmt.Printf("GCP Job Path:    %s\n", dash(j.GcpBatchJobPath))
 		fmt.Printf("Image:           %s\n", dash(j.ImageURI))
 		if j.EnvVarsJson != "" && j.EnvVarsJson != "{}" && j.EnvVarsJson != "null" {
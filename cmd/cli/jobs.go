@@ -3,6 +3,7 @@ package main
 import (
 	"encoding/json"
 	"fmt"
+	"strings"
 )
 
 // ResourceOverride holds the per-job resource limits sent to the gateway.
@@ -12,6 +13,94 @@ type ResourceOverride struct {
 	MaxRunDurationSeconds json.Number `json:"maxRunDurationSeconds"`
 }
 
+// Accelerator is the GPU request attached to a job.
+type Accelerator struct {
+	Type          string      `json:"type"`
+	Count         json.Number `json:"count"`
+	DriverVersion string      `json:"driverVersion"`
+}
+
+// Volume is a storage mount attached to a job.
+type Volume struct {
+	MountPath string `json:"mountPath"`
+	ReadOnly  bool   `json:"readOnly"`
+	Gcs       *struct {
+		Bucket string `json:"bucket"`
+	} `json:"gcs"`
+	Nfs *struct {
+		Server string `json:"server"`
+		Path   string `json:"path"`
+	} `json:"nfs"`
+	LocalSsd *struct {
+		SizeGb json.Number `json:"sizeGb"`
+	} `json:"localSsd"`
+}
+
+// String renders the volume in the same form the --volume flag accepts.
+func (v Volume) String() string {
+	var s string
+	switch {
+	case v.Gcs != nil:
+		s = "gcs:" + v.Gcs.Bucket + ":" + v.MountPath
+	case v.Nfs != nil:
+		s = "nfs:" + v.Nfs.Server + ":" + v.Nfs.Path + ":" + v.MountPath
+	case v.LocalSsd != nil:
+		s = "ssd:" + v.MountPath
+		if size := v.LocalSsd.SizeGb.String(); size != "" {
+			s += ":" + size
+		}
+	default:
+		s = v.MountPath
+	}
+	if v.ReadOnly {
+		s += ":ro"
+	}
+	return s
+}
+
+// Runnable is one ordered task step of a multi-step job.
+type Runnable struct {
+	Name             string   `json:"name"`
+	ImageURI         string   `json:"imageUri"`
+	Commands         []string `json:"commands"`
+	Script           string   `json:"script"`
+	Barrier          bool     `json:"barrier"`
+	Background       bool     `json:"background"`
+	IgnoreExitStatus bool     `json:"ignoreExitStatus"`
+	AlwaysRun        bool     `json:"alwaysRun"`
+}
+
+// String summarises the step as "name: what [flags]".
+func (r Runnable) String() string {
+	what := "container " + strings.Join(r.Commands, " ")
+	switch {
+	case r.Barrier:
+		what = "barrier"
+	case r.Script != "":
+		what = "script"
+	case r.ImageURI != "":
+		what = "container " + r.ImageURI + " " + strings.Join(r.Commands, " ")
+	}
+	var flags []string
+	if r.Background {
+		flags = append(flags, "background")
+	}
+	if r.IgnoreExitStatus {
+		flags = append(flags, "ignore-exit-status")
+	}
+	if r.AlwaysRun {
+		flags = append(flags, "always-run")
+	}
+	s := strings.TrimSpace(what)
+	if r.Name != "" {
+		s = r.Name + ": " + s
+	}
+	if len(flags) > 0 {
+		s += " [" + strings.Join(flags, ", ") + "]"
+	}
+	return s
+}
+
 // Job is the common job structure returned by the gateway.
 type Job struct {
 	JobID            string           `json:"jobId"`
@@ -38,6 +127,11 @@ type Job struct {
 	ServiceAccount   string           `json:"serviceAccount"`
 	ComplexityLevel  string           `json:"complexityLevel"`
 	AssignedService  string           `json:"assignedService"`
+	Region           string           `json:"region"`
+	PreemptionCount  json.Number      `json:"preemptionCount"`
+	Accelerator      Accelerator      `json:"accelerator"`
+	Volumes          []Volume         `json:"volumes"`
+	Runnables        []Runnable       `json:"runnables"`
 }
 
 // fetchJobs calls ListJobs on the gateway and returns all jobs for the user.
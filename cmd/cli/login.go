@@ -25,7 +25,6 @@ const githubClientID = "Ov23lieja2yqgsKqGkGT"
 const (
 	githubDeviceCodeURL = "https://github.com/login/device/code"
 	githubTokenURL      = "https://github.com/login/oauth/access_token"
-	githubUserURL       = "https://api.github.com/user"
 )
 
 type githubDeviceCodeResp struct {
@@ -45,13 +44,6 @@ type githubTokenResp struct {
 	ErrorDesc   string `json:"error_description"`
 }
 
-type githubUserResp struct {
-	ID    int64  `json:"id"`
-	Login string `json:"login"`
-	Email string `json:"email"` // may be empty if user keeps it private
-	Name  string `json:"name"`
-}
-
 func githubPostJSON(endpoint string, params url.Values) (*http.Response, error) {
 	req, err := http.NewRequest("POST", endpoint, strings.NewReader(params.Encode()))
 	if err != nil {
@@ -126,61 +118,37 @@ func githubPollForToken(clientID, deviceCode string, intervalSec, expiresSec int
 	return "", fmt.Errorf("timed out waiting for GitHub authorization")
 }
 
-func githubGetPrimaryEmail(accessToken string) (string, error) {
-	req, err := http.NewRequest("GET", "https://api.github.com/user/emails", nil)
+// githubDeviceLogin runs the GitHub Device Flow and returns the access
+// token the user authorized.
+func githubDeviceLogin() (string, error) {
+	clientID := githubClientID
+
+	// Step 1: Request a device + user code from GitHub.
+	dcResp, err := githubRequestDeviceCode(clientID)
 	if err != nil {
 		return "", err
 	}
-	req.Header.Set("Authorization", "Bearer "+accessToken)
-	req.Header.Set("Accept", "application/vnd.github+json")
-	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
 
-	resp, err := http.DefaultClient.Do(req)
-	if err != nil {
-		return "", fmt.Errorf("fetching GitHub emails: %w", err)
-	}
-	defer resp.Body.Close()
-
-	var emails []struct {
-		Email    string `json:"email"`
-		Primary  bool   `json:"primary"`
-		Verified bool   `json:"verified"`
-	}
-	if err := json.NewDecoder(resp.Body).Decode(&emails); err != nil {
-		return "", fmt.Errorf("decoding emails response: %w", err)
-	}
-	for _, e := range emails {
-		if e.Primary && e.Verified {
-			return e.Email, nil
+	// Step 2: Open the browser and show the user code.
+	fmt.Printf("Opening GitHub in your browser...\n")
+	fmt.Printf("If it doesn't open, go to: \033[36m%s\033[0m\n\n", dcResp.VerificationURI)
+	fmt.Printf("Enter this code: \033[1;33m%s\033[0m\n\n", dcResp.UserCode)
+	openBrowser(dcResp.VerificationURI)
+
+	// Live countdown while polling.
+	var done atomic.Bool
+	go func() {
+		for secs := dcResp.ExpiresIn; secs >= 0 && !done.Load(); secs-- {
+			fmt.Printf("\rWaiting for authorization (expires in \033[31m%ds\033[0m)...", secs)
+			time.Sleep(1 * time.Second)
 		}
-	}
-	return "", fmt.Errorf("no primary verified email found on GitHub account")
-}
-
-func githubGetUser(accessToken string) (*githubUserResp, error) {
-	req, err := http.NewRequest("GET", githubUserURL, nil)
-	if err != nil {
-		return nil, err
-	}
-	req.Header.Set("Authorization", "Bearer "+accessToken)
-	req.Header.Set("Accept", "application/vnd.github+json")
-	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
-
-	resp, err := http.DefaultClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("fetching GitHub user: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("GitHub user API returned %d", resp.StatusCode)
-	}
+	}()
 
-	var user githubUserResp
-	if err := json.NewDecoder(resp.Body).Decode(&user); err != nil {
-		return nil, fmt.Errorf("decoding user response: %w", err)
-	}
-	return &user, nil
+	// Step 3: Poll until approved or expired.
+	accessToken, err := githubPollForToken(clientID, dcResp.DeviceCode, dcResp.Interval, dcResp.ExpiresIn)
+	done.Store(true)
+	fmt.Print("\r\033[2K") // clear the countdown line
+	return accessToken, err
 }
 
 // Config holds saved credentials.
@@ -189,6 +157,12 @@ type Config struct {
 	UserID   string `json:"user_id"`
 	TenantID string `json:"tenant_id,omitempty"`
 	Provider string `json:"provider,omitempty"`
+	// SessionToken is issued by the gateway and sent on every request.
+	SessionToken     string `json:"session_token,omitempty"`
+	SessionExpiresAt string `json:"session_expires_at,omitempty"`
+	// ActiveOrg is the organization chosen with `jennah org use`; empty
+	// means the personal one.
+	ActiveOrg string `json:"active_org,omitempty"`
 }
 
 func configPath() (string, error) {
@@ -272,74 +246,37 @@ var loginCmd = &cobra.Command{
 			return nil
 		}
 
-		clientID := githubClientID
-
 		fmt.Println("Log in to Jennah")
 		fmt.Println("────────────────")
 		fmt.Println("Authenticating via GitHub...")
 		fmt.Println()
 
-		// Step 1: Request a device + user code from GitHub.
-		dcResp, err := githubRequestDeviceCode(clientID)
-		if err != nil {
-			return err
-		}
-
-		// Step 2: Open the browser and show the user code.
-		fmt.Printf("Opening GitHub in your browser...\n")
-		fmt.Printf("If it doesn't open, go to: \033[36m%s\033[0m\n\n", dcResp.VerificationURI)
-		fmt.Printf("Enter this code: \033[1;33m%s\033[0m\n\n", dcResp.UserCode)
-		openBrowser(dcResp.VerificationURI)
-
-		// Live countdown while polling.
-		var done atomic.Bool
-		go func() {
-			for secs := dcResp.ExpiresIn; secs >= 0 && !done.Load(); secs-- {
-				fmt.Printf("\rWaiting for authorization (expires in \033[31m%ds\033[0m)...", secs)
-				time.Sleep(1 * time.Second)
-			}
-		}()
-
-		// Step 3: Poll until approved or expired.
-		accessToken, err := githubPollForToken(clientID, dcResp.DeviceCode, dcResp.Interval, dcResp.ExpiresIn)
-		done.Store(true)
-		fmt.Print("\r\033[2K") // clear the countdown line
+		// Steps 1-3: GitHub Device Flow.
+		accessToken, err := githubDeviceLogin()
 		if err != nil {
 			return err
 		}
 
-		// Step 4: Fetch the GitHub user profile.
-		ghUser, err := githubGetUser(accessToken)
-		if err != nil {
-			return fmt.Errorf("failed to fetch GitHub user info: %w", err)
-		}
-
-		userID := ghUser.Login
-
-		email := ghUser.Email
-		if email == "" {
-			// Email is private — fetch it explicitly via /user/emails.
-			email, err = githubGetPrimaryEmail(accessToken)
-			if err != nil {
-				return fmt.Errorf("could not retrieve GitHub email: %w", err)
-			}
-		}
-
-		// Temporarily save config so newGatewayClient can read headers.
-		cfg := &Config{Email: email, UserID: userID, Provider: "github"}
-		if err := saveConfig(cfg); err != nil {
-			return fmt.Errorf("failed to save credentials: %w", err)
-		}
-
+		// Step 4: Exchange the GitHub token for a gateway session. The
+		// gateway verifies the token with GitHub itself.
 		fmt.Println()
 		fmt.Println("Checking account...")
 
-		gw, err := newGatewayClient(cmd)
-		if err != nil {
-			path, _ := configPath()
-			os.Remove(path)
-			return err
+		gw := &GatewayClient{baseURL: gatewayURL(cmd), http: &http.Client{}}
+		var session struct {
+			SessionToken  string `json:"sessionToken"`
+			ExpiresAt     string `json:"expiresAt"`
+			UserEmail     string `json:"userEmail"`
+			OAuthProvider string `json:"oauthProvider"`
+			OAuthUserID   string `json:"oauthUserId"`
+		}
+		if err := gw.post("/jennah.v1.AuthService/CreateSession", map[string]string{
+			"provider": "github",
+			"token":    accessToken,
+		}, &session); err != nil {
+			return fmt.Errorf("sign-in failed: %w", err)
 		}
+		gw.token, gw.userID = session.SessionToken, session.OAuthUserID
 
 		var tenantResult struct {
 			TenantID  string `json:"tenantId"`
@@ -347,8 +284,6 @@ var loginCmd = &cobra.Command{
 			CreatedAt string `json:"createdAt"`
 		}
 		if err := gw.post("/jennah.v1.DeploymentService/GetCurrentTenant", map[string]interface{}{}, &tenantResult); err != nil {
-			path, _ := configPath()
-			os.Remove(path)
 			return fmt.Errorf("could not reach server: %w", err)
 		}
 
@@ -364,14 +299,20 @@ var loginCmd = &cobra.Command{
 			fmt.Println("✅ Existing account found.")
 		}
 
-		// Save tenant ID into config.
-		cfg.TenantID = tenantResult.TenantID
+		cfg := &Config{
+			Email:            session.UserEmail,
+			UserID:           session.OAuthUserID,
+			TenantID:         tenantResult.TenantID,
+			Provider:         session.OAuthProvider,
+			SessionToken:     session.SessionToken,
+			SessionExpiresAt: session.ExpiresAt,
+		}
 		if err := saveConfig(cfg); err != nil {
-			return fmt.Errorf("failed to save tenant id: %w", err)
+			return fmt.Errorf("failed to save credentials: %w", err)
 		}
 
 		fmt.Println()
-		fmt.Printf("Logged in as \033[36m%s\033[0m (%s)\n", ghUser.Login, email)
+		fmt.Printf("Logged in as \033[36m%s\033[0m (%s)\n", session.OAuthUserID, session.UserEmail)
 		fmt.Println()
 		rootCmd.Help()
 		return nil
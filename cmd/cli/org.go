@@ -0,0 +1,274 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var orgCmd = &cobra.Command{
+	Use:   "org",
+	Short: "Manage organizations and pick the active one",
+	Long: "jennah org\n\nAn organization is a tenant shared by several members. Viewers can see jobs,\n" +
+		"submitters can also submit and cancel them, and admins can also manage\n" +
+		"members and API keys. Everyone also has a personal organization.",
+	Run: func(cmd *cobra.Command, args []string) {
+		cmd.Help()
+	},
+}
+
+type orgInfo struct {
+	OrgID     string `json:"orgId"`
+	Name      string `json:"name"`
+	Role      string `json:"role"`
+	CreatedAt string `json:"createdAt"`
+}
+
+func (o orgInfo) displayName() string {
+	if o.Name == "" {
+		return "(personal)"
+	}
+	return o.Name
+}
+
+func fetchOrgs(gw *GatewayClient) ([]orgInfo, error) {
+	var result struct {
+		Organizations []orgInfo `json:"organizations"`
+	}
+	if err := gw.post("/jennah.v1.DeploymentService/ListOrganizations", map[string]interface{}{}, &result); err != nil {
+		return nil, fmt.Errorf("failed to list organizations: %w", err)
+	}
+	return result.Organizations, nil
+}
+
+var orgListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List your organizations",
+	Long:  "jennah org list\n\nThe active organization is marked with *.",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		gw, err := newGatewayClient(cmd)
+		if err != nil {
+			return err
+		}
+		orgs, err := fetchOrgs(gw)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("  %-38s  %-30s  %s\n", "ORG ID", "NAME", "ROLE")
+		fmt.Println(strings.Repeat("─", 82))
+		for i, o := range orgs {
+			active := gw.org == o.OrgID || (gw.org == "" && i == 0)
+			marker := " "
+			if active {
+				marker = "*"
+			}
+			fmt.Printf("%s %-38s  %-30s  %s\n", marker, o.OrgID, o.displayName(), o.Role)
+		}
+		return nil
+	},
+}
+
+var orgCreateCmd = &cobra.Command{
+	Use:   "create <name>",
+	Short: "Create an organization",
+	Long:  "jennah org create <name>\n\nCreates an organization with you as its admin.",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		gw, err := newGatewayClient(cmd)
+		if err != nil {
+			return err
+		}
+		var result struct {
+			Organization orgInfo `json:"organization"`
+		}
+		if err := gw.post("/jennah.v1.DeploymentService/CreateOrganization", map[string]string{"name": args[0]}, &result); err != nil {
+			return fmt.Errorf("failed to create organization: %w", err)
+		}
+		fmt.Printf("✅ Created organization %s (%s)\n", result.Organization.Name, result.Organization.OrgID)
+		fmt.Printf("Run 'jennah org use %s' to switch to it.\n", result.Organization.OrgID)
+		return nil
+	},
+}
+
+var orgUseCmd = &cobra.Command{
+	Use:   "use <org-id|personal>",
+	Short: "Set the active organization",
+	Long: "jennah org use <org-id|personal>\n\nSaves the organization that later commands act on. The --org flag and\n" +
+		"JENNAH_ORG override it.",
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := loadConfig()
+		if err != nil {
+			return err
+		}
+		if cfg == nil {
+			return fmt.Errorf("not logged in: run 'jennah login'")
+		}
+		gw, err := newGatewayClient(cmd)
+		if err != nil {
+			return err
+		}
+		orgs, err := fetchOrgs(gw)
+		if err != nil {
+			return err
+		}
+
+		// The client may have refreshed and saved the session.
+		if cfg, err = loadConfig(); err != nil {
+			return err
+		}
+		var chosen *orgInfo
+		for i, o := range orgs {
+			if o.OrgID == args[0] || (args[0] == "personal" && o.Name == "") {
+				chosen = &orgs[i]
+				break
+			}
+		}
+		if chosen == nil {
+			return fmt.Errorf("you are not a member of organization %s; see 'jennah org list'", args[0])
+		}
+		cfg.ActiveOrg = chosen.OrgID
+		if chosen.Name == "" {
+			cfg.ActiveOrg = ""
+		}
+		if err := saveConfig(cfg); err != nil {
+			return fmt.Errorf("failed to save active organization: %w", err)
+		}
+		fmt.Printf("Active organization: \033[36m%s\033[0m (%s, %s)\n", chosen.displayName(), chosen.OrgID, chosen.Role)
+		return nil
+	},
+}
+
+var orgMembersCmd = &cobra.Command{
+	Use:   "members",
+	Short: "List the members of the active organization",
+	Long:  "jennah org members",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		gw, err := newGatewayClient(cmd)
+		if err != nil {
+			return err
+		}
+		var result struct {
+			Members []struct {
+				Email         string `json:"email"`
+				OAuthProvider string `json:"oauthProvider"`
+				OAuthUserID   string `json:"oauthUserId"`
+				Role          string `json:"role"`
+			} `json:"members"`
+		}
+		if err := gw.post("/jennah.v1.DeploymentService/ListMembers", map[string]interface{}{}, &result); err != nil {
+			return fmt.Errorf("failed to list members: %w", err)
+		}
+
+		fmt.Printf("%-36s  %-30s  %s\n", "EMAIL", "USER", "ROLE")
+		fmt.Println(strings.Repeat("─", 80))
+		for _, m := range result.Members {
+			fmt.Printf("%-36s  %-30s  %s\n", m.Email, m.OAuthProvider+"/"+m.OAuthUserID, m.Role)
+		}
+		return nil
+	},
+}
+
+var orgInviteCmd = &cobra.Command{
+	Use:   "invite <email>",
+	Short: "Invite someone to the active organization (admins only)",
+	Long: "jennah org invite <email> [--role viewer|submitter|admin]\n\nThe invitee accepts with 'jennah org accept <invitation-id>' after\n" +
+		"logging in with an account that has this email.",
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		role, _ := cmd.Flags().GetString("role")
+		gw, err := newGatewayClient(cmd)
+		if err != nil {
+			return err
+		}
+		var result struct {
+			Invitation struct {
+				InvitationID string `json:"invitationId"`
+				Email        string `json:"email"`
+				Role         string `json:"role"`
+				ExpiresAt    string `json:"expiresAt"`
+			} `json:"invitation"`
+		}
+		if err := gw.post("/jennah.v1.DeploymentService/InviteMember", map[string]string{"email": args[0], "role": role}, &result); err != nil {
+			return fmt.Errorf("failed to invite %s: %w", args[0], err)
+		}
+		inv := result.Invitation
+		fmt.Printf("✅ Invited %s as %s (expires %s)\n", inv.Email, inv.Role, inv.ExpiresAt)
+		fmt.Printf("Invitation ID: %s\n", inv.InvitationID)
+		return nil
+	},
+}
+
+var orgInvitationsCmd = &cobra.Command{
+	Use:   "invitations",
+	Short: "List invitations sent to you",
+	Long:  "jennah org invitations",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		gw, err := newGatewayClient(cmd)
+		if err != nil {
+			return err
+		}
+		var result struct {
+			Invitations []struct {
+				InvitationID string `json:"invitationId"`
+				OrgName      string `json:"orgName"`
+				Role         string `json:"role"`
+				InvitedBy    string `json:"invitedBy"`
+			} `json:"invitations"`
+		}
+		if err := gw.post("/jennah.v1.DeploymentService/ListInvitations", map[string]interface{}{}, &result); err != nil {
+			return fmt.Errorf("failed to list invitations: %w", err)
+		}
+		if len(result.Invitations) == 0 {
+			fmt.Println("No pending invitations.")
+			return nil
+		}
+
+		fmt.Printf("%-38s  %-24s  %-10s  %s\n", "INVITATION ID", "ORGANIZATION", "ROLE", "INVITED BY")
+		fmt.Println(strings.Repeat("─", 100))
+		for _, inv := range result.Invitations {
+			fmt.Printf("%-38s  %-24s  %-10s  %s\n", inv.InvitationID, inv.OrgName, inv.Role, inv.InvitedBy)
+		}
+		return nil
+	},
+}
+
+var orgAcceptCmd = &cobra.Command{
+	Use:   "accept <invitation-id>",
+	Short: "Accept an invitation",
+	Long:  "jennah org accept <invitation-id>",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		gw, err := newGatewayClient(cmd)
+		if err != nil {
+			return err
+		}
+		var result struct {
+			Organization orgInfo `json:"organization"`
+		}
+		if err := gw.post("/jennah.v1.DeploymentService/AcceptInvitation", map[string]string{"invitationId": args[0]}, &result); err != nil {
+			return fmt.Errorf("failed to accept invitation: %w", err)
+		}
+		o := result.Organization
+		fmt.Printf("✅ Joined %s as %s\n", o.displayName(), o.Role)
+		fmt.Printf("Run 'jennah org use %s' to switch to it.\n", o.OrgID)
+		return nil
+	},
+}
+
+func init() {
+	orgInviteCmd.Flags().String("role", "viewer", "Role of the new member: viewer, submitter or admin")
+
+	orgCmd.AddCommand(orgListCmd)
+	orgCmd.AddCommand(orgCreateCmd)
+	orgCmd.AddCommand(orgUseCmd)
+	orgCmd.AddCommand(orgMembersCmd)
+	orgCmd.AddCommand(orgInviteCmd)
+	orgCmd.AddCommand(orgInvitationsCmd)
+	orgCmd.AddCommand(orgAcceptCmd)
+}
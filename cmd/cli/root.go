@@ -19,10 +19,9 @@ var rootCmd = &cobra.Command{
 func init() {
 	cobra.EnableCommandSorting = false
 
-	rootCmd.PersistentFlags().String("provider", "", "OAuth provider, default: google (or JENNAH_PROVIDER env var)")
 	rootCmd.PersistentFlags().String("gateway", "", "Gateway URL (or JENNAH_GATEWAY env var)")
-	rootCmd.PersistentFlags().MarkHidden("provider")
 	rootCmd.PersistentFlags().MarkHidden("gateway")
+	rootCmd.PersistentFlags().String("org", "", "Organization ID to act on (or JENNAH_ORG env var; default: set by 'jennah org use')")
 
 	rootCmd.CompletionOptions.DisableDefaultCmd = true
 
@@ -31,6 +30,9 @@ func init() {
 	rootCmd.AddCommand(listCmd)
 	rootCmd.AddCommand(deleteCmd)
 	rootCmd.AddCommand(tenantCmd)
+	rootCmd.AddCommand(orgCmd)
+	rootCmd.AddCommand(auditCmd)
+	rootCmd.AddCommand(adminCmd)
 	rootCmd.AddCommand(loginCmd)
 	rootCmd.AddCommand(logoutCmd)
 }
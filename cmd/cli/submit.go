@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"os/signal"
+	"strconv"
 	"strings"
 	"syscall"
 	"time"
@@ -18,9 +19,24 @@ var submitCmd = &cobra.Command{
 	Long: `Reads base job parameters from a JSON file and submits the job.
 Flags override values in the JSON file.
 
-Routing tiers (decided automatically by the gateway using Gemini AI):
-  SIMPLE  → Cloud Run Jobs (no machine type, cpu ≤ 4000m, memory ≤ 8192 MiB, timeout ≤ 3600s)
-  COMPLEX → Cloud Batch    (machine type set, cpu > 4000m, memory > 8192 MiB, or timeout > 3600s)`,
+Routing tiers (decided automatically from each provider's reported limits):
+  SIMPLE  → Cloud Run Jobs (no machine type or spot, resources within Cloud Run limits)
+  COMPLEX → Cloud Batch    (machine type, spot, GPU or local SSD set, or resources beyond Cloud Run limits)
+
+Volumes (--volume, repeatable):
+  gcs:BUCKET:MOUNT_PATH[:ro]          Cloud Storage bucket
+  nfs:SERVER:EXPORT:MOUNT_PATH[:ro]   NFS share (e.g. Filestore)
+  ssd:MOUNT_PATH[:SIZE_GB]            local SSD scratch (Cloud Batch only, 375 GB steps)
+
+Labels (--label KEY=VALUE, repeatable) are attached to the job's cloud
+resources for billing, e.g. --label cost-center=cc-1234. Keys and values use
+lowercase letters, digits, "_" and "-".
+
+Multi-step tasks (setup, sidecar, teardown) are declared in the JSON file as
+an ordered "runnables" list and always run on Cloud Batch.
+
+The routing reason printed after submission names the limit that excluded
+each cheaper provider.`,
 
 	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
@@ -50,6 +66,7 @@ Routing tiers (decided automatically by the gateway using Gemini AI):
 			"boot_disk_size_gb": "bootDiskSizeGb",
 			"use_spot_vms":      "useSpotVms",
 			"service_account":   "serviceAccount",
+			"preemption_policy": "preemptionPolicy",
 		}
 		for snake, camel := range snakeToCamel {
 			if _, hasCamel := body[camel]; !hasCamel {
@@ -78,6 +95,72 @@ Routing tiers (decided automatically by the gateway using Gemini AI):
 			body["useSpotVms"] = true
 		}
 
+		// preemption_policy sub-object (merges with existing if present)
+		if cmd.Flags().Changed("max-preemption-resubmits") || cmd.Flags().Changed("standard-after-preemptions") {
+			policy, _ := body["preemptionPolicy"].(map[string]interface{})
+			if policy == nil {
+				policy = map[string]interface{}{}
+			}
+			if cmd.Flags().Changed("max-preemption-resubmits") {
+				policy["maxResubmits"], _ = cmd.Flags().GetInt32("max-preemption-resubmits")
+			}
+			if cmd.Flags().Changed("standard-after-preemptions") {
+				policy["standardAfter"], _ = cmd.Flags().GetInt32("standard-after-preemptions")
+			}
+			body["preemptionPolicy"] = policy
+		}
+
+		// --gpu type[:count] and --gpu-driver (merge with accelerator from the JSON file)
+		if accel, ok := body["accelerator"].(map[string]interface{}); ok {
+			if v, ok := accel["driver_version"]; ok {
+				accel["driverVersion"] = v
+				delete(accel, "driver_version")
+			}
+		}
+		if v, _ := cmd.Flags().GetString("gpu"); v != "" {
+			accel, err := parseGPUFlag(v)
+			if err != nil {
+				return err
+			}
+			body["accelerator"] = accel
+		}
+		if v, _ := cmd.Flags().GetString("gpu-driver"); v != "" {
+			accel, _ := body["accelerator"].(map[string]interface{})
+			if accel == nil {
+				return fmt.Errorf("--gpu-driver requires --gpu or an accelerator in the job file")
+			}
+			accel["driverVersion"] = v
+		}
+
+		// --volume (appends to volumes from the JSON file)
+		if specs, _ := cmd.Flags().GetStringArray("volume"); len(specs) > 0 {
+			volumes, _ := body["volumes"].([]interface{})
+			for _, spec := range specs {
+				vol, err := parseVolumeFlag(spec)
+				if err != nil {
+					return err
+				}
+				volumes = append(volumes, vol)
+			}
+			body["volumes"] = volumes
+		}
+
+		// --label (merges with labels from the JSON file)
+		if specs, _ := cmd.Flags().GetStringArray("label"); len(specs) > 0 {
+			labels, _ := body["labels"].(map[string]interface{})
+			if labels == nil {
+				labels = make(map[string]interface{})
+			}
+			for _, spec := range specs {
+				k, v, ok := strings.Cut(spec, "=")
+				if !ok || k == "" {
+					return fmt.Errorf("invalid --label %q: want KEY=VALUE", spec)
+				}
+				labels[k] = v
+			}
+			body["labels"] = labels
+		}
+
 		// --instances: inject JENNAH_TASK_COUNT + JENNAH_PARALLELISM into envVars
 		if instances, _ := cmd.Flags().GetInt64("instances"); instances > 1 {
 			envVars, _ := body["envVars"].(map[string]interface{})
@@ -132,6 +215,13 @@ Routing tiers (decided automatically by the gateway using Gemini AI):
 		if instances, _ := cmd.Flags().GetInt64("instances"); instances > 1 {
 			fmt.Printf("Instances:    %d\n", instances)
 		}
+		if accel, ok := body["accelerator"].(map[string]interface{}); ok {
+			count, ok := accel["count"]
+			if !ok {
+				count = 1
+			}
+			fmt.Printf("GPU:          %v x%v\n", accel["type"], count)
+		}
 		fmt.Println()
 
 		payloadJSON, _ := json.MarshalIndent(body, "", "  ")
@@ -273,6 +363,59 @@ func friendlyComplexity(s string) string {
 	}
 }
 
+// parseGPUFlag parses a --gpu value of the form type[:count] into the
+// accelerator object sent to the gateway. The count defaults to 1.
+func parseGPUFlag(v string) (map[string]interface{}, error) {
+	accelType, countStr, hasCount := strings.Cut(v, ":")
+	if accelType == "" {
+		return nil, fmt.Errorf("--gpu %q: accelerator type is required (e.g. nvidia-tesla-t4:2)", v)
+	}
+	count := int64(1)
+	if hasCount {
+		n, err := strconv.ParseInt(countStr, 10, 64)
+		if err != nil || n < 1 {
+			return nil, fmt.Errorf("--gpu %q: count must be a positive integer", v)
+		}
+		count = n
+	}
+	return map[string]interface{}{"type": accelType, "count": count}, nil
+}
+
+// parseVolumeFlag parses a --volume value into the volume object sent to the
+// gateway. See the submit help text for the accepted forms.
+func parseVolumeFlag(v string) (map[string]interface{}, error) {
+	parts := strings.Split(v, ":")
+	readOnly := len(parts) > 0 && parts[len(parts)-1] == "ro"
+	if readOnly {
+		parts = parts[:len(parts)-1]
+	}
+	switch {
+	case parts[0] == "gcs" && len(parts) == 3:
+		return map[string]interface{}{
+			"mountPath": parts[2],
+			"readOnly":  readOnly,
+			"gcs":       map[string]interface{}{"bucket": parts[1]},
+		}, nil
+	case parts[0] == "nfs" && len(parts) == 4:
+		return map[string]interface{}{
+			"mountPath": parts[3],
+			"readOnly":  readOnly,
+			"nfs":       map[string]interface{}{"server": parts[1], "path": parts[2]},
+		}, nil
+	case parts[0] == "ssd" && !readOnly && (len(parts) == 2 || len(parts) == 3):
+		ssd := map[string]interface{}{}
+		if len(parts) == 3 {
+			size, err := strconv.ParseInt(parts[2], 10, 64)
+			if err != nil || size < 1 {
+				return nil, fmt.Errorf("--volume %q: size must be a positive number of GB", v)
+			}
+			ssd["sizeGb"] = size
+		}
+		return map[string]interface{}{"mountPath": parts[1], "localSsd": ssd}, nil
+	}
+	return nil, fmt.Errorf("--volume %q: expected gcs:BUCKET:MOUNT_PATH[:ro], nfs:SERVER:EXPORT:MOUNT_PATH[:ro] or ssd:MOUNT_PATH[:SIZE_GB]", v)
+}
+
 // friendlyService converts proto enum string to a readable label.
 func friendlyService(s string) string {
 	switch {
@@ -297,5 +440,11 @@ func init() {
 	submitCmd.Flags().String("name", "", "Optional human-readable job name")
 	submitCmd.Flags().String("service-account", "", "Custom GCP service account email")
 	submitCmd.Flags().Bool("spot", false, "Use Spot VMs (cheaper, preemptible)")
+	submitCmd.Flags().Int32("max-preemption-resubmits", 0, "Resubmit a preempted Spot job up to N times (default from worker config)")
+	submitCmd.Flags().Int32("standard-after-preemptions", 0, "Resubmit on standard VMs after N preemptions; 0 stays on Spot (default from worker config)")
+	submitCmd.Flags().String("gpu", "", "GPU accelerator as type[:count] — routes to Cloud Batch (e.g. nvidia-tesla-t4:2)")
+	submitCmd.Flags().String("gpu-driver", "", "GPU driver version to install (default: provider's default driver)")
+	submitCmd.Flags().StringArray("volume", nil, "Mount a volume (repeatable): gcs:BUCKET:PATH[:ro], nfs:SERVER:EXPORT:PATH[:ro], ssd:PATH[:SIZE_GB]")
+	submitCmd.Flags().StringArray("label", nil, "Billing label KEY=VALUE for the job's cloud resources (repeatable), e.g. cost-center=cc-1234")
 	submitCmd.Flags().Int64("instances", 0, "Number of parallel instances (e.g. 4) — sets JENNAH_TASK_COUNT")
 }
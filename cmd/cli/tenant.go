@@ -27,10 +27,13 @@ var tenantWhoamiCmd = &cobra.Command{
 		}
 
 		var result struct {
-			TenantID      string `json:"tenantId"`
-			UserEmail     string `json:"userEmail"`
-			OAuthProvider string `json:"oauthProvider"`
-			CreatedAt     string `json:"createdAt"`
+			TenantID      string           `json:"tenantId"`
+			UserEmail     string           `json:"userEmail"`
+			OAuthProvider string           `json:"oauthProvider"`
+			CreatedAt     string           `json:"createdAt"`
+			OrgName       string           `json:"orgName"`
+			Role          string           `json:"role"`
+			Identities    []linkedIdentity `json:"identities"`
 		}
 		if err := gw.post("/jennah.v1.DeploymentService/GetCurrentTenant", map[string]interface{}{}, &result); err != nil {
 			return fmt.Errorf("failed to get tenant: %w", err)
@@ -41,6 +44,12 @@ var tenantWhoamiCmd = &cobra.Command{
 		fmt.Printf("Tenant ID: %s\n", result.TenantID)
 		fmt.Printf("Email:     %s\n", result.UserEmail)
 		fmt.Printf("Provider:  %s\n", result.OAuthProvider)
+		if result.OrgName != "" {
+			fmt.Printf("Org:       %s\n", result.OrgName)
+		}
+		if result.Role != "" {
+			fmt.Printf("Role:      %s\n", result.Role)
+		}
 
 		createdAt := result.CreatedAt
 		if t, err := time.Parse(time.RFC3339, result.CreatedAt); err == nil {
@@ -48,10 +57,122 @@ var tenantWhoamiCmd = &cobra.Command{
 			createdAt = t.In(pht).Format("2006-01-02 15:04:05")
 		}
 		fmt.Printf("Created:   %s\n", createdAt)
+		if len(result.Identities) > 0 {
+			fmt.Println()
+			printIdentities(result.Identities)
+		}
+		return nil
+	},
+}
+
+type linkedIdentity struct {
+	Provider string `json:"provider"`
+	UserID   string `json:"userId"`
+	Email    string `json:"email"`
+	Primary  bool   `json:"primary"`
+}
+
+func printIdentities(identities []linkedIdentity) {
+	fmt.Println("Linked identities")
+	for _, id := range identities {
+		primary := ""
+		if id.Primary {
+			primary = " (primary)"
+		}
+		fmt.Printf("  %-8s %-24s %s%s\n", id.Provider, id.UserID, id.Email, primary)
+	}
+}
+
+var tenantLinkCmd = &cobra.Command{
+	Use:   "link",
+	Short: "Link your Google sign-in to this tenant",
+	Long: "jennah tenant link --google-id-token <token> [--merge]\n\n" +
+		"Links a Google identity to the tenant you are logged in to, so signing in\n" +
+		"with either reaches the same jobs. You confirm your GitHub account again in\n" +
+		"the browser. If the Google identity already has its own tenant, --merge\n" +
+		"moves its finished jobs and notifications here and deletes it.",
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		idToken, _ := cmd.Flags().GetString("google-id-token")
+		merge, _ := cmd.Flags().GetBool("merge")
+		if idToken == "" {
+			return fmt.Errorf("--google-id-token is required")
+		}
+		gw, err := newGatewayClient(cmd)
+		if err != nil {
+			return err
+		}
+
+		fmt.Println("Confirm your GitHub account to link identities.")
+		fmt.Println()
+		githubToken, err := githubDeviceLogin()
+		if err != nil {
+			return err
+		}
+
+		var result struct {
+			Identities         []linkedIdentity `json:"identities"`
+			MergedTenantID     string           `json:"mergedTenantId"`
+			MovedJobs          string           `json:"movedJobs"`
+			MovedNotifications string           `json:"movedNotifications"`
+		}
+		body := map[string]interface{}{
+			"current":     map[string]string{"provider": "github", "token": githubToken},
+			"other":       map[string]string{"provider": "google", "token": idToken},
+			"mergeTenant": merge,
+		}
+		if err := gw.post("/jennah.v1.DeploymentService/LinkIdentity", body, &result); err != nil {
+			return fmt.Errorf("failed to link identity: %w", err)
+		}
+
+		fmt.Println("✅ Identity linked.")
+		if result.MergedTenantID != "" {
+			fmt.Printf("Merged tenant %s: moved %s jobs and %s notifications.\n",
+				result.MergedTenantID, orZero(result.MovedJobs), orZero(result.MovedNotifications))
+		}
+		fmt.Println()
+		printIdentities(result.Identities)
+		return nil
+	},
+}
+
+var tenantUnlinkCmd = &cobra.Command{
+	Use:   "unlink <provider> <user-id>",
+	Short: "Unlink a sign-in identity from this tenant",
+	Long: "jennah tenant unlink <provider> <user-id>\n\n" +
+		"The identity gets a new, empty tenant on its next sign-in. Jobs stay here.",
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		gw, err := newGatewayClient(cmd)
+		if err != nil {
+			return err
+		}
+		var result struct {
+			Identities []linkedIdentity `json:"identities"`
+		}
+		if err := gw.post("/jennah.v1.DeploymentService/UnlinkIdentity", map[string]string{"provider": args[0], "userId": args[1]}, &result); err != nil {
+			return fmt.Errorf("failed to unlink identity: %w", err)
+		}
+		fmt.Println("✅ Identity unlinked.")
+		fmt.Println()
+		printIdentities(result.Identities)
 		return nil
 	},
 }
 
+// orZero returns s, or "0" for an int64 field omitted from a JSON response.
+func orZero(s string) string {
+	if s == "" {
+		return "0"
+	}
+	return s
+}
+
 func init() {
 	tenantCmd.AddCommand(tenantWhoamiCmd)
+
+	tenantLinkCmd.Flags().String("google-id-token", "", "Google ID token of the identity to link")
+	tenantLinkCmd.Flags().Bool("merge", false, "Merge the Google identity's own tenant into this one")
+	tenantCmd.AddCommand(tenantLinkCmd)
+	tenantCmd.AddCommand(tenantUnlinkCmd)
 }
@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/alphauslabs/jennah/internal/database"
+	"github.com/alphauslabs/jennah/internal/userauth"
+)
+
+var (
+	migrateGitHubToken string
+	migrateDryRun      bool
+)
+
+var migrateGitHubIDsCmd = &cobra.Command{
+	Use:   "migrate-github-ids",
+	Short: "Re-key GitHub users from their login to their numeric user ID",
+	Long: `Tenants, identities and memberships of GitHub users used to be keyed by
+login, which GitHub lets users rename and others then claim. This one-off
+migration looks up the numeric ID of every login still recorded and re-keys
+its rows. Run it once, before users sign in to the gateway version that keys
+GitHub users by ID; it skips rows that already hold an ID, so it can be rerun.
+A login renamed and claimed by someone else since resolves to its new holder,
+so review the --dry-run output first.`,
+	RunE: runMigrateGitHubIDs,
+}
+
+func init() {
+	migrateGitHubIDsCmd.Flags().StringVar(&dbProjectID, "db-project-id", "labs-169405", "Database project ID (GCP project for Spanner)")
+	migrateGitHubIDsCmd.Flags().StringVar(&dbInstance, "db-instance", "alphaus-dev", "Database instance (Spanner instance name)")
+	migrateGitHubIDsCmd.Flags().StringVar(&dbDatabase, "db-database", "main", "Database name")
+	migrateGitHubIDsCmd.Flags().StringVar(&githubAPIURL, "github-api-url", userauth.DefaultGitHubAPIURL, "Base URL of the GitHub API used to look up user IDs")
+	migrateGitHubIDsCmd.Flags().StringVar(&migrateGitHubToken, "github-token", os.Getenv("GITHUB_TOKEN"), "GitHub token for the lookups; raises the API rate limit")
+	migrateGitHubIDsCmd.Flags().BoolVar(&migrateDryRun, "dry-run", false, "Only log the logins that would be re-keyed")
+}
+
+func runMigrateGitHubIDs(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+	dbClient, err := database.NewClient(ctx, dbProjectID, dbInstance, dbDatabase)
+	if err != nil {
+		return fmt.Errorf("failed to initialize database client: %w", err)
+	}
+	defer dbClient.Close()
+
+	ids, err := dbClient.ListOAuthUserIds(ctx, "github")
+	if err != nil {
+		return err
+	}
+	client := &http.Client{Timeout: 10 * time.Second}
+	var rekeyed, unknown int
+	for _, login := range ids {
+		if userauth.IsGitHubUserID(login) {
+			continue
+		}
+		id, err := userauth.LookupGitHubUserID(ctx, githubAPIURL, client, migrateGitHubToken, login)
+		if errors.Is(err, userauth.ErrGitHubUserNotFound) {
+			// The account is gone; its rows stay keyed by login, which a
+			// session can no longer carry.
+			log.Printf("GitHub user %s no longer exists; left as is", login)
+			unknown++
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to look up GitHub user %s: %w", login, err)
+		}
+		if migrateDryRun {
+			log.Printf("Would re-key GitHub user %s as %s", login, id)
+			continue
+		}
+		if err := dbClient.RekeyOAuthUser(ctx, "github", login, id); err != nil {
+			return err
+		}
+		log.Printf("Re-keyed GitHub user %s as %s", login, id)
+		rekeyed++
+	}
+	log.Printf("Re-keyed %d GitHub users; %d logins no longer exist", rekeyed, unknown)
+	return nil
+}
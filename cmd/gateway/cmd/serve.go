@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
@@ -11,13 +12,18 @@ import (
 	"syscall"
 	"time"
 
+	"connectrpc.com/connect"
 	"github.com/spf13/cobra"
 
 	"github.com/alphauslabs/jennah/cmd/gateway/middleware"
 	"github.com/alphauslabs/jennah/cmd/gateway/service"
 	jennahv1connect "github.com/alphauslabs/jennah/gen/proto/jennahv1connect"
+	"github.com/alphauslabs/jennah/internal/audit"
 	"github.com/alphauslabs/jennah/internal/database"
 	"github.com/alphauslabs/jennah/internal/hashing"
+	"github.com/alphauslabs/jennah/internal/ratelimit"
+	"github.com/alphauslabs/jennah/internal/svcauth"
+	"github.com/alphauslabs/jennah/internal/userauth"
 )
 
 var (
@@ -27,6 +33,37 @@ var (
 	dbInstance     string
 	dbDatabase     string
 	allowedOrigins string
+	adminEmails    string
+	adminToken     string
+
+	workerRefresh      time.Duration
+	workerHeartbeatTTL time.Duration
+	healthInterval     time.Duration
+	healthTimeout      time.Duration
+
+	serviceAuthSecret string
+	serviceTokenTTL   time.Duration
+	workerTLSCA       string
+	workerTLSCert     string
+	workerTLSKey      string
+
+	sessionSecret      string
+	sessionTTL         time.Duration
+	sessionMaxAge      time.Duration
+	googleClientIDs    string
+	googleJWKSURL      string
+	githubAPIURL       string
+	githubClientID     string
+	githubClientSecret string
+
+	rateLimit       string
+	rateLimitRPCs   string
+	rateLimitCaller string
+	rateLimitShared bool
+	maxRequestBytes int
+	maxEnvVars      int
+	maxCommands     int
+	trustedProxies  int
 )
 
 var serveCmd = &cobra.Command{
@@ -38,7 +75,11 @@ var serveCmd = &cobra.Command{
 
 func init() {
 	serveCmd.Flags().StringVar(&port, "port", "8080", "Port to listen on")
-	serveCmd.Flags().StringVar(&workerIPs, "worker-ips", "10.146.0.26,10.146.0.44,10.146.0.81", "Comma-separated list of worker IPs")
+	serveCmd.Flags().StringVar(&workerIPs, "worker-ips", "", "Comma-separated list of static worker IPs (default: discover workers from the Workers table)")
+	serveCmd.Flags().DurationVar(&workerRefresh, "worker-refresh", 10*time.Second, "How often the Workers table is read")
+	serveCmd.Flags().DurationVar(&healthInterval, "health-interval", 5*time.Second, "How often each worker's /health is checked")
+	serveCmd.Flags().DurationVar(&healthTimeout, "health-timeout", 2*time.Second, "Timeout of each worker health check")
+	serveCmd.Flags().DurationVar(&workerHeartbeatTTL, "worker-heartbeat-ttl", 30*time.Second, "Remove workers from the ring when their last heartbeat is older than this")
 	serveCmd.Flags().StringVar(&dbProjectID, "db-project-id", "labs-169405", "Database project ID (GCP project for Spanner)")
 	serveCmd.Flags().StringVar(&dbInstance, "db-instance", "alphaus-dev", "Database instance (Spanner instance name)")
 	serveCmd.Flags().StringVar(&dbDatabase, "db-database", "main", "Database name")
@@ -48,6 +89,29 @@ func init() {
 		defaultOrigins = "https://jennah-ui-382915581671.asia-northeast1.run.app,http://localhost:5173"
 	}
 	serveCmd.Flags().StringVar(&allowedOrigins, "allowed-origins", defaultOrigins, "Comma-separated list of allowed CORS origins")
+	serveCmd.Flags().StringVar(&adminEmails, "admin-emails", os.Getenv("ADMIN_EMAILS"), "Comma-separated list of OAuth emails allowed to call AdminService")
+	serveCmd.Flags().StringVar(&adminToken, "admin-token", os.Getenv("ADMIN_TOKEN"), "Shared secret AdminService callers must send in "+service.AdminTokenHeader+"; AdminService is disabled without it")
+	serveCmd.Flags().StringVar(&serviceAuthSecret, "service-auth-secret", os.Getenv("SERVICE_AUTH_SECRET"), "Secret shared with workers for signing service tokens")
+	serveCmd.Flags().DurationVar(&serviceTokenTTL, "service-token-ttl", 30*time.Second, "Lifetime of service tokens sent to workers")
+	serveCmd.Flags().StringVar(&workerTLSCA, "worker-tls-ca", "", "CA certificate of worker server certificates; enables HTTPS to workers")
+	serveCmd.Flags().StringVar(&workerTLSCert, "worker-tls-cert", "", "Client certificate presented to workers (mutual TLS)")
+	serveCmd.Flags().StringVar(&workerTLSKey, "worker-tls-key", "", "Key of --worker-tls-cert")
+	serveCmd.Flags().StringVar(&sessionSecret, "session-secret", os.Getenv("SESSION_SECRET"), "Secret for signing user session tokens (required)")
+	serveCmd.Flags().DurationVar(&sessionTTL, "session-ttl", time.Hour, "Lifetime of a session token")
+	serveCmd.Flags().DurationVar(&sessionMaxAge, "session-max-age", 30*24*time.Hour, "How long after sign-in a session can be refreshed")
+	serveCmd.Flags().StringVar(&googleClientIDs, "google-client-ids", os.Getenv("GOOGLE_CLIENT_IDS"), "Comma-separated OAuth client IDs accepted as Google ID token audiences; enables Google sign-in")
+	serveCmd.Flags().StringVar(&googleJWKSURL, "google-jwks-url", userauth.DefaultGoogleJWKSURL, "URL of Google's ID token signing keys")
+	serveCmd.Flags().StringVar(&githubAPIURL, "github-api-url", userauth.DefaultGitHubAPIURL, "Base URL of the GitHub API used to verify access tokens")
+	serveCmd.Flags().StringVar(&githubClientID, "github-client-id", os.Getenv("GITHUB_CLIENT_ID"), "GitHub OAuth app client ID; with --github-client-secret, enables GitHub sign-in with that app's tokens")
+	serveCmd.Flags().StringVar(&githubClientSecret, "github-client-secret", os.Getenv("GITHUB_CLIENT_SECRET"), "GitHub OAuth app client secret")
+	serveCmd.Flags().StringVar(&rateLimit, "rate-limit", envOr("RATE_LIMIT", "20:40"), "Calls per second each tenant may make, as <rate>[:<burst>]; 0 disables")
+	serveCmd.Flags().StringVar(&rateLimitRPCs, "rate-limit-rpcs", envOr("RATE_LIMIT_RPCS", "SubmitJob=2:20"), "Further per-tenant limits of single RPCs, as <method>=<rate>[:<burst>],...")
+	serveCmd.Flags().StringVar(&rateLimitCaller, "rate-limit-callers", envOr("RATE_LIMIT_CALLERS", "50:100"), "Calls per second each client address may make, valid credentials or not, as <rate>[:<burst>]; 0 disables")
+	serveCmd.Flags().BoolVar(&rateLimitShared, "rate-limit-shared", false, "Share rate limits with other gateways through the RateLimitBuckets table instead of limiting in memory")
+	serveCmd.Flags().IntVar(&maxRequestBytes, "max-request-bytes", 4<<20, "Largest request body accepted")
+	serveCmd.Flags().IntVar(&maxEnvVars, "max-env-vars", 200, "Most env vars a job or one of its runnables may set")
+	serveCmd.Flags().IntVar(&maxCommands, "max-commands", 100, "Most commands a job or one of its runnables may run")
+	serveCmd.Flags().IntVar(&trustedProxies, "trusted-proxies", 1, "X-Forwarded-For entries appended by the proxies in front of the gateway (2 behind a Google Cloud HTTPS load balancer); 0 uses the peer address")
 }
 
 func runServe(cmd *cobra.Command, args []string) error {
@@ -61,26 +125,140 @@ func runServe(cmd *cobra.Command, args []string) error {
 	defer dbClient.Close()
 	log.Printf("Connected to database: %s/%s/%s", dbProjectID, dbInstance, dbDatabase)
 
-	workers := strings.Split(workerIPs, ",")
-	for i, ip := range workers {
-		workers[i] = strings.TrimSpace(ip)
+	var workers []string
+	for _, ip := range strings.Split(workerIPs, ",") {
+		if ip = strings.TrimSpace(ip); ip != "" {
+			workers = append(workers, ip)
+		}
 	}
-	log.Printf("Worker IPs: %v", workers)
 
 	router := hashing.NewRouter(workers)
-	log.Printf("Initialized consistent hashing router with workers: %v", workers)
 
 	workerClients := make(map[string]jennahv1connect.DeploymentServiceClient)
+	adminClients := make(map[string]jennahv1connect.AdminServiceClient)
+	var clientOpts []connect.ClientOption
+	if serviceAuthSecret != "" {
+		signer, err := svcauth.NewSigner(serviceAuthSecret, "gateway", serviceTokenTTL)
+		if err != nil {
+			return fmt.Errorf("invalid service auth settings: %w", err)
+		}
+		clientOpts = append(clientOpts, connect.WithInterceptors(svcauth.NewClientInterceptor(signer)))
+		log.Printf("Signing worker calls with service tokens (TTL %s)", serviceTokenTTL)
+	} else {
+		log.Println("WARNING: no --service-auth-secret; workers only accept these calls with WORKER_ALLOW_UNAUTHENTICATED=true")
+	}
+
+	scheme := "http"
+	var transport http.RoundTripper
+	if workerTLSCA != "" || workerTLSCert != "" {
+		tlsConfig, err := svcauth.ClientTLSConfig(workerTLSCA, workerTLSCert, workerTLSKey)
+		if err != nil {
+			return err
+		}
+		scheme, transport = "https", &http.Transport{TLSClientConfig: tlsConfig}
+		log.Printf("Calling workers over HTTPS (client certificate: %v)", workerTLSCert != "")
+	}
+	workerURL := func(member string) string {
+		return workerBaseURL(scheme, member)
+	}
+
 	httpClient := &http.Client{
-		Timeout: 30 * time.Second,
+		Timeout:   30 * time.Second,
+		Transport: transport,
+	}
+	// Reconciliation lists every job resource, so it gets a longer timeout.
+	adminHTTPClient := &http.Client{
+		Timeout:   5 * time.Minute,
+		Transport: transport,
+	}
+	dial := func(member string) (jennahv1connect.DeploymentServiceClient, jennahv1connect.AdminServiceClient) {
+		url := workerURL(member)
+		log.Printf("Created client for worker at %s", url)
+		return jennahv1connect.NewDeploymentServiceClient(httpClient, url, clientOpts...),
+			jennahv1connect.NewAdminServiceClient(adminHTTPClient, url, clientOpts...)
 	}
 	for _, workerIP := range workers {
-		workerURL := fmt.Sprintf("http://%s:8081", workerIP)
-		workerClients[workerIP] = jennahv1connect.NewDeploymentServiceClient(httpClient, workerURL)
-		log.Printf("Created client for worker at %s", workerURL)
+		workerClients[workerIP], adminClients[workerIP] = dial(workerIP)
 	}
 
-	gatewayService := service.NewGatewayService(router, workerClients, dbClient)
+	var admins []string
+	for _, email := range strings.Split(adminEmails, ",") {
+		if email = strings.TrimSpace(email); email != "" {
+			admins = append(admins, email)
+		}
+	}
+	log.Printf("Admins: %v", admins)
+	if adminToken == "" {
+		log.Printf("Warning: --admin-token is not set; AdminService calls will be rejected")
+	}
+
+	sessions, err := userauth.NewSessions(sessionSecret, sessionTTL, sessionMaxAge)
+	if err != nil {
+		return fmt.Errorf("invalid session settings: %w", err)
+	}
+	verifierClient := &http.Client{Timeout: 10 * time.Second}
+	identityVerifiers := make(map[string]userauth.IdentityVerifier)
+	// Without the OAuth app, tokens issued to any app could be replayed here,
+	// so GitHub sign-in is only enabled with both the client ID and secret.
+	if githubClientID != "" || githubClientSecret != "" {
+		githubVerifier, err := userauth.NewGitHubVerifier(userauth.GitHubOptions{
+			APIURL:       githubAPIURL,
+			ClientID:     githubClientID,
+			ClientSecret: githubClientSecret,
+			HTTPClient:   verifierClient,
+		})
+		if err != nil {
+			return fmt.Errorf("invalid GitHub sign-in settings: %w", err)
+		}
+		identityVerifiers["github"] = githubVerifier
+	} else {
+		log.Println("WARNING: no --github-client-id and --github-client-secret; GitHub sign-in is disabled")
+	}
+	var googleAudiences []string
+	for _, id := range strings.Split(googleClientIDs, ",") {
+		if id = strings.TrimSpace(id); id != "" {
+			googleAudiences = append(googleAudiences, id)
+		}
+	}
+	if len(googleAudiences) > 0 {
+		googleVerifier, err := userauth.NewGoogleVerifier(userauth.GoogleOptions{
+			ClientIDs:  googleAudiences,
+			JWKSURL:    googleJWKSURL,
+			HTTPClient: verifierClient,
+		})
+		if err != nil {
+			return fmt.Errorf("invalid Google sign-in settings: %w", err)
+		}
+		identityVerifiers["google"] = googleVerifier
+	}
+	log.Printf("Sign-in providers: github=%v, google=%v (session TTL %s, max age %s)", identityVerifiers["github"] != nil, len(googleAudiences) > 0, sessionTTL, sessionMaxAge)
+
+	gatewayService := service.NewGatewayService(router, workerClients, adminClients, admins, dbClient, sessions, identityVerifiers)
+
+	sigCtx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if len(workers) > 0 {
+		log.Printf("Initialized consistent hashing router with static workers: %v", workers)
+	} else {
+		err := gatewayService.WatchWorkers(sigCtx, service.WorkerWatchOptions{
+			Interval:     workerRefresh,
+			HeartbeatTTL: workerHeartbeatTTL,
+			Dial:         dial,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to read worker registry: %w", err)
+		}
+		log.Printf("Discovering workers from the Workers table every %s (heartbeat TTL %s)", workerRefresh, workerHeartbeatTTL)
+	}
+
+	gatewayService.StartHealthChecks(sigCtx, service.HealthCheckOptions{
+		Interval:  healthInterval,
+		Timeout:   healthTimeout,
+		URL:       workerURL,
+		Transport: transport,
+	})
+	log.Printf("Checking worker health every %s (timeout %s)", healthInterval, healthTimeout)
 
 	origins := strings.Split(allowedOrigins, ",")
 	for i, origin := range origins {
@@ -89,18 +267,65 @@ func runServe(cmd *cobra.Command, args []string) error {
 	log.Printf("CORS allowed origins: %v", origins)
 	corsMiddleware := middleware.CORSMiddleware(origins)
 
+	tenantLimit, err := ratelimit.ParseLimit(rateLimit)
+	if err != nil {
+		return fmt.Errorf("invalid --rate-limit: %w", err)
+	}
+	rpcLimits, err := ratelimit.ParseRPCLimits(rateLimitRPCs)
+	if err != nil {
+		return fmt.Errorf("invalid --rate-limit-rpcs: %w", err)
+	}
+	callerLimit, err := ratelimit.ParseLimit(rateLimitCaller)
+	if err != nil {
+		return fmt.Errorf("invalid --rate-limit-callers: %w", err)
+	}
+	var limitStore ratelimit.Store = ratelimit.NewMemoryStore()
+	if rateLimitShared {
+		limitStore = ratelimit.NewSharedStore(dbClient)
+	}
+	limiter := ratelimit.NewLimiter(limitStore, tenantLimit, callerLimit, rpcLimits)
+	log.Printf("Rate limits per client: %s, per tenant: %s, per RPC: %v (shared: %v); max request %d bytes, %d env vars, %d commands",
+		callerLimit, tenantLimit, rpcLimits, rateLimitShared, maxRequestBytes, maxEnvVars, maxCommands)
+
+	// Requests over the limits are rejected first. Calls to mutating RPCs
+	// are then recorded in the AuditEvents table.
+	handlerOpts := []connect.HandlerOption{
+		connect.WithReadMaxBytes(maxRequestBytes),
+		connect.WithInterceptors(
+			ratelimit.NewInterceptor(limiter, ratelimit.RequestLimits{MaxEnvVars: maxEnvVars, MaxCommands: maxCommands}, trustedProxies),
+			audit.NewInterceptor(dbClient, gatewayService.AuditActor, trustedProxies),
+		),
+	}
+
 	mux := http.NewServeMux()
-	path, handler := jennahv1connect.NewDeploymentServiceHandler(gatewayService)
+	path, handler := jennahv1connect.NewDeploymentServiceHandler(gatewayService, handlerOpts...)
 
 	mux.Handle(path, corsMiddleware(handler))
 	log.Printf("Registered DeploymentService handler at path: %s (with CORS)", path)
 
+	// Audit AdminService calls even when the admin token is wrong.
+	adminPath, adminHandler := jennahv1connect.NewAdminServiceHandler(gatewayService,
+		append(handlerOpts, connect.WithInterceptors(service.NewAdminTokenInterceptor(adminToken)))...)
+	mux.Handle(adminPath, corsMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Admin operations can outlast the server's WriteTimeout.
+		http.NewResponseController(w).SetWriteDeadline(time.Now().Add(5 * time.Minute))
+		adminHandler.ServeHTTP(w, r)
+	})))
+	log.Printf("Registered AdminService handler at path: %s (with CORS)", adminPath)
+
+	authPath, authHandler := jennahv1connect.NewAuthServiceHandler(gatewayService, handlerOpts...)
+	mux.Handle(authPath, corsMiddleware(authHandler))
+	log.Printf("Registered AuthService handler at path: %s (with CORS)", authPath)
+
 	mux.Handle("/health", corsMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusOK)
 		w.Write([]byte("OK"))
 	})))
 	log.Println("Health check endpoint: /health (with CORS)")
 
+	mux.Handle("/status", corsMiddleware(http.HandlerFunc(gatewayService.ServeStatus)))
+	log.Println("Worker status endpoint: /status (with CORS)")
+
 	addr := fmt.Sprintf("0.0.0.0:%s", port)
 	server := &http.Server{
 		Addr:         addr,
@@ -110,9 +335,6 @@ func runServe(cmd *cobra.Command, args []string) error {
 		IdleTimeout:  60 * time.Second,
 	}
 
-	sigCtx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
-	defer stop()
-
 	go func() {
 		log.Printf("Gateway listening on %s", addr)
 		log.Println("Available endpoints:")
@@ -121,7 +343,12 @@ func runServe(cmd *cobra.Command, args []string) error {
 		log.Printf("  • POST %sGetCurrentTenant", path)
 		log.Printf("  • POST %sCancelJob", path)
 		log.Printf("  • POST %sDeleteJob", path)
+		log.Printf("  • POST %sReconcileResources", adminPath)
+		log.Printf("  • POST %sSimulateRouting", adminPath)
+		log.Printf("  • POST %sCreateSession", authPath)
+		log.Printf("  • POST %sRefreshSession", authPath)
 		log.Printf("  • GET  /health")
+		log.Printf("  • GET  /status")
 		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
 			log.Fatalf("Failed to start server: %v", err)
 		}
@@ -140,3 +367,20 @@ func runServe(cmd *cobra.Command, args []string) error {
 	log.Println("Gateway stopped")
 	return nil
 }
+
+// workerBaseURL returns the URL of a ring member: registered workers are
+// host:port, static --worker-ips entries use the default worker port.
+func workerBaseURL(scheme, member string) string {
+	if _, _, err := net.SplitHostPort(member); err != nil {
+		return fmt.Sprintf("%s://%s:8081", scheme, member)
+	}
+	return scheme + "://" + member
+}
+
+// envOr returns the environment variable key, or def when it is unset.
+func envOr(key, def string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return def
+}
@@ -24,8 +24,8 @@ func CORSMiddleware(allowedOrigins []string) func(http.Handler) http.Handler {
 				w.Header().Set("Access-Control-Allow-Origin", origin)
 				w.Header().Set("Access-Control-Allow-Credentials", "true")
 				w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-				w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-OAuth-Email, X-OAuth-UserId, X-OAuth-Provider, Connect-Protocol-Version, Connect-Timeout-Ms")
-				w.Header().Set("Access-Control-Expose-Headers", "Content-Type, Connect-Protocol-Version")
+				w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-Jennah-Org, Connect-Protocol-Version, Connect-Timeout-Ms")
+				w.Header().Set("Access-Control-Expose-Headers", "Content-Type, Connect-Protocol-Version, Retry-After")
 				w.Header().Set("Access-Control-Max-Age", "3600")
 			}
 
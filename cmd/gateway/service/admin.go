@@ -0,0 +1,144 @@
+package service
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	"connectrpc.com/connect"
+
+	jennahv1 "github.com/alphauslabs/jennah/gen/proto"
+	jennahv1connect "github.com/alphauslabs/jennah/gen/proto/jennahv1connect"
+)
+
+// adminRoutingKey picks the worker that runs on-demand admin operations.
+const adminRoutingKey = "admin/reconcile"
+
+// AdminTokenHeader carries the shared secret that AdminService callers must
+// present in addition to being on the admin allowlist.
+const AdminTokenHeader = "X-Admin-Token"
+
+// NewAdminTokenInterceptor rejects AdminService calls that do not carry
+// token in AdminTokenHeader, so that a leaked admin session alone cannot run
+// destructive operations. With no token configured every call is rejected.
+func NewAdminTokenInterceptor(token string) connect.UnaryInterceptorFunc {
+	return func(next connect.UnaryFunc) connect.UnaryFunc {
+		return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+			if req.Spec().IsClient {
+				return next(ctx, req)
+			}
+			if token == "" {
+				return nil, connect.NewError(connect.CodePermissionDenied, errors.New("admin operations are disabled: no admin token configured"))
+			}
+			got := req.Header().Get(AdminTokenHeader)
+			if subtle.ConstantTimeCompare([]byte(got), []byte(token)) != 1 {
+				log.Printf("Admin request for %s rejected: missing or invalid admin token", req.Spec().Procedure)
+				return nil, connect.NewError(connect.CodeUnauthenticated, errors.New("missing or invalid admin token"))
+			}
+			return next(ctx, req)
+		}
+	}
+}
+
+// requireAdmin checks that the session user is on the admin allowlist.
+func (s *GatewayService) requireAdmin(header http.Header) (*OAuthUser, error) {
+	oauthUser, err := s.authenticate(header)
+	if err != nil {
+		log.Printf("Authentication failed: %v", err)
+		return nil, connect.NewError(connect.CodeUnauthenticated, fmt.Errorf("invalid session: %w", err))
+	}
+	if !s.adminEmails[strings.ToLower(oauthUser.Email)] {
+		log.Printf("Admin request denied for %s", oauthUser.Email)
+		return nil, connect.NewError(connect.CodePermissionDenied, errors.New("admin access required"))
+	}
+	return oauthUser, nil
+}
+
+// withAdminClient runs call on the workers that run admin operations, in
+// failover order, moving on to the next one while a worker cannot be
+// reached. It returns the worker that answered.
+func (s *GatewayService) withAdminClient(ctx context.Context, call func(jennahv1connect.AdminServiceClient) error) (string, error) {
+	order := s.failoverOrder(adminRoutingKey)
+	if len(order) == 0 {
+		log.Printf("No worker found for routingKey: %s", adminRoutingKey)
+		return "", connect.NewError(connect.CodeInternal, errors.New("no worker found for routing key"))
+	}
+
+	var err error
+	for _, workerIP := range order {
+		s.clientsMu.RLock()
+		adminClient, exists := s.adminClients[workerIP]
+		s.clientsMu.RUnlock()
+		if !exists {
+			log.Printf("No admin client found for IP: %s", workerIP)
+			err = connect.NewError(connect.CodeInternal, fmt.Errorf("no worker client found for IP: %s", workerIP))
+			continue
+		}
+		err = call(adminClient)
+		if err == nil {
+			return workerIP, nil
+		}
+		if !isConnectionError(err) || ctx.Err() != nil {
+			return workerIP, err
+		}
+		log.Printf("ERROR: Worker %s unreachable for admin call: %v", workerIP, err)
+		s.noteWorkerError(workerIP, err)
+	}
+	return "", err
+}
+
+func (s *GatewayService) ReconcileResources(
+	ctx context.Context,
+	req *connect.Request[jennahv1.ReconcileResourcesRequest],
+) (*connect.Response[jennahv1.ReconcileResourcesResponse], error) {
+	log.Printf("Received reconcile resources request")
+
+	oauthUser, err := s.requireAdmin(req.Header())
+	if err != nil {
+		return nil, err
+	}
+
+	var response *connect.Response[jennahv1.ReconcileResourcesResponse]
+	workerIP, err := s.withAdminClient(ctx, func(adminClient jennahv1connect.AdminServiceClient) error {
+		response, err = adminClient.ReconcileResources(ctx, connect.NewRequest(req.Msg))
+		return err
+	})
+	if err != nil {
+		log.Printf("ERROR: Worker %s ReconcileResources failed: %v", workerIP, err)
+		return nil, connect.NewError(connect.CodeOf(err), fmt.Errorf("worker failed: %w", err))
+	}
+
+	log.Printf("Reconcile run by %s on worker %s (dry_run=%v): %d orphan(s), %d missing",
+		oauthUser.Email, workerIP, req.Msg.DryRun, len(response.Msg.Orphans), len(response.Msg.Missing))
+	return response, nil
+}
+
+func (s *GatewayService) SimulateRouting(
+	ctx context.Context,
+	req *connect.Request[jennahv1.SimulateRoutingRequest],
+) (*connect.Response[jennahv1.SimulateRoutingResponse], error) {
+	log.Printf("Received simulate routing request")
+
+	oauthUser, err := s.requireAdmin(req.Header())
+	if err != nil {
+		return nil, err
+	}
+
+	var response *connect.Response[jennahv1.SimulateRoutingResponse]
+	workerIP, err := s.withAdminClient(ctx, func(adminClient jennahv1connect.AdminServiceClient) error {
+		response, err = adminClient.SimulateRouting(ctx, connect.NewRequest(req.Msg))
+		return err
+	})
+	if err != nil {
+		log.Printf("ERROR: Worker %s SimulateRouting failed: %v", workerIP, err)
+		return nil, connect.NewError(connect.CodeOf(err), fmt.Errorf("worker failed: %w", err))
+	}
+
+	log.Printf("Routing simulation run by %s on worker %s: %d job(s) replayed, %d would change service",
+		oauthUser.Email, workerIP, response.Msg.JobsReplayed, response.Msg.JobsChanged)
+	return response, nil
+}
@@ -0,0 +1,218 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"time"
+
+	"connectrpc.com/connect"
+
+	jennahv1 "github.com/alphauslabs/jennah/gen/proto"
+	"github.com/alphauslabs/jennah/internal/database"
+	"github.com/alphauslabs/jennah/internal/userauth"
+)
+
+// apiKeyStore looks up and touches API keys; *database.Client implements it.
+type apiKeyStore interface {
+	GetApiKey(ctx context.Context, keyID string) (*database.ApiKey, error)
+	TouchApiKey(ctx context.Context, tenantID, keyID string, usedAt time.Time) error
+}
+
+// apiKeyTouchInterval is how stale an API key's LastUsedAt may get before a
+// request updates it, which keeps busy keys from writing on every call.
+const apiKeyTouchInterval = time.Minute
+
+// Errors returned when an API key is rejected.
+var (
+	errInvalidApiKey = errors.New("API key is invalid")
+	errRevokedApiKey = errors.New("API key has been revoked")
+	errExpiredApiKey = errors.New("API key has expired")
+)
+
+// resolveApiKey authenticates a request carrying an API key and returns the
+// key's tenant.
+func (s *GatewayService) resolveApiKey(ctx context.Context, key, scope string) (string, error) {
+	keyID, secret, ok := userauth.ParseAPIKey(key)
+	if !ok {
+		return "", connect.NewError(connect.CodeUnauthenticated, errInvalidApiKey)
+	}
+	apiKey, err := s.apiKeys.GetApiKey(ctx, keyID)
+	if err != nil {
+		log.Printf("Failed to look up API key %s: %v", keyID, err)
+		return "", connect.NewError(connect.CodeInternal, err)
+	}
+
+	now := time.Now().UTC()
+	if err := checkApiKey(apiKey, secret, now); err != nil {
+		log.Printf("API key %s rejected: %v", keyID, err)
+		return "", connect.NewError(connect.CodeUnauthenticated, err)
+	}
+	if !slices.Contains(apiKey.Scopes, scope) {
+		return "", connect.NewError(connect.CodePermissionDenied, fmt.Errorf("API key %s lacks the %q scope", keyID, scope))
+	}
+
+	if apiKey.LastUsedAt == nil || now.Sub(*apiKey.LastUsedAt) > apiKeyTouchInterval {
+		go func() {
+			if err := s.apiKeys.TouchApiKey(context.Background(), apiKey.TenantId, keyID, now); err != nil {
+				log.Printf("Warning: %v", err)
+			}
+		}()
+	}
+	return apiKey.TenantId, nil
+}
+
+// checkApiKey reports why a stored key does not accept secret at now, or
+// nil when it does. A nil key is rejected as invalid.
+func checkApiKey(apiKey *database.ApiKey, secret string, now time.Time) error {
+	if apiKey == nil || !userauth.CheckAPIKeySecret(apiKey.Salt, apiKey.SecretHash, secret) {
+		return errInvalidApiKey
+	}
+	if apiKey.RevokedAt != nil {
+		return errRevokedApiKey
+	}
+	if apiKey.ExpiresAt != nil && !now.Before(*apiKey.ExpiresAt) {
+		return errExpiredApiKey
+	}
+	return nil
+}
+
+// apiKeyScopes validates the scopes requested for a new key. No scopes
+// means all of them.
+func apiKeyScopes(requested []string) ([]string, error) {
+	if len(requested) == 0 {
+		return slices.Clone(userauth.AllScopes), nil
+	}
+	var scopes []string
+	for _, scope := range requested {
+		if !userauth.ValidScope(scope) {
+			return nil, fmt.Errorf("unknown scope %q, must be one of %v", scope, userauth.AllScopes)
+		}
+		if !slices.Contains(scopes, scope) {
+			scopes = append(scopes, scope)
+		}
+	}
+	return scopes, nil
+}
+
+// CreateApiKey issues a new API key for the active organization. The key is
+// returned only in this response. Managing keys needs an admin's session, so
+// a leaked key cannot mint others.
+func (s *GatewayService) CreateApiKey(
+	ctx context.Context,
+	req *connect.Request[jennahv1.CreateApiKeyRequest],
+) (*connect.Response[jennahv1.CreateApiKeyResponse], error) {
+	c, err := s.requireOrgAdmin(ctx, req.Header())
+	if err != nil {
+		return nil, err
+	}
+	if req.Msg.Name == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("name is required"))
+	}
+	if req.Msg.ExpiresInSeconds < 0 {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("expires_in_seconds must not be negative"))
+	}
+	scopes, err := apiKeyScopes(req.Msg.Scopes)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	generated, err := userauth.GenerateAPIKey()
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	apiKey := &database.ApiKey{
+		TenantId:   c.tenantId,
+		KeyId:      generated.KeyID,
+		Name:       req.Msg.Name,
+		SecretHash: generated.Hash,
+		Salt:       generated.Salt,
+		Scopes:     scopes,
+		CreatedBy:  c.user.Email,
+		CreatedAt:  time.Now().UTC(),
+	}
+	if req.Msg.ExpiresInSeconds > 0 {
+		expires := apiKey.CreatedAt.Add(time.Duration(req.Msg.ExpiresInSeconds) * time.Second)
+		apiKey.ExpiresAt = &expires
+	}
+	if err := s.dbClient.InsertApiKey(ctx, apiKey); err != nil {
+		log.Printf("Failed to create API key: %v", err)
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	log.Printf("Created API key %s (%s) for tenant %s with scopes %v", apiKey.KeyId, apiKey.Name, c.tenantId, scopes)
+	return connect.NewResponse(&jennahv1.CreateApiKeyResponse{
+		ApiKey: dbApiKeyToProto(apiKey),
+		Key:    generated.Key,
+	}), nil
+}
+
+// ListApiKeys lists the API keys of the caller's tenant, without their
+// secrets.
+func (s *GatewayService) ListApiKeys(
+	ctx context.Context,
+	req *connect.Request[jennahv1.ListApiKeysRequest],
+) (*connect.Response[jennahv1.ListApiKeysResponse], error) {
+	c, err := s.requireOrgAdmin(ctx, req.Header())
+	if err != nil {
+		return nil, err
+	}
+	keys, err := s.dbClient.ListApiKeys(ctx, c.tenantId)
+	if err != nil {
+		log.Printf("Failed to list API keys: %v", err)
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	response := &jennahv1.ListApiKeysResponse{}
+	for _, k := range keys {
+		response.ApiKeys = append(response.ApiKeys, dbApiKeyToProto(k))
+	}
+	return connect.NewResponse(response), nil
+}
+
+// RevokeApiKey revokes one of the caller's API keys. Revoked keys are kept
+// so they still show up in ListApiKeys.
+func (s *GatewayService) RevokeApiKey(
+	ctx context.Context,
+	req *connect.Request[jennahv1.RevokeApiKeyRequest],
+) (*connect.Response[jennahv1.RevokeApiKeyResponse], error) {
+	c, err := s.requireOrgAdmin(ctx, req.Header())
+	if err != nil {
+		return nil, err
+	}
+	if req.Msg.KeyId == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("key_id is required"))
+	}
+	apiKey, err := s.dbClient.RevokeApiKey(ctx, c.tenantId, req.Msg.KeyId)
+	if err != nil {
+		log.Printf("Failed to revoke API key: %v", err)
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	if apiKey == nil {
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("API key %s not found", req.Msg.KeyId))
+	}
+
+	log.Printf("Revoked API key %s for tenant %s", apiKey.KeyId, c.tenantId)
+	return connect.NewResponse(&jennahv1.RevokeApiKeyResponse{ApiKey: dbApiKeyToProto(apiKey)}), nil
+}
+
+func dbApiKeyToProto(k *database.ApiKey) *jennahv1.ApiKey {
+	p := &jennahv1.ApiKey{
+		KeyId:     k.KeyId,
+		Name:      k.Name,
+		Scopes:    k.Scopes,
+		CreatedBy: k.CreatedBy,
+		CreatedAt: k.CreatedAt.Format(time.RFC3339),
+	}
+	if k.ExpiresAt != nil {
+		p.ExpiresAt = k.ExpiresAt.Format(time.RFC3339)
+	}
+	if k.LastUsedAt != nil {
+		p.LastUsedAt = k.LastUsedAt.Format(time.RFC3339)
+	}
+	if k.RevokedAt != nil {
+		p.RevokedAt = k.RevokedAt.Format(time.RFC3339)
+	}
+	return p
+}
@@ -0,0 +1,101 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+
+	jennahv1 "github.com/alphauslabs/jennah/gen/proto"
+	"github.com/alphauslabs/jennah/internal/database"
+	"github.com/alphauslabs/jennah/internal/hashing"
+	"github.com/alphauslabs/jennah/internal/userauth"
+)
+
+// fakeApiKeys holds API keys by key ID.
+type fakeApiKeys map[string]*database.ApiKey
+
+func (f fakeApiKeys) GetApiKey(ctx context.Context, keyID string) (*database.ApiKey, error) {
+	return f[keyID], nil
+}
+
+func (f fakeApiKeys) TouchApiKey(ctx context.Context, tenantID, keyID string, usedAt time.Time) error {
+	return nil
+}
+
+func TestCheckApiKey(t *testing.T) {
+	generated, err := userauth.GenerateAPIKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, secret, _ := userauth.ParseAPIKey(generated.Key)
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	past, future := now.Add(-time.Second), now.Add(time.Hour)
+	stored := func(expires, revoked *time.Time) *database.ApiKey {
+		return &database.ApiKey{Salt: generated.Salt, SecretHash: generated.Hash, ExpiresAt: expires, RevokedAt: revoked}
+	}
+
+	tests := []struct {
+		name   string
+		key    *database.ApiKey
+		secret string
+		want   error
+	}{
+		{"valid", stored(nil, nil), secret, nil},
+		{"valid until expiry", stored(&future, nil), secret, nil},
+		{"unknown key", nil, secret, errInvalidApiKey},
+		{"wrong secret", stored(nil, nil), secret + "x", errInvalidApiKey},
+		{"revoked", stored(nil, &past), secret, errRevokedApiKey},
+		{"expired", stored(&past, nil), secret, errExpiredApiKey},
+		{"expires now", stored(&now, nil), secret, errExpiredApiKey},
+	}
+	for _, tt := range tests {
+		if err := checkApiKey(tt.key, tt.secret, now); err != tt.want {
+			t.Errorf("%s: checkApiKey = %v, want %v", tt.name, err, tt.want)
+		}
+	}
+}
+
+func TestApiKeyScopes(t *testing.T) {
+	if scopes, err := apiKeyScopes(nil); err != nil || !slices.Equal(scopes, userauth.AllScopes) {
+		t.Errorf("apiKeyScopes(nil) = %v, %v; want all scopes", scopes, err)
+	}
+	if scopes, err := apiKeyScopes([]string{"read", "submit", "read"}); err != nil || !slices.Equal(scopes, []string{"read", "submit"}) {
+		t.Errorf("apiKeyScopes = %v, %v; want [read submit]", scopes, err)
+	}
+	if _, err := apiKeyScopes([]string{"read", "admin"}); err == nil {
+		t.Error("apiKeyScopes accepted an unknown scope")
+	}
+}
+
+func TestAckNotification_RejectsReadOnlyKey(t *testing.T) {
+	generated, err := userauth.GenerateAPIKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyID, _, _ := userauth.ParseAPIKey(generated.Key)
+	s := NewGatewayService(hashing.NewRouter(nil), nil, nil, nil, nil, nil, nil)
+	s.apiKeys = fakeApiKeys{keyID: {
+		TenantId:   "tenant-a",
+		KeyId:      keyID,
+		Salt:       generated.Salt,
+		SecretHash: generated.Hash,
+		Scopes:     []string{userauth.ScopeRead},
+	}}
+
+	req := connect.NewRequest(&jennahv1.AckNotificationRequest{NotificationId: "n-1"})
+	req.Header().Set("Authorization", "Bearer "+generated.Key)
+	if _, err := s.AckNotification(context.Background(), req); connect.CodeOf(err) != connect.CodePermissionDenied {
+		t.Errorf("AckNotification with a read-only key: err = %v, want PermissionDenied", err)
+	}
+
+	// The same key may still list notifications.
+	header := http.Header{}
+	header.Set("Authorization", "Bearer "+generated.Key)
+	if tenantID, err := s.resolveTenant(context.Background(), header, userauth.ScopeRead); err != nil || tenantID != "tenant-a" {
+		t.Errorf("resolveTenant(read) = %q, %v; want tenant-a", tenantID, err)
+	}
+}
@@ -0,0 +1,178 @@
+package service
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"connectrpc.com/connect"
+
+	jennahv1 "github.com/alphauslabs/jennah/gen/proto"
+	"github.com/alphauslabs/jennah/internal/audit"
+	"github.com/alphauslabs/jennah/internal/database"
+	"github.com/alphauslabs/jennah/internal/userauth"
+)
+
+// Page sizes of ListAuditEvents.
+const (
+	defaultAuditPageSize = 100
+	maxAuditPageSize     = 1000
+)
+
+// AuditActor identifies the caller of a request for the audit log from its
+// session or API key, without checking the key against the database.
+func (s *GatewayService) AuditActor(header http.Header) audit.Actor {
+	token := userauth.BearerToken(header)
+	if strings.HasPrefix(token, userauth.APIKeyPrefix) {
+		if keyID, _, ok := userauth.ParseAPIKey(token); ok {
+			return audit.Actor{Type: audit.ActorAPIKey, ID: keyID}
+		}
+		return audit.Actor{Type: audit.ActorAnonymous}
+	}
+	if s.sessions == nil {
+		return audit.Actor{Type: audit.ActorAnonymous}
+	}
+	identity, err := s.sessions.Verify(token)
+	if err != nil {
+		return audit.Actor{Type: audit.ActorAnonymous}
+	}
+	return identityActor(identity)
+}
+
+func identityActor(identity *userauth.Identity) audit.Actor {
+	return audit.Actor{
+		Type:  audit.ActorUser,
+		ID:    identity.Provider + "/" + identity.UserID,
+		Email: identity.Email,
+	}
+}
+
+// ListAuditEvents lists the audit log of the active organization, newest
+// first.
+func (s *GatewayService) ListAuditEvents(
+	ctx context.Context,
+	req *connect.Request[jennahv1.ListAuditEventsRequest],
+) (*connect.Response[jennahv1.ListAuditEventsResponse], error) {
+	c, err := s.requireOrgAdmin(ctx, req.Header())
+	if err != nil {
+		return nil, err
+	}
+	filter, pageSize, err := auditFilter(req.Msg)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	// One extra event tells whether there is another page.
+	events, err := s.dbClient.ListAuditEvents(ctx, c.tenantId, filter, pageSize+1)
+	if err != nil {
+		log.Printf("Failed to list audit events: %v", err)
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	response := &jennahv1.ListAuditEventsResponse{}
+	if len(events) > pageSize {
+		events = events[:pageSize]
+		last := events[len(events)-1]
+		response.NextPageToken = encodeAuditCursor(&database.AuditCursor{OccurredAt: last.OccurredAt, EventId: last.EventId})
+	}
+	for _, e := range events {
+		response.Events = append(response.Events, dbAuditEventToProto(e))
+	}
+	return connect.NewResponse(response), nil
+}
+
+// auditFilter validates a ListAuditEvents request.
+func auditFilter(req *jennahv1.ListAuditEventsRequest) (database.AuditFilter, int, error) {
+	filter := database.AuditFilter{
+		Procedure: strings.TrimSpace(req.Procedure),
+		ActorId:   strings.TrimSpace(req.ActorId),
+		TargetId:  strings.TrimSpace(req.TargetId),
+	}
+	for _, bound := range []struct {
+		name  string
+		value string
+		dst   *time.Time
+	}{
+		{"since", req.Since, &filter.Since},
+		{"until", req.Until, &filter.Until},
+	} {
+		if bound.value == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, bound.value)
+		if err != nil {
+			return filter, 0, fmt.Errorf("%s must be an RFC 3339 time: %w", bound.name, err)
+		}
+		*bound.dst = t
+	}
+	if req.PageToken != "" {
+		cursor, err := decodeAuditCursor(req.PageToken)
+		if err != nil {
+			return filter, 0, err
+		}
+		filter.After = cursor
+	}
+
+	pageSize := int(req.PageSize)
+	switch {
+	case pageSize < 0:
+		return filter, 0, errors.New("page_size must not be negative")
+	case pageSize == 0:
+		pageSize = defaultAuditPageSize
+	case pageSize > maxAuditPageSize:
+		pageSize = maxAuditPageSize
+	}
+	return filter, pageSize, nil
+}
+
+// encodeAuditCursor turns the last event of a page into a page token.
+func encodeAuditCursor(cursor *database.AuditCursor) string {
+	raw := cursor.OccurredAt.UTC().Format(time.RFC3339Nano) + "|" + cursor.EventId
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func decodeAuditCursor(token string) (*database.AuditCursor, error) {
+	errInvalid := errors.New("invalid page_token")
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, errInvalid
+	}
+	occurredAt, eventId, ok := strings.Cut(string(raw), "|")
+	if !ok || eventId == "" {
+		return nil, errInvalid
+	}
+	t, err := time.Parse(time.RFC3339Nano, occurredAt)
+	if err != nil {
+		return nil, errInvalid
+	}
+	return &database.AuditCursor{OccurredAt: t, EventId: eventId}, nil
+}
+
+func dbAuditEventToProto(e *database.AuditEvent) *jennahv1.AuditEvent {
+	p := &jennahv1.AuditEvent{
+		EventId:     e.EventId,
+		OccurredAt:  e.OccurredAt.UTC().Format(time.RFC3339Nano),
+		Procedure:   e.Procedure,
+		ActorType:   e.ActorType,
+		ActorId:     e.ActorId,
+		ActorEmail:  e.ActorEmail,
+		RequestJson: e.RequestJson,
+		ResultCode:  e.ResultCode,
+		ClientIp:    e.ClientIp,
+		UserAgent:   e.UserAgent,
+	}
+	if e.TargetType != nil {
+		p.TargetType = *e.TargetType
+	}
+	if e.TargetId != nil {
+		p.TargetId = *e.TargetId
+	}
+	if e.ErrorMessage != nil {
+		p.ErrorMessage = *e.ErrorMessage
+	}
+	return p
+}
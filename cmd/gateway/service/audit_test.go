@@ -0,0 +1,76 @@
+package service
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	jennahv1 "github.com/alphauslabs/jennah/gen/proto"
+	"github.com/alphauslabs/jennah/internal/audit"
+	"github.com/alphauslabs/jennah/internal/database"
+	"github.com/alphauslabs/jennah/internal/hashing"
+	"github.com/alphauslabs/jennah/internal/userauth"
+)
+
+func TestAuditActor(t *testing.T) {
+	sessions, err := userauth.NewSessions("0123456789abcdef0123456789abcdef", time.Hour, 24*time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := NewGatewayService(hashing.NewRouter(nil), nil, nil, nil, nil, sessions, nil)
+	token, _, err := sessions.Issue(&userauth.Identity{Provider: "github", UserID: "42", Email: "dev@example.com"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name          string
+		authorization string
+		want          audit.Actor
+	}{
+		{"session", "Bearer " + token, audit.Actor{Type: audit.ActorUser, ID: "github/42", Email: "dev@example.com"}},
+		{"API key", "Bearer jnh_key1_secret", audit.Actor{Type: audit.ActorAPIKey, ID: "key1"}},
+		{"bad session", "Bearer forged", audit.Actor{Type: audit.ActorAnonymous}},
+		{"no credentials", "", audit.Actor{Type: audit.ActorAnonymous}},
+	}
+	for _, tt := range tests {
+		header := http.Header{}
+		if tt.authorization != "" {
+			header.Set("Authorization", tt.authorization)
+		}
+		if got := s.AuditActor(header); got != tt.want {
+			t.Errorf("%s: got %+v, want %+v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestAuditFilter(t *testing.T) {
+	cursor := &database.AuditCursor{OccurredAt: time.Date(2026, 3, 1, 12, 0, 0, 123456789, time.UTC), EventId: "e-1"}
+	filter, pageSize, err := auditFilter(&jennahv1.ListAuditEventsRequest{
+		Procedure: " CancelJob ",
+		Since:     "2026-03-01T00:00:00Z",
+		PageToken: encodeAuditCursor(cursor),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if filter.Procedure != "CancelJob" || filter.Since.IsZero() || !filter.Until.IsZero() || pageSize != defaultAuditPageSize {
+		t.Errorf("filter = %+v, page size %d", filter, pageSize)
+	}
+	if filter.After == nil || !filter.After.OccurredAt.Equal(cursor.OccurredAt) || filter.After.EventId != "e-1" {
+		t.Errorf("cursor = %+v, want %+v", filter.After, cursor)
+	}
+
+	if _, pageSize, _ := auditFilter(&jennahv1.ListAuditEventsRequest{PageSize: 5000}); pageSize != maxAuditPageSize {
+		t.Errorf("page size = %d, want %d", pageSize, maxAuditPageSize)
+	}
+	for name, req := range map[string]*jennahv1.ListAuditEventsRequest{
+		"bad since":      {Since: "yesterday"},
+		"bad page token": {PageToken: "not-a-token"},
+		"negative size":  {PageSize: -1},
+	} {
+		if _, _, err := auditFilter(req); err == nil {
+			t.Errorf("%s: no error", name)
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"connectrpc.com/connect"
+
+	jennahv1 "github.com/alphauslabs/jennah/gen/proto"
+	"github.com/alphauslabs/jennah/internal/audit"
+	"github.com/alphauslabs/jennah/internal/userauth"
+)
+
+// CreateSession verifies an identity provider token and issues a session
+// token for its user. The user's tenant is created on first sign-in.
+func (s *GatewayService) CreateSession(
+	ctx context.Context,
+	req *connect.Request[jennahv1.CreateSessionRequest],
+) (*connect.Response[jennahv1.CreateSessionResponse], error) {
+	verifier, ok := s.identityVerifiers[req.Msg.Provider]
+	if !ok {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("unsupported provider %q", req.Msg.Provider))
+	}
+	identity, err := verifier.Verify(ctx, req.Msg.Token)
+	if err != nil {
+		log.Printf("Sign-in with %s failed: %v", req.Msg.Provider, err)
+		if isRejectedToken(err) {
+			return nil, connect.NewError(connect.CodeUnauthenticated, err)
+		}
+		return nil, connect.NewError(connect.CodeUnavailable, fmt.Errorf("failed to verify %s token: %w", req.Msg.Provider, err))
+	}
+
+	audit.SetActor(ctx, identityActor(identity))
+	oauthUser := &OAuthUser{Email: identity.Email, UserId: identity.UserID, Provider: identity.Provider}
+	tenantId, err := s.getOrCreateTenant(oauthUser)
+	if err != nil {
+		log.Printf("Failed to get or create tenant: %v", err)
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	audit.SetTenant(ctx, tenantId)
+
+	token, expires, err := s.sessions.Issue(identity)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to issue session: %w", err))
+	}
+	log.Printf("Issued session for %s (provider: %s) until %s", identity.Email, identity.Provider, expires.Format(time.RFC3339))
+	return connect.NewResponse(&jennahv1.CreateSessionResponse{
+		SessionToken:  token,
+		ExpiresAt:     expires.UTC().Format(time.RFC3339),
+		UserEmail:     identity.Email,
+		OauthProvider: identity.Provider,
+		OauthUserId:   identity.UserID,
+	}), nil
+}
+
+// RefreshSession exchanges a session token, which may have expired, for a
+// new one.
+func (s *GatewayService) RefreshSession(
+	ctx context.Context,
+	req *connect.Request[jennahv1.RefreshSessionRequest],
+) (*connect.Response[jennahv1.RefreshSessionResponse], error) {
+	token, expires, err := s.sessions.Refresh(req.Msg.SessionToken)
+	if err != nil {
+		log.Printf("Session refresh failed: %v", err)
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+	return connect.NewResponse(&jennahv1.RefreshSessionResponse{
+		SessionToken: token,
+		ExpiresAt:    expires.UTC().Format(time.RFC3339),
+	}), nil
+}
+
+// isRejectedToken reports whether err means the token itself is invalid, as
+// opposed to the identity provider being unreachable.
+func isRejectedToken(err error) bool {
+	for _, target := range []error{
+		userauth.ErrMissingToken,
+		userauth.ErrMalformedToken,
+		userauth.ErrBadSignature,
+		userauth.ErrExpiredToken,
+		userauth.ErrInvalidClaims,
+		userauth.ErrTokenRejected,
+	} {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
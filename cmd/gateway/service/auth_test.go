@@ -0,0 +1,64 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+
+	jennahv1 "github.com/alphauslabs/jennah/gen/proto"
+	"github.com/alphauslabs/jennah/internal/hashing"
+	"github.com/alphauslabs/jennah/internal/userauth"
+)
+
+type fakeVerifier struct {
+	token    string
+	identity userauth.Identity
+}
+
+func (v fakeVerifier) Verify(ctx context.Context, token string) (*userauth.Identity, error) {
+	if token != v.token {
+		return nil, userauth.ErrTokenRejected
+	}
+	return &v.identity, nil
+}
+
+func TestSessionAuthentication(t *testing.T) {
+	sessions, err := userauth.NewSessions("0123456789abcdef0123456789abcdef", time.Hour, 24*time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	verifier := fakeVerifier{token: "id-token", identity: userauth.Identity{Provider: "google", UserID: "123", Email: "a@example.com"}}
+	s := NewGatewayService(hashing.NewRouter(nil), nil, nil, nil, nil, sessions,
+		map[string]userauth.IdentityVerifier{"google": verifier})
+	s.cacheTenant("google/123", "tenant-a")
+
+	ctx := context.Background()
+	if _, err := s.CreateSession(ctx, connect.NewRequest(&jennahv1.CreateSessionRequest{Provider: "github", Token: "id-token"})); connect.CodeOf(err) != connect.CodeInvalidArgument {
+		t.Errorf("unknown provider: err = %v, want InvalidArgument", err)
+	}
+	if _, err := s.CreateSession(ctx, connect.NewRequest(&jennahv1.CreateSessionRequest{Provider: "google", Token: "forged"})); connect.CodeOf(err) != connect.CodeUnauthenticated {
+		t.Errorf("forged token: err = %v, want Unauthenticated", err)
+	}
+
+	resp, err := s.CreateSession(ctx, connect.NewRequest(&jennahv1.CreateSessionRequest{Provider: "google", Token: "id-token"}))
+	if err != nil {
+		t.Fatalf("CreateSession: %v", err)
+	}
+	header := http.Header{}
+	header.Set("Authorization", "Bearer "+resp.Msg.SessionToken)
+	if tenantID, err := s.resolveTenant(ctx, header, userauth.ScopeSubmit); err != nil || tenantID != "tenant-a" {
+		t.Errorf("resolveTenant = %q, %v; want tenant-a", tenantID, err)
+	}
+
+	// Identity headers alone are no longer trusted.
+	spoofed := http.Header{}
+	spoofed.Set("X-OAuth-Email", "a@example.com")
+	spoofed.Set("X-OAuth-UserId", "123")
+	spoofed.Set("X-OAuth-Provider", "google")
+	if _, err := s.resolveTenant(ctx, spoofed, userauth.ScopeRead); connect.CodeOf(err) != connect.CodeUnauthenticated {
+		t.Errorf("spoofed headers: err = %v, want Unauthenticated", err)
+	}
+}
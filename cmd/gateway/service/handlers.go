@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"log"
@@ -14,33 +15,33 @@ import (
 	jennahv1 "github.com/alphauslabs/jennah/gen/proto"
 	jennahv1connect "github.com/alphauslabs/jennah/gen/proto/jennahv1connect"
 	"github.com/alphauslabs/jennah/internal/database"
-	"github.com/alphauslabs/jennah/internal/router"
+	"github.com/alphauslabs/jennah/internal/userauth"
 )
 
-func (s *GatewayService) resolveTenant(header http.Header) (string, error) {
-	oauthUser, err := extractOAuthUser(header)
+// resolveTenant authenticates a request and returns the tenant it acts on.
+// API keys need scope; session callers need a role in the active
+// organization that allows it.
+func (s *GatewayService) resolveTenant(ctx context.Context, header http.Header, scope string) (string, error) {
+	c, err := s.resolveCaller(ctx, header, scope)
 	if err != nil {
-		log.Printf("OAuth authentication failed: %v", err)
-		return "", connect.NewError(connect.CodeUnauthenticated, errors.New("missing or invalid OAuth headers"))
+		return "", err
 	}
-
-	tenantId, err := s.getOrCreateTenant(oauthUser)
-	if err != nil {
-		log.Printf("Failed to get or create tenant: %v", err)
-		return "", connect.NewError(connect.CodeInternal, err)
-	}
-
-	return tenantId, nil
+	return c.tenantId, nil
 }
 
+// getWorkerClient returns the first healthy worker the ring assigns to
+// routingKey.
 func (s *GatewayService) getWorkerClient(routingKey string) (string, jennahv1connect.DeploymentServiceClient, error) {
-	workerIP := s.router.GetWorkerIP(routingKey)
-	if workerIP == "" {
+	order := s.failoverOrder(routingKey)
+	if len(order) == 0 {
 		log.Printf("No worker found for routingKey: %s", routingKey)
 		return "", nil, connect.NewError(connect.CodeInternal, errors.New("no worker found for routing key"))
 	}
+	workerIP := order[0]
 
+	s.clientsMu.RLock()
 	workerClient, exists := s.workerClients[workerIP]
+	s.clientsMu.RUnlock()
 	if !exists {
 		log.Printf("No worker client found for IP: %s", workerIP)
 		return "", nil, connect.NewError(connect.CodeInternal, fmt.Errorf("no worker client found for IP: %s", workerIP))
@@ -49,6 +50,33 @@ func (s *GatewayService) getWorkerClient(routingKey string) (string, jennahv1con
 	return workerIP, workerClient, nil
 }
 
+// getJobWorkerClient returns the worker that should handle a request about
+// an existing job: the worker holding the job's lease when it is a live,
+// healthy ring member, otherwise the worker the ring assigns to the job ID. A worker that
+// is not the owner forwards the request or claims the lease itself.
+func (s *GatewayService) getJobWorkerClient(ctx context.Context, tenantID, jobID string) (string, jennahv1connect.DeploymentServiceClient, error) {
+	job, err := s.dbClient.GetJob(ctx, tenantID, jobID)
+	if err == nil {
+		s.clientsMu.RLock()
+		addr := leaseOwnerAddress(job, s.workerAddrs, time.Now().UTC())
+		workerClient, exists := s.workerClients[addr]
+		s.clientsMu.RUnlock()
+		if addr != "" && exists && s.health.healthy(addr) {
+			return addr, workerClient, nil
+		}
+	}
+	return s.getWorkerClient(jobID)
+}
+
+// leaseOwnerAddress returns the address of the worker holding job's lease, or
+// "" when the lease is free or expired or its owner is not a known worker.
+func leaseOwnerAddress(job *database.Job, addrs map[string]string, now time.Time) string {
+	if job.OwnerWorkerId == nil || job.LeaseExpiresAt == nil || !job.LeaseExpiresAt.After(now) {
+		return ""
+	}
+	return addrs[*job.OwnerWorkerId]
+}
+
 func dbJobToProto(job *database.Job) *jennahv1.Job {
 	p := &jennahv1.Job{
 		JobId:      job.JobId,
@@ -107,6 +135,9 @@ func dbJobToProto(job *database.Job) *jennahv1.Job {
 	if job.AssignedService != nil {
 		p.AssignedService = *job.AssignedService
 	}
+	if job.RoutingReason != nil {
+		p.RoutingReason = *job.RoutingReason
+	}
 	if job.MemoryMib != nil {
 		p.MemoryMib = *job.MemoryMib
 	}
@@ -116,6 +147,36 @@ func dbJobToProto(job *database.Job) *jennahv1.Job {
 	if job.MaxRunDurationSeconds != nil {
 		p.MaxRunDurationSeconds = *job.MaxRunDurationSeconds
 	}
+	if job.Region != nil {
+		p.Region = *job.Region
+	}
+	if job.PreemptionCount != nil {
+		p.PreemptionCount = *job.PreemptionCount
+	}
+	if job.VolumesJson != nil && *job.VolumesJson != "" {
+		if err := json.Unmarshal([]byte(*job.VolumesJson), &p.Volumes); err != nil {
+			log.Printf("Error decoding volumes for job %s: %v", job.JobId, err)
+		}
+	}
+	if job.RunnablesJson != nil && *job.RunnablesJson != "" {
+		if err := json.Unmarshal([]byte(*job.RunnablesJson), &p.Runnables); err != nil {
+			log.Printf("Error decoding runnables for job %s: %v", job.JobId, err)
+		}
+	}
+	if job.LabelsJson != nil && *job.LabelsJson != "" {
+		if err := json.Unmarshal([]byte(*job.LabelsJson), &p.Labels); err != nil {
+			log.Printf("Error decoding labels for job %s: %v", job.JobId, err)
+		}
+	}
+	if job.AcceleratorType != nil {
+		p.Accelerator = &jennahv1.Accelerator{Type: *job.AcceleratorType}
+		if job.AcceleratorCount != nil {
+			p.Accelerator.Count = *job.AcceleratorCount
+		}
+		if job.AcceleratorDriverVersion != nil {
+			p.Accelerator.DriverVersion = *job.AcceleratorDriverVersion
+		}
+	}
 
 	return p
 }
@@ -124,10 +185,11 @@ func (s *GatewayService) GetCurrentTenant(
 	ctx context.Context,
 	req *connect.Request[jennahv1.GetCurrentTenantRequest],
 ) (*connect.Response[jennahv1.GetCurrentTenantResponse], error) {
-	tenantId, err := s.resolveTenant(req.Header())
+	c, err := s.resolveCaller(ctx, req.Header(), userauth.ScopeRead)
 	if err != nil {
 		return nil, err
 	}
+	tenantId := c.tenantId
 
 	tenant, err := s.dbClient.GetTenant(ctx, tenantId)
 	if err != nil {
@@ -140,7 +202,22 @@ func (s *GatewayService) GetCurrentTenant(
 		UserEmail:     tenant.UserEmail,
 		OauthProvider: tenant.OAuthProvider,
 		CreatedAt:     tenant.CreatedAt.Format(time.RFC3339),
+		Role:          c.role,
 	})
+	if tenant.Name != nil {
+		response.Msg.OrgName = *tenant.Name
+	}
+	if c.user != nil {
+		response.Msg.UserEmail, response.Msg.OauthProvider = c.user.Email, c.user.Provider
+	}
+	if tenant.Name == nil {
+		identities, err := s.dbClient.ListIdentities(ctx, tenantId)
+		if err != nil {
+			log.Printf("Failed to list identities: %v", err)
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+		response.Msg.Identities = identitiesToProto(tenant, identities)
+	}
 
 	log.Printf("Retrieved tenant info for user %s: tenantId=%s", tenant.UserEmail, tenantId)
 	return response, nil
@@ -152,7 +229,7 @@ func (s *GatewayService) SubmitJob(
 ) (*connect.Response[jennahv1.SubmitJobResponse], error) {
 	log.Printf("Received job submission")
 
-	tenantId, err := s.resolveTenant(req.Header())
+	tenantId, err := s.resolveTenant(ctx, req.Header(), userauth.ScopeSubmit)
 	if err != nil {
 		return nil, err
 	}
@@ -163,17 +240,13 @@ func (s *GatewayService) SubmitJob(
 	}
 
 	gatewayJobID := uuid.NewString()
-	workerIP, workerClient, err := s.getWorkerClient(gatewayJobID)
-	if err != nil {
-		return nil, err
+	workerIPs := s.failoverOrder(gatewayJobID)
+	if len(workerIPs) == 0 {
+		log.Printf("No worker found for routingKey: %s", gatewayJobID)
+		return nil, connect.NewError(connect.CodeInternal, errors.New("no worker found for routing key"))
 	}
-	log.Printf("Selected worker: %s for tenant (routing key: %s)", workerIP, gatewayJobID)
-
-	routingDecision := router.EvaluateJobComplexityWithGemini(ctx, req.Msg)
-	log.Printf("Routing decision: complexity=%s, service=%s, reason=%s",
-		routingDecision.Complexity, routingDecision.AssignedService, routingDecision.Reason)
 
-	workerReq := connect.NewRequest(&jennahv1.SubmitJobRequest{
+	workerMsg := &jennahv1.SubmitJobRequest{
 		JobId:            gatewayJobID,
 		ImageUri:         req.Msg.ImageUri,
 		EnvVars:          req.Msg.EnvVars,
@@ -185,22 +258,51 @@ func (s *GatewayService) SubmitJob(
 		UseSpotVms:       req.Msg.UseSpotVms,
 		ServiceAccount:   req.Msg.ServiceAccount,
 		Commands:         req.Msg.Commands,
-	})
-	workerReq.Header().Set("X-Tenant-Id", tenantId)
-
-	response, err := workerClient.SubmitJob(ctx, workerReq)
+		PreemptionPolicy: req.Msg.PreemptionPolicy,
+		Accelerator:      req.Msg.Accelerator,
+		Volumes:          req.Msg.Volumes,
+		Runnables:        req.Msg.Runnables,
+		Labels:           req.Msg.Labels,
+	}
+
+	// The job ID is fixed here, so a submission that may or may not have
+	// reached a worker can be retried on the next one: workers treat a
+	// repeated job ID as the same job.
+	var response *connect.Response[jennahv1.SubmitJobResponse]
+	var workerIP string
+	for attempt, ip := range workerIPs {
+		workerIP = ip
+		s.clientsMu.RLock()
+		workerClient, exists := s.workerClients[ip]
+		s.clientsMu.RUnlock()
+		if !exists {
+			log.Printf("No worker client found for IP: %s", ip)
+			err = connect.NewError(connect.CodeInternal, fmt.Errorf("no worker client found for IP: %s", ip))
+			continue
+		}
+		log.Printf("Selected worker: %s for tenant (routing key: %s, attempt %d)", ip, gatewayJobID, attempt+1)
+
+		workerReq := connect.NewRequest(workerMsg)
+		workerReq.Header().Set("X-Tenant-Id", tenantId)
+		response, err = workerClient.SubmitJob(ctx, workerReq)
+		if err == nil {
+			break
+		}
+		log.Printf("ERROR: Worker %s failed: %v", ip, err)
+		if !isConnectionError(err) || ctx.Err() != nil {
+			break
+		}
+		s.noteWorkerError(ip, err)
+	}
 	if err != nil {
-		log.Printf("ERROR: Worker %s failed: %v", workerIP, err)
-		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("worker failed: %w", err))
+		return nil, connect.NewError(connect.CodeOf(err), fmt.Errorf("worker failed: %w", err))
 	}
 
+	// Routing is decided by the worker; its response reports what was dispatched.
 	response.Msg.WorkerAssigned = workerIP
-	response.Msg.ComplexityLevel = routingDecision.Complexity.String()
-	response.Msg.AssignedService = routingDecision.AssignedService.String()
-	response.Msg.RoutingReason = routingDecision.Reason
-	log.Printf("Job submitted successfully: jobId=%s, worker=%s, status=%s, complexity=%s, service=%s",
+	log.Printf("Job submitted successfully: jobId=%s, worker=%s, status=%s, complexity=%s, service=%s, reason=%s",
 		response.Msg.JobId, workerIP, response.Msg.Status,
-		response.Msg.ComplexityLevel, response.Msg.AssignedService)
+		response.Msg.ComplexityLevel, response.Msg.AssignedService, response.Msg.RoutingReason)
 
 	return response, nil
 }
@@ -211,7 +313,7 @@ func (s *GatewayService) ListJobs(
 ) (*connect.Response[jennahv1.ListJobsResponse], error) {
 	log.Printf("Received list jobs request")
 
-	tenantId, err := s.resolveTenant(req.Header())
+	tenantId, err := s.resolveTenant(ctx, req.Header(), userauth.ScopeRead)
 	if err != nil {
 		return nil, err
 	}
@@ -243,12 +345,12 @@ func (s *GatewayService) CancelJob(
 		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("job_id is required"))
 	}
 
-	tenantId, err := s.resolveTenant(req.Header())
+	tenantId, err := s.resolveTenant(ctx, req.Header(), userauth.ScopeCancel)
 	if err != nil {
 		return nil, err
 	}
 
-	workerIP, workerClient, err := s.getWorkerClient(req.Msg.JobId)
+	workerIP, workerClient, err := s.getJobWorkerClient(ctx, tenantId, req.Msg.JobId)
 	if err != nil {
 		return nil, err
 	}
@@ -259,7 +361,8 @@ func (s *GatewayService) CancelJob(
 	response, err := workerClient.CancelJob(ctx, workerReq)
 	if err != nil {
 		log.Printf("ERROR: Worker %s CancelJob failed for job %s: %v", workerIP, req.Msg.JobId, err)
-		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("worker failed: %w", err))
+		s.noteWorkerError(workerIP, err)
+		return nil, connect.NewError(connect.CodeOf(err), fmt.Errorf("worker failed: %w", err))
 	}
 
 	log.Printf("Job cancelled successfully: jobId=%s, tenantId=%s, worker=%s", req.Msg.JobId, tenantId, workerIP)
@@ -276,12 +379,12 @@ func (s *GatewayService) DeleteJob(
 		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("job_id is required"))
 	}
 
-	tenantId, err := s.resolveTenant(req.Header())
+	tenantId, err := s.resolveTenant(ctx, req.Header(), userauth.ScopeCancel)
 	if err != nil {
 		return nil, err
 	}
 
-	workerIP, workerClient, err := s.getWorkerClient(req.Msg.JobId)
+	workerIP, workerClient, err := s.getJobWorkerClient(ctx, tenantId, req.Msg.JobId)
 	if err != nil {
 		return nil, err
 	}
@@ -292,7 +395,8 @@ func (s *GatewayService) DeleteJob(
 	response, err := workerClient.DeleteJob(ctx, workerReq)
 	if err != nil {
 		log.Printf("ERROR: Worker %s DeleteJob failed for job %s: %v", workerIP, req.Msg.JobId, err)
-		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("worker failed: %w", err))
+		s.noteWorkerError(workerIP, err)
+		return nil, connect.NewError(connect.CodeOf(err), fmt.Errorf("worker failed: %w", err))
 	}
 
 	log.Printf("Job deleted successfully: jobId=%s, tenantId=%s, worker=%s", req.Msg.JobId, tenantId, workerIP)
@@ -309,12 +413,12 @@ func (s *GatewayService) GetJob(
 		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("job_id is required"))
 	}
 
-	tenantId, err := s.resolveTenant(req.Header())
+	tenantId, err := s.resolveTenant(ctx, req.Header(), userauth.ScopeRead)
 	if err != nil {
 		return nil, err
 	}
 
-	workerIP, workerClient, err := s.getWorkerClient(req.Msg.JobId)
+	workerIP, workerClient, err := s.getJobWorkerClient(ctx, tenantId, req.Msg.JobId)
 	if err != nil {
 		return nil, err
 	}
@@ -325,7 +429,8 @@ func (s *GatewayService) GetJob(
 	response, err := workerClient.GetJob(ctx, workerReq)
 	if err != nil {
 		log.Printf("ERROR: Worker %s GetJob failed for job %s: %v", workerIP, req.Msg.JobId, err)
-		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("worker failed: %w", err))
+		s.noteWorkerError(workerIP, err)
+		return nil, connect.NewError(connect.CodeOf(err), fmt.Errorf("worker failed: %w", err))
 	}
 
 	log.Printf("Job retrieved successfully: jobId=%s, tenantId=%s, worker=%s", req.Msg.JobId, tenantId, workerIP)
@@ -336,7 +441,7 @@ func (s *GatewayService) ListNotifications(
 	ctx context.Context,
 	req *connect.Request[jennahv1.ListNotificationsRequest],
 ) (*connect.Response[jennahv1.ListNotificationsResponse], error) {
-	tenantId, err := s.resolveTenant(req.Header())
+	tenantId, err := s.resolveTenant(ctx, req.Header(), userauth.ScopeRead)
 	if err != nil {
 		return nil, err
 	}
@@ -372,7 +477,9 @@ func (s *GatewayService) AckNotification(
 		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("notification_id is required"))
 	}
 
-	tenantId, err := s.resolveTenant(req.Header())
+	// Acking changes the tenant's notifications, so read-only keys and
+	// viewers may not.
+	tenantId, err := s.resolveTenant(ctx, req.Header(), userauth.ScopeSubmit)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,28 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alphauslabs/jennah/internal/database"
+)
+
+func TestLeaseOwnerAddress(t *testing.T) {
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	addrs := map[string]string{"worker-a": "10.0.0.1:8081"}
+	owner, unknown := "worker-a", "worker-z"
+	current, expired := now.Add(10*time.Second), now.Add(-time.Second)
+
+	if got := leaseOwnerAddress(&database.Job{OwnerWorkerId: &owner, LeaseExpiresAt: &current}, addrs, now); got != "10.0.0.1:8081" {
+		t.Errorf("current lease: got %q", got)
+	}
+	if got := leaseOwnerAddress(&database.Job{OwnerWorkerId: &owner, LeaseExpiresAt: &expired}, addrs, now); got != "" {
+		t.Errorf("expired lease should fall back to the ring, got %q", got)
+	}
+	if got := leaseOwnerAddress(&database.Job{OwnerWorkerId: &unknown, LeaseExpiresAt: &current}, addrs, now); got != "" {
+		t.Errorf("unknown owner should fall back to the ring, got %q", got)
+	}
+	if got := leaseOwnerAddress(&database.Job{}, addrs, now); got != "" {
+		t.Errorf("unleased job should fall back to the ring, got %q", got)
+	}
+}
@@ -0,0 +1,231 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// HealthCheckOptions configures StartHealthChecks.
+type HealthCheckOptions struct {
+	// Interval is how often every ring member's /health is probed.
+	Interval time.Duration
+	// Timeout bounds each probe.
+	Timeout time.Duration
+	// FailureThreshold is the number of consecutive failed probes after
+	// which a worker is skipped.
+	FailureThreshold int
+	// URL returns the base URL (scheme://host:port) of a ring member.
+	URL func(member string) string
+	// Transport makes the probes; nil means http.DefaultTransport.
+	Transport http.RoundTripper
+}
+
+// ejectDuration is how long a worker that failed a request is skipped when
+// no probe reports it healthy again first.
+const ejectDuration = 30 * time.Second
+
+// submitAttempts is how many ring members SubmitJob tries.
+const submitAttempts = 3
+
+// WorkerHealth is the gateway's view of one worker.
+type WorkerHealth struct {
+	Address             string    `json:"address"`
+	Weight              int       `json:"weight"`
+	Healthy             bool      `json:"healthy"`
+	ConsecutiveFailures int       `json:"consecutive_failures"`
+	LastCheck           time.Time `json:"last_check,omitzero"`
+	LatencyMs           int64     `json:"latency_ms"`
+	LastError           string    `json:"last_error,omitempty"`
+	EjectedUntil        time.Time `json:"ejected_until,omitzero"`
+}
+
+// healthTracker records probe results and request failures per worker.
+// Workers it knows nothing about are healthy.
+type healthTracker struct {
+	mu               sync.Mutex
+	workers          map[string]*WorkerHealth
+	failureThreshold int
+	now              func() time.Time
+}
+
+func newHealthTracker() *healthTracker {
+	return &healthTracker{workers: make(map[string]*WorkerHealth), failureThreshold: 2, now: time.Now}
+}
+
+func (h *healthTracker) entry(addr string) *WorkerHealth {
+	w, ok := h.workers[addr]
+	if !ok {
+		w = &WorkerHealth{Address: addr, Healthy: true}
+		h.workers[addr] = w
+	}
+	return w
+}
+
+// healthy reports whether requests should be sent to addr.
+func (h *healthTracker) healthy(addr string) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	w, ok := h.workers[addr]
+	if !ok {
+		return true
+	}
+	return w.Healthy && !h.now().Before(w.EjectedUntil)
+}
+
+// record stores the outcome of a probe of addr.
+func (h *healthTracker) record(addr string, latency time.Duration, err error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	w := h.entry(addr)
+	w.LastCheck = h.now().UTC()
+	w.LatencyMs = latency.Milliseconds()
+	if err == nil {
+		if !w.Healthy || !w.EjectedUntil.IsZero() {
+			log.Printf("Worker %s is healthy again", addr)
+		}
+		w.Healthy, w.ConsecutiveFailures, w.LastError, w.EjectedUntil = true, 0, "", time.Time{}
+		return
+	}
+	w.ConsecutiveFailures++
+	w.LastError = err.Error()
+	if w.Healthy && w.ConsecutiveFailures >= h.failureThreshold {
+		w.Healthy = false
+		log.Printf("Worker %s marked unhealthy after %d failed health check(s): %v", addr, w.ConsecutiveFailures, err)
+	}
+}
+
+// eject skips addr for ejectDuration, or until a probe succeeds, after a
+// request to it failed to connect.
+func (h *healthTracker) eject(addr string, err error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	w := h.entry(addr)
+	w.LastError = err.Error()
+	w.EjectedUntil = h.now().UTC().Add(ejectDuration)
+	log.Printf("Worker %s ejected for %s: %v", addr, ejectDuration, err)
+}
+
+// snapshot returns the health of each address, in order.
+func (h *healthTracker) snapshot(addrs []string) []WorkerHealth {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	now := h.now()
+	out := make([]WorkerHealth, 0, len(addrs))
+	for _, addr := range addrs {
+		w := *h.entry(addr)
+		w.Healthy = w.Healthy && !now.Before(w.EjectedUntil)
+		out = append(out, w)
+	}
+	return out
+}
+
+// StartHealthChecks probes every ring member's /health each interval until
+// ctx is done.
+func (s *GatewayService) StartHealthChecks(ctx context.Context, opts HealthCheckOptions) {
+	if opts.FailureThreshold > 0 {
+		s.health.failureThreshold = opts.FailureThreshold
+	}
+	client := &http.Client{Timeout: opts.Timeout, Transport: opts.Transport}
+	go func() {
+		ticker := time.NewTicker(opts.Interval)
+		defer ticker.Stop()
+		for {
+			var wg sync.WaitGroup
+			for _, m := range s.router.Members() {
+				wg.Add(1)
+				go func(addr string) {
+					defer wg.Done()
+					start := time.Now()
+					err := probe(ctx, client, opts.URL(addr)+"/health")
+					s.health.record(addr, time.Since(start), err)
+				}(m.Name)
+			}
+			wg.Wait()
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+func probe(ctx context.Context, client *http.Client, url string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("health check returned %s", resp.Status)
+	}
+	return nil
+}
+
+// isConnectionError reports whether err means the worker could not be
+// reached or did not answer, as opposed to the worker rejecting the request.
+func isConnectionError(err error) bool {
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
+
+// noteWorkerError ejects workerIP when err shows it is unreachable.
+func (s *GatewayService) noteWorkerError(workerIP string, err error) {
+	if isConnectionError(err) {
+		s.health.eject(workerIP, err)
+	}
+}
+
+// failoverOrder returns the workers a request keyed by routingKey may try, in
+// ring order with healthy workers first, at most submitAttempts of them.
+func (s *GatewayService) failoverOrder(routingKey string) []string {
+	var healthy, unhealthy []string
+	for _, ip := range s.router.GetWorkerIPs(routingKey) {
+		if s.health.healthy(ip) {
+			healthy = append(healthy, ip)
+		} else {
+			unhealthy = append(unhealthy, ip)
+		}
+	}
+	order := append(healthy, unhealthy...)
+	return order[:min(len(order), submitAttempts)]
+}
+
+// ServeStatus writes the health of every ring member as JSON.
+func (s *GatewayService) ServeStatus(w http.ResponseWriter, r *http.Request) {
+	members := s.router.Members()
+	addrs := make([]string, 0, len(members))
+	for _, m := range members {
+		addrs = append(addrs, m.Name)
+	}
+	workers := s.health.snapshot(addrs)
+	healthy := 0
+	for i, m := range members {
+		workers[i].Weight = m.Weight
+		if workers[i].Healthy {
+			healthy++
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if healthy == 0 {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	json.NewEncoder(w).Encode(struct {
+		Workers []WorkerHealth `json:"workers"`
+		Healthy int            `json:"healthy"`
+		Total   int            `json:"total"`
+	}{Workers: workers, Healthy: healthy, Total: len(workers)})
+}
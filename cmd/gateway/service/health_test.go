@@ -0,0 +1,142 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+
+	jennahv1connect "github.com/alphauslabs/jennah/gen/proto/jennahv1connect"
+	"github.com/alphauslabs/jennah/internal/hashing"
+)
+
+func TestHealthTracker_ProbesAndEjection(t *testing.T) {
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	h := newHealthTracker()
+	h.now = func() time.Time { return now }
+
+	if !h.healthy("a") {
+		t.Fatal("unknown workers are healthy")
+	}
+	h.record("a", time.Millisecond, errors.New("refused"))
+	if !h.healthy("a") {
+		t.Error("one failed probe is below the threshold")
+	}
+	h.record("a", time.Millisecond, errors.New("refused"))
+	if h.healthy("a") {
+		t.Error("two failed probes should mark the worker unhealthy")
+	}
+	h.record("a", time.Millisecond, nil)
+	if !h.healthy("a") {
+		t.Error("a successful probe should restore the worker")
+	}
+
+	h.eject("a", errors.New("connection reset"))
+	if h.healthy("a") {
+		t.Error("ejected worker should be skipped")
+	}
+	now = now.Add(ejectDuration)
+	if !h.healthy("a") {
+		t.Error("ejection should expire")
+	}
+}
+
+func TestFailoverOrder_SkipsUnhealthy(t *testing.T) {
+	s := NewGatewayService(hashing.NewRouter([]string{"a", "b", "c", "d"}), nil, nil, nil, nil, nil, nil)
+	key := "job-1"
+	full := s.router.GetWorkerIPs(key)
+
+	order := s.failoverOrder(key)
+	if len(order) != submitAttempts || order[0] != full[0] {
+		t.Fatalf("got %v, want the first %d of %v", order, submitAttempts, full)
+	}
+
+	s.health.eject(full[0], errors.New("down"))
+	order = s.failoverOrder(key)
+	if order[0] != full[1] {
+		t.Errorf("ejected owner should be tried last: got %v (ring %v)", order, full)
+	}
+}
+
+// namedAdminClient tells admin clients apart in tests; its methods are not
+// called.
+type namedAdminClient struct {
+	jennahv1connect.AdminServiceClient
+	name string
+}
+
+func TestWithAdminClient_FailsOverUnreachableWorkers(t *testing.T) {
+	members := []string{"a", "b", "c", "d"}
+	adminClients := make(map[string]jennahv1connect.AdminServiceClient)
+	for _, m := range members {
+		adminClients[m] = namedAdminClient{name: m}
+	}
+	s := NewGatewayService(hashing.NewRouter(members), nil, adminClients, nil, nil, nil, nil)
+	order := s.failoverOrder(adminRoutingKey)
+	unreachable := connect.NewError(connect.CodeUnavailable, &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")})
+
+	var called []string
+	workerIP, err := s.withAdminClient(context.Background(), func(c jennahv1connect.AdminServiceClient) error {
+		name := c.(namedAdminClient).name
+		called = append(called, name)
+		if name == order[0] {
+			return unreachable
+		}
+		return nil
+	})
+	if err != nil || workerIP != order[1] {
+		t.Fatalf("withAdminClient = %q, %v; want %q after %q was unreachable", workerIP, err, order[1], order[0])
+	}
+	if s.health.healthy(order[0]) {
+		t.Errorf("unreachable worker %s was not ejected", order[0])
+	}
+
+	// An error returned by a worker is not retried elsewhere.
+	called = nil
+	failed := connect.NewError(connect.CodeInternal, errors.New("reconcile failed"))
+	if _, err := s.withAdminClient(context.Background(), func(c jennahv1connect.AdminServiceClient) error {
+		called = append(called, c.(namedAdminClient).name)
+		return failed
+	}); err != failed || len(called) != 1 {
+		t.Errorf("worker error: err = %v after %v, want it returned from the first worker", err, called)
+	}
+}
+
+func TestIsConnectionError(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	addr := server.Listener.Addr().String()
+	server.Close()
+
+	_, dialErr := net.Dial("tcp", addr)
+	if dialErr == nil {
+		t.Skip("port reused")
+	}
+	if !isConnectionError(connect.NewError(connect.CodeUnavailable, fmt.Errorf("post: %w", dialErr))) {
+		t.Error("dial failure should be a connection error")
+	}
+	if isConnectionError(connect.NewError(connect.CodeUnavailable, errors.New("provider circuit open"))) {
+		t.Error("an error returned by the worker is not a connection error")
+	}
+}
+
+func TestServeStatus(t *testing.T) {
+	s := NewGatewayService(hashing.NewWeightedRouter([]hashing.WeightedMember{{Name: "a", Weight: 2}}), nil, nil, nil, nil, nil, nil)
+	rec := httptest.NewRecorder()
+	s.ServeStatus(rec, httptest.NewRequest(http.MethodGet, "/status", nil))
+	if rec.Code != http.StatusOK {
+		t.Errorf("status: got %d", rec.Code)
+	}
+
+	s.health.eject("a", errors.New("down"))
+	rec = httptest.NewRecorder()
+	s.ServeStatus(rec, httptest.NewRequest(http.MethodGet, "/status", nil))
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("no healthy worker: got %d", rec.Code)
+	}
+}
@@ -0,0 +1,217 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"connectrpc.com/connect"
+
+	jennahv1 "github.com/alphauslabs/jennah/gen/proto"
+	"github.com/alphauslabs/jennah/internal/audit"
+	"github.com/alphauslabs/jennah/internal/database"
+	"github.com/alphauslabs/jennah/internal/userauth"
+)
+
+// verifyProof checks an identity provider token as CreateSession does.
+func (s *GatewayService) verifyProof(ctx context.Context, proof *jennahv1.IdentityProof) (*userauth.Identity, error) {
+	if proof == nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("identity proof is required"))
+	}
+	verifier, ok := s.identityVerifiers[proof.Provider]
+	if !ok {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("unsupported provider %q", proof.Provider))
+	}
+	identity, err := verifier.Verify(ctx, proof.Token)
+	if err != nil {
+		log.Printf("Identity proof from %s failed: %v", proof.Provider, err)
+		if isRejectedToken(err) {
+			return nil, connect.NewError(connect.CodeUnauthenticated, err)
+		}
+		return nil, connect.NewError(connect.CodeUnavailable, fmt.Errorf("failed to verify %s token: %w", proof.Provider, err))
+	}
+	return identity, nil
+}
+
+// LinkIdentity links a second sign-in identity to the caller's personal
+// tenant. Both identities must be proven with fresh provider tokens; a
+// session alone is not enough to take over another account.
+func (s *GatewayService) LinkIdentity(
+	ctx context.Context,
+	req *connect.Request[jennahv1.LinkIdentityRequest],
+) (*connect.Response[jennahv1.LinkIdentityResponse], error) {
+	oauthUser, err := s.authenticate(req.Header())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, fmt.Errorf("invalid session: %w", err))
+	}
+	current, err := s.verifyProof(ctx, req.Msg.Current)
+	if err != nil {
+		return nil, err
+	}
+	if current.Provider != oauthUser.Provider || current.UserID != oauthUser.UserId {
+		return nil, connect.NewError(connect.CodePermissionDenied, errors.New("the current identity proof does not match the session"))
+	}
+	other, err := s.verifyProof(ctx, req.Msg.Other)
+	if err != nil {
+		return nil, err
+	}
+	if other.Provider == current.Provider && other.UserID == current.UserID {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("cannot link an identity to itself"))
+	}
+	audit.SetTarget(ctx, "identity", other.Provider+"/"+other.UserID)
+
+	tenantId, err := s.getOrCreateTenant(oauthUser)
+	if err != nil {
+		log.Printf("Failed to get or create tenant: %v", err)
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	audit.SetTenant(ctx, tenantId)
+	otherTenant, err := s.dbClient.GetTenantByOAuth(ctx, other.Provider, other.UserID)
+	if err != nil {
+		log.Printf("Failed to look up tenant of %s user %s: %v", other.Provider, other.UserID, err)
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	response := &jennahv1.LinkIdentityResponse{}
+	if otherTenant != nil && otherTenant.TenantId == tenantId {
+		return nil, connect.NewError(connect.CodeAlreadyExists, fmt.Errorf("%s user %s is already linked", other.Provider, other.UserID))
+	}
+	if otherTenant != nil {
+		if !isPersonalOwner(otherTenant, other.Provider, other.UserID) {
+			return nil, connect.NewError(connect.CodeFailedPrecondition,
+				fmt.Errorf("%s user %s is linked to another tenant; unlink it there first", other.Provider, other.UserID))
+		}
+		if !req.Msg.MergeTenant {
+			return nil, connect.NewError(connect.CodeFailedPrecondition,
+				fmt.Errorf("%s user %s has its own tenant %s; set merge_tenant to move its jobs and notifications here", other.Provider, other.UserID, otherTenant.TenantId))
+		}
+		stats, err := s.dbClient.MergeTenant(ctx, otherTenant.TenantId, tenantId)
+		if errors.Is(err, database.ErrTenantHasActiveJobs) {
+			return nil, connect.NewError(connect.CodeFailedPrecondition,
+				fmt.Errorf("tenant %s has jobs that have not finished; wait for them or cancel them, then retry", otherTenant.TenantId))
+		}
+		if err != nil {
+			log.Printf("Failed to merge tenant: %v", err)
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+		log.Printf("Merged tenant %s into %s: %d jobs, %d notifications", otherTenant.TenantId, tenantId, stats.Jobs, stats.Notifications)
+		response.MergedTenantId = otherTenant.TenantId
+		response.MovedJobs, response.MovedNotifications = stats.Jobs, stats.Notifications
+	}
+
+	if err := s.dbClient.LinkIdentities(ctx, tenantId,
+		&database.Identity{OAuthProvider: current.Provider, OAuthUserId: current.UserID, Email: current.Email},
+		&database.Identity{OAuthProvider: other.Provider, OAuthUserId: other.UserID, Email: other.Email},
+	); err != nil {
+		log.Printf("Failed to link identity: %v", err)
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	s.mu.Lock()
+	s.cacheTenant(tenantCacheKey(&OAuthUser{Provider: other.Provider, UserId: other.UserID}), tenantId)
+	s.mu.Unlock()
+
+	log.Printf("Linked %s user %s to tenant %s of %s user %s", other.Provider, other.UserID, tenantId, current.Provider, current.UserID)
+	if response.Identities, err = s.linkedIdentities(ctx, tenantId); err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(response), nil
+}
+
+// UnlinkIdentity unlinks an identity from the caller's personal tenant. The
+// identity the caller signed in with and the tenant's primary identity
+// cannot be unlinked.
+func (s *GatewayService) UnlinkIdentity(
+	ctx context.Context,
+	req *connect.Request[jennahv1.UnlinkIdentityRequest],
+) (*connect.Response[jennahv1.UnlinkIdentityResponse], error) {
+	oauthUser, err := s.authenticate(req.Header())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, fmt.Errorf("invalid session: %w", err))
+	}
+	if req.Msg.Provider == oauthUser.Provider && req.Msg.UserId == oauthUser.UserId {
+		return nil, connect.NewError(connect.CodeFailedPrecondition, errors.New("cannot unlink the identity you are signed in with"))
+	}
+	tenantId, err := s.getOrCreateTenant(oauthUser)
+	if err != nil {
+		log.Printf("Failed to get or create tenant: %v", err)
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	audit.SetTenant(ctx, tenantId)
+	tenant, err := s.dbClient.GetTenant(ctx, tenantId)
+	if err != nil {
+		log.Printf("Failed to fetch tenant: %v", err)
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	if isPersonalOwner(tenant, req.Msg.Provider, req.Msg.UserId) {
+		return nil, connect.NewError(connect.CodeFailedPrecondition, errors.New("cannot unlink the tenant's primary identity"))
+	}
+
+	identity, err := s.dbClient.GetIdentity(ctx, req.Msg.Provider, req.Msg.UserId)
+	if err != nil {
+		log.Printf("Failed to look up identity: %v", err)
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	if identity == nil || identity.TenantId != tenantId {
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("%s user %s is not linked to this tenant", req.Msg.Provider, req.Msg.UserId))
+	}
+	if err := s.dbClient.DeleteIdentity(ctx, req.Msg.Provider, req.Msg.UserId); err != nil {
+		log.Printf("Failed to unlink identity: %v", err)
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	s.mu.Lock()
+	delete(s.oauthToTenant, tenantCacheKey(&OAuthUser{Provider: req.Msg.Provider, UserId: req.Msg.UserId}))
+	s.mu.Unlock()
+
+	log.Printf("Unlinked %s user %s from tenant %s", req.Msg.Provider, req.Msg.UserId, tenantId)
+	identities, err := s.linkedIdentities(ctx, tenantId)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(&jennahv1.UnlinkIdentityResponse{Identities: identities}), nil
+}
+
+// linkedIdentities lists the identities of a personal tenant, primary first.
+// Organizations have none.
+func (s *GatewayService) linkedIdentities(ctx context.Context, tenantId string) ([]*jennahv1.LinkedIdentity, error) {
+	tenant, err := s.dbClient.GetTenant(ctx, tenantId)
+	if err != nil {
+		log.Printf("Failed to fetch tenant: %v", err)
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	if tenant.Name != nil {
+		return nil, nil
+	}
+	identities, err := s.dbClient.ListIdentities(ctx, tenantId)
+	if err != nil {
+		log.Printf("Failed to list identities: %v", err)
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	return identitiesToProto(tenant, identities), nil
+}
+
+// identitiesToProto converts the identities of a personal tenant, listing
+// its primary identity first even when it was never recorded.
+func identitiesToProto(tenant *database.Tenant, identities []*database.Identity) []*jennahv1.LinkedIdentity {
+	result := []*jennahv1.LinkedIdentity{{
+		Provider: tenant.OAuthProvider,
+		UserId:   tenant.OAuthUserId,
+		Email:    tenant.UserEmail,
+		Primary:  true,
+	}}
+	for _, identity := range identities {
+		if isPersonalOwner(tenant, identity.OAuthProvider, identity.OAuthUserId) {
+			result[0].Email = identity.Email
+			result[0].LinkedAt = identity.LinkedAt.Format(time.RFC3339)
+			continue
+		}
+		result = append(result, &jennahv1.LinkedIdentity{
+			Provider: identity.OAuthProvider,
+			UserId:   identity.OAuthUserId,
+			Email:    identity.Email,
+			LinkedAt: identity.LinkedAt.Format(time.RFC3339),
+		})
+	}
+	return result
+}
@@ -0,0 +1,74 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+
+	jennahv1 "github.com/alphauslabs/jennah/gen/proto"
+	"github.com/alphauslabs/jennah/internal/database"
+	"github.com/alphauslabs/jennah/internal/hashing"
+	"github.com/alphauslabs/jennah/internal/userauth"
+)
+
+func TestLinkIdentityNeedsProofOfBoth(t *testing.T) {
+	sessions, err := userauth.NewSessions("0123456789abcdef0123456789abcdef", time.Hour, 24*time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	google := fakeVerifier{token: "google-token", identity: userauth.Identity{Provider: "google", UserID: "123", Email: "a@example.com"}}
+	github := fakeVerifier{token: "github-token", identity: userauth.Identity{Provider: "github", UserID: "583231", Email: "a@example.com"}}
+	s := NewGatewayService(hashing.NewRouter(nil), nil, nil, nil, nil, sessions,
+		map[string]userauth.IdentityVerifier{"google": google, "github": github})
+	token, _, err := sessions.Issue(&google.identity)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name           string
+		current, other *jennahv1.IdentityProof
+		want           connect.Code
+	}{
+		{"missing current proof", nil, &jennahv1.IdentityProof{Provider: "github", Token: "github-token"}, connect.CodeInvalidArgument},
+		{"forged current proof", &jennahv1.IdentityProof{Provider: "google", Token: "forged"}, &jennahv1.IdentityProof{Provider: "github", Token: "github-token"}, connect.CodeUnauthenticated},
+		{"current proof of another user", &jennahv1.IdentityProof{Provider: "github", Token: "github-token"}, &jennahv1.IdentityProof{Provider: "google", Token: "google-token"}, connect.CodePermissionDenied},
+		{"forged other proof", &jennahv1.IdentityProof{Provider: "google", Token: "google-token"}, &jennahv1.IdentityProof{Provider: "github", Token: "forged"}, connect.CodeUnauthenticated},
+		{"linking itself", &jennahv1.IdentityProof{Provider: "google", Token: "google-token"}, &jennahv1.IdentityProof{Provider: "google", Token: "google-token"}, connect.CodeInvalidArgument},
+	}
+	for _, tt := range tests {
+		req := connect.NewRequest(&jennahv1.LinkIdentityRequest{Current: tt.current, Other: tt.other})
+		req.Header().Set("Authorization", "Bearer "+token)
+		if _, err := s.LinkIdentity(context.Background(), req); connect.CodeOf(err) != tt.want {
+			t.Errorf("%s: err = %v, want %v", tt.name, err, tt.want)
+		}
+	}
+}
+
+func TestIdentitiesToProto(t *testing.T) {
+	linked := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	tenant := &database.Tenant{TenantId: "tenant-a", UserEmail: "old@example.com", OAuthProvider: "github", OAuthUserId: "583231"}
+
+	// A tenant created before identities were recorded still lists its
+	// primary identity.
+	got := identitiesToProto(tenant, nil)
+	if len(got) != 1 || !got[0].Primary || got[0].UserId != "583231" || got[0].LinkedAt != "" {
+		t.Fatalf("identitiesToProto(no identities) = %v", got)
+	}
+
+	got = identitiesToProto(tenant, []*database.Identity{
+		{OAuthProvider: "google", OAuthUserId: "123", TenantId: "tenant-a", Email: "a@example.com", LinkedAt: linked},
+		{OAuthProvider: "github", OAuthUserId: "583231", TenantId: "tenant-a", Email: "new@example.com", LinkedAt: linked},
+	})
+	if len(got) != 2 {
+		t.Fatalf("got %d identities, want 2", len(got))
+	}
+	if !got[0].Primary || got[0].Email != "new@example.com" || got[0].LinkedAt != "2026-10-01T12:00:00Z" {
+		t.Errorf("primary = %v", got[0])
+	}
+	if got[1].Primary || got[1].Provider != "google" || got[1].UserId != "123" {
+		t.Errorf("linked = %v", got[1])
+	}
+}
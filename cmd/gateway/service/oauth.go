@@ -2,37 +2,65 @@ package service
 
 import (
 	"context"
-	"errors"
 	"log"
 	"net/http"
+	"time"
 
 	"cloud.google.com/go/spanner"
 	"github.com/google/uuid"
 	"google.golang.org/grpc/codes"
-)
 
-func extractOAuthUser(headers http.Header) (*OAuthUser, error) {
-	email := headers.Get("X-OAuth-Email")
-	oauthUserId := headers.Get("X-OAuth-UserId")
-	provider := headers.Get("X-OAuth-Provider")
+	"github.com/alphauslabs/jennah/internal/userauth"
+)
 
-	if email == "" || oauthUserId == "" || provider == "" {
-		return nil, errors.New("missing required OAuth headers")
+// authenticate returns the user of the session token in the request's
+// Authorization header.
+func (s *GatewayService) authenticate(headers http.Header) (*OAuthUser, error) {
+	identity, err := s.sessions.Verify(userauth.BearerToken(headers))
+	if err != nil {
+		return nil, err
 	}
-
 	return &OAuthUser{
-		Email:    email,
-		UserId:   oauthUserId,
-		Provider: provider,
+		Email:    identity.Email,
+		UserId:   identity.UserID,
+		Provider: identity.Provider,
 	}, nil
 }
 
+// tenantCacheKey keys oauthToTenant; user IDs are only unique per provider.
+func tenantCacheKey(oauthUser *OAuthUser) string {
+	return oauthUser.Provider + "/" + oauthUser.UserId
+}
+
+// tenantCacheTTL bounds how long a gateway keeps sending an identity to its
+// old tenant after another gateway links or unlinks it.
+const tenantCacheTTL = time.Minute
+
+type cachedTenant struct {
+	tenantId string
+	expires  time.Time
+}
+
+// cachedTenantId returns the cached tenant of key. Callers hold s.mu.
+func (s *GatewayService) cachedTenantId(key string) (string, bool) {
+	entry, ok := s.oauthToTenant[key]
+	if !ok || time.Now().After(entry.expires) {
+		return "", false
+	}
+	return entry.tenantId, true
+}
+
+// cacheTenant caches the tenant of key. Callers hold s.mu for writing.
+func (s *GatewayService) cacheTenant(key, tenantId string) {
+	s.oauthToTenant[key] = cachedTenant{tenantId: tenantId, expires: time.Now().Add(tenantCacheTTL)}
+}
+
 func (s *GatewayService) getOrCreateTenant(oauthUser *OAuthUser) (string, error) {
 	ctx := context.Background()
 
 	// Check in-memory cache first (fast path)
 	s.mu.RLock()
-	tenantId, exists := s.oauthToTenant[oauthUser.UserId]
+	tenantId, exists := s.cachedTenantId(tenantCacheKey(oauthUser))
 	s.mu.RUnlock()
 
 	if exists {
@@ -51,7 +79,7 @@ func (s *GatewayService) getOrCreateTenant(oauthUser *OAuthUser) (string, error)
 	if tenant != nil {
 		// Found existing tenant in database
 		s.mu.Lock()
-		s.oauthToTenant[oauthUser.UserId] = tenant.TenantId
+		s.cacheTenant(tenantCacheKey(oauthUser), tenant.TenantId)
 		s.mu.Unlock()
 
 		log.Printf("Found existing tenant in database for user %s: tenantId=%s",
@@ -64,7 +92,7 @@ func (s *GatewayService) getOrCreateTenant(oauthUser *OAuthUser) (string, error)
 	defer s.mu.Unlock()
 
 	// Double-check cache after acquiring write lock
-	if tenantId, exists = s.oauthToTenant[oauthUser.UserId]; exists {
+	if tenantId, exists = s.cachedTenantId(tenantCacheKey(oauthUser)); exists {
 		log.Printf("Found tenant created by another request: tenantId=%s", tenantId)
 		return tenantId, nil
 	}
@@ -79,7 +107,7 @@ func (s *GatewayService) getOrCreateTenant(oauthUser *OAuthUser) (string, error)
 		if spanner.ErrCode(err) == codes.AlreadyExists {
 			log.Printf("Tenant %s already exists in database (created by another instance)", tenantId)
 			// Cache it and return
-			s.oauthToTenant[oauthUser.UserId] = tenantId
+			s.cacheTenant(tenantCacheKey(oauthUser), tenantId)
 			return tenantId, nil
 		}
 		log.Printf("Failed to insert tenant into database: %v", err)
@@ -87,7 +115,7 @@ func (s *GatewayService) getOrCreateTenant(oauthUser *OAuthUser) (string, error)
 	}
 
 	// Cache the OAuth -> Tenant mapping
-	s.oauthToTenant[oauthUser.UserId] = tenantId
+	s.cacheTenant(tenantCacheKey(oauthUser), tenantId)
 
 	log.Printf("Created new tenant for user %s (provider: %s): tenantId=%s (persisted to database)",
 		oauthUser.Email, oauthUser.Provider, tenantId)
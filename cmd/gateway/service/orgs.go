@@ -0,0 +1,493 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/google/uuid"
+
+	jennahv1 "github.com/alphauslabs/jennah/gen/proto"
+	"github.com/alphauslabs/jennah/internal/audit"
+	"github.com/alphauslabs/jennah/internal/database"
+	"github.com/alphauslabs/jennah/internal/ratelimit"
+	"github.com/alphauslabs/jennah/internal/userauth"
+)
+
+// OrgHeader selects the organization a session request acts on. Without it
+// the caller's personal organization is used.
+const OrgHeader = "X-Jennah-Org"
+
+// invitationTTL is how long an invitation can be accepted.
+const invitationTTL = 7 * 24 * time.Hour
+
+// caller is who made a request and the organization it acts on.
+type caller struct {
+	tenantId string
+	// user and role are unset for API keys, which act for the whole tenant
+	// within their scopes.
+	user *OAuthUser
+	role string
+}
+
+// resolveCaller authenticates a request with an API key or a session and
+// checks that it may make calls needing scope.
+func (s *GatewayService) resolveCaller(ctx context.Context, header http.Header, scope string) (*caller, error) {
+	if token := userauth.BearerToken(header); strings.HasPrefix(token, userauth.APIKeyPrefix) {
+		tenantId, err := s.resolveApiKey(ctx, token, scope)
+		if err != nil {
+			return nil, err
+		}
+		if err := actingOn(ctx, tenantId); err != nil {
+			return nil, err
+		}
+		return &caller{tenantId: tenantId}, nil
+	}
+
+	c, err := s.resolveMember(ctx, header)
+	if err != nil {
+		return nil, err
+	}
+	if !userauth.RoleAllows(c.role, scope) {
+		return nil, connect.NewError(connect.CodePermissionDenied,
+			fmt.Errorf("role %q in organization %s does not allow %q calls", c.role, c.tenantId, scope))
+	}
+	return c, nil
+}
+
+// resolveMember authenticates a session request and returns the caller's
+// role in the organization selected by OrgHeader. Users are always admins
+// of their personal organization.
+func (s *GatewayService) resolveMember(ctx context.Context, header http.Header) (*caller, error) {
+	oauthUser, err := s.authenticate(header)
+	if err != nil {
+		log.Printf("Authentication failed: %v", err)
+		return nil, connect.NewError(connect.CodeUnauthenticated, fmt.Errorf("invalid session: %w", err))
+	}
+	personal, err := s.getOrCreateTenant(oauthUser)
+	if err != nil {
+		log.Printf("Failed to get or create tenant: %v", err)
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	orgId := strings.TrimSpace(header.Get(OrgHeader))
+	if orgId == "" || orgId == personal {
+		if err := actingOn(ctx, personal); err != nil {
+			return nil, err
+		}
+		return &caller{tenantId: personal, user: oauthUser, role: userauth.RoleAdmin}, nil
+	}
+	membership, err := s.findMembership(ctx, orgId, personal, oauthUser)
+	if err != nil {
+		log.Printf("Failed to look up membership: %v", err)
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	if membership == nil {
+		return nil, connect.NewError(connect.CodePermissionDenied, fmt.Errorf("not a member of organization %s", orgId))
+	}
+	if err := actingOn(ctx, orgId); err != nil {
+		return nil, err
+	}
+	return &caller{tenantId: orgId, user: oauthUser, role: membership.Role}, nil
+}
+
+// actingOn records the tenant a call acts on for the audit log and charges
+// the call to the tenant's rate limits.
+func actingOn(ctx context.Context, tenantId string) error {
+	audit.SetTenant(ctx, tenantId)
+	return ratelimit.Charge(ctx, tenantId)
+}
+
+// findMembership returns the user's membership of an organization, or of
+// one of the identities linked to their personal tenant, or nil when they
+// are not a member.
+func (s *GatewayService) findMembership(ctx context.Context, orgId, personal string, oauthUser *OAuthUser) (*database.Membership, error) {
+	membership, err := s.dbClient.GetMembership(ctx, orgId, oauthUser.Provider, oauthUser.UserId)
+	if err != nil || membership != nil {
+		return membership, err
+	}
+	identities, err := s.dbClient.ListIdentities(ctx, personal)
+	if err != nil {
+		return nil, err
+	}
+	for _, identity := range identities {
+		if identity.OAuthProvider == oauthUser.Provider && identity.OAuthUserId == oauthUser.UserId {
+			continue
+		}
+		membership, err := s.dbClient.GetMembership(ctx, orgId, identity.OAuthProvider, identity.OAuthUserId)
+		if err != nil || membership != nil {
+			return membership, err
+		}
+	}
+	return nil, nil
+}
+
+// requireOrgAdmin resolves a session request whose caller must be an admin
+// of the active organization.
+func (s *GatewayService) requireOrgAdmin(ctx context.Context, header http.Header) (*caller, error) {
+	c, err := s.resolveMember(ctx, header)
+	if err != nil {
+		return nil, err
+	}
+	if c.role != userauth.RoleAdmin {
+		return nil, connect.NewError(connect.CodePermissionDenied,
+			fmt.Errorf("only admins of organization %s can do this", c.tenantId))
+	}
+	return c, nil
+}
+
+// CreateOrganization creates an organization with the caller as its admin.
+func (s *GatewayService) CreateOrganization(
+	ctx context.Context,
+	req *connect.Request[jennahv1.CreateOrganizationRequest],
+) (*connect.Response[jennahv1.CreateOrganizationResponse], error) {
+	oauthUser, err := s.authenticate(req.Header())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, fmt.Errorf("invalid session: %w", err))
+	}
+	name := strings.TrimSpace(req.Msg.Name)
+	if name == "" || len(name) > 255 {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("name must be 1 to 255 characters"))
+	}
+
+	tenantId := uuid.New().String()
+	audit.SetTenant(ctx, tenantId)
+	creator := &database.Membership{
+		OAuthProvider: oauthUser.Provider,
+		OAuthUserId:   oauthUser.UserId,
+		Email:         oauthUser.Email,
+	}
+	if err := s.dbClient.InsertOrganization(ctx, tenantId, name, creator); err != nil {
+		log.Printf("Failed to create organization: %v", err)
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	log.Printf("Created organization %s (%s) for %s", tenantId, name, oauthUser.Email)
+	return connect.NewResponse(&jennahv1.CreateOrganizationResponse{
+		Organization: &jennahv1.Organization{
+			OrgId:     tenantId,
+			Name:      name,
+			Role:      userauth.RoleAdmin,
+			CreatedAt: time.Now().UTC().Format(time.RFC3339),
+		},
+	}), nil
+}
+
+// ListOrganizations lists the caller's organizations, personal one first.
+func (s *GatewayService) ListOrganizations(
+	ctx context.Context,
+	req *connect.Request[jennahv1.ListOrganizationsRequest],
+) (*connect.Response[jennahv1.ListOrganizationsResponse], error) {
+	oauthUser, err := s.authenticate(req.Header())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, fmt.Errorf("invalid session: %w", err))
+	}
+	personal, err := s.getOrCreateTenant(oauthUser)
+	if err != nil {
+		log.Printf("Failed to get or create tenant: %v", err)
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	orgs, err := s.dbClient.ListUserOrganizations(ctx, oauthUser.Provider, oauthUser.UserId)
+	if err != nil {
+		log.Printf("Failed to list organizations: %v", err)
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	response := &jennahv1.ListOrganizationsResponse{
+		Organizations: []*jennahv1.Organization{{OrgId: personal, Role: userauth.RoleAdmin}},
+	}
+	for _, o := range orgs {
+		if o.TenantId == personal {
+			response.Organizations[0].CreatedAt = o.CreatedAt.Format(time.RFC3339)
+			continue
+		}
+		org := &jennahv1.Organization{OrgId: o.TenantId, Role: o.Role, CreatedAt: o.CreatedAt.Format(time.RFC3339)}
+		if o.Name != nil {
+			org.Name = *o.Name
+		}
+		response.Organizations = append(response.Organizations, org)
+	}
+	return connect.NewResponse(response), nil
+}
+
+// ListMembers lists the members of the active organization.
+func (s *GatewayService) ListMembers(
+	ctx context.Context,
+	req *connect.Request[jennahv1.ListMembersRequest],
+) (*connect.Response[jennahv1.ListMembersResponse], error) {
+	c, err := s.resolveMember(ctx, req.Header())
+	if err != nil {
+		return nil, err
+	}
+	members, err := s.dbClient.ListMemberships(ctx, c.tenantId)
+	if err != nil {
+		log.Printf("Failed to list members: %v", err)
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	response := &jennahv1.ListMembersResponse{}
+	for _, m := range members {
+		response.Members = append(response.Members, dbMembershipToProto(m))
+	}
+	return connect.NewResponse(response), nil
+}
+
+// UpdateMemberRole changes the role of a member of the active organization.
+func (s *GatewayService) UpdateMemberRole(
+	ctx context.Context,
+	req *connect.Request[jennahv1.UpdateMemberRoleRequest],
+) (*connect.Response[jennahv1.UpdateMemberRoleResponse], error) {
+	c, err := s.requireOrgAdmin(ctx, req.Header())
+	if err != nil {
+		return nil, err
+	}
+	if !userauth.ValidRole(req.Msg.Role) {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("unknown role %q", req.Msg.Role))
+	}
+	if err := s.checkNotPersonalOwner(ctx, c.tenantId, req.Msg.OauthProvider, req.Msg.OauthUserId); err != nil {
+		return nil, err
+	}
+
+	m, err := s.dbClient.UpdateMembershipRole(ctx, c.tenantId, req.Msg.OauthProvider, req.Msg.OauthUserId, req.Msg.Role)
+	if errors.Is(err, database.ErrLastAdmin) {
+		return nil, connect.NewError(connect.CodeFailedPrecondition, database.ErrLastAdmin)
+	}
+	if err != nil {
+		log.Printf("Failed to update member role: %v", err)
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	if m == nil {
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("%s user %s is not a member", req.Msg.OauthProvider, req.Msg.OauthUserId))
+	}
+
+	log.Printf("%s set the role of %s in organization %s to %s", c.user.Email, m.Email, c.tenantId, m.Role)
+	return connect.NewResponse(&jennahv1.UpdateMemberRoleResponse{Member: dbMembershipToProto(m)}), nil
+}
+
+// RemoveMember removes a member from the active organization. Members may
+// remove themselves; removing others needs an admin.
+func (s *GatewayService) RemoveMember(
+	ctx context.Context,
+	req *connect.Request[jennahv1.RemoveMemberRequest],
+) (*connect.Response[jennahv1.RemoveMemberResponse], error) {
+	c, err := s.resolveMember(ctx, req.Header())
+	if err != nil {
+		return nil, err
+	}
+	self := req.Msg.OauthProvider == c.user.Provider && req.Msg.OauthUserId == c.user.UserId
+	if !self && c.role != userauth.RoleAdmin {
+		return nil, connect.NewError(connect.CodePermissionDenied,
+			fmt.Errorf("only admins of organization %s can remove other members", c.tenantId))
+	}
+	if err := s.checkNotPersonalOwner(ctx, c.tenantId, req.Msg.OauthProvider, req.Msg.OauthUserId); err != nil {
+		return nil, err
+	}
+
+	deleted, err := s.dbClient.DeleteMembership(ctx, c.tenantId, req.Msg.OauthProvider, req.Msg.OauthUserId)
+	if errors.Is(err, database.ErrLastAdmin) {
+		return nil, connect.NewError(connect.CodeFailedPrecondition, database.ErrLastAdmin)
+	}
+	if err != nil {
+		log.Printf("Failed to remove member: %v", err)
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	if !deleted {
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("%s user %s is not a member", req.Msg.OauthProvider, req.Msg.OauthUserId))
+	}
+
+	log.Printf("%s removed %s user %s from organization %s", c.user.Email, req.Msg.OauthProvider, req.Msg.OauthUserId, c.tenantId)
+	return connect.NewResponse(&jennahv1.RemoveMemberResponse{}), nil
+}
+
+// checkNotPersonalOwner fails when the user owns tenantId as their personal
+// organization; owners always stay admins of it.
+func (s *GatewayService) checkNotPersonalOwner(ctx context.Context, tenantId, oauthProvider, oauthUserId string) error {
+	tenant, err := s.dbClient.GetTenant(ctx, tenantId)
+	if err != nil {
+		log.Printf("Failed to fetch tenant: %v", err)
+		return connect.NewError(connect.CodeInternal, err)
+	}
+	if isPersonalOwner(tenant, oauthProvider, oauthUserId) {
+		return connect.NewError(connect.CodeFailedPrecondition,
+			errors.New("the owner of a personal organization cannot be demoted or removed"))
+	}
+	return nil
+}
+
+// isPersonalOwner reports whether tenant is the personal organization of
+// the given user.
+func isPersonalOwner(tenant *database.Tenant, oauthProvider, oauthUserId string) bool {
+	return tenant.Name == nil && tenant.OAuthProvider == oauthProvider && tenant.OAuthUserId == oauthUserId
+}
+
+// InviteMember invites an email address to the active organization.
+func (s *GatewayService) InviteMember(
+	ctx context.Context,
+	req *connect.Request[jennahv1.InviteMemberRequest],
+) (*connect.Response[jennahv1.InviteMemberResponse], error) {
+	c, err := s.requireOrgAdmin(ctx, req.Header())
+	if err != nil {
+		return nil, err
+	}
+	email, role, err := invitationFields(req.Msg.Email, req.Msg.Role)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	now := time.Now().UTC()
+	inv := &database.Invitation{
+		TenantId:     c.tenantId,
+		InvitationId: uuid.New().String(),
+		Email:        email,
+		Role:         role,
+		InvitedBy:    c.user.Email,
+		CreatedAt:    now,
+		ExpiresAt:    now.Add(invitationTTL),
+	}
+	if err := s.dbClient.InsertInvitation(ctx, inv); err != nil {
+		log.Printf("Failed to create invitation: %v", err)
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	log.Printf("%s invited %s to organization %s as %s", c.user.Email, email, c.tenantId, role)
+	return connect.NewResponse(&jennahv1.InviteMemberResponse{
+		Invitation: dbInvitationToProto(inv, s.organizationName(ctx, c.tenantId)),
+	}), nil
+}
+
+// invitationFields validates and normalizes the email and role of a new
+// invitation. The role defaults to viewer.
+func invitationFields(email, role string) (string, string, error) {
+	email = strings.ToLower(strings.TrimSpace(email))
+	if local, domain, ok := strings.Cut(email, "@"); !ok || local == "" || domain == "" {
+		return "", "", fmt.Errorf("invalid email %q", email)
+	}
+	if role == "" {
+		role = userauth.RoleViewer
+	}
+	if !userauth.ValidRole(role) {
+		return "", "", fmt.Errorf("unknown role %q", role)
+	}
+	return email, role, nil
+}
+
+// ListInvitations lists the pending invitations to the caller's email.
+func (s *GatewayService) ListInvitations(
+	ctx context.Context,
+	req *connect.Request[jennahv1.ListInvitationsRequest],
+) (*connect.Response[jennahv1.ListInvitationsResponse], error) {
+	oauthUser, err := s.authenticate(req.Header())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, fmt.Errorf("invalid session: %w", err))
+	}
+	invitations, err := s.dbClient.ListPendingInvitations(ctx, strings.ToLower(oauthUser.Email), time.Now().UTC())
+	if err != nil {
+		log.Printf("Failed to list invitations: %v", err)
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	response := &jennahv1.ListInvitationsResponse{}
+	for _, inv := range invitations {
+		response.Invitations = append(response.Invitations, dbInvitationToProto(inv, s.organizationName(ctx, inv.TenantId)))
+	}
+	return connect.NewResponse(response), nil
+}
+
+// AcceptInvitation makes the caller a member of the organization that
+// invited their email.
+func (s *GatewayService) AcceptInvitation(
+	ctx context.Context,
+	req *connect.Request[jennahv1.AcceptInvitationRequest],
+) (*connect.Response[jennahv1.AcceptInvitationResponse], error) {
+	oauthUser, err := s.authenticate(req.Header())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, fmt.Errorf("invalid session: %w", err))
+	}
+	inv, err := s.dbClient.GetInvitation(ctx, req.Msg.InvitationId)
+	if err != nil {
+		log.Printf("Failed to fetch invitation: %v", err)
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	if inv == nil || inv.Email != strings.ToLower(oauthUser.Email) {
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("no invitation %s for %s", req.Msg.InvitationId, oauthUser.Email))
+	}
+	audit.SetTenant(ctx, inv.TenantId)
+	if !time.Now().Before(inv.ExpiresAt) {
+		return nil, connect.NewError(connect.CodeFailedPrecondition, fmt.Errorf("invitation %s has expired", inv.InvitationId))
+	}
+
+	m, err := s.dbClient.AcceptInvitation(ctx, inv, &database.Membership{
+		OAuthProvider: oauthUser.Provider,
+		OAuthUserId:   oauthUser.UserId,
+		Email:         oauthUser.Email,
+	})
+	if errors.Is(err, database.ErrInvitationAccepted) {
+		return nil, connect.NewError(connect.CodeFailedPrecondition, database.ErrInvitationAccepted)
+	}
+	if err != nil {
+		log.Printf("Failed to accept invitation: %v", err)
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	log.Printf("%s joined organization %s as %s", oauthUser.Email, inv.TenantId, m.Role)
+	tenant, err := s.dbClient.GetTenant(ctx, inv.TenantId)
+	if err != nil {
+		log.Printf("Failed to fetch tenant: %v", err)
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	return connect.NewResponse(&jennahv1.AcceptInvitationResponse{
+		Organization: dbTenantToOrganization(tenant, m.Role),
+	}), nil
+}
+
+// organizationName returns the name of a tenant, or "" for personal
+// tenants and when it cannot be read.
+func (s *GatewayService) organizationName(ctx context.Context, tenantId string) string {
+	tenant, err := s.dbClient.GetTenant(ctx, tenantId)
+	if err != nil {
+		log.Printf("Warning: failed to fetch name of organization %s: %v", tenantId, err)
+		return ""
+	}
+	if tenant.Name == nil {
+		return ""
+	}
+	return *tenant.Name
+}
+
+func dbTenantToOrganization(t *database.Tenant, role string) *jennahv1.Organization {
+	org := &jennahv1.Organization{
+		OrgId:     t.TenantId,
+		Role:      role,
+		CreatedAt: t.CreatedAt.Format(time.RFC3339),
+	}
+	if t.Name != nil {
+		org.Name = *t.Name
+	}
+	return org
+}
+
+func dbMembershipToProto(m *database.Membership) *jennahv1.Member {
+	return &jennahv1.Member{
+		Email:         m.Email,
+		OauthProvider: m.OAuthProvider,
+		OauthUserId:   m.OAuthUserId,
+		Role:          m.Role,
+		JoinedAt:      m.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+func dbInvitationToProto(inv *database.Invitation, orgName string) *jennahv1.Invitation {
+	return &jennahv1.Invitation{
+		InvitationId: inv.InvitationId,
+		OrgId:        inv.TenantId,
+		OrgName:      orgName,
+		Email:        inv.Email,
+		Role:         inv.Role,
+		InvitedBy:    inv.InvitedBy,
+		CreatedAt:    inv.CreatedAt.Format(time.RFC3339),
+		ExpiresAt:    inv.ExpiresAt.Format(time.RFC3339),
+	}
+}
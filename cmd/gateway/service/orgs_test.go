@@ -0,0 +1,69 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/alphauslabs/jennah/internal/database"
+	"github.com/alphauslabs/jennah/internal/hashing"
+	"github.com/alphauslabs/jennah/internal/userauth"
+)
+
+func TestResolveMemberPersonalOrganization(t *testing.T) {
+	sessions, err := userauth.NewSessions("0123456789abcdef0123456789abcdef", time.Hour, 24*time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := NewGatewayService(hashing.NewRouter(nil), nil, nil, nil, nil, sessions, nil)
+	s.cacheTenant("github/583231", "tenant-a")
+	token, _, err := sessions.Issue(&userauth.Identity{Provider: "github", UserID: "583231", Email: "octo@example.com"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, org := range []string{"", "tenant-a"} {
+		header := http.Header{}
+		header.Set("Authorization", "Bearer "+token)
+		header.Set(OrgHeader, org)
+		c, err := s.resolveCaller(context.Background(), header, userauth.ScopeCancel)
+		if err != nil {
+			t.Fatalf("org %q: resolveCaller: %v", org, err)
+		}
+		if c.tenantId != "tenant-a" || c.role != userauth.RoleAdmin || c.user.Email != "octo@example.com" {
+			t.Errorf("org %q: caller = %+v, want admin of tenant-a", org, c)
+		}
+	}
+}
+
+func TestInvitationFields(t *testing.T) {
+	email, role, err := invitationFields("  Alice@Example.com ", "")
+	if err != nil || email != "alice@example.com" || role != userauth.RoleViewer {
+		t.Errorf("invitationFields = %q, %q, %v; want alice@example.com, viewer", email, role, err)
+	}
+	if _, _, err := invitationFields("alice@example.com", "owner"); err == nil {
+		t.Error("invitationFields accepted an unknown role")
+	}
+	for _, bad := range []string{"", "alice", "@example.com", "alice@"} {
+		if _, _, err := invitationFields(bad, userauth.RoleAdmin); err == nil {
+			t.Errorf("invitationFields accepted email %q", bad)
+		}
+	}
+}
+
+func TestIsPersonalOwner(t *testing.T) {
+	name := "Platform team"
+	personal := &database.Tenant{OAuthProvider: "github", OAuthUserId: "583231"}
+	org := &database.Tenant{OAuthProvider: "github", OAuthUserId: "583231", Name: &name}
+
+	if !isPersonalOwner(personal, "github", "583231") {
+		t.Error("owner of a personal tenant not recognized")
+	}
+	if isPersonalOwner(personal, "google", "583231") {
+		t.Error("user of another provider treated as owner")
+	}
+	if isPersonalOwner(org, "github", "583231") {
+		t.Error("creator of an organization treated as personal owner")
+	}
+}
@@ -1,31 +1,65 @@
 package service
 
 import (
+	"strings"
 	"sync"
 
 	jennahv1connect "github.com/alphauslabs/jennah/gen/proto/jennahv1connect"
 	"github.com/alphauslabs/jennah/internal/database"
 	"github.com/alphauslabs/jennah/internal/hashing"
+	"github.com/alphauslabs/jennah/internal/userauth"
 )
 
 type GatewayService struct {
 	jennahv1connect.UnimplementedDeploymentServiceHandler
+	jennahv1connect.UnimplementedAdminServiceHandler
+	jennahv1connect.UnimplementedAuthServiceHandler
 	router        *hashing.Router
+	clientsMu     sync.RWMutex
 	workerClients map[string]jennahv1connect.DeploymentServiceClient
+	adminClients  map[string]jennahv1connect.AdminServiceClient
+	// workerAddrs maps the IDs of registered ring members to their address.
+	workerAddrs   map[string]string
+	health        *healthTracker
+	adminEmails   map[string]bool
 	dbClient      *database.Client
 	mu            sync.RWMutex
-	oauthToTenant map[string]string
+	oauthToTenant map[string]cachedTenant
+	// apiKeys looks up the API keys requests carry; it is dbClient outside
+	// tests.
+	apiKeys apiKeyStore
+
+	sessions *userauth.Sessions
+	// identityVerifiers maps provider names accepted by CreateSession to
+	// the verifier of their tokens.
+	identityVerifiers map[string]userauth.IdentityVerifier
 }
 
 func NewGatewayService(
 	router *hashing.Router,
 	workerClients map[string]jennahv1connect.DeploymentServiceClient,
+	adminClients map[string]jennahv1connect.AdminServiceClient,
+	adminEmails []string,
 	dbClient *database.Client,
+	sessions *userauth.Sessions,
+	identityVerifiers map[string]userauth.IdentityVerifier,
 ) *GatewayService {
+	admins := make(map[string]bool, len(adminEmails))
+	for _, email := range adminEmails {
+		admins[strings.ToLower(email)] = true
+	}
 	return &GatewayService{
 		router:        router,
 		workerClients: workerClients,
+		adminClients:  adminClients,
+		workerAddrs:   make(map[string]string),
+		health:        newHealthTracker(),
+		adminEmails:   admins,
 		dbClient:      dbClient,
-		oauthToTenant: make(map[string]string),
+		oauthToTenant: make(map[string]cachedTenant),
+		apiKeys:       dbClient,
+
+		sessions:          sessions,
+		identityVerifiers: identityVerifiers,
 	}
 }
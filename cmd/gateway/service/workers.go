@@ -0,0 +1,171 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	jennahv1connect "github.com/alphauslabs/jennah/gen/proto/jennahv1connect"
+	"github.com/alphauslabs/jennah/internal/database"
+	"github.com/alphauslabs/jennah/internal/hashing"
+)
+
+// WorkerDialer creates the clients for a worker at address (host:port).
+type WorkerDialer func(address string) (jennahv1connect.DeploymentServiceClient, jennahv1connect.AdminServiceClient)
+
+// WorkerWatchOptions configures WatchWorkers.
+type WorkerWatchOptions struct {
+	// Interval is how often the Workers table is read.
+	Interval time.Duration
+	// HeartbeatTTL is how old a worker's last heartbeat may be before it
+	// leaves the ring.
+	HeartbeatTTL time.Duration
+	// Dial creates clients for workers that join.
+	Dial WorkerDialer
+}
+
+// WatchWorkers keeps the ring in step with the Workers table until ctx is
+// done: ACTIVE workers with a recent heartbeat are members, weighted by their
+// capacity, and DRAINING or silent workers are removed. The first refresh
+// runs before WatchWorkers returns; a failed read keeps the current ring.
+func (s *GatewayService) WatchWorkers(ctx context.Context, opts WorkerWatchOptions) error {
+	if err := s.refreshWorkers(ctx, opts); err != nil {
+		return err
+	}
+	go func() {
+		ticker := time.NewTicker(opts.Interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := s.refreshWorkers(ctx, opts); err != nil {
+					log.Printf("Worker registry refresh failed, keeping current ring: %v", err)
+				}
+			}
+		}
+	}()
+	return nil
+}
+
+// refreshWorkers reads the registry, records where each live worker can be
+// reached, and rebuilds the ring when its members changed.
+func (s *GatewayService) refreshWorkers(ctx context.Context, opts WorkerWatchOptions) error {
+	workers, err := s.dbClient.ListWorkers(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list workers: %w", err)
+	}
+	now := time.Now().UTC()
+	members := liveMembers(workers, now, opts.HeartbeatTTL)
+	current := s.router.Members()
+
+	addrs := make(map[string]string, len(members))
+	for _, w := range workers {
+		if w.State == database.WorkerStateActive && w.Alive(now, opts.HeartbeatTTL) {
+			addrs[w.WorkerId] = w.Address
+		}
+	}
+	s.clientsMu.Lock()
+	s.workerAddrs = addrs
+	s.clientsMu.Unlock()
+
+	if sameMembers(current, members) {
+		return nil
+	}
+	if len(members) == 0 {
+		// An empty ring would reject every request; keep routing to the
+		// last known workers until one heartbeats again.
+		log.Printf("WARNING: no live workers in the registry (%d registered), keeping %d current member(s)", len(workers), len(current))
+		return nil
+	}
+
+	s.clientsMu.Lock()
+	live := make(map[string]bool, len(members))
+	for _, m := range members {
+		live[m.Name] = true
+		if _, ok := s.workerClients[m.Name]; !ok {
+			s.workerClients[m.Name], s.adminClients[m.Name] = opts.Dial(m.Name)
+		}
+	}
+	s.clientsMu.Unlock()
+
+	moves := s.router.Update(members)
+	log.Printf("Worker ring updated: %s → %s; %d/%d partition(s) moved%s",
+		formatMembers(current), formatMembers(members), len(moves), hashing.PartitionCount, formatMoves(moves))
+
+	// Requests that located a removed worker before the update may still
+	// use its client, so clients are dropped only after the ring changed.
+	s.clientsMu.Lock()
+	for name := range s.workerClients {
+		if !live[name] {
+			delete(s.workerClients, name)
+			delete(s.adminClients, name)
+		}
+	}
+	s.clientsMu.Unlock()
+	return nil
+}
+
+// liveMembers returns the ring members for workers, sorted by address: the
+// ACTIVE workers whose heartbeat is no older than ttl, weighted by capacity.
+func liveMembers(workers []*database.Worker, now time.Time, ttl time.Duration) []hashing.WeightedMember {
+	var members []hashing.WeightedMember
+	for _, w := range workers {
+		if w.State != database.WorkerStateActive || !w.Alive(now, ttl) || w.Address == "" {
+			continue
+		}
+		members = append(members, hashing.WeightedMember{Name: w.Address, Weight: int(w.Capacity)})
+	}
+	sort.Slice(members, func(i, j int) bool { return members[i].Name < members[j].Name })
+	return members
+}
+
+func sameMembers(a, b []hashing.WeightedMember) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func formatMembers(members []hashing.WeightedMember) string {
+	names := make([]string, 0, len(members))
+	for _, m := range members {
+		names = append(names, fmt.Sprintf("%s(w%d)", m.Name, m.Weight))
+	}
+	return "[" + strings.Join(names, " ") + "]"
+}
+
+// formatMoves groups moved partitions by their old and new owner.
+func formatMoves(moves []hashing.PartitionMove) string {
+	groups := make(map[string][]string)
+	var keys []string
+	for _, m := range moves {
+		key := fmt.Sprintf("%s → %s", orNone(m.From), orNone(m.To))
+		if _, ok := groups[key]; !ok {
+			keys = append(keys, key)
+		}
+		groups[key] = append(groups[key], fmt.Sprint(m.Partition))
+	}
+	sort.Strings(keys)
+	var b strings.Builder
+	for _, key := range keys {
+		fmt.Fprintf(&b, "; %s: %s", key, strings.Join(groups[key], ","))
+	}
+	return b.String()
+}
+
+func orNone(s string) string {
+	if s == "" {
+		return "(none)"
+	}
+	return s
+}
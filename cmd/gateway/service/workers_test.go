@@ -0,0 +1,37 @@
+package service
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alphauslabs/jennah/internal/database"
+	"github.com/alphauslabs/jennah/internal/hashing"
+)
+
+func TestLiveMembers(t *testing.T) {
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	workers := []*database.Worker{
+		{WorkerId: "w2", Address: "10.0.0.2:8081", Capacity: 2, State: database.WorkerStateActive, LastHeartbeatAt: now.Add(-5 * time.Second)},
+		{WorkerId: "w1", Address: "10.0.0.1:8081", Capacity: 1, State: database.WorkerStateActive, LastHeartbeatAt: now},
+		{WorkerId: "w3", Address: "10.0.0.3:8081", Capacity: 1, State: database.WorkerStateDraining, LastHeartbeatAt: now},
+		{WorkerId: "w4", Address: "10.0.0.4:8081", Capacity: 1, State: database.WorkerStateActive, LastHeartbeatAt: now.Add(-time.Minute)},
+	}
+
+	got := liveMembers(workers, now, 30*time.Second)
+	want := []hashing.WeightedMember{{Name: "10.0.0.1:8081", Weight: 1}, {Name: "10.0.0.2:8081", Weight: 2}}
+	if !sameMembers(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestFormatMoves_GroupsByOwners(t *testing.T) {
+	got := formatMoves([]hashing.PartitionMove{
+		{Partition: 3, From: "a", To: "c"},
+		{Partition: 9, From: "b", To: ""},
+		{Partition: 7, From: "a", To: "c"},
+	})
+	if !strings.Contains(got, "a → c: 3,7") || !strings.Contains(got, "b → (none): 9") {
+		t.Errorf("got %q", got)
+	}
+}
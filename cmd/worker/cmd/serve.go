@@ -2,27 +2,38 @@ package cmd
 
 import (
 	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
+	"runtime/debug"
 	"strconv"
+	"strings"
 	"syscall"
 	"time"
 
 	gcpbatch "cloud.google.com/go/batch/apiv1"
 	"cloud.google.com/go/pubsub"
+	"connectrpc.com/connect"
 	"github.com/spf13/cobra"
 
 	"github.com/alphauslabs/jennah/cmd/worker/service"
+	jennahv1 "github.com/alphauslabs/jennah/gen/proto"
 	"github.com/alphauslabs/jennah/gen/proto/jennahv1connect"
 	batch "github.com/alphauslabs/jennah/internal/cloudexec"
-	_ "github.com/alphauslabs/jennah/internal/cloudexec/gcp" // Register GCP providers (Cloud Batch, Cloud Run)
+	"github.com/alphauslabs/jennah/internal/cloudexec/gcp" // Register GCP providers (Cloud Batch, Cloud Run)
+	"github.com/alphauslabs/jennah/internal/cloudexec/middleware"
 	"github.com/alphauslabs/jennah/internal/config"
 	"github.com/alphauslabs/jennah/internal/database"
 	"github.com/alphauslabs/jennah/internal/dispatcher"
 	"github.com/alphauslabs/jennah/internal/notifier"
+	"github.com/alphauslabs/jennah/internal/router"
+	"github.com/alphauslabs/jennah/internal/svcauth"
 )
 
 var serveCmd = &cobra.Command{
@@ -55,12 +66,17 @@ func runServe(cmd *cobra.Command, args []string) error {
 		cfg.Database.ProjectID, cfg.Database.Instance, cfg.Database.Database)
 
 	// Initialize batch provider (Cloud Batch — always required for COMPLEX jobs).
-	batchProvider, err := batch.NewProvider(ctx, cfg.BatchProvider)
+	// One client is created per region in BATCH_REGIONS; the pool fails over
+	// between them when a region is out of capacity or quota, or unavailable.
+	// Each regional client retries transient errors and has its own rate
+	// limit and circuit breaker.
+	guarded := &guardedProviders{}
+	batchProvider, err := newProviderPool(ctx, cfg.BatchProvider, cfg.BatchRegions, guarded)
 	if err != nil {
 		return fmt.Errorf("failed to create batch provider: %w", err)
 	}
-	log.Printf("Initialized %s batch provider in region: %s",
-		cfg.BatchProvider.Provider, cfg.BatchProvider.Region)
+	log.Printf("Initialized %s batch provider in regions: %v",
+		cfg.BatchProvider.Provider, batchProvider.Regions())
 
 	// Initialize dispatcher with all available GCP service providers.
 	dispatcherOpts := []dispatcher.Option{
@@ -77,13 +93,13 @@ func runServe(cmd *cobra.Command, args []string) error {
 			Region:          cfg.CloudRun.Region,
 			ProviderOptions: make(map[string]string),
 		}
-		crProvider, err := batch.NewProvider(ctx, crConfig)
+		crProvider, err := newProviderPool(ctx, crConfig, cfg.CloudRun.Regions, guarded)
 		if err != nil {
 			log.Printf("Warning: failed to create Cloud Run Jobs provider: %v (SIMPLE jobs will fail)", err)
 			log.Printf("Warning: failed to create Cloud Run Jobs provider: %v (SIMPLE jobs will fail)", err)
 		} else {
 			dispatcherOpts = append(dispatcherOpts, dispatcher.WithCloudRunJobs(crProvider))
-			log.Printf("Initialized Cloud Run Jobs provider in region: %s", cfg.CloudRun.Region)
+			log.Printf("Initialized Cloud Run Jobs provider in regions: %v", crProvider.Regions())
 		}
 	} else {
 		log.Println("WARNING: Cloud Run Jobs provider not configured (set CLOUD_RUN_ENABLED=true) — SIMPLE jobs will be rejected")
@@ -154,29 +170,84 @@ func runServe(cmd *cobra.Command, args []string) error {
 	leaseTTL := time.Duration(leaseTTLSeconds) * time.Second
 	claimInterval := time.Duration(claimIntervalSeconds) * time.Second
 
-	workerService := service.NewWorkerService(dbClient, batchProvider, d, jobConfig, gcpBatchClient, workerID, leaseTTL, claimInterval, jobNotifier)
+	workerService := service.NewWorkerService(dbClient, batchProvider, d, jobConfig, gcpBatchClient, workerID, leaseTTL, claimInterval, jobNotifier, cfg.Environment)
 	log.Printf("Worker identity: %s (lease_ttl=%s, claim_interval=%s)", workerID, leaseTTL, claimInterval)
 
+	registration, err := workerRegistration(workerID, cfg.ServerPort)
+	if err != nil {
+		return err
+	}
+	workerService.SetRegistration(registration)
+
+	auth, err := serviceAuth(workerID)
+	if err != nil {
+		return err
+	}
+	workerService.SetPeerDialer(auth.dialPeer)
+
+	rulesPolicy, rulesPath, err := routingRules()
+	if err != nil {
+		return err
+	}
+	policies, classifier, err := routingPolicies(rulesPolicy, d.Candidates())
+	if err != nil {
+		return err
+	}
+	guarded.classifier = classifier
+	workerService.SetRoutingPolicies(policies)
+	names := make([]string, 0, len(policies))
+	for _, p := range policies {
+		names = append(names, p.Name())
+	}
+	log.Printf("Routing policies: %s", strings.Join(names, " → "))
+
+	// Subscribe to Cloud Batch state change notifications; polling then
+	// only runs as a slow safety net for Cloud Batch jobs.
+	statusCtx, stopStatus := context.WithCancel(ctx)
+	defer stopStatus()
+	if cfg.PubSub.BatchStatusTopic != "" {
+		statusClient, err := pubsub.NewClient(ctx, cfg.PubSub.ProjectID)
+		if err != nil {
+			return fmt.Errorf("failed to create Pub/Sub client for status notifications: %w", err)
+		}
+		defer statusClient.Close()
+		subID := fmt.Sprintf("%s-%s", cfg.PubSub.BatchStatusTopic, workerID)
+		sub, err := gcp.EnsureBatchStatusSubscription(ctx, statusClient, cfg.PubSub.BatchStatusTopic, subID)
+		if err != nil {
+			return fmt.Errorf("failed to subscribe to Cloud Batch status notifications: %w", err)
+		}
+		safetyPoll := time.Duration(getEnvAsIntOrDefault("WORKER_STATUS_SAFETY_POLL_SECONDS", 60)) * time.Second
+		workerService.StartStatusSubscriber(statusCtx, gcp.NewBatchStatusSubscriber(sub), safetyPoll)
+		log.Printf("Receiving Cloud Batch status notifications via %s (safety poll every %s)", subID, safetyPoll)
+	} else {
+		log.Println("Cloud Batch status notifications disabled (set BATCH_STATUS_TOPIC to enable); polling every job")
+	}
+
 	// Resume polling for active jobs from before restart.
 	if err := service.ResumeActiveJobPollers(ctx, workerService, dbClient); err != nil {
 		log.Printf("Warning: failed to resume job pollers on startup: %v", err)
 	}
 
+	var handlerOpts []connect.HandlerOption
+	if auth.interceptor != nil {
+		handlerOpts = append(handlerOpts, connect.WithInterceptors(auth.interceptor))
+	}
 	mux := http.NewServeMux()
-	path, handler := jennahv1connect.NewDeploymentServiceHandler(workerService)
+	path, handler := jennahv1connect.NewDeploymentServiceHandler(workerService, handlerOpts...)
 	mux.Handle(path, handler)
 	log.Printf("ConnectRPC handler registered at path: %s", path)
+	adminPath, adminHandler := jennahv1connect.NewAdminServiceHandler(workerService, handlerOpts...)
+	mux.Handle(adminPath, adminHandler)
+	log.Printf("Admin handler registered at path: %s", adminPath)
 
-	mux.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
-		w.WriteHeader(http.StatusOK)
-		w.Write([]byte("OK"))
-	})
+	mux.HandleFunc("/health", guarded.serveHealth)
 	log.Println("Health check endpoint: /health")
 
 	addr := fmt.Sprintf("0.0.0.0:%s", cfg.ServerPort)
 	server := &http.Server{
-		Addr:    addr,
-		Handler: mux,
+		Addr:      addr,
+		Handler:   mux,
+		TLSConfig: auth.serverTLS,
 	}
 
 	sigCtx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
@@ -184,17 +255,41 @@ func runServe(cmd *cobra.Command, args []string) error {
 
 	workerService.StartLeaseReconciler(sigCtx)
 
+	if rulesPolicy != nil {
+		reload := time.Duration(getEnvAsIntOrDefault("ROUTING_RULES_RELOAD_SECONDS", 30)) * time.Second
+		go rulesPolicy.Watch(sigCtx, rulesPath, reload)
+	}
+
+	reconcileOpts, reconcileInterval, err := resourceReconcileConfig()
+	if err != nil {
+		return err
+	}
+	if reconcileInterval > 0 {
+		workerService.StartResourceReconciler(sigCtx, reconcileInterval, reconcileOpts)
+		log.Printf("Resource reconciliation every %s (orphan_action=%s, fail_missing=%v)",
+			reconcileInterval, reconcileOpts.OrphanAction, reconcileOpts.FailMissing)
+	} else {
+		log.Println("Periodic resource reconciliation disabled")
+	}
+
 	go func() {
 		log.Printf("Worker listening on %s", addr)
 		log.Println("Available endpoints:")
 		log.Printf("  • POST %sSubmitJob", path)
 		log.Printf("  • POST %sListJobs", path)
+		log.Printf("  • POST %sReconcileResources", adminPath)
 		log.Printf("  • GET  /health")
 		log.Printf("Worker configured for provider: %s, region: %s",
 			cfg.BatchProvider.Provider, cfg.BatchProvider.Region)
 		log.Println("")
 
-		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		var err error
+		if server.TLSConfig != nil {
+			err = server.ListenAndServeTLS("", "")
+		} else {
+			err = server.ListenAndServe()
+		}
+		if err != nil && err != http.ErrServerClosed {
 			log.Fatalf("Failed to start server: %v", err)
 		}
 	}()
@@ -202,7 +297,13 @@ func runServe(cmd *cobra.Command, args []string) error {
 	<-sigCtx.Done()
 	log.Println("Shutdown signal received, gracefully shutting down...")
 
+	// Leave the gateways' rings before refusing requests.
+	drainCtx, cancelDrain := context.WithTimeout(context.Background(), 5*time.Second)
+	workerService.Drain(drainCtx)
+	cancelDrain()
+
 	// Stop all active job pollers.
+	stopStatus()
 	workerService.StopAllPollers()
 
 	shutdownCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
@@ -216,6 +317,311 @@ func runServe(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// newProviderPool creates one provider per region from base, wraps each with
+// retries, rate limiting and a circuit breaker, and pools them in preference
+// order. The wrapped providers are added to guarded.
+func newProviderPool(ctx context.Context, base batch.ProviderConfig, regions []string, guarded *guardedProviders) (*dispatcher.ProviderPool, error) {
+	if len(regions) == 0 {
+		regions = []string{base.Region}
+	}
+	members := make([]dispatcher.PoolMember, 0, len(regions))
+	for _, region := range regions {
+		regionConfig := base
+		regionConfig.Region = region
+		p, err := batch.NewProvider(ctx, regionConfig)
+		if err != nil {
+			return nil, fmt.Errorf("region %s: %w", region, err)
+		}
+		wrapped := middleware.Wrap(p, providerMiddlewareOptions(fmt.Sprintf("%s/%s", p.ServiceType(), region)))
+		guarded.providers = append(guarded.providers, wrapped)
+		members = append(members, dispatcher.PoolMember{Region: region, Provider: wrapped})
+	}
+	return dispatcher.NewProviderPool(members...)
+}
+
+// workerRegistration describes this worker in the Workers table. The address
+// gateways dial is WORKER_ADVERTISE_ADDR, or the first non-loopback IPv4
+// address with the server port.
+func workerRegistration(workerID, port string) (*database.Worker, error) {
+	address := os.Getenv("WORKER_ADVERTISE_ADDR")
+	if address == "" {
+		ip, err := advertiseIP()
+		if err != nil {
+			return nil, fmt.Errorf("failed to detect worker address (set WORKER_ADVERTISE_ADDR): %w", err)
+		}
+		address = net.JoinHostPort(ip, port)
+	}
+
+	capacity := getEnvAsIntOrDefault("WORKER_CAPACITY", 1)
+	if capacity < 1 {
+		return nil, fmt.Errorf("invalid WORKER_CAPACITY %d: must be at least 1", capacity)
+	}
+
+	version := os.Getenv("WORKER_VERSION")
+	if version == "" {
+		if info, ok := debug.ReadBuildInfo(); ok {
+			for _, setting := range info.Settings {
+				if setting.Key == "vcs.revision" {
+					version = setting.Value
+				}
+			}
+		}
+	}
+	w := &database.Worker{WorkerId: workerID, Address: address, Capacity: int64(capacity)}
+	if version != "" {
+		w.Version = &version
+	}
+	return w, nil
+}
+
+// workerAuth holds how the worker authenticates calls from gateways and to
+// other workers.
+type workerAuth struct {
+	// interceptor verifies the service token of incoming calls; nil accepts
+	// every call.
+	interceptor connect.Interceptor
+	// serverTLS serves HTTPS, requiring client certificates for mutual TLS;
+	// nil serves plain HTTP.
+	serverTLS *tls.Config
+	// dialPeer creates clients for forwarding requests to other workers.
+	dialPeer func(address string) jennahv1connect.DeploymentServiceClient
+}
+
+// serviceAuth reads the service authentication settings. Incoming calls must
+// carry a token signed with SERVICE_AUTH_SECRET (or, while rotating,
+// SERVICE_AUTH_PREVIOUS_SECRET); without a secret the worker only starts
+// when WORKER_ALLOW_UNAUTHENTICATED=true. WORKER_TLS_CERT and WORKER_TLS_KEY
+// switch the server to HTTPS, and WORKER_TLS_CLIENT_CA requires callers to
+// present a certificate signed by it.
+func serviceAuth(workerID string) (*workerAuth, error) {
+	auth := &workerAuth{}
+	allowUnsigned := os.Getenv("WORKER_ALLOW_UNAUTHENTICATED") == "true"
+
+	var peerOpts []connect.ClientOption
+	if secret := os.Getenv("SERVICE_AUTH_SECRET"); secret != "" {
+		secrets := []string{secret}
+		if previous := os.Getenv("SERVICE_AUTH_PREVIOUS_SECRET"); previous != "" {
+			secrets = append(secrets, previous)
+		}
+		verifier, err := svcauth.NewVerifier(secrets...)
+		if err != nil {
+			return nil, fmt.Errorf("invalid SERVICE_AUTH_SECRET: %w", err)
+		}
+		ttl := time.Duration(getEnvAsIntOrDefault("SERVICE_TOKEN_TTL_SECONDS", 30)) * time.Second
+		signer, err := svcauth.NewSigner(secret, workerID, ttl)
+		if err != nil {
+			return nil, fmt.Errorf("invalid service auth settings: %w", err)
+		}
+		auth.interceptor = svcauth.NewServerInterceptor(verifier, allowUnsigned)
+		peerOpts = append(peerOpts, connect.WithInterceptors(svcauth.NewClientInterceptor(signer)))
+		if allowUnsigned {
+			log.Println("WARNING: accepting calls without a service token (WORKER_ALLOW_UNAUTHENTICATED=true)")
+		} else {
+			log.Printf("Service authentication enabled (token TTL %s)", ttl)
+		}
+	} else if allowUnsigned {
+		log.Println("WARNING: service authentication disabled; any caller can act for any tenant (WORKER_ALLOW_UNAUTHENTICATED=true)")
+	} else {
+		return nil, errors.New("SERVICE_AUTH_SECRET is required; set WORKER_ALLOW_UNAUTHENTICATED=true to accept unauthenticated calls in development")
+	}
+
+	scheme := "http"
+	peerClient := &http.Client{Timeout: 30 * time.Second}
+	if certFile := os.Getenv("WORKER_TLS_CERT"); certFile != "" {
+		keyFile, clientCA := os.Getenv("WORKER_TLS_KEY"), os.Getenv("WORKER_TLS_CLIENT_CA")
+		serverTLS, err := svcauth.ServerTLSConfig(certFile, keyFile, clientCA)
+		if err != nil {
+			return nil, err
+		}
+		// Forwarded requests present this worker's own certificate.
+		peerTLS, err := svcauth.ClientTLSConfig(os.Getenv("WORKER_TLS_CA"), certFile, keyFile)
+		if err != nil {
+			return nil, err
+		}
+		auth.serverTLS = serverTLS
+		peerClient.Transport = &http.Transport{TLSClientConfig: peerTLS}
+		scheme = "https"
+		log.Printf("Serving HTTPS (client certificates required: %v)", clientCA != "")
+	}
+	auth.dialPeer = func(address string) jennahv1connect.DeploymentServiceClient {
+		return jennahv1connect.NewDeploymentServiceClient(peerClient, scheme+"://"+address, peerOpts...)
+	}
+	return auth, nil
+}
+
+// advertiseIP returns the first non-loopback IPv4 address of this host.
+func advertiseIP() (string, error) {
+	addrs, err := net.InterfaceAddrs()
+	if err != nil {
+		return "", err
+	}
+	for _, addr := range addrs {
+		if ipNet, ok := addr.(*net.IPNet); ok && !ipNet.IP.IsLoopback() && ipNet.IP.To4() != nil {
+			return ipNet.IP.String(), nil
+		}
+	}
+	return "", errors.New("no non-loopback IPv4 address")
+}
+
+// resourceReconcileConfig reads the periodic reconciliation settings.
+// RECONCILE_INTERVAL_MINUTES=0 disables it.
+func resourceReconcileConfig() (*jennahv1.ReconcileResourcesRequest, time.Duration, error) {
+	minutes := 60
+	if v := os.Getenv("RECONCILE_INTERVAL_MINUTES"); v != "" {
+		parsed, err := strconv.Atoi(v)
+		if err != nil || parsed < 0 {
+			return nil, 0, fmt.Errorf("invalid RECONCILE_INTERVAL_MINUTES %q", v)
+		}
+		minutes = parsed
+	}
+
+	opts := &jennahv1.ReconcileResourcesRequest{}
+	switch action := os.Getenv("RECONCILE_ORPHAN_ACTION"); action {
+	case "", "none":
+		opts.OrphanAction = jennahv1.OrphanAction_ORPHAN_ACTION_UNSPECIFIED
+	case "cancel":
+		opts.OrphanAction = jennahv1.OrphanAction_ORPHAN_ACTION_CANCEL
+	case "delete":
+		opts.OrphanAction = jennahv1.OrphanAction_ORPHAN_ACTION_DELETE
+	default:
+		return nil, 0, fmt.Errorf("invalid RECONCILE_ORPHAN_ACTION %q (want none, cancel or delete)", action)
+	}
+	if v := os.Getenv("RECONCILE_FAIL_MISSING"); v != "" {
+		failMissing, err := strconv.ParseBool(v)
+		if err != nil {
+			return nil, 0, fmt.Errorf("invalid RECONCILE_FAIL_MISSING %q", v)
+		}
+		opts.FailMissing = failMissing
+	}
+	opts.GracePeriodSeconds = int64(getEnvAsIntOrDefault("RECONCILE_GRACE_SECONDS", 600))
+
+	return opts, time.Duration(minutes) * time.Minute, nil
+}
+
+// routingRules loads the declarative routing rules from ROUTING_RULES_PATH
+// (default config/routing-rules.yaml). A missing default file disables rules;
+// an explicitly configured file must load.
+func routingRules() (*router.RulesPolicy, string, error) {
+	path := os.Getenv("ROUTING_RULES_PATH")
+	explicit := path != ""
+	if !explicit {
+		path = "config/routing-rules.yaml"
+	}
+	set, err := router.LoadRules(path)
+	if err != nil {
+		if !explicit && errors.Is(err, os.ErrNotExist) {
+			log.Printf("No routing rules at %s; routing by provider capabilities only", path)
+			return nil, "", nil
+		}
+		return nil, "", fmt.Errorf("invalid routing rules %s: %w", path, err)
+	}
+	log.Printf("Loaded %d routing rules from %s", set.Len(), path)
+	return router.NewRulesPolicy(set), path, nil
+}
+
+// routingPolicies returns the routing policy chain: hard requirements, the
+// declarative rules when loaded, the classifier selected by
+// ROUTING_CLASSIFIER when set, and finally the capability check.
+func routingPolicies(rules *router.RulesPolicy, candidates []router.ProviderCandidate) (router.PolicyChain, *router.ClassifierPolicy, error) {
+	policies := router.PolicyChain{router.RequiredPolicy{}}
+	if rules != nil {
+		policies = append(policies, rules)
+	}
+	classifier, err := routingClassifier(candidates)
+	if err != nil {
+		return nil, nil, err
+	}
+	if classifier != nil {
+		policies = append(policies, classifier)
+	}
+	return append(policies, router.CapabilityPolicy{}), classifier, nil
+}
+
+// routingClassifier builds the classifier policy from ROUTING_CLASSIFIER
+// (none, gemini, openai or rules). LLM classifiers fall back to the
+// deterministic rules classifier when a call fails or times out.
+func routingClassifier(candidates []router.ProviderCandidate) (*router.ClassifierPolicy, error) {
+	rules := router.RulesClassifier{Candidates: candidates}
+	var classifier router.Classifier
+	switch kind := os.Getenv("ROUTING_CLASSIFIER"); kind {
+	case "", "none":
+		return nil, nil
+	case "gemini":
+		classifier = router.NewVertexClassifierFromEnv()
+	case "openai":
+		url := os.Getenv("ROUTING_CLASSIFIER_URL")
+		if url == "" {
+			return nil, fmt.Errorf("ROUTING_CLASSIFIER_URL is required for the openai classifier")
+		}
+		classifier = router.NewHTTPClassifier(url, os.Getenv("ROUTING_CLASSIFIER_MODEL"), os.Getenv("ROUTING_CLASSIFIER_API_KEY"), nil)
+	case "rules":
+		classifier = rules
+	default:
+		return nil, fmt.Errorf("invalid ROUTING_CLASSIFIER %q (want none, gemini, openai or rules)", kind)
+	}
+
+	opts := router.ClassifierOptions{
+		Timeout:   time.Duration(getEnvAsIntOrDefault("ROUTING_CLASSIFIER_TIMEOUT_MS", 5000)) * time.Millisecond,
+		CacheSize: getEnvAsIntOrDefault("ROUTING_CLASSIFIER_CACHE_SIZE", 1024),
+		CacheTTL:  time.Duration(getEnvAsIntOrDefault("ROUTING_CLASSIFIER_CACHE_TTL_SECONDS", 600)) * time.Second,
+	}
+	if classifier.Name() != rules.Name() {
+		opts.Fallback = rules
+	}
+	return router.NewClassifierPolicy(classifier, opts), nil
+}
+
+// providerMiddlewareOptions returns the middleware options for the provider
+// called name, with defaults overridden from the environment.
+func providerMiddlewareOptions(name string) middleware.Options {
+	opts := middleware.DefaultOptions(name)
+	opts.MaxAttempts = getEnvAsIntOrDefault("PROVIDER_MAX_ATTEMPTS", opts.MaxAttempts)
+	opts.RateLimit = float64(getEnvAsIntOrDefault("PROVIDER_RATE_LIMIT", int(opts.RateLimit)))
+	opts.Burst = 2 * int(opts.RateLimit)
+	opts.FailureThreshold = getEnvAsIntOrDefault("PROVIDER_BREAKER_THRESHOLD", opts.FailureThreshold)
+	opts.OpenDuration = time.Duration(getEnvAsIntOrDefault("PROVIDER_BREAKER_OPEN_SECONDS", int(opts.OpenDuration.Seconds()))) * time.Second
+	return opts
+}
+
+// guardedProviders are the worker's wrapped providers and routing
+// classifier, reported on /health.
+type guardedProviders struct {
+	providers  []*middleware.Provider
+	classifier *router.ClassifierPolicy
+}
+
+// serveHealth reports the worker as up along with each provider's circuit
+// breaker state. The status is "degraded" while any breaker is not closed;
+// the response is 200 either way, since the worker can still serve jobs on
+// other providers and regions.
+func (g *guardedProviders) serveHealth(w http.ResponseWriter, r *http.Request) {
+	type providerHealth struct {
+		Name    string `json:"name"`
+		Breaker string `json:"breaker"`
+	}
+	health := struct {
+		Status     string                  `json:"status"`
+		Providers  []providerHealth        `json:"providers"`
+		Classifier *router.ClassifierStats `json:"classifier,omitempty"`
+	}{Status: "ok", Providers: []providerHealth{}}
+	if g.classifier != nil {
+		stats := g.classifier.Stats()
+		health.Classifier = &stats
+	}
+
+	for _, p := range g.providers {
+		state := p.BreakerState()
+		if state != middleware.BreakerClosed {
+			health.Status = "degraded"
+		}
+		health.Providers = append(health.Providers, providerHealth{Name: p.Name(), Breaker: string(state)})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(health)
+}
+
 func getEnvAsIntOrDefault(name string, fallback int) int {
 	v := os.Getenv(name)
 	if v == "" {
@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	jennahv1 "github.com/alphauslabs/jennah/gen/proto"
+	"github.com/alphauslabs/jennah/internal/cloudexec/gcp"
+	"github.com/alphauslabs/jennah/internal/router"
+)
+
+// TestDefaultRoutingChain runs the shipped rules file through the chain serve
+// builds, checking that jobs no rule matches reach the classifier.
+func TestDefaultRoutingChain(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"choices":[{"message":{"role":"assistant","content":"{\"complexity\": \"COMPLEX\", \"reason\": \"classified by test\"}"}}]}`))
+	}))
+	defer srv.Close()
+
+	candidates := []router.ProviderCandidate{
+		{Service: router.AssignedServiceCloudRunJob, Capabilities: gcp.CloudRunJobCapabilities},
+		{Service: router.AssignedServiceCloudBatch, Capabilities: gcp.CloudBatchCapabilities},
+	}
+	chain := func(t *testing.T) router.PolicyChain {
+		t.Helper()
+		t.Setenv("ROUTING_RULES_PATH", "../../../config/routing-rules.yaml")
+		rules, _, err := routingRules()
+		if err != nil {
+			t.Fatalf("routingRules: %v", err)
+		}
+		policies, _, err := routingPolicies(rules, candidates)
+		if err != nil {
+			t.Fatalf("routingPolicies: %v", err)
+		}
+		return policies
+	}
+	route := func(policies router.PolicyChain, req *jennahv1.SubmitJobRequest) router.RoutingDecision {
+		return policies.Evaluate(context.Background(), router.RoutingInput{
+			Request:    req,
+			Workload:   router.WorkloadFromRequest(req),
+			Candidates: candidates,
+		})
+	}
+	plain := &jennahv1.SubmitJobRequest{ImageUri: "gcr.io/google-samples/hello-app:1.0"}
+	heavy := &jennahv1.SubmitJobRequest{ResourceOverride: &jennahv1.ResourceOverride{MemoryMib: 16384}}
+
+	t.Run("classifier", func(t *testing.T) {
+		t.Setenv("ROUTING_CLASSIFIER", "openai")
+		t.Setenv("ROUTING_CLASSIFIER_URL", srv.URL)
+		policies := chain(t)
+
+		got := route(policies, plain)
+		if got.Policy != "openai" || got.AssignedService != router.AssignedServiceCloudBatch {
+			t.Errorf("plain job: routed by %q to %s (%s), want the classifier's CLOUD_BATCH", got.Policy, got.AssignedService, got.Reason)
+		}
+		got = route(policies, heavy)
+		if got.Policy != "rules" || got.AssignedService != router.AssignedServiceCloudBatch {
+			t.Errorf("heavy job: routed by %q to %s, want the heavy rule", got.Policy, got.AssignedService)
+		}
+	})
+
+	t.Run("no classifier", func(t *testing.T) {
+		t.Setenv("ROUTING_CLASSIFIER", "")
+		got := route(chain(t), plain)
+		if got.Policy != "capability" || got.AssignedService != router.AssignedServiceCloudRunJob {
+			t.Errorf("plain job: routed by %q to %s, want the capability check's CLOUD_RUN_JOB", got.Policy, got.AssignedService)
+		}
+	})
+}
@@ -11,6 +11,8 @@ import (
 
 	"connectrpc.com/connect"
 	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	jennahv1 "github.com/alphauslabs/jennah/gen/proto"
 	batch "github.com/alphauslabs/jennah/internal/cloudexec"
@@ -79,6 +81,9 @@ func dbJobToProto(job *database.Job) *jennahv1.Job {
 	if job.AssignedService != nil {
 		p.AssignedService = *job.AssignedService
 	}
+	if job.RoutingReason != nil {
+		p.RoutingReason = *job.RoutingReason
+	}
 	if job.MemoryMib != nil {
 		p.MemoryMib = *job.MemoryMib
 	}
@@ -88,6 +93,36 @@ func dbJobToProto(job *database.Job) *jennahv1.Job {
 	if job.MaxRunDurationSeconds != nil {
 		p.MaxRunDurationSeconds = *job.MaxRunDurationSeconds
 	}
+	if job.Region != nil {
+		p.Region = *job.Region
+	}
+	if job.PreemptionCount != nil {
+		p.PreemptionCount = *job.PreemptionCount
+	}
+	if job.VolumesJson != nil && *job.VolumesJson != "" {
+		if err := json.Unmarshal([]byte(*job.VolumesJson), &p.Volumes); err != nil {
+			log.Printf("Error decoding volumes for job %s: %v", job.JobId, err)
+		}
+	}
+	if job.RunnablesJson != nil && *job.RunnablesJson != "" {
+		if err := json.Unmarshal([]byte(*job.RunnablesJson), &p.Runnables); err != nil {
+			log.Printf("Error decoding runnables for job %s: %v", job.JobId, err)
+		}
+	}
+	if job.LabelsJson != nil && *job.LabelsJson != "" {
+		if err := json.Unmarshal([]byte(*job.LabelsJson), &p.Labels); err != nil {
+			log.Printf("Error decoding labels for job %s: %v", job.JobId, err)
+		}
+	}
+	if job.AcceleratorType != nil {
+		p.Accelerator = &jennahv1.Accelerator{
+			Type:          *job.AcceleratorType,
+			DriverVersion: ptrToString(job.AcceleratorDriverVersion),
+		}
+		if job.AcceleratorCount != nil {
+			p.Accelerator.Count = *job.AcceleratorCount
+		}
+	}
 
 	return p
 }
@@ -117,6 +152,34 @@ func (s *WorkerService) SubmitJob(
 		return nil, connect.NewError(connect.CodeInvalidArgument, err)
 	}
 
+	preemptionPolicy, err := resolvePreemptionPolicy(req.Msg, s.jobConfig.PreemptionPolicy)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	// Reject unknown accelerator types and counts before the job is recorded.
+	accelerator, err := navigator.ResolveAccelerator(req.Msg.GetAccelerator(), s.jobConfig)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	var acceleratorType, acceleratorDriverVersion *string
+	var acceleratorCount *int64
+	if accelerator != nil {
+		acceleratorType = &accelerator.Type
+		acceleratorCount = &accelerator.Count
+		acceleratorDriverVersion = ptrStringOrNil(accelerator.DriverVersion)
+	}
+
+	if _, err := navigator.ResolveVolumes(req.Msg.GetVolumes()); err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	if _, err := navigator.ResolveRunnables(req.Msg); err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	if _, err := navigator.ResolveLabels(req.Msg); err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
 	// Use canonical job ID from gateway when provided; otherwise generate one
 	// for backward compatibility (e.g., direct worker calls).
 	internalJobID := req.Msg.JobId
@@ -127,11 +190,6 @@ func (s *WorkerService) SubmitJob(
 		log.Printf("Using gateway-provided internal job ID: %s", internalJobID)
 	}
 
-	// Generate cloud provider-compatible job ID.
-	// Use user-provided name if available, otherwise fall back to UUID-based ID.
-	providerJobID := generateProviderJobID(req.Msg.Name, internalJobID)
-	log.Printf("Generated provider job ID: %s", providerJobID)
-
 	// Serialize environment variables to JSON for storage.
 	var envVarsJson *string
 	if len(req.Msg.EnvVars) > 0 {
@@ -143,33 +201,76 @@ func (s *WorkerService) SubmitJob(
 		s := string(envBytes)
 		envVarsJson = &s
 	}
+	var volumesJson *string
+	if len(req.Msg.Volumes) > 0 {
+		volBytes, err := json.Marshal(req.Msg.Volumes)
+		if err != nil {
+			log.Printf("Error serializing volumes: %v", err)
+			return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to serialize volumes: %w", err))
+		}
+		s := string(volBytes)
+		volumesJson = &s
+	}
+	var runnablesJson *string
+	if len(req.Msg.Runnables) > 0 {
+		runBytes, err := json.Marshal(req.Msg.Runnables)
+		if err != nil {
+			log.Printf("Error serializing runnables: %v", err)
+			return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to serialize runnables: %w", err))
+		}
+		s := string(runBytes)
+		runnablesJson = &s
+	}
+	var labelsJson *string
+	if len(req.Msg.Labels) > 0 {
+		labelBytes, err := json.Marshal(req.Msg.Labels)
+		if err != nil {
+			log.Printf("Error serializing labels: %v", err)
+			return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to serialize labels: %w", err))
+		}
+		s := string(labelBytes)
+		labelsJson = &s
+	}
 
 	// Insert job record with PENDING status and advanced config.
 	now := time.Now().UTC()
 	leaseUntil := now.Add(s.leaseTTL)
-	err := s.dbClient.InsertJobFull(ctx, &database.Job{
-		TenantId:              tenantID,
-		JobId:                 internalJobID,
-		Status:                database.JobStatusPending,
-		ImageUri:              req.Msg.ImageUri,
-		Commands:              req.Msg.Commands,
-		RetryCount:            0,
-		MaxRetries:            3,
-		EnvVarsJson:           envVarsJson,
-		Name:                  ptrStringOrNil(req.Msg.Name),
-		ResourceProfile:       ptrStringOrNil(req.Msg.ResourceProfile),
-		MachineType:           ptrStringOrNil(req.Msg.MachineType),
-		BootDiskSizeGb:        ptrInt64OrNil(req.Msg.BootDiskSizeGb),
-		UseSpotVms:            ptrBoolOrNil(req.Msg.UseSpotVms),
-		ServiceAccount:        ptrStringOrNil(req.Msg.ServiceAccount),
-		MemoryMib:             ptrInt64OrNil(req.Msg.GetResourceOverride().GetMemoryMib()),
-		CpuMillis:             ptrInt64OrNil(req.Msg.GetResourceOverride().GetCpuMillis()),
-		MaxRunDurationSeconds: ptrInt64OrNil(req.Msg.GetResourceOverride().GetMaxRunDurationSeconds()),
-		OwnerWorkerId:         &s.workerID,
-		PreferredWorkerId:     &s.workerID,
-		LeaseExpiresAt:        &leaseUntil,
-		LastHeartbeatAt:       &now,
+	err = s.dbClient.InsertJobFull(ctx, &database.Job{
+		TenantId:                 tenantID,
+		JobId:                    internalJobID,
+		Status:                   database.JobStatusPending,
+		ImageUri:                 req.Msg.ImageUri,
+		Commands:                 req.Msg.Commands,
+		RetryCount:               0,
+		MaxRetries:               3,
+		EnvVarsJson:              envVarsJson,
+		Name:                     ptrStringOrNil(req.Msg.Name),
+		ResourceProfile:          ptrStringOrNil(req.Msg.ResourceProfile),
+		MachineType:              ptrStringOrNil(req.Msg.MachineType),
+		BootDiskSizeGb:           ptrInt64OrNil(req.Msg.BootDiskSizeGb),
+		UseSpotVms:               ptrBoolOrNil(req.Msg.UseSpotVms),
+		ServiceAccount:           ptrStringOrNil(req.Msg.ServiceAccount),
+		MemoryMib:                ptrInt64OrNil(req.Msg.GetResourceOverride().GetMemoryMib()),
+		CpuMillis:                ptrInt64OrNil(req.Msg.GetResourceOverride().GetCpuMillis()),
+		MaxRunDurationSeconds:    ptrInt64OrNil(req.Msg.GetResourceOverride().GetMaxRunDurationSeconds()),
+		OwnerWorkerId:            &s.workerID,
+		PreferredWorkerId:        &s.workerID,
+		LeaseExpiresAt:           &leaseUntil,
+		LastHeartbeatAt:          &now,
+		MaxPreemptionResubmits:   ptrInt64OrNil(int64(preemptionPolicy.GetMaxResubmits())),
+		StandardAfterPreemptions: ptrInt64OrNil(int64(preemptionPolicy.GetStandardAfter())),
+		AcceleratorType:          acceleratorType,
+		AcceleratorCount:         acceleratorCount,
+		AcceleratorDriverVersion: acceleratorDriverVersion,
+		VolumesJson:              volumesJson,
+		RunnablesJson:            runnablesJson,
+		LabelsJson:               labelsJson,
 	})
+	if errors.Is(err, database.ErrJobExists) {
+		// The gateway retried a submission this or another worker already
+		// accepted; report that job instead of submitting it twice.
+		return s.existingSubmission(ctx, tenantID, req.Msg)
+	}
 	if err != nil {
 		log.Printf("Error inserting job to database: %v", err)
 		return nil, connect.NewError(
@@ -179,31 +280,37 @@ func (s *WorkerService) SubmitJob(
 	}
 	log.Printf("Job %s saved to database with PENDING status", internalJobID)
 
+	return s.submitRecordedJob(ctx, tenantID, internalJobID, req.Msg)
+}
+
+// submitRecordedJob plans a job already recorded PENDING, submits it to a
+// provider and starts tracking it. A job that cannot be planned or submitted
+// is marked FAILED.
+func (s *WorkerService) submitRecordedJob(ctx context.Context, tenantID, internalJobID string, req *jennahv1.SubmitJobRequest) (*connect.Response[jennahv1.SubmitJobResponse], error) {
+	// Generate cloud provider-compatible job ID.
+	// Use user-provided name if available, otherwise fall back to UUID-based ID.
+	providerJobID := generateProviderJobID(req.Name, internalJobID)
+	log.Printf("Generated provider job ID: %s", providerJobID)
+
 	// Submit job to cloud batch provider.
 	// Use the navigator to classify the job and build configuration, then
-	// dispatch to the appropriate provider (Cloud Run Jobs / Cloud Batch).
-	plan, err := navigator.Navigate(req.Msg, internalJobID, s.jobConfig)
+	// dispatch to the cheapest registered provider whose capabilities fit.
+	plan, err := s.navigate(ctx, req, tenantID, internalJobID)
 	if err != nil {
 		log.Printf("Error building navigation plan: %v", err)
-		failErr := s.dbClient.FailJob(ctx, tenantID, internalJobID, err.Error())
-		if failErr != nil {
-			log.Printf("Error updating job status to FAILED: %v", failErr)
-		}
-		// Publish terminal event for immediate failure.
-		event := notifier.BuildEvent(uuid.New().String(), tenantID, internalJobID, database.JobStatusFailed, database.JobStatusPending)
-		event.ErrorMessage = err.Error()
-		s.publishTerminalEvent(ctx, event, tenantID)
+		s.failSubmission(ctx, tenantID, internalJobID, err)
 		return nil, connect.NewError(
 			connect.CodeInternal,
 			fmt.Errorf("failed to build execution plan: %w", err),
 		)
 	}
-	log.Printf("Navigation plan: %s (reason: %s)", plan.Summary, plan.ClassifyReason)
+	log.Printf("Navigation plan: %s (policy: %s, reason: %s)", plan.Summary, plan.RoutingPolicy, plan.ClassifyReason)
 
 	// Override the plan's JobID with the provider-compatible one we generated.
 	plan.Config.JobID = providerJobID
 	plan.Config.RequestID = internalJobID
 	plan.Config.TenantID = tenantID
+	plan.Config.JobLabels = navigator.JobLabels(plan.Config.JobLabels, tenantID, internalJobID, serviceTierFromPlan(plan), s.environment)
 
 	var jobResult *batch.JobResult
 	if s.dispatcher != nil {
@@ -214,20 +321,13 @@ func (s *WorkerService) SubmitJob(
 	}
 	if err != nil {
 		log.Printf("Error submitting job to batch provider: %v", err)
-		failErr := s.dbClient.FailJob(ctx, tenantID, internalJobID, err.Error())
-		if failErr != nil {
-			log.Printf("Error updating job status to FAILED: %v", failErr)
-		}
-		// Publish terminal event for submission failure.
-		event := notifier.BuildEvent(uuid.New().String(), tenantID, internalJobID, database.JobStatusFailed, database.JobStatusPending)
-		event.ErrorMessage = err.Error()
-		s.publishTerminalEvent(ctx, event, tenantID)
+		s.failSubmission(ctx, tenantID, internalJobID, err)
 		return nil, connect.NewError(
-			connect.CodeInternal,
+			providerErrorCode(err),
 			fmt.Errorf("failed to submit batch job: %w", err),
 		)
 	}
-	log.Printf("Batch job created: %s", jobResult.CloudResourcePath)
+	log.Printf("Batch job created: %s (region: %s)", jobResult.CloudResourcePath, jobResult.Region)
 
 	// Update job status and GCP Batch job name based on provider's initial status.
 	statusToSet := string(jobResult.InitialStatus)
@@ -235,7 +335,7 @@ func (s *WorkerService) SubmitJob(
 		statusToSet = database.JobStatusRunning
 	}
 
-	err = s.dbClient.UpdateJobStatusAndGcpBatchJobPath(ctx, tenantID, internalJobID, statusToSet, jobResult.CloudResourcePath, serviceTierFromPlan(plan), plan.AssignedService.String())
+	err = s.dbClient.UpdateJobStatusAndGcpBatchJobPath(ctx, tenantID, internalJobID, statusToSet, jobResult.CloudResourcePath, serviceTierFromPlan(plan), plan.AssignedService.String(), plan.ClassifyReason, jobResult.Region)
 	if err != nil {
 		log.Printf("Error updating job status to %s: %v", statusToSet, err)
 		return nil, connect.NewError(
@@ -249,17 +349,89 @@ func (s *WorkerService) SubmitJob(
 	time.Sleep(2 * time.Second)
 
 	// Start background polling goroutine to track job status.
-	s.startJobPollerWithService(ctx, tenantID, internalJobID, jobResult.CloudResourcePath, statusToSet, serviceTierFromPlan(plan), plan.AssignedService)
+	s.startJobPollerWithService(ctx, tenantID, internalJobID, jobResult.CloudResourcePath, statusToSet, serviceTierFromPlan(plan), plan.AssignedService, time.Now())
 
+	// Report the decision that was dispatched, not a separate estimate.
 	response := connect.NewResponse(&jennahv1.SubmitJobResponse{
-		JobId:  internalJobID,
-		Status: statusToSet,
+		JobId:           internalJobID,
+		Status:          statusToSet,
+		ComplexityLevel: serviceTierFromPlan(plan),
+		AssignedService: plan.AssignedService.String(),
+		RoutingReason:   plan.ClassifyReason,
 	})
 
 	log.Printf("Successfully submitted job %s for tenant %s", internalJobID, tenantID)
 	return response, nil
 }
 
+// failSubmission marks a PENDING job that could not be submitted FAILED and
+// publishes the terminal event.
+func (s *WorkerService) failSubmission(ctx context.Context, tenantID, jobID string, err error) {
+	failErr := s.dbClient.FailJob(ctx, tenantID, jobID, err.Error())
+	if failErr != nil {
+		log.Printf("Error updating job status to FAILED: %v", failErr)
+	}
+	event := notifier.BuildEvent(uuid.New().String(), tenantID, jobID, database.JobStatusFailed, database.JobStatusPending)
+	event.ErrorMessage = err.Error()
+	s.publishTerminalEvent(ctx, event, tenantID)
+}
+
+// existingSubmission answers a repeated SubmitJob for a job ID that is
+// already recorded. A job still PENDING is being submitted by the worker that
+// recorded it and is reported as such, unless that worker stopped before
+// reaching a provider, in which case this worker resumes the submission.
+func (s *WorkerService) existingSubmission(ctx context.Context, tenantID string, req *jennahv1.SubmitJobRequest) (*connect.Response[jennahv1.SubmitJobResponse], error) {
+	job, err := s.dbClient.GetJob(ctx, tenantID, req.JobId)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to read existing job: %w", err))
+	}
+	if job.ImageUri != req.ImageUri {
+		return nil, connect.NewError(connect.CodeAlreadyExists, fmt.Errorf("job %s already exists with a different image", req.JobId))
+	}
+	if submissionStalled(job, time.Now().UTC()) {
+		resp, err := s.resumeStalledSubmission(ctx, job)
+		if resp != nil || err != nil {
+			return resp, err
+		}
+	}
+	log.Printf("Job %s already submitted (status %s); returning existing job", req.JobId, job.Status)
+	return connect.NewResponse(&jennahv1.SubmitJobResponse{
+		JobId:           job.JobId,
+		Status:          job.Status,
+		ComplexityLevel: ptrToString(job.ServiceTier),
+		AssignedService: ptrToString(job.AssignedService),
+		RoutingReason:   ptrToString(job.RoutingReason),
+	}), nil
+}
+
+// submissionStalled reports whether job was recorded but never reached a
+// provider and the lease of the worker that recorded it has expired.
+func submissionStalled(job *database.Job, now time.Time) bool {
+	return job.Status == database.JobStatusPending && job.GcpBatchJobPath == nil &&
+		(job.LeaseExpiresAt == nil || job.LeaseExpiresAt.Before(now))
+}
+
+// resumeStalledSubmission claims a stalled job and submits it from its stored
+// request. It returns a nil response and error when another worker claimed
+// the job first.
+func (s *WorkerService) resumeStalledSubmission(ctx context.Context, job *database.Job) (*connect.Response[jennahv1.SubmitJobResponse], error) {
+	claimed, err := s.dbClient.ClaimStalledSubmission(ctx, job.TenantId, job.JobId, s.workerID, time.Now().UTC().Add(s.leaseTTL))
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	if !claimed {
+		return nil, nil
+	}
+	log.Printf("Job %s was never submitted to a provider; resuming its submission", job.JobId)
+
+	req, err := submitRequestFromJob(job)
+	if err != nil {
+		s.failSubmission(ctx, job.TenantId, job.JobId, err)
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	return s.submitRecordedJob(ctx, job.TenantId, job.JobId, req)
+}
+
 // ListJobs returns all jobs for the tenant.
 func (s *WorkerService) ListJobs(
 	ctx context.Context,
@@ -329,6 +501,21 @@ func (s *WorkerService) CancelJob(
 		)
 	}
 
+	// Only the lease owner may cancel, or its poller would keep overwriting
+	// the status.
+	ownerAddr, err := s.acquireJob(ctx, req.Header(), job)
+	if err != nil {
+		return nil, err
+	}
+	if ownerAddr != "" {
+		log.Printf("Forwarding CancelJob for job %s to lease owner at %s", jobID, ownerAddr)
+		response, err := s.peer(ownerAddr).CancelJob(ctx, forwardRequest(req, s.workerID))
+		if err != nil {
+			return nil, connect.NewError(connect.CodeOf(err), fmt.Errorf("lease owner failed: %w", err))
+		}
+		return response, nil
+	}
+
 	// Cancel job in cloud provider.
 	if job.GcpBatchJobPath != nil {
 		// Determine which provider to use based on AssignedService.
@@ -351,7 +538,7 @@ func (s *WorkerService) CancelJob(
 		err = s.dispatcher.CancelJob(ctx, assignedService, *job.GcpBatchJobPath)
 		if err != nil {
 			log.Printf("Error cancelling job in provider: %v", err)
-			return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to cancel job in provider: %w", err))
+			return nil, connect.NewError(providerErrorCode(err), fmt.Errorf("failed to cancel job in provider: %w", err))
 		}
 		log.Printf("Job %s cancelled in provider (%s)", jobID, assignedService)
 	}
@@ -421,6 +608,19 @@ func (s *WorkerService) DeleteJob(
 		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("job not found: %w", err))
 	}
 
+	ownerAddr, err := s.acquireJob(ctx, req.Header(), job)
+	if err != nil {
+		return nil, err
+	}
+	if ownerAddr != "" {
+		log.Printf("Forwarding DeleteJob for job %s to lease owner at %s", jobID, ownerAddr)
+		response, err := s.peer(ownerAddr).DeleteJob(ctx, forwardRequest(req, s.workerID))
+		if err != nil {
+			return nil, connect.NewError(connect.CodeOf(err), fmt.Errorf("lease owner failed: %w", err))
+		}
+		return response, nil
+	}
+
 	// Delete job from cloud provider (if it has a resource path).
 	if job.GcpBatchJobPath != nil {
 		// Determine which provider to use based on AssignedService.
@@ -443,7 +643,7 @@ func (s *WorkerService) DeleteJob(
 		err = s.dispatcher.DeleteJob(ctx, assignedService, *job.GcpBatchJobPath)
 		if err != nil {
 			log.Printf("Error deleting job from provider: %v", err)
-			return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to delete job from provider: %w", err))
+			return nil, connect.NewError(providerErrorCode(err), fmt.Errorf("failed to delete job from provider: %w", err))
 		}
 		log.Printf("Job %s deleted from cloud provider (%s)", jobID, assignedService)
 	}
@@ -583,3 +783,21 @@ func serviceTierFromPlan(plan *navigator.NavigationPlan) string {
 		return database.ServiceTierComplex
 	}
 }
+
+// providerErrorCode maps a provider error to a Connect code by its class
+// (see batch.ClassifyError). Permanent errors keep their gRPC code, which
+// Connect shares.
+func providerErrorCode(err error) connect.Code {
+	switch batch.ClassifyError(err) {
+	case batch.ErrorClassRetryable:
+		return connect.CodeUnavailable
+	case batch.ErrorClassQuota:
+		return connect.CodeResourceExhausted
+	case batch.ErrorClassNotFound:
+		return connect.CodeNotFound
+	}
+	if s, ok := status.FromError(err); ok && s.Code() != codes.Unknown {
+		return connect.Code(s.Code())
+	}
+	return connect.CodeInternal
+}
@@ -0,0 +1,64 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	batch "github.com/alphauslabs/jennah/internal/cloudexec"
+	"github.com/alphauslabs/jennah/internal/database"
+)
+
+func TestProviderErrorCode(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want connect.Code
+	}{
+		{"circuit open", &batch.ProviderError{Class: batch.ErrorClassRetryable, Err: batch.ErrCircuitOpen}, connect.CodeUnavailable},
+		{"transient", status.Error(codes.Unavailable, "down"), connect.CodeUnavailable},
+		{"no capacity anywhere", fmt.Errorf("no region could take job j: %w", status.Error(codes.ResourceExhausted, "stockout")), connect.CodeResourceExhausted},
+		{"not found", status.Error(codes.NotFound, "gone"), connect.CodeNotFound},
+		{"invalid", status.Error(codes.InvalidArgument, "bad image"), connect.CodeInvalidArgument},
+		{"permission", status.Error(codes.PermissionDenied, "no"), connect.CodePermissionDenied},
+		{"unclassified", errors.New("boom"), connect.CodeInternal},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := providerErrorCode(tt.err); got != tt.want {
+				t.Errorf("providerErrorCode(%v) = %s, want %s", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSubmissionStalled(t *testing.T) {
+	now := time.Now().UTC()
+	expired := now.Add(-time.Second)
+	held := now.Add(time.Minute)
+	path := "projects/p/locations/r/jobs/j"
+
+	tests := []struct {
+		name string
+		job  database.Job
+		want bool
+	}{
+		{"worker crashed before submitting", database.Job{Status: database.JobStatusPending, LeaseExpiresAt: &expired}, true},
+		{"no lease recorded", database.Job{Status: database.JobStatusPending}, true},
+		{"still being submitted", database.Job{Status: database.JobStatusPending, LeaseExpiresAt: &held}, false},
+		{"submitted", database.Job{Status: database.JobStatusPending, GcpBatchJobPath: &path, LeaseExpiresAt: &expired}, false},
+		{"failed before submitting", database.Job{Status: database.JobStatusFailed, LeaseExpiresAt: &expired}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := submissionStalled(&tt.job, now); got != tt.want {
+				t.Errorf("submissionStalled() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"connectrpc.com/connect"
+
+	"github.com/alphauslabs/jennah/gen/proto/jennahv1connect"
+	"github.com/alphauslabs/jennah/internal/database"
+)
+
+// forwardedByHeader names the worker that forwarded a request to the job's
+// lease owner. A forwarded request is never forwarded again.
+const forwardedByHeader = "X-Jennah-Forwarded-By"
+
+// peerHTTPClient is used for requests forwarded to other workers when no
+// peer dialer is set.
+var peerHTTPClient = &http.Client{Timeout: 30 * time.Second}
+
+// acquireJob makes sure this worker may change job. When another worker holds
+// a current lease and is alive, it returns that worker's address so the
+// request can be forwarded to it. Otherwise it claims the lease, so the
+// previous owner's status loop lets go of the job, and returns "". Jobs that
+// have finished need no lease.
+func (s *WorkerService) acquireJob(ctx context.Context, header http.Header, job *database.Job) (string, error) {
+	if !isCancellableStatus(job.Status) {
+		return "", nil
+	}
+	now := time.Now().UTC()
+	if owner := forwardTarget(job, s.workerID, header.Get(forwardedByHeader) != "", now); owner != "" {
+		if addr := s.liveWorkerAddress(ctx, owner, now); addr != "" {
+			return addr, nil
+		}
+	}
+
+	owned, err := s.dbClient.TryClaimOrRenewJobLease(ctx, job.TenantId, job.JobId, s.workerID, now.Add(s.leaseTTL))
+	if err != nil {
+		return "", connect.NewError(connect.CodeUnavailable, fmt.Errorf("failed to claim job lease: %w", err))
+	}
+	if !owned {
+		return "", connect.NewError(connect.CodeUnavailable,
+			fmt.Errorf("job %s is leased by worker %s until %s; retry later", job.JobId, ptrToString(job.OwnerWorkerId), job.LeaseExpiresAt.Format(time.RFC3339)))
+	}
+	return "", nil
+}
+
+// forwardTarget returns the worker a request about job should be forwarded
+// to: the holder of a current lease other than workerID, unless the request
+// was already forwarded.
+func forwardTarget(job *database.Job, workerID string, forwarded bool, now time.Time) string {
+	if forwarded || job.OwnerWorkerId == nil || job.LeaseExpiresAt == nil {
+		return ""
+	}
+	owner := *job.OwnerWorkerId
+	if owner == "" || owner == workerID || !job.LeaseExpiresAt.After(now) {
+		return ""
+	}
+	return owner
+}
+
+// liveWorkerAddress returns the address of workerID when it heartbeated
+// within a lease TTL, draining or not, and "" otherwise.
+func (s *WorkerService) liveWorkerAddress(ctx context.Context, workerID string, now time.Time) string {
+	w, err := s.dbClient.GetWorker(ctx, workerID)
+	if err != nil {
+		log.Printf("Lease owner %s not found in worker registry: %v", workerID, err)
+		return ""
+	}
+	if !w.Alive(now, s.leaseTTL) {
+		return ""
+	}
+	return w.Address
+}
+
+// SetPeerDialer sets how clients for the worker at an address are created
+// when requests are forwarded. Without one, peers are called over plain HTTP
+// without a service token.
+func (s *WorkerService) SetPeerDialer(dial func(address string) jennahv1connect.DeploymentServiceClient) {
+	s.peerDial = dial
+}
+
+// peer returns a client for the worker at address.
+func (s *WorkerService) peer(address string) jennahv1connect.DeploymentServiceClient {
+	if s.peerDial != nil {
+		return s.peerDial(address)
+	}
+	return jennahv1connect.NewDeploymentServiceClient(peerHTTPClient, "http://"+address)
+}
+
+// forwardRequest copies req for the job's owner, marking it as forwarded by
+// workerID.
+func forwardRequest[T any](req *connect.Request[T], workerID string) *connect.Request[T] {
+	fwd := connect.NewRequest(req.Msg)
+	fwd.Header().Set("X-Tenant-Id", req.Header().Get("X-Tenant-Id"))
+	fwd.Header().Set(forwardedByHeader, workerID)
+	return fwd
+}
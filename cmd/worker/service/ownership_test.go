@@ -0,0 +1,38 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alphauslabs/jennah/internal/database"
+)
+
+func TestForwardTarget(t *testing.T) {
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	other, self, empty := "worker-b", "worker-a", ""
+	current, expired := now.Add(10*time.Second), now.Add(-time.Second)
+
+	tests := []struct {
+		name      string
+		owner     *string
+		expires   *time.Time
+		forwarded bool
+		want      string
+	}{
+		{name: "other worker holds current lease", owner: &other, expires: &current, want: other},
+		{name: "already forwarded", owner: &other, expires: &current, forwarded: true},
+		{name: "lease expired", owner: &other, expires: &expired},
+		{name: "own lease", owner: &self, expires: &current},
+		{name: "no owner", expires: &current},
+		{name: "empty owner", owner: &empty, expires: &current},
+		{name: "no expiry", owner: &other},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			job := &database.Job{Status: database.JobStatusRunning, OwnerWorkerId: tt.owner, LeaseExpiresAt: tt.expires}
+			if got := forwardTarget(job, self, tt.forwarded, now); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
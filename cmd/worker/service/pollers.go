@@ -5,17 +5,22 @@ import (
 	"fmt"
 	"log"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/google/uuid"
 
+	jennahv1 "github.com/alphauslabs/jennah/gen/proto"
 	batch "github.com/alphauslabs/jennah/internal/cloudexec"
 	"github.com/alphauslabs/jennah/internal/database"
+	"github.com/alphauslabs/jennah/internal/navigator"
 	"github.com/alphauslabs/jennah/internal/notifier"
 	"github.com/alphauslabs/jennah/internal/router"
 )
 
-// JobPoller manages polling of a single job's status from the batch provider.
+// JobPoller tracks the status of a single job. Pollers are driven by the
+// statusLoop of their assigned service; once registered, only that loop's
+// goroutine reads or writes their fields, except while resubmitting is set.
 type JobPoller struct {
 	tenantID          string
 	jobID             string
@@ -25,25 +30,43 @@ type JobPoller struct {
 	assignedService   router.AssignedService
 	batchProvider     batch.Provider
 	dbClient          *database.Client
-	ticker            *time.Ticker
 	done              chan bool
 	stopOnce          sync.Once
-	pollingInterval   time.Duration
 	maxFailedAttempts int
 	failedAttempts    int
+
+	// resubmitting is set while a preempted job is resubmitted off the
+	// status loop; the resubmission owns the poller's fields until it clears.
+	resubmitting atomic.Bool
+
+	// updates carries provider status notifications for this job.
+	updates chan batch.JobStatus
+	// trackedSince is when the current attempt was submitted. The adaptive
+	// poll interval grows with the attempt's age.
+	trackedSince time.Time
+	// nextStatusPoll is when the provider is next asked for the job status.
+	nextStatusPoll time.Time
 }
 
-// startJobPoller spawns a background goroutine to poll the batch provider for job status updates.
-func (s *WorkerService) startJobPoller(ctx context.Context, tenantID, jobID, gcpResourcePath, initialStatus, serviceTier string) {
-	s.startJobPollerWithService(ctx, tenantID, jobID, gcpResourcePath, initialStatus, serviceTier, router.AssignedServiceUnspecified)
+// defaultPollingInterval is the shortest interval between status reads for
+// a job, used while jobs are young.
+const defaultPollingInterval = 5 * time.Second
+
+// pollerUpdateBuffer bounds the notifications queued for one poller. Further
+// notifications are dropped; the next safety poll picks up the state.
+const pollerUpdateBuffer = 8
+
+// startJobPoller starts tracking a recovered job submitted at since.
+func (s *WorkerService) startJobPoller(ctx context.Context, tenantID, jobID, gcpResourcePath, initialStatus, serviceTier string, since time.Time) {
+	s.startJobPollerWithService(ctx, tenantID, jobID, gcpResourcePath, initialStatus, serviceTier, router.AssignedServiceUnspecified, since)
 }
 
-// startJobPollerWithService spawns a background goroutine to poll the correct provider for job status updates.
-func (s *WorkerService) startJobPollerWithService(ctx context.Context, tenantID, jobID, gcpResourcePath, initialStatus, serviceTier string, assignedService router.AssignedService) {
+// startJobPollerWithService hands the job to the status loop of the correct
+// provider. since is when the job was submitted.
+func (s *WorkerService) startJobPollerWithService(ctx context.Context, tenantID, jobID, gcpResourcePath, initialStatus, serviceTier string, assignedService router.AssignedService, since time.Time) {
 	pollerKey := fmt.Sprintf("%s/%s", tenantID, jobID)
 
 	// Determine the correct provider to use based on assignedService or serviceTier
-	var provider batch.Provider
 	var inferredService router.AssignedService
 
 	if assignedService == router.AssignedServiceUnspecified {
@@ -59,16 +82,7 @@ func (s *WorkerService) startJobPollerWithService(ctx context.Context, tenantID,
 	}
 
 	// Get the correct provider from dispatcher
-	if s.dispatcher != nil {
-		var err error
-		provider, err = s.dispatcher.ProviderFor(inferredService)
-		if err != nil {
-			log.Printf("Failed to get provider for service %s, falling back to batchProvider: %v", inferredService, err)
-			provider = s.batchProvider
-		}
-	} else {
-		provider = s.batchProvider
-	}
+	provider := s.providerFor(inferredService)
 
 	poller := &JobPoller{
 		tenantID:          tenantID,
@@ -79,136 +93,113 @@ func (s *WorkerService) startJobPollerWithService(ctx context.Context, tenantID,
 		assignedService:   inferredService,
 		batchProvider:     provider,
 		dbClient:          s.dbClient,
-		pollingInterval:   5 * time.Second,
 		maxFailedAttempts: 10,
 		failedAttempts:    0,
 		done:              make(chan bool),
+		updates:           make(chan batch.JobStatus, pollerUpdateBuffer),
+		trackedSince:      since,
 	}
 
-	// Register poller.
-	s.pollersMutex.Lock()
-	if s.pollers == nil {
-		s.pollers = make(map[string]*JobPoller)
-	}
-	if _, exists := s.pollers[pollerKey]; exists {
-		s.pollersMutex.Unlock()
+	if !s.registerPoller(pollerKey, poller) {
 		return
 	}
-	s.pollers[pollerKey] = poller
-	s.pollersMutex.Unlock()
 
 	log.Printf("Starting poller for job %s (tenant: %s, service: %s, provider: %s)", jobID, tenantID, inferredService, provider.ServiceType())
 
-	// Start polling in background with a new context (independent of request lifecycle).
-	go poller.poll(context.Background(), s, pollerKey)
+	s.statusLoopFor(inferredService).add(poller)
 }
 
-// poll continuously checks job status and updates the database when status changes.
-func (poller *JobPoller) poll(ctx context.Context, server *WorkerService, pollerKey string) {
-	ticker := time.NewTicker(poller.pollingInterval)
-	defer ticker.Stop()
-	defer server.unregisterPoller(pollerKey)
-
-	for {
-		select {
-		case <-poller.done:
-			log.Printf("Poller for job %s (tenant: %s) stopped", poller.jobID, poller.tenantID)
-			return
-
-		case <-ticker.C:
-			leaseUntil := time.Now().UTC().Add(server.leaseTTL)
-			owned, err := poller.dbClient.TryClaimOrRenewJobLease(ctx, poller.tenantID, poller.jobID, server.workerID, leaseUntil)
-			if err != nil {
-				log.Printf("Error renewing lease for job %s: %v", poller.jobID, err)
-				continue
-			}
+// applyStatus records a job status observed by polling or delivered by a
+// notification. It reports whether the poller has stopped.
+func (poller *JobPoller) applyStatus(ctx context.Context, server *WorkerService, status batch.JobStatus, reason string) bool {
+	// Spot preemption is not a workload failure: resubmit per the job's policy.
+	if status == batch.JobStatusPreempted {
+		server.resubmitPreempted(poller)
+		return false
+	}
 
-			if !owned {
-				log.Printf("Lease ownership lost for job %s; stopping local poller", poller.jobID)
-				poller.stop()
-				return
-			}
+	// Convert batch provider status to database status.
+	dbStatus := mapBatchStatusToDBStatus(status)
 
-			status, err := poller.batchProvider.GetJobStatus(ctx, poller.gcpResourcePath)
-			if err != nil {
-				poller.failedAttempts++
-				log.Printf("Error polling job %s (attempt %d/%d) [service=%s, tier=%s, path=%s]: %v",
-					poller.jobID, poller.failedAttempts, poller.maxFailedAttempts,
-					poller.assignedService, poller.serviceTier, poller.gcpResourcePath, err)
-
-				// If this is a SIMPLE tier job failing with Cloud Run provider, it might actually be a Cloud Batch job
-				// (e.g., created before the dispatcher was implemented). Try falling back to Cloud Batch.
-				if poller.serviceTier == database.ServiceTierSimple && poller.assignedService == router.AssignedServiceCloudRunJob {
-					if server.dispatcher != nil {
-						if batchProvider, err := server.dispatcher.ProviderFor(router.AssignedServiceCloudBatch); err == nil {
-							log.Printf("Retrying job %s with Cloud Batch provider (fallback)", poller.jobID)
-							status, err = batchProvider.GetJobStatus(ctx, poller.gcpResourcePath)
-							if err == nil {
-								// Success! Update the poller to use Cloud Batch going forward
-								log.Printf("Job %s is actually a Cloud Batch job, updating poller", poller.jobID)
-								poller.batchProvider = batchProvider
-								poller.assignedService = router.AssignedServiceCloudBatch
-								poller.failedAttempts = 0 // Reset since we found the right provider
-								// Don't continue; process the status below
-								goto processStatus
-							}
-							log.Printf("Cloud Batch fallback also failed for job %s: %v", poller.jobID, err)
-						}
-					}
-				}
+	// Check if status changed.
+	if dbStatus == poller.currentStatus {
+		return false
+	}
+	oldStatus := poller.currentStatus
+	poller.currentStatus = dbStatus
 
-				if poller.failedAttempts >= poller.maxFailedAttempts {
-					log.Printf("Max failed attempts reached for job %s, stopping poller", poller.jobID)
-					poller.stop()
-					return
-				}
-				continue
-			}
+	log.Printf("Job %s status changed: %s → %s", poller.jobID, oldStatus, dbStatus)
 
-		processStatus:
-			poller.failedAttempts = 0 // Reset on successful poll.
+	// Update database with new status.
+	err := poller.dbClient.UpdateJobStatus(ctx, poller.tenantID, poller.jobID, dbStatus)
+	if err != nil {
+		log.Printf("Error updating job status in database: %v", err)
+	}
 
-			// Convert batch provider status to database status.
-			dbStatus := mapBatchStatusToDBStatus(status)
+	// Record state transition in audit trail.
+	transitionID := uuid.New().String()
+	err = poller.dbClient.RecordStateTransition(ctx, poller.tenantID, poller.jobID, transitionID, &oldStatus, dbStatus, &reason)
+	if err != nil {
+		log.Printf("Error recording state transition: %v", err)
+	}
 
-			// Check if status changed.
-			if dbStatus != poller.currentStatus {
-				oldStatus := poller.currentStatus
-				poller.currentStatus = dbStatus
+	// Stop polling if job reached a terminal state.
+	if !isTerminalStatus(dbStatus) {
+		return false
+	}
+	log.Printf("Job %s reached terminal status %s, stopping poller", poller.jobID, dbStatus)
 
-				log.Printf("Job %s status changed: %s → %s", poller.jobID, oldStatus, dbStatus)
+	// Publish terminal event notification.
+	event := notifier.BuildEvent(transitionID, poller.tenantID, poller.jobID, dbStatus, oldStatus)
+	event.CloudResourcePath = poller.gcpResourcePath
+	event.ServiceTier = poller.serviceTier
+	event.AssignedService = poller.assignedService.String()
+	server.publishTerminalEvent(ctx, event, poller.tenantID)
 
-				// Update database with new status.
-				err := poller.dbClient.UpdateJobStatus(ctx, poller.tenantID, poller.jobID, dbStatus)
-				if err != nil {
-					log.Printf("Error updating job status in database: %v", err)
-				}
+	poller.stop()
+	return true
+}
 
-				// Record state transition in audit trail.
-				transitionID := uuid.New().String()
-				reason := "Status updated from GCP Batch API"
-				err = poller.dbClient.RecordStateTransition(ctx, poller.tenantID, poller.jobID, transitionID, &oldStatus, dbStatus, &reason)
-				if err != nil {
-					log.Printf("Error recording state transition: %v", err)
-				}
+// providerFor returns the dispatcher's provider for svc, falling back to the
+// single batchProvider when no dispatcher or no matching provider is configured.
+func (s *WorkerService) providerFor(svc router.AssignedService) batch.Provider {
+	if s.dispatcher == nil {
+		return s.batchProvider
+	}
+	provider, err := s.dispatcher.ProviderFor(svc)
+	if err != nil {
+		log.Printf("Failed to get provider for service %s, falling back to batchProvider: %v", svc, err)
+		return s.batchProvider
+	}
+	return provider
+}
 
-				// Stop polling if job reached a terminal state.
-				if isTerminalStatus(dbStatus) {
-					log.Printf("Job %s reached terminal status %s, stopping poller", poller.jobID, dbStatus)
+// SetRoutingPolicies replaces the routing policy chain used for new and
+// resubmitted jobs. It must be called before the service starts serving.
+func (s *WorkerService) SetRoutingPolicies(policies router.PolicyChain) {
+	s.routingPolicies = policies
+}
 
-					// Publish terminal event notification.
-					event := notifier.BuildEvent(transitionID, poller.tenantID, poller.jobID, dbStatus, oldStatus)
-					event.CloudResourcePath = poller.gcpResourcePath
-					event.ServiceTier = poller.serviceTier
-					event.AssignedService = poller.assignedService.String()
-					server.publishTerminalEvent(ctx, event, poller.tenantID)
+// navigate builds the execution plan for req, evaluating the routing policy
+// chain once against the registered providers.
+func (s *WorkerService) navigate(ctx context.Context, req *jennahv1.SubmitJobRequest, tenantID, jobID string) (*navigator.NavigationPlan, error) {
+	policies := s.routingPolicies
+	if policies == nil {
+		policies = router.DefaultPolicies()
+	}
+	return navigator.NavigateWithPolicies(ctx, req, tenantID, jobID, s.jobConfig, s.routingCandidates(), policies)
+}
 
-					poller.stop()
-					return
-				}
-			}
-		}
+// routingCandidates returns the providers routing may choose from: those
+// registered in the dispatcher or, without one, the single batchProvider.
+func (s *WorkerService) routingCandidates() []router.ProviderCandidate {
+	if s.dispatcher != nil {
+		return s.dispatcher.Candidates()
+	}
+	if s.batchProvider == nil {
+		return nil
 	}
+	return []router.ProviderCandidate{{Service: router.AssignedServiceCloudBatch, Capabilities: s.batchProvider.Capabilities()}}
 }
 
 // stop signals the poller to stop polling.
@@ -218,6 +209,16 @@ func (poller *JobPoller) stop() {
 	})
 }
 
+// stopped reports whether stop has been called.
+func (poller *JobPoller) stopped() bool {
+	select {
+	case <-poller.done:
+		return true
+	default:
+		return false
+	}
+}
+
 // StopAllPollers gracefully stops all active job pollers.
 func (s *WorkerService) StopAllPollers() {
 	s.pollersMutex.Lock()
@@ -228,13 +229,75 @@ func (s *WorkerService) StopAllPollers() {
 		log.Printf("Stopping poller: %s", pollerKey)
 		poller.stop()
 	}
+	for _, loop := range s.statusLoops {
+		loop.stop()
+	}
+	s.statusLoops = nil
 	s.pollers = make(map[string]*JobPoller)
+	s.pollerPaths = make(map[string]string)
+	s.pollersByPath = make(map[string]string)
+}
+
+// registerPoller adds poller under pollerKey and indexes its resource path.
+// It reports false if a poller for the job is already registered.
+func (s *WorkerService) registerPoller(pollerKey string, poller *JobPoller) bool {
+	s.pollersMutex.Lock()
+	defer s.pollersMutex.Unlock()
+
+	if s.pollers == nil {
+		s.pollers = make(map[string]*JobPoller)
+	}
+	if _, exists := s.pollers[pollerKey]; exists {
+		return false
+	}
+	s.pollers[pollerKey] = poller
+	s.indexPollerPathLocked(pollerKey, poller.gcpResourcePath)
+	return true
 }
 
-func (s *WorkerService) unregisterPoller(pollerKey string) {
+// unregisterPoller removes poller from pollerKey unless a newer poller has
+// since been registered for the job.
+func (s *WorkerService) unregisterPoller(pollerKey string, poller *JobPoller) {
 	s.pollersMutex.Lock()
 	defer s.pollersMutex.Unlock()
+	if s.pollers[pollerKey] != poller {
+		return
+	}
 	delete(s.pollers, pollerKey)
+	s.unindexPollerLocked(pollerKey)
+}
+
+// hasPoller reports whether the job is tracked by this worker.
+func (s *WorkerService) hasPoller(tenantID, jobID string) bool {
+	s.pollersMutex.Lock()
+	defer s.pollersMutex.Unlock()
+	_, ok := s.pollers[fmt.Sprintf("%s/%s", tenantID, jobID)]
+	return ok
+}
+
+// indexPollerPath points notifications for path at the poller under
+// pollerKey, replacing the path of a previous attempt.
+func (s *WorkerService) indexPollerPath(pollerKey, path string) {
+	s.pollersMutex.Lock()
+	defer s.pollersMutex.Unlock()
+	s.indexPollerPathLocked(pollerKey, path)
+}
+
+func (s *WorkerService) indexPollerPathLocked(pollerKey, path string) {
+	if s.pollerPaths == nil {
+		s.pollerPaths = make(map[string]string)
+		s.pollersByPath = make(map[string]string)
+	}
+	s.unindexPollerLocked(pollerKey)
+	s.pollerPaths[pollerKey] = path
+	s.pollersByPath[path] = pollerKey
+}
+
+func (s *WorkerService) unindexPollerLocked(pollerKey string) {
+	if path, ok := s.pollerPaths[pollerKey]; ok {
+		delete(s.pollersByPath, path)
+		delete(s.pollerPaths, pollerKey)
+	}
 }
 
 // stopPollerForJob removes and stops a specific job's poller.
@@ -247,6 +310,7 @@ func (s *WorkerService) stopPollerForJob(tenantID, jobID string) {
 		log.Printf("Stopping poller for job %s", jobID)
 		poller.stop()
 		delete(s.pollers, pollerKey)
+		s.unindexPollerLocked(pollerKey)
 	}
 }
 
@@ -257,8 +321,10 @@ func ResumeActiveJobPollers(ctx context.Context, server *WorkerService, dbClient
 }
 
 // StartLeaseReconciler continuously claims/renews active job ownership so jobs fail over across workers.
+// Each tick also heartbeats the worker's registry row (see SetRegistration).
 func (s *WorkerService) StartLeaseReconciler(ctx context.Context) {
 	go func() {
+		s.register(ctx)
 		if err := s.reconcileActiveJobLeases(ctx, true); err != nil {
 			log.Printf("Initial lease reconcile failed: %v", err)
 		}
@@ -272,6 +338,7 @@ func (s *WorkerService) StartLeaseReconciler(ctx context.Context) {
 				log.Println("Lease reconciler stopped")
 				return
 			case <-ticker.C:
+				s.heartbeat(ctx)
 				if err := s.reconcileActiveJobLeases(context.Background(), false); err != nil {
 					log.Printf("Lease reconcile tick failed: %v", err)
 				}
@@ -291,8 +358,22 @@ func (s *WorkerService) reconcileActiveJobLeases(ctx context.Context, startup bo
 	}
 
 	claimedCount := 0
+	now := time.Now().UTC()
 	for _, job := range jobs {
 		if job.GcpBatchJobPath == nil {
+			// A job whose worker stopped between recording and submitting
+			// it would otherwise stay PENDING for good.
+			if submissionStalled(job, now) {
+				if _, err := s.resumeStalledSubmission(ctx, job); err != nil {
+					log.Printf("Resuming submission of job %s failed: %v", job.JobId, err)
+				}
+			}
+			continue
+		}
+
+		// Leases of jobs tracked here are renewed by their status loop, and
+		// jobs leased to another worker cannot be claimed yet.
+		if s.hasPoller(job.TenantId, job.JobId) || !job.LeaseClaimable(s.workerID, now) {
 			continue
 		}
 
@@ -309,7 +390,7 @@ func (s *WorkerService) reconcileActiveJobLeases(ctx context.Context, startup bo
 			continue
 		}
 
-		s.startJobPoller(ctx, job.TenantId, job.JobId, *job.GcpBatchJobPath, job.Status, ptrToString(job.ServiceTier))
+		s.startJobPoller(ctx, job.TenantId, job.JobId, *job.GcpBatchJobPath, job.Status, ptrToString(job.ServiceTier), job.CreatedAt)
 		claimedCount++
 	}
 
@@ -331,7 +412,7 @@ func mapBatchStatusToDBStatus(status batch.JobStatus) string {
 		return database.JobStatusRunning
 	case batch.JobStatusCompleted:
 		return database.JobStatusCompleted
-	case batch.JobStatusFailed:
+	case batch.JobStatusFailed, batch.JobStatusPreempted:
 		return database.JobStatusFailed
 	case batch.JobStatusCancelled:
 		return database.JobStatusCancelled
@@ -0,0 +1,312 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	jennahv1 "github.com/alphauslabs/jennah/gen/proto"
+	batch "github.com/alphauslabs/jennah/internal/cloudexec"
+	"github.com/alphauslabs/jennah/internal/config"
+	"github.com/alphauslabs/jennah/internal/database"
+	"github.com/alphauslabs/jennah/internal/navigator"
+	"github.com/alphauslabs/jennah/internal/notifier"
+	"github.com/alphauslabs/jennah/internal/router"
+)
+
+// preemptionReason prefixes audit-trail reasons and error messages for
+// attempts lost to Spot preemption.
+const preemptionReason = "PREEMPTED"
+
+// preemptionDecision is what to do with a job after its Spot VMs were preempted.
+type preemptionDecision struct {
+	// Resubmit is false once the policy's resubmission budget is spent.
+	Resubmit bool
+
+	// UseSpot selects Spot provisioning for the next attempt.
+	UseSpot bool
+}
+
+// decidePreemption applies policy to a job that has now been preempted
+// preemptions times (including the preemption being handled).
+func decidePreemption(policy *jennahv1.PreemptionPolicy, preemptions int64) preemptionDecision {
+	if preemptions > int64(policy.GetMaxResubmits()) {
+		return preemptionDecision{}
+	}
+	standardAfter := int64(policy.GetStandardAfter())
+	return preemptionDecision{
+		Resubmit: true,
+		UseSpot:  standardAfter == 0 || preemptions < standardAfter,
+	}
+}
+
+// resolvePreemptionPolicy returns the request's policy, or the configured
+// default when the request does not set one.
+func resolvePreemptionPolicy(req *jennahv1.SubmitJobRequest, defaults config.PreemptionPolicy) (*jennahv1.PreemptionPolicy, error) {
+	policy := req.GetPreemptionPolicy()
+	if policy == nil {
+		policy = &jennahv1.PreemptionPolicy{
+			MaxResubmits:  defaults.MaxResubmits,
+			StandardAfter: defaults.StandardAfter,
+		}
+	}
+	if policy.GetMaxResubmits() < 0 || policy.GetStandardAfter() < 0 {
+		return nil, fmt.Errorf("preemption_policy values must not be negative")
+	}
+	return policy, nil
+}
+
+// policyFromJob reads the preemption policy stored with a job.
+func policyFromJob(job *database.Job) *jennahv1.PreemptionPolicy {
+	policy := &jennahv1.PreemptionPolicy{}
+	if job.MaxPreemptionResubmits != nil {
+		policy.MaxResubmits = int32(*job.MaxPreemptionResubmits)
+	}
+	if job.StandardAfterPreemptions != nil {
+		policy.StandardAfter = int32(*job.StandardAfterPreemptions)
+	}
+	return policy
+}
+
+// submitRequestFromJob rebuilds the SubmitJobRequest a job was created from,
+// so a preempted job can be planned and submitted again.
+func submitRequestFromJob(job *database.Job) (*jennahv1.SubmitJobRequest, error) {
+	req := &jennahv1.SubmitJobRequest{
+		JobId:            job.JobId,
+		ImageUri:         job.ImageUri,
+		Commands:         job.Commands,
+		Name:             ptrToString(job.Name),
+		ResourceProfile:  ptrToString(job.ResourceProfile),
+		MachineType:      ptrToString(job.MachineType),
+		ServiceAccount:   ptrToString(job.ServiceAccount),
+		PreemptionPolicy: policyFromJob(job),
+	}
+	if job.EnvVarsJson != nil && *job.EnvVarsJson != "" {
+		if err := json.Unmarshal([]byte(*job.EnvVarsJson), &req.EnvVars); err != nil {
+			return nil, fmt.Errorf("failed to decode stored env vars: %w", err)
+		}
+	}
+	if job.VolumesJson != nil && *job.VolumesJson != "" {
+		if err := json.Unmarshal([]byte(*job.VolumesJson), &req.Volumes); err != nil {
+			return nil, fmt.Errorf("failed to decode stored volumes: %w", err)
+		}
+	}
+	if job.RunnablesJson != nil && *job.RunnablesJson != "" {
+		if err := json.Unmarshal([]byte(*job.RunnablesJson), &req.Runnables); err != nil {
+			return nil, fmt.Errorf("failed to decode stored runnables: %w", err)
+		}
+	}
+	if job.LabelsJson != nil && *job.LabelsJson != "" {
+		if err := json.Unmarshal([]byte(*job.LabelsJson), &req.Labels); err != nil {
+			return nil, fmt.Errorf("failed to decode stored labels: %w", err)
+		}
+	}
+	if job.BootDiskSizeGb != nil {
+		req.BootDiskSizeGb = *job.BootDiskSizeGb
+	}
+	if job.UseSpotVms != nil {
+		req.UseSpotVms = *job.UseSpotVms
+	}
+	if job.AcceleratorType != nil {
+		req.Accelerator = &jennahv1.Accelerator{
+			Type:          *job.AcceleratorType,
+			DriverVersion: ptrToString(job.AcceleratorDriverVersion),
+		}
+		if job.AcceleratorCount != nil {
+			req.Accelerator.Count = *job.AcceleratorCount
+		}
+	}
+	if job.MemoryMib != nil || job.CpuMillis != nil || job.MaxRunDurationSeconds != nil {
+		req.ResourceOverride = &jennahv1.ResourceOverride{}
+		if job.MemoryMib != nil {
+			req.ResourceOverride.MemoryMib = *job.MemoryMib
+		}
+		if job.CpuMillis != nil {
+			req.ResourceOverride.CpuMillis = *job.CpuMillis
+		}
+		if job.MaxRunDurationSeconds != nil {
+			req.ResourceOverride.MaxRunDurationSeconds = *job.MaxRunDurationSeconds
+		}
+	}
+	return req, nil
+}
+
+// attemptProviderJobID returns the provider job ID for the resubmission that
+// follows the given preemption. Provider job IDs cannot be reused, so each
+// attempt gets a "-p<n>" suffix while keeping the 64-character limit and
+// the short internal-ID suffix that makes the base ID unique.
+func attemptProviderJobID(name, jobID string, preemptions int64) string {
+	base := generateProviderJobID(name, jobID)
+	attempt := fmt.Sprintf("-p%d", preemptions)
+	if over := len(base) + len(attempt) - 64; over > 0 {
+		const shortIDLen = 9 // "-" + 8 hex characters
+		prefix := base[:len(base)-shortIDLen]
+		prefix = strings.TrimRight(prefix[:len(prefix)-over], "-")
+		base = prefix + base[len(base)-shortIDLen:]
+	}
+	return base + attempt
+}
+
+// preemptionResubmitTimeout bounds the resubmission of a preempted job,
+// including the dispatcher's retries.
+const preemptionResubmitTimeout = 2 * time.Minute
+
+// resubmitPreempted runs handlePreemption for poller on its own goroutine,
+// so the status loop keeps polling its other jobs meanwhile. The loop leaves
+// the poller alone until the resubmission is done, and stops it if the job
+// was failed instead.
+func (s *WorkerService) resubmitPreempted(poller *JobPoller) {
+	poller.resubmitting.Store(true)
+	go func() {
+		defer poller.resubmitting.Store(false)
+		// Independent of the status loop cycle that saw the preemption.
+		ctx, cancel := context.WithTimeout(context.Background(), preemptionResubmitTimeout)
+		defer cancel()
+		if !s.handlePreemption(ctx, poller) {
+			poller.stop()
+		}
+	}()
+}
+
+// handlePreemption resubmits a preempted job according to its policy.
+// It returns true when a new attempt was submitted and the poller now tracks
+// it; otherwise the job has been marked FAILED and the poller should stop.
+// Every attempt is recorded in JobStateTransitions.
+func (s *WorkerService) handlePreemption(ctx context.Context, poller *JobPoller) bool {
+	oldStatus := poller.currentStatus
+
+	job, err := s.dbClient.GetJob(ctx, poller.tenantID, poller.jobID)
+	if err != nil {
+		log.Printf("Error loading preempted job %s: %v", poller.jobID, err)
+		s.failPreemptedJob(ctx, poller, oldStatus,
+			fmt.Sprintf("%s: spot VMs preempted; could not load job for resubmission: %v", preemptionReason, err))
+		return false
+	}
+
+	preemptions := int64(1)
+	if job.PreemptionCount != nil {
+		preemptions += *job.PreemptionCount
+	}
+	policy := policyFromJob(job)
+	decision := decidePreemption(policy, preemptions)
+	useSpot := job.UseSpotVms != nil && *job.UseSpotVms
+	if job.AttemptUseSpotVms != nil {
+		useSpot = *job.AttemptUseSpotVms
+	}
+	if decision.Resubmit {
+		useSpot = decision.UseSpot
+	}
+	if err := s.dbClient.RecordPreemption(ctx, job.TenantId, job.JobId, preemptions, useSpot, poller.gcpResourcePath); err != nil {
+		log.Printf("Error recording preemption for job %s: %v", job.JobId, err)
+	}
+
+	if !decision.Resubmit {
+		s.failPreemptedJob(ctx, poller, oldStatus,
+			fmt.Sprintf("%s: spot VMs preempted %d time(s); resubmission limit of %d reached (last attempt: %s)",
+				preemptionReason, preemptions, policy.GetMaxResubmits(), poller.gcpResourcePath))
+		return false
+	}
+
+	provisioning := "SPOT"
+	if !useSpot {
+		provisioning = "STANDARD"
+	}
+	log.Printf("Job %s preempted (%d/%d), resubmitting on %s", job.JobId, preemptions, policy.GetMaxResubmits(), provisioning)
+
+	reason := fmt.Sprintf("%s: spot VMs preempted (preemption %d, max resubmits %d, attempt: %s); resubmitting on %s",
+		preemptionReason, preemptions, policy.GetMaxResubmits(), poller.gcpResourcePath, provisioning)
+	if err := s.dbClient.UpdateJobStatus(ctx, job.TenantId, job.JobId, database.JobStatusPending); err != nil {
+		log.Printf("Error resetting preempted job %s to PENDING: %v", job.JobId, err)
+	}
+	if err := s.dbClient.RecordStateTransition(ctx, job.TenantId, job.JobId, uuid.New().String(), &oldStatus, database.JobStatusPending, &reason); err != nil {
+		log.Printf("Error recording state transition: %v", err)
+	}
+	poller.currentStatus = database.JobStatusPending
+
+	req, err := submitRequestFromJob(job)
+	if err != nil {
+		s.failPreemptedJob(ctx, poller, database.JobStatusPending,
+			fmt.Sprintf("%s: resubmission after preemption failed: %v", preemptionReason, err))
+		return false
+	}
+	req.UseSpotVms = useSpot
+
+	// Routing was decided on the first attempt; only the provisioning changes.
+	service, ok := router.ParseAssignedService(ptrToString(job.AssignedService))
+	if !ok {
+		service = poller.assignedService
+	}
+	plan, err := navigator.NavigateAssigned(req, job.JobId, s.jobConfig, service, ptrToString(job.RoutingReason))
+	if err != nil {
+		s.failPreemptedJob(ctx, poller, database.JobStatusPending,
+			fmt.Sprintf("%s: resubmission after preemption failed: %v", preemptionReason, err))
+		return false
+	}
+	plan.Config.JobID = attemptProviderJobID(req.Name, job.JobId, preemptions)
+	// Deterministic per attempt so a retried CreateJob cannot start the attempt twice.
+	plan.Config.RequestID = uuid.NewSHA1(uuid.NameSpaceURL, []byte(fmt.Sprintf("%s/%s/preemption/%d", job.TenantId, job.JobId, preemptions))).String()
+	plan.Config.TenantID = job.TenantId
+	plan.Config.JobLabels = navigator.JobLabels(plan.Config.JobLabels, job.TenantId, job.JobId, serviceTierFromPlan(plan), s.environment)
+
+	var jobResult *batch.JobResult
+	if s.dispatcher != nil {
+		jobResult, err = s.dispatcher.SubmitJob(ctx, plan.AssignedService, plan.Config)
+	} else {
+		jobResult, err = s.batchProvider.SubmitJob(ctx, plan.Config)
+	}
+	if err != nil {
+		s.failPreemptedJob(ctx, poller, database.JobStatusPending,
+			fmt.Sprintf("%s: resubmission after preemption failed: %v", preemptionReason, err))
+		return false
+	}
+
+	statusToSet := string(jobResult.InitialStatus)
+	if statusToSet == "" || statusToSet == string(batch.JobStatusUnknown) {
+		statusToSet = database.JobStatusRunning
+	}
+	serviceTier := serviceTierFromPlan(plan)
+	if err := s.dbClient.UpdateJobStatusAndGcpBatchJobPath(ctx, job.TenantId, job.JobId, statusToSet, jobResult.CloudResourcePath, serviceTier, plan.AssignedService.String(), plan.ClassifyReason, jobResult.Region); err != nil {
+		log.Printf("Error updating resubmitted job %s: %v", job.JobId, err)
+	}
+	resubmitted := fmt.Sprintf("Resubmitted after preemption %d on %s: %s", preemptions, provisioning, jobResult.CloudResourcePath)
+	pending := database.JobStatusPending
+	if err := s.dbClient.RecordStateTransition(ctx, job.TenantId, job.JobId, uuid.New().String(), &pending, statusToSet, &resubmitted); err != nil {
+		log.Printf("Error recording state transition: %v", err)
+	}
+
+	poller.gcpResourcePath = jobResult.CloudResourcePath
+	poller.currentStatus = statusToSet
+	poller.serviceTier = serviceTier
+	poller.assignedService = plan.AssignedService
+	poller.batchProvider = s.providerFor(plan.AssignedService)
+	poller.trackedSince = time.Now()
+	s.indexPollerPath(fmt.Sprintf("%s/%s", job.TenantId, job.JobId), poller.gcpResourcePath)
+	log.Printf("Job %s resubmitted as %s", job.JobId, jobResult.CloudResourcePath)
+	return true
+}
+
+// failPreemptedJob marks a preempted job FAILED with errorMessage, records
+// the transition, and publishes the terminal event.
+func (s *WorkerService) failPreemptedJob(ctx context.Context, poller *JobPoller, fromStatus, errorMessage string) {
+	log.Printf("Job %s failed after preemption: %s", poller.jobID, errorMessage)
+	if err := s.dbClient.FailJob(ctx, poller.tenantID, poller.jobID, errorMessage); err != nil {
+		log.Printf("Error updating job status to FAILED: %v", err)
+	}
+	transitionID := uuid.New().String()
+	if err := s.dbClient.RecordStateTransition(ctx, poller.tenantID, poller.jobID, transitionID, &fromStatus, database.JobStatusFailed, &errorMessage); err != nil {
+		log.Printf("Error recording state transition: %v", err)
+	}
+	poller.currentStatus = database.JobStatusFailed
+
+	event := notifier.BuildEvent(transitionID, poller.tenantID, poller.jobID, database.JobStatusFailed, fromStatus)
+	event.ErrorMessage = errorMessage
+	event.CloudResourcePath = poller.gcpResourcePath
+	event.ServiceTier = poller.serviceTier
+	event.AssignedService = poller.assignedService.String()
+	s.publishTerminalEvent(ctx, event, poller.tenantID)
+}
@@ -0,0 +1,183 @@
+package service
+
+import (
+	"strings"
+	"testing"
+
+	jennahv1 "github.com/alphauslabs/jennah/gen/proto"
+	"github.com/alphauslabs/jennah/internal/config"
+	"github.com/alphauslabs/jennah/internal/database"
+)
+
+func TestDecidePreemption(t *testing.T) {
+	policy := &jennahv1.PreemptionPolicy{MaxResubmits: 3, StandardAfter: 2}
+	cases := []struct {
+		preemptions int64
+		want        preemptionDecision
+	}{
+		{1, preemptionDecision{Resubmit: true, UseSpot: true}},
+		{2, preemptionDecision{Resubmit: true, UseSpot: false}},
+		{3, preemptionDecision{Resubmit: true, UseSpot: false}},
+		{4, preemptionDecision{}},
+	}
+	for _, tc := range cases {
+		if got := decidePreemption(policy, tc.preemptions); got != tc.want {
+			t.Errorf("preemption %d: got %+v, want %+v", tc.preemptions, got, tc.want)
+		}
+	}
+}
+
+func TestDecidePreemption_StaysOnSpotWithoutStandardAfter(t *testing.T) {
+	policy := &jennahv1.PreemptionPolicy{MaxResubmits: 5}
+	if got := decidePreemption(policy, 5); !got.Resubmit || !got.UseSpot {
+		t.Errorf("got %+v, want resubmit on spot", got)
+	}
+}
+
+func TestDecidePreemption_ZeroBudgetFailsImmediately(t *testing.T) {
+	if got := decidePreemption(&jennahv1.PreemptionPolicy{}, 1); got.Resubmit {
+		t.Errorf("got %+v, want no resubmission", got)
+	}
+}
+
+func TestResolvePreemptionPolicy(t *testing.T) {
+	defaults := config.PreemptionPolicy{MaxResubmits: 3, StandardAfter: 2}
+
+	got, err := resolvePreemptionPolicy(&jennahv1.SubmitJobRequest{}, defaults)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.GetMaxResubmits() != 3 || got.GetStandardAfter() != 2 {
+		t.Errorf("unset policy should use defaults, got %+v", got)
+	}
+
+	explicit := &jennahv1.PreemptionPolicy{MaxResubmits: 1}
+	got, err = resolvePreemptionPolicy(&jennahv1.SubmitJobRequest{PreemptionPolicy: explicit}, defaults)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.GetMaxResubmits() != 1 || got.GetStandardAfter() != 0 {
+		t.Errorf("explicit policy should win, got %+v", got)
+	}
+
+	negative := &jennahv1.PreemptionPolicy{MaxResubmits: -1}
+	if _, err := resolvePreemptionPolicy(&jennahv1.SubmitJobRequest{PreemptionPolicy: negative}, defaults); err == nil {
+		t.Error("expected error for negative max_resubmits")
+	}
+}
+
+func TestSubmitRequestFromJob(t *testing.T) {
+	envJSON := `{"MODE":"fast"}`
+	spot := true
+	memory := int64(4096)
+	maxResubmits := int64(2)
+	job := &database.Job{
+		JobId:                  "0b9f3c1e-2f4a-4c39-9d7e-5b1f6d2a8c10",
+		ImageUri:               "gcr.io/p/img:1",
+		Commands:               []string{"run"},
+		EnvVarsJson:            &envJSON,
+		UseSpotVms:             &spot,
+		MemoryMib:              &memory,
+		MaxPreemptionResubmits: &maxResubmits,
+	}
+
+	req, err := submitRequestFromJob(job)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if req.ImageUri != job.ImageUri || req.EnvVars["MODE"] != "fast" || !req.UseSpotVms {
+		t.Errorf("request not rebuilt from job: %+v", req)
+	}
+	if req.GetResourceOverride().GetMemoryMib() != 4096 {
+		t.Errorf("memory override: got %d, want 4096", req.GetResourceOverride().GetMemoryMib())
+	}
+	if req.GetPreemptionPolicy().GetMaxResubmits() != 2 {
+		t.Errorf("policy not carried over: %+v", req.GetPreemptionPolicy())
+	}
+}
+
+func TestSubmitRequestFromJob_CarriesAccelerator(t *testing.T) {
+	accelType := "nvidia-l4"
+	count := int64(2)
+	job := &database.Job{
+		JobId:            "0b9f3c1e-2f4a-4c39-9d7e-5b1f6d2a8c10",
+		ImageUri:         "gcr.io/p/trainer:1",
+		AcceleratorType:  &accelType,
+		AcceleratorCount: &count,
+	}
+
+	req, err := submitRequestFromJob(job)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if req.GetAccelerator().GetType() != "nvidia-l4" || req.GetAccelerator().GetCount() != 2 {
+		t.Errorf("accelerator not carried over: %+v", req.GetAccelerator())
+	}
+}
+
+func TestAttemptProviderJobID(t *testing.T) {
+	jobID := "0b9f3c1e-2f4a-4c39-9d7e-5b1f6d2a8c10"
+
+	if got := attemptProviderJobID("", jobID, 2); got != "jennah-0b9f3c1e-p2" {
+		t.Errorf("unnamed: got %q", got)
+	}
+
+	long := attemptProviderJobID(strings.Repeat("a", 80), jobID, 12)
+	if len(long) > 64 {
+		t.Errorf("length %d exceeds 64: %q", len(long), long)
+	}
+	if !strings.HasSuffix(long, "-0b9f3c1e-p12") {
+		t.Errorf("long name should keep the short ID and attempt suffix: %q", long)
+	}
+}
+
+func TestSubmitRequestFromJob_CarriesVolumes(t *testing.T) {
+	volumesJSON := `[{"mount_path":"/mnt/data","read_only":true,"gcs":{"bucket":"shared-data"}}]`
+	job := &database.Job{
+		JobId:       "0b9f3c1e-2f4a-4c39-9d7e-5b1f6d2a8c10",
+		ImageUri:    "gcr.io/p/img:1",
+		VolumesJson: &volumesJSON,
+	}
+
+	req, err := submitRequestFromJob(job)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(req.Volumes) != 1 || req.Volumes[0].GetGcs().GetBucket() != "shared-data" || !req.Volumes[0].GetReadOnly() {
+		t.Errorf("volumes not carried over: %+v", req.Volumes)
+	}
+}
+
+func TestSubmitRequestFromJob_CarriesRunnables(t *testing.T) {
+	runnablesJSON := `[{"name":"fetch","script":"gsutil cp gs://in/x /tmp/"},{"name":"main","commands":["run"]}]`
+	job := &database.Job{
+		JobId:         "0b9f3c1e-2f4a-4c39-9d7e-5b1f6d2a8c10",
+		ImageUri:      "gcr.io/p/img:1",
+		RunnablesJson: &runnablesJSON,
+	}
+
+	req, err := submitRequestFromJob(job)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(req.Runnables) != 2 || req.Runnables[0].GetScript() == "" || req.Runnables[1].GetCommands()[0] != "run" {
+		t.Errorf("runnables not carried over: %+v", req.Runnables)
+	}
+}
+
+func TestSubmitRequestFromJob_CarriesLabels(t *testing.T) {
+	labelsJSON := `{"cost-center":"cc-1234"}`
+	job := &database.Job{
+		JobId:      "0b9f3c1e-2f4a-4c39-9d7e-5b1f6d2a8c10",
+		ImageUri:   "gcr.io/p/img:1",
+		LabelsJson: &labelsJSON,
+	}
+
+	req, err := submitRequestFromJob(job)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if req.Labels["cost-center"] != "cc-1234" {
+		t.Errorf("labels not carried over: %v", req.Labels)
+	}
+}
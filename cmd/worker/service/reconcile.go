@@ -0,0 +1,347 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/google/uuid"
+
+	jennahv1 "github.com/alphauslabs/jennah/gen/proto"
+	batch "github.com/alphauslabs/jennah/internal/cloudexec"
+	"github.com/alphauslabs/jennah/internal/database"
+	"github.com/alphauslabs/jennah/internal/notifier"
+	"github.com/alphauslabs/jennah/internal/router"
+)
+
+// reconcileTaskName is the TaskLeases row that lets one worker at a time run
+// periodic reconciliation.
+const reconcileTaskName = "reconcile-resources"
+
+// defaultReconcileGracePeriod keeps reconciliation away from resources and
+// jobs that a submission may still be writing.
+const defaultReconcileGracePeriod = 10 * time.Minute
+
+// reconciledServices are the services whose resources are diffed against
+// the Jobs table.
+var reconciledServices = []router.AssignedService{
+	router.AssignedServiceCloudBatch,
+	router.AssignedServiceCloudRunJob,
+}
+
+// orphanCandidate is a listed resource no job points at.
+type orphanCandidate struct {
+	service  router.AssignedService
+	resource batch.ListedJob
+}
+
+// ReconcileResources diffs cloud resources against the Jobs table. Orphans
+// are Jennah-created resources no job points at; missing resources are
+// active jobs whose resource no longer exists.
+func (s *WorkerService) ReconcileResources(
+	ctx context.Context,
+	req *connect.Request[jennahv1.ReconcileResourcesRequest],
+) (*connect.Response[jennahv1.ReconcileResourcesResponse], error) {
+	log.Printf("Received reconcile request (dry_run=%v, orphan_action=%s, fail_missing=%v)",
+		req.Msg.DryRun, req.Msg.OrphanAction, req.Msg.FailMissing)
+
+	if _, ok := jennahv1.OrphanAction_name[int32(req.Msg.OrphanAction)]; !ok {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("unknown orphan_action %d", req.Msg.OrphanAction))
+	}
+	if req.Msg.GracePeriodSeconds < 0 {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("grace_period_seconds must not be negative"))
+	}
+
+	resp, err := s.reconcileResources(ctx, req.Msg)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	return connect.NewResponse(resp), nil
+}
+
+// StartResourceReconciler reconciles resources every interval with opts, on
+// whichever worker holds the reconcile task lease.
+func (s *WorkerService) StartResourceReconciler(ctx context.Context, interval time.Duration, opts *jennahv1.ReconcileResourcesRequest) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				log.Println("Resource reconciler stopped")
+				return
+			case <-ticker.C:
+				// Held for longer than the interval so the owner keeps it
+				// from tick to tick; another worker takes over if it dies.
+				leaseUntil := time.Now().UTC().Add(interval + interval/2)
+				owned, err := s.dbClient.TryAcquireTaskLease(ctx, reconcileTaskName, s.workerID, leaseUntil)
+				if err != nil {
+					log.Printf("Resource reconcile lease check failed: %v", err)
+					continue
+				}
+				if !owned {
+					continue
+				}
+				resp, err := s.reconcileResources(ctx, opts)
+				if err != nil {
+					log.Printf("Resource reconcile failed: %v", err)
+					continue
+				}
+				log.Printf("Resource reconcile complete: %d resource(s) and %d job(s) scanned, %d orphan(s), %d missing, %d listing error(s)",
+					resp.ResourcesScanned, resp.JobsScanned, len(resp.Orphans), len(resp.Missing), len(resp.Errors))
+			}
+		}
+	}()
+}
+
+func (s *WorkerService) reconcileResources(ctx context.Context, opts *jennahv1.ReconcileResourcesRequest) (*jennahv1.ReconcileResourcesResponse, error) {
+	grace := time.Duration(opts.GetGracePeriodSeconds()) * time.Second
+	if grace <= 0 {
+		grace = defaultReconcileGracePeriod
+	}
+	resp := &jennahv1.ReconcileResourcesResponse{DryRun: opts.GetDryRun()}
+
+	jobs, err := s.dbClient.ListJobsWithResources(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list jobs: %w", err)
+	}
+	resp.JobsScanned = int32(len(jobs))
+
+	providers := make(map[router.AssignedService]batch.Provider)
+	listed := make(map[router.AssignedService][]batch.ListedJob)
+	for _, svc := range reconciledServices {
+		provider := s.reconcileProvider(svc)
+		if provider == nil {
+			continue
+		}
+		resources, err := listJobResources(ctx, provider)
+		if err != nil {
+			log.Printf("Reconcile: skipping %s: %v", svc, err)
+			resp.Errors = append(resp.Errors, fmt.Sprintf("%s: %v", svc, err))
+			continue
+		}
+		providers[svc] = provider
+		listed[svc] = resources
+		resp.ResourcesScanned += int32(len(resources))
+	}
+
+	orphans, missing := diffResources(listed, jobs, time.Now().UTC(), grace)
+	for _, o := range orphans {
+		r := resolveOrphan(ctx, providers[o.service], o, opts.GetOrphanAction(), opts.GetDryRun())
+		log.Printf("Reconcile: orphaned %s resource %s (%s): action=%s %s", o.service, o.resource.Path, o.resource.Status, r.Action, r.Error)
+		resp.Orphans = append(resp.Orphans, r)
+	}
+	for _, job := range missing {
+		r, ok := s.resolveMissing(ctx, providers[jobAssignedService(job)], job, opts.GetFailMissing(), opts.GetDryRun())
+		if !ok {
+			continue
+		}
+		log.Printf("Reconcile: job %s (tenant %s) resource %s is missing: action=%s %s", job.JobId, job.TenantId, r.CloudResourcePath, r.Action, r.Error)
+		resp.Missing = append(resp.Missing, r)
+	}
+
+	return resp, nil
+}
+
+// reconcileProvider returns the provider for svc, or nil if the worker has
+// none configured.
+func (s *WorkerService) reconcileProvider(svc router.AssignedService) batch.Provider {
+	if s.dispatcher == nil {
+		if svc == router.AssignedServiceCloudBatch {
+			return s.batchProvider
+		}
+		return nil
+	}
+	provider, err := s.dispatcher.ProviderFor(svc)
+	if err != nil {
+		return nil
+	}
+	return provider
+}
+
+// listJobResources lists provider's Jennah-created jobs, falling back to
+// ListJobs, without status or creation time, for providers that do not
+// implement batch.ResourceLister.
+func listJobResources(ctx context.Context, provider batch.Provider) ([]batch.ListedJob, error) {
+	if lister, ok := provider.(batch.ResourceLister); ok {
+		return lister.ListJobResources(ctx)
+	}
+	paths, err := provider.ListJobs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var resources []batch.ListedJob
+	for _, path := range paths {
+		if batch.IsJennahJobPath(path) {
+			resources = append(resources, batch.ListedJob{Path: path, Status: batch.JobStatusUnknown})
+		}
+	}
+	return resources, nil
+}
+
+// diffResources compares listed resources against jobs. Resources of
+// earlier, preempted attempts belong to their job. Resources created within
+// grace, and jobs updated within grace, are left alone because a submission
+// may still be in flight. Jobs are only reported missing when their service
+// was listed.
+func diffResources(listed map[router.AssignedService][]batch.ListedJob, jobs []*database.Job, now time.Time, grace time.Duration) ([]orphanCandidate, []*database.Job) {
+	known := make(map[string]bool, len(jobs))
+	for _, job := range jobs {
+		known[*job.GcpBatchJobPath] = true
+		for _, path := range job.PreviousJobPaths {
+			known[path] = true
+		}
+	}
+
+	present := make(map[string]bool)
+	var orphans []orphanCandidate
+	for _, svc := range reconciledServices {
+		for _, resource := range listed[svc] {
+			present[resource.Path] = true
+			if known[resource.Path] {
+				continue
+			}
+			if !resource.CreateTime.IsZero() && now.Sub(resource.CreateTime) < grace {
+				continue
+			}
+			orphans = append(orphans, orphanCandidate{service: svc, resource: resource})
+		}
+	}
+
+	var missing []*database.Job
+	for _, job := range jobs {
+		if !isCancellableStatus(job.Status) || present[*job.GcpBatchJobPath] {
+			continue
+		}
+		if _, ok := listed[jobAssignedService(job)]; !ok {
+			continue
+		}
+		if now.Sub(job.UpdatedAt) < grace {
+			continue
+		}
+		missing = append(missing, job)
+	}
+	return orphans, missing
+}
+
+// resolveOrphan applies action to an orphaned resource unless dryRun is set,
+// and describes what was (or would be) done.
+func resolveOrphan(ctx context.Context, provider batch.Provider, o orphanCandidate, action jennahv1.OrphanAction, dryRun bool) *jennahv1.OrphanedResource {
+	r := &jennahv1.OrphanedResource{
+		CloudResourcePath: o.resource.Path,
+		AssignedService:   o.service.String(),
+		Status:            string(o.resource.Status),
+		Action:            "none",
+	}
+	if !o.resource.CreateTime.IsZero() {
+		r.CreateTime = o.resource.CreateTime.Format(time.RFC3339)
+	}
+
+	active := providerStatusActive(o.resource.Status)
+	switch action {
+	case jennahv1.OrphanAction_ORPHAN_ACTION_UNSPECIFIED:
+		return r
+	case jennahv1.OrphanAction_ORPHAN_ACTION_CANCEL:
+		r.Action = "cancel"
+		if !active {
+			r.Action = "skip: not running"
+		}
+	case jennahv1.OrphanAction_ORPHAN_ACTION_DELETE:
+		r.Action = "delete"
+	}
+	if o.resource.CreateTime.IsZero() {
+		// Without a creation time an in-flight submission cannot be ruled out.
+		r.Action = "skip: creation time unknown"
+	}
+	if dryRun || (r.Action != "cancel" && r.Action != "delete") {
+		return r
+	}
+
+	if active {
+		if err := provider.CancelJob(ctx, o.resource.Path); err != nil {
+			r.Error = fmt.Sprintf("cancel failed: %v", err)
+			return r
+		}
+	}
+	if r.Action == "delete" {
+		if err := provider.DeleteJob(ctx, o.resource.Path); err != nil {
+			r.Error = fmt.Sprintf("delete failed: %v", err)
+		}
+	}
+	return r
+}
+
+// resolveMissing confirms that a job's resource is really gone and, when
+// failMissing is set and dryRun is not, marks the job FAILED. It reports
+// false when the resource turned out to exist (the listing was stale).
+func (s *WorkerService) resolveMissing(ctx context.Context, provider batch.Provider, job *database.Job, failMissing, dryRun bool) (*jennahv1.MissingResource, bool) {
+	r := &jennahv1.MissingResource{
+		TenantId:          job.TenantId,
+		JobId:             job.JobId,
+		CloudResourcePath: *job.GcpBatchJobPath,
+		Status:            job.Status,
+		AssignedService:   jobAssignedService(job).String(),
+		Action:            "none",
+	}
+
+	if _, err := provider.GetJobStatus(ctx, *job.GcpBatchJobPath); err == nil {
+		return nil, false
+	} else if !batch.IsNotFound(err) {
+		r.Action = "skip: could not confirm"
+		r.Error = err.Error()
+		return r, true
+	}
+
+	if !failMissing {
+		return r, true
+	}
+	r.Action = "fail"
+	if dryRun {
+		return r, true
+	}
+
+	errorMessage := fmt.Sprintf("cloud resource %s no longer exists (found by reconciliation)", *job.GcpBatchJobPath)
+	if err := s.dbClient.FailJob(ctx, job.TenantId, job.JobId, errorMessage); err != nil {
+		r.Error = fmt.Sprintf("failed to mark job FAILED: %v", err)
+		return r, true
+	}
+	s.stopPollerForJob(job.TenantId, job.JobId)
+
+	transitionID := uuid.New().String()
+	fromStatus := job.Status
+	if err := s.dbClient.RecordStateTransition(ctx, job.TenantId, job.JobId, transitionID, &fromStatus, database.JobStatusFailed, &errorMessage); err != nil {
+		log.Printf("Error recording state transition: %v", err)
+	}
+
+	event := notifier.BuildEvent(transitionID, job.TenantId, job.JobId, database.JobStatusFailed, fromStatus)
+	event.ErrorMessage = errorMessage
+	event.CloudResourcePath = *job.GcpBatchJobPath
+	event.ServiceTier = ptrToString(job.ServiceTier)
+	event.AssignedService = r.AssignedService
+	s.publishTerminalEvent(ctx, event, job.TenantId)
+	return r, true
+}
+
+// providerStatusActive reports whether a provider status may still be
+// queued or running. Unknown counts as active.
+func providerStatusActive(status batch.JobStatus) bool {
+	switch status {
+	case batch.JobStatusCompleted, batch.JobStatusFailed, batch.JobStatusPreempted, batch.JobStatusCancelled:
+		return false
+	default:
+		return true
+	}
+}
+
+// jobAssignedService parses a job's stored AssignedService, defaulting to
+// Cloud Batch for jobs recorded before the column existed.
+func jobAssignedService(job *database.Job) router.AssignedService {
+	if job.AssignedService != nil && *job.AssignedService == router.AssignedServiceCloudRunJob.String() {
+		return router.AssignedServiceCloudRunJob
+	}
+	return router.AssignedServiceCloudBatch
+}
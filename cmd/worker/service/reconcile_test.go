@@ -0,0 +1,182 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	jennahv1 "github.com/alphauslabs/jennah/gen/proto"
+	batch "github.com/alphauslabs/jennah/internal/cloudexec"
+	"github.com/alphauslabs/jennah/internal/database"
+	"github.com/alphauslabs/jennah/internal/router"
+)
+
+// recordingProvider records cancels and deletes and reports listed paths as
+// existing.
+type recordingProvider struct {
+	fakeStatusProvider
+	existing  map[string]bool
+	cancelled []string
+	deleted   []string
+}
+
+func (p *recordingProvider) GetJobStatus(ctx context.Context, path string) (batch.JobStatus, error) {
+	if p.existing[path] {
+		return batch.JobStatusRunning, nil
+	}
+	return batch.JobStatusUnknown, status.Error(codes.NotFound, "job not found")
+}
+
+func (p *recordingProvider) CancelJob(ctx context.Context, path string) error {
+	p.cancelled = append(p.cancelled, path)
+	return nil
+}
+
+func (p *recordingProvider) DeleteJob(ctx context.Context, path string) error {
+	p.deleted = append(p.deleted, path)
+	return nil
+}
+
+func reconcileJob(jobID, status, path, service string, updatedAt time.Time) *database.Job {
+	return &database.Job{
+		TenantId:        "tenant-1",
+		JobId:           jobID,
+		Status:          status,
+		GcpBatchJobPath: &path,
+		AssignedService: &service,
+		UpdatedAt:       updatedAt,
+	}
+}
+
+func TestDiffResources(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	old := now.Add(-time.Hour)
+	recent := now.Add(-time.Minute)
+	batchSvc := router.AssignedServiceCloudBatch.String()
+	runSvc := router.AssignedServiceCloudRunJob.String()
+
+	listed := map[router.AssignedService][]batch.ListedJob{
+		router.AssignedServiceCloudBatch: {
+			{Path: "batch/jennah-known", Status: batch.JobStatusRunning, CreateTime: old},
+			{Path: "batch/jennah-orphan", Status: batch.JobStatusRunning, CreateTime: old},
+			{Path: "batch/jennah-young", Status: batch.JobStatusRunning, CreateTime: recent},
+			{Path: "batch/jennah-ageless", Status: batch.JobStatusUnknown},
+		},
+	}
+	jobs := []*database.Job{
+		reconcileJob("known", database.JobStatusRunning, "batch/jennah-known", batchSvc, old),
+		reconcileJob("gone", database.JobStatusRunning, "batch/jennah-gone", batchSvc, old),
+		reconcileJob("just-submitted", database.JobStatusPending, "batch/jennah-new", batchSvc, recent),
+		reconcileJob("finished", database.JobStatusCompleted, "batch/jennah-done", batchSvc, old),
+		// Cloud Run was not listed, so its jobs cannot be judged missing.
+		reconcileJob("unlisted", database.JobStatusRunning, "run/jennah-unlisted", runSvc, old),
+	}
+
+	orphans, missing := diffResources(listed, jobs, now, 10*time.Minute)
+
+	var orphanPaths []string
+	for _, o := range orphans {
+		orphanPaths = append(orphanPaths, o.resource.Path)
+	}
+	if len(orphanPaths) != 2 || orphanPaths[0] != "batch/jennah-orphan" || orphanPaths[1] != "batch/jennah-ageless" {
+		t.Errorf("orphans = %v, want [batch/jennah-orphan batch/jennah-ageless]", orphanPaths)
+	}
+	if len(missing) != 1 || missing[0].JobId != "gone" {
+		t.Errorf("missing = %v, want only job gone", missing)
+	}
+}
+
+func TestDiffResources_PreemptedAttemptsAreKnown(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	old := now.Add(-time.Hour)
+	batchSvc := router.AssignedServiceCloudBatch.String()
+
+	listed := map[router.AssignedService][]batch.ListedJob{
+		router.AssignedServiceCloudBatch: {
+			{Path: "batch/jennah-train-1a2b3c4d", Status: batch.JobStatusPreempted, CreateTime: old},
+			{Path: "batch/jennah-train-1a2b3c4d-p1", Status: batch.JobStatusPreempted, CreateTime: old},
+			{Path: "batch/jennah-train-1a2b3c4d-p2", Status: batch.JobStatusRunning, CreateTime: old},
+		},
+	}
+	job := reconcileJob("train", database.JobStatusRunning, "batch/jennah-train-1a2b3c4d-p2", batchSvc, old)
+	job.PreviousJobPaths = []string{"batch/jennah-train-1a2b3c4d", "batch/jennah-train-1a2b3c4d-p1"}
+
+	orphans, missing := diffResources(listed, []*database.Job{job}, now, 10*time.Minute)
+	if len(orphans) != 0 {
+		t.Errorf("orphans = %v, want none: every resource is an attempt of job train", orphans)
+	}
+	if len(missing) != 0 {
+		t.Errorf("missing = %v, want none", missing)
+	}
+}
+
+func TestResolveOrphan(t *testing.T) {
+	created := time.Now().Add(-time.Hour)
+	running := orphanCandidate{
+		service:  router.AssignedServiceCloudBatch,
+		resource: batch.ListedJob{Path: "batch/jennah-running", Status: batch.JobStatusRunning, CreateTime: created},
+	}
+	finished := orphanCandidate{
+		service:  router.AssignedServiceCloudBatch,
+		resource: batch.ListedJob{Path: "batch/jennah-finished", Status: batch.JobStatusCompleted, CreateTime: created},
+	}
+	ageless := orphanCandidate{
+		service:  router.AssignedServiceCloudBatch,
+		resource: batch.ListedJob{Path: "batch/jennah-ageless", Status: batch.JobStatusRunning},
+	}
+
+	tests := []struct {
+		name          string
+		orphan        orphanCandidate
+		action        jennahv1.OrphanAction
+		dryRun        bool
+		wantAction    string
+		wantCancelled int
+		wantDeleted   int
+	}{
+		{"report only", running, jennahv1.OrphanAction_ORPHAN_ACTION_UNSPECIFIED, false, "none", 0, 0},
+		{"dry run cancel", running, jennahv1.OrphanAction_ORPHAN_ACTION_CANCEL, true, "cancel", 0, 0},
+		{"cancel running", running, jennahv1.OrphanAction_ORPHAN_ACTION_CANCEL, false, "cancel", 1, 0},
+		{"cancel finished", finished, jennahv1.OrphanAction_ORPHAN_ACTION_CANCEL, false, "skip: not running", 0, 0},
+		{"delete running cancels first", running, jennahv1.OrphanAction_ORPHAN_ACTION_DELETE, false, "delete", 1, 1},
+		{"delete finished", finished, jennahv1.OrphanAction_ORPHAN_ACTION_DELETE, false, "delete", 0, 1},
+		{"dry run delete", finished, jennahv1.OrphanAction_ORPHAN_ACTION_DELETE, true, "delete", 0, 0},
+		{"unknown age is never touched", ageless, jennahv1.OrphanAction_ORPHAN_ACTION_DELETE, false, "skip: creation time unknown", 0, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := &recordingProvider{}
+			got := resolveOrphan(context.Background(), provider, tt.orphan, tt.action, tt.dryRun)
+			if got.Action != tt.wantAction {
+				t.Errorf("action = %q, want %q", got.Action, tt.wantAction)
+			}
+			if len(provider.cancelled) != tt.wantCancelled || len(provider.deleted) != tt.wantDeleted {
+				t.Errorf("cancelled %v, deleted %v; want %d cancels and %d deletes",
+					provider.cancelled, provider.deleted, tt.wantCancelled, tt.wantDeleted)
+			}
+		})
+	}
+}
+
+func TestResolveMissing_ConfirmsBeforeReporting(t *testing.T) {
+	s := &WorkerService{}
+	provider := &recordingProvider{existing: map[string]bool{"batch/jennah-stale-listing": true}}
+	svc := router.AssignedServiceCloudBatch.String()
+
+	stale := reconcileJob("stale", database.JobStatusRunning, "batch/jennah-stale-listing", svc, time.Time{})
+	if _, ok := s.resolveMissing(context.Background(), provider, stale, true, true); ok {
+		t.Error("job whose resource still exists was reported missing")
+	}
+
+	gone := reconcileJob("gone", database.JobStatusRunning, "batch/jennah-gone", svc, time.Time{})
+	got, ok := s.resolveMissing(context.Background(), provider, gone, true, true)
+	if !ok {
+		t.Fatal("job whose resource is gone was not reported")
+	}
+	if got.Action != "fail" {
+		t.Errorf("dry-run action = %q, want fail", got.Action)
+	}
+}
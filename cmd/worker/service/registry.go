@@ -0,0 +1,57 @@
+package service
+
+import (
+	"context"
+	"log"
+
+	"github.com/alphauslabs/jennah/internal/database"
+)
+
+// SetRegistration makes the worker register w in the Workers table when its
+// lease loop starts and heartbeat on every tick, so gateways can route to it.
+// Without a registration the worker is only reachable through a static
+// gateway --worker-ips list.
+func (s *WorkerService) SetRegistration(w *database.Worker) {
+	s.registration = w
+}
+
+// register records the worker as ACTIVE.
+func (s *WorkerService) register(ctx context.Context) {
+	if s.registration == nil {
+		return
+	}
+	if err := s.dbClient.RegisterWorker(ctx, s.registration); err != nil {
+		log.Printf("Worker registration failed: %v", err)
+		return
+	}
+	log.Printf("Registered worker %s at %s (capacity=%d)", s.registration.WorkerId, s.registration.Address, s.registration.Capacity)
+}
+
+// heartbeat refreshes the worker's registry row, registering again when the
+// row is gone.
+func (s *WorkerService) heartbeat(ctx context.Context) {
+	if s.registration == nil {
+		return
+	}
+	found, err := s.dbClient.HeartbeatWorker(ctx, s.registration.WorkerId)
+	if err != nil {
+		log.Printf("Worker heartbeat failed: %v", err)
+		return
+	}
+	if !found {
+		s.register(ctx)
+	}
+}
+
+// Drain marks the worker DRAINING so gateways stop routing to it before it
+// shuts down.
+func (s *WorkerService) Drain(ctx context.Context) {
+	if s.registration == nil {
+		return
+	}
+	if err := s.dbClient.SetWorkerState(ctx, s.registration.WorkerId, database.WorkerStateDraining); err != nil {
+		log.Printf("Failed to mark worker draining: %v", err)
+		return
+	}
+	log.Printf("Worker %s marked %s", s.registration.WorkerId, database.WorkerStateDraining)
+}
@@ -0,0 +1,241 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"connectrpc.com/connect"
+
+	jennahv1 "github.com/alphauslabs/jennah/gen/proto"
+	"github.com/alphauslabs/jennah/internal/database"
+	"github.com/alphauslabs/jennah/internal/navigator"
+	"github.com/alphauslabs/jennah/internal/router"
+)
+
+const (
+	defaultSimulationWindow  = 30 * 24 * time.Hour
+	defaultSimulationSamples = 5
+	defaultSimulationMaxJobs = 10000
+)
+
+// serviceRate is the approximate us-central1 list price of a service and its
+// typical start-up delay, used to estimate the impact of moving jobs.
+type serviceRate struct {
+	vCPUHourUSD float64
+	gibHourUSD  float64
+	startup     time.Duration
+}
+
+// serviceRates are the prices routing simulations use. Cloud Batch is
+// charged as the Compute Engine VMs it starts; Spot VMs are discounted by
+// spotPriceFactor.
+var serviceRates = map[router.AssignedService]serviceRate{
+	router.AssignedServiceCloudRunJob: {vCPUHourUSD: 0.0648, gibHourUSD: 0.0072, startup: 10 * time.Second},
+	router.AssignedServiceCloudBatch:  {vCPUHourUSD: 0.0316, gibHourUSD: 0.0042, startup: 90 * time.Second},
+}
+
+const spotPriceFactor = 0.35
+
+// SimulateRouting replays stored jobs through candidate routing rules and
+// reports which jobs would move between services, with estimated cost and
+// start-up impact. It only plans; nothing is submitted to a provider.
+func (s *WorkerService) SimulateRouting(
+	ctx context.Context,
+	req *connect.Request[jennahv1.SimulateRoutingRequest],
+) (*connect.Response[jennahv1.SimulateRoutingResponse], error) {
+	if req.Msg.SinceSeconds < 0 || req.Msg.SamplesPerChange < 0 || req.Msg.MaxJobs < 0 {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("since_seconds, samples_per_change and max_jobs must not be negative"))
+	}
+
+	rules, err := s.simulationRules(req.Msg.Rules)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	window := defaultSimulationWindow
+	if req.Msg.SinceSeconds > 0 {
+		window = time.Duration(req.Msg.SinceSeconds) * time.Second
+	}
+	maxJobs := int64(defaultSimulationMaxJobs)
+	if req.Msg.MaxJobs > 0 {
+		maxJobs = int64(req.Msg.MaxJobs)
+	}
+	since := time.Now().UTC().Add(-window)
+
+	jobs, err := s.dbClient.ListJobsCreatedSince(ctx, since, maxJobs)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to list jobs: %w", err))
+	}
+	log.Printf("Simulating routing for %d job(s) since %s against %d rule(s)", len(jobs), since.Format(time.RFC3339), rules.Len())
+
+	samples := defaultSimulationSamples
+	if req.Msg.SamplesPerChange > 0 {
+		samples = int(req.Msg.SamplesPerChange)
+	}
+	chain := router.PolicyChain{router.RequiredPolicy{}}
+	if rules.Len() > 0 {
+		chain = append(chain, router.NewRulesPolicy(rules))
+	}
+	chain = append(chain, router.CapabilityPolicy{})
+
+	resp := simulateRouting(ctx, jobs, func(ctx context.Context, req *jennahv1.SubmitJobRequest, tenantID, jobID string) (*navigator.NavigationPlan, error) {
+		return navigator.NavigateWithPolicies(ctx, req, tenantID, jobID, s.jobConfig, s.routingCandidates(), chain)
+	}, samples)
+	resp.Rules = int32(rules.Len())
+	resp.Since = since.Format(time.RFC3339)
+	return connect.NewResponse(resp), nil
+}
+
+// simulationRules parses candidate rules, or returns the rules in use when
+// none are given.
+func (s *WorkerService) simulationRules(candidate string) (*router.RuleSet, error) {
+	if candidate != "" {
+		set, err := router.ParseRules([]byte(candidate))
+		if err != nil {
+			return nil, fmt.Errorf("invalid rules: %w", err)
+		}
+		return set, nil
+	}
+	for _, p := range s.routingPolicies {
+		if rp, ok := p.(*router.RulesPolicy); ok {
+			return rp.Rules(), nil
+		}
+	}
+	return nil, nil
+}
+
+// planFunc plans one stored job; it must not call providers.
+type planFunc func(ctx context.Context, req *jennahv1.SubmitJobRequest, tenantID, jobID string) (*navigator.NavigationPlan, error)
+
+// simulateRouting replans every job and groups the ones whose service
+// changes by (from, to), keeping up to samples example jobs per group.
+func simulateRouting(ctx context.Context, jobs []*database.Job, plan planFunc, samples int) *jennahv1.SimulateRoutingResponse {
+	resp := &jennahv1.SimulateRoutingResponse{}
+	changes := make(map[[2]router.AssignedService]*jennahv1.RoutingChange)
+
+	for _, job := range jobs {
+		req, err := submitRequestFromJob(job)
+		if err != nil {
+			resp.JobsSkipped++
+			continue
+		}
+		resp.JobsReplayed++
+
+		current := router.AssignedServiceUnspecified
+		if job.AssignedService != nil {
+			current, _ = router.ParseAssignedService(*job.AssignedService)
+		}
+
+		simulated := router.AssignedServiceUnspecified
+		var simulatedReason string
+		var resources *navigator.NavigationPlan
+		p, err := plan(ctx, req, job.TenantId, job.JobId)
+		if err != nil {
+			simulatedReason = err.Error()
+		} else {
+			simulated = p.AssignedService
+			simulatedReason = p.ClassifyReason
+			resources = p
+		}
+		// Jobs that were never dispatched have nothing to move from.
+		if current == router.AssignedServiceUnspecified || current == simulated {
+			continue
+		}
+		resp.JobsChanged++
+
+		key := [2]router.AssignedService{current, simulated}
+		change, ok := changes[key]
+		if !ok {
+			change = &jennahv1.RoutingChange{FromService: current.String(), ToService: simulated.String()}
+			changes[key] = change
+		}
+		change.Jobs++
+		change.StartupDeltaSeconds += int64((serviceRates[simulated].startup - serviceRates[current].startup).Seconds())
+
+		if runtime, ok := observedRuntime(job); ok && resources != nil {
+			vCPUs, gib := planSize(resources)
+			spot := job.UseSpotVms != nil && *job.UseSpotVms
+			change.JobsWithRuntime++
+			change.ObservedRuntimeSeconds += int64(runtime.Seconds())
+			change.CurrentCostUsd += estimateCost(current, vCPUs, gib, runtime, spot)
+			change.SimulatedCostUsd += estimateCost(simulated, vCPUs, gib, runtime, spot)
+		}
+
+		if len(change.Samples) < samples {
+			change.Samples = append(change.Samples, &jennahv1.RoutingSample{
+				TenantId:        job.TenantId,
+				JobId:           job.JobId,
+				Name:            ptrToString(job.Name),
+				CreatedAt:       job.CreatedAt.Format(time.RFC3339),
+				CurrentReason:   ptrToString(job.RoutingReason),
+				SimulatedReason: simulatedReason,
+			})
+		}
+	}
+
+	for _, change := range changes {
+		resp.Changes = append(resp.Changes, change)
+		resp.CostDeltaUsd += change.SimulatedCostUsd - change.CurrentCostUsd
+		resp.StartupDeltaSeconds += change.StartupDeltaSeconds
+	}
+	sort.Slice(resp.Changes, func(i, j int) bool {
+		if resp.Changes[i].Jobs != resp.Changes[j].Jobs {
+			return resp.Changes[i].Jobs > resp.Changes[j].Jobs
+		}
+		return resp.Changes[i].FromService+resp.Changes[i].ToService < resp.Changes[j].FromService+resp.Changes[j].ToService
+	})
+	return resp
+}
+
+// observedRuntime is how long a finished job ran. StartedAt and CompletedAt
+// are used when recorded; otherwise the job's creation and its last status
+// update bound the runtime.
+func observedRuntime(job *database.Job) (time.Duration, bool) {
+	switch job.Status {
+	case database.JobStatusCompleted, database.JobStatusFailed, database.JobStatusCancelled:
+	default:
+		return 0, false
+	}
+	start := job.CreatedAt
+	if job.StartedAt != nil {
+		start = *job.StartedAt
+	}
+	end := job.UpdatedAt
+	if job.CompletedAt != nil {
+		end = *job.CompletedAt
+	}
+	if !end.After(start) {
+		return 0, false
+	}
+	return end.Sub(start), true
+}
+
+// planSize returns the vCPUs and GiB of memory a plan requests across its tasks.
+func planSize(plan *navigator.NavigationPlan) (float64, float64) {
+	tasks := int64(1)
+	if tg := plan.Config.TaskGroup; tg != nil && tg.TaskCount > 0 {
+		tasks = tg.TaskCount
+	}
+	var cpuMillis, memoryMiB int64
+	if r := plan.Config.Resources; r != nil {
+		cpuMillis, memoryMiB = r.CPUMillis, r.MemoryMiB
+	}
+	return float64(cpuMillis*tasks) / 1000, float64(memoryMiB*tasks) / 1024
+}
+
+// estimateCost prices running vCPUs and gib for runtime on svc.
+func estimateCost(svc router.AssignedService, vCPUs, gib float64, runtime time.Duration, spot bool) float64 {
+	rate, ok := serviceRates[svc]
+	if !ok {
+		return 0
+	}
+	cost := (vCPUs*rate.vCPUHourUSD + gib*rate.gibHourUSD) * runtime.Hours()
+	if spot && svc == router.AssignedServiceCloudBatch {
+		cost *= spotPriceFactor
+	}
+	return cost
+}
@@ -0,0 +1,98 @@
+package service
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	jennahv1 "github.com/alphauslabs/jennah/gen/proto"
+	batch "github.com/alphauslabs/jennah/internal/cloudexec"
+	"github.com/alphauslabs/jennah/internal/database"
+	"github.com/alphauslabs/jennah/internal/navigator"
+	"github.com/alphauslabs/jennah/internal/router"
+)
+
+func TestSimulateRouting_GroupsChangesWithCostAndSamples(t *testing.T) {
+	created := time.Date(2026, 9, 1, 10, 0, 0, 0, time.UTC)
+	run, batchSvc := router.AssignedServiceCloudRunJob.String(), router.AssignedServiceCloudBatch.String()
+	reason := "capability: cheapest fit"
+	badEnv := "{not json"
+	big := int64(16384)
+
+	jobs := []*database.Job{
+		// Big jobs that ran on Cloud Run for an hour; the rules move them.
+		{JobId: "a", TenantId: "t1", ImageUri: "img", Status: database.JobStatusCompleted, AssignedService: &run, RoutingReason: &reason, MemoryMib: &big, CreatedAt: created, UpdatedAt: created.Add(time.Hour)},
+		{JobId: "b", TenantId: "t1", ImageUri: "img", Status: database.JobStatusRunning, AssignedService: &run, MemoryMib: &big, CreatedAt: created, UpdatedAt: created},
+		{JobId: "c", TenantId: "t2", ImageUri: "img", Status: database.JobStatusCompleted, AssignedService: &run, MemoryMib: &big, CreatedAt: created, UpdatedAt: created.Add(time.Hour)},
+		// Unchanged, never dispatched and undecodable jobs.
+		{JobId: "d", TenantId: "t2", ImageUri: "img", Status: database.JobStatusCompleted, AssignedService: &batchSvc, MemoryMib: &big, CreatedAt: created, UpdatedAt: created},
+		{JobId: "e", TenantId: "t2", ImageUri: "img", Status: database.JobStatusPending, MemoryMib: &big, CreatedAt: created, UpdatedAt: created},
+		{JobId: "f", TenantId: "t2", ImageUri: "img", Status: database.JobStatusCompleted, AssignedService: &run, EnvVarsJson: &badEnv, CreatedAt: created, UpdatedAt: created},
+	}
+
+	plan := func(_ context.Context, req *jennahv1.SubmitJobRequest, _, _ string) (*navigator.NavigationPlan, error) {
+		p := &navigator.NavigationPlan{
+			AssignedService: router.AssignedServiceCloudRunJob,
+			ClassifyReason:  "rule small",
+			Config:          batch.JobConfig{Resources: &batch.ResourceRequirements{CPUMillis: 2000, MemoryMiB: 1024}},
+		}
+		if req.GetResourceOverride().GetMemoryMib() > 8192 {
+			p.AssignedService = router.AssignedServiceCloudBatch
+			p.ClassifyReason = "rule big"
+		}
+		return p, nil
+	}
+
+	resp := simulateRouting(context.Background(), jobs, plan, 1)
+
+	if resp.JobsReplayed != 5 || resp.JobsSkipped != 1 || resp.JobsChanged != 3 {
+		t.Fatalf("replayed/skipped/changed: got %d/%d/%d, want 5/1/3", resp.JobsReplayed, resp.JobsSkipped, resp.JobsChanged)
+	}
+	if len(resp.Changes) != 1 {
+		t.Fatalf("changes: got %d, want 1: %+v", len(resp.Changes), resp.Changes)
+	}
+	c := resp.Changes[0]
+	if c.FromService != run || c.ToService != batchSvc || c.Jobs != 3 {
+		t.Errorf("change: got %s→%s x%d", c.FromService, c.ToService, c.Jobs)
+	}
+	if c.JobsWithRuntime != 2 || c.ObservedRuntimeSeconds != 7200 {
+		t.Errorf("runtime: got %d job(s), %ds; want 2, 7200s", c.JobsWithRuntime, c.ObservedRuntimeSeconds)
+	}
+	// 2 vCPU and 1 GiB for two hours in total.
+	wantCurrent := 2 * (2*0.0648 + 0.0072)
+	wantSimulated := 2 * (2*0.0316 + 0.0042)
+	if math.Abs(c.CurrentCostUsd-wantCurrent) > 1e-9 || math.Abs(c.SimulatedCostUsd-wantSimulated) > 1e-9 {
+		t.Errorf("cost: got %.4f→%.4f, want %.4f→%.4f", c.CurrentCostUsd, c.SimulatedCostUsd, wantCurrent, wantSimulated)
+	}
+	if math.Abs(resp.CostDeltaUsd-(wantSimulated-wantCurrent)) > 1e-9 {
+		t.Errorf("cost delta: got %.4f", resp.CostDeltaUsd)
+	}
+	if c.StartupDeltaSeconds != 3*80 || resp.StartupDeltaSeconds != 3*80 {
+		t.Errorf("startup delta: got %d/%d, want 240", c.StartupDeltaSeconds, resp.StartupDeltaSeconds)
+	}
+	if len(c.Samples) != 1 || c.Samples[0].JobId != "a" || c.Samples[0].CurrentReason != reason || c.Samples[0].SimulatedReason != "rule big" {
+		t.Errorf("samples: got %+v", c.Samples)
+	}
+}
+
+func TestObservedRuntime_PrefersRecordedTimestamps(t *testing.T) {
+	created := time.Date(2026, 9, 1, 10, 0, 0, 0, time.UTC)
+	started := created.Add(10 * time.Minute)
+	completed := started.Add(30 * time.Minute)
+
+	job := &database.Job{Status: database.JobStatusFailed, CreatedAt: created, UpdatedAt: completed.Add(time.Minute), StartedAt: &started, CompletedAt: &completed}
+	if got, ok := observedRuntime(job); !ok || got != 30*time.Minute {
+		t.Errorf("got %v, %v; want 30m", got, ok)
+	}
+
+	job = &database.Job{Status: database.JobStatusCompleted, CreatedAt: created, UpdatedAt: created.Add(time.Hour)}
+	if got, ok := observedRuntime(job); !ok || got != time.Hour {
+		t.Errorf("fallback: got %v, %v; want 1h", got, ok)
+	}
+
+	job = &database.Job{Status: database.JobStatusRunning, CreatedAt: created, UpdatedAt: created.Add(time.Hour)}
+	if _, ok := observedRuntime(job); ok {
+		t.Error("running job should have no observed runtime")
+	}
+}
@@ -14,11 +14,13 @@ import (
 	"github.com/alphauslabs/jennah/internal/database"
 	"github.com/alphauslabs/jennah/internal/dispatcher"
 	"github.com/alphauslabs/jennah/internal/notifier"
+	"github.com/alphauslabs/jennah/internal/router"
 )
 
-// WorkerService implements the DeploymentService RPC handlers for the worker.
+// WorkerService implements the DeploymentService and AdminService RPC handlers for the worker.
 type WorkerService struct {
 	jennahv1connect.UnimplementedDeploymentServiceHandler
+	jennahv1connect.UnimplementedAdminServiceHandler
 	dbClient       *database.Client
 	batchProvider  batch.Provider
 	dispatcher     *dispatcher.Dispatcher
@@ -27,9 +29,32 @@ type WorkerService struct {
 	leaseTTL       time.Duration
 	claimInterval  time.Duration
 	pollers        map[string]*JobPoller // Key: "tenantID/jobID"
+	pollerPaths    map[string]string     // Poller key → cloud resource path
+	pollersByPath  map[string]string     // Cloud resource path → poller key
 	pollersMutex   sync.Mutex
 	gcpBatchClient *gcpbatch.Client
 	notifier       notifier.Notifier
+	environment    string // jennah-env label value
+
+	// routingPolicies decides where each job runs; nil means router.DefaultPolicies.
+	routingPolicies router.PolicyChain
+
+	// statusLoops poll the jobs of each service; guarded by pollersMutex.
+	statusLoops map[router.AssignedService]*statusLoop
+	// leases renews job leases in bulk for the status loops.
+	leases leaseStore
+
+	// registration is this worker's Workers row; nil disables registry
+	// heartbeats.
+	registration *database.Worker
+
+	// peerDial creates clients for forwarding requests to other workers;
+	// nil means plain HTTP.
+	peerDial func(address string) jennahv1connect.DeploymentServiceClient
+
+	// safetyPollInterval is how often Cloud Batch jobs are polled while
+	// status notifications are received; zero when they are not.
+	safetyPollInterval time.Duration
 }
 
 // NewWorkerService creates a new WorkerService with the given dependencies.
@@ -43,6 +68,7 @@ func NewWorkerService(
 	leaseTTL time.Duration,
 	claimInterval time.Duration,
 	n notifier.Notifier,
+	environment string,
 ) *WorkerService {
 	return &WorkerService{
 		dbClient:       dbClient,
@@ -53,8 +79,10 @@ func NewWorkerService(
 		leaseTTL:       leaseTTL,
 		claimInterval:  claimInterval,
 		pollers:        make(map[string]*JobPoller),
+		leases:         dbClient,
 		gcpBatchClient: gcpBatchClient,
 		notifier:       n,
+		environment:    environment,
 	}
 }
 
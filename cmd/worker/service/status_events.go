@@ -0,0 +1,95 @@
+package service
+
+import (
+	"context"
+	"log"
+	"time"
+
+	batch "github.com/alphauslabs/jennah/internal/cloudexec"
+	"github.com/alphauslabs/jennah/internal/database"
+	"github.com/alphauslabs/jennah/internal/router"
+)
+
+// StartStatusSubscriber applies provider status notifications to the pollers
+// of jobs this worker owns. While it runs, Cloud Batch jobs are polled only
+// every safetyPollInterval to catch missed notifications; if the
+// subscription ends, pollers return to the adaptive interval.
+func (s *WorkerService) StartStatusSubscriber(ctx context.Context, sub batch.StatusSubscriber, safetyPollInterval time.Duration) {
+	s.setSafetyPollInterval(safetyPollInterval)
+
+	go func() {
+		defer s.setSafetyPollInterval(0)
+
+		err := sub.Receive(ctx, func(_ context.Context, n batch.StatusNotification) {
+			s.deliverStatusNotification(n)
+		})
+		if err != nil && ctx.Err() == nil {
+			log.Printf("Status subscriber failed, falling back to regular polling: %v", err)
+			return
+		}
+		log.Println("Status subscriber stopped")
+	}()
+}
+
+// deliverStatusNotification hands n to the poller of the job it describes.
+// Notifications for jobs owned by other workers, or for earlier attempts of
+// a resubmitted job, are ignored. It reports whether a poller was found.
+func (s *WorkerService) deliverStatusNotification(n batch.StatusNotification) bool {
+	s.pollersMutex.Lock()
+	defer s.pollersMutex.Unlock()
+
+	poller, ok := s.pollers[s.pollersByPath[n.JobPath]]
+	if !ok {
+		return false
+	}
+	select {
+	case poller.updates <- n.Status:
+	default:
+		log.Printf("Dropping %s notification for job %s: poller is behind", n.Status, poller.jobID)
+	}
+	return true
+}
+
+// statusPollInterval returns how often a poller asks the provider for the
+// status of a job running on svc.
+func (s *WorkerService) statusPollInterval(svc router.AssignedService) time.Duration {
+	s.pollersMutex.Lock()
+	defer s.pollersMutex.Unlock()
+
+	if svc == router.AssignedServiceCloudBatch && s.safetyPollInterval > 0 {
+		return s.safetyPollInterval
+	}
+	return defaultPollingInterval
+}
+
+func (s *WorkerService) setSafetyPollInterval(d time.Duration) {
+	s.pollersMutex.Lock()
+	defer s.pollersMutex.Unlock()
+	s.safetyPollInterval = d
+}
+
+// isStaleNotification reports whether a notified status would move the job
+// backwards from current. Pub/Sub does not preserve order, so a late
+// SCHEDULED must not overwrite RUNNING. JobStatusUnknown is never stale: it
+// only asks for a fresh read.
+func isStaleNotification(current string, status batch.JobStatus) bool {
+	if status == batch.JobStatusUnknown {
+		return false
+	}
+	return statusProgress(mapBatchStatusToDBStatus(status)) < statusProgress(current)
+}
+
+// statusProgress orders database statuses along a job's lifecycle.
+func statusProgress(status string) int {
+	switch status {
+	case database.JobStatusScheduled:
+		return 1
+	case database.JobStatusRunning:
+		return 2
+	default:
+		if isTerminalStatus(status) {
+			return 3
+		}
+		return 0
+	}
+}
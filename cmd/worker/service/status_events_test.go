@@ -0,0 +1,151 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	batch "github.com/alphauslabs/jennah/internal/cloudexec"
+	"github.com/alphauslabs/jennah/internal/database"
+	"github.com/alphauslabs/jennah/internal/router"
+)
+
+// fakeStatusSubscriber delivers a fixed set of notifications, then blocks
+// until the context is cancelled, like a live Pub/Sub subscription.
+type fakeStatusSubscriber struct {
+	notifications []batch.StatusNotification
+}
+
+func (f *fakeStatusSubscriber) Receive(ctx context.Context, handle func(ctx context.Context, n batch.StatusNotification)) error {
+	for _, n := range f.notifications {
+		handle(ctx, n)
+	}
+	<-ctx.Done()
+	return nil
+}
+
+func testPoller(tenantID, jobID, path string) *JobPoller {
+	return &JobPoller{
+		tenantID:        tenantID,
+		jobID:           jobID,
+		gcpResourcePath: path,
+		currentStatus:   database.JobStatusPending,
+		assignedService: router.AssignedServiceCloudBatch,
+		done:            make(chan bool),
+		updates:         make(chan batch.JobStatus, pollerUpdateBuffer),
+	}
+}
+
+func receiveUpdate(t *testing.T, poller *JobPoller) batch.JobStatus {
+	t.Helper()
+	select {
+	case status := <-poller.updates:
+		return status
+	case <-time.After(2 * time.Second):
+		t.Fatalf("no notification delivered to poller for %s", poller.jobID)
+		return ""
+	}
+}
+
+func TestStatusSubscriber_RoutesNotificationsToOwningPoller(t *testing.T) {
+	const path = "projects/p/locations/r/jobs/job-1"
+	s := &WorkerService{}
+	poller := testPoller("tenant-1", "job-1", path)
+	if !s.registerPoller("tenant-1/job-1", poller) {
+		t.Fatal("registerPoller() = false, want true")
+	}
+
+	sub := &fakeStatusSubscriber{notifications: []batch.StatusNotification{
+		{JobPath: "projects/p/locations/r/jobs/someone-else", Status: batch.JobStatusFailed},
+		{JobPath: path, Status: batch.JobStatusRunning},
+		{JobPath: path, Status: batch.JobStatusCompleted},
+	}}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s.StartStatusSubscriber(ctx, sub, time.Minute)
+
+	if got := receiveUpdate(t, poller); got != batch.JobStatusRunning {
+		t.Errorf("first update = %s, want %s", got, batch.JobStatusRunning)
+	}
+	if got := receiveUpdate(t, poller); got != batch.JobStatusCompleted {
+		t.Errorf("second update = %s, want %s", got, batch.JobStatusCompleted)
+	}
+	select {
+	case got := <-poller.updates:
+		t.Errorf("unexpected update %s for another worker's job", got)
+	default:
+	}
+}
+
+func TestStatusSubscriber_FollowsResubmittedAttempt(t *testing.T) {
+	const (
+		firstAttempt  = "projects/p/locations/r/jobs/job-1"
+		secondAttempt = "projects/p/locations/r/jobs/job-1-p1"
+	)
+	s := &WorkerService{}
+	poller := testPoller("tenant-1", "job-1", firstAttempt)
+	s.registerPoller("tenant-1/job-1", poller)
+	s.indexPollerPath("tenant-1/job-1", secondAttempt)
+
+	if s.deliverStatusNotification(batch.StatusNotification{JobPath: firstAttempt, Status: batch.JobStatusFailed}) {
+		t.Error("notification for the preempted attempt was delivered")
+	}
+	if !s.deliverStatusNotification(batch.StatusNotification{JobPath: secondAttempt, Status: batch.JobStatusRunning}) {
+		t.Fatal("notification for the current attempt was not delivered")
+	}
+	if got := receiveUpdate(t, poller); got != batch.JobStatusRunning {
+		t.Errorf("update = %s, want %s", got, batch.JobStatusRunning)
+	}
+
+	s.unregisterPoller("tenant-1/job-1", poller)
+	if s.deliverStatusNotification(batch.StatusNotification{JobPath: secondAttempt, Status: batch.JobStatusCompleted}) {
+		t.Error("notification delivered after the poller was unregistered")
+	}
+}
+
+func TestStatusSubscriber_SafetyPollInterval(t *testing.T) {
+	s := &WorkerService{}
+	if got := s.statusPollInterval(router.AssignedServiceCloudBatch); got != defaultPollingInterval {
+		t.Errorf("before subscribing: interval = %s, want %s", got, defaultPollingInterval)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s.StartStatusSubscriber(ctx, &fakeStatusSubscriber{}, time.Minute)
+
+	if got := s.statusPollInterval(router.AssignedServiceCloudBatch); got != time.Minute {
+		t.Errorf("Cloud Batch interval = %s, want %s", got, time.Minute)
+	}
+	if got := s.statusPollInterval(router.AssignedServiceCloudRunJob); got != defaultPollingInterval {
+		t.Errorf("Cloud Run interval = %s, want %s", got, defaultPollingInterval)
+	}
+
+	cancel()
+	deadline := time.Now().Add(2 * time.Second)
+	for s.statusPollInterval(router.AssignedServiceCloudBatch) != defaultPollingInterval {
+		if time.Now().After(deadline) {
+			t.Fatal("interval did not return to default after the subscriber stopped")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestIsStaleNotification(t *testing.T) {
+	tests := []struct {
+		current string
+		status  batch.JobStatus
+		want    bool
+	}{
+		{database.JobStatusPending, batch.JobStatusRunning, false},
+		{database.JobStatusRunning, batch.JobStatusScheduled, true},
+		{database.JobStatusRunning, batch.JobStatusPending, true},
+		{database.JobStatusRunning, batch.JobStatusRunning, false},
+		{database.JobStatusRunning, batch.JobStatusFailed, false},
+		{database.JobStatusRunning, batch.JobStatusUnknown, false},
+		{database.JobStatusCompleted, batch.JobStatusRunning, true},
+	}
+	for _, tt := range tests {
+		if got := isStaleNotification(tt.current, tt.status); got != tt.want {
+			t.Errorf("isStaleNotification(%s, %s) = %v, want %v", tt.current, tt.status, got, tt.want)
+		}
+	}
+}
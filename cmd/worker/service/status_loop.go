@@ -0,0 +1,456 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	batch "github.com/alphauslabs/jennah/internal/cloudexec"
+	"github.com/alphauslabs/jennah/internal/database"
+	"github.com/alphauslabs/jennah/internal/router"
+)
+
+// statusLoopTick is how often a status loop wakes to apply notifications
+// and read the status of jobs that are due.
+const statusLoopTick = time.Second
+
+// statusFetchConcurrency bounds concurrent GetJobStatus calls for jobs whose
+// provider cannot read statuses in batches.
+const statusFetchConcurrency = 16
+
+// leaseStore renews job leases in bulk; *database.Client implements it.
+type leaseStore interface {
+	RenewJobLeases(ctx context.Context, workerID string, jobs []database.JobKey, leaseUntil time.Time) (map[database.JobKey]bool, error)
+}
+
+// statusLoop polls every job of one service from a single goroutine. Each
+// cycle applies queued notifications and reads the status of jobs whose
+// adaptive poll interval has elapsed, in one call where the provider
+// implements batch.StatusBatcher. A second goroutine renews the loop's
+// leases in one batched transaction per interval, so a slow provider cannot
+// hold renewals back until the leases expire.
+type statusLoop struct {
+	server  *WorkerService
+	service router.AssignedService
+
+	mu       sync.Mutex
+	incoming []*JobPoller
+	// leased holds the loop's jobs for the lease renewer.
+	leased map[string]*JobPoller
+
+	// jobs belongs to the loop goroutine.
+	jobs map[string]*JobPoller // Key: "tenantID/jobID"
+
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+// statusResult is the outcome of reading one job's status.
+type statusResult struct {
+	status batch.JobStatus
+	err    error
+}
+
+func newStatusLoop(s *WorkerService, svc router.AssignedService) *statusLoop {
+	return &statusLoop{
+		server:  s,
+		service: svc,
+		leased:  make(map[string]*JobPoller),
+		jobs:    make(map[string]*JobPoller),
+		done:    make(chan struct{}),
+	}
+}
+
+// statusLoopFor returns the status loop for svc, starting it on first use.
+func (s *WorkerService) statusLoopFor(svc router.AssignedService) *statusLoop {
+	s.pollersMutex.Lock()
+	defer s.pollersMutex.Unlock()
+
+	if loop, ok := s.statusLoops[svc]; ok {
+		return loop
+	}
+	if s.statusLoops == nil {
+		s.statusLoops = make(map[router.AssignedService]*statusLoop)
+	}
+	loop := newStatusLoop(s, svc)
+	s.statusLoops[svc] = loop
+	log.Printf("Starting status loop for %s jobs", svc)
+	// Independent of any request lifecycle.
+	go loop.run(context.Background())
+	return loop
+}
+
+// add hands a poller to the loop; it is picked up on the next cycle.
+func (l *statusLoop) add(poller *JobPoller) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.incoming = append(l.incoming, poller)
+}
+
+// stop ends the loop and lease renewer goroutines.
+func (l *statusLoop) stop() {
+	l.stopOnce.Do(func() {
+		close(l.done)
+	})
+}
+
+func (l *statusLoop) run(ctx context.Context) {
+	go l.renewLoop(ctx)
+
+	ticker := time.NewTicker(statusLoopTick)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-l.done:
+			log.Printf("Status loop for %s jobs stopped", l.service)
+			return
+		case now := <-ticker.C:
+			l.cycle(ctx, now)
+		}
+	}
+}
+
+// renewLoop renews the loop's leases every lease renew interval.
+func (l *statusLoop) renewLoop(ctx context.Context) {
+	ticker := time.NewTicker(l.server.leaseRenewInterval())
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-l.done:
+			return
+		case now := <-ticker.C:
+			l.renewLeases(ctx, now)
+		}
+	}
+}
+
+// cycle runs one pass of the loop.
+func (l *statusLoop) cycle(ctx context.Context, now time.Time) {
+	ctx, cancel := context.WithTimeout(ctx, l.server.statusCycleTimeout())
+	defer cancel()
+
+	l.adopt()
+
+	for key, poller := range l.jobs {
+		l.drainUpdates(ctx, poller)
+		l.settle(key, poller)
+	}
+
+	l.pollDue(ctx, now)
+}
+
+// adopt takes over pollers handed to the loop and drops stopped ones.
+func (l *statusLoop) adopt() {
+	l.mu.Lock()
+	incoming := l.incoming
+	l.incoming = nil
+	l.mu.Unlock()
+
+	for _, poller := range incoming {
+		l.jobs[pollerKeyOf(poller)] = poller
+	}
+	l.mu.Lock()
+	for _, poller := range incoming {
+		l.leased[pollerKeyOf(poller)] = poller
+	}
+	l.mu.Unlock()
+	for key, poller := range l.jobs {
+		l.settle(key, poller)
+	}
+}
+
+// renewLeases renews the lease of every job in the loop in one call and
+// stops the pollers of jobs whose lease went to another worker; the loop
+// drops them on its next cycle. It only touches the immutable job keys of
+// pollers, so it runs beside the loop goroutine.
+func (l *statusLoop) renewLeases(ctx context.Context, now time.Time) {
+	l.mu.Lock()
+	pollers := make([]*JobPoller, 0, len(l.leased))
+	for _, poller := range l.leased {
+		pollers = append(pollers, poller)
+	}
+	l.mu.Unlock()
+	if len(pollers) == 0 {
+		return
+	}
+	keys := make([]database.JobKey, len(pollers))
+	for i, poller := range pollers {
+		keys[i] = database.JobKey{TenantID: poller.tenantID, JobID: poller.jobID}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, l.server.statusCallTimeout())
+	defer cancel()
+	owned, err := l.server.leases.RenewJobLeases(ctx, l.server.workerID, keys, now.UTC().Add(l.server.leaseTTL))
+	if err != nil {
+		log.Printf("Error renewing %d lease(s) for %s jobs: %v", len(keys), l.service, err)
+		return
+	}
+
+	for i, poller := range pollers {
+		if owned[keys[i]] || poller.stopped() {
+			continue
+		}
+		log.Printf("Lease ownership lost for job %s; stopping local poller", poller.jobID)
+		poller.stop()
+	}
+}
+
+// drainUpdates applies the notifications queued for poller.
+func (l *statusLoop) drainUpdates(ctx context.Context, poller *JobPoller) {
+	for !poller.stopped() && !poller.resubmitting.Load() {
+		select {
+		case status := <-poller.updates:
+			l.applyNotification(ctx, poller, status)
+		default:
+			return
+		}
+	}
+}
+
+func (l *statusLoop) applyNotification(ctx context.Context, poller *JobPoller, status batch.JobStatus) {
+	if isStaleNotification(poller.currentStatus, status) {
+		return
+	}
+	if status == batch.JobStatusUnknown || status == batch.JobStatusFailed {
+		// Re-read task changes and failures so a Spot preemption is still
+		// told apart from a workload failure.
+		callCtx, cancel := context.WithTimeout(ctx, l.server.statusCallTimeout())
+		fetched, err := poller.batchProvider.GetJobStatus(callCtx, poller.gcpResourcePath)
+		cancel()
+		if err != nil {
+			log.Printf("Error fetching status of job %s after notification: %v", poller.jobID, err)
+			return
+		}
+		status = fetched
+	}
+	poller.applyStatus(ctx, l.server, status, "Status updated from Cloud Batch notification")
+}
+
+// pollDue reads the status of every job whose poll interval has elapsed.
+func (l *statusLoop) pollDue(ctx context.Context, now time.Time) {
+	var due []*JobPoller
+	for _, poller := range l.jobs {
+		if !now.Before(poller.nextStatusPoll) && !poller.resubmitting.Load() {
+			due = append(due, poller)
+		}
+	}
+	if len(due) == 0 {
+		return
+	}
+
+	results := fetchStatuses(ctx, due, l.server.statusCallTimeout())
+	for i, poller := range due {
+		if ctx.Err() != nil {
+			// The cycle ran out of time; the rest stay due for the next one.
+			return
+		}
+		if err := results[i].err; err != nil {
+			l.pollFailed(ctx, poller, err)
+		} else {
+			poller.failedAttempts = 0 // Reset on successful poll.
+			poller.applyStatus(ctx, l.server, results[i].status, "Status updated from GCP Batch API")
+		}
+		if poller.resubmitting.Load() {
+			// The resubmission owns the poller until it is done.
+			continue
+		}
+		poller.nextStatusPoll = now.Add(l.server.pollInterval(poller, now))
+		l.settle(pollerKeyOf(poller), poller)
+	}
+}
+
+// pollFailed counts a failed status read and stops the poller after
+// maxFailedAttempts consecutive failures.
+func (l *statusLoop) pollFailed(ctx context.Context, poller *JobPoller, err error) {
+	if errors.Is(err, batch.ErrCircuitOpen) {
+		// The provider is down; the breaker logs that once for every job.
+		return
+	}
+	if batch.IsUnavailable(err) {
+		// An outage says nothing about this job, so it does not count
+		// towards giving up on it.
+		log.Printf("Provider unavailable polling job %s, will retry: %v", poller.jobID, err)
+		return
+	}
+	poller.failedAttempts++
+	log.Printf("Error polling job %s (attempt %d/%d) [service=%s, tier=%s, path=%s]: %v",
+		poller.jobID, poller.failedAttempts, poller.maxFailedAttempts,
+		poller.assignedService, poller.serviceTier, poller.gcpResourcePath, err)
+
+	// If this is a SIMPLE tier job failing with Cloud Run provider, it might actually be a Cloud Batch job
+	// (e.g., created before the dispatcher was implemented). Try falling back to Cloud Batch.
+	if poller.serviceTier == database.ServiceTierSimple && poller.assignedService == router.AssignedServiceCloudRunJob && l.server.dispatcher != nil {
+		if batchProvider, err := l.server.dispatcher.ProviderFor(router.AssignedServiceCloudBatch); err == nil {
+			log.Printf("Retrying job %s with Cloud Batch provider (fallback)", poller.jobID)
+			callCtx, cancel := context.WithTimeout(ctx, l.server.statusCallTimeout())
+			status, err := batchProvider.GetJobStatus(callCtx, poller.gcpResourcePath)
+			cancel()
+			if err == nil {
+				// Success! Move the poller to Cloud Batch going forward.
+				log.Printf("Job %s is actually a Cloud Batch job, updating poller", poller.jobID)
+				poller.batchProvider = batchProvider
+				poller.assignedService = router.AssignedServiceCloudBatch
+				poller.failedAttempts = 0 // Reset since we found the right provider
+				poller.applyStatus(ctx, l.server, status, "Status updated from GCP Batch API")
+				return
+			}
+			log.Printf("Cloud Batch fallback also failed for job %s: %v", poller.jobID, err)
+		}
+	}
+
+	if poller.failedAttempts >= poller.maxFailedAttempts {
+		log.Printf("Max failed attempts reached for job %s, stopping poller", poller.jobID)
+		poller.stop()
+	}
+}
+
+// settle removes a stopped poller, or hands it to another loop when
+// preemption or the Cloud Batch fallback moved the job to another service.
+// Pollers whose job is being resubmitted are settled once that is done.
+func (l *statusLoop) settle(key string, poller *JobPoller) {
+	if l.jobs[key] != poller || poller.resubmitting.Load() {
+		return
+	}
+	switch {
+	case poller.stopped():
+		l.forget(key)
+		l.server.unregisterPoller(key, poller)
+		log.Printf("Poller for job %s (tenant: %s) stopped", poller.jobID, poller.tenantID)
+	case poller.assignedService != l.service:
+		l.forget(key)
+		l.server.statusLoopFor(poller.assignedService).add(poller)
+	}
+}
+
+// forget drops the job at key from the loop and its lease renewals.
+func (l *statusLoop) forget(key string) {
+	delete(l.jobs, key)
+	l.mu.Lock()
+	delete(l.leased, key)
+	l.mu.Unlock()
+}
+
+// fetchStatuses reads the status of each poller's job, giving each provider
+// call timeout. Jobs whose provider implements batch.StatusBatcher are read
+// in one call per provider; the rest, and any the batched read did not
+// return, are read one by one.
+func fetchStatuses(ctx context.Context, pollers []*JobPoller, timeout time.Duration) []statusResult {
+	results := make([]statusResult, len(pollers))
+
+	byProvider := make(map[batch.Provider][]int)
+	for i, poller := range pollers {
+		byProvider[poller.batchProvider] = append(byProvider[poller.batchProvider], i)
+	}
+
+	var single []int
+	for provider, indexes := range byProvider {
+		batcher, ok := provider.(batch.StatusBatcher)
+		if !ok {
+			single = append(single, indexes...)
+			continue
+		}
+		paths := make([]string, len(indexes))
+		for j, i := range indexes {
+			paths[j] = pollers[i].gcpResourcePath
+		}
+		callCtx, cancel := context.WithTimeout(ctx, timeout)
+		statuses, err := batcher.GetJobStatuses(callCtx, paths)
+		cancel()
+		if errors.Is(err, batch.ErrCircuitOpen) {
+			// Individual reads would be rejected the same way.
+			for _, i := range indexes {
+				results[i].err = err
+			}
+			continue
+		}
+		if err != nil {
+			log.Printf("Batched status read of %d job(s) failed, reading individually: %v", len(paths), err)
+			single = append(single, indexes...)
+			continue
+		}
+		for _, i := range indexes {
+			if status, ok := statuses[pollers[i].gcpResourcePath]; ok {
+				results[i].status = status
+			} else {
+				single = append(single, i)
+			}
+		}
+	}
+
+	sem := make(chan struct{}, statusFetchConcurrency)
+	var wg sync.WaitGroup
+	for _, i := range single {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			callCtx, cancel := context.WithTimeout(ctx, timeout)
+			defer cancel()
+			results[i].status, results[i].err = pollers[i].batchProvider.GetJobStatus(callCtx, pollers[i].gcpResourcePath)
+		}()
+	}
+	wg.Wait()
+
+	return results
+}
+
+// adaptivePollInterval returns how long to wait between status reads for a
+// job on svc whose current attempt is age old. Young jobs are read often,
+// since that is when bad images, quota errors and preemptions show up; the
+// interval then grows, more for Cloud Batch jobs, which commonly run for
+// hours, than for Cloud Run jobs, which run for minutes.
+func adaptivePollInterval(svc router.AssignedService, age time.Duration) time.Duration {
+	if svc == router.AssignedServiceCloudBatch {
+		switch {
+		case age < 2*time.Minute:
+			return defaultPollingInterval
+		case age < 15*time.Minute:
+			return 15 * time.Second
+		case age < time.Hour:
+			return 30 * time.Second
+		default:
+			return 2 * time.Minute
+		}
+	}
+	switch {
+	case age < time.Minute:
+		return defaultPollingInterval
+	case age < 10*time.Minute:
+		return 10 * time.Second
+	default:
+		return 30 * time.Second
+	}
+}
+
+// pollInterval returns how long poller waits before its next status read:
+// the adaptive interval, or the notification safety interval if longer.
+func (s *WorkerService) pollInterval(poller *JobPoller, now time.Time) time.Duration {
+	interval := adaptivePollInterval(poller.assignedService, now.Sub(poller.trackedSince))
+	return max(interval, s.statusPollInterval(poller.assignedService))
+}
+
+// leaseRenewInterval is how often status loops renew their leases: often
+// enough that a lease survives two missed renewals.
+func (s *WorkerService) leaseRenewInterval() time.Duration {
+	return min(defaultPollingInterval, s.leaseTTL/3)
+}
+
+// statusCycleTimeout bounds one status loop cycle, and statusCallTimeout
+// each provider read and lease renewal, well under a third of the lease TTL.
+func (s *WorkerService) statusCycleTimeout() time.Duration {
+	return s.leaseTTL / 6
+}
+
+func (s *WorkerService) statusCallTimeout() time.Duration {
+	return s.leaseTTL / 12
+}
+
+func pollerKeyOf(poller *JobPoller) string {
+	return fmt.Sprintf("%s/%s", poller.tenantID, poller.jobID)
+}
@@ -0,0 +1,360 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	batch "github.com/alphauslabs/jennah/internal/cloudexec"
+	"github.com/alphauslabs/jennah/internal/database"
+	"github.com/alphauslabs/jennah/internal/router"
+)
+
+// fakeStatusProvider reports every job as RUNNING, optionally after a
+// simulated round trip, and counts the calls it receives.
+type fakeStatusProvider struct {
+	latency time.Duration
+	gets    atomic.Int64
+	batches atomic.Int64
+	// missing paths are left out of batched reads.
+	missing map[string]bool
+}
+
+func (f *fakeStatusProvider) SubmitJob(ctx context.Context, config batch.JobConfig) (*batch.JobResult, error) {
+	return nil, fmt.Errorf("not implemented")
+}
+
+func (f *fakeStatusProvider) GetJobStatus(ctx context.Context, path string) (batch.JobStatus, error) {
+	f.gets.Add(1)
+	time.Sleep(f.latency)
+	return batch.JobStatusRunning, nil
+}
+
+func (f *fakeStatusProvider) CancelJob(ctx context.Context, path string) error { return nil }
+func (f *fakeStatusProvider) DeleteJob(ctx context.Context, path string) error { return nil }
+func (f *fakeStatusProvider) ListJobs(ctx context.Context) ([]string, error)   { return nil, nil }
+func (f *fakeStatusProvider) ServiceType() string                              { return batch.ServiceTypeCloudBatch }
+func (f *fakeStatusProvider) Capabilities() batch.Capabilities                 { return batch.Capabilities{} }
+
+// fakeBatchingProvider adds batched status reads to fakeStatusProvider.
+type fakeBatchingProvider struct {
+	*fakeStatusProvider
+}
+
+func (f *fakeBatchingProvider) GetJobStatuses(ctx context.Context, paths []string) (map[string]batch.JobStatus, error) {
+	f.batches.Add(1)
+	time.Sleep(f.latency)
+	statuses := make(map[string]batch.JobStatus, len(paths))
+	for _, path := range paths {
+		if !f.missing[path] {
+			statuses[path] = batch.JobStatusRunning
+		}
+	}
+	return statuses, nil
+}
+
+// fakeLeases grants every lease except those in lost.
+type fakeLeases struct {
+	mu    sync.Mutex
+	calls int
+	lost  map[database.JobKey]bool
+}
+
+func (f *fakeLeases) RenewJobLeases(ctx context.Context, workerID string, jobs []database.JobKey, leaseUntil time.Time) (map[database.JobKey]bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls++
+	owned := make(map[database.JobKey]bool, len(jobs))
+	for _, job := range jobs {
+		if !f.lost[job] {
+			owned[job] = true
+		}
+	}
+	return owned, nil
+}
+
+// newTestStatusLoop returns a Cloud Batch loop, not yet running, tracking n
+// RUNNING jobs on provider.
+func newTestStatusLoop(n int, provider batch.Provider, leases leaseStore) (*WorkerService, *statusLoop) {
+	s := &WorkerService{workerID: "worker-1", leaseTTL: 30 * time.Second, leases: leases}
+	loop := newStatusLoop(s, router.AssignedServiceCloudBatch)
+	for i := 0; i < n; i++ {
+		jobID := fmt.Sprintf("job-%d", i)
+		poller := testPoller("tenant-1", jobID, "projects/p/locations/r/jobs/"+jobID)
+		poller.currentStatus = database.JobStatusRunning
+		poller.batchProvider = provider
+		poller.maxFailedAttempts = 10
+		poller.trackedSince = time.Now()
+		s.registerPoller(pollerKeyOf(poller), poller)
+		loop.add(poller)
+	}
+	return s, loop
+}
+
+func TestStatusLoop_BatchesStatusReads(t *testing.T) {
+	provider := &fakeBatchingProvider{&fakeStatusProvider{
+		missing: map[string]bool{"projects/p/locations/r/jobs/job-7": true},
+	}}
+	_, loop := newTestStatusLoop(120, provider, &fakeLeases{})
+
+	now := time.Now()
+	loop.cycle(context.Background(), now)
+
+	if got := provider.batches.Load(); got != 1 {
+		t.Errorf("batched reads = %d, want 1", got)
+	}
+	if got := provider.gets.Load(); got != 1 {
+		t.Errorf("individual reads = %d, want 1 (the job missing from the batch)", got)
+	}
+
+	// Nothing is due again until the poll interval has elapsed.
+	loop.cycle(context.Background(), now.Add(time.Second))
+	if got := provider.batches.Load() + provider.gets.Load(); got != 2 {
+		t.Errorf("provider calls after second cycle = %d, want 2", got)
+	}
+	loop.cycle(context.Background(), now.Add(defaultPollingInterval))
+	if got := provider.batches.Load(); got != 2 {
+		t.Errorf("batched reads after poll interval = %d, want 2", got)
+	}
+}
+
+func TestStatusLoop_ReadsIndividuallyWithoutBatcher(t *testing.T) {
+	provider := &fakeStatusProvider{}
+	_, loop := newTestStatusLoop(40, provider, &fakeLeases{})
+
+	loop.cycle(context.Background(), time.Now())
+
+	if got := provider.gets.Load(); got != 40 {
+		t.Errorf("individual reads = %d, want 40", got)
+	}
+}
+
+func TestStatusLoop_OutagesDoNotCountAgainstJobs(t *testing.T) {
+	_, loop := newTestStatusLoop(0, &fakeStatusProvider{}, &fakeLeases{})
+	poller := testPoller("tenant-1", "job-1", "projects/p/locations/r/jobs/job-1")
+	poller.maxFailedAttempts = 3
+
+	open := &batch.ProviderError{Class: batch.ErrorClassRetryable, Op: "GetJobStatus", Err: batch.ErrCircuitOpen}
+	for i := 0; i < 2*poller.maxFailedAttempts; i++ {
+		loop.pollFailed(context.Background(), poller, open)
+		loop.pollFailed(context.Background(), poller, status.Error(codes.Unavailable, "down"))
+	}
+	if poller.failedAttempts != 0 || poller.stopped() {
+		t.Fatalf("failedAttempts = %d, stopped = %v; outages must not give up on the job", poller.failedAttempts, poller.stopped())
+	}
+
+	loop.pollFailed(context.Background(), poller, status.Error(codes.PermissionDenied, "no"))
+	if poller.failedAttempts != 1 {
+		t.Errorf("failedAttempts = %d after a permanent error, want 1", poller.failedAttempts)
+	}
+}
+
+func TestStatusLoop_RenewsLeasesInOneCallAndDropsLostJobs(t *testing.T) {
+	lostKey := database.JobKey{TenantID: "tenant-1", JobID: "job-3"}
+	leases := &fakeLeases{lost: map[database.JobKey]bool{lostKey: true}}
+	s, loop := newTestStatusLoop(10, &fakeStatusProvider{}, leases)
+	lostPoller := s.pollers["tenant-1/job-3"]
+
+	loop.adopt()
+	loop.renewLeases(context.Background(), time.Now())
+
+	if leases.calls != 1 {
+		t.Errorf("lease renewals = %d, want 1 for 10 jobs", leases.calls)
+	}
+	if !lostPoller.stopped() {
+		t.Error("poller of the job whose lease was lost is still running")
+	}
+
+	// The loop drops the stopped poller on its next cycle, which leaves
+	// lease renewal to the renewer.
+	loop.cycle(context.Background(), time.Now())
+	if s.hasPoller("tenant-1", "job-3") {
+		t.Error("job whose lease was lost is still registered")
+	}
+	if len(loop.jobs) != 9 || len(loop.leased) != 9 {
+		t.Errorf("loop tracks %d jobs and renews %d leases, want 9", len(loop.jobs), len(loop.leased))
+	}
+	if leases.calls != 1 {
+		t.Errorf("lease renewals after a cycle = %d, want 1", leases.calls)
+	}
+}
+
+// blockingStatusProvider holds every status read until release is closed,
+// ignoring its context like a hung connection would.
+type blockingStatusProvider struct {
+	*fakeStatusProvider
+	entered chan struct{}
+	release chan struct{}
+	once    sync.Once
+}
+
+func (f *blockingStatusProvider) GetJobStatus(ctx context.Context, path string) (batch.JobStatus, error) {
+	f.once.Do(func() { close(f.entered) })
+	<-f.release
+	return batch.JobStatusRunning, nil
+}
+
+func TestStatusLoop_RenewsLeasesWhileProviderHangs(t *testing.T) {
+	provider := &blockingStatusProvider{
+		fakeStatusProvider: &fakeStatusProvider{},
+		entered:            make(chan struct{}),
+		release:            make(chan struct{}),
+	}
+	leases := &fakeLeases{}
+	s, loop := newTestStatusLoop(3, provider, leases)
+	s.leaseTTL = 300 * time.Millisecond
+	go loop.run(context.Background())
+	defer close(provider.release)
+	defer loop.stop()
+
+	select {
+	case <-provider.entered:
+	case <-time.After(5 * time.Second):
+		t.Fatal("status loop never read a status")
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		leases.mu.Lock()
+		calls := leases.calls
+		leases.mu.Unlock()
+		if calls >= 2 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("lease renewals = %d while a status read hangs, want at least 2", calls)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// deadlineStatusProvider reports whether status reads carry a deadline and
+// waits for it to pass.
+type deadlineStatusProvider struct {
+	*fakeStatusProvider
+	noDeadline atomic.Bool
+}
+
+func (f *deadlineStatusProvider) GetJobStatus(ctx context.Context, path string) (batch.JobStatus, error) {
+	if _, ok := ctx.Deadline(); !ok {
+		f.noDeadline.Store(true)
+		return batch.JobStatusRunning, nil
+	}
+	<-ctx.Done()
+	return batch.JobStatusUnknown, ctx.Err()
+}
+
+func TestStatusLoop_CycleBoundsProviderCalls(t *testing.T) {
+	provider := &deadlineStatusProvider{fakeStatusProvider: &fakeStatusProvider{}}
+	s, loop := newTestStatusLoop(3, provider, &fakeLeases{})
+	s.leaseTTL = 600 * time.Millisecond
+
+	start := time.Now()
+	loop.cycle(context.Background(), start)
+
+	if provider.noDeadline.Load() {
+		t.Error("status read without a deadline")
+	}
+	if elapsed := time.Since(start); elapsed > s.leaseTTL/3 {
+		t.Errorf("cycle took %s, want under a third of the %s lease TTL", elapsed, s.leaseTTL)
+	}
+	for _, poller := range loop.jobs {
+		if poller.stopped() {
+			t.Errorf("poller of job %s stopped after a timed-out read", poller.jobID)
+		}
+	}
+}
+
+func TestStatusLoop_DropsStoppedPollers(t *testing.T) {
+	s, loop := newTestStatusLoop(3, &fakeStatusProvider{}, &fakeLeases{})
+	loop.cycle(context.Background(), time.Now())
+
+	s.stopPollerForJob("tenant-1", "job-1")
+	loop.cycle(context.Background(), time.Now())
+
+	if _, ok := loop.jobs["tenant-1/job-1"]; ok {
+		t.Error("stopped poller is still tracked by the loop")
+	}
+	if len(loop.jobs) != 2 {
+		t.Errorf("loop tracks %d jobs, want 2", len(loop.jobs))
+	}
+}
+
+func TestAdaptivePollInterval(t *testing.T) {
+	tests := []struct {
+		svc  router.AssignedService
+		age  time.Duration
+		want time.Duration
+	}{
+		{router.AssignedServiceCloudBatch, 0, 5 * time.Second},
+		{router.AssignedServiceCloudBatch, 5 * time.Minute, 15 * time.Second},
+		{router.AssignedServiceCloudBatch, 30 * time.Minute, 30 * time.Second},
+		{router.AssignedServiceCloudBatch, 6 * time.Hour, 2 * time.Minute},
+		{router.AssignedServiceCloudRunJob, 30 * time.Second, 5 * time.Second},
+		{router.AssignedServiceCloudRunJob, 5 * time.Minute, 10 * time.Second},
+		{router.AssignedServiceCloudRunJob, time.Hour, 30 * time.Second},
+	}
+	for _, tt := range tests {
+		if got := adaptivePollInterval(tt.svc, tt.age); got != tt.want {
+			t.Errorf("adaptivePollInterval(%s, %s) = %s, want %s", tt.svc, tt.age, got, tt.want)
+		}
+	}
+}
+
+func TestPollInterval_SafetyIntervalIsAFloor(t *testing.T) {
+	s := &WorkerService{safetyPollInterval: time.Minute}
+	now := time.Now()
+	young := &JobPoller{assignedService: router.AssignedServiceCloudBatch, trackedSince: now}
+	old := &JobPoller{assignedService: router.AssignedServiceCloudBatch, trackedSince: now.Add(-6 * time.Hour)}
+
+	if got := s.pollInterval(young, now); got != time.Minute {
+		t.Errorf("young job interval = %s, want %s", got, time.Minute)
+	}
+	if got := s.pollInterval(old, now); got != 2*time.Minute {
+		t.Errorf("old job interval = %s, want %s", got, 2*time.Minute)
+	}
+}
+
+// BenchmarkStatusLoopCycle measures one full poll of n due jobs against a
+// fake provider with a 200µs round trip, with and without batched reads.
+func BenchmarkStatusLoopCycle(b *testing.B) {
+	for _, n := range []int{100, 1000, 10000} {
+		for _, batched := range []bool{false, true} {
+			name := fmt.Sprintf("jobs=%d/per-job", n)
+			if batched {
+				name = fmt.Sprintf("jobs=%d/batched", n)
+			}
+
+			b.Run(name, func(b *testing.B) {
+				fake := &fakeStatusProvider{latency: 200 * time.Microsecond}
+				var provider batch.Provider = fake
+				if batched {
+					provider = &fakeBatchingProvider{fake}
+				}
+				leases := &fakeLeases{}
+				_, loop := newTestStatusLoop(n, provider, leases)
+				ctx := context.Background()
+				loop.adopt()
+
+				b.ResetTimer()
+				for i := 0; i < b.N; i++ {
+					for _, poller := range loop.jobs {
+						poller.nextStatusPoll = time.Time{}
+					}
+					now := time.Now().Add(time.Duration(i) * defaultPollingInterval)
+					loop.renewLeases(ctx, now)
+					loop.cycle(ctx, now)
+				}
+				b.StopTimer()
+
+				b.ReportMetric(float64(fake.gets.Load()+fake.batches.Load())/float64(b.N), "provider-calls/op")
+				b.ReportMetric(float64(leases.calls)/float64(b.N), "lease-txns/op")
+			})
+		}
+	}
+}
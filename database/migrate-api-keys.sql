@@ -0,0 +1,20 @@
+-- ApiKeys table: tenant-scoped credentials for CI and automation, sent as
+-- "Authorization: Bearer jnh_<KeyId>_<secret>". The gateway looks keys up by
+-- KeyId and compares a salted SHA-256 hash of the secret; the secret itself
+-- is never stored. Revoked and expired keys are kept for auditing.
+CREATE TABLE ApiKeys (
+  TenantId   STRING(36)  NOT NULL,
+  KeyId      STRING(32)  NOT NULL,
+  Name       STRING(255) NOT NULL,
+  SecretHash BYTES(32)   NOT NULL,
+  Salt       BYTES(16)   NOT NULL,
+  Scopes     ARRAY<STRING(32)> NOT NULL,
+  CreatedBy  STRING(255) NOT NULL,
+  CreatedAt  TIMESTAMP   NOT NULL OPTIONS (allow_commit_timestamp=true),
+  ExpiresAt  TIMESTAMP,
+  LastUsedAt TIMESTAMP,
+  RevokedAt  TIMESTAMP,
+) PRIMARY KEY (TenantId, KeyId),
+  INTERLEAVE IN PARENT Tenants ON DELETE CASCADE;
+
+CREATE UNIQUE INDEX ApiKeysByKeyId ON ApiKeys(KeyId);
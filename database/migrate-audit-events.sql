@@ -0,0 +1,27 @@
+-- AuditEvents table: an append-only record of who called which mutating RPC
+-- on what, kept apart from Tenants so it survives job and tenant deletion.
+
+-- Step 1 (DDL tab)
+CREATE TABLE AuditEvents (
+  TenantId     STRING(36)  NOT NULL,
+  EventId      STRING(36)  NOT NULL,
+  OccurredAt   TIMESTAMP   NOT NULL OPTIONS (allow_commit_timestamp=true),
+  Procedure    STRING(255) NOT NULL,
+  ActorType    STRING(16)  NOT NULL,
+  ActorId      STRING(320) NOT NULL,
+  ActorEmail   STRING(255) NOT NULL,
+  TargetType   STRING(32),
+  TargetId     STRING(320),
+  RequestJson  STRING(MAX) NOT NULL,
+  ResultCode   STRING(32)  NOT NULL,
+  ErrorMessage STRING(MAX),
+  ClientIp     STRING(64)  NOT NULL,
+  UserAgent    STRING(512) NOT NULL,
+) PRIMARY KEY (TenantId, EventId);
+
+CREATE INDEX AuditEventsByTime ON AuditEvents(TenantId, OccurredAt DESC, EventId DESC);
+
+-- Step 2 (optional, DDL tab): with fine-grained access control, let the
+-- gateway's database role insert and read events but never change them.
+-- CREATE ROLE gateway;
+-- GRANT SELECT, INSERT ON TABLE AuditEvents TO ROLE gateway;
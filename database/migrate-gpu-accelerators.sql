@@ -0,0 +1,3 @@
+ALTER TABLE Jobs ADD COLUMN AcceleratorType STRING(64);
+ALTER TABLE Jobs ADD COLUMN AcceleratorCount INT64;
+ALTER TABLE Jobs ADD COLUMN AcceleratorDriverVersion STRING(64);
@@ -0,0 +1,27 @@
+-- Identities table: maps each sign-in identity (provider + user ID) to the
+-- personal tenant it reaches, so one person signing in with Google and
+-- GitHub can link both to one tenant. The gateway still falls back to the
+-- Tenants OAuth columns for identities missing here.
+
+-- Step 1 (DDL tab)
+CREATE TABLE Identities (
+  OAuthProvider STRING(50)  NOT NULL,
+  OAuthUserId   STRING(255) NOT NULL,
+  TenantId      STRING(36)  NOT NULL,
+  Email         STRING(255) NOT NULL,
+  LinkedAt      TIMESTAMP   NOT NULL OPTIONS (allow_commit_timestamp=true),
+) PRIMARY KEY (OAuthProvider, OAuthUserId);
+
+CREATE INDEX IdentitiesByTenant ON Identities(TenantId);
+
+-- Step 2 (Query tab, DML): record the identity of every existing personal
+-- tenant. Safe to re-run. Duplicate tenants of one person are merged later
+-- with the gateway's LinkIdentity RPC (merge_tenant: true).
+INSERT INTO Identities (OAuthProvider, OAuthUserId, TenantId, Email, LinkedAt)
+SELECT t.OAuthProvider, t.OAuthUserId, t.TenantId, t.UserEmail, t.CreatedAt
+FROM Tenants t
+WHERE t.Name IS NULL
+  AND NOT EXISTS (
+    SELECT 1 FROM Identities i
+    WHERE i.OAuthProvider = t.OAuthProvider AND i.OAuthUserId = t.OAuthUserId
+  );
@@ -0,0 +1 @@
+ALTER TABLE Jobs ADD COLUMN LabelsJson STRING(MAX);
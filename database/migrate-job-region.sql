@@ -0,0 +1 @@
+ALTER TABLE Jobs ADD COLUMN Region STRING(64);
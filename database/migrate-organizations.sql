@@ -0,0 +1,45 @@
+-- Organizations: tenants shared by several members with roles (viewer,
+-- submitter, admin), plus invitations to join them. An organization is a
+-- Tenants row with a Name; personal tenants keep a NULL Name.
+
+-- Step 1 (DDL tab)
+ALTER TABLE Tenants ADD COLUMN Name STRING(255);
+
+CREATE TABLE Memberships (
+  TenantId      STRING(36)  NOT NULL,
+  OAuthProvider STRING(50)  NOT NULL,
+  OAuthUserId   STRING(255) NOT NULL,
+  Email         STRING(255) NOT NULL,
+  Role          STRING(32)  NOT NULL,
+  CreatedAt     TIMESTAMP   NOT NULL OPTIONS (allow_commit_timestamp=true),
+) PRIMARY KEY (TenantId, OAuthProvider, OAuthUserId),
+  INTERLEAVE IN PARENT Tenants ON DELETE CASCADE;
+
+CREATE INDEX MembershipsByUser ON Memberships(OAuthProvider, OAuthUserId);
+
+CREATE TABLE Invitations (
+  TenantId     STRING(36)  NOT NULL,
+  InvitationId STRING(36)  NOT NULL,
+  Email        STRING(255) NOT NULL,
+  Role         STRING(32)  NOT NULL,
+  InvitedBy    STRING(255) NOT NULL,
+  CreatedAt    TIMESTAMP   NOT NULL OPTIONS (allow_commit_timestamp=true),
+  ExpiresAt    TIMESTAMP   NOT NULL,
+  AcceptedAt   TIMESTAMP,
+) PRIMARY KEY (TenantId, InvitationId),
+  INTERLEAVE IN PARENT Tenants ON DELETE CASCADE;
+
+CREATE UNIQUE INDEX InvitationsByInvitationId ON Invitations(InvitationId);
+CREATE INDEX InvitationsByEmail ON Invitations(Email);
+
+-- Step 2 (Query tab, DML): make every existing personal tenant a
+-- single-member organization with its user as admin. Safe to re-run.
+INSERT INTO Memberships (TenantId, OAuthProvider, OAuthUserId, Email, Role, CreatedAt)
+SELECT t.TenantId, t.OAuthProvider, t.OAuthUserId, t.UserEmail, 'admin', t.CreatedAt
+FROM Tenants t
+WHERE NOT EXISTS (
+  SELECT 1 FROM Memberships m
+  WHERE m.TenantId = t.TenantId
+    AND m.OAuthProvider = t.OAuthProvider
+    AND m.OAuthUserId = t.OAuthUserId
+);
@@ -0,0 +1 @@
+ALTER TABLE Jobs ADD COLUMN PreviousJobPaths ARRAY<STRING(1024)>;
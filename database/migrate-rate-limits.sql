@@ -0,0 +1,11 @@
+-- RateLimitBuckets table: token buckets of the gateway's rate limiter,
+-- shared by all gateways started with --rate-limit-shared. Gateways without
+-- that flag keep their buckets in memory and do not need this table.
+
+-- Step 1 (DDL tab)
+CREATE TABLE RateLimitBuckets (
+  BucketKey STRING(512) NOT NULL,
+  Tokens    FLOAT64     NOT NULL,
+  UpdatedAt TIMESTAMP   NOT NULL,
+) PRIMARY KEY (BucketKey),
+  ROW DELETION POLICY (OLDER_THAN(UpdatedAt, INTERVAL 1 DAY));
@@ -0,0 +1,3 @@
+ALTER TABLE Jobs ADD COLUMN PreemptionCount INT64;
+ALTER TABLE Jobs ADD COLUMN MaxPreemptionResubmits INT64;
+ALTER TABLE Jobs ADD COLUMN StandardAfterPreemptions INT64;
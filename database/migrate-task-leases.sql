@@ -0,0 +1,8 @@
+-- TaskLeases table: one row per worker-wide periodic task (for example the
+-- cloud/DB reconciler) so that only one worker runs it at a time.
+CREATE TABLE TaskLeases (
+  TaskName       STRING(64)  NOT NULL,
+  OwnerWorkerId  STRING(255) NOT NULL,
+  LeaseExpiresAt TIMESTAMP   NOT NULL,
+  UpdatedAt      TIMESTAMP   NOT NULL OPTIONS (allow_commit_timestamp=true),
+) PRIMARY KEY (TaskName);
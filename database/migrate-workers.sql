@@ -0,0 +1,13 @@
+-- Workers table: one row per worker process. Workers register on startup and
+-- heartbeat on their lease loop; gateways build their consistent-hash ring
+-- from the ACTIVE workers whose heartbeat is recent. Setting State to
+-- DRAINING takes a worker out of the ring without stopping it.
+CREATE TABLE Workers (
+  WorkerId        STRING(255) NOT NULL,
+  Address         STRING(255) NOT NULL,
+  Capacity        INT64       NOT NULL,
+  Version         STRING(64),
+  State           STRING(32)  NOT NULL,
+  RegisteredAt    TIMESTAMP   NOT NULL OPTIONS (allow_commit_timestamp=true),
+  LastHeartbeatAt TIMESTAMP   NOT NULL OPTIONS (allow_commit_timestamp=true),
+) PRIMARY KEY (WorkerId);
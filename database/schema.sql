@@ -5,6 +5,8 @@ CREATE TABLE Tenants (
   OAuthUserId STRING(255) NOT NULL,
   CreatedAt TIMESTAMP NOT NULL OPTIONS (allow_commit_timestamp=true),
   UpdatedAt TIMESTAMP NOT NULL OPTIONS (allow_commit_timestamp=true),
+  -- Organization name; NULL for a user's personal tenant
+  Name STRING(255),
 ) PRIMARY KEY (TenantId);
 
 CREATE INDEX TenantsByOAuth ON Tenants(OAuthProvider, OAuthUserId);
@@ -34,6 +36,28 @@ CREATE TABLE Jobs (
   PreferredWorkerId STRING(128),
   LeaseExpiresAt TIMESTAMP,
   LastHeartbeatAt TIMESTAMP,
+  -- Region the provider pool placed the job in (may differ from the primary region after failover)
+  Region STRING(64),
+  -- Spot preemption tracking and per-job resubmission policy
+  PreemptionCount INT64,
+  MaxPreemptionResubmits INT64,
+  StandardAfterPreemptions INT64,
+  -- Provisioning model of the current attempt; UseSpotVms keeps what was requested
+  AttemptUseSpotVms BOOL,
+  -- Requested GPU accelerator (validated against the job-config.json catalog)
+  AcceleratorType STRING(64),
+  AcceleratorCount INT64,
+  AcceleratorDriverVersion STRING(64),
+  -- Requested volume mounts (GCS, NFS, local SSD) stored as JSON
+  VolumesJson STRING(MAX),
+  -- Ordered task steps (setup, sidecar, teardown) stored as JSON
+  RunnablesJson STRING(MAX),
+  -- Custom billing labels from the submission stored as JSON
+  LabelsJson STRING(MAX),
+  -- Why the routing policy chain dispatched the job to AssignedService
+  RoutingReason STRING(MAX),
+  -- Provider resource paths of attempts superseded by a preemption resubmission
+  PreviousJobPaths ARRAY<STRING(1024)>,
 ) PRIMARY KEY (TenantId, JobId),
   INTERLEAVE IN PARENT Tenants ON DELETE CASCADE;
 
@@ -51,3 +75,117 @@ CREATE TABLE JobStateTransitions (
   INTERLEAVE IN PARENT Jobs ON DELETE CASCADE;
 
 CREATE INDEX TransitionsByJob ON JobStateTransitions(TenantId, JobId, TransitionedAt DESC);
+
+-- Leases for worker-wide periodic tasks (one owner per task)
+CREATE TABLE TaskLeases (
+  TaskName STRING(64) NOT NULL,
+  OwnerWorkerId STRING(255) NOT NULL,
+  LeaseExpiresAt TIMESTAMP NOT NULL,
+  UpdatedAt TIMESTAMP NOT NULL OPTIONS (allow_commit_timestamp=true),
+) PRIMARY KEY (TaskName);
+
+-- Worker registry (gateways route to ACTIVE workers with a recent heartbeat)
+CREATE TABLE Workers (
+  WorkerId STRING(255) NOT NULL,
+  Address STRING(255) NOT NULL,
+  Capacity INT64 NOT NULL,
+  Version STRING(64),
+  State STRING(32) NOT NULL,
+  RegisteredAt TIMESTAMP NOT NULL OPTIONS (allow_commit_timestamp=true),
+  LastHeartbeatAt TIMESTAMP NOT NULL OPTIONS (allow_commit_timestamp=true),
+) PRIMARY KEY (WorkerId);
+
+-- Tenant-scoped API keys for CI and automation. Keys are sent as
+-- jnh_<KeyId>_<secret>; only a salted SHA-256 hash of the secret is stored.
+CREATE TABLE ApiKeys (
+  TenantId   STRING(36)  NOT NULL,
+  KeyId      STRING(32)  NOT NULL,
+  Name       STRING(255) NOT NULL,
+  SecretHash BYTES(32)   NOT NULL,
+  Salt       BYTES(16)   NOT NULL,
+  Scopes     ARRAY<STRING(32)> NOT NULL,
+  CreatedBy  STRING(255) NOT NULL,
+  CreatedAt  TIMESTAMP   NOT NULL OPTIONS (allow_commit_timestamp=true),
+  ExpiresAt  TIMESTAMP,
+  LastUsedAt TIMESTAMP,
+  RevokedAt  TIMESTAMP,
+) PRIMARY KEY (TenantId, KeyId),
+  INTERLEAVE IN PARENT Tenants ON DELETE CASCADE;
+
+CREATE UNIQUE INDEX ApiKeysByKeyId ON ApiKeys(KeyId);
+
+-- Organization members. Roles: viewer, submitter, admin. Every tenant has at
+-- least one admin.
+CREATE TABLE Memberships (
+  TenantId      STRING(36)  NOT NULL,
+  OAuthProvider STRING(50)  NOT NULL,
+  OAuthUserId   STRING(255) NOT NULL,
+  Email         STRING(255) NOT NULL,
+  Role          STRING(32)  NOT NULL,
+  CreatedAt     TIMESTAMP   NOT NULL OPTIONS (allow_commit_timestamp=true),
+) PRIMARY KEY (TenantId, OAuthProvider, OAuthUserId),
+  INTERLEAVE IN PARENT Tenants ON DELETE CASCADE;
+
+CREATE INDEX MembershipsByUser ON Memberships(OAuthProvider, OAuthUserId);
+
+-- Invitations to join an organization, accepted by the user with the
+-- (lowercased) Email.
+CREATE TABLE Invitations (
+  TenantId     STRING(36)  NOT NULL,
+  InvitationId STRING(36)  NOT NULL,
+  Email        STRING(255) NOT NULL,
+  Role         STRING(32)  NOT NULL,
+  InvitedBy    STRING(255) NOT NULL,
+  CreatedAt    TIMESTAMP   NOT NULL OPTIONS (allow_commit_timestamp=true),
+  ExpiresAt    TIMESTAMP   NOT NULL,
+  AcceptedAt   TIMESTAMP,
+) PRIMARY KEY (TenantId, InvitationId),
+  INTERLEAVE IN PARENT Tenants ON DELETE CASCADE;
+
+CREATE UNIQUE INDEX InvitationsByInvitationId ON Invitations(InvitationId);
+CREATE INDEX InvitationsByEmail ON Invitations(Email);
+
+-- Sign-in identities of personal tenants. A user who signs in with several
+-- providers links them here so they all reach the same tenant.
+CREATE TABLE Identities (
+  OAuthProvider STRING(50)  NOT NULL,
+  OAuthUserId   STRING(255) NOT NULL,
+  TenantId      STRING(36)  NOT NULL,
+  Email         STRING(255) NOT NULL,
+  LinkedAt      TIMESTAMP   NOT NULL OPTIONS (allow_commit_timestamp=true),
+) PRIMARY KEY (OAuthProvider, OAuthUserId);
+
+CREATE INDEX IdentitiesByTenant ON Identities(TenantId);
+
+-- Append-only log of calls to mutating RPCs, written by the gateway. Not
+-- interleaved in Tenants so events outlive the jobs, members and tenants
+-- they refer to. Calls that fail before a tenant is known have an empty
+-- TenantId.
+CREATE TABLE AuditEvents (
+  TenantId     STRING(36)  NOT NULL,
+  EventId      STRING(36)  NOT NULL,
+  OccurredAt   TIMESTAMP   NOT NULL OPTIONS (allow_commit_timestamp=true),
+  Procedure    STRING(255) NOT NULL,
+  ActorType    STRING(16)  NOT NULL,
+  ActorId      STRING(320) NOT NULL,
+  ActorEmail   STRING(255) NOT NULL,
+  TargetType   STRING(32),
+  TargetId     STRING(320),
+  RequestJson  STRING(MAX) NOT NULL,
+  ResultCode   STRING(32)  NOT NULL,
+  ErrorMessage STRING(MAX),
+  ClientIp     STRING(64)  NOT NULL,
+  UserAgent    STRING(512) NOT NULL,
+) PRIMARY KEY (TenantId, EventId);
+
+CREATE INDEX AuditEventsByTime ON AuditEvents(TenantId, OccurredAt DESC, EventId DESC);
+
+-- Token buckets of the gateway's rate limiter, when gateways share them
+-- (--rate-limit-shared). Buckets idle for a day are full again and are
+-- deleted.
+CREATE TABLE RateLimitBuckets (
+  BucketKey STRING(512) NOT NULL,
+  Tokens    FLOAT64     NOT NULL,
+  UpdatedAt TIMESTAMP   NOT NULL,
+) PRIMARY KEY (BucketKey),
+  ROW DELETION POLICY (OLDER_THAN(UpdatedAt, INTERVAL 1 DAY));
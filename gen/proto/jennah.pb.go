@@ -126,6 +126,59 @@ func (AssignedService) EnumDescriptor() ([]byte, []int) {
 	return file_proto_jennah_proto_rawDescGZIP(), []int{1}
 }
 
+// OrphanAction is what ReconcileResources does with orphaned resources.
+type OrphanAction int32
+
+const (
+	// Report only.
+	OrphanAction_ORPHAN_ACTION_UNSPECIFIED OrphanAction = 0
+	// Cancel orphans that are still queued or running.
+	OrphanAction_ORPHAN_ACTION_CANCEL OrphanAction = 1
+	// Delete orphans, cancelling running ones first.
+	OrphanAction_ORPHAN_ACTION_DELETE OrphanAction = 2
+)
+
+// Enum value maps for OrphanAction.
+var (
+	OrphanAction_name = map[int32]string{
+		0: "ORPHAN_ACTION_UNSPECIFIED",
+		1: "ORPHAN_ACTION_CANCEL",
+		2: "ORPHAN_ACTION_DELETE",
+	}
+	OrphanAction_value = map[string]int32{
+		"ORPHAN_ACTION_UNSPECIFIED": 0,
+		"ORPHAN_ACTION_CANCEL":      1,
+		"ORPHAN_ACTION_DELETE":      2,
+	}
+)
+
+func (x OrphanAction) Enum() *OrphanAction {
+	p := new(OrphanAction)
+	*p = x
+	return p
+}
+
+func (x OrphanAction) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (OrphanAction) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_jennah_proto_enumTypes[2].Descriptor()
+}
+
+func (OrphanAction) Type() protoreflect.EnumType {
+	return &file_proto_jennah_proto_enumTypes[2]
+}
+
+func (x OrphanAction) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use OrphanAction.Descriptor instead.
+func (OrphanAction) EnumDescriptor() ([]byte, []int) {
+	return file_proto_jennah_proto_rawDescGZIP(), []int{2}
+}
+
 // ResourceOverride allows callers to specify custom compute resource values.
 // Any zero-value field is filled in from the resolved preset (or default).
 type ResourceOverride struct {
@@ -191,49 +244,35 @@ func (x *ResourceOverride) GetMaxRunDurationSeconds() int64 {
 	return 0
 }
 
-type SubmitJobRequest struct {
+// Accelerator requests GPUs for every task VM of a job. Accelerator jobs
+// always run on Cloud Batch.
+type Accelerator struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// Canonical internal job ID generated by gateway.
-	// If empty, worker may generate one for backward compatibility.
-	JobId    string            `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
-	ImageUri string            `protobuf:"bytes,2,opt,name=image_uri,json=imageUri,proto3" json:"image_uri,omitempty"`
-	EnvVars  map[string]string `protobuf:"bytes,3,rep,name=env_vars,json=envVars,proto3" json:"env_vars,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // Example: { "DB_HOST": "10.0.0.1", "DEBUG": "true" }
-	// resource_profile selects a named preset: "small", "medium", "large", "xlarge".
-	// Defaults to "medium" when empty.
-	ResourceProfile string `protobuf:"bytes,4,opt,name=resource_profile,json=resourceProfile,proto3" json:"resource_profile,omitempty"`
-	// resource_override provides inline resource values that take precedence over the preset.
-	// Partial overrides are supported — zero fields fall back to the resolved preset.
-	ResourceOverride *ResourceOverride `protobuf:"bytes,5,opt,name=resource_override,json=resourceOverride,proto3" json:"resource_override,omitempty"`
-	// Optional user-provided job name.
-	Name string `protobuf:"bytes,6,opt,name=name,proto3" json:"name,omitempty"`
-	// Resolved machine type: "e2-standard-4", "n1-standard-16", etc.
-	MachineType string `protobuf:"bytes,7,opt,name=machine_type,json=machineType,proto3" json:"machine_type,omitempty"`
-	// Boot disk size in GB (default: 50).
-	BootDiskSizeGb int64 `protobuf:"varint,8,opt,name=boot_disk_size_gb,json=bootDiskSizeGb,proto3" json:"boot_disk_size_gb,omitempty"`
-	// Whether to use Spot VMs (default: false).
-	UseSpotVms bool `protobuf:"varint,9,opt,name=use_spot_vms,json=useSpotVms,proto3" json:"use_spot_vms,omitempty"`
-	// Custom service account email (optional).
-	ServiceAccount string `protobuf:"bytes,10,opt,name=service_account,json=serviceAccount,proto3" json:"service_account,omitempty"`
-	// Commands to execute in the container.
-	Commands      []string `protobuf:"bytes,11,rep,name=commands,proto3" json:"commands,omitempty"`
+	// type is the GCP accelerator type, e.g. "nvidia-tesla-t4" or "nvidia-l4".
+	// Must be listed in the worker's accelerator catalog.
+	Type string `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	// count is the number of accelerators per VM. Defaults to 1 when 0.
+	Count int64 `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	// driver_version pins a specific NVIDIA driver version (optional).
+	DriverVersion string `protobuf:"bytes,3,opt,name=driver_version,json=driverVersion,proto3" json:"driver_version,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SubmitJobRequest) Reset() {
-	*x = SubmitJobRequest{}
+func (x *Accelerator) Reset() {
+	*x = Accelerator{}
 	mi := &file_proto_jennah_proto_msgTypes[1]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SubmitJobRequest) String() string {
+func (x *Accelerator) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SubmitJobRequest) ProtoMessage() {}
+func (*Accelerator) ProtoMessage() {}
 
-func (x *SubmitJobRequest) ProtoReflect() protoreflect.Message {
+func (x *Accelerator) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_jennah_proto_msgTypes[1]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -245,117 +284,63 @@ func (x *SubmitJobRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SubmitJobRequest.ProtoReflect.Descriptor instead.
-func (*SubmitJobRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use Accelerator.ProtoReflect.Descriptor instead.
+func (*Accelerator) Descriptor() ([]byte, []int) {
 	return file_proto_jennah_proto_rawDescGZIP(), []int{1}
 }
 
-func (x *SubmitJobRequest) GetJobId() string {
-	if x != nil {
-		return x.JobId
-	}
-	return ""
-}
-
-func (x *SubmitJobRequest) GetImageUri() string {
-	if x != nil {
-		return x.ImageUri
-	}
-	return ""
-}
-
-func (x *SubmitJobRequest) GetEnvVars() map[string]string {
-	if x != nil {
-		return x.EnvVars
-	}
-	return nil
-}
-
-func (x *SubmitJobRequest) GetResourceProfile() string {
-	if x != nil {
-		return x.ResourceProfile
-	}
-	return ""
-}
-
-func (x *SubmitJobRequest) GetResourceOverride() *ResourceOverride {
-	if x != nil {
-		return x.ResourceOverride
-	}
-	return nil
-}
-
-func (x *SubmitJobRequest) GetName() string {
-	if x != nil {
-		return x.Name
-	}
-	return ""
-}
-
-func (x *SubmitJobRequest) GetMachineType() string {
+func (x *Accelerator) GetType() string {
 	if x != nil {
-		return x.MachineType
+		return x.Type
 	}
 	return ""
 }
 
-func (x *SubmitJobRequest) GetBootDiskSizeGb() int64 {
+func (x *Accelerator) GetCount() int64 {
 	if x != nil {
-		return x.BootDiskSizeGb
+		return x.Count
 	}
 	return 0
 }
 
-func (x *SubmitJobRequest) GetUseSpotVms() bool {
-	if x != nil {
-		return x.UseSpotVms
-	}
-	return false
-}
-
-func (x *SubmitJobRequest) GetServiceAccount() string {
+func (x *Accelerator) GetDriverVersion() string {
 	if x != nil {
-		return x.ServiceAccount
+		return x.DriverVersion
 	}
 	return ""
 }
 
-func (x *SubmitJobRequest) GetCommands() []string {
-	if x != nil {
-		return x.Commands
-	}
-	return nil
-}
-
-type SubmitJobResponse struct {
-	state          protoimpl.MessageState `protogen:"open.v1"`
-	JobId          string                 `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
-	Status         string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
-	WorkerAssigned string                 `protobuf:"bytes,3,opt,name=worker_assigned,json=workerAssigned,proto3" json:"worker_assigned,omitempty"`
-	// Complexity tier assigned by the routing classifier: SIMPLE or COMPLEX.
-	ComplexityLevel string `protobuf:"bytes,4,opt,name=complexity_level,json=complexityLevel,proto3" json:"complexity_level,omitempty"`
-	// GCP service assigned to execute this job: CLOUD_RUN_JOB or CLOUD_BATCH.
-	AssignedService string `protobuf:"bytes,5,opt,name=assigned_service,json=assignedService,proto3" json:"assigned_service,omitempty"`
-	// Human-readable explanation of why this routing decision was made.
-	RoutingReason string `protobuf:"bytes,6,opt,name=routing_reason,json=routingReason,proto3" json:"routing_reason,omitempty"`
+// Volume mounts shared storage into every task's container so jobs do not
+// have to download their inputs. Exactly one of gcs, nfs or local_ssd must be
+// set. Cloud Run Jobs mounts GCS and NFS volumes; local SSD scratch needs
+// Cloud Batch.
+type Volume struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// mount_path is the absolute path inside the container, e.g. "/mnt/data".
+	MountPath string `protobuf:"bytes,1,opt,name=mount_path,json=mountPath,proto3" json:"mount_path,omitempty"`
+	// read_only mounts the volume read-only. Not valid for local_ssd.
+	ReadOnly      bool            `protobuf:"varint,2,opt,name=read_only,json=readOnly,proto3" json:"read_only,omitempty"`
+	Gcs           *GcsVolume      `protobuf:"bytes,3,opt,name=gcs,proto3" json:"gcs,omitempty"`
+	Nfs           *NfsVolume      `protobuf:"bytes,4,opt,name=nfs,proto3" json:"nfs,omitempty"`
+	LocalSsd      *LocalSsdVolume `protobuf:"bytes,5,opt,name=local_ssd,json=localSsd,proto3" json:"local_ssd,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SubmitJobResponse) Reset() {
-	*x = SubmitJobResponse{}
+func (x *Volume) Reset() {
+	*x = Volume{}
 	mi := &file_proto_jennah_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SubmitJobResponse) String() string {
+func (x *Volume) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SubmitJobResponse) ProtoMessage() {}
+func (*Volume) ProtoMessage() {}
 
-func (x *SubmitJobResponse) ProtoReflect() protoreflect.Message {
+func (x *Volume) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_jennah_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -367,73 +352,69 @@ func (x *SubmitJobResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SubmitJobResponse.ProtoReflect.Descriptor instead.
-func (*SubmitJobResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use Volume.ProtoReflect.Descriptor instead.
+func (*Volume) Descriptor() ([]byte, []int) {
 	return file_proto_jennah_proto_rawDescGZIP(), []int{2}
 }
 
-func (x *SubmitJobResponse) GetJobId() string {
-	if x != nil {
-		return x.JobId
-	}
-	return ""
-}
-
-func (x *SubmitJobResponse) GetStatus() string {
+func (x *Volume) GetMountPath() string {
 	if x != nil {
-		return x.Status
+		return x.MountPath
 	}
 	return ""
 }
 
-func (x *SubmitJobResponse) GetWorkerAssigned() string {
+func (x *Volume) GetReadOnly() bool {
 	if x != nil {
-		return x.WorkerAssigned
+		return x.ReadOnly
 	}
-	return ""
+	return false
 }
 
-func (x *SubmitJobResponse) GetComplexityLevel() string {
+func (x *Volume) GetGcs() *GcsVolume {
 	if x != nil {
-		return x.ComplexityLevel
+		return x.Gcs
 	}
-	return ""
+	return nil
 }
 
-func (x *SubmitJobResponse) GetAssignedService() string {
+func (x *Volume) GetNfs() *NfsVolume {
 	if x != nil {
-		return x.AssignedService
+		return x.Nfs
 	}
-	return ""
+	return nil
 }
 
-func (x *SubmitJobResponse) GetRoutingReason() string {
+func (x *Volume) GetLocalSsd() *LocalSsdVolume {
 	if x != nil {
-		return x.RoutingReason
+		return x.LocalSsd
 	}
-	return ""
+	return nil
 }
 
-type ListJobsRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
+// GcsVolume mounts a Cloud Storage bucket with Cloud Storage FUSE.
+type GcsVolume struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// bucket is the bucket name without the "gs://" prefix.
+	Bucket        string `protobuf:"bytes,1,opt,name=bucket,proto3" json:"bucket,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListJobsRequest) Reset() {
-	*x = ListJobsRequest{}
+func (x *GcsVolume) Reset() {
+	*x = GcsVolume{}
 	mi := &file_proto_jennah_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListJobsRequest) String() string {
+func (x *GcsVolume) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListJobsRequest) ProtoMessage() {}
+func (*GcsVolume) ProtoMessage() {}
 
-func (x *ListJobsRequest) ProtoReflect() protoreflect.Message {
+func (x *GcsVolume) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_jennah_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -445,32 +426,43 @@ func (x *ListJobsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListJobsRequest.ProtoReflect.Descriptor instead.
-func (*ListJobsRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use GcsVolume.ProtoReflect.Descriptor instead.
+func (*GcsVolume) Descriptor() ([]byte, []int) {
 	return file_proto_jennah_proto_rawDescGZIP(), []int{3}
 }
 
-type ListJobsResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Jobs          []*Job                 `protobuf:"bytes,1,rep,name=jobs,proto3" json:"jobs,omitempty"`
+func (x *GcsVolume) GetBucket() string {
+	if x != nil {
+		return x.Bucket
+	}
+	return ""
+}
+
+// NfsVolume mounts an NFS export, e.g. a Filestore share.
+type NfsVolume struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// server is the NFS server IP address or hostname.
+	Server string `protobuf:"bytes,1,opt,name=server,proto3" json:"server,omitempty"`
+	// path is the exported directory, e.g. "/share".
+	Path          string `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListJobsResponse) Reset() {
-	*x = ListJobsResponse{}
+func (x *NfsVolume) Reset() {
+	*x = NfsVolume{}
 	mi := &file_proto_jennah_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListJobsResponse) String() string {
+func (x *NfsVolume) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListJobsResponse) ProtoMessage() {}
+func (*NfsVolume) ProtoMessage() {}
 
-func (x *ListJobsResponse) ProtoReflect() protoreflect.Message {
+func (x *NfsVolume) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_jennah_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -482,74 +474,48 @@ func (x *ListJobsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListJobsResponse.ProtoReflect.Descriptor instead.
-func (*ListJobsResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use NfsVolume.ProtoReflect.Descriptor instead.
+func (*NfsVolume) Descriptor() ([]byte, []int) {
 	return file_proto_jennah_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *ListJobsResponse) GetJobs() []*Job {
+func (x *NfsVolume) GetServer() string {
 	if x != nil {
-		return x.Jobs
+		return x.Server
 	}
-	return nil
+	return ""
 }
 
-type Job struct {
-	state             protoimpl.MessageState `protogen:"open.v1"`
-	JobId             string                 `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
-	TenantId          string                 `protobuf:"bytes,2,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
-	ImageUri          string                 `protobuf:"bytes,3,opt,name=image_uri,json=imageUri,proto3" json:"image_uri,omitempty"`
-	Status            string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
-	CreatedAt         string                 `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	UpdatedAt         string                 `protobuf:"bytes,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
-	ScheduledAt       string                 `protobuf:"bytes,7,opt,name=scheduled_at,json=scheduledAt,proto3" json:"scheduled_at,omitempty"`
-	StartedAt         string                 `protobuf:"bytes,8,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
-	CompletedAt       string                 `protobuf:"bytes,9,opt,name=completed_at,json=completedAt,proto3" json:"completed_at,omitempty"`
-	RetryCount        int64                  `protobuf:"varint,10,opt,name=retry_count,json=retryCount,proto3" json:"retry_count,omitempty"`
-	MaxRetries        int64                  `protobuf:"varint,11,opt,name=max_retries,json=maxRetries,proto3" json:"max_retries,omitempty"`
-	ErrorMessage      string                 `protobuf:"bytes,12,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
-	GcpBatchJobPath   string                 `protobuf:"bytes,13,opt,name=gcp_batch_job_path,json=gcpBatchJobPath,proto3" json:"gcp_batch_job_path,omitempty"`
-	Commands          []string               `protobuf:"bytes,14,rep,name=commands,proto3" json:"commands,omitempty"`
-	EnvVarsJson       string                 `protobuf:"bytes,15,opt,name=env_vars_json,json=envVarsJson,proto3" json:"env_vars_json,omitempty"`
-	GcpBatchTaskGroup string                 `protobuf:"bytes,16,opt,name=gcp_batch_task_group,json=gcpBatchTaskGroup,proto3" json:"gcp_batch_task_group,omitempty"`
-	// User-facing job name.
-	Name string `protobuf:"bytes,17,opt,name=name,proto3" json:"name,omitempty"`
-	// Resource preset used at submission: "small", "medium", "large", "xlarge".
-	ResourceProfile string `protobuf:"bytes,18,opt,name=resource_profile,json=resourceProfile,proto3" json:"resource_profile,omitempty"`
-	// Resolved machine type: "e2-standard-4", "n1-standard-16", etc.
-	MachineType string `protobuf:"bytes,19,opt,name=machine_type,json=machineType,proto3" json:"machine_type,omitempty"`
-	// Boot disk size in GB.
-	BootDiskSizeGb int64 `protobuf:"varint,20,opt,name=boot_disk_size_gb,json=bootDiskSizeGb,proto3" json:"boot_disk_size_gb,omitempty"`
-	// Whether Spot VMs were requested.
-	UseSpotVms bool `protobuf:"varint,21,opt,name=use_spot_vms,json=useSpotVms,proto3" json:"use_spot_vms,omitempty"`
-	// Custom service account email (optional).
-	ServiceAccount string `protobuf:"bytes,22,opt,name=service_account,json=serviceAccount,proto3" json:"service_account,omitempty"`
-	// Complexity tier assigned by the routing classifier: SIMPLE or COMPLEX.
-	ComplexityLevel string `protobuf:"bytes,23,opt,name=complexity_level,json=complexityLevel,proto3" json:"complexity_level,omitempty"`
-	// GCP service assigned to execute this job: CLOUD_RUN_JOB or CLOUD_BATCH.
-	AssignedService string `protobuf:"bytes,24,opt,name=assigned_service,json=assignedService,proto3" json:"assigned_service,omitempty"`
-	// Resource override values saved at submission time.
-	MemoryMib             int64 `protobuf:"varint,25,opt,name=memory_mib,json=memoryMib,proto3" json:"memory_mib,omitempty"`
-	CpuMillis             int64 `protobuf:"varint,26,opt,name=cpu_millis,json=cpuMillis,proto3" json:"cpu_millis,omitempty"`
-	MaxRunDurationSeconds int64 `protobuf:"varint,27,opt,name=max_run_duration_seconds,json=maxRunDurationSeconds,proto3" json:"max_run_duration_seconds,omitempty"`
-	unknownFields         protoimpl.UnknownFields
-	sizeCache             protoimpl.SizeCache
+func (x *NfsVolume) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
 }
 
-func (x *Job) Reset() {
-	*x = Job{}
+// LocalSsdVolume attaches local SSD scratch space to each task VM.
+type LocalSsdVolume struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// size_gb must be a multiple of 375. Defaults to 375 when 0.
+	SizeGb        int64 `protobuf:"varint,1,opt,name=size_gb,json=sizeGb,proto3" json:"size_gb,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LocalSsdVolume) Reset() {
+	*x = LocalSsdVolume{}
 	mi := &file_proto_jennah_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Job) String() string {
+func (x *LocalSsdVolume) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Job) ProtoMessage() {}
+func (*LocalSsdVolume) ProtoMessage() {}
 
-func (x *Job) ProtoReflect() protoreflect.Message {
+func (x *LocalSsdVolume) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_jennah_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -561,221 +527,3787 @@ func (x *Job) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Job.ProtoReflect.Descriptor instead.
-func (*Job) Descriptor() ([]byte, []int) {
+// Deprecated: Use LocalSsdVolume.ProtoReflect.Descriptor instead.
+func (*LocalSsdVolume) Descriptor() ([]byte, []int) {
 	return file_proto_jennah_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *Job) GetJobId() string {
+func (x *LocalSsdVolume) GetSizeGb() int64 {
 	if x != nil {
-		return x.JobId
+		return x.SizeGb
 	}
-	return ""
+	return 0
 }
 
-func (x *Job) GetTenantId() string {
-	if x != nil {
-		return x.TenantId
-	}
-	return ""
-}
+// Runnable is one step of a multi-step task, e.g. a data-fetch setup step,
+// a background sidecar, or an always-run upload step. Steps run in list order
+// on the same VM. Multi-step jobs run on Cloud Batch only.
+//
+// A step is a container, a script, or a barrier:
+//   - container: image_uri (defaults to the job's image_uri) and commands
+//   - script:    a shell script run on the VM
+//   - barrier:   waits until every task of the job reaches this point
+type Runnable struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// name is shown in logs and, for barriers, identifies the barrier.
+	Name     string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	ImageUri string   `protobuf:"bytes,2,opt,name=image_uri,json=imageUri,proto3" json:"image_uri,omitempty"`
+	Commands []string `protobuf:"bytes,3,rep,name=commands,proto3" json:"commands,omitempty"`
+	Script   string   `protobuf:"bytes,4,opt,name=script,proto3" json:"script,omitempty"`
+	Barrier  bool     `protobuf:"varint,5,opt,name=barrier,proto3" json:"barrier,omitempty"`
+	// env_vars are added to the job's env_vars for this step only.
+	EnvVars map[string]string `protobuf:"bytes,6,rep,name=env_vars,json=envVars,proto3" json:"env_vars,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// background runs the step alongside the following steps, e.g. a sidecar.
+	Background bool `protobuf:"varint,7,opt,name=background,proto3" json:"background,omitempty"`
+	// ignore_exit_status keeps the task running when this step fails.
+	IgnoreExitStatus bool `protobuf:"varint,8,opt,name=ignore_exit_status,json=ignoreExitStatus,proto3" json:"ignore_exit_status,omitempty"`
+	// always_run runs the step even after an earlier step failed, e.g. teardown.
+	AlwaysRun     bool `protobuf:"varint,9,opt,name=always_run,json=alwaysRun,proto3" json:"always_run,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Runnable) Reset() {
+	*x = Runnable{}
+	mi := &file_proto_jennah_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Runnable) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Runnable) ProtoMessage() {}
+
+func (x *Runnable) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_jennah_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Runnable.ProtoReflect.Descriptor instead.
+func (*Runnable) Descriptor() ([]byte, []int) {
+	return file_proto_jennah_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Runnable) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Runnable) GetImageUri() string {
+	if x != nil {
+		return x.ImageUri
+	}
+	return ""
+}
+
+func (x *Runnable) GetCommands() []string {
+	if x != nil {
+		return x.Commands
+	}
+	return nil
+}
+
+func (x *Runnable) GetScript() string {
+	if x != nil {
+		return x.Script
+	}
+	return ""
+}
+
+func (x *Runnable) GetBarrier() bool {
+	if x != nil {
+		return x.Barrier
+	}
+	return false
+}
+
+func (x *Runnable) GetEnvVars() map[string]string {
+	if x != nil {
+		return x.EnvVars
+	}
+	return nil
+}
+
+func (x *Runnable) GetBackground() bool {
+	if x != nil {
+		return x.Background
+	}
+	return false
+}
+
+func (x *Runnable) GetIgnoreExitStatus() bool {
+	if x != nil {
+		return x.IgnoreExitStatus
+	}
+	return false
+}
+
+func (x *Runnable) GetAlwaysRun() bool {
+	if x != nil {
+		return x.AlwaysRun
+	}
+	return false
+}
+
+// PreemptionPolicy controls automatic resubmission of Spot jobs whose VMs
+// are preempted by the cloud provider.
+type PreemptionPolicy struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// max_resubmits is how many times a preempted job is resubmitted before it
+	// is marked FAILED. 0 fails the job on its first preemption.
+	MaxResubmits int32 `protobuf:"varint,1,opt,name=max_resubmits,json=maxResubmits,proto3" json:"max_resubmits,omitempty"`
+	// standard_after switches resubmissions from Spot to STANDARD provisioning
+	// once the job has been preempted this many times. 0 keeps using Spot.
+	StandardAfter int32 `protobuf:"varint,2,opt,name=standard_after,json=standardAfter,proto3" json:"standard_after,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PreemptionPolicy) Reset() {
+	*x = PreemptionPolicy{}
+	mi := &file_proto_jennah_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PreemptionPolicy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PreemptionPolicy) ProtoMessage() {}
+
+func (x *PreemptionPolicy) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_jennah_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PreemptionPolicy.ProtoReflect.Descriptor instead.
+func (*PreemptionPolicy) Descriptor() ([]byte, []int) {
+	return file_proto_jennah_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *PreemptionPolicy) GetMaxResubmits() int32 {
+	if x != nil {
+		return x.MaxResubmits
+	}
+	return 0
+}
+
+func (x *PreemptionPolicy) GetStandardAfter() int32 {
+	if x != nil {
+		return x.StandardAfter
+	}
+	return 0
+}
+
+type SubmitJobRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Canonical internal job ID generated by gateway.
+	// If empty, worker may generate one for backward compatibility.
+	JobId    string            `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	ImageUri string            `protobuf:"bytes,2,opt,name=image_uri,json=imageUri,proto3" json:"image_uri,omitempty"`
+	EnvVars  map[string]string `protobuf:"bytes,3,rep,name=env_vars,json=envVars,proto3" json:"env_vars,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // Example: { "DB_HOST": "10.0.0.1", "DEBUG": "true" }
+	// resource_profile selects a named preset: "small", "medium", "large", "xlarge".
+	// Defaults to "medium" when empty.
+	ResourceProfile string `protobuf:"bytes,4,opt,name=resource_profile,json=resourceProfile,proto3" json:"resource_profile,omitempty"`
+	// resource_override provides inline resource values that take precedence over the preset.
+	// Partial overrides are supported — zero fields fall back to the resolved preset.
+	ResourceOverride *ResourceOverride `protobuf:"bytes,5,opt,name=resource_override,json=resourceOverride,proto3" json:"resource_override,omitempty"`
+	// Optional user-provided job name.
+	Name string `protobuf:"bytes,6,opt,name=name,proto3" json:"name,omitempty"`
+	// Resolved machine type: "e2-standard-4", "n1-standard-16", etc.
+	MachineType string `protobuf:"bytes,7,opt,name=machine_type,json=machineType,proto3" json:"machine_type,omitempty"`
+	// Boot disk size in GB (default: 50).
+	BootDiskSizeGb int64 `protobuf:"varint,8,opt,name=boot_disk_size_gb,json=bootDiskSizeGb,proto3" json:"boot_disk_size_gb,omitempty"`
+	// Whether to use Spot VMs (default: false).
+	UseSpotVms bool `protobuf:"varint,9,opt,name=use_spot_vms,json=useSpotVms,proto3" json:"use_spot_vms,omitempty"`
+	// Custom service account email (optional).
+	ServiceAccount string `protobuf:"bytes,10,opt,name=service_account,json=serviceAccount,proto3" json:"service_account,omitempty"`
+	// Commands to execute in the container.
+	Commands []string `protobuf:"bytes,11,rep,name=commands,proto3" json:"commands,omitempty"`
+	// What to do when Spot VMs are preempted. Only used with use_spot_vms.
+	// When unset, the worker's configured default policy applies.
+	PreemptionPolicy *PreemptionPolicy `protobuf:"bytes,12,opt,name=preemption_policy,json=preemptionPolicy,proto3" json:"preemption_policy,omitempty"`
+	// GPUs to attach to each task VM (optional).
+	Accelerator *Accelerator `protobuf:"bytes,13,opt,name=accelerator,proto3" json:"accelerator,omitempty"`
+	// Storage to mount into the job's containers (optional).
+	Volumes []*Volume `protobuf:"bytes,14,rep,name=volumes,proto3" json:"volumes,omitempty"`
+	// Ordered task steps (optional). When set they replace the single container
+	// built from image_uri and commands; image_uri is still the default image
+	// for container steps, and commands must be left empty.
+	Runnables []*Runnable `protobuf:"bytes,15,rep,name=runnables,proto3" json:"runnables,omitempty"`
+	// Custom labels for billing attribution (optional), e.g. {"cost-center": "cc-1234"}.
+	// Keys must start with a lowercase letter; keys and values may contain only
+	// lowercase letters, digits, "_" and "-", up to 63 characters. Keys starting
+	// with "jennah-" or "goog" are reserved. Labels are attached to every cloud
+	// resource the job creates, alongside Jennah's own labels.
+	Labels        map[string]string `protobuf:"bytes,16,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubmitJobRequest) Reset() {
+	*x = SubmitJobRequest{}
+	mi := &file_proto_jennah_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubmitJobRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubmitJobRequest) ProtoMessage() {}
+
+func (x *SubmitJobRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_jennah_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubmitJobRequest.ProtoReflect.Descriptor instead.
+func (*SubmitJobRequest) Descriptor() ([]byte, []int) {
+	return file_proto_jennah_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *SubmitJobRequest) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+func (x *SubmitJobRequest) GetImageUri() string {
+	if x != nil {
+		return x.ImageUri
+	}
+	return ""
+}
+
+func (x *SubmitJobRequest) GetEnvVars() map[string]string {
+	if x != nil {
+		return x.EnvVars
+	}
+	return nil
+}
+
+func (x *SubmitJobRequest) GetResourceProfile() string {
+	if x != nil {
+		return x.ResourceProfile
+	}
+	return ""
+}
+
+func (x *SubmitJobRequest) GetResourceOverride() *ResourceOverride {
+	if x != nil {
+		return x.ResourceOverride
+	}
+	return nil
+}
+
+func (x *SubmitJobRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *SubmitJobRequest) GetMachineType() string {
+	if x != nil {
+		return x.MachineType
+	}
+	return ""
+}
+
+func (x *SubmitJobRequest) GetBootDiskSizeGb() int64 {
+	if x != nil {
+		return x.BootDiskSizeGb
+	}
+	return 0
+}
+
+func (x *SubmitJobRequest) GetUseSpotVms() bool {
+	if x != nil {
+		return x.UseSpotVms
+	}
+	return false
+}
+
+func (x *SubmitJobRequest) GetServiceAccount() string {
+	if x != nil {
+		return x.ServiceAccount
+	}
+	return ""
+}
+
+func (x *SubmitJobRequest) GetCommands() []string {
+	if x != nil {
+		return x.Commands
+	}
+	return nil
+}
+
+func (x *SubmitJobRequest) GetPreemptionPolicy() *PreemptionPolicy {
+	if x != nil {
+		return x.PreemptionPolicy
+	}
+	return nil
+}
+
+func (x *SubmitJobRequest) GetAccelerator() *Accelerator {
+	if x != nil {
+		return x.Accelerator
+	}
+	return nil
+}
+
+func (x *SubmitJobRequest) GetVolumes() []*Volume {
+	if x != nil {
+		return x.Volumes
+	}
+	return nil
+}
+
+func (x *SubmitJobRequest) GetRunnables() []*Runnable {
+	if x != nil {
+		return x.Runnables
+	}
+	return nil
+}
+
+func (x *SubmitJobRequest) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+type SubmitJobResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	JobId          string                 `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	Status         string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	WorkerAssigned string                 `protobuf:"bytes,3,opt,name=worker_assigned,json=workerAssigned,proto3" json:"worker_assigned,omitempty"`
+	// Complexity tier of the service the job was dispatched to: SIMPLE or COMPLEX.
+	ComplexityLevel string `protobuf:"bytes,4,opt,name=complexity_level,json=complexityLevel,proto3" json:"complexity_level,omitempty"`
+	// GCP service the job was dispatched to: CLOUD_RUN_JOB or CLOUD_BATCH.
+	AssignedService string `protobuf:"bytes,5,opt,name=assigned_service,json=assignedService,proto3" json:"assigned_service,omitempty"`
+	// Human-readable explanation of why the worker's routing chose that service.
+	RoutingReason string `protobuf:"bytes,6,opt,name=routing_reason,json=routingReason,proto3" json:"routing_reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubmitJobResponse) Reset() {
+	*x = SubmitJobResponse{}
+	mi := &file_proto_jennah_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubmitJobResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubmitJobResponse) ProtoMessage() {}
+
+func (x *SubmitJobResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_jennah_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubmitJobResponse.ProtoReflect.Descriptor instead.
+func (*SubmitJobResponse) Descriptor() ([]byte, []int) {
+	return file_proto_jennah_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *SubmitJobResponse) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+func (x *SubmitJobResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *SubmitJobResponse) GetWorkerAssigned() string {
+	if x != nil {
+		return x.WorkerAssigned
+	}
+	return ""
+}
+
+func (x *SubmitJobResponse) GetComplexityLevel() string {
+	if x != nil {
+		return x.ComplexityLevel
+	}
+	return ""
+}
+
+func (x *SubmitJobResponse) GetAssignedService() string {
+	if x != nil {
+		return x.AssignedService
+	}
+	return ""
+}
+
+func (x *SubmitJobResponse) GetRoutingReason() string {
+	if x != nil {
+		return x.RoutingReason
+	}
+	return ""
+}
+
+type ListJobsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListJobsRequest) Reset() {
+	*x = ListJobsRequest{}
+	mi := &file_proto_jennah_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListJobsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListJobsRequest) ProtoMessage() {}
+
+func (x *ListJobsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_jennah_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListJobsRequest.ProtoReflect.Descriptor instead.
+func (*ListJobsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_jennah_proto_rawDescGZIP(), []int{10}
+}
+
+type ListJobsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Jobs          []*Job                 `protobuf:"bytes,1,rep,name=jobs,proto3" json:"jobs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListJobsResponse) Reset() {
+	*x = ListJobsResponse{}
+	mi := &file_proto_jennah_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListJobsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListJobsResponse) ProtoMessage() {}
+
+func (x *ListJobsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_jennah_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListJobsResponse.ProtoReflect.Descriptor instead.
+func (*ListJobsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_jennah_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ListJobsResponse) GetJobs() []*Job {
+	if x != nil {
+		return x.Jobs
+	}
+	return nil
+}
+
+type Job struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	JobId             string                 `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	TenantId          string                 `protobuf:"bytes,2,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	ImageUri          string                 `protobuf:"bytes,3,opt,name=image_uri,json=imageUri,proto3" json:"image_uri,omitempty"`
+	Status            string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	CreatedAt         string                 `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt         string                 `protobuf:"bytes,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	ScheduledAt       string                 `protobuf:"bytes,7,opt,name=scheduled_at,json=scheduledAt,proto3" json:"scheduled_at,omitempty"`
+	StartedAt         string                 `protobuf:"bytes,8,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	CompletedAt       string                 `protobuf:"bytes,9,opt,name=completed_at,json=completedAt,proto3" json:"completed_at,omitempty"`
+	RetryCount        int64                  `protobuf:"varint,10,opt,name=retry_count,json=retryCount,proto3" json:"retry_count,omitempty"`
+	MaxRetries        int64                  `protobuf:"varint,11,opt,name=max_retries,json=maxRetries,proto3" json:"max_retries,omitempty"`
+	ErrorMessage      string                 `protobuf:"bytes,12,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	GcpBatchJobPath   string                 `protobuf:"bytes,13,opt,name=gcp_batch_job_path,json=gcpBatchJobPath,proto3" json:"gcp_batch_job_path,omitempty"`
+	Commands          []string               `protobuf:"bytes,14,rep,name=commands,proto3" json:"commands,omitempty"`
+	EnvVarsJson       string                 `protobuf:"bytes,15,opt,name=env_vars_json,json=envVarsJson,proto3" json:"env_vars_json,omitempty"`
+	GcpBatchTaskGroup string                 `protobuf:"bytes,16,opt,name=gcp_batch_task_group,json=gcpBatchTaskGroup,proto3" json:"gcp_batch_task_group,omitempty"`
+	// User-facing job name.
+	Name string `protobuf:"bytes,17,opt,name=name,proto3" json:"name,omitempty"`
+	// Resource preset used at submission: "small", "medium", "large", "xlarge".
+	ResourceProfile string `protobuf:"bytes,18,opt,name=resource_profile,json=resourceProfile,proto3" json:"resource_profile,omitempty"`
+	// Resolved machine type: "e2-standard-4", "n1-standard-16", etc.
+	MachineType string `protobuf:"bytes,19,opt,name=machine_type,json=machineType,proto3" json:"machine_type,omitempty"`
+	// Boot disk size in GB.
+	BootDiskSizeGb int64 `protobuf:"varint,20,opt,name=boot_disk_size_gb,json=bootDiskSizeGb,proto3" json:"boot_disk_size_gb,omitempty"`
+	// Whether Spot VMs were requested.
+	UseSpotVms bool `protobuf:"varint,21,opt,name=use_spot_vms,json=useSpotVms,proto3" json:"use_spot_vms,omitempty"`
+	// Custom service account email (optional).
+	ServiceAccount string `protobuf:"bytes,22,opt,name=service_account,json=serviceAccount,proto3" json:"service_account,omitempty"`
+	// Complexity tier assigned by the routing classifier: SIMPLE or COMPLEX.
+	ComplexityLevel string `protobuf:"bytes,23,opt,name=complexity_level,json=complexityLevel,proto3" json:"complexity_level,omitempty"`
+	// GCP service assigned to execute this job: CLOUD_RUN_JOB or CLOUD_BATCH.
+	AssignedService string `protobuf:"bytes,24,opt,name=assigned_service,json=assignedService,proto3" json:"assigned_service,omitempty"`
+	// Resource override values saved at submission time.
+	MemoryMib             int64 `protobuf:"varint,25,opt,name=memory_mib,json=memoryMib,proto3" json:"memory_mib,omitempty"`
+	CpuMillis             int64 `protobuf:"varint,26,opt,name=cpu_millis,json=cpuMillis,proto3" json:"cpu_millis,omitempty"`
+	MaxRunDurationSeconds int64 `protobuf:"varint,27,opt,name=max_run_duration_seconds,json=maxRunDurationSeconds,proto3" json:"max_run_duration_seconds,omitempty"`
+	// Region the job was placed in; differs from the primary region after failover.
+	Region string `protobuf:"bytes,28,opt,name=region,proto3" json:"region,omitempty"`
+	// Number of times the job's Spot VMs have been preempted.
+	PreemptionCount int64 `protobuf:"varint,29,opt,name=preemption_count,json=preemptionCount,proto3" json:"preemption_count,omitempty"`
+	// GPUs requested at submission time.
+	Accelerator *Accelerator `protobuf:"bytes,30,opt,name=accelerator,proto3" json:"accelerator,omitempty"`
+	// Volumes requested at submission time.
+	Volumes []*Volume `protobuf:"bytes,31,rep,name=volumes,proto3" json:"volumes,omitempty"`
+	// Task steps requested at submission time.
+	Runnables []*Runnable `protobuf:"bytes,32,rep,name=runnables,proto3" json:"runnables,omitempty"`
+	// Custom labels from the submission.
+	Labels map[string]string `protobuf:"bytes,33,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Why routing dispatched the job to assigned_service.
+	RoutingReason string `protobuf:"bytes,34,opt,name=routing_reason,json=routingReason,proto3" json:"routing_reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Job) Reset() {
+	*x = Job{}
+	mi := &file_proto_jennah_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Job) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Job) ProtoMessage() {}
+
+func (x *Job) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_jennah_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Job.ProtoReflect.Descriptor instead.
+func (*Job) Descriptor() ([]byte, []int) {
+	return file_proto_jennah_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *Job) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+func (x *Job) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *Job) GetImageUri() string {
+	if x != nil {
+		return x.ImageUri
+	}
+	return ""
+}
+
+func (x *Job) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Job) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *Job) GetUpdatedAt() string {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return ""
+}
+
+func (x *Job) GetScheduledAt() string {
+	if x != nil {
+		return x.ScheduledAt
+	}
+	return ""
+}
+
+func (x *Job) GetStartedAt() string {
+	if x != nil {
+		return x.StartedAt
+	}
+	return ""
+}
+
+func (x *Job) GetCompletedAt() string {
+	if x != nil {
+		return x.CompletedAt
+	}
+	return ""
+}
+
+func (x *Job) GetRetryCount() int64 {
+	if x != nil {
+		return x.RetryCount
+	}
+	return 0
+}
+
+func (x *Job) GetMaxRetries() int64 {
+	if x != nil {
+		return x.MaxRetries
+	}
+	return 0
+}
+
+func (x *Job) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+func (x *Job) GetGcpBatchJobPath() string {
+	if x != nil {
+		return x.GcpBatchJobPath
+	}
+	return ""
+}
+
+func (x *Job) GetCommands() []string {
+	if x != nil {
+		return x.Commands
+	}
+	return nil
+}
+
+func (x *Job) GetEnvVarsJson() string {
+	if x != nil {
+		return x.EnvVarsJson
+	}
+	return ""
+}
+
+func (x *Job) GetGcpBatchTaskGroup() string {
+	if x != nil {
+		return x.GcpBatchTaskGroup
+	}
+	return ""
+}
+
+func (x *Job) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Job) GetResourceProfile() string {
+	if x != nil {
+		return x.ResourceProfile
+	}
+	return ""
+}
+
+func (x *Job) GetMachineType() string {
+	if x != nil {
+		return x.MachineType
+	}
+	return ""
+}
+
+func (x *Job) GetBootDiskSizeGb() int64 {
+	if x != nil {
+		return x.BootDiskSizeGb
+	}
+	return 0
+}
+
+func (x *Job) GetUseSpotVms() bool {
+	if x != nil {
+		return x.UseSpotVms
+	}
+	return false
+}
+
+func (x *Job) GetServiceAccount() string {
+	if x != nil {
+		return x.ServiceAccount
+	}
+	return ""
+}
+
+func (x *Job) GetComplexityLevel() string {
+	if x != nil {
+		return x.ComplexityLevel
+	}
+	return ""
+}
+
+func (x *Job) GetAssignedService() string {
+	if x != nil {
+		return x.AssignedService
+	}
+	return ""
+}
+
+func (x *Job) GetMemoryMib() int64 {
+	if x != nil {
+		return x.MemoryMib
+	}
+	return 0
+}
+
+func (x *Job) GetCpuMillis() int64 {
+	if x != nil {
+		return x.CpuMillis
+	}
+	return 0
+}
+
+func (x *Job) GetMaxRunDurationSeconds() int64 {
+	if x != nil {
+		return x.MaxRunDurationSeconds
+	}
+	return 0
+}
+
+func (x *Job) GetRegion() string {
+	if x != nil {
+		return x.Region
+	}
+	return ""
+}
+
+func (x *Job) GetPreemptionCount() int64 {
+	if x != nil {
+		return x.PreemptionCount
+	}
+	return 0
+}
+
+func (x *Job) GetAccelerator() *Accelerator {
+	if x != nil {
+		return x.Accelerator
+	}
+	return nil
+}
+
+func (x *Job) GetVolumes() []*Volume {
+	if x != nil {
+		return x.Volumes
+	}
+	return nil
+}
+
+func (x *Job) GetRunnables() []*Runnable {
+	if x != nil {
+		return x.Runnables
+	}
+	return nil
+}
+
+func (x *Job) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *Job) GetRoutingReason() string {
+	if x != nil {
+		return x.RoutingReason
+	}
+	return ""
+}
+
+type GetCurrentTenantRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCurrentTenantRequest) Reset() {
+	*x = GetCurrentTenantRequest{}
+	mi := &file_proto_jennah_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCurrentTenantRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCurrentTenantRequest) ProtoMessage() {}
+
+func (x *GetCurrentTenantRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_jennah_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCurrentTenantRequest.ProtoReflect.Descriptor instead.
+func (*GetCurrentTenantRequest) Descriptor() ([]byte, []int) {
+	return file_proto_jennah_proto_rawDescGZIP(), []int{13}
+}
+
+type GetCurrentTenantResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TenantId      string                 `protobuf:"bytes,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	UserEmail     string                 `protobuf:"bytes,2,opt,name=user_email,json=userEmail,proto3" json:"user_email,omitempty"`
+	OauthProvider string                 `protobuf:"bytes,3,opt,name=oauth_provider,json=oauthProvider,proto3" json:"oauth_provider,omitempty"` // "google", "github"
+	CreatedAt     string                 `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// Name of the organization; empty for a personal tenant.
+	OrgName string `protobuf:"bytes,5,opt,name=org_name,json=orgName,proto3" json:"org_name,omitempty"`
+	// The caller's role in it. For API keys, the role is empty.
+	Role string `protobuf:"bytes,6,opt,name=role,proto3" json:"role,omitempty"`
+	// Sign-in identities linked to the tenant; empty for organizations.
+	Identities    []*LinkedIdentity `protobuf:"bytes,7,rep,name=identities,proto3" json:"identities,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCurrentTenantResponse) Reset() {
+	*x = GetCurrentTenantResponse{}
+	mi := &file_proto_jennah_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCurrentTenantResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCurrentTenantResponse) ProtoMessage() {}
+
+func (x *GetCurrentTenantResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_jennah_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCurrentTenantResponse.ProtoReflect.Descriptor instead.
+func (*GetCurrentTenantResponse) Descriptor() ([]byte, []int) {
+	return file_proto_jennah_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *GetCurrentTenantResponse) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *GetCurrentTenantResponse) GetUserEmail() string {
+	if x != nil {
+		return x.UserEmail
+	}
+	return ""
+}
+
+func (x *GetCurrentTenantResponse) GetOauthProvider() string {
+	if x != nil {
+		return x.OauthProvider
+	}
+	return ""
+}
+
+func (x *GetCurrentTenantResponse) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *GetCurrentTenantResponse) GetOrgName() string {
+	if x != nil {
+		return x.OrgName
+	}
+	return ""
+}
+
+func (x *GetCurrentTenantResponse) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *GetCurrentTenantResponse) GetIdentities() []*LinkedIdentity {
+	if x != nil {
+		return x.Identities
+	}
+	return nil
+}
+
+type CancelJobRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	JobId         string                 `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelJobRequest) Reset() {
+	*x = CancelJobRequest{}
+	mi := &file_proto_jennah_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelJobRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelJobRequest) ProtoMessage() {}
+
+func (x *CancelJobRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_jennah_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelJobRequest.ProtoReflect.Descriptor instead.
+func (*CancelJobRequest) Descriptor() ([]byte, []int) {
+	return file_proto_jennah_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *CancelJobRequest) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+type CancelJobResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	JobId         string                 `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelJobResponse) Reset() {
+	*x = CancelJobResponse{}
+	mi := &file_proto_jennah_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelJobResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelJobResponse) ProtoMessage() {}
+
+func (x *CancelJobResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_jennah_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelJobResponse.ProtoReflect.Descriptor instead.
+func (*CancelJobResponse) Descriptor() ([]byte, []int) {
+	return file_proto_jennah_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *CancelJobResponse) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+func (x *CancelJobResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+type DeleteJobRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	JobId         string                 `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteJobRequest) Reset() {
+	*x = DeleteJobRequest{}
+	mi := &file_proto_jennah_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteJobRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteJobRequest) ProtoMessage() {}
+
+func (x *DeleteJobRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_jennah_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteJobRequest.ProtoReflect.Descriptor instead.
+func (*DeleteJobRequest) Descriptor() ([]byte, []int) {
+	return file_proto_jennah_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *DeleteJobRequest) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+type DeleteJobResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	JobId         string                 `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteJobResponse) Reset() {
+	*x = DeleteJobResponse{}
+	mi := &file_proto_jennah_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteJobResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteJobResponse) ProtoMessage() {}
+
+func (x *DeleteJobResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_jennah_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteJobResponse.ProtoReflect.Descriptor instead.
+func (*DeleteJobResponse) Descriptor() ([]byte, []int) {
+	return file_proto_jennah_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *DeleteJobResponse) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+func (x *DeleteJobResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type GetJobRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	JobId         string                 `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetJobRequest) Reset() {
+	*x = GetJobRequest{}
+	mi := &file_proto_jennah_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetJobRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetJobRequest) ProtoMessage() {}
+
+func (x *GetJobRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_jennah_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetJobRequest.ProtoReflect.Descriptor instead.
+func (*GetJobRequest) Descriptor() ([]byte, []int) {
+	return file_proto_jennah_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *GetJobRequest) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+type GetJobResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Job           *Job                   `protobuf:"bytes,1,opt,name=job,proto3" json:"job,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetJobResponse) Reset() {
+	*x = GetJobResponse{}
+	mi := &file_proto_jennah_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetJobResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetJobResponse) ProtoMessage() {}
+
+func (x *GetJobResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_jennah_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetJobResponse.ProtoReflect.Descriptor instead.
+func (*GetJobResponse) Descriptor() ([]byte, []int) {
+	return file_proto_jennah_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *GetJobResponse) GetJob() *Job {
+	if x != nil {
+		return x.Job
+	}
+	return nil
+}
+
+// A single in-app notification produced from a job.terminal Pub/Sub event.
+type Notification struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Stable event ID from the Pub/Sub payload — used for deduplication.
+	Id      string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	JobId   string `protobuf:"bytes,2,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	JobName string `protobuf:"bytes,3,opt,name=job_name,json=jobName,proto3" json:"job_name,omitempty"`
+	// final_status: COMPLETED | FAILED | CANCELLED
+	FinalStatus     string `protobuf:"bytes,4,opt,name=final_status,json=finalStatus,proto3" json:"final_status,omitempty"`
+	ServiceTier     string `protobuf:"bytes,5,opt,name=service_tier,json=serviceTier,proto3" json:"service_tier,omitempty"`             // SIMPLE | COMPLEX
+	AssignedService string `protobuf:"bytes,6,opt,name=assigned_service,json=assignedService,proto3" json:"assigned_service,omitempty"` // CLOUD_RUN_JOB | CLOUD_BATCH
+	// Unix timestamp (seconds) of when the terminal event occurred.
+	OccurredAt    int64  `protobuf:"varint,7,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`
+	ErrorMessage  string `protobuf:"bytes,8,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	IsRead        bool   `protobuf:"varint,9,opt,name=is_read,json=isRead,proto3" json:"is_read,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Notification) Reset() {
+	*x = Notification{}
+	mi := &file_proto_jennah_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Notification) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Notification) ProtoMessage() {}
+
+func (x *Notification) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_jennah_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Notification.ProtoReflect.Descriptor instead.
+func (*Notification) Descriptor() ([]byte, []int) {
+	return file_proto_jennah_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *Notification) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Notification) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+func (x *Notification) GetJobName() string {
+	if x != nil {
+		return x.JobName
+	}
+	return ""
+}
+
+func (x *Notification) GetFinalStatus() string {
+	if x != nil {
+		return x.FinalStatus
+	}
+	return ""
+}
+
+func (x *Notification) GetServiceTier() string {
+	if x != nil {
+		return x.ServiceTier
+	}
+	return ""
+}
+
+func (x *Notification) GetAssignedService() string {
+	if x != nil {
+		return x.AssignedService
+	}
+	return ""
+}
+
+func (x *Notification) GetOccurredAt() int64 {
+	if x != nil {
+		return x.OccurredAt
+	}
+	return 0
+}
+
+func (x *Notification) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+func (x *Notification) GetIsRead() bool {
+	if x != nil {
+		return x.IsRead
+	}
+	return false
+}
+
+type ListNotificationsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Maximum number of notifications to return (default 20).
+	Limit         int32 `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListNotificationsRequest) Reset() {
+	*x = ListNotificationsRequest{}
+	mi := &file_proto_jennah_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListNotificationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListNotificationsRequest) ProtoMessage() {}
+
+func (x *ListNotificationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_jennah_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListNotificationsRequest.ProtoReflect.Descriptor instead.
+func (*ListNotificationsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_jennah_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ListNotificationsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListNotificationsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Notifications []*Notification        `protobuf:"bytes,1,rep,name=notifications,proto3" json:"notifications,omitempty"`
+	UnreadCount   int32                  `protobuf:"varint,2,opt,name=unread_count,json=unreadCount,proto3" json:"unread_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListNotificationsResponse) Reset() {
+	*x = ListNotificationsResponse{}
+	mi := &file_proto_jennah_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListNotificationsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListNotificationsResponse) ProtoMessage() {}
+
+func (x *ListNotificationsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_jennah_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListNotificationsResponse.ProtoReflect.Descriptor instead.
+func (*ListNotificationsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_jennah_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ListNotificationsResponse) GetNotifications() []*Notification {
+	if x != nil {
+		return x.Notifications
+	}
+	return nil
+}
+
+func (x *ListNotificationsResponse) GetUnreadCount() int32 {
+	if x != nil {
+		return x.UnreadCount
+	}
+	return 0
+}
+
+type AckNotificationRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	NotificationId string                 `protobuf:"bytes,1,opt,name=notification_id,json=notificationId,proto3" json:"notification_id,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *AckNotificationRequest) Reset() {
+	*x = AckNotificationRequest{}
+	mi := &file_proto_jennah_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AckNotificationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AckNotificationRequest) ProtoMessage() {}
+
+func (x *AckNotificationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_jennah_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AckNotificationRequest.ProtoReflect.Descriptor instead.
+func (*AckNotificationRequest) Descriptor() ([]byte, []int) {
+	return file_proto_jennah_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *AckNotificationRequest) GetNotificationId() string {
+	if x != nil {
+		return x.NotificationId
+	}
+	return ""
+}
+
+type AckNotificationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AckNotificationResponse) Reset() {
+	*x = AckNotificationResponse{}
+	mi := &file_proto_jennah_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AckNotificationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AckNotificationResponse) ProtoMessage() {}
+
+func (x *AckNotificationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_jennah_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AckNotificationResponse.ProtoReflect.Descriptor instead.
+func (*AckNotificationResponse) Descriptor() ([]byte, []int) {
+	return file_proto_jennah_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *AckNotificationResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+// An API key, without its secret. Keys are sent as
+// "Authorization: Bearer jnh_<key_id>_<secret>".
+type ApiKey struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	KeyId string                 `protobuf:"bytes,1,opt,name=key_id,json=keyId,proto3" json:"key_id,omitempty"`
+	Name  string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// Scopes the key grants: "submit" (SubmitJob), "read" (GetJob, ListJobs,
+	// GetCurrentTenant, notifications) and "cancel" (CancelJob, DeleteJob).
+	Scopes []string `protobuf:"bytes,3,rep,name=scopes,proto3" json:"scopes,omitempty"`
+	// Email of the user who created the key.
+	CreatedBy string `protobuf:"bytes,4,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+	// RFC 3339. expires_at, last_used_at and revoked_at are empty when unset.
+	CreatedAt     string `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	ExpiresAt     string `protobuf:"bytes,6,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	LastUsedAt    string `protobuf:"bytes,7,opt,name=last_used_at,json=lastUsedAt,proto3" json:"last_used_at,omitempty"`
+	RevokedAt     string `protobuf:"bytes,8,opt,name=revoked_at,json=revokedAt,proto3" json:"revoked_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApiKey) Reset() {
+	*x = ApiKey{}
+	mi := &file_proto_jennah_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApiKey) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApiKey) ProtoMessage() {}
+
+func (x *ApiKey) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_jennah_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApiKey.ProtoReflect.Descriptor instead.
+func (*ApiKey) Descriptor() ([]byte, []int) {
+	return file_proto_jennah_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *ApiKey) GetKeyId() string {
+	if x != nil {
+		return x.KeyId
+	}
+	return ""
+}
+
+func (x *ApiKey) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ApiKey) GetScopes() []string {
+	if x != nil {
+		return x.Scopes
+	}
+	return nil
+}
+
+func (x *ApiKey) GetCreatedBy() string {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return ""
+}
+
+func (x *ApiKey) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *ApiKey) GetExpiresAt() string {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return ""
+}
+
+func (x *ApiKey) GetLastUsedAt() string {
+	if x != nil {
+		return x.LastUsedAt
+	}
+	return ""
+}
+
+func (x *ApiKey) GetRevokedAt() string {
+	if x != nil {
+		return x.RevokedAt
+	}
+	return ""
+}
+
+type CreateApiKeyRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Name  string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Defaults to all scopes.
+	Scopes []string `protobuf:"bytes,2,rep,name=scopes,proto3" json:"scopes,omitempty"`
+	// Lifetime of the key; 0 means it does not expire.
+	ExpiresInSeconds int64 `protobuf:"varint,3,opt,name=expires_in_seconds,json=expiresInSeconds,proto3" json:"expires_in_seconds,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *CreateApiKeyRequest) Reset() {
+	*x = CreateApiKeyRequest{}
+	mi := &file_proto_jennah_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateApiKeyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateApiKeyRequest) ProtoMessage() {}
+
+func (x *CreateApiKeyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_jennah_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateApiKeyRequest.ProtoReflect.Descriptor instead.
+func (*CreateApiKeyRequest) Descriptor() ([]byte, []int) {
+	return file_proto_jennah_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *CreateApiKeyRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateApiKeyRequest) GetScopes() []string {
+	if x != nil {
+		return x.Scopes
+	}
+	return nil
+}
+
+func (x *CreateApiKeyRequest) GetExpiresInSeconds() int64 {
+	if x != nil {
+		return x.ExpiresInSeconds
+	}
+	return 0
+}
+
+type CreateApiKeyResponse struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	ApiKey *ApiKey                `protobuf:"bytes,1,opt,name=api_key,json=apiKey,proto3" json:"api_key,omitempty"`
+	// The full key. It cannot be retrieved again.
+	Key           string `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateApiKeyResponse) Reset() {
+	*x = CreateApiKeyResponse{}
+	mi := &file_proto_jennah_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateApiKeyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateApiKeyResponse) ProtoMessage() {}
+
+func (x *CreateApiKeyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_jennah_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateApiKeyResponse.ProtoReflect.Descriptor instead.
+func (*CreateApiKeyResponse) Descriptor() ([]byte, []int) {
+	return file_proto_jennah_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *CreateApiKeyResponse) GetApiKey() *ApiKey {
+	if x != nil {
+		return x.ApiKey
+	}
+	return nil
+}
+
+func (x *CreateApiKeyResponse) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+type ListApiKeysRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListApiKeysRequest) Reset() {
+	*x = ListApiKeysRequest{}
+	mi := &file_proto_jennah_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListApiKeysRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListApiKeysRequest) ProtoMessage() {}
+
+func (x *ListApiKeysRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_jennah_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListApiKeysRequest.ProtoReflect.Descriptor instead.
+func (*ListApiKeysRequest) Descriptor() ([]byte, []int) {
+	return file_proto_jennah_proto_rawDescGZIP(), []int{29}
+}
+
+type ListApiKeysResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ApiKeys       []*ApiKey              `protobuf:"bytes,1,rep,name=api_keys,json=apiKeys,proto3" json:"api_keys,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListApiKeysResponse) Reset() {
+	*x = ListApiKeysResponse{}
+	mi := &file_proto_jennah_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListApiKeysResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListApiKeysResponse) ProtoMessage() {}
+
+func (x *ListApiKeysResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_jennah_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListApiKeysResponse.ProtoReflect.Descriptor instead.
+func (*ListApiKeysResponse) Descriptor() ([]byte, []int) {
+	return file_proto_jennah_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *ListApiKeysResponse) GetApiKeys() []*ApiKey {
+	if x != nil {
+		return x.ApiKeys
+	}
+	return nil
+}
+
+type RevokeApiKeyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	KeyId         string                 `protobuf:"bytes,1,opt,name=key_id,json=keyId,proto3" json:"key_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeApiKeyRequest) Reset() {
+	*x = RevokeApiKeyRequest{}
+	mi := &file_proto_jennah_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeApiKeyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeApiKeyRequest) ProtoMessage() {}
+
+func (x *RevokeApiKeyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_jennah_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeApiKeyRequest.ProtoReflect.Descriptor instead.
+func (*RevokeApiKeyRequest) Descriptor() ([]byte, []int) {
+	return file_proto_jennah_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *RevokeApiKeyRequest) GetKeyId() string {
+	if x != nil {
+		return x.KeyId
+	}
+	return ""
+}
+
+type RevokeApiKeyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ApiKey        *ApiKey                `protobuf:"bytes,1,opt,name=api_key,json=apiKey,proto3" json:"api_key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeApiKeyResponse) Reset() {
+	*x = RevokeApiKeyResponse{}
+	mi := &file_proto_jennah_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeApiKeyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeApiKeyResponse) ProtoMessage() {}
+
+func (x *RevokeApiKeyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_jennah_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeApiKeyResponse.ProtoReflect.Descriptor instead.
+func (*RevokeApiKeyResponse) Descriptor() ([]byte, []int) {
+	return file_proto_jennah_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *RevokeApiKeyResponse) GetApiKey() *ApiKey {
+	if x != nil {
+		return x.ApiKey
+	}
+	return nil
+}
+
+// An organization is a tenant with members. Every user also has a personal
+// organization of their own, which is the active one unless a request sets
+// the "X-Jennah-Org: <org_id>" header.
+type Organization struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The tenant ID of the organization.
+	OrgId string `protobuf:"bytes,1,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	// Empty for a personal organization.
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// The caller's role: "viewer", "submitter" or "admin".
+	Role string `protobuf:"bytes,3,opt,name=role,proto3" json:"role,omitempty"`
+	// RFC 3339.
+	CreatedAt     string `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Organization) Reset() {
+	*x = Organization{}
+	mi := &file_proto_jennah_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Organization) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Organization) ProtoMessage() {}
+
+func (x *Organization) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_jennah_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Organization.ProtoReflect.Descriptor instead.
+func (*Organization) Descriptor() ([]byte, []int) {
+	return file_proto_jennah_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *Organization) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
+func (x *Organization) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Organization) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *Organization) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+// A member of an organization. Viewers can read jobs and notifications,
+// submitters can also submit and cancel jobs, and admins can also manage
+// members, invitations and API keys.
+type Member struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Email         string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
+	OauthProvider string                 `protobuf:"bytes,2,opt,name=oauth_provider,json=oauthProvider,proto3" json:"oauth_provider,omitempty"`
+	OauthUserId   string                 `protobuf:"bytes,3,opt,name=oauth_user_id,json=oauthUserId,proto3" json:"oauth_user_id,omitempty"`
+	Role          string                 `protobuf:"bytes,4,opt,name=role,proto3" json:"role,omitempty"`
+	// RFC 3339.
+	JoinedAt      string `protobuf:"bytes,5,opt,name=joined_at,json=joinedAt,proto3" json:"joined_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Member) Reset() {
+	*x = Member{}
+	mi := &file_proto_jennah_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Member) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Member) ProtoMessage() {}
+
+func (x *Member) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_jennah_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Member.ProtoReflect.Descriptor instead.
+func (*Member) Descriptor() ([]byte, []int) {
+	return file_proto_jennah_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *Member) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *Member) GetOauthProvider() string {
+	if x != nil {
+		return x.OauthProvider
+	}
+	return ""
+}
+
+func (x *Member) GetOauthUserId() string {
+	if x != nil {
+		return x.OauthUserId
+	}
+	return ""
+}
+
+func (x *Member) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *Member) GetJoinedAt() string {
+	if x != nil {
+		return x.JoinedAt
+	}
+	return ""
+}
+
+// An invitation to join an organization, accepted by the user whose
+// verified email matches.
+type Invitation struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	InvitationId string                 `protobuf:"bytes,1,opt,name=invitation_id,json=invitationId,proto3" json:"invitation_id,omitempty"`
+	OrgId        string                 `protobuf:"bytes,2,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	OrgName      string                 `protobuf:"bytes,3,opt,name=org_name,json=orgName,proto3" json:"org_name,omitempty"`
+	Email        string                 `protobuf:"bytes,4,opt,name=email,proto3" json:"email,omitempty"`
+	Role         string                 `protobuf:"bytes,5,opt,name=role,proto3" json:"role,omitempty"`
+	// Email of the admin who sent the invitation.
+	InvitedBy string `protobuf:"bytes,6,opt,name=invited_by,json=invitedBy,proto3" json:"invited_by,omitempty"`
+	// RFC 3339.
+	CreatedAt     string `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	ExpiresAt     string `protobuf:"bytes,8,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Invitation) Reset() {
+	*x = Invitation{}
+	mi := &file_proto_jennah_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Invitation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Invitation) ProtoMessage() {}
+
+func (x *Invitation) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_jennah_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Invitation.ProtoReflect.Descriptor instead.
+func (*Invitation) Descriptor() ([]byte, []int) {
+	return file_proto_jennah_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *Invitation) GetInvitationId() string {
+	if x != nil {
+		return x.InvitationId
+	}
+	return ""
+}
+
+func (x *Invitation) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
+func (x *Invitation) GetOrgName() string {
+	if x != nil {
+		return x.OrgName
+	}
+	return ""
+}
+
+func (x *Invitation) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *Invitation) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *Invitation) GetInvitedBy() string {
+	if x != nil {
+		return x.InvitedBy
+	}
+	return ""
+}
+
+func (x *Invitation) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *Invitation) GetExpiresAt() string {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return ""
+}
+
+type CreateOrganizationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateOrganizationRequest) Reset() {
+	*x = CreateOrganizationRequest{}
+	mi := &file_proto_jennah_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateOrganizationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateOrganizationRequest) ProtoMessage() {}
+
+func (x *CreateOrganizationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_jennah_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateOrganizationRequest.ProtoReflect.Descriptor instead.
+func (*CreateOrganizationRequest) Descriptor() ([]byte, []int) {
+	return file_proto_jennah_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *CreateOrganizationRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type CreateOrganizationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Organization  *Organization          `protobuf:"bytes,1,opt,name=organization,proto3" json:"organization,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateOrganizationResponse) Reset() {
+	*x = CreateOrganizationResponse{}
+	mi := &file_proto_jennah_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateOrganizationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateOrganizationResponse) ProtoMessage() {}
+
+func (x *CreateOrganizationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_jennah_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateOrganizationResponse.ProtoReflect.Descriptor instead.
+func (*CreateOrganizationResponse) Descriptor() ([]byte, []int) {
+	return file_proto_jennah_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *CreateOrganizationResponse) GetOrganization() *Organization {
+	if x != nil {
+		return x.Organization
+	}
+	return nil
+}
+
+type ListOrganizationsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListOrganizationsRequest) Reset() {
+	*x = ListOrganizationsRequest{}
+	mi := &file_proto_jennah_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListOrganizationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOrganizationsRequest) ProtoMessage() {}
+
+func (x *ListOrganizationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_jennah_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOrganizationsRequest.ProtoReflect.Descriptor instead.
+func (*ListOrganizationsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_jennah_proto_rawDescGZIP(), []int{38}
+}
+
+type ListOrganizationsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Organizations []*Organization        `protobuf:"bytes,1,rep,name=organizations,proto3" json:"organizations,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListOrganizationsResponse) Reset() {
+	*x = ListOrganizationsResponse{}
+	mi := &file_proto_jennah_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListOrganizationsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOrganizationsResponse) ProtoMessage() {}
+
+func (x *ListOrganizationsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_jennah_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOrganizationsResponse.ProtoReflect.Descriptor instead.
+func (*ListOrganizationsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_jennah_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *ListOrganizationsResponse) GetOrganizations() []*Organization {
+	if x != nil {
+		return x.Organizations
+	}
+	return nil
+}
+
+type ListMembersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMembersRequest) Reset() {
+	*x = ListMembersRequest{}
+	mi := &file_proto_jennah_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMembersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMembersRequest) ProtoMessage() {}
+
+func (x *ListMembersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_jennah_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMembersRequest.ProtoReflect.Descriptor instead.
+func (*ListMembersRequest) Descriptor() ([]byte, []int) {
+	return file_proto_jennah_proto_rawDescGZIP(), []int{40}
+}
+
+type ListMembersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Members       []*Member              `protobuf:"bytes,1,rep,name=members,proto3" json:"members,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMembersResponse) Reset() {
+	*x = ListMembersResponse{}
+	mi := &file_proto_jennah_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMembersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMembersResponse) ProtoMessage() {}
+
+func (x *ListMembersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_jennah_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMembersResponse.ProtoReflect.Descriptor instead.
+func (*ListMembersResponse) Descriptor() ([]byte, []int) {
+	return file_proto_jennah_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *ListMembersResponse) GetMembers() []*Member {
+	if x != nil {
+		return x.Members
+	}
+	return nil
+}
+
+type UpdateMemberRoleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OauthProvider string                 `protobuf:"bytes,1,opt,name=oauth_provider,json=oauthProvider,proto3" json:"oauth_provider,omitempty"`
+	OauthUserId   string                 `protobuf:"bytes,2,opt,name=oauth_user_id,json=oauthUserId,proto3" json:"oauth_user_id,omitempty"`
+	Role          string                 `protobuf:"bytes,3,opt,name=role,proto3" json:"role,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateMemberRoleRequest) Reset() {
+	*x = UpdateMemberRoleRequest{}
+	mi := &file_proto_jennah_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateMemberRoleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateMemberRoleRequest) ProtoMessage() {}
+
+func (x *UpdateMemberRoleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_jennah_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateMemberRoleRequest.ProtoReflect.Descriptor instead.
+func (*UpdateMemberRoleRequest) Descriptor() ([]byte, []int) {
+	return file_proto_jennah_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *UpdateMemberRoleRequest) GetOauthProvider() string {
+	if x != nil {
+		return x.OauthProvider
+	}
+	return ""
+}
+
+func (x *UpdateMemberRoleRequest) GetOauthUserId() string {
+	if x != nil {
+		return x.OauthUserId
+	}
+	return ""
+}
+
+func (x *UpdateMemberRoleRequest) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+type UpdateMemberRoleResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Member        *Member                `protobuf:"bytes,1,opt,name=member,proto3" json:"member,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateMemberRoleResponse) Reset() {
+	*x = UpdateMemberRoleResponse{}
+	mi := &file_proto_jennah_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateMemberRoleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateMemberRoleResponse) ProtoMessage() {}
+
+func (x *UpdateMemberRoleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_jennah_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateMemberRoleResponse.ProtoReflect.Descriptor instead.
+func (*UpdateMemberRoleResponse) Descriptor() ([]byte, []int) {
+	return file_proto_jennah_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *UpdateMemberRoleResponse) GetMember() *Member {
+	if x != nil {
+		return x.Member
+	}
+	return nil
+}
+
+type RemoveMemberRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OauthProvider string                 `protobuf:"bytes,1,opt,name=oauth_provider,json=oauthProvider,proto3" json:"oauth_provider,omitempty"`
+	OauthUserId   string                 `protobuf:"bytes,2,opt,name=oauth_user_id,json=oauthUserId,proto3" json:"oauth_user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveMemberRequest) Reset() {
+	*x = RemoveMemberRequest{}
+	mi := &file_proto_jennah_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveMemberRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveMemberRequest) ProtoMessage() {}
+
+func (x *RemoveMemberRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_jennah_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveMemberRequest.ProtoReflect.Descriptor instead.
+func (*RemoveMemberRequest) Descriptor() ([]byte, []int) {
+	return file_proto_jennah_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *RemoveMemberRequest) GetOauthProvider() string {
+	if x != nil {
+		return x.OauthProvider
+	}
+	return ""
+}
+
+func (x *RemoveMemberRequest) GetOauthUserId() string {
+	if x != nil {
+		return x.OauthUserId
+	}
+	return ""
+}
+
+type RemoveMemberResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveMemberResponse) Reset() {
+	*x = RemoveMemberResponse{}
+	mi := &file_proto_jennah_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveMemberResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveMemberResponse) ProtoMessage() {}
+
+func (x *RemoveMemberResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_jennah_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveMemberResponse.ProtoReflect.Descriptor instead.
+func (*RemoveMemberResponse) Descriptor() ([]byte, []int) {
+	return file_proto_jennah_proto_rawDescGZIP(), []int{45}
+}
+
+type InviteMemberRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Email string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
+	// Defaults to "viewer".
+	Role          string `protobuf:"bytes,2,opt,name=role,proto3" json:"role,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InviteMemberRequest) Reset() {
+	*x = InviteMemberRequest{}
+	mi := &file_proto_jennah_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InviteMemberRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InviteMemberRequest) ProtoMessage() {}
+
+func (x *InviteMemberRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_jennah_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InviteMemberRequest.ProtoReflect.Descriptor instead.
+func (*InviteMemberRequest) Descriptor() ([]byte, []int) {
+	return file_proto_jennah_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *InviteMemberRequest) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *InviteMemberRequest) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+type InviteMemberResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Invitation    *Invitation            `protobuf:"bytes,1,opt,name=invitation,proto3" json:"invitation,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InviteMemberResponse) Reset() {
+	*x = InviteMemberResponse{}
+	mi := &file_proto_jennah_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InviteMemberResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InviteMemberResponse) ProtoMessage() {}
+
+func (x *InviteMemberResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_jennah_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InviteMemberResponse.ProtoReflect.Descriptor instead.
+func (*InviteMemberResponse) Descriptor() ([]byte, []int) {
+	return file_proto_jennah_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *InviteMemberResponse) GetInvitation() *Invitation {
+	if x != nil {
+		return x.Invitation
+	}
+	return nil
+}
+
+type ListInvitationsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListInvitationsRequest) Reset() {
+	*x = ListInvitationsRequest{}
+	mi := &file_proto_jennah_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListInvitationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListInvitationsRequest) ProtoMessage() {}
+
+func (x *ListInvitationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_jennah_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListInvitationsRequest.ProtoReflect.Descriptor instead.
+func (*ListInvitationsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_jennah_proto_rawDescGZIP(), []int{48}
+}
+
+type ListInvitationsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Invitations   []*Invitation          `protobuf:"bytes,1,rep,name=invitations,proto3" json:"invitations,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListInvitationsResponse) Reset() {
+	*x = ListInvitationsResponse{}
+	mi := &file_proto_jennah_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListInvitationsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListInvitationsResponse) ProtoMessage() {}
+
+func (x *ListInvitationsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_jennah_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListInvitationsResponse.ProtoReflect.Descriptor instead.
+func (*ListInvitationsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_jennah_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *ListInvitationsResponse) GetInvitations() []*Invitation {
+	if x != nil {
+		return x.Invitations
+	}
+	return nil
+}
+
+type AcceptInvitationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	InvitationId  string                 `protobuf:"bytes,1,opt,name=invitation_id,json=invitationId,proto3" json:"invitation_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AcceptInvitationRequest) Reset() {
+	*x = AcceptInvitationRequest{}
+	mi := &file_proto_jennah_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AcceptInvitationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AcceptInvitationRequest) ProtoMessage() {}
+
+func (x *AcceptInvitationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_jennah_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AcceptInvitationRequest.ProtoReflect.Descriptor instead.
+func (*AcceptInvitationRequest) Descriptor() ([]byte, []int) {
+	return file_proto_jennah_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *AcceptInvitationRequest) GetInvitationId() string {
+	if x != nil {
+		return x.InvitationId
+	}
+	return ""
+}
+
+type AcceptInvitationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Organization  *Organization          `protobuf:"bytes,1,opt,name=organization,proto3" json:"organization,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AcceptInvitationResponse) Reset() {
+	*x = AcceptInvitationResponse{}
+	mi := &file_proto_jennah_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AcceptInvitationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AcceptInvitationResponse) ProtoMessage() {}
+
+func (x *AcceptInvitationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_jennah_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AcceptInvitationResponse.ProtoReflect.Descriptor instead.
+func (*AcceptInvitationResponse) Descriptor() ([]byte, []int) {
+	return file_proto_jennah_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *AcceptInvitationResponse) GetOrganization() *Organization {
+	if x != nil {
+		return x.Organization
+	}
+	return nil
+}
+
+// A sign-in identity linked to a personal tenant.
+type LinkedIdentity struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Provider string                 `protobuf:"bytes,1,opt,name=provider,proto3" json:"provider,omitempty"` // "google", "github"
+	UserId   string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Email    string                 `protobuf:"bytes,3,opt,name=email,proto3" json:"email,omitempty"`
+	// RFC 3339; empty for the tenant's primary identity if it was never linked.
+	LinkedAt string `protobuf:"bytes,4,opt,name=linked_at,json=linkedAt,proto3" json:"linked_at,omitempty"`
+	// The identity the tenant was created for. It cannot be unlinked.
+	Primary       bool `protobuf:"varint,5,opt,name=primary,proto3" json:"primary,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LinkedIdentity) Reset() {
+	*x = LinkedIdentity{}
+	mi := &file_proto_jennah_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LinkedIdentity) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LinkedIdentity) ProtoMessage() {}
+
+func (x *LinkedIdentity) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_jennah_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LinkedIdentity.ProtoReflect.Descriptor instead.
+func (*LinkedIdentity) Descriptor() ([]byte, []int) {
+	return file_proto_jennah_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *LinkedIdentity) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
+func (x *LinkedIdentity) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *LinkedIdentity) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *LinkedIdentity) GetLinkedAt() string {
+	if x != nil {
+		return x.LinkedAt
+	}
+	return ""
+}
+
+func (x *LinkedIdentity) GetPrimary() bool {
+	if x != nil {
+		return x.Primary
+	}
+	return false
+}
+
+// A token from an identity provider, as passed to CreateSession.
+type IdentityProof struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Provider      string                 `protobuf:"bytes,1,opt,name=provider,proto3" json:"provider,omitempty"`
+	Token         string                 `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IdentityProof) Reset() {
+	*x = IdentityProof{}
+	mi := &file_proto_jennah_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IdentityProof) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IdentityProof) ProtoMessage() {}
+
+func (x *IdentityProof) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_jennah_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IdentityProof.ProtoReflect.Descriptor instead.
+func (*IdentityProof) Descriptor() ([]byte, []int) {
+	return file_proto_jennah_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *IdentityProof) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
+func (x *IdentityProof) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+type LinkIdentityRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Proves the identity of the caller's session.
+	Current *IdentityProof `protobuf:"bytes,1,opt,name=current,proto3" json:"current,omitempty"`
+	// Proves the identity to link.
+	Other *IdentityProof `protobuf:"bytes,2,opt,name=other,proto3" json:"other,omitempty"`
+	// When the other identity already has a personal tenant, move its
+	// finished jobs, notifications, API keys and members into the caller's
+	// tenant and delete it. Without this, linking such an identity fails.
+	MergeTenant   bool `protobuf:"varint,3,opt,name=merge_tenant,json=mergeTenant,proto3" json:"merge_tenant,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LinkIdentityRequest) Reset() {
+	*x = LinkIdentityRequest{}
+	mi := &file_proto_jennah_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LinkIdentityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LinkIdentityRequest) ProtoMessage() {}
+
+func (x *LinkIdentityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_jennah_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LinkIdentityRequest.ProtoReflect.Descriptor instead.
+func (*LinkIdentityRequest) Descriptor() ([]byte, []int) {
+	return file_proto_jennah_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *LinkIdentityRequest) GetCurrent() *IdentityProof {
+	if x != nil {
+		return x.Current
+	}
+	return nil
+}
+
+func (x *LinkIdentityRequest) GetOther() *IdentityProof {
+	if x != nil {
+		return x.Other
+	}
+	return nil
+}
+
+func (x *LinkIdentityRequest) GetMergeTenant() bool {
+	if x != nil {
+		return x.MergeTenant
+	}
+	return false
+}
+
+type LinkIdentityResponse struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Identities []*LinkedIdentity      `protobuf:"bytes,1,rep,name=identities,proto3" json:"identities,omitempty"`
+	// Set when a tenant was merged.
+	MergedTenantId     string `protobuf:"bytes,2,opt,name=merged_tenant_id,json=mergedTenantId,proto3" json:"merged_tenant_id,omitempty"`
+	MovedJobs          int64  `protobuf:"varint,3,opt,name=moved_jobs,json=movedJobs,proto3" json:"moved_jobs,omitempty"`
+	MovedNotifications int64  `protobuf:"varint,4,opt,name=moved_notifications,json=movedNotifications,proto3" json:"moved_notifications,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *LinkIdentityResponse) Reset() {
+	*x = LinkIdentityResponse{}
+	mi := &file_proto_jennah_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LinkIdentityResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LinkIdentityResponse) ProtoMessage() {}
+
+func (x *LinkIdentityResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_jennah_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
 
-func (x *Job) GetImageUri() string {
+// Deprecated: Use LinkIdentityResponse.ProtoReflect.Descriptor instead.
+func (*LinkIdentityResponse) Descriptor() ([]byte, []int) {
+	return file_proto_jennah_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *LinkIdentityResponse) GetIdentities() []*LinkedIdentity {
 	if x != nil {
-		return x.ImageUri
+		return x.Identities
+	}
+	return nil
+}
+
+func (x *LinkIdentityResponse) GetMergedTenantId() string {
+	if x != nil {
+		return x.MergedTenantId
 	}
 	return ""
 }
 
-func (x *Job) GetStatus() string {
+func (x *LinkIdentityResponse) GetMovedJobs() int64 {
 	if x != nil {
-		return x.Status
+		return x.MovedJobs
+	}
+	return 0
+}
+
+func (x *LinkIdentityResponse) GetMovedNotifications() int64 {
+	if x != nil {
+		return x.MovedNotifications
+	}
+	return 0
+}
+
+type UnlinkIdentityRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Provider      string                 `protobuf:"bytes,1,opt,name=provider,proto3" json:"provider,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnlinkIdentityRequest) Reset() {
+	*x = UnlinkIdentityRequest{}
+	mi := &file_proto_jennah_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnlinkIdentityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnlinkIdentityRequest) ProtoMessage() {}
+
+func (x *UnlinkIdentityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_jennah_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnlinkIdentityRequest.ProtoReflect.Descriptor instead.
+func (*UnlinkIdentityRequest) Descriptor() ([]byte, []int) {
+	return file_proto_jennah_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *UnlinkIdentityRequest) GetProvider() string {
+	if x != nil {
+		return x.Provider
 	}
 	return ""
 }
 
-func (x *Job) GetCreatedAt() string {
+func (x *UnlinkIdentityRequest) GetUserId() string {
 	if x != nil {
-		return x.CreatedAt
+		return x.UserId
 	}
 	return ""
 }
 
-func (x *Job) GetUpdatedAt() string {
+type UnlinkIdentityResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Identities    []*LinkedIdentity      `protobuf:"bytes,1,rep,name=identities,proto3" json:"identities,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnlinkIdentityResponse) Reset() {
+	*x = UnlinkIdentityResponse{}
+	mi := &file_proto_jennah_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnlinkIdentityResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnlinkIdentityResponse) ProtoMessage() {}
+
+func (x *UnlinkIdentityResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_jennah_proto_msgTypes[57]
 	if x != nil {
-		return x.UpdatedAt
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnlinkIdentityResponse.ProtoReflect.Descriptor instead.
+func (*UnlinkIdentityResponse) Descriptor() ([]byte, []int) {
+	return file_proto_jennah_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *UnlinkIdentityResponse) GetIdentities() []*LinkedIdentity {
+	if x != nil {
+		return x.Identities
+	}
+	return nil
+}
+
+// A call to a mutating RPC, as recorded by the gateway. Events outlive the
+// jobs and members they refer to.
+type AuditEvent struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	EventId    string                 `protobuf:"bytes,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	OccurredAt string                 `protobuf:"bytes,2,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"` // RFC 3339
+	// Full procedure, e.g. "/jennah.v1.DeploymentService/CancelJob".
+	Procedure string `protobuf:"bytes,3,opt,name=procedure,proto3" json:"procedure,omitempty"`
+	ActorType string `protobuf:"bytes,4,opt,name=actor_type,json=actorType,proto3" json:"actor_type,omitempty"` // "user", "api_key", "anonymous"
+	// "<provider>/<user_id>" for users, the key ID for API keys.
+	ActorId    string `protobuf:"bytes,5,opt,name=actor_id,json=actorId,proto3" json:"actor_id,omitempty"`
+	ActorEmail string `protobuf:"bytes,6,opt,name=actor_email,json=actorEmail,proto3" json:"actor_email,omitempty"`
+	// What the call acted on, e.g. "job" and the job ID.
+	TargetType string `protobuf:"bytes,7,opt,name=target_type,json=targetType,proto3" json:"target_type,omitempty"`
+	TargetId   string `protobuf:"bytes,8,opt,name=target_id,json=targetId,proto3" json:"target_id,omitempty"`
+	// The request as JSON, with tokens, keys and env var values redacted.
+	RequestJson string `protobuf:"bytes,9,opt,name=request_json,json=requestJson,proto3" json:"request_json,omitempty"`
+	// "ok" or the Connect error code, e.g. "permission_denied".
+	ResultCode    string `protobuf:"bytes,10,opt,name=result_code,json=resultCode,proto3" json:"result_code,omitempty"`
+	ErrorMessage  string `protobuf:"bytes,11,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	ClientIp      string `protobuf:"bytes,12,opt,name=client_ip,json=clientIp,proto3" json:"client_ip,omitempty"`
+	UserAgent     string `protobuf:"bytes,13,opt,name=user_agent,json=userAgent,proto3" json:"user_agent,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AuditEvent) Reset() {
+	*x = AuditEvent{}
+	mi := &file_proto_jennah_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuditEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuditEvent) ProtoMessage() {}
+
+func (x *AuditEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_jennah_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuditEvent.ProtoReflect.Descriptor instead.
+func (*AuditEvent) Descriptor() ([]byte, []int) {
+	return file_proto_jennah_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *AuditEvent) GetEventId() string {
+	if x != nil {
+		return x.EventId
 	}
 	return ""
 }
 
-func (x *Job) GetScheduledAt() string {
+func (x *AuditEvent) GetOccurredAt() string {
 	if x != nil {
-		return x.ScheduledAt
+		return x.OccurredAt
 	}
 	return ""
 }
 
-func (x *Job) GetStartedAt() string {
+func (x *AuditEvent) GetProcedure() string {
 	if x != nil {
-		return x.StartedAt
+		return x.Procedure
 	}
 	return ""
 }
 
-func (x *Job) GetCompletedAt() string {
+func (x *AuditEvent) GetActorType() string {
 	if x != nil {
-		return x.CompletedAt
+		return x.ActorType
 	}
 	return ""
 }
 
-func (x *Job) GetRetryCount() int64 {
+func (x *AuditEvent) GetActorId() string {
 	if x != nil {
-		return x.RetryCount
+		return x.ActorId
 	}
-	return 0
+	return ""
 }
 
-func (x *Job) GetMaxRetries() int64 {
+func (x *AuditEvent) GetActorEmail() string {
 	if x != nil {
-		return x.MaxRetries
+		return x.ActorEmail
 	}
-	return 0
+	return ""
 }
 
-func (x *Job) GetErrorMessage() string {
+func (x *AuditEvent) GetTargetType() string {
+	if x != nil {
+		return x.TargetType
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetTargetId() string {
+	if x != nil {
+		return x.TargetId
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetRequestJson() string {
+	if x != nil {
+		return x.RequestJson
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetResultCode() string {
+	if x != nil {
+		return x.ResultCode
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetErrorMessage() string {
 	if x != nil {
 		return x.ErrorMessage
 	}
 	return ""
 }
 
-func (x *Job) GetGcpBatchJobPath() string {
+func (x *AuditEvent) GetClientIp() string {
 	if x != nil {
-		return x.GcpBatchJobPath
+		return x.ClientIp
 	}
 	return ""
 }
 
-func (x *Job) GetCommands() []string {
+func (x *AuditEvent) GetUserAgent() string {
 	if x != nil {
-		return x.Commands
+		return x.UserAgent
 	}
-	return nil
+	return ""
 }
 
-func (x *Job) GetEnvVarsJson() string {
+type ListAuditEventsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Full procedure or method name, e.g. "CancelJob".
+	Procedure string `protobuf:"bytes,1,opt,name=procedure,proto3" json:"procedure,omitempty"`
+	ActorId   string `protobuf:"bytes,2,opt,name=actor_id,json=actorId,proto3" json:"actor_id,omitempty"`
+	TargetId  string `protobuf:"bytes,3,opt,name=target_id,json=targetId,proto3" json:"target_id,omitempty"`
+	// RFC 3339 bounds on occurred_at; since is inclusive, until exclusive.
+	Since string `protobuf:"bytes,4,opt,name=since,proto3" json:"since,omitempty"`
+	Until string `protobuf:"bytes,5,opt,name=until,proto3" json:"until,omitempty"`
+	// Defaults to 100, at most 1000.
+	PageSize int32 `protobuf:"varint,6,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// next_page_token of the previous page.
+	PageToken     string `protobuf:"bytes,7,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAuditEventsRequest) Reset() {
+	*x = ListAuditEventsRequest{}
+	mi := &file_proto_jennah_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAuditEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAuditEventsRequest) ProtoMessage() {}
+
+func (x *ListAuditEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_jennah_proto_msgTypes[59]
 	if x != nil {
-		return x.EnvVarsJson
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAuditEventsRequest.ProtoReflect.Descriptor instead.
+func (*ListAuditEventsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_jennah_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *ListAuditEventsRequest) GetProcedure() string {
+	if x != nil {
+		return x.Procedure
 	}
 	return ""
 }
 
-func (x *Job) GetGcpBatchTaskGroup() string {
+func (x *ListAuditEventsRequest) GetActorId() string {
 	if x != nil {
-		return x.GcpBatchTaskGroup
+		return x.ActorId
 	}
 	return ""
 }
 
-func (x *Job) GetName() string {
+func (x *ListAuditEventsRequest) GetTargetId() string {
 	if x != nil {
-		return x.Name
+		return x.TargetId
+	}
+	return ""
+}
+
+func (x *ListAuditEventsRequest) GetSince() string {
+	if x != nil {
+		return x.Since
+	}
+	return ""
+}
+
+func (x *ListAuditEventsRequest) GetUntil() string {
+	if x != nil {
+		return x.Until
+	}
+	return ""
+}
+
+func (x *ListAuditEventsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListAuditEventsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type ListAuditEventsResponse struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Events []*AuditEvent          `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
+	// Empty on the last page.
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAuditEventsResponse) Reset() {
+	*x = ListAuditEventsResponse{}
+	mi := &file_proto_jennah_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAuditEventsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAuditEventsResponse) ProtoMessage() {}
+
+func (x *ListAuditEventsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_jennah_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAuditEventsResponse.ProtoReflect.Descriptor instead.
+func (*ListAuditEventsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_jennah_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *ListAuditEventsResponse) GetEvents() []*AuditEvent {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+func (x *ListAuditEventsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
 	}
 	return ""
 }
 
-func (x *Job) GetResourceProfile() string {
+type ReconcileResourcesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Report the actions that would be taken without taking them.
+	DryRun       bool         `protobuf:"varint,1,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	OrphanAction OrphanAction `protobuf:"varint,2,opt,name=orphan_action,json=orphanAction,proto3,enum=jennah.v1.OrphanAction" json:"orphan_action,omitempty"`
+	// Mark active jobs whose cloud resource no longer exists as FAILED.
+	FailMissing bool `protobuf:"varint,3,opt,name=fail_missing,json=failMissing,proto3" json:"fail_missing,omitempty"`
+	// Ignore resources and jobs changed more recently than this, so in-flight
+	// submissions are not mistaken for drift. Defaults to 600.
+	GracePeriodSeconds int64 `protobuf:"varint,4,opt,name=grace_period_seconds,json=gracePeriodSeconds,proto3" json:"grace_period_seconds,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *ReconcileResourcesRequest) Reset() {
+	*x = ReconcileResourcesRequest{}
+	mi := &file_proto_jennah_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReconcileResourcesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReconcileResourcesRequest) ProtoMessage() {}
+
+func (x *ReconcileResourcesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_jennah_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReconcileResourcesRequest.ProtoReflect.Descriptor instead.
+func (*ReconcileResourcesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_jennah_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *ReconcileResourcesRequest) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+func (x *ReconcileResourcesRequest) GetOrphanAction() OrphanAction {
 	if x != nil {
-		return x.ResourceProfile
+		return x.OrphanAction
 	}
-	return ""
+	return OrphanAction_ORPHAN_ACTION_UNSPECIFIED
 }
 
-func (x *Job) GetMachineType() string {
+func (x *ReconcileResourcesRequest) GetFailMissing() bool {
 	if x != nil {
-		return x.MachineType
+		return x.FailMissing
 	}
-	return ""
+	return false
 }
 
-func (x *Job) GetBootDiskSizeGb() int64 {
+func (x *ReconcileResourcesRequest) GetGracePeriodSeconds() int64 {
 	if x != nil {
-		return x.BootDiskSizeGb
+		return x.GracePeriodSeconds
 	}
 	return 0
 }
 
-func (x *Job) GetUseSpotVms() bool {
+// OrphanedResource is a Jennah-created cloud resource no job points at.
+type OrphanedResource struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	CloudResourcePath string                 `protobuf:"bytes,1,opt,name=cloud_resource_path,json=cloudResourcePath,proto3" json:"cloud_resource_path,omitempty"`
+	AssignedService   string                 `protobuf:"bytes,2,opt,name=assigned_service,json=assignedService,proto3" json:"assigned_service,omitempty"`
+	// Provider status, e.g. RUNNING or COMPLETED.
+	Status string `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	// RFC 3339; empty when the provider does not report it.
+	CreateTime string `protobuf:"bytes,4,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`
+	// What was done (or, in a dry run, would be done): "none", "cancel",
+	// "delete", or "skip: <reason>".
+	Action string `protobuf:"bytes,5,opt,name=action,proto3" json:"action,omitempty"`
+	// Set when the action failed.
+	Error         string `protobuf:"bytes,6,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrphanedResource) Reset() {
+	*x = OrphanedResource{}
+	mi := &file_proto_jennah_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrphanedResource) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrphanedResource) ProtoMessage() {}
+
+func (x *OrphanedResource) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_jennah_proto_msgTypes[62]
 	if x != nil {
-		return x.UseSpotVms
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return false
+	return mi.MessageOf(x)
 }
 
-func (x *Job) GetServiceAccount() string {
+// Deprecated: Use OrphanedResource.ProtoReflect.Descriptor instead.
+func (*OrphanedResource) Descriptor() ([]byte, []int) {
+	return file_proto_jennah_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *OrphanedResource) GetCloudResourcePath() string {
 	if x != nil {
-		return x.ServiceAccount
+		return x.CloudResourcePath
 	}
 	return ""
 }
 
-func (x *Job) GetComplexityLevel() string {
+func (x *OrphanedResource) GetAssignedService() string {
 	if x != nil {
-		return x.ComplexityLevel
+		return x.AssignedService
 	}
 	return ""
 }
 
-func (x *Job) GetAssignedService() string {
+func (x *OrphanedResource) GetStatus() string {
 	if x != nil {
-		return x.AssignedService
+		return x.Status
 	}
 	return ""
 }
 
-func (x *Job) GetMemoryMib() int64 {
+func (x *OrphanedResource) GetCreateTime() string {
 	if x != nil {
-		return x.MemoryMib
+		return x.CreateTime
 	}
-	return 0
+	return ""
 }
 
-func (x *Job) GetCpuMillis() int64 {
+func (x *OrphanedResource) GetAction() string {
 	if x != nil {
-		return x.CpuMillis
+		return x.Action
 	}
-	return 0
+	return ""
 }
 
-func (x *Job) GetMaxRunDurationSeconds() int64 {
+func (x *OrphanedResource) GetError() string {
 	if x != nil {
-		return x.MaxRunDurationSeconds
+		return x.Error
 	}
-	return 0
+	return ""
 }
 
-type GetCurrentTenantRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
+// MissingResource is an active job whose cloud resource no longer exists.
+type MissingResource struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	TenantId          string                 `protobuf:"bytes,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	JobId             string                 `protobuf:"bytes,2,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	CloudResourcePath string                 `protobuf:"bytes,3,opt,name=cloud_resource_path,json=cloudResourcePath,proto3" json:"cloud_resource_path,omitempty"`
+	Status            string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	AssignedService   string                 `protobuf:"bytes,5,opt,name=assigned_service,json=assignedService,proto3" json:"assigned_service,omitempty"`
+	// "none" or "fail".
+	Action        string `protobuf:"bytes,6,opt,name=action,proto3" json:"action,omitempty"`
+	Error         string `protobuf:"bytes,7,opt,name=error,proto3" json:"error,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetCurrentTenantRequest) Reset() {
-	*x = GetCurrentTenantRequest{}
-	mi := &file_proto_jennah_proto_msgTypes[6]
+func (x *MissingResource) Reset() {
+	*x = MissingResource{}
+	mi := &file_proto_jennah_proto_msgTypes[63]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetCurrentTenantRequest) String() string {
+func (x *MissingResource) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetCurrentTenantRequest) ProtoMessage() {}
+func (*MissingResource) ProtoMessage() {}
 
-func (x *GetCurrentTenantRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_jennah_proto_msgTypes[6]
+func (x *MissingResource) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_jennah_proto_msgTypes[63]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -786,101 +4318,88 @@ func (x *GetCurrentTenantRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetCurrentTenantRequest.ProtoReflect.Descriptor instead.
-func (*GetCurrentTenantRequest) Descriptor() ([]byte, []int) {
-	return file_proto_jennah_proto_rawDescGZIP(), []int{6}
-}
-
-type GetCurrentTenantResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	TenantId      string                 `protobuf:"bytes,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
-	UserEmail     string                 `protobuf:"bytes,2,opt,name=user_email,json=userEmail,proto3" json:"user_email,omitempty"`
-	OauthProvider string                 `protobuf:"bytes,3,opt,name=oauth_provider,json=oauthProvider,proto3" json:"oauth_provider,omitempty"` // "google", "github"
-	CreatedAt     string                 `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *GetCurrentTenantResponse) Reset() {
-	*x = GetCurrentTenantResponse{}
-	mi := &file_proto_jennah_proto_msgTypes[7]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+// Deprecated: Use MissingResource.ProtoReflect.Descriptor instead.
+func (*MissingResource) Descriptor() ([]byte, []int) {
+	return file_proto_jennah_proto_rawDescGZIP(), []int{63}
 }
 
-func (x *GetCurrentTenantResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *MissingResource) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
 }
 
-func (*GetCurrentTenantResponse) ProtoMessage() {}
-
-func (x *GetCurrentTenantResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_jennah_proto_msgTypes[7]
+func (x *MissingResource) GetJobId() string {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.JobId
 	}
-	return mi.MessageOf(x)
+	return ""
 }
 
-// Deprecated: Use GetCurrentTenantResponse.ProtoReflect.Descriptor instead.
-func (*GetCurrentTenantResponse) Descriptor() ([]byte, []int) {
-	return file_proto_jennah_proto_rawDescGZIP(), []int{7}
+func (x *MissingResource) GetCloudResourcePath() string {
+	if x != nil {
+		return x.CloudResourcePath
+	}
+	return ""
 }
 
-func (x *GetCurrentTenantResponse) GetTenantId() string {
+func (x *MissingResource) GetStatus() string {
 	if x != nil {
-		return x.TenantId
+		return x.Status
 	}
 	return ""
 }
 
-func (x *GetCurrentTenantResponse) GetUserEmail() string {
+func (x *MissingResource) GetAssignedService() string {
 	if x != nil {
-		return x.UserEmail
+		return x.AssignedService
 	}
 	return ""
 }
 
-func (x *GetCurrentTenantResponse) GetOauthProvider() string {
+func (x *MissingResource) GetAction() string {
 	if x != nil {
-		return x.OauthProvider
+		return x.Action
 	}
 	return ""
 }
 
-func (x *GetCurrentTenantResponse) GetCreatedAt() string {
+func (x *MissingResource) GetError() string {
 	if x != nil {
-		return x.CreatedAt
+		return x.Error
 	}
 	return ""
 }
 
-type CancelJobRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	JobId         string                 `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+type ReconcileResourcesResponse struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Orphans          []*OrphanedResource    `protobuf:"bytes,1,rep,name=orphans,proto3" json:"orphans,omitempty"`
+	Missing          []*MissingResource     `protobuf:"bytes,2,rep,name=missing,proto3" json:"missing,omitempty"`
+	ResourcesScanned int32                  `protobuf:"varint,3,opt,name=resources_scanned,json=resourcesScanned,proto3" json:"resources_scanned,omitempty"`
+	JobsScanned      int32                  `protobuf:"varint,4,opt,name=jobs_scanned,json=jobsScanned,proto3" json:"jobs_scanned,omitempty"`
+	// Services whose resources could not be listed; they were skipped.
+	Errors        []string `protobuf:"bytes,5,rep,name=errors,proto3" json:"errors,omitempty"`
+	DryRun        bool     `protobuf:"varint,6,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CancelJobRequest) Reset() {
-	*x = CancelJobRequest{}
-	mi := &file_proto_jennah_proto_msgTypes[8]
+func (x *ReconcileResourcesResponse) Reset() {
+	*x = ReconcileResourcesResponse{}
+	mi := &file_proto_jennah_proto_msgTypes[64]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CancelJobRequest) String() string {
+func (x *ReconcileResourcesResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CancelJobRequest) ProtoMessage() {}
+func (*ReconcileResourcesResponse) ProtoMessage() {}
 
-func (x *CancelJobRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_jennah_proto_msgTypes[8]
+func (x *ReconcileResourcesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_jennah_proto_msgTypes[64]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -891,92 +4410,83 @@ func (x *CancelJobRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CancelJobRequest.ProtoReflect.Descriptor instead.
-func (*CancelJobRequest) Descriptor() ([]byte, []int) {
-	return file_proto_jennah_proto_rawDescGZIP(), []int{8}
+// Deprecated: Use ReconcileResourcesResponse.ProtoReflect.Descriptor instead.
+func (*ReconcileResourcesResponse) Descriptor() ([]byte, []int) {
+	return file_proto_jennah_proto_rawDescGZIP(), []int{64}
 }
 
-func (x *CancelJobRequest) GetJobId() string {
+func (x *ReconcileResourcesResponse) GetOrphans() []*OrphanedResource {
 	if x != nil {
-		return x.JobId
+		return x.Orphans
 	}
-	return ""
-}
-
-type CancelJobResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	JobId         string                 `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
-	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *CancelJobResponse) Reset() {
-	*x = CancelJobResponse{}
-	mi := &file_proto_jennah_proto_msgTypes[9]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+	return nil
 }
 
-func (x *CancelJobResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *ReconcileResourcesResponse) GetMissing() []*MissingResource {
+	if x != nil {
+		return x.Missing
+	}
+	return nil
 }
 
-func (*CancelJobResponse) ProtoMessage() {}
-
-func (x *CancelJobResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_jennah_proto_msgTypes[9]
+func (x *ReconcileResourcesResponse) GetResourcesScanned() int32 {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.ResourcesScanned
 	}
-	return mi.MessageOf(x)
+	return 0
 }
 
-// Deprecated: Use CancelJobResponse.ProtoReflect.Descriptor instead.
-func (*CancelJobResponse) Descriptor() ([]byte, []int) {
-	return file_proto_jennah_proto_rawDescGZIP(), []int{9}
+func (x *ReconcileResourcesResponse) GetJobsScanned() int32 {
+	if x != nil {
+		return x.JobsScanned
+	}
+	return 0
 }
 
-func (x *CancelJobResponse) GetJobId() string {
+func (x *ReconcileResourcesResponse) GetErrors() []string {
 	if x != nil {
-		return x.JobId
+		return x.Errors
 	}
-	return ""
+	return nil
 }
 
-func (x *CancelJobResponse) GetStatus() string {
+func (x *ReconcileResourcesResponse) GetDryRun() bool {
 	if x != nil {
-		return x.Status
+		return x.DryRun
 	}
-	return ""
+	return false
 }
 
-type DeleteJobRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	JobId         string                 `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+type SimulateRoutingRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Candidate routing rules in the routing-rules.yaml format (YAML or JSON).
+	// Empty replays the worker's current rules.
+	Rules string `protobuf:"bytes,1,opt,name=rules,proto3" json:"rules,omitempty"`
+	// Replay jobs created within this many seconds. Defaults to 30 days.
+	SinceSeconds int64 `protobuf:"varint,2,opt,name=since_seconds,json=sinceSeconds,proto3" json:"since_seconds,omitempty"`
+	// Sample jobs listed per change. Defaults to 5.
+	SamplesPerChange int32 `protobuf:"varint,3,opt,name=samples_per_change,json=samplesPerChange,proto3" json:"samples_per_change,omitempty"`
+	// Most recent jobs replayed at most. Defaults to 10000.
+	MaxJobs       int32 `protobuf:"varint,4,opt,name=max_jobs,json=maxJobs,proto3" json:"max_jobs,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteJobRequest) Reset() {
-	*x = DeleteJobRequest{}
-	mi := &file_proto_jennah_proto_msgTypes[10]
+func (x *SimulateRoutingRequest) Reset() {
+	*x = SimulateRoutingRequest{}
+	mi := &file_proto_jennah_proto_msgTypes[65]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteJobRequest) String() string {
+func (x *SimulateRoutingRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteJobRequest) ProtoMessage() {}
+func (*SimulateRoutingRequest) ProtoMessage() {}
 
-func (x *DeleteJobRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_jennah_proto_msgTypes[10]
+func (x *SimulateRoutingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_jennah_proto_msgTypes[65]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -987,41 +4497,69 @@ func (x *DeleteJobRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteJobRequest.ProtoReflect.Descriptor instead.
-func (*DeleteJobRequest) Descriptor() ([]byte, []int) {
-	return file_proto_jennah_proto_rawDescGZIP(), []int{10}
+// Deprecated: Use SimulateRoutingRequest.ProtoReflect.Descriptor instead.
+func (*SimulateRoutingRequest) Descriptor() ([]byte, []int) {
+	return file_proto_jennah_proto_rawDescGZIP(), []int{65}
 }
 
-func (x *DeleteJobRequest) GetJobId() string {
+func (x *SimulateRoutingRequest) GetRules() string {
 	if x != nil {
-		return x.JobId
+		return x.Rules
 	}
 	return ""
 }
 
-type DeleteJobResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	JobId         string                 `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
-	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *SimulateRoutingRequest) GetSinceSeconds() int64 {
+	if x != nil {
+		return x.SinceSeconds
+	}
+	return 0
 }
 
-func (x *DeleteJobResponse) Reset() {
-	*x = DeleteJobResponse{}
-	mi := &file_proto_jennah_proto_msgTypes[11]
+func (x *SimulateRoutingRequest) GetSamplesPerChange() int32 {
+	if x != nil {
+		return x.SamplesPerChange
+	}
+	return 0
+}
+
+func (x *SimulateRoutingRequest) GetMaxJobs() int32 {
+	if x != nil {
+		return x.MaxJobs
+	}
+	return 0
+}
+
+// RoutingSample is one job whose service would change.
+type RoutingSample struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	TenantId  string                 `protobuf:"bytes,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	JobId     string                 `protobuf:"bytes,2,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	Name      string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	CreatedAt string                 `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// Why the job was routed where it ran.
+	CurrentReason string `protobuf:"bytes,5,opt,name=current_reason,json=currentReason,proto3" json:"current_reason,omitempty"`
+	// Why the candidate rules route it elsewhere.
+	SimulatedReason string `protobuf:"bytes,6,opt,name=simulated_reason,json=simulatedReason,proto3" json:"simulated_reason,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *RoutingSample) Reset() {
+	*x = RoutingSample{}
+	mi := &file_proto_jennah_proto_msgTypes[66]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteJobResponse) String() string {
+func (x *RoutingSample) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteJobResponse) ProtoMessage() {}
+func (*RoutingSample) ProtoMessage() {}
 
-func (x *DeleteJobResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_jennah_proto_msgTypes[11]
+func (x *RoutingSample) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_jennah_proto_msgTypes[66]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1032,47 +4570,88 @@ func (x *DeleteJobResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteJobResponse.ProtoReflect.Descriptor instead.
-func (*DeleteJobResponse) Descriptor() ([]byte, []int) {
-	return file_proto_jennah_proto_rawDescGZIP(), []int{11}
+// Deprecated: Use RoutingSample.ProtoReflect.Descriptor instead.
+func (*RoutingSample) Descriptor() ([]byte, []int) {
+	return file_proto_jennah_proto_rawDescGZIP(), []int{66}
 }
 
-func (x *DeleteJobResponse) GetJobId() string {
+func (x *RoutingSample) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *RoutingSample) GetJobId() string {
 	if x != nil {
 		return x.JobId
 	}
 	return ""
 }
 
-func (x *DeleteJobResponse) GetMessage() string {
+func (x *RoutingSample) GetName() string {
 	if x != nil {
-		return x.Message
+		return x.Name
 	}
 	return ""
 }
 
-type GetJobRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	JobId         string                 `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *RoutingSample) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
 }
 
-func (x *GetJobRequest) Reset() {
-	*x = GetJobRequest{}
-	mi := &file_proto_jennah_proto_msgTypes[12]
+func (x *RoutingSample) GetCurrentReason() string {
+	if x != nil {
+		return x.CurrentReason
+	}
+	return ""
+}
+
+func (x *RoutingSample) GetSimulatedReason() string {
+	if x != nil {
+		return x.SimulatedReason
+	}
+	return ""
+}
+
+// RoutingChange groups the jobs that move from one service to another.
+type RoutingChange struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	FromService string                 `protobuf:"bytes,1,opt,name=from_service,json=fromService,proto3" json:"from_service,omitempty"`
+	// UNSPECIFIED when no registered provider could run the job.
+	ToService string `protobuf:"bytes,2,opt,name=to_service,json=toService,proto3" json:"to_service,omitempty"`
+	Jobs      int32  `protobuf:"varint,3,opt,name=jobs,proto3" json:"jobs,omitempty"`
+	// Jobs that finished, so their runtime was observed.
+	JobsWithRuntime        int32 `protobuf:"varint,4,opt,name=jobs_with_runtime,json=jobsWithRuntime,proto3" json:"jobs_with_runtime,omitempty"`
+	ObservedRuntimeSeconds int64 `protobuf:"varint,5,opt,name=observed_runtime_seconds,json=observedRuntimeSeconds,proto3" json:"observed_runtime_seconds,omitempty"`
+	// Estimated cost of the observed runtime on each service, in USD at list prices.
+	CurrentCostUsd   float64 `protobuf:"fixed64,6,opt,name=current_cost_usd,json=currentCostUsd,proto3" json:"current_cost_usd,omitempty"`
+	SimulatedCostUsd float64 `protobuf:"fixed64,7,opt,name=simulated_cost_usd,json=simulatedCostUsd,proto3" json:"simulated_cost_usd,omitempty"`
+	// Estimated change in total start-up latency, in seconds.
+	StartupDeltaSeconds int64            `protobuf:"varint,8,opt,name=startup_delta_seconds,json=startupDeltaSeconds,proto3" json:"startup_delta_seconds,omitempty"`
+	Samples             []*RoutingSample `protobuf:"bytes,9,rep,name=samples,proto3" json:"samples,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *RoutingChange) Reset() {
+	*x = RoutingChange{}
+	mi := &file_proto_jennah_proto_msgTypes[67]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetJobRequest) String() string {
+func (x *RoutingChange) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetJobRequest) ProtoMessage() {}
+func (*RoutingChange) ProtoMessage() {}
 
-func (x *GetJobRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_jennah_proto_msgTypes[12]
+func (x *RoutingChange) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_jennah_proto_msgTypes[67]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1083,96 +4662,107 @@ func (x *GetJobRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetJobRequest.ProtoReflect.Descriptor instead.
-func (*GetJobRequest) Descriptor() ([]byte, []int) {
-	return file_proto_jennah_proto_rawDescGZIP(), []int{12}
+// Deprecated: Use RoutingChange.ProtoReflect.Descriptor instead.
+func (*RoutingChange) Descriptor() ([]byte, []int) {
+	return file_proto_jennah_proto_rawDescGZIP(), []int{67}
 }
 
-func (x *GetJobRequest) GetJobId() string {
+func (x *RoutingChange) GetFromService() string {
 	if x != nil {
-		return x.JobId
+		return x.FromService
 	}
 	return ""
 }
 
-type GetJobResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Job           *Job                   `protobuf:"bytes,1,opt,name=job,proto3" json:"job,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *RoutingChange) GetToService() string {
+	if x != nil {
+		return x.ToService
+	}
+	return ""
 }
 
-func (x *GetJobResponse) Reset() {
-	*x = GetJobResponse{}
-	mi := &file_proto_jennah_proto_msgTypes[13]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *RoutingChange) GetJobs() int32 {
+	if x != nil {
+		return x.Jobs
+	}
+	return 0
 }
 
-func (x *GetJobResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *RoutingChange) GetJobsWithRuntime() int32 {
+	if x != nil {
+		return x.JobsWithRuntime
+	}
+	return 0
 }
 
-func (*GetJobResponse) ProtoMessage() {}
+func (x *RoutingChange) GetObservedRuntimeSeconds() int64 {
+	if x != nil {
+		return x.ObservedRuntimeSeconds
+	}
+	return 0
+}
 
-func (x *GetJobResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_jennah_proto_msgTypes[13]
+func (x *RoutingChange) GetCurrentCostUsd() float64 {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.CurrentCostUsd
 	}
-	return mi.MessageOf(x)
+	return 0
 }
 
-// Deprecated: Use GetJobResponse.ProtoReflect.Descriptor instead.
-func (*GetJobResponse) Descriptor() ([]byte, []int) {
-	return file_proto_jennah_proto_rawDescGZIP(), []int{13}
+func (x *RoutingChange) GetSimulatedCostUsd() float64 {
+	if x != nil {
+		return x.SimulatedCostUsd
+	}
+	return 0
 }
 
-func (x *GetJobResponse) GetJob() *Job {
+func (x *RoutingChange) GetStartupDeltaSeconds() int64 {
 	if x != nil {
-		return x.Job
+		return x.StartupDeltaSeconds
+	}
+	return 0
+}
+
+func (x *RoutingChange) GetSamples() []*RoutingSample {
+	if x != nil {
+		return x.Samples
 	}
 	return nil
 }
 
-// A single in-app notification produced from a job.terminal Pub/Sub event.
-type Notification struct {
-	state protoimpl.MessageState `protogen:"open.v1"`
-	// Stable event ID from the Pub/Sub payload — used for deduplication.
-	Id      string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	JobId   string `protobuf:"bytes,2,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
-	JobName string `protobuf:"bytes,3,opt,name=job_name,json=jobName,proto3" json:"job_name,omitempty"`
-	// final_status: COMPLETED | FAILED | CANCELLED
-	FinalStatus     string `protobuf:"bytes,4,opt,name=final_status,json=finalStatus,proto3" json:"final_status,omitempty"`
-	ServiceTier     string `protobuf:"bytes,5,opt,name=service_tier,json=serviceTier,proto3" json:"service_tier,omitempty"`             // SIMPLE | COMPLEX
-	AssignedService string `protobuf:"bytes,6,opt,name=assigned_service,json=assignedService,proto3" json:"assigned_service,omitempty"` // CLOUD_RUN_JOB | CLOUD_BATCH
-	// Unix timestamp (seconds) of when the terminal event occurred.
-	OccurredAt    int64  `protobuf:"varint,7,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`
-	ErrorMessage  string `protobuf:"bytes,8,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
-	IsRead        bool   `protobuf:"varint,9,opt,name=is_read,json=isRead,proto3" json:"is_read,omitempty"`
+type SimulateRoutingResponse struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	JobsReplayed int32                  `protobuf:"varint,1,opt,name=jobs_replayed,json=jobsReplayed,proto3" json:"jobs_replayed,omitempty"`
+	JobsChanged  int32                  `protobuf:"varint,2,opt,name=jobs_changed,json=jobsChanged,proto3" json:"jobs_changed,omitempty"`
+	// Jobs whose stored spec could not be replayed.
+	JobsSkipped int32            `protobuf:"varint,3,opt,name=jobs_skipped,json=jobsSkipped,proto3" json:"jobs_skipped,omitempty"`
+	Changes     []*RoutingChange `protobuf:"bytes,4,rep,name=changes,proto3" json:"changes,omitempty"`
+	// Totals over all changes.
+	CostDeltaUsd        float64 `protobuf:"fixed64,5,opt,name=cost_delta_usd,json=costDeltaUsd,proto3" json:"cost_delta_usd,omitempty"`
+	StartupDeltaSeconds int64   `protobuf:"varint,6,opt,name=startup_delta_seconds,json=startupDeltaSeconds,proto3" json:"startup_delta_seconds,omitempty"`
+	// Number of candidate rules evaluated.
+	Rules int32 `protobuf:"varint,7,opt,name=rules,proto3" json:"rules,omitempty"`
+	// Start of the replayed window (RFC 3339).
+	Since         string `protobuf:"bytes,8,opt,name=since,proto3" json:"since,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Notification) Reset() {
-	*x = Notification{}
-	mi := &file_proto_jennah_proto_msgTypes[14]
+func (x *SimulateRoutingResponse) Reset() {
+	*x = SimulateRoutingResponse{}
+	mi := &file_proto_jennah_proto_msgTypes[68]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Notification) String() string {
+func (x *SimulateRoutingResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Notification) ProtoMessage() {}
+func (*SimulateRoutingResponse) ProtoMessage() {}
 
-func (x *Notification) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_jennah_proto_msgTypes[14]
+func (x *SimulateRoutingResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_jennah_proto_msgTypes[68]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1183,97 +4773,92 @@ func (x *Notification) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Notification.ProtoReflect.Descriptor instead.
-func (*Notification) Descriptor() ([]byte, []int) {
-	return file_proto_jennah_proto_rawDescGZIP(), []int{14}
+// Deprecated: Use SimulateRoutingResponse.ProtoReflect.Descriptor instead.
+func (*SimulateRoutingResponse) Descriptor() ([]byte, []int) {
+	return file_proto_jennah_proto_rawDescGZIP(), []int{68}
 }
 
-func (x *Notification) GetId() string {
+func (x *SimulateRoutingResponse) GetJobsReplayed() int32 {
 	if x != nil {
-		return x.Id
+		return x.JobsReplayed
 	}
-	return ""
+	return 0
 }
 
-func (x *Notification) GetJobId() string {
+func (x *SimulateRoutingResponse) GetJobsChanged() int32 {
 	if x != nil {
-		return x.JobId
+		return x.JobsChanged
 	}
-	return ""
+	return 0
 }
 
-func (x *Notification) GetJobName() string {
+func (x *SimulateRoutingResponse) GetJobsSkipped() int32 {
 	if x != nil {
-		return x.JobName
+		return x.JobsSkipped
 	}
-	return ""
+	return 0
 }
 
-func (x *Notification) GetFinalStatus() string {
+func (x *SimulateRoutingResponse) GetChanges() []*RoutingChange {
 	if x != nil {
-		return x.FinalStatus
+		return x.Changes
 	}
-	return ""
+	return nil
 }
 
-func (x *Notification) GetServiceTier() string {
+func (x *SimulateRoutingResponse) GetCostDeltaUsd() float64 {
 	if x != nil {
-		return x.ServiceTier
+		return x.CostDeltaUsd
 	}
-	return ""
+	return 0
 }
 
-func (x *Notification) GetAssignedService() string {
+func (x *SimulateRoutingResponse) GetStartupDeltaSeconds() int64 {
 	if x != nil {
-		return x.AssignedService
+		return x.StartupDeltaSeconds
 	}
-	return ""
+	return 0
 }
 
-func (x *Notification) GetOccurredAt() int64 {
+func (x *SimulateRoutingResponse) GetRules() int32 {
 	if x != nil {
-		return x.OccurredAt
+		return x.Rules
 	}
 	return 0
 }
 
-func (x *Notification) GetErrorMessage() string {
+func (x *SimulateRoutingResponse) GetSince() string {
 	if x != nil {
-		return x.ErrorMessage
+		return x.Since
 	}
 	return ""
 }
 
-func (x *Notification) GetIsRead() bool {
-	if x != nil {
-		return x.IsRead
-	}
-	return false
-}
-
-type ListNotificationsRequest struct {
+type CreateSessionRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// Maximum number of notifications to return (default 20).
-	Limit         int32 `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
+	// "google" (token is an OIDC ID token) or "github" (token is an OAuth
+	// access token).
+	Provider      string `protobuf:"bytes,1,opt,name=provider,proto3" json:"provider,omitempty"`
+	Token         string `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListNotificationsRequest) Reset() {
-	*x = ListNotificationsRequest{}
-	mi := &file_proto_jennah_proto_msgTypes[15]
+func (x *CreateSessionRequest) Reset() {
+	*x = CreateSessionRequest{}
+	mi := &file_proto_jennah_proto_msgTypes[69]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListNotificationsRequest) String() string {
+func (x *CreateSessionRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListNotificationsRequest) ProtoMessage() {}
+func (*CreateSessionRequest) ProtoMessage() {}
 
-func (x *ListNotificationsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_jennah_proto_msgTypes[15]
+func (x *CreateSessionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_jennah_proto_msgTypes[69]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1284,41 +4869,53 @@ func (x *ListNotificationsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListNotificationsRequest.ProtoReflect.Descriptor instead.
-func (*ListNotificationsRequest) Descriptor() ([]byte, []int) {
-	return file_proto_jennah_proto_rawDescGZIP(), []int{15}
+// Deprecated: Use CreateSessionRequest.ProtoReflect.Descriptor instead.
+func (*CreateSessionRequest) Descriptor() ([]byte, []int) {
+	return file_proto_jennah_proto_rawDescGZIP(), []int{69}
 }
 
-func (x *ListNotificationsRequest) GetLimit() int32 {
+func (x *CreateSessionRequest) GetProvider() string {
 	if x != nil {
-		return x.Limit
+		return x.Provider
 	}
-	return 0
+	return ""
 }
 
-type ListNotificationsResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Notifications []*Notification        `protobuf:"bytes,1,rep,name=notifications,proto3" json:"notifications,omitempty"`
-	UnreadCount   int32                  `protobuf:"varint,2,opt,name=unread_count,json=unreadCount,proto3" json:"unread_count,omitempty"`
+func (x *CreateSessionRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+type CreateSessionResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Sent as "Authorization: Bearer <session_token>".
+	SessionToken string `protobuf:"bytes,1,opt,name=session_token,json=sessionToken,proto3" json:"session_token,omitempty"`
+	// RFC 3339.
+	ExpiresAt     string `protobuf:"bytes,2,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	UserEmail     string `protobuf:"bytes,3,opt,name=user_email,json=userEmail,proto3" json:"user_email,omitempty"`
+	OauthProvider string `protobuf:"bytes,4,opt,name=oauth_provider,json=oauthProvider,proto3" json:"oauth_provider,omitempty"`
+	OauthUserId   string `protobuf:"bytes,5,opt,name=oauth_user_id,json=oauthUserId,proto3" json:"oauth_user_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListNotificationsResponse) Reset() {
-	*x = ListNotificationsResponse{}
-	mi := &file_proto_jennah_proto_msgTypes[16]
+func (x *CreateSessionResponse) Reset() {
+	*x = CreateSessionResponse{}
+	mi := &file_proto_jennah_proto_msgTypes[70]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListNotificationsResponse) String() string {
+func (x *CreateSessionResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListNotificationsResponse) ProtoMessage() {}
+func (*CreateSessionResponse) ProtoMessage() {}
 
-func (x *ListNotificationsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_jennah_proto_msgTypes[16]
+func (x *CreateSessionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_jennah_proto_msgTypes[70]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1329,47 +4926,68 @@ func (x *ListNotificationsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListNotificationsResponse.ProtoReflect.Descriptor instead.
-func (*ListNotificationsResponse) Descriptor() ([]byte, []int) {
-	return file_proto_jennah_proto_rawDescGZIP(), []int{16}
+// Deprecated: Use CreateSessionResponse.ProtoReflect.Descriptor instead.
+func (*CreateSessionResponse) Descriptor() ([]byte, []int) {
+	return file_proto_jennah_proto_rawDescGZIP(), []int{70}
 }
 
-func (x *ListNotificationsResponse) GetNotifications() []*Notification {
+func (x *CreateSessionResponse) GetSessionToken() string {
 	if x != nil {
-		return x.Notifications
+		return x.SessionToken
 	}
-	return nil
+	return ""
 }
 
-func (x *ListNotificationsResponse) GetUnreadCount() int32 {
+func (x *CreateSessionResponse) GetExpiresAt() string {
 	if x != nil {
-		return x.UnreadCount
+		return x.ExpiresAt
 	}
-	return 0
+	return ""
 }
 
-type AckNotificationRequest struct {
-	state          protoimpl.MessageState `protogen:"open.v1"`
-	NotificationId string                 `protobuf:"bytes,1,opt,name=notification_id,json=notificationId,proto3" json:"notification_id,omitempty"`
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+func (x *CreateSessionResponse) GetUserEmail() string {
+	if x != nil {
+		return x.UserEmail
+	}
+	return ""
 }
 
-func (x *AckNotificationRequest) Reset() {
-	*x = AckNotificationRequest{}
-	mi := &file_proto_jennah_proto_msgTypes[17]
+func (x *CreateSessionResponse) GetOauthProvider() string {
+	if x != nil {
+		return x.OauthProvider
+	}
+	return ""
+}
+
+func (x *CreateSessionResponse) GetOauthUserId() string {
+	if x != nil {
+		return x.OauthUserId
+	}
+	return ""
+}
+
+type RefreshSessionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SessionToken  string                 `protobuf:"bytes,1,opt,name=session_token,json=sessionToken,proto3" json:"session_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RefreshSessionRequest) Reset() {
+	*x = RefreshSessionRequest{}
+	mi := &file_proto_jennah_proto_msgTypes[71]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *AckNotificationRequest) String() string {
+func (x *RefreshSessionRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AckNotificationRequest) ProtoMessage() {}
+func (*RefreshSessionRequest) ProtoMessage() {}
 
-func (x *AckNotificationRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_jennah_proto_msgTypes[17]
+func (x *RefreshSessionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_jennah_proto_msgTypes[71]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1380,40 +4998,42 @@ func (x *AckNotificationRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AckNotificationRequest.ProtoReflect.Descriptor instead.
-func (*AckNotificationRequest) Descriptor() ([]byte, []int) {
-	return file_proto_jennah_proto_rawDescGZIP(), []int{17}
+// Deprecated: Use RefreshSessionRequest.ProtoReflect.Descriptor instead.
+func (*RefreshSessionRequest) Descriptor() ([]byte, []int) {
+	return file_proto_jennah_proto_rawDescGZIP(), []int{71}
 }
 
-func (x *AckNotificationRequest) GetNotificationId() string {
+func (x *RefreshSessionRequest) GetSessionToken() string {
 	if x != nil {
-		return x.NotificationId
+		return x.SessionToken
 	}
 	return ""
 }
 
-type AckNotificationResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+type RefreshSessionResponse struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	SessionToken string                 `protobuf:"bytes,1,opt,name=session_token,json=sessionToken,proto3" json:"session_token,omitempty"`
+	// RFC 3339.
+	ExpiresAt     string `protobuf:"bytes,2,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *AckNotificationResponse) Reset() {
-	*x = AckNotificationResponse{}
-	mi := &file_proto_jennah_proto_msgTypes[18]
+func (x *RefreshSessionResponse) Reset() {
+	*x = RefreshSessionResponse{}
+	mi := &file_proto_jennah_proto_msgTypes[72]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *AckNotificationResponse) String() string {
+func (x *RefreshSessionResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AckNotificationResponse) ProtoMessage() {}
+func (*RefreshSessionResponse) ProtoMessage() {}
 
-func (x *AckNotificationResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_jennah_proto_msgTypes[18]
+func (x *RefreshSessionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_jennah_proto_msgTypes[72]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1424,16 +5044,23 @@ func (x *AckNotificationResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AckNotificationResponse.ProtoReflect.Descriptor instead.
-func (*AckNotificationResponse) Descriptor() ([]byte, []int) {
-	return file_proto_jennah_proto_rawDescGZIP(), []int{18}
+// Deprecated: Use RefreshSessionResponse.ProtoReflect.Descriptor instead.
+func (*RefreshSessionResponse) Descriptor() ([]byte, []int) {
+	return file_proto_jennah_proto_rawDescGZIP(), []int{72}
 }
 
-func (x *AckNotificationResponse) GetSuccess() bool {
+func (x *RefreshSessionResponse) GetSessionToken() string {
 	if x != nil {
-		return x.Success
+		return x.SessionToken
 	}
-	return false
+	return ""
+}
+
+func (x *RefreshSessionResponse) GetExpiresAt() string {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return ""
 }
 
 var File_proto_jennah_proto protoreflect.FileDescriptor
@@ -1446,7 +5073,44 @@ const file_proto_jennah_proto_rawDesc = "" +
 	"cpu_millis\x18\x01 \x01(\x03R\tcpuMillis\x12\x1d\n" +
 	"\n" +
 	"memory_mib\x18\x02 \x01(\x03R\tmemoryMib\x127\n" +
-	"\x18max_run_duration_seconds\x18\x03 \x01(\x03R\x15maxRunDurationSeconds\"\x85\x04\n" +
+	"\x18max_run_duration_seconds\x18\x03 \x01(\x03R\x15maxRunDurationSeconds\"^\n" +
+	"\vAccelerator\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x03R\x05count\x12%\n" +
+	"\x0edriver_version\x18\x03 \x01(\tR\rdriverVersion\"\xcc\x01\n" +
+	"\x06Volume\x12\x1d\n" +
+	"\n" +
+	"mount_path\x18\x01 \x01(\tR\tmountPath\x12\x1b\n" +
+	"\tread_only\x18\x02 \x01(\bR\breadOnly\x12&\n" +
+	"\x03gcs\x18\x03 \x01(\v2\x14.jennah.v1.GcsVolumeR\x03gcs\x12&\n" +
+	"\x03nfs\x18\x04 \x01(\v2\x14.jennah.v1.NfsVolumeR\x03nfs\x126\n" +
+	"\tlocal_ssd\x18\x05 \x01(\v2\x19.jennah.v1.LocalSsdVolumeR\blocalSsd\"#\n" +
+	"\tGcsVolume\x12\x16\n" +
+	"\x06bucket\x18\x01 \x01(\tR\x06bucket\"7\n" +
+	"\tNfsVolume\x12\x16\n" +
+	"\x06server\x18\x01 \x01(\tR\x06server\x12\x12\n" +
+	"\x04path\x18\x02 \x01(\tR\x04path\")\n" +
+	"\x0eLocalSsdVolume\x12\x17\n" +
+	"\asize_gb\x18\x01 \x01(\x03R\x06sizeGb\"\xef\x02\n" +
+	"\bRunnable\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1b\n" +
+	"\timage_uri\x18\x02 \x01(\tR\bimageUri\x12\x1a\n" +
+	"\bcommands\x18\x03 \x03(\tR\bcommands\x12\x16\n" +
+	"\x06script\x18\x04 \x01(\tR\x06script\x12\x18\n" +
+	"\abarrier\x18\x05 \x01(\bR\abarrier\x12;\n" +
+	"\benv_vars\x18\x06 \x03(\v2 .jennah.v1.Runnable.EnvVarsEntryR\aenvVars\x12\x1e\n" +
+	"\n" +
+	"background\x18\a \x01(\bR\n" +
+	"background\x12,\n" +
+	"\x12ignore_exit_status\x18\b \x01(\bR\x10ignoreExitStatus\x12\x1d\n" +
+	"\n" +
+	"always_run\x18\t \x01(\bR\talwaysRun\x1a:\n" +
+	"\fEnvVarsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"^\n" +
+	"\x10PreemptionPolicy\x12#\n" +
+	"\rmax_resubmits\x18\x01 \x01(\x05R\fmaxResubmits\x12%\n" +
+	"\x0estandard_after\x18\x02 \x01(\x05R\rstandardAfter\"\xe5\x06\n" +
 	"\x10SubmitJobRequest\x12\x15\n" +
 	"\x06job_id\x18\x01 \x01(\tR\x05jobId\x12\x1b\n" +
 	"\timage_uri\x18\x02 \x01(\tR\bimageUri\x12C\n" +
@@ -1460,9 +5124,17 @@ const file_proto_jennah_proto_rawDesc = "" +
 	"useSpotVms\x12'\n" +
 	"\x0fservice_account\x18\n" +
 	" \x01(\tR\x0eserviceAccount\x12\x1a\n" +
-	"\bcommands\x18\v \x03(\tR\bcommands\x1a:\n" +
+	"\bcommands\x18\v \x03(\tR\bcommands\x12H\n" +
+	"\x11preemption_policy\x18\f \x01(\v2\x1b.jennah.v1.PreemptionPolicyR\x10preemptionPolicy\x128\n" +
+	"\vaccelerator\x18\r \x01(\v2\x16.jennah.v1.AcceleratorR\vaccelerator\x12+\n" +
+	"\avolumes\x18\x0e \x03(\v2\x11.jennah.v1.VolumeR\avolumes\x121\n" +
+	"\trunnables\x18\x0f \x03(\v2\x13.jennah.v1.RunnableR\trunnables\x12?\n" +
+	"\x06labels\x18\x10 \x03(\v2'.jennah.v1.SubmitJobRequest.LabelsEntryR\x06labels\x1a:\n" +
 	"\fEnvVarsEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
 	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xe8\x01\n" +
 	"\x11SubmitJobResponse\x12\x15\n" +
 	"\x06job_id\x18\x01 \x01(\tR\x05jobId\x12\x16\n" +
@@ -1473,7 +5145,8 @@ const file_proto_jennah_proto_rawDesc = "" +
 	"\x0erouting_reason\x18\x06 \x01(\tR\rroutingReason\"\x11\n" +
 	"\x0fListJobsRequest\"6\n" +
 	"\x10ListJobsResponse\x12\"\n" +
-	"\x04jobs\x18\x01 \x03(\v2\x0e.jennah.v1.JobR\x04jobs\"\xbb\a\n" +
+	"\x04jobs\x18\x01 \x03(\v2\x0e.jennah.v1.JobR\x04jobs\"\xae\n" +
+	"\n" +
 	"\x03Job\x12\x15\n" +
 	"\x06job_id\x18\x01 \x01(\tR\x05jobId\x12\x1b\n" +
 	"\ttenant_id\x18\x02 \x01(\tR\btenantId\x12\x1b\n" +
@@ -1510,15 +5183,30 @@ const file_proto_jennah_proto_rawDesc = "" +
 	"memory_mib\x18\x19 \x01(\x03R\tmemoryMib\x12\x1d\n" +
 	"\n" +
 	"cpu_millis\x18\x1a \x01(\x03R\tcpuMillis\x127\n" +
-	"\x18max_run_duration_seconds\x18\x1b \x01(\x03R\x15maxRunDurationSeconds\"\x19\n" +
-	"\x17GetCurrentTenantRequest\"\x9c\x01\n" +
+	"\x18max_run_duration_seconds\x18\x1b \x01(\x03R\x15maxRunDurationSeconds\x12\x16\n" +
+	"\x06region\x18\x1c \x01(\tR\x06region\x12)\n" +
+	"\x10preemption_count\x18\x1d \x01(\x03R\x0fpreemptionCount\x128\n" +
+	"\vaccelerator\x18\x1e \x01(\v2\x16.jennah.v1.AcceleratorR\vaccelerator\x12+\n" +
+	"\avolumes\x18\x1f \x03(\v2\x11.jennah.v1.VolumeR\avolumes\x121\n" +
+	"\trunnables\x18  \x03(\v2\x13.jennah.v1.RunnableR\trunnables\x122\n" +
+	"\x06labels\x18! \x03(\v2\x1a.jennah.v1.Job.LabelsEntryR\x06labels\x12%\n" +
+	"\x0erouting_reason\x18\" \x01(\tR\rroutingReason\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x19\n" +
+	"\x17GetCurrentTenantRequest\"\x86\x02\n" +
 	"\x18GetCurrentTenantResponse\x12\x1b\n" +
 	"\ttenant_id\x18\x01 \x01(\tR\btenantId\x12\x1d\n" +
 	"\n" +
 	"user_email\x18\x02 \x01(\tR\tuserEmail\x12%\n" +
 	"\x0eoauth_provider\x18\x03 \x01(\tR\roauthProvider\x12\x1d\n" +
 	"\n" +
-	"created_at\x18\x04 \x01(\tR\tcreatedAt\")\n" +
+	"created_at\x18\x04 \x01(\tR\tcreatedAt\x12\x19\n" +
+	"\borg_name\x18\x05 \x01(\tR\aorgName\x12\x12\n" +
+	"\x04role\x18\x06 \x01(\tR\x04role\x129\n" +
+	"\n" +
+	"identities\x18\a \x03(\v2\x19.jennah.v1.LinkedIdentityR\n" +
+	"identities\")\n" +
 	"\x10CancelJobRequest\x12\x15\n" +
 	"\x06job_id\x18\x01 \x01(\tR\x05jobId\"B\n" +
 	"\x11CancelJobResponse\x12\x15\n" +
@@ -1552,7 +5240,234 @@ const file_proto_jennah_proto_rawDesc = "" +
 	"\x16AckNotificationRequest\x12'\n" +
 	"\x0fnotification_id\x18\x01 \x01(\tR\x0enotificationId\"3\n" +
 	"\x17AckNotificationResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess*\x8d\x01\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"\xe9\x01\n" +
+	"\x06ApiKey\x12\x15\n" +
+	"\x06key_id\x18\x01 \x01(\tR\x05keyId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x16\n" +
+	"\x06scopes\x18\x03 \x03(\tR\x06scopes\x12\x1d\n" +
+	"\n" +
+	"created_by\x18\x04 \x01(\tR\tcreatedBy\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\tR\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\x06 \x01(\tR\texpiresAt\x12 \n" +
+	"\flast_used_at\x18\a \x01(\tR\n" +
+	"lastUsedAt\x12\x1d\n" +
+	"\n" +
+	"revoked_at\x18\b \x01(\tR\trevokedAt\"o\n" +
+	"\x13CreateApiKeyRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x16\n" +
+	"\x06scopes\x18\x02 \x03(\tR\x06scopes\x12,\n" +
+	"\x12expires_in_seconds\x18\x03 \x01(\x03R\x10expiresInSeconds\"T\n" +
+	"\x14CreateApiKeyResponse\x12*\n" +
+	"\aapi_key\x18\x01 \x01(\v2\x11.jennah.v1.ApiKeyR\x06apiKey\x12\x10\n" +
+	"\x03key\x18\x02 \x01(\tR\x03key\"\x14\n" +
+	"\x12ListApiKeysRequest\"C\n" +
+	"\x13ListApiKeysResponse\x12,\n" +
+	"\bapi_keys\x18\x01 \x03(\v2\x11.jennah.v1.ApiKeyR\aapiKeys\",\n" +
+	"\x13RevokeApiKeyRequest\x12\x15\n" +
+	"\x06key_id\x18\x01 \x01(\tR\x05keyId\"B\n" +
+	"\x14RevokeApiKeyResponse\x12*\n" +
+	"\aapi_key\x18\x01 \x01(\v2\x11.jennah.v1.ApiKeyR\x06apiKey\"l\n" +
+	"\fOrganization\x12\x15\n" +
+	"\x06org_id\x18\x01 \x01(\tR\x05orgId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x12\n" +
+	"\x04role\x18\x03 \x01(\tR\x04role\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\tR\tcreatedAt\"\x9a\x01\n" +
+	"\x06Member\x12\x14\n" +
+	"\x05email\x18\x01 \x01(\tR\x05email\x12%\n" +
+	"\x0eoauth_provider\x18\x02 \x01(\tR\roauthProvider\x12\"\n" +
+	"\roauth_user_id\x18\x03 \x01(\tR\voauthUserId\x12\x12\n" +
+	"\x04role\x18\x04 \x01(\tR\x04role\x12\x1b\n" +
+	"\tjoined_at\x18\x05 \x01(\tR\bjoinedAt\"\xea\x01\n" +
+	"\n" +
+	"Invitation\x12#\n" +
+	"\rinvitation_id\x18\x01 \x01(\tR\finvitationId\x12\x15\n" +
+	"\x06org_id\x18\x02 \x01(\tR\x05orgId\x12\x19\n" +
+	"\borg_name\x18\x03 \x01(\tR\aorgName\x12\x14\n" +
+	"\x05email\x18\x04 \x01(\tR\x05email\x12\x12\n" +
+	"\x04role\x18\x05 \x01(\tR\x04role\x12\x1d\n" +
+	"\n" +
+	"invited_by\x18\x06 \x01(\tR\tinvitedBy\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\a \x01(\tR\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\b \x01(\tR\texpiresAt\"/\n" +
+	"\x19CreateOrganizationRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"Y\n" +
+	"\x1aCreateOrganizationResponse\x12;\n" +
+	"\forganization\x18\x01 \x01(\v2\x17.jennah.v1.OrganizationR\forganization\"\x1a\n" +
+	"\x18ListOrganizationsRequest\"Z\n" +
+	"\x19ListOrganizationsResponse\x12=\n" +
+	"\rorganizations\x18\x01 \x03(\v2\x17.jennah.v1.OrganizationR\rorganizations\"\x14\n" +
+	"\x12ListMembersRequest\"B\n" +
+	"\x13ListMembersResponse\x12+\n" +
+	"\amembers\x18\x01 \x03(\v2\x11.jennah.v1.MemberR\amembers\"x\n" +
+	"\x17UpdateMemberRoleRequest\x12%\n" +
+	"\x0eoauth_provider\x18\x01 \x01(\tR\roauthProvider\x12\"\n" +
+	"\roauth_user_id\x18\x02 \x01(\tR\voauthUserId\x12\x12\n" +
+	"\x04role\x18\x03 \x01(\tR\x04role\"E\n" +
+	"\x18UpdateMemberRoleResponse\x12)\n" +
+	"\x06member\x18\x01 \x01(\v2\x11.jennah.v1.MemberR\x06member\"`\n" +
+	"\x13RemoveMemberRequest\x12%\n" +
+	"\x0eoauth_provider\x18\x01 \x01(\tR\roauthProvider\x12\"\n" +
+	"\roauth_user_id\x18\x02 \x01(\tR\voauthUserId\"\x16\n" +
+	"\x14RemoveMemberResponse\"?\n" +
+	"\x13InviteMemberRequest\x12\x14\n" +
+	"\x05email\x18\x01 \x01(\tR\x05email\x12\x12\n" +
+	"\x04role\x18\x02 \x01(\tR\x04role\"M\n" +
+	"\x14InviteMemberResponse\x125\n" +
+	"\n" +
+	"invitation\x18\x01 \x01(\v2\x15.jennah.v1.InvitationR\n" +
+	"invitation\"\x18\n" +
+	"\x16ListInvitationsRequest\"R\n" +
+	"\x17ListInvitationsResponse\x127\n" +
+	"\vinvitations\x18\x01 \x03(\v2\x15.jennah.v1.InvitationR\vinvitations\">\n" +
+	"\x17AcceptInvitationRequest\x12#\n" +
+	"\rinvitation_id\x18\x01 \x01(\tR\finvitationId\"W\n" +
+	"\x18AcceptInvitationResponse\x12;\n" +
+	"\forganization\x18\x01 \x01(\v2\x17.jennah.v1.OrganizationR\forganization\"\x92\x01\n" +
+	"\x0eLinkedIdentity\x12\x1a\n" +
+	"\bprovider\x18\x01 \x01(\tR\bprovider\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x14\n" +
+	"\x05email\x18\x03 \x01(\tR\x05email\x12\x1b\n" +
+	"\tlinked_at\x18\x04 \x01(\tR\blinkedAt\x12\x18\n" +
+	"\aprimary\x18\x05 \x01(\bR\aprimary\"A\n" +
+	"\rIdentityProof\x12\x1a\n" +
+	"\bprovider\x18\x01 \x01(\tR\bprovider\x12\x14\n" +
+	"\x05token\x18\x02 \x01(\tR\x05token\"\x9c\x01\n" +
+	"\x13LinkIdentityRequest\x122\n" +
+	"\acurrent\x18\x01 \x01(\v2\x18.jennah.v1.IdentityProofR\acurrent\x12.\n" +
+	"\x05other\x18\x02 \x01(\v2\x18.jennah.v1.IdentityProofR\x05other\x12!\n" +
+	"\fmerge_tenant\x18\x03 \x01(\bR\vmergeTenant\"\xcb\x01\n" +
+	"\x14LinkIdentityResponse\x129\n" +
+	"\n" +
+	"identities\x18\x01 \x03(\v2\x19.jennah.v1.LinkedIdentityR\n" +
+	"identities\x12(\n" +
+	"\x10merged_tenant_id\x18\x02 \x01(\tR\x0emergedTenantId\x12\x1d\n" +
+	"\n" +
+	"moved_jobs\x18\x03 \x01(\x03R\tmovedJobs\x12/\n" +
+	"\x13moved_notifications\x18\x04 \x01(\x03R\x12movedNotifications\"L\n" +
+	"\x15UnlinkIdentityRequest\x12\x1a\n" +
+	"\bprovider\x18\x01 \x01(\tR\bprovider\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\"S\n" +
+	"\x16UnlinkIdentityResponse\x129\n" +
+	"\n" +
+	"identities\x18\x01 \x03(\v2\x19.jennah.v1.LinkedIdentityR\n" +
+	"identities\"\xa4\x03\n" +
+	"\n" +
+	"AuditEvent\x12\x19\n" +
+	"\bevent_id\x18\x01 \x01(\tR\aeventId\x12\x1f\n" +
+	"\voccurred_at\x18\x02 \x01(\tR\n" +
+	"occurredAt\x12\x1c\n" +
+	"\tprocedure\x18\x03 \x01(\tR\tprocedure\x12\x1d\n" +
+	"\n" +
+	"actor_type\x18\x04 \x01(\tR\tactorType\x12\x19\n" +
+	"\bactor_id\x18\x05 \x01(\tR\aactorId\x12\x1f\n" +
+	"\vactor_email\x18\x06 \x01(\tR\n" +
+	"actorEmail\x12\x1f\n" +
+	"\vtarget_type\x18\a \x01(\tR\n" +
+	"targetType\x12\x1b\n" +
+	"\ttarget_id\x18\b \x01(\tR\btargetId\x12!\n" +
+	"\frequest_json\x18\t \x01(\tR\vrequestJson\x12\x1f\n" +
+	"\vresult_code\x18\n" +
+	" \x01(\tR\n" +
+	"resultCode\x12#\n" +
+	"\rerror_message\x18\v \x01(\tR\ferrorMessage\x12\x1b\n" +
+	"\tclient_ip\x18\f \x01(\tR\bclientIp\x12\x1d\n" +
+	"\n" +
+	"user_agent\x18\r \x01(\tR\tuserAgent\"\xd6\x01\n" +
+	"\x16ListAuditEventsRequest\x12\x1c\n" +
+	"\tprocedure\x18\x01 \x01(\tR\tprocedure\x12\x19\n" +
+	"\bactor_id\x18\x02 \x01(\tR\aactorId\x12\x1b\n" +
+	"\ttarget_id\x18\x03 \x01(\tR\btargetId\x12\x14\n" +
+	"\x05since\x18\x04 \x01(\tR\x05since\x12\x14\n" +
+	"\x05until\x18\x05 \x01(\tR\x05until\x12\x1b\n" +
+	"\tpage_size\x18\x06 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\a \x01(\tR\tpageToken\"p\n" +
+	"\x17ListAuditEventsResponse\x12-\n" +
+	"\x06events\x18\x01 \x03(\v2\x15.jennah.v1.AuditEventR\x06events\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"\xc7\x01\n" +
+	"\x19ReconcileResourcesRequest\x12\x17\n" +
+	"\adry_run\x18\x01 \x01(\bR\x06dryRun\x12<\n" +
+	"\rorphan_action\x18\x02 \x01(\x0e2\x17.jennah.v1.OrphanActionR\forphanAction\x12!\n" +
+	"\ffail_missing\x18\x03 \x01(\bR\vfailMissing\x120\n" +
+	"\x14grace_period_seconds\x18\x04 \x01(\x03R\x12gracePeriodSeconds\"\xd4\x01\n" +
+	"\x10OrphanedResource\x12.\n" +
+	"\x13cloud_resource_path\x18\x01 \x01(\tR\x11cloudResourcePath\x12)\n" +
+	"\x10assigned_service\x18\x02 \x01(\tR\x0fassignedService\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\x12\x1f\n" +
+	"\vcreate_time\x18\x04 \x01(\tR\n" +
+	"createTime\x12\x16\n" +
+	"\x06action\x18\x05 \x01(\tR\x06action\x12\x14\n" +
+	"\x05error\x18\x06 \x01(\tR\x05error\"\xe6\x01\n" +
+	"\x0fMissingResource\x12\x1b\n" +
+	"\ttenant_id\x18\x01 \x01(\tR\btenantId\x12\x15\n" +
+	"\x06job_id\x18\x02 \x01(\tR\x05jobId\x12.\n" +
+	"\x13cloud_resource_path\x18\x03 \x01(\tR\x11cloudResourcePath\x12\x16\n" +
+	"\x06status\x18\x04 \x01(\tR\x06status\x12)\n" +
+	"\x10assigned_service\x18\x05 \x01(\tR\x0fassignedService\x12\x16\n" +
+	"\x06action\x18\x06 \x01(\tR\x06action\x12\x14\n" +
+	"\x05error\x18\a \x01(\tR\x05error\"\x8a\x02\n" +
+	"\x1aReconcileResourcesResponse\x125\n" +
+	"\aorphans\x18\x01 \x03(\v2\x1b.jennah.v1.OrphanedResourceR\aorphans\x124\n" +
+	"\amissing\x18\x02 \x03(\v2\x1a.jennah.v1.MissingResourceR\amissing\x12+\n" +
+	"\x11resources_scanned\x18\x03 \x01(\x05R\x10resourcesScanned\x12!\n" +
+	"\fjobs_scanned\x18\x04 \x01(\x05R\vjobsScanned\x12\x16\n" +
+	"\x06errors\x18\x05 \x03(\tR\x06errors\x12\x17\n" +
+	"\adry_run\x18\x06 \x01(\bR\x06dryRun\"\x9c\x01\n" +
+	"\x16SimulateRoutingRequest\x12\x14\n" +
+	"\x05rules\x18\x01 \x01(\tR\x05rules\x12#\n" +
+	"\rsince_seconds\x18\x02 \x01(\x03R\fsinceSeconds\x12,\n" +
+	"\x12samples_per_change\x18\x03 \x01(\x05R\x10samplesPerChange\x12\x19\n" +
+	"\bmax_jobs\x18\x04 \x01(\x05R\amaxJobs\"\xc8\x01\n" +
+	"\rRoutingSample\x12\x1b\n" +
+	"\ttenant_id\x18\x01 \x01(\tR\btenantId\x12\x15\n" +
+	"\x06job_id\x18\x02 \x01(\tR\x05jobId\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\tR\tcreatedAt\x12%\n" +
+	"\x0ecurrent_reason\x18\x05 \x01(\tR\rcurrentReason\x12)\n" +
+	"\x10simulated_reason\x18\x06 \x01(\tR\x0fsimulatedReason\"\x8b\x03\n" +
+	"\rRoutingChange\x12!\n" +
+	"\ffrom_service\x18\x01 \x01(\tR\vfromService\x12\x1d\n" +
+	"\n" +
+	"to_service\x18\x02 \x01(\tR\ttoService\x12\x12\n" +
+	"\x04jobs\x18\x03 \x01(\x05R\x04jobs\x12*\n" +
+	"\x11jobs_with_runtime\x18\x04 \x01(\x05R\x0fjobsWithRuntime\x128\n" +
+	"\x18observed_runtime_seconds\x18\x05 \x01(\x03R\x16observedRuntimeSeconds\x12(\n" +
+	"\x10current_cost_usd\x18\x06 \x01(\x01R\x0ecurrentCostUsd\x12,\n" +
+	"\x12simulated_cost_usd\x18\a \x01(\x01R\x10simulatedCostUsd\x122\n" +
+	"\x15startup_delta_seconds\x18\b \x01(\x03R\x13startupDeltaSeconds\x122\n" +
+	"\asamples\x18\t \x03(\v2\x18.jennah.v1.RoutingSampleR\asamples\"\xbe\x02\n" +
+	"\x17SimulateRoutingResponse\x12#\n" +
+	"\rjobs_replayed\x18\x01 \x01(\x05R\fjobsReplayed\x12!\n" +
+	"\fjobs_changed\x18\x02 \x01(\x05R\vjobsChanged\x12!\n" +
+	"\fjobs_skipped\x18\x03 \x01(\x05R\vjobsSkipped\x122\n" +
+	"\achanges\x18\x04 \x03(\v2\x18.jennah.v1.RoutingChangeR\achanges\x12$\n" +
+	"\x0ecost_delta_usd\x18\x05 \x01(\x01R\fcostDeltaUsd\x122\n" +
+	"\x15startup_delta_seconds\x18\x06 \x01(\x03R\x13startupDeltaSeconds\x12\x14\n" +
+	"\x05rules\x18\a \x01(\x05R\x05rules\x12\x14\n" +
+	"\x05since\x18\b \x01(\tR\x05since\"H\n" +
+	"\x14CreateSessionRequest\x12\x1a\n" +
+	"\bprovider\x18\x01 \x01(\tR\bprovider\x12\x14\n" +
+	"\x05token\x18\x02 \x01(\tR\x05token\"\xc5\x01\n" +
+	"\x15CreateSessionResponse\x12#\n" +
+	"\rsession_token\x18\x01 \x01(\tR\fsessionToken\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\x02 \x01(\tR\texpiresAt\x12\x1d\n" +
+	"\n" +
+	"user_email\x18\x03 \x01(\tR\tuserEmail\x12%\n" +
+	"\x0eoauth_provider\x18\x04 \x01(\tR\roauthProvider\x12\"\n" +
+	"\roauth_user_id\x18\x05 \x01(\tR\voauthUserId\"<\n" +
+	"\x15RefreshSessionRequest\x12#\n" +
+	"\rsession_token\x18\x01 \x01(\tR\fsessionToken\"\\\n" +
+	"\x16RefreshSessionResponse\x12#\n" +
+	"\rsession_token\x18\x01 \x01(\tR\fsessionToken\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\x02 \x01(\tR\texpiresAt*\x8d\x01\n" +
 	"\x0fComplexityLevel\x12 \n" +
 	"\x1cCOMPLEXITY_LEVEL_UNSPECIFIED\x10\x00\x12\x1b\n" +
 	"\x17COMPLEXITY_LEVEL_SIMPLE\x10\x01\x12\x1c\n" +
@@ -1560,7 +5475,11 @@ const file_proto_jennah_proto_rawDesc = "" +
 	"\x0fAssignedService\x12 \n" +
 	"\x1cASSIGNED_SERVICE_UNSPECIFIED\x10\x00\x12\"\n" +
 	"\x1eASSIGNED_SERVICE_CLOUD_RUN_JOB\x10\x02\x12 \n" +
-	"\x1cASSIGNED_SERVICE_CLOUD_BATCH\x10\x03\"\x04\b\x01\x10\x01*\x1cASSIGNED_SERVICE_CLOUD_TASKS2\x86\x05\n" +
+	"\x1cASSIGNED_SERVICE_CLOUD_BATCH\x10\x03\"\x04\b\x01\x10\x01*\x1cASSIGNED_SERVICE_CLOUD_TASKS*a\n" +
+	"\fOrphanAction\x12\x1d\n" +
+	"\x19ORPHAN_ACTION_UNSPECIFIED\x10\x00\x12\x18\n" +
+	"\x14ORPHAN_ACTION_CANCEL\x10\x01\x12\x18\n" +
+	"\x14ORPHAN_ACTION_DELETE\x10\x022\xbf\x0e\n" +
 	"\x11DeploymentService\x12F\n" +
 	"\tSubmitJob\x12\x1b.jennah.v1.SubmitJobRequest\x1a\x1c.jennah.v1.SubmitJobResponse\x12C\n" +
 	"\bListJobs\x12\x1a.jennah.v1.ListJobsRequest\x1a\x1b.jennah.v1.ListJobsResponse\x12[\n" +
@@ -1569,7 +5488,27 @@ const file_proto_jennah_proto_rawDesc = "" +
 	"\tDeleteJob\x12\x1b.jennah.v1.DeleteJobRequest\x1a\x1c.jennah.v1.DeleteJobResponse\x12=\n" +
 	"\x06GetJob\x12\x18.jennah.v1.GetJobRequest\x1a\x19.jennah.v1.GetJobResponse\x12^\n" +
 	"\x11ListNotifications\x12#.jennah.v1.ListNotificationsRequest\x1a$.jennah.v1.ListNotificationsResponse\x12X\n" +
-	"\x0fAckNotification\x12!.jennah.v1.AckNotificationRequest\x1a\".jennah.v1.AckNotificationResponseB2Z0github.com/alphauslabs/jennah/gen/proto;jennahv1b\x06proto3"
+	"\x0fAckNotification\x12!.jennah.v1.AckNotificationRequest\x1a\".jennah.v1.AckNotificationResponse\x12O\n" +
+	"\fCreateApiKey\x12\x1e.jennah.v1.CreateApiKeyRequest\x1a\x1f.jennah.v1.CreateApiKeyResponse\x12L\n" +
+	"\vListApiKeys\x12\x1d.jennah.v1.ListApiKeysRequest\x1a\x1e.jennah.v1.ListApiKeysResponse\x12O\n" +
+	"\fRevokeApiKey\x12\x1e.jennah.v1.RevokeApiKeyRequest\x1a\x1f.jennah.v1.RevokeApiKeyResponse\x12a\n" +
+	"\x12CreateOrganization\x12$.jennah.v1.CreateOrganizationRequest\x1a%.jennah.v1.CreateOrganizationResponse\x12^\n" +
+	"\x11ListOrganizations\x12#.jennah.v1.ListOrganizationsRequest\x1a$.jennah.v1.ListOrganizationsResponse\x12L\n" +
+	"\vListMembers\x12\x1d.jennah.v1.ListMembersRequest\x1a\x1e.jennah.v1.ListMembersResponse\x12[\n" +
+	"\x10UpdateMemberRole\x12\".jennah.v1.UpdateMemberRoleRequest\x1a#.jennah.v1.UpdateMemberRoleResponse\x12O\n" +
+	"\fRemoveMember\x12\x1e.jennah.v1.RemoveMemberRequest\x1a\x1f.jennah.v1.RemoveMemberResponse\x12O\n" +
+	"\fInviteMember\x12\x1e.jennah.v1.InviteMemberRequest\x1a\x1f.jennah.v1.InviteMemberResponse\x12X\n" +
+	"\x0fListInvitations\x12!.jennah.v1.ListInvitationsRequest\x1a\".jennah.v1.ListInvitationsResponse\x12[\n" +
+	"\x10AcceptInvitation\x12\".jennah.v1.AcceptInvitationRequest\x1a#.jennah.v1.AcceptInvitationResponse\x12O\n" +
+	"\fLinkIdentity\x12\x1e.jennah.v1.LinkIdentityRequest\x1a\x1f.jennah.v1.LinkIdentityResponse\x12U\n" +
+	"\x0eUnlinkIdentity\x12 .jennah.v1.UnlinkIdentityRequest\x1a!.jennah.v1.UnlinkIdentityResponse\x12X\n" +
+	"\x0fListAuditEvents\x12!.jennah.v1.ListAuditEventsRequest\x1a\".jennah.v1.ListAuditEventsResponse2\xcb\x01\n" +
+	"\fAdminService\x12a\n" +
+	"\x12ReconcileResources\x12$.jennah.v1.ReconcileResourcesRequest\x1a%.jennah.v1.ReconcileResourcesResponse\x12X\n" +
+	"\x0fSimulateRouting\x12!.jennah.v1.SimulateRoutingRequest\x1a\".jennah.v1.SimulateRoutingResponse2\xb8\x01\n" +
+	"\vAuthService\x12R\n" +
+	"\rCreateSession\x12\x1f.jennah.v1.CreateSessionRequest\x1a .jennah.v1.CreateSessionResponse\x12U\n" +
+	"\x0eRefreshSession\x12 .jennah.v1.RefreshSessionRequest\x1a!.jennah.v1.RefreshSessionResponseB2Z0github.com/alphauslabs/jennah/gen/proto;jennahv1b\x06proto3"
 
 var (
 	file_proto_jennah_proto_rawDescOnce sync.Once
@@ -1583,59 +5522,187 @@ func file_proto_jennah_proto_rawDescGZIP() []byte {
 	return file_proto_jennah_proto_rawDescData
 }
 
-var file_proto_jennah_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_proto_jennah_proto_msgTypes = make([]protoimpl.MessageInfo, 20)
+var file_proto_jennah_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_proto_jennah_proto_msgTypes = make([]protoimpl.MessageInfo, 77)
 var file_proto_jennah_proto_goTypes = []any{
-	(ComplexityLevel)(0),              // 0: jennah.v1.ComplexityLevel
-	(AssignedService)(0),              // 1: jennah.v1.AssignedService
-	(*ResourceOverride)(nil),          // 2: jennah.v1.ResourceOverride
-	(*SubmitJobRequest)(nil),          // 3: jennah.v1.SubmitJobRequest
-	(*SubmitJobResponse)(nil),         // 4: jennah.v1.SubmitJobResponse
-	(*ListJobsRequest)(nil),           // 5: jennah.v1.ListJobsRequest
-	(*ListJobsResponse)(nil),          // 6: jennah.v1.ListJobsResponse
-	(*Job)(nil),                       // 7: jennah.v1.Job
-	(*GetCurrentTenantRequest)(nil),   // 8: jennah.v1.GetCurrentTenantRequest
-	(*GetCurrentTenantResponse)(nil),  // 9: jennah.v1.GetCurrentTenantResponse
-	(*CancelJobRequest)(nil),          // 10: jennah.v1.CancelJobRequest
-	(*CancelJobResponse)(nil),         // 11: jennah.v1.CancelJobResponse
-	(*DeleteJobRequest)(nil),          // 12: jennah.v1.DeleteJobRequest
-	(*DeleteJobResponse)(nil),         // 13: jennah.v1.DeleteJobResponse
-	(*GetJobRequest)(nil),             // 14: jennah.v1.GetJobRequest
-	(*GetJobResponse)(nil),            // 15: jennah.v1.GetJobResponse
-	(*Notification)(nil),              // 16: jennah.v1.Notification
-	(*ListNotificationsRequest)(nil),  // 17: jennah.v1.ListNotificationsRequest
-	(*ListNotificationsResponse)(nil), // 18: jennah.v1.ListNotificationsResponse
-	(*AckNotificationRequest)(nil),    // 19: jennah.v1.AckNotificationRequest
-	(*AckNotificationResponse)(nil),   // 20: jennah.v1.AckNotificationResponse
-	nil,                               // 21: jennah.v1.SubmitJobRequest.EnvVarsEntry
+	(ComplexityLevel)(0),               // 0: jennah.v1.ComplexityLevel
+	(AssignedService)(0),               // 1: jennah.v1.AssignedService
+	(OrphanAction)(0),                  // 2: jennah.v1.OrphanAction
+	(*ResourceOverride)(nil),           // 3: jennah.v1.ResourceOverride
+	(*Accelerator)(nil),                // 4: jennah.v1.Accelerator
+	(*Volume)(nil),                     // 5: jennah.v1.Volume
+	(*GcsVolume)(nil),                  // 6: jennah.v1.GcsVolume
+	(*NfsVolume)(nil),                  // 7: jennah.v1.NfsVolume
+	(*LocalSsdVolume)(nil),             // 8: jennah.v1.LocalSsdVolume
+	(*Runnable)(nil),                   // 9: jennah.v1.Runnable
+	(*PreemptionPolicy)(nil),           // 10: jennah.v1.PreemptionPolicy
+	(*SubmitJobRequest)(nil),           // 11: jennah.v1.SubmitJobRequest
+	(*SubmitJobResponse)(nil),          // 12: jennah.v1.SubmitJobResponse
+	(*ListJobsRequest)(nil),            // 13: jennah.v1.ListJobsRequest
+	(*ListJobsResponse)(nil),           // 14: jennah.v1.ListJobsResponse
+	(*Job)(nil),                        // 15: jennah.v1.Job
+	(*GetCurrentTenantRequest)(nil),    // 16: jennah.v1.GetCurrentTenantRequest
+	(*GetCurrentTenantResponse)(nil),   // 17: jennah.v1.GetCurrentTenantResponse
+	(*CancelJobRequest)(nil),           // 18: jennah.v1.CancelJobRequest
+	(*CancelJobResponse)(nil),          // 19: jennah.v1.CancelJobResponse
+	(*DeleteJobRequest)(nil),           // 20: jennah.v1.DeleteJobRequest
+	(*DeleteJobResponse)(nil),          // 21: jennah.v1.DeleteJobResponse
+	(*GetJobRequest)(nil),              // 22: jennah.v1.GetJobRequest
+	(*GetJobResponse)(nil),             // 23: jennah.v1.GetJobResponse
+	(*Notification)(nil),               // 24: jennah.v1.Notification
+	(*ListNotificationsRequest)(nil),   // 25: jennah.v1.ListNotificationsRequest
+	(*ListNotificationsResponse)(nil),  // 26: jennah.v1.ListNotificationsResponse
+	(*AckNotificationRequest)(nil),     // 27: jennah.v1.AckNotificationRequest
+	(*AckNotificationResponse)(nil),    // 28: jennah.v1.AckNotificationResponse
+	(*ApiKey)(nil),                     // 29: jennah.v1.ApiKey
+	(*CreateApiKeyRequest)(nil),        // 30: jennah.v1.CreateApiKeyRequest
+	(*CreateApiKeyResponse)(nil),       // 31: jennah.v1.CreateApiKeyResponse
+	(*ListApiKeysRequest)(nil),         // 32: jennah.v1.ListApiKeysRequest
+	(*ListApiKeysResponse)(nil),        // 33: jennah.v1.ListApiKeysResponse
+	(*RevokeApiKeyRequest)(nil),        // 34: jennah.v1.RevokeApiKeyRequest
+	(*RevokeApiKeyResponse)(nil),       // 35: jennah.v1.RevokeApiKeyResponse
+	(*Organization)(nil),               // 36: jennah.v1.Organization
+	(*Member)(nil),                     // 37: jennah.v1.Member
+	(*Invitation)(nil),                 // 38: jennah.v1.Invitation
+	(*CreateOrganizationRequest)(nil),  // 39: jennah.v1.CreateOrganizationRequest
+	(*CreateOrganizationResponse)(nil), // 40: jennah.v1.CreateOrganizationResponse
+	(*ListOrganizationsRequest)(nil),   // 41: jennah.v1.ListOrganizationsRequest
+	(*ListOrganizationsResponse)(nil),  // 42: jennah.v1.ListOrganizationsResponse
+	(*ListMembersRequest)(nil),         // 43: jennah.v1.ListMembersRequest
+	(*ListMembersResponse)(nil),        // 44: jennah.v1.ListMembersResponse
+	(*UpdateMemberRoleRequest)(nil),    // 45: jennah.v1.UpdateMemberRoleRequest
+	(*UpdateMemberRoleResponse)(nil),   // 46: jennah.v1.UpdateMemberRoleResponse
+	(*RemoveMemberRequest)(nil),        // 47: jennah.v1.RemoveMemberRequest
+	(*RemoveMemberResponse)(nil),       // 48: jennah.v1.RemoveMemberResponse
+	(*InviteMemberRequest)(nil),        // 49: jennah.v1.InviteMemberRequest
+	(*InviteMemberResponse)(nil),       // 50: jennah.v1.InviteMemberResponse
+	(*ListInvitationsRequest)(nil),     // 51: jennah.v1.ListInvitationsRequest
+	(*ListInvitationsResponse)(nil),    // 52: jennah.v1.ListInvitationsResponse
+	(*AcceptInvitationRequest)(nil),    // 53: jennah.v1.AcceptInvitationRequest
+	(*AcceptInvitationResponse)(nil),   // 54: jennah.v1.AcceptInvitationResponse
+	(*LinkedIdentity)(nil),             // 55: jennah.v1.LinkedIdentity
+	(*IdentityProof)(nil),              // 56: jennah.v1.IdentityProof
+	(*LinkIdentityRequest)(nil),        // 57: jennah.v1.LinkIdentityRequest
+	(*LinkIdentityResponse)(nil),       // 58: jennah.v1.LinkIdentityResponse
+	(*UnlinkIdentityRequest)(nil),      // 59: jennah.v1.UnlinkIdentityRequest
+	(*UnlinkIdentityResponse)(nil),     // 60: jennah.v1.UnlinkIdentityResponse
+	(*AuditEvent)(nil),                 // 61: jennah.v1.AuditEvent
+	(*ListAuditEventsRequest)(nil),     // 62: jennah.v1.ListAuditEventsRequest
+	(*ListAuditEventsResponse)(nil),    // 63: jennah.v1.ListAuditEventsResponse
+	(*ReconcileResourcesRequest)(nil),  // 64: jennah.v1.ReconcileResourcesRequest
+	(*OrphanedResource)(nil),           // 65: jennah.v1.OrphanedResource
+	(*MissingResource)(nil),            // 66: jennah.v1.MissingResource
+	(*ReconcileResourcesResponse)(nil), // 67: jennah.v1.ReconcileResourcesResponse
+	(*SimulateRoutingRequest)(nil),     // 68: jennah.v1.SimulateRoutingRequest
+	(*RoutingSample)(nil),              // 69: jennah.v1.RoutingSample
+	(*RoutingChange)(nil),              // 70: jennah.v1.RoutingChange
+	(*SimulateRoutingResponse)(nil),    // 71: jennah.v1.SimulateRoutingResponse
+	(*CreateSessionRequest)(nil),       // 72: jennah.v1.CreateSessionRequest
+	(*CreateSessionResponse)(nil),      // 73: jennah.v1.CreateSessionResponse
+	(*RefreshSessionRequest)(nil),      // 74: jennah.v1.RefreshSessionRequest
+	(*RefreshSessionResponse)(nil),     // 75: jennah.v1.RefreshSessionResponse
+	nil,                                // 76: jennah.v1.Runnable.EnvVarsEntry
+	nil,                                // 77: jennah.v1.SubmitJobRequest.EnvVarsEntry
+	nil,                                // 78: jennah.v1.SubmitJobRequest.LabelsEntry
+	nil,                                // 79: jennah.v1.Job.LabelsEntry
 }
 var file_proto_jennah_proto_depIdxs = []int32{
-	21, // 0: jennah.v1.SubmitJobRequest.env_vars:type_name -> jennah.v1.SubmitJobRequest.EnvVarsEntry
-	2,  // 1: jennah.v1.SubmitJobRequest.resource_override:type_name -> jennah.v1.ResourceOverride
-	7,  // 2: jennah.v1.ListJobsResponse.jobs:type_name -> jennah.v1.Job
-	7,  // 3: jennah.v1.GetJobResponse.job:type_name -> jennah.v1.Job
-	16, // 4: jennah.v1.ListNotificationsResponse.notifications:type_name -> jennah.v1.Notification
-	3,  // 5: jennah.v1.DeploymentService.SubmitJob:input_type -> jennah.v1.SubmitJobRequest
-	5,  // 6: jennah.v1.DeploymentService.ListJobs:input_type -> jennah.v1.ListJobsRequest
-	8,  // 7: jennah.v1.DeploymentService.GetCurrentTenant:input_type -> jennah.v1.GetCurrentTenantRequest
-	10, // 8: jennah.v1.DeploymentService.CancelJob:input_type -> jennah.v1.CancelJobRequest
-	12, // 9: jennah.v1.DeploymentService.DeleteJob:input_type -> jennah.v1.DeleteJobRequest
-	14, // 10: jennah.v1.DeploymentService.GetJob:input_type -> jennah.v1.GetJobRequest
-	17, // 11: jennah.v1.DeploymentService.ListNotifications:input_type -> jennah.v1.ListNotificationsRequest
-	19, // 12: jennah.v1.DeploymentService.AckNotification:input_type -> jennah.v1.AckNotificationRequest
-	4,  // 13: jennah.v1.DeploymentService.SubmitJob:output_type -> jennah.v1.SubmitJobResponse
-	6,  // 14: jennah.v1.DeploymentService.ListJobs:output_type -> jennah.v1.ListJobsResponse
-	9,  // 15: jennah.v1.DeploymentService.GetCurrentTenant:output_type -> jennah.v1.GetCurrentTenantResponse
-	11, // 16: jennah.v1.DeploymentService.CancelJob:output_type -> jennah.v1.CancelJobResponse
-	13, // 17: jennah.v1.DeploymentService.DeleteJob:output_type -> jennah.v1.DeleteJobResponse
-	15, // 18: jennah.v1.DeploymentService.GetJob:output_type -> jennah.v1.GetJobResponse
-	18, // 19: jennah.v1.DeploymentService.ListNotifications:output_type -> jennah.v1.ListNotificationsResponse
-	20, // 20: jennah.v1.DeploymentService.AckNotification:output_type -> jennah.v1.AckNotificationResponse
-	13, // [13:21] is the sub-list for method output_type
-	5,  // [5:13] is the sub-list for method input_type
-	5,  // [5:5] is the sub-list for extension type_name
-	5,  // [5:5] is the sub-list for extension extendee
-	0,  // [0:5] is the sub-list for field type_name
+	6,  // 0: jennah.v1.Volume.gcs:type_name -> jennah.v1.GcsVolume
+	7,  // 1: jennah.v1.Volume.nfs:type_name -> jennah.v1.NfsVolume
+	8,  // 2: jennah.v1.Volume.local_ssd:type_name -> jennah.v1.LocalSsdVolume
+	76, // 3: jennah.v1.Runnable.env_vars:type_name -> jennah.v1.Runnable.EnvVarsEntry
+	77, // 4: jennah.v1.SubmitJobRequest.env_vars:type_name -> jennah.v1.SubmitJobRequest.EnvVarsEntry
+	3,  // 5: jennah.v1.SubmitJobRequest.resource_override:type_name -> jennah.v1.ResourceOverride
+	10, // 6: jennah.v1.SubmitJobRequest.preemption_policy:type_name -> jennah.v1.PreemptionPolicy
+	4,  // 7: jennah.v1.SubmitJobRequest.accelerator:type_name -> jennah.v1.Accelerator
+	5,  // 8: jennah.v1.SubmitJobRequest.volumes:type_name -> jennah.v1.Volume
+	9,  // 9: jennah.v1.SubmitJobRequest.runnables:type_name -> jennah.v1.Runnable
+	78, // 10: jennah.v1.SubmitJobRequest.labels:type_name -> jennah.v1.SubmitJobRequest.LabelsEntry
+	15, // 11: jennah.v1.ListJobsResponse.jobs:type_name -> jennah.v1.Job
+	4,  // 12: jennah.v1.Job.accelerator:type_name -> jennah.v1.Accelerator
+	5,  // 13: jennah.v1.Job.volumes:type_name -> jennah.v1.Volume
+	9,  // 14: jennah.v1.Job.runnables:type_name -> jennah.v1.Runnable
+	79, // 15: jennah.v1.Job.labels:type_name -> jennah.v1.Job.LabelsEntry
+	55, // 16: jennah.v1.GetCurrentTenantResponse.identities:type_name -> jennah.v1.LinkedIdentity
+	15, // 17: jennah.v1.GetJobResponse.job:type_name -> jennah.v1.Job
+	24, // 18: jennah.v1.ListNotificationsResponse.notifications:type_name -> jennah.v1.Notification
+	29, // 19: jennah.v1.CreateApiKeyResponse.api_key:type_name -> jennah.v1.ApiKey
+	29, // 20: jennah.v1.ListApiKeysResponse.api_keys:type_name -> jennah.v1.ApiKey
+	29, // 21: jennah.v1.RevokeApiKeyResponse.api_key:type_name -> jennah.v1.ApiKey
+	36, // 22: jennah.v1.CreateOrganizationResponse.organization:type_name -> jennah.v1.Organization
+	36, // 23: jennah.v1.ListOrganizationsResponse.organizations:type_name -> jennah.v1.Organization
+	37, // 24: jennah.v1.ListMembersResponse.members:type_name -> jennah.v1.Member
+	37, // 25: jennah.v1.UpdateMemberRoleResponse.member:type_name -> jennah.v1.Member
+	38, // 26: jennah.v1.InviteMemberResponse.invitation:type_name -> jennah.v1.Invitation
+	38, // 27: jennah.v1.ListInvitationsResponse.invitations:type_name -> jennah.v1.Invitation
+	36, // 28: jennah.v1.AcceptInvitationResponse.organization:type_name -> jennah.v1.Organization
+	56, // 29: jennah.v1.LinkIdentityRequest.current:type_name -> jennah.v1.IdentityProof
+	56, // 30: jennah.v1.LinkIdentityRequest.other:type_name -> jennah.v1.IdentityProof
+	55, // 31: jennah.v1.LinkIdentityResponse.identities:type_name -> jennah.v1.LinkedIdentity
+	55, // 32: jennah.v1.UnlinkIdentityResponse.identities:type_name -> jennah.v1.LinkedIdentity
+	61, // 33: jennah.v1.ListAuditEventsResponse.events:type_name -> jennah.v1.AuditEvent
+	2,  // 34: jennah.v1.ReconcileResourcesRequest.orphan_action:type_name -> jennah.v1.OrphanAction
+	65, // 35: jennah.v1.ReconcileResourcesResponse.orphans:type_name -> jennah.v1.OrphanedResource
+	66, // 36: jennah.v1.ReconcileResourcesResponse.missing:type_name -> jennah.v1.MissingResource
+	69, // 37: jennah.v1.RoutingChange.samples:type_name -> jennah.v1.RoutingSample
+	70, // 38: jennah.v1.SimulateRoutingResponse.changes:type_name -> jennah.v1.RoutingChange
+	11, // 39: jennah.v1.DeploymentService.SubmitJob:input_type -> jennah.v1.SubmitJobRequest
+	13, // 40: jennah.v1.DeploymentService.ListJobs:input_type -> jennah.v1.ListJobsRequest
+	16, // 41: jennah.v1.DeploymentService.GetCurrentTenant:input_type -> jennah.v1.GetCurrentTenantRequest
+	18, // 42: jennah.v1.DeploymentService.CancelJob:input_type -> jennah.v1.CancelJobRequest
+	20, // 43: jennah.v1.DeploymentService.DeleteJob:input_type -> jennah.v1.DeleteJobRequest
+	22, // 44: jennah.v1.DeploymentService.GetJob:input_type -> jennah.v1.GetJobRequest
+	25, // 45: jennah.v1.DeploymentService.ListNotifications:input_type -> jennah.v1.ListNotificationsRequest
+	27, // 46: jennah.v1.DeploymentService.AckNotification:input_type -> jennah.v1.AckNotificationRequest
+	30, // 47: jennah.v1.DeploymentService.CreateApiKey:input_type -> jennah.v1.CreateApiKeyRequest
+	32, // 48: jennah.v1.DeploymentService.ListApiKeys:input_type -> jennah.v1.ListApiKeysRequest
+	34, // 49: jennah.v1.DeploymentService.RevokeApiKey:input_type -> jennah.v1.RevokeApiKeyRequest
+	39, // 50: jennah.v1.DeploymentService.CreateOrganization:input_type -> jennah.v1.CreateOrganizationRequest
+	41, // 51: jennah.v1.DeploymentService.ListOrganizations:input_type -> jennah.v1.ListOrganizationsRequest
+	43, // 52: jennah.v1.DeploymentService.ListMembers:input_type -> jennah.v1.ListMembersRequest
+	45, // 53: jennah.v1.DeploymentService.UpdateMemberRole:input_type -> jennah.v1.UpdateMemberRoleRequest
+	47, // 54: jennah.v1.DeploymentService.RemoveMember:input_type -> jennah.v1.RemoveMemberRequest
+	49, // 55: jennah.v1.DeploymentService.InviteMember:input_type -> jennah.v1.InviteMemberRequest
+	51, // 56: jennah.v1.DeploymentService.ListInvitations:input_type -> jennah.v1.ListInvitationsRequest
+	53, // 57: jennah.v1.DeploymentService.AcceptInvitation:input_type -> jennah.v1.AcceptInvitationRequest
+	57, // 58: jennah.v1.DeploymentService.LinkIdentity:input_type -> jennah.v1.LinkIdentityRequest
+	59, // 59: jennah.v1.DeploymentService.UnlinkIdentity:input_type -> jennah.v1.UnlinkIdentityRequest
+	62, // 60: jennah.v1.DeploymentService.ListAuditEvents:input_type -> jennah.v1.ListAuditEventsRequest
+	64, // 61: jennah.v1.AdminService.ReconcileResources:input_type -> jennah.v1.ReconcileResourcesRequest
+	68, // 62: jennah.v1.AdminService.SimulateRouting:input_type -> jennah.v1.SimulateRoutingRequest
+	72, // 63: jennah.v1.AuthService.CreateSession:input_type -> jennah.v1.CreateSessionRequest
+	74, // 64: jennah.v1.AuthService.RefreshSession:input_type -> jennah.v1.RefreshSessionRequest
+	12, // 65: jennah.v1.DeploymentService.SubmitJob:output_type -> jennah.v1.SubmitJobResponse
+	14, // 66: jennah.v1.DeploymentService.ListJobs:output_type -> jennah.v1.ListJobsResponse
+	17, // 67: jennah.v1.DeploymentService.GetCurrentTenant:output_type -> jennah.v1.GetCurrentTenantResponse
+	19, // 68: jennah.v1.DeploymentService.CancelJob:output_type -> jennah.v1.CancelJobResponse
+	21, // 69: jennah.v1.DeploymentService.DeleteJob:output_type -> jennah.v1.DeleteJobResponse
+	23, // 70: jennah.v1.DeploymentService.GetJob:output_type -> jennah.v1.GetJobResponse
+	26, // 71: jennah.v1.DeploymentService.ListNotifications:output_type -> jennah.v1.ListNotificationsResponse
+	28, // 72: jennah.v1.DeploymentService.AckNotification:output_type -> jennah.v1.AckNotificationResponse
+	31, // 73: jennah.v1.DeploymentService.CreateApiKey:output_type -> jennah.v1.CreateApiKeyResponse
+	33, // 74: jennah.v1.DeploymentService.ListApiKeys:output_type -> jennah.v1.ListApiKeysResponse
+	35, // 75: jennah.v1.DeploymentService.RevokeApiKey:output_type -> jennah.v1.RevokeApiKeyResponse
+	40, // 76: jennah.v1.DeploymentService.CreateOrganization:output_type -> jennah.v1.CreateOrganizationResponse
+	42, // 77: jennah.v1.DeploymentService.ListOrganizations:output_type -> jennah.v1.ListOrganizationsResponse
+	44, // 78: jennah.v1.DeploymentService.ListMembers:output_type -> jennah.v1.ListMembersResponse
+	46, // 79: jennah.v1.DeploymentService.UpdateMemberRole:output_type -> jennah.v1.UpdateMemberRoleResponse
+	48, // 80: jennah.v1.DeploymentService.RemoveMember:output_type -> jennah.v1.RemoveMemberResponse
+	50, // 81: jennah.v1.DeploymentService.InviteMember:output_type -> jennah.v1.InviteMemberResponse
+	52, // 82: jennah.v1.DeploymentService.ListInvitations:output_type -> jennah.v1.ListInvitationsResponse
+	54, // 83: jennah.v1.DeploymentService.AcceptInvitation:output_type -> jennah.v1.AcceptInvitationResponse
+	58, // 84: jennah.v1.DeploymentService.LinkIdentity:output_type -> jennah.v1.LinkIdentityResponse
+	60, // 85: jennah.v1.DeploymentService.UnlinkIdentity:output_type -> jennah.v1.UnlinkIdentityResponse
+	63, // 86: jennah.v1.DeploymentService.ListAuditEvents:output_type -> jennah.v1.ListAuditEventsResponse
+	67, // 87: jennah.v1.AdminService.ReconcileResources:output_type -> jennah.v1.ReconcileResourcesResponse
+	71, // 88: jennah.v1.AdminService.SimulateRouting:output_type -> jennah.v1.SimulateRoutingResponse
+	73, // 89: jennah.v1.AuthService.CreateSession:output_type -> jennah.v1.CreateSessionResponse
+	75, // 90: jennah.v1.AuthService.RefreshSession:output_type -> jennah.v1.RefreshSessionResponse
+	65, // [65:91] is the sub-list for method output_type
+	39, // [39:65] is the sub-list for method input_type
+	39, // [39:39] is the sub-list for extension type_name
+	39, // [39:39] is the sub-list for extension extendee
+	0,  // [0:39] is the sub-list for field type_name
 }
 
 func init() { file_proto_jennah_proto_init() }
@@ -1648,10 +5715,10 @@ func file_proto_jennah_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_jennah_proto_rawDesc), len(file_proto_jennah_proto_rawDesc)),
-			NumEnums:      2,
-			NumMessages:   20,
+			NumEnums:      3,
+			NumMessages:   77,
 			NumExtensions: 0,
-			NumServices:   1,
+			NumServices:   3,
 		},
 		GoTypes:           file_proto_jennah_proto_goTypes,
 		DependencyIndexes: file_proto_jennah_proto_depIdxs,
@@ -23,6 +23,10 @@ const _ = connect.IsAtLeastVersion1_13_0
 const (
 	// DeploymentServiceName is the fully-qualified name of the DeploymentService service.
 	DeploymentServiceName = "jennah.v1.DeploymentService"
+	// AdminServiceName is the fully-qualified name of the AdminService service.
+	AdminServiceName = "jennah.v1.AdminService"
+	// AuthServiceName is the fully-qualified name of the AuthService service.
+	AuthServiceName = "jennah.v1.AuthService"
 )
 
 // These constants are the fully-qualified names of the RPCs defined in this package. They're
@@ -57,6 +61,60 @@ const (
 	// DeploymentServiceAckNotificationProcedure is the fully-qualified name of the DeploymentService's
 	// AckNotification RPC.
 	DeploymentServiceAckNotificationProcedure = "/jennah.v1.DeploymentService/AckNotification"
+	// DeploymentServiceCreateApiKeyProcedure is the fully-qualified name of the DeploymentService's
+	// CreateApiKey RPC.
+	DeploymentServiceCreateApiKeyProcedure = "/jennah.v1.DeploymentService/CreateApiKey"
+	// DeploymentServiceListApiKeysProcedure is the fully-qualified name of the DeploymentService's
+	// ListApiKeys RPC.
+	DeploymentServiceListApiKeysProcedure = "/jennah.v1.DeploymentService/ListApiKeys"
+	// DeploymentServiceRevokeApiKeyProcedure is the fully-qualified name of the DeploymentService's
+	// RevokeApiKey RPC.
+	DeploymentServiceRevokeApiKeyProcedure = "/jennah.v1.DeploymentService/RevokeApiKey"
+	// DeploymentServiceCreateOrganizationProcedure is the fully-qualified name of the
+	// DeploymentService's CreateOrganization RPC.
+	DeploymentServiceCreateOrganizationProcedure = "/jennah.v1.DeploymentService/CreateOrganization"
+	// DeploymentServiceListOrganizationsProcedure is the fully-qualified name of the
+	// DeploymentService's ListOrganizations RPC.
+	DeploymentServiceListOrganizationsProcedure = "/jennah.v1.DeploymentService/ListOrganizations"
+	// DeploymentServiceListMembersProcedure is the fully-qualified name of the DeploymentService's
+	// ListMembers RPC.
+	DeploymentServiceListMembersProcedure = "/jennah.v1.DeploymentService/ListMembers"
+	// DeploymentServiceUpdateMemberRoleProcedure is the fully-qualified name of the DeploymentService's
+	// UpdateMemberRole RPC.
+	DeploymentServiceUpdateMemberRoleProcedure = "/jennah.v1.DeploymentService/UpdateMemberRole"
+	// DeploymentServiceRemoveMemberProcedure is the fully-qualified name of the DeploymentService's
+	// RemoveMember RPC.
+	DeploymentServiceRemoveMemberProcedure = "/jennah.v1.DeploymentService/RemoveMember"
+	// DeploymentServiceInviteMemberProcedure is the fully-qualified name of the DeploymentService's
+	// InviteMember RPC.
+	DeploymentServiceInviteMemberProcedure = "/jennah.v1.DeploymentService/InviteMember"
+	// DeploymentServiceListInvitationsProcedure is the fully-qualified name of the DeploymentService's
+	// ListInvitations RPC.
+	DeploymentServiceListInvitationsProcedure = "/jennah.v1.DeploymentService/ListInvitations"
+	// DeploymentServiceAcceptInvitationProcedure is the fully-qualified name of the DeploymentService's
+	// AcceptInvitation RPC.
+	DeploymentServiceAcceptInvitationProcedure = "/jennah.v1.DeploymentService/AcceptInvitation"
+	// DeploymentServiceLinkIdentityProcedure is the fully-qualified name of the DeploymentService's
+	// LinkIdentity RPC.
+	DeploymentServiceLinkIdentityProcedure = "/jennah.v1.DeploymentService/LinkIdentity"
+	// DeploymentServiceUnlinkIdentityProcedure is the fully-qualified name of the DeploymentService's
+	// UnlinkIdentity RPC.
+	DeploymentServiceUnlinkIdentityProcedure = "/jennah.v1.DeploymentService/UnlinkIdentity"
+	// DeploymentServiceListAuditEventsProcedure is the fully-qualified name of the DeploymentService's
+	// ListAuditEvents RPC.
+	DeploymentServiceListAuditEventsProcedure = "/jennah.v1.DeploymentService/ListAuditEvents"
+	// AdminServiceReconcileResourcesProcedure is the fully-qualified name of the AdminService's
+	// ReconcileResources RPC.
+	AdminServiceReconcileResourcesProcedure = "/jennah.v1.AdminService/ReconcileResources"
+	// AdminServiceSimulateRoutingProcedure is the fully-qualified name of the AdminService's
+	// SimulateRouting RPC.
+	AdminServiceSimulateRoutingProcedure = "/jennah.v1.AdminService/SimulateRouting"
+	// AuthServiceCreateSessionProcedure is the fully-qualified name of the AuthService's CreateSession
+	// RPC.
+	AuthServiceCreateSessionProcedure = "/jennah.v1.AuthService/CreateSession"
+	// AuthServiceRefreshSessionProcedure is the fully-qualified name of the AuthService's
+	// RefreshSession RPC.
+	AuthServiceRefreshSessionProcedure = "/jennah.v1.AuthService/RefreshSession"
 )
 
 // DeploymentServiceClient is a client for the jennah.v1.DeploymentService service.
@@ -77,6 +135,41 @@ type DeploymentServiceClient interface {
 	ListNotifications(context.Context, *connect.Request[proto.ListNotificationsRequest]) (*connect.Response[proto.ListNotificationsResponse], error)
 	// Mark a notification as read (ack).
 	AckNotification(context.Context, *connect.Request[proto.AckNotificationRequest]) (*connect.Response[proto.AckNotificationResponse], error)
+	// Create an API key for the current tenant. The secret is only returned
+	// here. API keys cannot manage API keys.
+	CreateApiKey(context.Context, *connect.Request[proto.CreateApiKeyRequest]) (*connect.Response[proto.CreateApiKeyResponse], error)
+	// List the current tenant's API keys, including revoked and expired ones.
+	ListApiKeys(context.Context, *connect.Request[proto.ListApiKeysRequest]) (*connect.Response[proto.ListApiKeysResponse], error)
+	// Revoke an API key; requests with it fail from then on.
+	RevokeApiKey(context.Context, *connect.Request[proto.RevokeApiKeyRequest]) (*connect.Response[proto.RevokeApiKeyResponse], error)
+	// Create an organization with the caller as its admin.
+	CreateOrganization(context.Context, *connect.Request[proto.CreateOrganizationRequest]) (*connect.Response[proto.CreateOrganizationResponse], error)
+	// List the organizations the caller is a member of, including their
+	// personal one.
+	ListOrganizations(context.Context, *connect.Request[proto.ListOrganizationsRequest]) (*connect.Response[proto.ListOrganizationsResponse], error)
+	// List the members of the active organization.
+	ListMembers(context.Context, *connect.Request[proto.ListMembersRequest]) (*connect.Response[proto.ListMembersResponse], error)
+	// Change a member's role (admins only).
+	UpdateMemberRole(context.Context, *connect.Request[proto.UpdateMemberRoleRequest]) (*connect.Response[proto.UpdateMemberRoleResponse], error)
+	// Remove a member from the active organization. Admins can remove anyone;
+	// other members can only remove themselves.
+	RemoveMember(context.Context, *connect.Request[proto.RemoveMemberRequest]) (*connect.Response[proto.RemoveMemberResponse], error)
+	// Invite someone by email to the active organization (admins only).
+	InviteMember(context.Context, *connect.Request[proto.InviteMemberRequest]) (*connect.Response[proto.InviteMemberResponse], error)
+	// List pending invitations addressed to the caller's email.
+	ListInvitations(context.Context, *connect.Request[proto.ListInvitationsRequest]) (*connect.Response[proto.ListInvitationsResponse], error)
+	// Accept an invitation addressed to the caller's email.
+	AcceptInvitation(context.Context, *connect.Request[proto.AcceptInvitationRequest]) (*connect.Response[proto.AcceptInvitationResponse], error)
+	// Link another sign-in identity to the caller's personal tenant, so both
+	// identities sign in to the same tenant. Needs fresh identity provider
+	// tokens for both identities.
+	LinkIdentity(context.Context, *connect.Request[proto.LinkIdentityRequest]) (*connect.Response[proto.LinkIdentityResponse], error)
+	// Unlink an identity from the caller's personal tenant. It gets a new
+	// tenant of its own on its next sign-in.
+	UnlinkIdentity(context.Context, *connect.Request[proto.UnlinkIdentityRequest]) (*connect.Response[proto.UnlinkIdentityResponse], error)
+	// List the audit log of the active organization, newest first. Admins
+	// only.
+	ListAuditEvents(context.Context, *connect.Request[proto.ListAuditEventsRequest]) (*connect.Response[proto.ListAuditEventsResponse], error)
 }
 
 // NewDeploymentServiceClient constructs a client for the jennah.v1.DeploymentService service. By
@@ -138,19 +231,117 @@ func NewDeploymentServiceClient(httpClient connect.HTTPClient, baseURL string, o
 			connect.WithSchema(deploymentServiceMethods.ByName("AckNotification")),
 			connect.WithClientOptions(opts...),
 		),
+		createApiKey: connect.NewClient[proto.CreateApiKeyRequest, proto.CreateApiKeyResponse](
+			httpClient,
+			baseURL+DeploymentServiceCreateApiKeyProcedure,
+			connect.WithSchema(deploymentServiceMethods.ByName("CreateApiKey")),
+			connect.WithClientOptions(opts...),
+		),
+		listApiKeys: connect.NewClient[proto.ListApiKeysRequest, proto.ListApiKeysResponse](
+			httpClient,
+			baseURL+DeploymentServiceListApiKeysProcedure,
+			connect.WithSchema(deploymentServiceMethods.ByName("ListApiKeys")),
+			connect.WithClientOptions(opts...),
+		),
+		revokeApiKey: connect.NewClient[proto.RevokeApiKeyRequest, proto.RevokeApiKeyResponse](
+			httpClient,
+			baseURL+DeploymentServiceRevokeApiKeyProcedure,
+			connect.WithSchema(deploymentServiceMethods.ByName("RevokeApiKey")),
+			connect.WithClientOptions(opts...),
+		),
+		createOrganization: connect.NewClient[proto.CreateOrganizationRequest, proto.CreateOrganizationResponse](
+			httpClient,
+			baseURL+DeploymentServiceCreateOrganizationProcedure,
+			connect.WithSchema(deploymentServiceMethods.ByName("CreateOrganization")),
+			connect.WithClientOptions(opts...),
+		),
+		listOrganizations: connect.NewClient[proto.ListOrganizationsRequest, proto.ListOrganizationsResponse](
+			httpClient,
+			baseURL+DeploymentServiceListOrganizationsProcedure,
+			connect.WithSchema(deploymentServiceMethods.ByName("ListOrganizations")),
+			connect.WithClientOptions(opts...),
+		),
+		listMembers: connect.NewClient[proto.ListMembersRequest, proto.ListMembersResponse](
+			httpClient,
+			baseURL+DeploymentServiceListMembersProcedure,
+			connect.WithSchema(deploymentServiceMethods.ByName("ListMembers")),
+			connect.WithClientOptions(opts...),
+		),
+		updateMemberRole: connect.NewClient[proto.UpdateMemberRoleRequest, proto.UpdateMemberRoleResponse](
+			httpClient,
+			baseURL+DeploymentServiceUpdateMemberRoleProcedure,
+			connect.WithSchema(deploymentServiceMethods.ByName("UpdateMemberRole")),
+			connect.WithClientOptions(opts...),
+		),
+		removeMember: connect.NewClient[proto.RemoveMemberRequest, proto.RemoveMemberResponse](
+			httpClient,
+			baseURL+DeploymentServiceRemoveMemberProcedure,
+			connect.WithSchema(deploymentServiceMethods.ByName("RemoveMember")),
+			connect.WithClientOptions(opts...),
+		),
+		inviteMember: connect.NewClient[proto.InviteMemberRequest, proto.InviteMemberResponse](
+			httpClient,
+			baseURL+DeploymentServiceInviteMemberProcedure,
+			connect.WithSchema(deploymentServiceMethods.ByName("InviteMember")),
+			connect.WithClientOptions(opts...),
+		),
+		listInvitations: connect.NewClient[proto.ListInvitationsRequest, proto.ListInvitationsResponse](
+			httpClient,
+			baseURL+DeploymentServiceListInvitationsProcedure,
+			connect.WithSchema(deploymentServiceMethods.ByName("ListInvitations")),
+			connect.WithClientOptions(opts...),
+		),
+		acceptInvitation: connect.NewClient[proto.AcceptInvitationRequest, proto.AcceptInvitationResponse](
+			httpClient,
+			baseURL+DeploymentServiceAcceptInvitationProcedure,
+			connect.WithSchema(deploymentServiceMethods.ByName("AcceptInvitation")),
+			connect.WithClientOptions(opts...),
+		),
+		linkIdentity: connect.NewClient[proto.LinkIdentityRequest, proto.LinkIdentityResponse](
+			httpClient,
+			baseURL+DeploymentServiceLinkIdentityProcedure,
+			connect.WithSchema(deploymentServiceMethods.ByName("LinkIdentity")),
+			connect.WithClientOptions(opts...),
+		),
+		unlinkIdentity: connect.NewClient[proto.UnlinkIdentityRequest, proto.UnlinkIdentityResponse](
+			httpClient,
+			baseURL+DeploymentServiceUnlinkIdentityProcedure,
+			connect.WithSchema(deploymentServiceMethods.ByName("UnlinkIdentity")),
+			connect.WithClientOptions(opts...),
+		),
+		listAuditEvents: connect.NewClient[proto.ListAuditEventsRequest, proto.ListAuditEventsResponse](
+			httpClient,
+			baseURL+DeploymentServiceListAuditEventsProcedure,
+			connect.WithSchema(deploymentServiceMethods.ByName("ListAuditEvents")),
+			connect.WithClientOptions(opts...),
+		),
 	}
 }
 
 // deploymentServiceClient implements DeploymentServiceClient.
 type deploymentServiceClient struct {
-	submitJob         *connect.Client[proto.SubmitJobRequest, proto.SubmitJobResponse]
-	listJobs          *connect.Client[proto.ListJobsRequest, proto.ListJobsResponse]
-	getCurrentTenant  *connect.Client[proto.GetCurrentTenantRequest, proto.GetCurrentTenantResponse]
-	cancelJob         *connect.Client[proto.CancelJobRequest, proto.CancelJobResponse]
-	deleteJob         *connect.Client[proto.DeleteJobRequest, proto.DeleteJobResponse]
-	getJob            *connect.Client[proto.GetJobRequest, proto.GetJobResponse]
-	listNotifications *connect.Client[proto.ListNotificationsRequest, proto.ListNotificationsResponse]
-	ackNotification   *connect.Client[proto.AckNotificationRequest, proto.AckNotificationResponse]
+	submitJob          *connect.Client[proto.SubmitJobRequest, proto.SubmitJobResponse]
+	listJobs           *connect.Client[proto.ListJobsRequest, proto.ListJobsResponse]
+	getCurrentTenant   *connect.Client[proto.GetCurrentTenantRequest, proto.GetCurrentTenantResponse]
+	cancelJob          *connect.Client[proto.CancelJobRequest, proto.CancelJobResponse]
+	deleteJob          *connect.Client[proto.DeleteJobRequest, proto.DeleteJobResponse]
+	getJob             *connect.Client[proto.GetJobRequest, proto.GetJobResponse]
+	listNotifications  *connect.Client[proto.ListNotificationsRequest, proto.ListNotificationsResponse]
+	ackNotification    *connect.Client[proto.AckNotificationRequest, proto.AckNotificationResponse]
+	createApiKey       *connect.Client[proto.CreateApiKeyRequest, proto.CreateApiKeyResponse]
+	listApiKeys        *connect.Client[proto.ListApiKeysRequest, proto.ListApiKeysResponse]
+	revokeApiKey       *connect.Client[proto.RevokeApiKeyRequest, proto.RevokeApiKeyResponse]
+	createOrganization *connect.Client[proto.CreateOrganizationRequest, proto.CreateOrganizationResponse]
+	listOrganizations  *connect.Client[proto.ListOrganizationsRequest, proto.ListOrganizationsResponse]
+	listMembers        *connect.Client[proto.ListMembersRequest, proto.ListMembersResponse]
+	updateMemberRole   *connect.Client[proto.UpdateMemberRoleRequest, proto.UpdateMemberRoleResponse]
+	removeMember       *connect.Client[proto.RemoveMemberRequest, proto.RemoveMemberResponse]
+	inviteMember       *connect.Client[proto.InviteMemberRequest, proto.InviteMemberResponse]
+	listInvitations    *connect.Client[proto.ListInvitationsRequest, proto.ListInvitationsResponse]
+	acceptInvitation   *connect.Client[proto.AcceptInvitationRequest, proto.AcceptInvitationResponse]
+	linkIdentity       *connect.Client[proto.LinkIdentityRequest, proto.LinkIdentityResponse]
+	unlinkIdentity     *connect.Client[proto.UnlinkIdentityRequest, proto.UnlinkIdentityResponse]
+	listAuditEvents    *connect.Client[proto.ListAuditEventsRequest, proto.ListAuditEventsResponse]
 }
 
 // SubmitJob calls jennah.v1.DeploymentService.SubmitJob.
@@ -193,6 +384,76 @@ func (c *deploymentServiceClient) AckNotification(ctx context.Context, req *conn
 	return c.ackNotification.CallUnary(ctx, req)
 }
 
+// CreateApiKey calls jennah.v1.DeploymentService.CreateApiKey.
+func (c *deploymentServiceClient) CreateApiKey(ctx context.Context, req *connect.Request[proto.CreateApiKeyRequest]) (*connect.Response[proto.CreateApiKeyResponse], error) {
+	return c.createApiKey.CallUnary(ctx, req)
+}
+
+// ListApiKeys calls jennah.v1.DeploymentService.ListApiKeys.
+func (c *deploymentServiceClient) ListApiKeys(ctx context.Context, req *connect.Request[proto.ListApiKeysRequest]) (*connect.Response[proto.ListApiKeysResponse], error) {
+	return c.listApiKeys.CallUnary(ctx, req)
+}
+
+// RevokeApiKey calls jennah.v1.DeploymentService.RevokeApiKey.
+func (c *deploymentServiceClient) RevokeApiKey(ctx context.Context, req *connect.Request[proto.RevokeApiKeyRequest]) (*connect.Response[proto.RevokeApiKeyResponse], error) {
+	return c.revokeApiKey.CallUnary(ctx, req)
+}
+
+// CreateOrganization calls jennah.v1.DeploymentService.CreateOrganization.
+func (c *deploymentServiceClient) CreateOrganization(ctx context.Context, req *connect.Request[proto.CreateOrganizationRequest]) (*connect.Response[proto.CreateOrganizationResponse], error) {
+	return c.createOrganization.CallUnary(ctx, req)
+}
+
+// ListOrganizations calls jennah.v1.DeploymentService.ListOrganizations.
+func (c *deploymentServiceClient) ListOrganizations(ctx context.Context, req *connect.Request[proto.ListOrganizationsRequest]) (*connect.Response[proto.ListOrganizationsResponse], error) {
+	return c.listOrganizations.CallUnary(ctx, req)
+}
+
+// ListMembers calls jennah.v1.DeploymentService.ListMembers.
+func (c *deploymentServiceClient) ListMembers(ctx context.Context, req *connect.Request[proto.ListMembersRequest]) (*connect.Response[proto.ListMembersResponse], error) {
+	return c.listMembers.CallUnary(ctx, req)
+}
+
+// UpdateMemberRole calls jennah.v1.DeploymentService.UpdateMemberRole.
+func (c *deploymentServiceClient) UpdateMemberRole(ctx context.Context, req *connect.Request[proto.UpdateMemberRoleRequest]) (*connect.Response[proto.UpdateMemberRoleResponse], error) {
+	return c.updateMemberRole.CallUnary(ctx, req)
+}
+
+// RemoveMember calls jennah.v1.DeploymentService.RemoveMember.
+func (c *deploymentServiceClient) RemoveMember(ctx context.Context, req *connect.Request[proto.RemoveMemberRequest]) (*connect.Response[proto.RemoveMemberResponse], error) {
+	return c.removeMember.CallUnary(ctx, req)
+}
+
+// InviteMember calls jennah.v1.DeploymentService.InviteMember.
+func (c *deploymentServiceClient) InviteMember(ctx context.Context, req *connect.Request[proto.InviteMemberRequest]) (*connect.Response[proto.InviteMemberResponse], error) {
+	return c.inviteMember.CallUnary(ctx, req)
+}
+
+// ListInvitations calls jennah.v1.DeploymentService.ListInvitations.
+func (c *deploymentServiceClient) ListInvitations(ctx context.Context, req *connect.Request[proto.ListInvitationsRequest]) (*connect.Response[proto.ListInvitationsResponse], error) {
+	return c.listInvitations.CallUnary(ctx, req)
+}
+
+// AcceptInvitation calls jennah.v1.DeploymentService.AcceptInvitation.
+func (c *deploymentServiceClient) AcceptInvitation(ctx context.Context, req *connect.Request[proto.AcceptInvitationRequest]) (*connect.Response[proto.AcceptInvitationResponse], error) {
+	return c.acceptInvitation.CallUnary(ctx, req)
+}
+
+// LinkIdentity calls jennah.v1.DeploymentService.LinkIdentity.
+func (c *deploymentServiceClient) LinkIdentity(ctx context.Context, req *connect.Request[proto.LinkIdentityRequest]) (*connect.Response[proto.LinkIdentityResponse], error) {
+	return c.linkIdentity.CallUnary(ctx, req)
+}
+
+// UnlinkIdentity calls jennah.v1.DeploymentService.UnlinkIdentity.
+func (c *deploymentServiceClient) UnlinkIdentity(ctx context.Context, req *connect.Request[proto.UnlinkIdentityRequest]) (*connect.Response[proto.UnlinkIdentityResponse], error) {
+	return c.unlinkIdentity.CallUnary(ctx, req)
+}
+
+// ListAuditEvents calls jennah.v1.DeploymentService.ListAuditEvents.
+func (c *deploymentServiceClient) ListAuditEvents(ctx context.Context, req *connect.Request[proto.ListAuditEventsRequest]) (*connect.Response[proto.ListAuditEventsResponse], error) {
+	return c.listAuditEvents.CallUnary(ctx, req)
+}
+
 // DeploymentServiceHandler is an implementation of the jennah.v1.DeploymentService service.
 type DeploymentServiceHandler interface {
 	// Submit a job for deployment.
@@ -211,6 +472,41 @@ type DeploymentServiceHandler interface {
 	ListNotifications(context.Context, *connect.Request[proto.ListNotificationsRequest]) (*connect.Response[proto.ListNotificationsResponse], error)
 	// Mark a notification as read (ack).
 	AckNotification(context.Context, *connect.Request[proto.AckNotificationRequest]) (*connect.Response[proto.AckNotificationResponse], error)
+	// Create an API key for the current tenant. The secret is only returned
+	// here. API keys cannot manage API keys.
+	CreateApiKey(context.Context, *connect.Request[proto.CreateApiKeyRequest]) (*connect.Response[proto.CreateApiKeyResponse], error)
+	// List the current tenant's API keys, including revoked and expired ones.
+	ListApiKeys(context.Context, *connect.Request[proto.ListApiKeysRequest]) (*connect.Response[proto.ListApiKeysResponse], error)
+	// Revoke an API key; requests with it fail from then on.
+	RevokeApiKey(context.Context, *connect.Request[proto.RevokeApiKeyRequest]) (*connect.Response[proto.RevokeApiKeyResponse], error)
+	// Create an organization with the caller as its admin.
+	CreateOrganization(context.Context, *connect.Request[proto.CreateOrganizationRequest]) (*connect.Response[proto.CreateOrganizationResponse], error)
+	// List the organizations the caller is a member of, including their
+	// personal one.
+	ListOrganizations(context.Context, *connect.Request[proto.ListOrganizationsRequest]) (*connect.Response[proto.ListOrganizationsResponse], error)
+	// List the members of the active organization.
+	ListMembers(context.Context, *connect.Request[proto.ListMembersRequest]) (*connect.Response[proto.ListMembersResponse], error)
+	// Change a member's role (admins only).
+	UpdateMemberRole(context.Context, *connect.Request[proto.UpdateMemberRoleRequest]) (*connect.Response[proto.UpdateMemberRoleResponse], error)
+	// Remove a member from the active organization. Admins can remove anyone;
+	// other members can only remove themselves.
+	RemoveMember(context.Context, *connect.Request[proto.RemoveMemberRequest]) (*connect.Response[proto.RemoveMemberResponse], error)
+	// Invite someone by email to the active organization (admins only).
+	InviteMember(context.Context, *connect.Request[proto.InviteMemberRequest]) (*connect.Response[proto.InviteMemberResponse], error)
+	// List pending invitations addressed to the caller's email.
+	ListInvitations(context.Context, *connect.Request[proto.ListInvitationsRequest]) (*connect.Response[proto.ListInvitationsResponse], error)
+	// Accept an invitation addressed to the caller's email.
+	AcceptInvitation(context.Context, *connect.Request[proto.AcceptInvitationRequest]) (*connect.Response[proto.AcceptInvitationResponse], error)
+	// Link another sign-in identity to the caller's personal tenant, so both
+	// identities sign in to the same tenant. Needs fresh identity provider
+	// tokens for both identities.
+	LinkIdentity(context.Context, *connect.Request[proto.LinkIdentityRequest]) (*connect.Response[proto.LinkIdentityResponse], error)
+	// Unlink an identity from the caller's personal tenant. It gets a new
+	// tenant of its own on its next sign-in.
+	UnlinkIdentity(context.Context, *connect.Request[proto.UnlinkIdentityRequest]) (*connect.Response[proto.UnlinkIdentityResponse], error)
+	// List the audit log of the active organization, newest first. Admins
+	// only.
+	ListAuditEvents(context.Context, *connect.Request[proto.ListAuditEventsRequest]) (*connect.Response[proto.ListAuditEventsResponse], error)
 }
 
 // NewDeploymentServiceHandler builds an HTTP handler from the service implementation. It returns
@@ -268,6 +564,90 @@ func NewDeploymentServiceHandler(svc DeploymentServiceHandler, opts ...connect.H
 		connect.WithSchema(deploymentServiceMethods.ByName("AckNotification")),
 		connect.WithHandlerOptions(opts...),
 	)
+	deploymentServiceCreateApiKeyHandler := connect.NewUnaryHandler(
+		DeploymentServiceCreateApiKeyProcedure,
+		svc.CreateApiKey,
+		connect.WithSchema(deploymentServiceMethods.ByName("CreateApiKey")),
+		connect.WithHandlerOptions(opts...),
+	)
+	deploymentServiceListApiKeysHandler := connect.NewUnaryHandler(
+		DeploymentServiceListApiKeysProcedure,
+		svc.ListApiKeys,
+		connect.WithSchema(deploymentServiceMethods.ByName("ListApiKeys")),
+		connect.WithHandlerOptions(opts...),
+	)
+	deploymentServiceRevokeApiKeyHandler := connect.NewUnaryHandler(
+		DeploymentServiceRevokeApiKeyProcedure,
+		svc.RevokeApiKey,
+		connect.WithSchema(deploymentServiceMethods.ByName("RevokeApiKey")),
+		connect.WithHandlerOptions(opts...),
+	)
+	deploymentServiceCreateOrganizationHandler := connect.NewUnaryHandler(
+		DeploymentServiceCreateOrganizationProcedure,
+		svc.CreateOrganization,
+		connect.WithSchema(deploymentServiceMethods.ByName("CreateOrganization")),
+		connect.WithHandlerOptions(opts...),
+	)
+	deploymentServiceListOrganizationsHandler := connect.NewUnaryHandler(
+		DeploymentServiceListOrganizationsProcedure,
+		svc.ListOrganizations,
+		connect.WithSchema(deploymentServiceMethods.ByName("ListOrganizations")),
+		connect.WithHandlerOptions(opts...),
+	)
+	deploymentServiceListMembersHandler := connect.NewUnaryHandler(
+		DeploymentServiceListMembersProcedure,
+		svc.ListMembers,
+		connect.WithSchema(deploymentServiceMethods.ByName("ListMembers")),
+		connect.WithHandlerOptions(opts...),
+	)
+	deploymentServiceUpdateMemberRoleHandler := connect.NewUnaryHandler(
+		DeploymentServiceUpdateMemberRoleProcedure,
+		svc.UpdateMemberRole,
+		connect.WithSchema(deploymentServiceMethods.ByName("UpdateMemberRole")),
+		connect.WithHandlerOptions(opts...),
+	)
+	deploymentServiceRemoveMemberHandler := connect.NewUnaryHandler(
+		DeploymentServiceRemoveMemberProcedure,
+		svc.RemoveMember,
+		connect.WithSchema(deploymentServiceMethods.ByName("RemoveMember")),
+		connect.WithHandlerOptions(opts...),
+	)
+	deploymentServiceInviteMemberHandler := connect.NewUnaryHandler(
+		DeploymentServiceInviteMemberProcedure,
+		svc.InviteMember,
+		connect.WithSchema(deploymentServiceMethods.ByName("InviteMember")),
+		connect.WithHandlerOptions(opts...),
+	)
+	deploymentServiceListInvitationsHandler := connect.NewUnaryHandler(
+		DeploymentServiceListInvitationsProcedure,
+		svc.ListInvitations,
+		connect.WithSchema(deploymentServiceMethods.ByName("ListInvitations")),
+		connect.WithHandlerOptions(opts...),
+	)
+	deploymentServiceAcceptInvitationHandler := connect.NewUnaryHandler(
+		DeploymentServiceAcceptInvitationProcedure,
+		svc.AcceptInvitation,
+		connect.WithSchema(deploymentServiceMethods.ByName("AcceptInvitation")),
+		connect.WithHandlerOptions(opts...),
+	)
+	deploymentServiceLinkIdentityHandler := connect.NewUnaryHandler(
+		DeploymentServiceLinkIdentityProcedure,
+		svc.LinkIdentity,
+		connect.WithSchema(deploymentServiceMethods.ByName("LinkIdentity")),
+		connect.WithHandlerOptions(opts...),
+	)
+	deploymentServiceUnlinkIdentityHandler := connect.NewUnaryHandler(
+		DeploymentServiceUnlinkIdentityProcedure,
+		svc.UnlinkIdentity,
+		connect.WithSchema(deploymentServiceMethods.ByName("UnlinkIdentity")),
+		connect.WithHandlerOptions(opts...),
+	)
+	deploymentServiceListAuditEventsHandler := connect.NewUnaryHandler(
+		DeploymentServiceListAuditEventsProcedure,
+		svc.ListAuditEvents,
+		connect.WithSchema(deploymentServiceMethods.ByName("ListAuditEvents")),
+		connect.WithHandlerOptions(opts...),
+	)
 	return "/jennah.v1.DeploymentService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
 		case DeploymentServiceSubmitJobProcedure:
@@ -286,6 +666,34 @@ func NewDeploymentServiceHandler(svc DeploymentServiceHandler, opts ...connect.H
 			deploymentServiceListNotificationsHandler.ServeHTTP(w, r)
 		case DeploymentServiceAckNotificationProcedure:
 			deploymentServiceAckNotificationHandler.ServeHTTP(w, r)
+		case DeploymentServiceCreateApiKeyProcedure:
+			deploymentServiceCreateApiKeyHandler.ServeHTTP(w, r)
+		case DeploymentServiceListApiKeysProcedure:
+			deploymentServiceListApiKeysHandler.ServeHTTP(w, r)
+		case DeploymentServiceRevokeApiKeyProcedure:
+			deploymentServiceRevokeApiKeyHandler.ServeHTTP(w, r)
+		case DeploymentServiceCreateOrganizationProcedure:
+			deploymentServiceCreateOrganizationHandler.ServeHTTP(w, r)
+		case DeploymentServiceListOrganizationsProcedure:
+			deploymentServiceListOrganizationsHandler.ServeHTTP(w, r)
+		case DeploymentServiceListMembersProcedure:
+			deploymentServiceListMembersHandler.ServeHTTP(w, r)
+		case DeploymentServiceUpdateMemberRoleProcedure:
+			deploymentServiceUpdateMemberRoleHandler.ServeHTTP(w, r)
+		case DeploymentServiceRemoveMemberProcedure:
+			deploymentServiceRemoveMemberHandler.ServeHTTP(w, r)
+		case DeploymentServiceInviteMemberProcedure:
+			deploymentServiceInviteMemberHandler.ServeHTTP(w, r)
+		case DeploymentServiceListInvitationsProcedure:
+			deploymentServiceListInvitationsHandler.ServeHTTP(w, r)
+		case DeploymentServiceAcceptInvitationProcedure:
+			deploymentServiceAcceptInvitationHandler.ServeHTTP(w, r)
+		case DeploymentServiceLinkIdentityProcedure:
+			deploymentServiceLinkIdentityHandler.ServeHTTP(w, r)
+		case DeploymentServiceUnlinkIdentityProcedure:
+			deploymentServiceUnlinkIdentityHandler.ServeHTTP(w, r)
+		case DeploymentServiceListAuditEventsProcedure:
+			deploymentServiceListAuditEventsHandler.ServeHTTP(w, r)
 		default:
 			http.NotFound(w, r)
 		}
@@ -326,3 +734,269 @@ func (UnimplementedDeploymentServiceHandler) ListNotifications(context.Context,
 func (UnimplementedDeploymentServiceHandler) AckNotification(context.Context, *connect.Request[proto.AckNotificationRequest]) (*connect.Response[proto.AckNotificationResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("jennah.v1.DeploymentService.AckNotification is not implemented"))
 }
+
+func (UnimplementedDeploymentServiceHandler) CreateApiKey(context.Context, *connect.Request[proto.CreateApiKeyRequest]) (*connect.Response[proto.CreateApiKeyResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("jennah.v1.DeploymentService.CreateApiKey is not implemented"))
+}
+
+func (UnimplementedDeploymentServiceHandler) ListApiKeys(context.Context, *connect.Request[proto.ListApiKeysRequest]) (*connect.Response[proto.ListApiKeysResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("jennah.v1.DeploymentService.ListApiKeys is not implemented"))
+}
+
+func (UnimplementedDeploymentServiceHandler) RevokeApiKey(context.Context, *connect.Request[proto.RevokeApiKeyRequest]) (*connect.Response[proto.RevokeApiKeyResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("jennah.v1.DeploymentService.RevokeApiKey is not implemented"))
+}
+
+func (UnimplementedDeploymentServiceHandler) CreateOrganization(context.Context, *connect.Request[proto.CreateOrganizationRequest]) (*connect.Response[proto.CreateOrganizationResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("jennah.v1.DeploymentService.CreateOrganization is not implemented"))
+}
+
+func (UnimplementedDeploymentServiceHandler) ListOrganizations(context.Context, *connect.Request[proto.ListOrganizationsRequest]) (*connect.Response[proto.ListOrganizationsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("jennah.v1.DeploymentService.ListOrganizations is not implemented"))
+}
+
+func (UnimplementedDeploymentServiceHandler) ListMembers(context.Context, *connect.Request[proto.ListMembersRequest]) (*connect.Response[proto.ListMembersResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("jennah.v1.DeploymentService.ListMembers is not implemented"))
+}
+
+func (UnimplementedDeploymentServiceHandler) UpdateMemberRole(context.Context, *connect.Request[proto.UpdateMemberRoleRequest]) (*connect.Response[proto.UpdateMemberRoleResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("jennah.v1.DeploymentService.UpdateMemberRole is not implemented"))
+}
+
+func (UnimplementedDeploymentServiceHandler) RemoveMember(context.Context, *connect.Request[proto.RemoveMemberRequest]) (*connect.Response[proto.RemoveMemberResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("jennah.v1.DeploymentService.RemoveMember is not implemented"))
+}
+
+func (UnimplementedDeploymentServiceHandler) InviteMember(context.Context, *connect.Request[proto.InviteMemberRequest]) (*connect.Response[proto.InviteMemberResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("jennah.v1.DeploymentService.InviteMember is not implemented"))
+}
+
+func (UnimplementedDeploymentServiceHandler) ListInvitations(context.Context, *connect.Request[proto.ListInvitationsRequest]) (*connect.Response[proto.ListInvitationsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("jennah.v1.DeploymentService.ListInvitations is not implemented"))
+}
+
+func (UnimplementedDeploymentServiceHandler) AcceptInvitation(context.Context, *connect.Request[proto.AcceptInvitationRequest]) (*connect.Response[proto.AcceptInvitationResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("jennah.v1.DeploymentService.AcceptInvitation is not implemented"))
+}
+
+func (UnimplementedDeploymentServiceHandler) LinkIdentity(context.Context, *connect.Request[proto.LinkIdentityRequest]) (*connect.Response[proto.LinkIdentityResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("jennah.v1.DeploymentService.LinkIdentity is not implemented"))
+}
+
+func (UnimplementedDeploymentServiceHandler) UnlinkIdentity(context.Context, *connect.Request[proto.UnlinkIdentityRequest]) (*connect.Response[proto.UnlinkIdentityResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("jennah.v1.DeploymentService.UnlinkIdentity is not implemented"))
+}
+
+func (UnimplementedDeploymentServiceHandler) ListAuditEvents(context.Context, *connect.Request[proto.ListAuditEventsRequest]) (*connect.Response[proto.ListAuditEventsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("jennah.v1.DeploymentService.ListAuditEvents is not implemented"))
+}
+
+// AdminServiceClient is a client for the jennah.v1.AdminService service.
+type AdminServiceClient interface {
+	// Diff cloud resources against the Jobs table: report resources no job
+	// points at (orphans) and active jobs whose resource is gone (missing),
+	// optionally cleaning up what it finds.
+	ReconcileResources(context.Context, *connect.Request[proto.ReconcileResourcesRequest]) (*connect.Response[proto.ReconcileResourcesResponse], error)
+	// Replay stored jobs through candidate routing rules and report which
+	// jobs would move between services. Nothing is submitted.
+	SimulateRouting(context.Context, *connect.Request[proto.SimulateRoutingRequest]) (*connect.Response[proto.SimulateRoutingResponse], error)
+}
+
+// NewAdminServiceClient constructs a client for the jennah.v1.AdminService service. By default, it
+// uses the Connect protocol with the binary Protobuf Codec, asks for gzipped responses, and sends
+// uncompressed requests. To use the gRPC or gRPC-Web protocols, supply the connect.WithGRPC() or
+// connect.WithGRPCWeb() options.
+//
+// The URL supplied here should be the base URL for the Connect or gRPC server (for example,
+// http://api.acme.com or https://acme.com/grpc).
+func NewAdminServiceClient(httpClient connect.HTTPClient, baseURL string, opts ...connect.ClientOption) AdminServiceClient {
+	baseURL = strings.TrimRight(baseURL, "/")
+	adminServiceMethods := proto.File_proto_jennah_proto.Services().ByName("AdminService").Methods()
+	return &adminServiceClient{
+		reconcileResources: connect.NewClient[proto.ReconcileResourcesRequest, proto.ReconcileResourcesResponse](
+			httpClient,
+			baseURL+AdminServiceReconcileResourcesProcedure,
+			connect.WithSchema(adminServiceMethods.ByName("ReconcileResources")),
+			connect.WithClientOptions(opts...),
+		),
+		simulateRouting: connect.NewClient[proto.SimulateRoutingRequest, proto.SimulateRoutingResponse](
+			httpClient,
+			baseURL+AdminServiceSimulateRoutingProcedure,
+			connect.WithSchema(adminServiceMethods.ByName("SimulateRouting")),
+			connect.WithClientOptions(opts...),
+		),
+	}
+}
+
+// adminServiceClient implements AdminServiceClient.
+type adminServiceClient struct {
+	reconcileResources *connect.Client[proto.ReconcileResourcesRequest, proto.ReconcileResourcesResponse]
+	simulateRouting    *connect.Client[proto.SimulateRoutingRequest, proto.SimulateRoutingResponse]
+}
+
+// ReconcileResources calls jennah.v1.AdminService.ReconcileResources.
+func (c *adminServiceClient) ReconcileResources(ctx context.Context, req *connect.Request[proto.ReconcileResourcesRequest]) (*connect.Response[proto.ReconcileResourcesResponse], error) {
+	return c.reconcileResources.CallUnary(ctx, req)
+}
+
+// SimulateRouting calls jennah.v1.AdminService.SimulateRouting.
+func (c *adminServiceClient) SimulateRouting(ctx context.Context, req *connect.Request[proto.SimulateRoutingRequest]) (*connect.Response[proto.SimulateRoutingResponse], error) {
+	return c.simulateRouting.CallUnary(ctx, req)
+}
+
+// AdminServiceHandler is an implementation of the jennah.v1.AdminService service.
+type AdminServiceHandler interface {
+	// Diff cloud resources against the Jobs table: report resources no job
+	// points at (orphans) and active jobs whose resource is gone (missing),
+	// optionally cleaning up what it finds.
+	ReconcileResources(context.Context, *connect.Request[proto.ReconcileResourcesRequest]) (*connect.Response[proto.ReconcileResourcesResponse], error)
+	// Replay stored jobs through candidate routing rules and report which
+	// jobs would move between services. Nothing is submitted.
+	SimulateRouting(context.Context, *connect.Request[proto.SimulateRoutingRequest]) (*connect.Response[proto.SimulateRoutingResponse], error)
+}
+
+// NewAdminServiceHandler builds an HTTP handler from the service implementation. It returns the
+// path on which to mount the handler and the handler itself.
+//
+// By default, handlers support the Connect, gRPC, and gRPC-Web protocols with the binary Protobuf
+// and JSON codecs. They also support gzip compression.
+func NewAdminServiceHandler(svc AdminServiceHandler, opts ...connect.HandlerOption) (string, http.Handler) {
+	adminServiceMethods := proto.File_proto_jennah_proto.Services().ByName("AdminService").Methods()
+	adminServiceReconcileResourcesHandler := connect.NewUnaryHandler(
+		AdminServiceReconcileResourcesProcedure,
+		svc.ReconcileResources,
+		connect.WithSchema(adminServiceMethods.ByName("ReconcileResources")),
+		connect.WithHandlerOptions(opts...),
+	)
+	adminServiceSimulateRoutingHandler := connect.NewUnaryHandler(
+		AdminServiceSimulateRoutingProcedure,
+		svc.SimulateRouting,
+		connect.WithSchema(adminServiceMethods.ByName("SimulateRouting")),
+		connect.WithHandlerOptions(opts...),
+	)
+	return "/jennah.v1.AdminService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case AdminServiceReconcileResourcesProcedure:
+			adminServiceReconcileResourcesHandler.ServeHTTP(w, r)
+		case AdminServiceSimulateRoutingProcedure:
+			adminServiceSimulateRoutingHandler.ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+// UnimplementedAdminServiceHandler returns CodeUnimplemented from all methods.
+type UnimplementedAdminServiceHandler struct{}
+
+func (UnimplementedAdminServiceHandler) ReconcileResources(context.Context, *connect.Request[proto.ReconcileResourcesRequest]) (*connect.Response[proto.ReconcileResourcesResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("jennah.v1.AdminService.ReconcileResources is not implemented"))
+}
+
+func (UnimplementedAdminServiceHandler) SimulateRouting(context.Context, *connect.Request[proto.SimulateRoutingRequest]) (*connect.Response[proto.SimulateRoutingResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("jennah.v1.AdminService.SimulateRouting is not implemented"))
+}
+
+// AuthServiceClient is a client for the jennah.v1.AuthService service.
+type AuthServiceClient interface {
+	// Verify a Google ID token or a GitHub access token and issue a gateway
+	// session token for that identity.
+	CreateSession(context.Context, *connect.Request[proto.CreateSessionRequest]) (*connect.Response[proto.CreateSessionResponse], error)
+	// Issue a new session token for a current one, up to the session's
+	// maximum age.
+	RefreshSession(context.Context, *connect.Request[proto.RefreshSessionRequest]) (*connect.Response[proto.RefreshSessionResponse], error)
+}
+
+// NewAuthServiceClient constructs a client for the jennah.v1.AuthService service. By default, it
+// uses the Connect protocol with the binary Protobuf Codec, asks for gzipped responses, and sends
+// uncompressed requests. To use the gRPC or gRPC-Web protocols, supply the connect.WithGRPC() or
+// connect.WithGRPCWeb() options.
+//
+// The URL supplied here should be the base URL for the Connect or gRPC server (for example,
+// http://api.acme.com or https://acme.com/grpc).
+func NewAuthServiceClient(httpClient connect.HTTPClient, baseURL string, opts ...connect.ClientOption) AuthServiceClient {
+	baseURL = strings.TrimRight(baseURL, "/")
+	authServiceMethods := proto.File_proto_jennah_proto.Services().ByName("AuthService").Methods()
+	return &authServiceClient{
+		createSession: connect.NewClient[proto.CreateSessionRequest, proto.CreateSessionResponse](
+			httpClient,
+			baseURL+AuthServiceCreateSessionProcedure,
+			connect.WithSchema(authServiceMethods.ByName("CreateSession")),
+			connect.WithClientOptions(opts...),
+		),
+		refreshSession: connect.NewClient[proto.RefreshSessionRequest, proto.RefreshSessionResponse](
+			httpClient,
+			baseURL+AuthServiceRefreshSessionProcedure,
+			connect.WithSchema(authServiceMethods.ByName("RefreshSession")),
+			connect.WithClientOptions(opts...),
+		),
+	}
+}
+
+// authServiceClient implements AuthServiceClient.
+type authServiceClient struct {
+	createSession  *connect.Client[proto.CreateSessionRequest, proto.CreateSessionResponse]
+	refreshSession *connect.Client[proto.RefreshSessionRequest, proto.RefreshSessionResponse]
+}
+
+// CreateSession calls jennah.v1.AuthService.CreateSession.
+func (c *authServiceClient) CreateSession(ctx context.Context, req *connect.Request[proto.CreateSessionRequest]) (*connect.Response[proto.CreateSessionResponse], error) {
+	return c.createSession.CallUnary(ctx, req)
+}
+
+// RefreshSession calls jennah.v1.AuthService.RefreshSession.
+func (c *authServiceClient) RefreshSession(ctx context.Context, req *connect.Request[proto.RefreshSessionRequest]) (*connect.Response[proto.RefreshSessionResponse], error) {
+	return c.refreshSession.CallUnary(ctx, req)
+}
+
+// AuthServiceHandler is an implementation of the jennah.v1.AuthService service.
+type AuthServiceHandler interface {
+	// Verify a Google ID token or a GitHub access token and issue a gateway
+	// session token for that identity.
+	CreateSession(context.Context, *connect.Request[proto.CreateSessionRequest]) (*connect.Response[proto.CreateSessionResponse], error)
+	// Issue a new session token for a current one, up to the session's
+	// maximum age.
+	RefreshSession(context.Context, *connect.Request[proto.RefreshSessionRequest]) (*connect.Response[proto.RefreshSessionResponse], error)
+}
+
+// NewAuthServiceHandler builds an HTTP handler from the service implementation. It returns the path
+// on which to mount the handler and the handler itself.
+//
+// By default, handlers support the Connect, gRPC, and gRPC-Web protocols with the binary Protobuf
+// and JSON codecs. They also support gzip compression.
+func NewAuthServiceHandler(svc AuthServiceHandler, opts ...connect.HandlerOption) (string, http.Handler) {
+	authServiceMethods := proto.File_proto_jennah_proto.Services().ByName("AuthService").Methods()
+	authServiceCreateSessionHandler := connect.NewUnaryHandler(
+		AuthServiceCreateSessionProcedure,
+		svc.CreateSession,
+		connect.WithSchema(authServiceMethods.ByName("CreateSession")),
+		connect.WithHandlerOptions(opts...),
+	)
+	authServiceRefreshSessionHandler := connect.NewUnaryHandler(
+		AuthServiceRefreshSessionProcedure,
+		svc.RefreshSession,
+		connect.WithSchema(authServiceMethods.ByName("RefreshSession")),
+		connect.WithHandlerOptions(opts...),
+	)
+	return "/jennah.v1.AuthService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case AuthServiceCreateSessionProcedure:
+			authServiceCreateSessionHandler.ServeHTTP(w, r)
+		case AuthServiceRefreshSessionProcedure:
+			authServiceRefreshSessionHandler.ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+// UnimplementedAuthServiceHandler returns CodeUnimplemented from all methods.
+type UnimplementedAuthServiceHandler struct{}
+
+func (UnimplementedAuthServiceHandler) CreateSession(context.Context, *connect.Request[proto.CreateSessionRequest]) (*connect.Response[proto.CreateSessionResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("jennah.v1.AuthService.CreateSession is not implemented"))
+}
+
+func (UnimplementedAuthServiceHandler) RefreshSession(context.Context, *connect.Request[proto.RefreshSessionRequest]) (*connect.Response[proto.RefreshSessionResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("jennah.v1.AuthService.RefreshSession is not implemented"))
+}
@@ -0,0 +1,224 @@
+// Package audit records calls to mutating RPCs in an append-only log.
+package audit
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/alphauslabs/jennah/internal/database"
+)
+
+// Actor types.
+const (
+	ActorUser      = "user"
+	ActorAPIKey    = "api_key"
+	ActorAnonymous = "anonymous"
+)
+
+// ResultOK is the result code of calls that succeeded.
+const ResultOK = "ok"
+
+// writeTimeout bounds how long recording an event may delay a response.
+const writeTimeout = 5 * time.Second
+
+// Actor is who made a call.
+type Actor struct {
+	// Type is ActorUser, ActorAPIKey or ActorAnonymous.
+	Type string
+	// ID is "<provider>/<user ID>" for users and the key ID for API keys.
+	ID    string
+	Email string
+}
+
+// Store appends events to the audit log.
+type Store interface {
+	InsertAuditEvent(ctx context.Context, e *database.AuditEvent) error
+}
+
+// Identify returns who made a call from its headers. It is not an
+// authentication check: a call with bad credentials is still recorded with
+// the actor it claimed to be, and its result code shows it was rejected.
+type Identify func(header http.Header) Actor
+
+// record collects what handlers learn about a call while it runs.
+type record struct {
+	mu         sync.Mutex
+	tenantID   string
+	actor      *Actor
+	targetType string
+	targetID   string
+}
+
+type recordKey struct{}
+
+func recordFrom(ctx context.Context) *record {
+	r, _ := ctx.Value(recordKey{}).(*record)
+	return r
+}
+
+// SetTenant records the tenant a call acts on. It does nothing outside an
+// audited call.
+func SetTenant(ctx context.Context, tenantID string) {
+	if r := recordFrom(ctx); r != nil {
+		r.mu.Lock()
+		r.tenantID = tenantID
+		r.mu.Unlock()
+	}
+}
+
+// SetActor records who made a call, for calls such as sign-ins whose actor
+// is not in their headers.
+func SetActor(ctx context.Context, actor Actor) {
+	if r := recordFrom(ctx); r != nil {
+		r.mu.Lock()
+		r.actor = &actor
+		r.mu.Unlock()
+	}
+}
+
+// SetTarget records what a call acts on when the request and response do
+// not show it.
+func SetTarget(ctx context.Context, targetType, targetID string) {
+	if r := recordFrom(ctx); r != nil {
+		r.mu.Lock()
+		r.targetType, r.targetID = targetType, targetID
+		r.mu.Unlock()
+	}
+}
+
+// Audited reports whether calls to a procedure are recorded: everything
+// except reads, which are named Get* or List*, and a few calls that change
+// nothing.
+func Audited(procedure string) bool {
+	method := procedure[strings.LastIndex(procedure, "/")+1:]
+	switch {
+	case strings.HasPrefix(method, "Get"), strings.HasPrefix(method, "List"):
+		return false
+	case method == "SimulateRouting", method == "RefreshSession":
+		return false
+	}
+	return true
+}
+
+// NewInterceptor records every audited unary call a server handles, once
+// it has finished. Failing to record an event is logged but does not fail
+// the call, which has already taken effect.
+func NewInterceptor(store Store, identify Identify, trustedProxies int) connect.UnaryInterceptorFunc {
+	return func(next connect.UnaryFunc) connect.UnaryFunc {
+		return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+			if req.Spec().IsClient || !Audited(req.Spec().Procedure) {
+				return next(ctx, req)
+			}
+			var summary string
+			msg, _ := req.Any().(proto.Message)
+			if msg != nil {
+				summary = Summarize(msg)
+			}
+			r := &record{}
+			res, err := next(context.WithValue(ctx, recordKey{}, r), req)
+
+			event := newEvent(req, r, identify, trustedProxies)
+			event.RequestJson = summary
+			if msg != nil {
+				setTarget(event, msg, res)
+			}
+			if err != nil {
+				event.ResultCode = connect.CodeOf(err).String()
+				message := err.Error()
+				var connectErr *connect.Error
+				if errors.As(err, &connectErr) {
+					message = connectErr.Message()
+				}
+				event.ErrorMessage = &message
+			}
+
+			writeCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), writeTimeout)
+			defer cancel()
+			if werr := store.InsertAuditEvent(writeCtx, event); werr != nil {
+				log.Printf("Failed to record audit event for %s by %s: %v", event.Procedure, event.ActorId, werr)
+			}
+			return res, err
+		}
+	}
+}
+
+// newEvent starts the event of a finished call from what its handler
+// recorded and its headers.
+func newEvent(req connect.AnyRequest, r *record, identify Identify, trustedProxies int) *database.AuditEvent {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	actor := Actor{Type: ActorAnonymous}
+	if r.actor != nil {
+		actor = *r.actor
+	} else if identify != nil {
+		actor = identify(req.Header())
+	}
+	event := &database.AuditEvent{
+		TenantId:   r.tenantID,
+		EventId:    uuid.New().String(),
+		Procedure:  req.Spec().Procedure,
+		ActorType:  actor.Type,
+		ActorId:    actor.ID,
+		ActorEmail: actor.Email,
+		ResultCode: ResultOK,
+		ClientIp:   ClientIP(req.Header(), req.Peer().Addr, trustedProxies),
+		UserAgent:  truncate(req.Header().Get("User-Agent"), 512),
+	}
+	if r.targetID != "" {
+		event.TargetType, event.TargetId = &r.targetType, &r.targetID
+	}
+	return event
+}
+
+// setTarget fills in the target of an event its handler did not set from
+// the request, or else the response.
+func setTarget(event *database.AuditEvent, msg proto.Message, res connect.AnyResponse) {
+	if event.TargetId != nil {
+		return
+	}
+	targetType, targetID := FindTarget(msg)
+	if targetID == "" && res != nil {
+		if resMsg, ok := res.Any().(proto.Message); ok {
+			targetType, targetID = FindTarget(resMsg)
+		}
+	}
+	if targetID != "" {
+		event.TargetType, event.TargetId = &targetType, &targetID
+	}
+}
+
+// ClientIP returns the address a call came from. trustedProxies is how many
+// X-Forwarded-For entries the proxies in front of the gateway append (2 for
+// a Google Cloud HTTPS load balancer, which appends the client's address and
+// its own); the client is the first of those, as anything before it was sent
+// by the client. Without trusted proxies, or with fewer entries than they
+// append, it is the peer address without its port.
+func ClientIP(header http.Header, peerAddr string, trustedProxies int) string {
+	if trustedProxies > 0 {
+		var hops []string
+		for _, forwarded := range header.Values("X-Forwarded-For") {
+			for _, hop := range strings.Split(forwarded, ",") {
+				if hop = strings.TrimSpace(hop); hop != "" {
+					hops = append(hops, hop)
+				}
+			}
+		}
+		if len(hops) >= trustedProxies {
+			return truncate(hops[len(hops)-trustedProxies], 64)
+		}
+	}
+	if host, _, err := net.SplitHostPort(peerAddr); err == nil {
+		return host
+	}
+	return truncate(peerAddr, 64)
+}
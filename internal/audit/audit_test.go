@@ -0,0 +1,145 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"connectrpc.com/connect"
+
+	jennahv1 "github.com/alphauslabs/jennah/gen/proto"
+	"github.com/alphauslabs/jennah/gen/proto/jennahv1connect"
+	"github.com/alphauslabs/jennah/internal/database"
+)
+
+type memoryStore struct {
+	mu     sync.Mutex
+	events []*database.AuditEvent
+}
+
+func (s *memoryStore) InsertAuditEvent(_ context.Context, e *database.AuditEvent) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.events = append(s.events, e)
+	return nil
+}
+
+type fakeDeployments struct {
+	jennahv1connect.UnimplementedDeploymentServiceHandler
+}
+
+func (fakeDeployments) SubmitJob(ctx context.Context, req *connect.Request[jennahv1.SubmitJobRequest]) (*connect.Response[jennahv1.SubmitJobResponse], error) {
+	SetTenant(ctx, "tenant-a")
+	return connect.NewResponse(&jennahv1.SubmitJobResponse{JobId: "job-1", Status: "PENDING"}), nil
+}
+
+func (fakeDeployments) CancelJob(ctx context.Context, req *connect.Request[jennahv1.CancelJobRequest]) (*connect.Response[jennahv1.CancelJobResponse], error) {
+	return nil, connect.NewError(connect.CodePermissionDenied, errors.New("role \"viewer\" does not allow \"cancel\" calls"))
+}
+
+func (fakeDeployments) GetJob(ctx context.Context, req *connect.Request[jennahv1.GetJobRequest]) (*connect.Response[jennahv1.GetJobResponse], error) {
+	return connect.NewResponse(&jennahv1.GetJobResponse{}), nil
+}
+
+func newTestClient(t *testing.T, store Store) jennahv1connect.DeploymentServiceClient {
+	t.Helper()
+	identify := func(header http.Header) Actor {
+		if header.Get("Authorization") == "" {
+			return Actor{Type: ActorAnonymous}
+		}
+		return Actor{Type: ActorUser, ID: "github/42", Email: "dev@example.com"}
+	}
+	path, handler := jennahv1connect.NewDeploymentServiceHandler(fakeDeployments{},
+		connect.WithInterceptors(NewInterceptor(store, identify, 1)))
+	mux := http.NewServeMux()
+	mux.Handle(path, handler)
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return jennahv1connect.NewDeploymentServiceClient(srv.Client(), srv.URL)
+}
+
+func TestInterceptorRecordsMutatingCalls(t *testing.T) {
+	store := &memoryStore{}
+	client := newTestClient(t, store)
+
+	req := connect.NewRequest(&jennahv1.SubmitJobRequest{
+		ImageUri: "gcr.io/p/app",
+		EnvVars:  map[string]string{"DB_PASSWORD": "hunter2"},
+	})
+	req.Header().Set("Authorization", "Bearer session")
+	// The client forged the first entry; the load balancer appended the second.
+	req.Header().Set("X-Forwarded-For", "198.51.100.66, 203.0.113.7")
+	req.Header().Set("User-Agent", "jennah-cli/1.0")
+	if _, err := client.SubmitJob(context.Background(), req); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.GetJob(context.Background(), connect.NewRequest(&jennahv1.GetJobRequest{JobId: "job-1"})); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.CancelJob(context.Background(), connect.NewRequest(&jennahv1.CancelJobRequest{JobId: "job-1"})); err == nil {
+		t.Fatal("CancelJob succeeded, want permission denied")
+	}
+
+	if len(store.events) != 2 {
+		t.Fatalf("recorded %d events, want 2 (GetJob is not audited)", len(store.events))
+	}
+	submit := store.events[0]
+	if submit.Procedure != jennahv1connect.DeploymentServiceSubmitJobProcedure ||
+		submit.TenantId != "tenant-a" || submit.ActorId != "github/42" || submit.ResultCode != ResultOK {
+		t.Errorf("submit event = %+v", submit)
+	}
+	if submit.TargetId == nil || *submit.TargetType != "job" || *submit.TargetId != "job-1" {
+		t.Errorf("submit target = %v/%v, want job/job-1 from the response", submit.TargetType, submit.TargetId)
+	}
+	if strings.Contains(submit.RequestJson, "hunter2") || !strings.Contains(submit.RequestJson, "DB_PASSWORD") {
+		t.Errorf("request summary %s leaks the env var value or drops its name", submit.RequestJson)
+	}
+	if submit.ClientIp != "203.0.113.7" || submit.UserAgent != "jennah-cli/1.0" {
+		t.Errorf("client = %q %q", submit.ClientIp, submit.UserAgent)
+	}
+
+	cancel := store.events[1]
+	if cancel.ResultCode != "permission_denied" || cancel.ErrorMessage == nil || !strings.Contains(*cancel.ErrorMessage, "viewer") {
+		t.Errorf("cancel event result = %q %v", cancel.ResultCode, cancel.ErrorMessage)
+	}
+	if cancel.ActorType != ActorAnonymous || cancel.TenantId != "" {
+		t.Errorf("cancel event actor = %q tenant = %q", cancel.ActorType, cancel.TenantId)
+	}
+	if cancel.TargetId == nil || *cancel.TargetId != "job-1" {
+		t.Errorf("cancel target = %v, want job-1 from the request", cancel.TargetId)
+	}
+	if cancel.ClientIp != "127.0.0.1" {
+		t.Errorf("cancel client IP = %q, want the peer address", cancel.ClientIp)
+	}
+}
+
+func TestAudited(t *testing.T) {
+	for procedure, want := range map[string]bool{
+		jennahv1connect.DeploymentServiceSubmitJobProcedure:        true,
+		jennahv1connect.DeploymentServiceDeleteJobProcedure:        true,
+		jennahv1connect.DeploymentServiceListJobsProcedure:         false,
+		jennahv1connect.DeploymentServiceGetCurrentTenantProcedure: false,
+		jennahv1connect.DeploymentServiceListAuditEventsProcedure:  false,
+		jennahv1connect.AuthServiceCreateSessionProcedure:          true,
+		jennahv1connect.AuthServiceRefreshSessionProcedure:         false,
+		jennahv1connect.AdminServiceReconcileResourcesProcedure:    true,
+		jennahv1connect.AdminServiceSimulateRoutingProcedure:       false,
+	} {
+		if got := Audited(procedure); got != want {
+			t.Errorf("Audited(%s) = %v, want %v", procedure, got, want)
+		}
+	}
+}
+
+func TestSettersOutsideAuditedCall(t *testing.T) {
+	// Handlers call these for every request; they must not panic when the
+	// call is not audited.
+	ctx := context.Background()
+	SetTenant(ctx, "tenant-a")
+	SetActor(ctx, Actor{Type: ActorUser})
+	SetTarget(ctx, "job", "job-1")
+}
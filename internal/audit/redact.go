@@ -0,0 +1,181 @@
+package audit
+
+import (
+	"fmt"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Redacted replaces secret values in request summaries.
+const Redacted = "[REDACTED]"
+
+const (
+	// maxValueLen is how much of a long string, e.g. a script, a summary
+	// keeps.
+	maxValueLen = 256
+	// maxSummaryLen caps a whole summary; longer ones are replaced.
+	maxSummaryLen = 64 << 10
+)
+
+// secretFields are redacted wherever they appear in a request.
+var secretFields = map[protoreflect.Name]bool{
+	"token":         true,
+	"session_token": true,
+	"key":           true,
+	"secret":        true,
+	"password":      true,
+	"client_secret": true,
+}
+
+// secretMaps keep their keys but have their values redacted, since env vars
+// routinely carry credentials.
+var secretMaps = map[protoreflect.Name]bool{
+	"env_vars": true,
+}
+
+// targetFields name the fields that identify what a call acts on, with the
+// kind of resource each identifies, in order of preference.
+var targetFields = []struct {
+	field protoreflect.Name
+	kind  string
+	// prefix is a field whose value, when set, is prepended to the ID.
+	prefix protoreflect.Name
+}{
+	{field: "job_id", kind: "job"},
+	{field: "key_id", kind: "api_key"},
+	{field: "invitation_id", kind: "invitation"},
+	{field: "notification_id", kind: "notification"},
+	{field: "org_id", kind: "organization"},
+	{field: "oauth_user_id", kind: "member", prefix: "oauth_provider"},
+	{field: "user_id", kind: "identity", prefix: "provider"},
+}
+
+// Summarize returns msg as JSON with secrets redacted and long strings
+// shortened.
+func Summarize(msg proto.Message) string {
+	m := proto.Clone(msg)
+	redact(m.ProtoReflect())
+	b, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(m)
+	if err != nil {
+		return fmt.Sprintf(`{"error":%q}`, err.Error())
+	}
+	if len(b) > maxSummaryLen {
+		return fmt.Sprintf(`{"truncated":true,"size":%d}`, len(b))
+	}
+	return string(b)
+}
+
+func redact(m protoreflect.Message) {
+	var fields []protoreflect.FieldDescriptor
+	m.Range(func(fd protoreflect.FieldDescriptor, _ protoreflect.Value) bool {
+		fields = append(fields, fd)
+		return true
+	})
+	for _, fd := range fields {
+		switch {
+		case fd.IsMap():
+			redactMap(fd, m.Mutable(fd).Map())
+		case fd.IsList():
+			list := m.Mutable(fd).List()
+			for i := 0; i < list.Len(); i++ {
+				switch {
+				case fd.Message() != nil:
+					redact(list.Get(i).Message())
+				case fd.Kind() == protoreflect.StringKind:
+					list.Set(i, protoreflect.ValueOfString(shorten(list.Get(i).String())))
+				}
+			}
+		case fd.Message() != nil:
+			redact(m.Mutable(fd).Message())
+		case fd.Kind() == protoreflect.StringKind:
+			value := shorten(m.Get(fd).String())
+			if secretFields[fd.Name()] {
+				value = Redacted
+			}
+			m.Set(fd, protoreflect.ValueOfString(value))
+		}
+	}
+}
+
+func redactMap(fd protoreflect.FieldDescriptor, values protoreflect.Map) {
+	var keys []protoreflect.MapKey
+	values.Range(func(k protoreflect.MapKey, _ protoreflect.Value) bool {
+		keys = append(keys, k)
+		return true
+	})
+	for _, k := range keys {
+		switch {
+		case fd.MapValue().Message() != nil:
+			redact(values.Get(k).Message())
+		case fd.MapValue().Kind() == protoreflect.StringKind:
+			value := shorten(values.Get(k).String())
+			if secretMaps[fd.Name()] {
+				value = Redacted
+			}
+			values.Set(k, protoreflect.ValueOfString(value))
+		}
+	}
+}
+
+// FindTarget returns the kind and ID of the resource a message refers to,
+// looking at its own fields first and then one level into its message
+// fields, or empty strings when it names none.
+func FindTarget(msg proto.Message) (kind, id string) {
+	m := msg.ProtoReflect()
+	if kind, id = targetOf(m); id != "" {
+		return kind, id
+	}
+	fields := m.Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		if fd.Message() == nil || fd.IsList() || fd.IsMap() || !m.Has(fd) {
+			continue
+		}
+		if kind, id = targetOf(m.Get(fd).Message()); id != "" {
+			return kind, id
+		}
+	}
+	return "", ""
+}
+
+func targetOf(m protoreflect.Message) (kind, id string) {
+	fields := m.Descriptor().Fields()
+	for _, t := range targetFields {
+		fd := fields.ByName(t.field)
+		if fd == nil || fd.Kind() != protoreflect.StringKind || fd.IsList() {
+			continue
+		}
+		id = m.Get(fd).String()
+		if id == "" {
+			continue
+		}
+		if p := fields.ByName(t.prefix); p != nil && p.Kind() == protoreflect.StringKind {
+			if prefix := m.Get(p).String(); prefix != "" {
+				id = prefix + "/" + id
+			}
+		}
+		return t.kind, truncate(id, 320)
+	}
+	return "", ""
+}
+
+// shorten cuts strings longer than maxValueLen, noting their full length.
+func shorten(s string) string {
+	if len(s) <= maxValueLen {
+		return s
+	}
+	return fmt.Sprintf("%s...(%d bytes)", truncate(s, maxValueLen), len(s))
+}
+
+// truncate cuts s to at most n bytes without splitting a UTF-8 sequence.
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	for n > 0 && s[n]&0xC0 == 0x80 {
+		n--
+	}
+	return s[:n]
+}
@@ -0,0 +1,97 @@
+package audit
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	jennahv1 "github.com/alphauslabs/jennah/gen/proto"
+)
+
+func TestSummarizeRedactsSecrets(t *testing.T) {
+	req := &jennahv1.SubmitJobRequest{
+		ImageUri: "gcr.io/p/app",
+		EnvVars:  map[string]string{"API_TOKEN": "s3cret-env"},
+		Runnables: []*jennahv1.Runnable{{
+			Script:  strings.Repeat("x", 1000),
+			EnvVars: map[string]string{"STEP_KEY": "s3cret-step"},
+		}},
+	}
+	summary := Summarize(req)
+	for _, secret := range []string{"s3cret-env", "s3cret-step"} {
+		if strings.Contains(summary, secret) {
+			t.Errorf("summary %s contains %q", summary, secret)
+		}
+	}
+	for _, kept := range []string{"gcr.io/p/app", "API_TOKEN", "STEP_KEY", "(1000 bytes)"} {
+		if !strings.Contains(summary, kept) {
+			t.Errorf("summary %s lacks %q", summary, kept)
+		}
+	}
+	if req.EnvVars["API_TOKEN"] != "s3cret-env" {
+		t.Error("Summarize changed the request itself")
+	}
+
+	link := Summarize(&jennahv1.LinkIdentityRequest{
+		Current: &jennahv1.IdentityProof{Provider: "github", Token: "gho_abc"},
+		Other:   &jennahv1.IdentityProof{Provider: "google", Token: "eyJhbGci"},
+	})
+	if strings.Contains(link, "gho_abc") || strings.Contains(link, "eyJhbGci") || !strings.Contains(link, "google") {
+		t.Errorf("link summary = %s", link)
+	}
+	session := Summarize(&jennahv1.RefreshSessionRequest{SessionToken: "v1.abc"})
+	if strings.Contains(session, "v1.abc") {
+		t.Errorf("session summary = %s", session)
+	}
+}
+
+func TestFindTarget(t *testing.T) {
+	cases := map[string]struct {
+		kind, id string
+		got      func() (string, string)
+	}{
+		"request field": {"job", "job-1", func() (string, string) {
+			return FindTarget(&jennahv1.DeleteJobRequest{JobId: "job-1"})
+		}},
+		"nested response": {"api_key", "k1", func() (string, string) {
+			return FindTarget(&jennahv1.CreateApiKeyResponse{ApiKey: &jennahv1.ApiKey{KeyId: "k1"}, Key: "jnh_k1_secret"})
+		}},
+		"invitation before org": {"invitation", "inv-1", func() (string, string) {
+			return FindTarget(&jennahv1.InviteMemberResponse{Invitation: &jennahv1.Invitation{InvitationId: "inv-1", OrgId: "org-1"}})
+		}},
+		"member with provider": {"member", "github/42", func() (string, string) {
+			return FindTarget(&jennahv1.RemoveMemberRequest{OauthProvider: "github", OauthUserId: "42"})
+		}},
+		"none": {"", "", func() (string, string) {
+			return FindTarget(&jennahv1.CreateOrganizationRequest{Name: "Platform"})
+		}},
+	}
+	for name, c := range cases {
+		kind, id := c.got()
+		if kind != c.kind || id != c.id {
+			t.Errorf("%s: got %s/%s, want %s/%s", name, kind, id, c.kind, c.id)
+		}
+	}
+}
+
+func TestClientIP(t *testing.T) {
+	for _, c := range []struct {
+		name      string
+		forwarded []string
+		proxies   int
+		want      string
+	}{
+		{"peer address", nil, 1, "192.0.2.1"},
+		{"one proxy", []string{"198.51.100.2"}, 1, "198.51.100.2"},
+		{"forged leading entry", []string{"203.0.113.66, 198.51.100.2"}, 1, "198.51.100.2"},
+		{"forged entry before a load balancer", []string{"203.0.113.66, 198.51.100.2, 10.0.0.1"}, 2, "198.51.100.2"},
+		{"entries across headers", []string{"203.0.113.66", "198.51.100.2"}, 1, "198.51.100.2"},
+		{"fewer entries than proxies", []string{"203.0.113.66"}, 2, "192.0.2.1"},
+		{"no trusted proxies", []string{"203.0.113.66"}, 0, "192.0.2.1"},
+	} {
+		header := http.Header{"X-Forwarded-For": c.forwarded}
+		if got := ClientIP(header, "192.0.2.1:5000", c.proxies); got != c.want {
+			t.Errorf("%s: got %q, want %q", c.name, got, c.want)
+		}
+	}
+}
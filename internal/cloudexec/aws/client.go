@@ -176,6 +176,18 @@ func (p *AWSBatchProvider) ServiceType() string {
 	return "AWS_BATCH"
 }
 
+// Capabilities returns the AWS Batch capability descriptor.
+// Array jobs are capped at 10,000 child jobs by AWS.
+func (p *AWSBatchProvider) Capabilities() batchpkg.Capabilities {
+	return batchpkg.Capabilities{
+		MaxTaskCount:        10000,
+		SupportsGPU:         true,
+		SupportsSpot:        true,
+		SupportsMachineType: true,
+		CostRank:            20,
+	}
+}
+
 // Close cleans up AWS Batch client resources.
 func (p *AWSBatchProvider) Close() error {
 	// AWS SDK v2 clients don't require explicit closing
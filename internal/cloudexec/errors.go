@@ -0,0 +1,177 @@
+package batch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"regexp"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// capacityErrorMarkers are substrings of provider error messages that indicate
+// a regional stockout or an exhausted quota. Cloud APIs do not always surface
+// these as RESOURCE_EXHAUSTED, so the message is checked as well.
+var capacityErrorMarkers = []string{
+	"zone_resource_pool_exhausted",
+	"resource_pool_exhausted",
+	"quota_exceeded",
+	"exceeded quota",
+	"insufficient quota",
+	"does not have enough resources available",
+	"stockout",
+}
+
+// quotaExceededPattern matches Compute Engine quota errors such as
+// "Quota 'CPUS' exceeded. Limit: 24.0 in region us-central1."
+var quotaExceededPattern = regexp.MustCompile(`quota\b[^.]*\bexceeded`)
+
+// IsCapacityError reports whether err means the provider's region could not
+// satisfy the request right now, such as a machine type stockout or an
+// exhausted CPU quota. Such errors are worth retrying in another region;
+// any other error would fail the same way everywhere.
+func IsCapacityError(err error) bool {
+	if err == nil {
+		return false
+	}
+	if s, ok := status.FromError(err); ok && s.Code() == codes.ResourceExhausted {
+		return true
+	}
+	msg := strings.ToLower(err.Error())
+	if quotaExceededPattern.MatchString(msg) {
+		return true
+	}
+	for _, marker := range capacityErrorMarkers {
+		if strings.Contains(msg, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsNotFound reports whether err means the cloud resource does not exist.
+func IsNotFound(err error) bool {
+	if err == nil {
+		return false
+	}
+	var perr *ProviderError
+	if errors.As(err, &perr) {
+		return perr.Class == ErrorClassNotFound
+	}
+	s, ok := status.FromError(err)
+	return ok && s.Code() == codes.NotFound
+}
+
+// ErrorClass says how a failed provider call should be handled.
+type ErrorClass string
+
+const (
+	// ErrorClassRetryable is a transient failure, such as an unavailable
+	// API or a dropped connection. The same call may succeed if repeated.
+	ErrorClassRetryable ErrorClass = "retryable"
+
+	// ErrorClassQuota means the region is out of capacity or quota. Retrying
+	// in the same region will not help; another region might.
+	ErrorClassQuota ErrorClass = "quota"
+
+	// ErrorClassPermanent is a failure that would repeat on every attempt,
+	// such as an invalid request or a permission error.
+	ErrorClassPermanent ErrorClass = "permanent"
+
+	// ErrorClassNotFound means the cloud resource does not exist.
+	ErrorClassNotFound ErrorClass = "not_found"
+)
+
+// ErrCircuitOpen is returned, wrapped in a ProviderError, when a provider's
+// circuit breaker is rejecting calls after repeated failures.
+var ErrCircuitOpen = errors.New("provider unavailable: circuit breaker open")
+
+// ProviderError is a classified error from a provider call.
+type ProviderError struct {
+	// Class is how the error should be handled.
+	Class ErrorClass
+
+	// Op is the provider method that failed, e.g. "SubmitJob".
+	Op string
+
+	// Provider names the provider, e.g. "cloud-batch/asia-northeast1".
+	Provider string
+
+	// Err is the underlying error.
+	Err error
+}
+
+func (e *ProviderError) Error() string {
+	return fmt.Sprintf("%s %s (%s): %v", e.Provider, e.Op, e.Class, e.Err)
+}
+
+func (e *ProviderError) Unwrap() error {
+	return e.Err
+}
+
+// ClassifyError returns the class of a provider error. Errors that are
+// already a ProviderError keep their class; anything unrecognised is
+// treated as permanent so it is not retried blindly.
+func ClassifyError(err error) ErrorClass {
+	var perr *ProviderError
+	if errors.As(err, &perr) {
+		return perr.Class
+	}
+	if IsNotFound(err) {
+		return ErrorClassNotFound
+	}
+	if IsCapacityError(err) {
+		return ErrorClassQuota
+	}
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		// The caller gave up; repeating the call would not change that.
+		return ErrorClassPermanent
+	}
+	if s, ok := status.FromError(err); ok {
+		switch s.Code() {
+		case codes.Unavailable, codes.DeadlineExceeded, codes.Aborted, codes.Internal:
+			return ErrorClassRetryable
+		case codes.Unknown:
+			// Not a gRPC error; fall through to the checks below.
+		default:
+			return ErrorClassPermanent
+		}
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return ErrorClassRetryable
+	}
+	msg := strings.ToLower(err.Error())
+	if strings.Contains(msg, "throttl") || strings.Contains(msg, "rate exceeded") || strings.Contains(msg, "connection reset") {
+		return ErrorClassRetryable
+	}
+	return ErrorClassPermanent
+}
+
+// IsNotProcessed reports whether err shows a request never reached the
+// provider's API: its circuit breaker was open, the connection could not be
+// dialed, or the API answered Unavailable. Only then is it safe to send a
+// call that is not idempotent, such as SubmitJob, somewhere else.
+func IsNotProcessed(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, ErrCircuitOpen) {
+		return true
+	}
+	if s, ok := status.FromError(err); ok && s.Code() == codes.Unavailable {
+		return true
+	}
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
+// IsUnavailable reports whether err means the provider could not be reached
+// or is rejecting calls, as opposed to rejecting this particular request.
+func IsUnavailable(err error) bool {
+	return err != nil && (errors.Is(err, ErrCircuitOpen) || ClassifyError(err) == ErrorClassRetryable)
+}
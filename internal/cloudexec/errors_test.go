@@ -0,0 +1,50 @@
+package batch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestClassifyError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want ErrorClass
+	}{
+		{"unavailable", status.Error(codes.Unavailable, "try again"), ErrorClassRetryable},
+		{"deadline", status.Error(codes.DeadlineExceeded, "slow"), ErrorClassRetryable},
+		{"wrapped unavailable", fmt.Errorf("failed to get job: %w", status.Error(codes.Unavailable, "x")), ErrorClassRetryable},
+		{"dial error", &net.OpError{Op: "dial", Err: errors.New("connection refused")}, ErrorClassRetryable},
+		{"stockout", status.Error(codes.ResourceExhausted, "ZONE_RESOURCE_POOL_EXHAUSTED"), ErrorClassQuota},
+		{"quota message", errors.New("Quota 'CPUS' exceeded. Limit: 24.0"), ErrorClassQuota},
+		{"not found", status.Error(codes.NotFound, "no such job"), ErrorClassNotFound},
+		{"invalid", status.Error(codes.InvalidArgument, "bad image"), ErrorClassPermanent},
+		{"permission", status.Error(codes.PermissionDenied, "no"), ErrorClassPermanent},
+		{"caller cancelled", context.Canceled, ErrorClassPermanent},
+		{"plain error", errors.New("boom"), ErrorClassPermanent},
+		{"provider error keeps class", &ProviderError{Class: ErrorClassQuota, Err: errors.New("x")}, ErrorClassQuota},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ClassifyError(tt.err); got != tt.want {
+				t.Errorf("ClassifyError(%v) = %s, want %s", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsUnavailable_CircuitOpen(t *testing.T) {
+	err := fmt.Errorf("region us-central1: %w", &ProviderError{Class: ErrorClassRetryable, Op: "SubmitJob", Err: ErrCircuitOpen})
+	if !IsUnavailable(err) {
+		t.Error("circuit-open error is not unavailable")
+	}
+	if IsUnavailable(status.Error(codes.InvalidArgument, "bad")) {
+		t.Error("invalid argument is unavailable")
+	}
+}
@@ -3,10 +3,12 @@ package gcp
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	batch "cloud.google.com/go/batch/apiv1"
 	"cloud.google.com/go/batch/apiv1/batchpb"
+	"google.golang.org/api/iterator"
 	"google.golang.org/protobuf/types/known/durationpb"
 
 	batchpkg "github.com/alphauslabs/jennah/internal/cloudexec"
@@ -22,6 +24,25 @@ type GCPBatchProvider struct {
 	client    *batch.Client
 	projectID string
 	region    string
+
+	// notificationTopic is the Pub/Sub topic ("projects/P/topics/T") Cloud
+	// Batch publishes job and task state changes to. Empty disables them.
+	notificationTopic string
+}
+
+// CloudBatchCapabilities describes what Jennah can run on GCP Batch.
+// CPU and memory are bounded by the selected machine type rather than by the
+// service, so they are left unlimited here.
+var CloudBatchCapabilities = batchpkg.Capabilities{
+	MaxTaskCount:        100000,
+	SupportsGPU:         true,
+	SupportsSpot:        true,
+	SupportsMachineType: true,
+	VolumeTypes: []batchpkg.VolumeType{
+		batchpkg.VolumeTypeGCS, batchpkg.VolumeTypeNFS, batchpkg.VolumeTypeLocalSSD,
+	},
+	SupportsRunnables: true,
+	CostRank:          20,
 }
 
 // ServiceType returns the service type identifier for GCP Batch.
@@ -29,6 +50,11 @@ func (p *GCPBatchProvider) ServiceType() string {
 	return batchpkg.ServiceTypeCloudBatch
 }
 
+// Capabilities returns the GCP Batch capability descriptor.
+func (p *GCPBatchProvider) Capabilities() batchpkg.Capabilities {
+	return CloudBatchCapabilities
+}
+
 // NewGCPBatchProvider creates a new GCP Batch provider.
 func NewGCPBatchProvider(ctx context.Context, config batchpkg.ProviderConfig) (batchpkg.Provider, error) {
 	if config.ProjectID == "" {
@@ -44,9 +70,10 @@ func NewGCPBatchProvider(ctx context.Context, config batchpkg.ProviderConfig) (b
 	}
 
 	return &GCPBatchProvider{
-		client:    client,
-		projectID: config.ProjectID,
-		region:    config.Region,
+		client:            client,
+		projectID:         config.ProjectID,
+		region:            config.Region,
+		notificationTopic: config.ProviderOptions["notification_topic"],
 	}, nil
 }
 
@@ -87,6 +114,44 @@ func (p *GCPBatchProvider) SubmitJob(ctx context.Context, config batchpkg.JobCon
 		Runnables: []*batchpb.Runnable{runnable},
 	}
 
+	// Multi-step tasks replace the single container. Job env vars then apply
+	// to every step through the task environment.
+	if len(config.Runnables) > 0 {
+		taskSpec.Runnables = batchRunnables(config.Runnables, config.ImageURI)
+		taskSpec.Environment = runnable.Environment
+	}
+
+	// Mount volumes. Container runnables get every task volume bind-mounted
+	// at the same path, so the runnable needs no volume list of its own.
+	var scratchDisks []*batchpb.AllocationPolicy_AttachedDisk
+	for i, v := range config.Volumes {
+		volume := &batchpb.Volume{MountPath: v.MountPath}
+		switch v.Type {
+		case batchpkg.VolumeTypeGCS:
+			volume.Source = &batchpb.Volume_Gcs{Gcs: &batchpb.GCS{RemotePath: v.Bucket}}
+			if v.ReadOnly {
+				volume.MountOptions = []string{"-o ro"}
+			}
+		case batchpkg.VolumeTypeNFS:
+			volume.Source = &batchpb.Volume_Nfs{Nfs: &batchpb.NFS{Server: v.NFSServer, RemotePath: v.NFSPath}}
+			if v.ReadOnly {
+				volume.MountOptions = []string{"ro"}
+			}
+		case batchpkg.VolumeTypeLocalSSD:
+			deviceName := fmt.Sprintf("jennah-scratch-%d", i)
+			volume.Source = &batchpb.Volume_DeviceName{DeviceName: deviceName}
+			scratchDisks = append(scratchDisks, &batchpb.AllocationPolicy_AttachedDisk{
+				Attached: &batchpb.AllocationPolicy_AttachedDisk_NewDisk{
+					NewDisk: &batchpb.AllocationPolicy_Disk{Type: "local-ssd", SizeGb: v.SizeGb},
+				},
+				DeviceName: deviceName,
+			})
+		default:
+			return nil, fmt.Errorf("volume %s: unsupported volume type %q", v, v.Type)
+		}
+		taskSpec.Volumes = append(taskSpec.Volumes, volume)
+	}
+
 	// Configure compute resources
 	if config.Resources != nil || config.BootDiskSizeGb > 0 {
 		computeResource := &batchpb.ComputeResource{}
@@ -188,12 +253,16 @@ func (p *GCPBatchProvider) SubmitJob(ctx context.Context, config batchpkg.JobCon
 		}
 	}
 
+	// Attach local SSD scratch disks backing LOCAL_SSD volumes
+	instancePolicy.Disks = scratchDisks
+
 	// Add accelerators if specified
 	if config.Accelerators != nil && config.Accelerators.Type != "" {
 		instancePolicy.Accelerators = []*batchpb.AllocationPolicy_Accelerator{
 			{
-				Type:  config.Accelerators.Type,
-				Count: config.Accelerators.Count,
+				Type:          config.Accelerators.Type,
+				Count:         config.Accelerators.Count,
+				DriverVersion: config.Accelerators.DriverVersion,
 			},
 		}
 	}
@@ -257,9 +326,15 @@ func (p *GCPBatchProvider) SubmitJob(ctx context.Context, config batchpkg.JobCon
 		},
 	}
 
-	// Set job labels if provided
+	// Label the job and the VMs and disks it creates for billing attribution.
 	if len(config.JobLabels) > 0 {
 		job.Labels = config.JobLabels
+		allocationPolicy.Labels = config.JobLabels
+	}
+
+	// Publish state changes so the worker does not have to poll for them.
+	if p.notificationTopic != "" {
+		job.Notifications = batchNotifications(p.notificationTopic)
 	}
 
 	// Create job submission request
@@ -281,6 +356,7 @@ func (p *GCPBatchProvider) SubmitJob(ctx context.Context, config batchpkg.JobCon
 	return &batchpkg.JobResult{
 		CloudResourcePath: batchJob.Name,
 		InitialStatus:     initialStatus,
+		Region:            p.region,
 	}, nil
 }
 
@@ -295,7 +371,50 @@ func (p *GCPBatchProvider) GetJobStatus(ctx context.Context, cloudResourcePath s
 		return batchpkg.JobStatusUnknown, fmt.Errorf("failed to get GCP Batch job: %w", err)
 	}
 
-	return mapGCPStatusToJennah(job.Status.State), nil
+	return mapGCPJobStatus(job.GetStatus()), nil
+}
+
+// statusListChunk is how many job names go into one ListJobs filter.
+const statusListChunk = 50
+
+// GetJobStatuses implements batchpkg.StatusBatcher with filtered ListJobs
+// calls, one per location and chunk of statusListChunk jobs.
+func (p *GCPBatchProvider) GetJobStatuses(ctx context.Context, cloudResourcePaths []string) (map[string]batchpkg.JobStatus, error) {
+	byParent := make(map[string][]string)
+	for _, path := range cloudResourcePaths {
+		parent, _, ok := strings.Cut(path, "/jobs/")
+		if !ok {
+			continue
+		}
+		byParent[parent] = append(byParent[parent], path)
+	}
+
+	statuses := make(map[string]batchpkg.JobStatus, len(cloudResourcePaths))
+	for parent, paths := range byParent {
+		for start := 0; start < len(paths); start += statusListChunk {
+			chunk := paths[start:min(start+statusListChunk, len(paths))]
+			filters := make([]string, len(chunk))
+			for i, path := range chunk {
+				filters[i] = fmt.Sprintf("name=%q", path)
+			}
+
+			it := p.client.ListJobs(ctx, &batchpb.ListJobsRequest{
+				Parent: parent,
+				Filter: strings.Join(filters, " OR "),
+			})
+			for {
+				job, err := it.Next()
+				if err == iterator.Done {
+					break
+				}
+				if err != nil {
+					return nil, fmt.Errorf("failed to list GCP Batch jobs in %s: %w", parent, err)
+				}
+				statuses[job.GetName()] = mapGCPJobStatus(job.GetStatus())
+			}
+		}
+	}
+	return statuses, nil
 }
 
 // CancelJob cancels a running GCP Batch job.
@@ -359,11 +478,107 @@ func (p *GCPBatchProvider) ListJobs(ctx context.Context) ([]string, error) {
 	return jobPaths, nil
 }
 
+// ListJobResources implements batchpkg.ResourceLister for the configured
+// project/region.
+func (p *GCPBatchProvider) ListJobResources(ctx context.Context) ([]batchpkg.ListedJob, error) {
+	parent := fmt.Sprintf("projects/%s/locations/%s", p.projectID, p.region)
+
+	it := p.client.ListJobs(ctx, &batchpb.ListJobsRequest{Parent: parent})
+	var jobs []batchpkg.ListedJob
+	for {
+		job, err := it.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to list GCP Batch jobs in %s: %w", parent, err)
+		}
+		if !batchpkg.IsJennahJobPath(job.GetName()) {
+			continue
+		}
+		jobs = append(jobs, batchpkg.ListedJob{
+			Path:       job.GetName(),
+			Status:     mapGCPJobStatus(job.GetStatus()),
+			CreateTime: job.GetCreateTime().AsTime(),
+		})
+	}
+	return jobs, nil
+}
+
 // Close closes the GCP Batch client.
 func (p *GCPBatchProvider) Close() error {
 	return p.client.Close()
 }
 
+// preemptionExitCode is the task exit code Cloud Batch reports when the
+// task's Spot VM was preempted.
+const preemptionExitCode = 50001
+
+// batchRunnables converts ordered task steps into Batch runnables. Container
+// steps without an image use defaultImage.
+func batchRunnables(steps []batchpkg.RunnableConfig, defaultImage string) []*batchpb.Runnable {
+	runnables := make([]*batchpb.Runnable, 0, len(steps))
+	for _, step := range steps {
+		r := &batchpb.Runnable{
+			DisplayName:      step.Name,
+			Background:       step.Background,
+			IgnoreExitStatus: step.IgnoreExitStatus,
+			AlwaysRun:        step.AlwaysRun,
+		}
+		switch {
+		case step.Barrier:
+			r.Executable = &batchpb.Runnable_Barrier_{Barrier: &batchpb.Runnable_Barrier{Name: step.Name}}
+		case step.Script != "":
+			r.Executable = &batchpb.Runnable_Script_{Script: &batchpb.Runnable_Script{
+				Command: &batchpb.Runnable_Script_Text{Text: step.Script},
+			}}
+		default:
+			image := step.ImageURI
+			if image == "" {
+				image = defaultImage
+			}
+			r.Executable = &batchpb.Runnable_Container_{Container: &batchpb.Runnable_Container{
+				ImageUri: image,
+				Commands: step.Commands,
+			}}
+		}
+		if len(step.EnvVars) > 0 {
+			r.Environment = &batchpb.Environment{Variables: step.EnvVars}
+		}
+		runnables = append(runnables, r)
+	}
+	return runnables
+}
+
+// mapGCPJobStatus maps a job's status to Jennah status. FAILED jobs whose
+// status events show a Spot preemption are reported as JobStatusPreempted.
+func mapGCPJobStatus(status *batchpb.JobStatus) batchpkg.JobStatus {
+	jennahStatus := mapGCPStatusToJennah(status.GetState())
+	if jennahStatus == batchpkg.JobStatusFailed && wasPreempted(status.GetStatusEvents()) {
+		return batchpkg.JobStatusPreempted
+	}
+	return jennahStatus
+}
+
+// wasPreempted reports whether the job's final failing task attempt was lost
+// to a Spot preemption: the latest event recording a failed attempt must
+// carry the preemption exit code. Earlier attempts that Batch retried, and
+// event descriptions, do not count.
+func wasPreempted(events []*batchpb.StatusEvent) bool {
+	var last *batchpb.StatusEvent
+	for _, event := range events {
+		failed := event.GetTaskState() == batchpb.TaskStatus_FAILED ||
+			(event.GetTaskState() == batchpb.TaskStatus_STATE_UNSPECIFIED && event.GetTaskExecution().GetExitCode() != 0)
+		if !failed {
+			continue
+		}
+		if last == nil || !event.GetEventTime().AsTime().Before(last.GetEventTime().AsTime()) {
+			last = event
+		}
+	}
+	return last.GetTaskExecution().GetExitCode() == preemptionExitCode
+}
+
 // mapGCPStatusToJennah maps GCP Batch job states to Jennah status constants.
 func mapGCPStatusToJennah(state batchpb.JobStatus_State) batchpkg.JobStatus {
 	switch state {
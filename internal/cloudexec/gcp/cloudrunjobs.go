@@ -9,6 +9,7 @@ import (
 
 	run "cloud.google.com/go/run/apiv2"
 	runpb "cloud.google.com/go/run/apiv2/runpb"
+	"google.golang.org/api/iterator"
 	api "google.golang.org/genproto/googleapis/api"
 	"google.golang.org/protobuf/types/known/durationpb"
 
@@ -19,8 +20,20 @@ func init() {
 	batchpkg.RegisterGCPCloudRunProvider(NewGCPCloudRunProvider)
 }
 
+// CloudRunJobCapabilities describes what Jennah can run on Cloud Run Jobs.
+// Routing limits are derived from these values, so raising them here is
+// enough to let larger jobs stay on Cloud Run.
+var CloudRunJobCapabilities = batchpkg.Capabilities{
+	MaxCPUMillis:          4000,
+	MaxMemoryMiB:          8192,
+	MaxRunDurationSeconds: 3600,
+	MaxTaskCount:          10000,
+	VolumeTypes:           []batchpkg.VolumeType{batchpkg.VolumeTypeGCS, batchpkg.VolumeTypeNFS},
+	CostRank:              10,
+}
+
 // GCPCloudRunProvider implements the batch.Provider interface for GCP Cloud Run Jobs.
-// Cloud Run Jobs is used for MEDIUM jobs: ≤4000 mCPU, ≤8192 MiB, ≤3600 s.
+// Cloud Run Jobs is used for SIMPLE jobs that fit within CloudRunJobCapabilities.
 //
 // Each job is created as a Cloud Run Job resource and immediately executed.
 // The job runs the configured container image with the specified resources.
@@ -36,6 +49,11 @@ func (p *GCPCloudRunProvider) ServiceType() string {
 	return batchpkg.ServiceTypeCloudRunJob
 }
 
+// Capabilities returns the Cloud Run Jobs capability descriptor.
+func (p *GCPCloudRunProvider) Capabilities() batchpkg.Capabilities {
+	return CloudRunJobCapabilities
+}
+
 // NewGCPCloudRunProvider creates a new GCP Cloud Run Jobs provider.
 func NewGCPCloudRunProvider(ctx context.Context, config batchpkg.ProviderConfig) (batchpkg.Provider, error) {
 	if config.ProjectID == "" {
@@ -72,6 +90,10 @@ func NewGCPCloudRunProvider(ctx context.Context, config batchpkg.ProviderConfig)
 func (p *GCPCloudRunProvider) SubmitJob(ctx context.Context, config batchpkg.JobConfig) (*batchpkg.JobResult, error) {
 	parent := fmt.Sprintf("projects/%s/locations/%s", p.projectID, p.region)
 
+	if len(config.Runnables) > 0 {
+		return nil, fmt.Errorf("runnables are not supported on Cloud Run Jobs (%d task steps requested)", len(config.Runnables))
+	}
+
 	// Build container definition.
 	container := &runpb.Container{
 		Image: config.ImageURI,
@@ -133,9 +155,30 @@ func (p *GCPCloudRunProvider) SubmitJob(ctx context.Context, config batchpkg.Job
 		taskTemplate.ServiceAccount = config.ServiceAccount
 	}
 
-	// Configure execution template.
+	// Mount volumes. GCS and NFS volumes need the second-generation
+	// execution environment.
+	for i, v := range config.Volumes {
+		volume := &runpb.Volume{Name: fmt.Sprintf("jennah-volume-%d", i)}
+		switch v.Type {
+		case batchpkg.VolumeTypeGCS:
+			volume.VolumeType = &runpb.Volume_Gcs{Gcs: &runpb.GCSVolumeSource{Bucket: v.Bucket, ReadOnly: v.ReadOnly}}
+		case batchpkg.VolumeTypeNFS:
+			volume.VolumeType = &runpb.Volume_Nfs{Nfs: &runpb.NFSVolumeSource{Server: v.NFSServer, Path: v.NFSPath, ReadOnly: v.ReadOnly}}
+		default:
+			return nil, fmt.Errorf("volume %s: not supported on Cloud Run Jobs", v)
+		}
+		taskTemplate.Volumes = append(taskTemplate.Volumes, volume)
+		container.VolumeMounts = append(container.VolumeMounts, &runpb.VolumeMount{Name: volume.Name, MountPath: v.MountPath})
+	}
+	if len(config.Volumes) > 0 {
+		taskTemplate.ExecutionEnvironment = runpb.ExecutionEnvironment_EXECUTION_ENVIRONMENT_GEN2
+	}
+
+	// Configure execution template. Labels on it are copied to every
+	// execution, so their cost is attributed like the job's.
 	executionTemplate := &runpb.ExecutionTemplate{
 		Template: taskTemplate,
+		Labels:   config.JobLabels,
 	}
 
 	// Set task count and parallelism from task group config.
@@ -153,6 +196,7 @@ func (p *GCPCloudRunProvider) SubmitJob(ctx context.Context, config batchpkg.Job
 		Parent: parent,
 		JobId:  config.JobID,
 		Job: &runpb.Job{
+			Labels:   config.JobLabels,
 			Template: executionTemplate,
 			LaunchStage: api.LaunchStage_GA,
 		},
@@ -189,6 +233,7 @@ func (p *GCPCloudRunProvider) SubmitJob(ctx context.Context, config batchpkg.Job
 		return &batchpkg.JobResult{
 			CloudResourcePath: job.GetName(),
 			InitialStatus:     batchpkg.JobStatusRunning,
+			Region:            p.region,
 		}, nil
 	}
 
@@ -197,6 +242,7 @@ func (p *GCPCloudRunProvider) SubmitJob(ctx context.Context, config batchpkg.Job
 	return &batchpkg.JobResult{
 		CloudResourcePath: job.GetName(),
 		InitialStatus:     batchpkg.JobStatusRunning,
+		Region:            p.region,
 	}, nil
 }
 
@@ -289,6 +335,55 @@ func (p *GCPCloudRunProvider) ListJobs(ctx context.Context) ([]string, error) {
 	return jobNames, nil
 }
 
+// ListJobResources implements batchpkg.ResourceLister. A job's status is
+// taken from its latest execution; jobs that never ran are PENDING.
+func (p *GCPCloudRunProvider) ListJobResources(ctx context.Context) ([]batchpkg.ListedJob, error) {
+	parent := fmt.Sprintf("projects/%s/locations/%s", p.projectID, p.region)
+
+	it := p.jobsClient.ListJobs(ctx, &runpb.ListJobsRequest{Parent: parent})
+	var jobs []batchpkg.ListedJob
+	for {
+		job, err := it.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to list Cloud Run jobs in %s: %w", parent, err)
+		}
+		if !batchpkg.IsJennahJobPath(job.GetName()) {
+			continue
+		}
+		jobs = append(jobs, batchpkg.ListedJob{
+			Path:       job.GetName(),
+			Status:     mapCloudRunExecutionReference(job.GetLatestCreatedExecution()),
+			CreateTime: job.GetCreateTime().AsTime(),
+		})
+	}
+	return jobs, nil
+}
+
+// mapCloudRunExecutionReference maps a job's latest execution summary to
+// Jennah status.
+func mapCloudRunExecutionReference(ref *runpb.ExecutionReference) batchpkg.JobStatus {
+	if ref == nil {
+		return batchpkg.JobStatusPending
+	}
+	switch ref.GetCompletionStatus() {
+	case runpb.ExecutionReference_EXECUTION_SUCCEEDED:
+		return batchpkg.JobStatusCompleted
+	case runpb.ExecutionReference_EXECUTION_FAILED:
+		return batchpkg.JobStatusFailed
+	case runpb.ExecutionReference_EXECUTION_CANCELLED:
+		return batchpkg.JobStatusCancelled
+	case runpb.ExecutionReference_EXECUTION_RUNNING:
+		return batchpkg.JobStatusRunning
+	case runpb.ExecutionReference_EXECUTION_PENDING:
+		return batchpkg.JobStatusPending
+	default:
+		return batchpkg.JobStatusUnknown
+	}
+}
+
 // Close closes the Cloud Run Jobs and Executions clients.
 func (p *GCPCloudRunProvider) Close() error {
 	// Close jobsClient
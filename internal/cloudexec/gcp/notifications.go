@@ -0,0 +1,115 @@
+package gcp
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"cloud.google.com/go/batch/apiv1/batchpb"
+	"cloud.google.com/go/pubsub"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	batchpkg "github.com/alphauslabs/jennah/internal/cloudexec"
+)
+
+// Attributes Cloud Batch sets on the Pub/Sub messages it publishes.
+const (
+	notificationTypeAttr     = "Type"
+	notificationJobNameAttr  = "JobName"
+	notificationJobStateAttr = "NewJobState"
+)
+
+// statusSubscriptionTTL is how long an idle per-worker subscription survives
+// after its worker goes away.
+const statusSubscriptionTTL = 24 * time.Hour
+
+// batchNotifications returns the notifications to configure on a job: every
+// job state change, plus task failures so Spot preemptions surface before the
+// whole job fails.
+func batchNotifications(topic string) []*batchpb.JobNotification {
+	return []*batchpb.JobNotification{
+		{
+			PubsubTopic: topic,
+			Message:     &batchpb.JobNotification_Message{Type: batchpb.JobNotification_JOB_STATE_CHANGED},
+		},
+		{
+			PubsubTopic: topic,
+			Message: &batchpb.JobNotification_Message{
+				Type:         batchpb.JobNotification_TASK_STATE_CHANGED,
+				NewTaskState: batchpb.TaskStatus_FAILED,
+			},
+		},
+	}
+}
+
+// BatchStatusSubscriber receives Cloud Batch state change notifications from
+// a Pub/Sub subscription.
+type BatchStatusSubscriber struct {
+	sub *pubsub.Subscription
+}
+
+// NewBatchStatusSubscriber returns a subscriber reading from sub.
+func NewBatchStatusSubscriber(sub *pubsub.Subscription) *BatchStatusSubscriber {
+	return &BatchStatusSubscriber{sub: sub}
+}
+
+// EnsureBatchStatusSubscription returns the subscription named subID on
+// topicID, creating it if needed. Each worker uses its own subscription so
+// every worker sees every notification and applies those for jobs it owns.
+func EnsureBatchStatusSubscription(ctx context.Context, client *pubsub.Client, topicID, subID string) (*pubsub.Subscription, error) {
+	sub := client.Subscription(subID)
+	exists, err := sub.Exists(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check subscription %s: %w", subID, err)
+	}
+	if exists {
+		return sub, nil
+	}
+
+	sub, err = client.CreateSubscription(ctx, subID, pubsub.SubscriptionConfig{
+		Topic:            client.Topic(topicID),
+		AckDeadline:      20 * time.Second,
+		ExpirationPolicy: statusSubscriptionTTL,
+	})
+	if status.Code(err) == codes.AlreadyExists {
+		return client.Subscription(subID), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create subscription %s: %w", subID, err)
+	}
+	return sub, nil
+}
+
+// Receive implements batchpkg.StatusSubscriber. Messages that are not Cloud
+// Batch notifications are acknowledged and dropped.
+func (s *BatchStatusSubscriber) Receive(ctx context.Context, handle func(ctx context.Context, n batchpkg.StatusNotification)) error {
+	return s.sub.Receive(ctx, func(ctx context.Context, msg *pubsub.Message) {
+		if n, ok := parseBatchNotification(msg.Attributes); ok {
+			handle(ctx, n)
+		}
+		msg.Ack()
+	})
+}
+
+// parseBatchNotification converts Cloud Batch message attributes into a
+// notification. Task state changes carry no job state, so they are reported
+// as JobStatusUnknown to make the receiver fetch the job's status.
+func parseBatchNotification(attrs map[string]string) (batchpkg.StatusNotification, bool) {
+	jobName := attrs[notificationJobNameAttr]
+	if jobName == "" {
+		return batchpkg.StatusNotification{}, false
+	}
+	n := batchpkg.StatusNotification{JobPath: jobName, Status: batchpkg.JobStatusUnknown}
+
+	switch attrs[notificationTypeAttr] {
+	case batchpb.JobNotification_JOB_STATE_CHANGED.String():
+		if state, ok := batchpb.JobStatus_State_value[attrs[notificationJobStateAttr]]; ok {
+			n.Status = mapGCPStatusToJennah(batchpb.JobStatus_State(state))
+		}
+	case batchpb.JobNotification_TASK_STATE_CHANGED.String():
+	default:
+		return batchpkg.StatusNotification{}, false
+	}
+	return n, true
+}
@@ -0,0 +1,111 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	batch "github.com/alphauslabs/jennah/internal/cloudexec"
+)
+
+// BreakerState is the state of a circuit breaker.
+type BreakerState string
+
+const (
+	// BreakerClosed lets every call through.
+	BreakerClosed BreakerState = "closed"
+
+	// BreakerOpen rejects every call until the open period has passed.
+	BreakerOpen BreakerState = "open"
+
+	// BreakerHalfOpen lets a single probe call through; its outcome decides
+	// whether the breaker closes or opens again.
+	BreakerHalfOpen BreakerState = "half-open"
+)
+
+// Breaker is a consecutive-failure circuit breaker. Only retryable errors
+// (see batch.ClassifyError) count as failures: a provider that answers
+// "not found" or "invalid argument" is still up.
+type Breaker struct {
+	mu        sync.Mutex
+	threshold int
+	openFor   time.Duration
+	now       func() time.Time
+
+	state    BreakerState
+	failures int
+	openedAt time.Time
+	probing  bool
+}
+
+// NewBreaker creates a breaker that opens after threshold consecutive
+// failures and stays open for openFor before probing again.
+func NewBreaker(threshold int, openFor time.Duration) *Breaker {
+	return &Breaker{
+		threshold: threshold,
+		openFor:   openFor,
+		now:       time.Now,
+		state:     BreakerClosed,
+	}
+}
+
+// Allow reports whether a call may proceed. Once the open period has passed
+// it admits one probe at a time.
+func (b *Breaker) Allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case BreakerOpen:
+		if b.now().Sub(b.openedAt) < b.openFor {
+			return false
+		}
+		b.state = BreakerHalfOpen
+		b.probing = true
+		return true
+	case BreakerHalfOpen:
+		if b.probing {
+			return false
+		}
+		b.probing = true
+		return true
+	default:
+		return true
+	}
+}
+
+// Record reports the outcome of an allowed call. It returns the state
+// before and after, so callers can log transitions.
+func (b *Breaker) Record(err error) (from, to BreakerState) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	from = b.state
+	switch {
+	case errors.Is(err, context.Canceled):
+		// The caller gave up; that says nothing about the provider.
+	case err == nil || batch.ClassifyError(err) != batch.ErrorClassRetryable:
+		b.state = BreakerClosed
+		b.failures = 0
+	default:
+		b.failures++
+		if b.state == BreakerHalfOpen || b.failures >= b.threshold {
+			b.state = BreakerOpen
+			b.openedAt = b.now()
+		}
+	}
+	b.probing = false
+	return from, b.state
+}
+
+// State returns the breaker's current state.
+func (b *Breaker) State() BreakerState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == BreakerOpen && b.now().Sub(b.openedAt) >= b.openFor {
+		return BreakerHalfOpen
+	}
+	return b.state
+}
@@ -0,0 +1,288 @@
+// Package middleware wraps a batch.Provider with retries, rate limiting and
+// a circuit breaker, and classifies its errors as batch.ProviderError.
+//
+// Idempotent calls (status reads, cancel, delete, list) are retried on any
+// retryable error. SubmitJob is only retried when the API is known not to
+// have processed the request (gRPC Unavailable or a failed dial), because
+// a resubmission of a job that was created would collide with it.
+package middleware
+
+import (
+	"context"
+	"errors"
+	"log"
+	"math/rand/v2"
+	"time"
+
+	"golang.org/x/time/rate"
+
+	batch "github.com/alphauslabs/jennah/internal/cloudexec"
+)
+
+// Options configures a wrapped provider.
+type Options struct {
+	// Name identifies the provider in errors, logs and health output,
+	// e.g. "CLOUD_BATCH/asia-northeast1".
+	Name string
+
+	// MaxAttempts is the most times a call is tried, including the first.
+	MaxAttempts int
+
+	// InitialBackoff is the delay before the first retry. It doubles on
+	// each retry up to MaxBackoff, and each delay is jittered.
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+
+	// RetryBudget bounds the time spent on one call across all attempts.
+	// No retry starts that would end after the budget or the caller's
+	// deadline.
+	RetryBudget time.Duration
+
+	// RateLimit is the sustained calls per second allowed to the provider,
+	// with bursts of up to Burst calls. Zero disables rate limiting.
+	RateLimit float64
+	Burst     int
+
+	// FailureThreshold consecutive retryable failures open the breaker,
+	// which then rejects calls for OpenDuration.
+	FailureThreshold int
+	OpenDuration     time.Duration
+}
+
+// DefaultOptions returns the options used for name unless overridden.
+func DefaultOptions(name string) Options {
+	return Options{
+		Name:             name,
+		MaxAttempts:      4,
+		InitialBackoff:   200 * time.Millisecond,
+		MaxBackoff:       5 * time.Second,
+		RetryBudget:      30 * time.Second,
+		RateLimit:        20,
+		Burst:            40,
+		FailureThreshold: 5,
+		OpenDuration:     30 * time.Second,
+	}
+}
+
+// Provider is a batch.Provider wrapped with retries, rate limiting and a
+// circuit breaker. It always implements batch.StatusBatcher and
+// batch.ResourceLister, falling back to the single-job calls when the
+// wrapped provider does not.
+type Provider struct {
+	next    batch.Provider
+	opts    Options
+	limiter *rate.Limiter
+	breaker *Breaker
+	sleep   func(ctx context.Context, d time.Duration) error
+}
+
+// Wrap wraps next with the given options.
+func Wrap(next batch.Provider, opts Options) *Provider {
+	if opts.MaxAttempts < 1 {
+		opts.MaxAttempts = 1
+	}
+	limit := rate.Inf
+	if opts.RateLimit > 0 {
+		limit = rate.Limit(opts.RateLimit)
+	}
+	if opts.Burst < 1 {
+		opts.Burst = 1
+	}
+	return &Provider{
+		next:    next,
+		opts:    opts,
+		limiter: rate.NewLimiter(limit, opts.Burst),
+		breaker: NewBreaker(opts.FailureThreshold, opts.OpenDuration),
+		sleep:   sleepContext,
+	}
+}
+
+// Name returns the provider's name.
+func (p *Provider) Name() string {
+	return p.opts.Name
+}
+
+// BreakerState returns the state of the provider's circuit breaker.
+func (p *Provider) BreakerState() BreakerState {
+	return p.breaker.State()
+}
+
+// SubmitJob implements batch.Provider.
+func (p *Provider) SubmitJob(ctx context.Context, config batch.JobConfig) (*batch.JobResult, error) {
+	var result *batch.JobResult
+	err := p.call(ctx, "SubmitJob", false, func(ctx context.Context) error {
+		var err error
+		result, err = p.next.SubmitJob(ctx, config)
+		return err
+	})
+	return result, err
+}
+
+// GetJobStatus implements batch.Provider.
+func (p *Provider) GetJobStatus(ctx context.Context, cloudResourcePath string) (batch.JobStatus, error) {
+	status := batch.JobStatusUnknown
+	err := p.call(ctx, "GetJobStatus", true, func(ctx context.Context) error {
+		var err error
+		status, err = p.next.GetJobStatus(ctx, cloudResourcePath)
+		return err
+	})
+	return status, err
+}
+
+// GetJobStatuses implements batch.StatusBatcher. When the wrapped provider
+// cannot batch it returns no statuses, leaving every path to GetJobStatus.
+func (p *Provider) GetJobStatuses(ctx context.Context, cloudResourcePaths []string) (map[string]batch.JobStatus, error) {
+	batcher, ok := p.next.(batch.StatusBatcher)
+	if !ok {
+		return map[string]batch.JobStatus{}, nil
+	}
+	var statuses map[string]batch.JobStatus
+	err := p.call(ctx, "GetJobStatuses", true, func(ctx context.Context) error {
+		var err error
+		statuses, err = batcher.GetJobStatuses(ctx, cloudResourcePaths)
+		return err
+	})
+	return statuses, err
+}
+
+// CancelJob implements batch.Provider.
+func (p *Provider) CancelJob(ctx context.Context, cloudResourcePath string) error {
+	return p.call(ctx, "CancelJob", true, func(ctx context.Context) error {
+		return p.next.CancelJob(ctx, cloudResourcePath)
+	})
+}
+
+// DeleteJob implements batch.Provider.
+func (p *Provider) DeleteJob(ctx context.Context, cloudResourcePath string) error {
+	return p.call(ctx, "DeleteJob", true, func(ctx context.Context) error {
+		return p.next.DeleteJob(ctx, cloudResourcePath)
+	})
+}
+
+// ListJobs implements batch.Provider.
+func (p *Provider) ListJobs(ctx context.Context) ([]string, error) {
+	var paths []string
+	err := p.call(ctx, "ListJobs", true, func(ctx context.Context) error {
+		var err error
+		paths, err = p.next.ListJobs(ctx)
+		return err
+	})
+	return paths, err
+}
+
+// ListJobResources implements batch.ResourceLister. When the wrapped
+// provider cannot list resources it falls back to ListJobs, with unknown
+// status and creation time.
+func (p *Provider) ListJobResources(ctx context.Context) ([]batch.ListedJob, error) {
+	lister, ok := p.next.(batch.ResourceLister)
+	if !ok {
+		paths, err := p.ListJobs(ctx)
+		if err != nil {
+			return nil, err
+		}
+		var jobs []batch.ListedJob
+		for _, path := range paths {
+			if batch.IsJennahJobPath(path) {
+				jobs = append(jobs, batch.ListedJob{Path: path, Status: batch.JobStatusUnknown})
+			}
+		}
+		return jobs, nil
+	}
+	var jobs []batch.ListedJob
+	err := p.call(ctx, "ListJobResources", true, func(ctx context.Context) error {
+		var err error
+		jobs, err = lister.ListJobResources(ctx)
+		return err
+	})
+	return jobs, err
+}
+
+// ServiceType implements batch.Provider.
+func (p *Provider) ServiceType() string {
+	return p.next.ServiceType()
+}
+
+// Capabilities implements batch.Provider.
+func (p *Provider) Capabilities() batch.Capabilities {
+	return p.next.Capabilities()
+}
+
+// call runs fn through the breaker and rate limiter, retrying it while the
+// error allows and the budget lasts. Errors are returned as
+// *batch.ProviderError.
+func (p *Provider) call(ctx context.Context, op string, idempotent bool, fn func(ctx context.Context) error) error {
+	start := time.Now()
+	backoff := p.opts.InitialBackoff
+	for attempt := 1; ; attempt++ {
+		if !p.breaker.Allow() {
+			return &batch.ProviderError{Class: batch.ErrorClassRetryable, Op: op, Provider: p.opts.Name, Err: batch.ErrCircuitOpen}
+		}
+		if err := p.limiter.Wait(ctx); err != nil {
+			p.breaker.Record(context.Canceled)
+			return p.classified(op, err)
+		}
+
+		err := fn(ctx)
+		if from, to := p.breaker.Record(err); from != to {
+			log.Printf("Provider %s: circuit breaker %s -> %s", p.opts.Name, from, to)
+		}
+		if err == nil {
+			return nil
+		}
+
+		perr := p.classified(op, err)
+		if attempt >= p.opts.MaxAttempts || !retryable(perr, idempotent) {
+			return perr
+		}
+		delay := jitter(backoff)
+		if time.Since(start)+delay > p.opts.RetryBudget {
+			return perr
+		}
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < delay {
+			return perr
+		}
+		log.Printf("Provider %s: %s failed (attempt %d/%d), retrying in %s: %v",
+			p.opts.Name, op, attempt, p.opts.MaxAttempts, delay.Round(time.Millisecond), err)
+		if err := p.sleep(ctx, delay); err != nil {
+			return perr
+		}
+		backoff = min(backoff*2, p.opts.MaxBackoff)
+	}
+}
+
+// classified wraps err as a ProviderError, keeping an existing one.
+func (p *Provider) classified(op string, err error) *batch.ProviderError {
+	var perr *batch.ProviderError
+	if errors.As(err, &perr) {
+		return perr
+	}
+	return &batch.ProviderError{Class: batch.ClassifyError(err), Op: op, Provider: p.opts.Name, Err: err}
+}
+
+// retryable reports whether a failed call may be repeated. Calls that are
+// not idempotent are only repeated when the request never reached the API.
+func retryable(err *batch.ProviderError, idempotent bool) bool {
+	if err.Class != batch.ErrorClassRetryable || errors.Is(err, batch.ErrCircuitOpen) {
+		return false
+	}
+	return idempotent || batch.IsNotProcessed(err.Err)
+}
+
+// jitter returns a random delay between half of d and d.
+func jitter(d time.Duration) time.Duration {
+	if d <= 0 {
+		return 0
+	}
+	return d/2 + rand.N(d/2+1)
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,195 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	batch "github.com/alphauslabs/jennah/internal/cloudexec"
+)
+
+// scriptedProvider returns errs in order, then succeeds.
+type scriptedProvider struct {
+	errs  []error
+	calls int
+}
+
+func (f *scriptedProvider) next() error {
+	f.calls++
+	if f.calls <= len(f.errs) {
+		return f.errs[f.calls-1]
+	}
+	return nil
+}
+
+func (f *scriptedProvider) SubmitJob(ctx context.Context, config batch.JobConfig) (*batch.JobResult, error) {
+	if err := f.next(); err != nil {
+		return nil, err
+	}
+	return &batch.JobResult{CloudResourcePath: "jobs/" + config.JobID}, nil
+}
+
+func (f *scriptedProvider) GetJobStatus(ctx context.Context, path string) (batch.JobStatus, error) {
+	if err := f.next(); err != nil {
+		return batch.JobStatusUnknown, err
+	}
+	return batch.JobStatusRunning, nil
+}
+
+func (f *scriptedProvider) CancelJob(ctx context.Context, path string) error { return f.next() }
+func (f *scriptedProvider) DeleteJob(ctx context.Context, path string) error { return f.next() }
+func (f *scriptedProvider) ListJobs(ctx context.Context) ([]string, error)   { return nil, f.next() }
+func (f *scriptedProvider) ServiceType() string                              { return batch.ServiceTypeCloudBatch }
+func (f *scriptedProvider) Capabilities() batch.Capabilities                 { return batch.Capabilities{} }
+
+var (
+	errUnavailable = status.Error(codes.Unavailable, "connection refused")
+	errTimeout     = status.Error(codes.DeadlineExceeded, "deadline exceeded")
+	errInvalid     = status.Error(codes.InvalidArgument, "bad image")
+)
+
+func newTestProvider(f *scriptedProvider) *Provider {
+	opts := DefaultOptions("test")
+	opts.RateLimit = 0
+	p := Wrap(f, opts)
+	p.sleep = func(ctx context.Context, d time.Duration) error { return nil }
+	return p
+}
+
+func TestProvider_RetriesTransientErrors(t *testing.T) {
+	f := &scriptedProvider{errs: []error{errUnavailable, errTimeout}}
+	p := newTestProvider(f)
+
+	status, err := p.GetJobStatus(context.Background(), "jobs/j")
+	if err != nil || status != batch.JobStatusRunning {
+		t.Fatalf("GetJobStatus = %v, %v; want RUNNING after retries", status, err)
+	}
+	if f.calls != 3 {
+		t.Errorf("calls = %d, want 3", f.calls)
+	}
+}
+
+func TestProvider_DoesNotRetryPermanentErrors(t *testing.T) {
+	f := &scriptedProvider{errs: []error{errInvalid}}
+	p := newTestProvider(f)
+
+	err := p.CancelJob(context.Background(), "jobs/j")
+	var perr *batch.ProviderError
+	if !errors.As(err, &perr) || perr.Class != batch.ErrorClassPermanent || perr.Op != "CancelJob" {
+		t.Fatalf("err = %v, want a permanent CancelJob ProviderError", err)
+	}
+	if f.calls != 1 {
+		t.Errorf("calls = %d, want 1", f.calls)
+	}
+}
+
+func TestProvider_SubmitJobOnlyRetriesUnprocessedRequests(t *testing.T) {
+	f := &scriptedProvider{errs: []error{errUnavailable}}
+	if _, err := newTestProvider(f).SubmitJob(context.Background(), batch.JobConfig{JobID: "j"}); err != nil {
+		t.Fatalf("SubmitJob after Unavailable: %v", err)
+	}
+
+	// A timed-out create may have created the job; retrying would collide.
+	f = &scriptedProvider{errs: []error{errTimeout}}
+	_, err := newTestProvider(f).SubmitJob(context.Background(), batch.JobConfig{JobID: "j"})
+	if batch.ClassifyError(err) != batch.ErrorClassRetryable {
+		t.Fatalf("err = %v, want a retryable ProviderError", err)
+	}
+	if f.calls != 1 {
+		t.Errorf("calls = %d, want 1", f.calls)
+	}
+}
+
+func TestProvider_StopsAtMaxAttempts(t *testing.T) {
+	f := &scriptedProvider{errs: []error{errUnavailable, errUnavailable, errUnavailable, errUnavailable, errUnavailable}}
+	p := newTestProvider(f)
+
+	if err := p.DeleteJob(context.Background(), "jobs/j"); !batch.IsUnavailable(err) {
+		t.Fatalf("err = %v, want unavailable", err)
+	}
+	if f.calls != p.opts.MaxAttempts {
+		t.Errorf("calls = %d, want %d", f.calls, p.opts.MaxAttempts)
+	}
+}
+
+func TestProvider_RespectsRetryBudget(t *testing.T) {
+	f := &scriptedProvider{errs: []error{errUnavailable, errUnavailable}}
+	p := newTestProvider(f)
+	p.opts.RetryBudget = time.Millisecond
+	p.opts.InitialBackoff = time.Second
+
+	if err := p.CancelJob(context.Background(), "jobs/j"); err == nil {
+		t.Fatal("CancelJob succeeded, want the first error once the budget is spent")
+	}
+	if f.calls != 1 {
+		t.Errorf("calls = %d, want 1", f.calls)
+	}
+}
+
+func TestProvider_CircuitBreakerFailsFast(t *testing.T) {
+	errs := make([]error, 100)
+	for i := range errs {
+		errs[i] = errUnavailable
+	}
+	f := &scriptedProvider{errs: errs}
+	p := newTestProvider(f)
+	p.opts.MaxAttempts = 1
+
+	for i := 0; i < p.opts.FailureThreshold; i++ {
+		p.CancelJob(context.Background(), "jobs/j")
+	}
+	if p.BreakerState() != BreakerOpen {
+		t.Fatalf("breaker = %s after %d failures, want open", p.BreakerState(), p.opts.FailureThreshold)
+	}
+
+	calls := f.calls
+	_, err := p.GetJobStatus(context.Background(), "jobs/j")
+	if !errors.Is(err, batch.ErrCircuitOpen) || !batch.IsUnavailable(err) {
+		t.Fatalf("err = %v, want ErrCircuitOpen", err)
+	}
+	if f.calls != calls {
+		t.Error("open breaker let a call through")
+	}
+}
+
+func TestBreaker_HalfOpenProbe(t *testing.T) {
+	now := time.Now()
+	b := NewBreaker(2, time.Minute)
+	b.now = func() time.Time { return now }
+
+	b.Record(errUnavailable)
+	b.Record(errUnavailable)
+	if b.Allow() {
+		t.Fatal("open breaker allowed a call")
+	}
+
+	now = now.Add(time.Minute)
+	if !b.Allow() {
+		t.Fatal("breaker did not allow a probe after the open period")
+	}
+	if b.Allow() {
+		t.Fatal("breaker allowed a second concurrent probe")
+	}
+	if _, to := b.Record(errUnavailable); to != BreakerOpen {
+		t.Fatalf("failed probe left breaker %s, want open", to)
+	}
+
+	now = now.Add(time.Minute)
+	b.Allow()
+	if _, to := b.Record(status.Error(codes.NotFound, "gone")); to != BreakerClosed {
+		t.Fatalf("answered probe left breaker %s, want closed", to)
+	}
+}
+
+func TestProvider_GetJobStatusesWithoutBatcher(t *testing.T) {
+	p := newTestProvider(&scriptedProvider{})
+
+	statuses, err := p.GetJobStatuses(context.Background(), []string{"jobs/a", "jobs/b"})
+	if err != nil || len(statuses) != 0 {
+		t.Fatalf("GetJobStatuses = %v, %v; want no statuses so callers read each job", statuses, err)
+	}
+}
@@ -3,6 +3,8 @@ package batch
 import (
 	"context"
 	"fmt"
+	"strings"
+	"time"
 )
 
 // Provider defines the interface for cloud batch service implementations.
@@ -31,6 +33,56 @@ type Provider interface {
 	// ServiceType returns the GCP service type this provider implements.
 	// Examples: "CLOUD_RUN_JOB", "CLOUD_BATCH".
 	ServiceType() string
+
+	// Capabilities reports the limits and features this provider supports.
+	// The router uses it to pick the cheapest provider that can run a job.
+	Capabilities() Capabilities
+}
+
+// Capabilities describes what a provider is able to run.
+// A zero limit means the provider imposes no limit that Jennah enforces.
+type Capabilities struct {
+	// MaxCPUMillis is the largest CPU request per task, in milli-cores.
+	MaxCPUMillis int64
+
+	// MaxMemoryMiB is the largest memory request per task, in mebibytes.
+	MaxMemoryMiB int64
+
+	// MaxRunDurationSeconds is the longest task timeout the provider accepts.
+	MaxRunDurationSeconds int64
+
+	// MaxTaskCount is the largest number of tasks in a single job.
+	MaxTaskCount int64
+
+	// SupportsGPU is true when accelerators can be attached to tasks.
+	SupportsGPU bool
+
+	// SupportsSpot is true when the provider can run on Spot/preemptible capacity.
+	SupportsSpot bool
+
+	// SupportsMachineType is true when callers can pin a specific machine type.
+	SupportsMachineType bool
+
+	// VolumeTypes lists the volume types the provider can mount into tasks.
+	VolumeTypes []VolumeType
+
+	// SupportsRunnables is true when a task can run several ordered steps
+	// (setup, sidecar, teardown) instead of a single container.
+	SupportsRunnables bool
+
+	// CostRank orders providers by relative cost for the same workload.
+	// Lower is cheaper; the router prefers the lowest rank that fits.
+	CostRank int
+}
+
+// SupportsVolume reports whether volumes of type t can be mounted.
+func (c Capabilities) SupportsVolume(t VolumeType) bool {
+	for _, vt := range c.VolumeTypes {
+		if vt == t {
+			return true
+		}
+	}
+	return false
 }
 
 // JobConfig contains the configuration for submitting a batch job.
@@ -68,6 +120,10 @@ type JobConfig struct {
 	// EnvVars are environment variables passed to the container.
 	EnvVars map[string]string
 
+	// Runnables, when set, replace the single container above with ordered
+	// task steps. EnvVars still apply to every step.
+	Runnables []RunnableConfig
+
 	// ── Compute Resources ─────────────────────────────────────────────────────
 
 	// Resources specifies CPU, memory, and max-run-duration requirements.
@@ -91,6 +147,11 @@ type JobConfig struct {
 	// Accelerators requests GPU/TPU resources (backend-only).
 	Accelerators *AcceleratorConfig
 
+	// ── Storage ───────────────────────────────────────────────────────────────
+
+	// Volumes are mounted into every task's container.
+	Volumes []VolumeConfig
+
 	// ── Networking & Security ─────────────────────────────────────────────────
 
 	// ServiceAccount is the GCP SA email for the job VMs.
@@ -133,7 +194,9 @@ type JobConfig struct {
 	// Priority is the job scheduling priority (0–100, backend-only).
 	Priority int64
 
-	// JobLabels are key-value labels attached to the GCP Batch job (backend-only).
+	// JobLabels are key-value labels attached to every cloud resource the
+	// job creates: the Batch job and its VMs, or the Cloud Run job and its
+	// executions.
 	JobLabels map[string]string
 }
 
@@ -185,6 +248,87 @@ type AcceleratorConfig struct {
 	DriverVersion string
 }
 
+// RunnableConfig is one step of a multi-step task. Exactly one of a container
+// (ImageURI), Script, or Barrier is set.
+type RunnableConfig struct {
+	// Name is the display name, and the barrier name for barrier steps.
+	Name string
+
+	// ImageURI and Commands describe a container step.
+	ImageURI string
+	Commands []string
+
+	// Script is a shell script run on the VM.
+	Script string
+
+	// Barrier makes this step wait for every task to reach it.
+	Barrier bool
+
+	// EnvVars are added to the job's EnvVars for this step.
+	EnvVars map[string]string
+
+	// Background runs the step concurrently with the steps after it.
+	Background bool
+
+	// IgnoreExitStatus keeps the task going when the step fails.
+	IgnoreExitStatus bool
+
+	// AlwaysRun runs the step even if an earlier step failed.
+	AlwaysRun bool
+}
+
+// VolumeType identifies the storage backing a volume.
+type VolumeType string
+
+const (
+	// VolumeTypeGCS mounts a Cloud Storage bucket (gcsfuse).
+	VolumeTypeGCS VolumeType = "GCS"
+	// VolumeTypeNFS mounts an NFS export such as a Filestore share.
+	VolumeTypeNFS VolumeType = "NFS"
+	// VolumeTypeLocalSSD attaches a local SSD as per-VM scratch space.
+	VolumeTypeLocalSSD VolumeType = "LOCAL_SSD"
+)
+
+// LocalSSDPartitionGb is the size of one local SSD partition. Local SSD
+// scratch sizes must be a multiple of it.
+const LocalSSDPartitionGb = 375
+
+// VolumeConfig describes storage mounted into a job's containers.
+type VolumeConfig struct {
+	// Type selects which of the source fields below is used.
+	Type VolumeType
+
+	// MountPath is the absolute path inside the container (e.g., "/mnt/data").
+	MountPath string
+
+	// ReadOnly mounts the volume read-only. Not valid for local SSD.
+	ReadOnly bool
+
+	// Bucket is the Cloud Storage bucket name (GCS).
+	Bucket string
+
+	// NFSServer is the NFS server address and NFSPath the exported path (NFS).
+	NFSServer string
+	NFSPath   string
+
+	// SizeGb is the local SSD scratch size in GB (LOCAL_SSD).
+	SizeGb int64
+}
+
+// String describes the volume for routing reasons and validation errors.
+func (v VolumeConfig) String() string {
+	switch v.Type {
+	case VolumeTypeGCS:
+		return fmt.Sprintf("gcs bucket %q at %s", v.Bucket, v.MountPath)
+	case VolumeTypeNFS:
+		return fmt.Sprintf("nfs %s:%s at %s", v.NFSServer, v.NFSPath, v.MountPath)
+	case VolumeTypeLocalSSD:
+		return fmt.Sprintf("local SSD (%d GB) at %s", v.SizeGb, v.MountPath)
+	default:
+		return fmt.Sprintf("volume at %s", v.MountPath)
+	}
+}
+
 // JobResult contains the result of submitting a batch job.
 type JobResult struct {
 	// CloudResourcePath is the full cloud-specific resource identifier.
@@ -196,6 +340,9 @@ type JobResult struct {
 
 	// InitialStatus is the job status immediately after submission.
 	InitialStatus JobStatus
+
+	// Region is the cloud region the job was created in.
+	Region string
 }
 
 // JobStatus represents the status of a batch job.
@@ -223,6 +370,11 @@ const (
 	// Maps to: GCP FAILED, AWS FAILED, Azure completed (failure).
 	JobStatusFailed JobStatus = "FAILED"
 
+	// JobStatusPreempted indicates the job failed because its Spot VMs were
+	// preempted rather than because the workload itself failed.
+	// Maps to: GCP FAILED with a preemption status event (task exit code 50001).
+	JobStatusPreempted JobStatus = "PREEMPTED"
+
 	// JobStatusCancelled indicates the job was cancelled.
 	// Maps to: GCP DELETION_IN_PROGRESS, AWS cancelled.
 	JobStatusCancelled JobStatus = "CANCELLED"
@@ -231,6 +383,64 @@ const (
 	JobStatusUnknown JobStatus = "UNKNOWN"
 )
 
+// StatusBatcher is implemented by providers that can fetch the status of
+// many jobs in one call. Pollers use it instead of one GetJobStatus per job.
+type StatusBatcher interface {
+	// GetJobStatuses returns the status of the jobs in cloudResourcePaths,
+	// keyed by path. Jobs the provider did not return should be fetched
+	// with GetJobStatus.
+	GetJobStatuses(ctx context.Context, cloudResourcePaths []string) (map[string]JobStatus, error)
+}
+
+// ListedJob is a job returned by ResourceLister.
+type ListedJob struct {
+	// Path is the job's cloud resource path.
+	Path string
+
+	// Status is the job's current status.
+	Status JobStatus
+
+	// CreateTime is when the job was created; zero if unknown.
+	CreateTime time.Time
+}
+
+// ResourceLister is implemented by providers that can list their
+// Jennah-created jobs with status and creation time. The reconciler uses it
+// to find cloud resources no database row points at.
+type ResourceLister interface {
+	// ListJobResources lists the provider's jobs whose IDs carry Jennah's
+	// "jennah-" prefix.
+	ListJobResources(ctx context.Context) ([]ListedJob, error)
+}
+
+// IsJennahJobPath reports whether the last segment of a cloud resource path
+// carries the "jennah-" prefix Jennah gives every job it creates.
+func IsJennahJobPath(path string) bool {
+	name := path[strings.LastIndexAny(path, "/:")+1:]
+	return strings.HasPrefix(name, "jennah-")
+}
+
+// StatusNotification is a job state change pushed by a provider instead of
+// being discovered by polling.
+type StatusNotification struct {
+	// JobPath is the cloud resource path of the job, as returned in
+	// JobResult.CloudResourcePath.
+	JobPath string
+
+	// Status is the job's new status. JobStatusUnknown means something about
+	// the job changed (for example one of its tasks failed) and the caller
+	// should fetch the current status with GetJobStatus.
+	Status JobStatus
+}
+
+// StatusSubscriber delivers provider status notifications.
+type StatusSubscriber interface {
+	// Receive calls handle for each notification until ctx is cancelled or
+	// the subscription fails. Notifications may arrive more than once and
+	// out of order.
+	Receive(ctx context.Context, handle func(ctx context.Context, n StatusNotification)) error
+}
+
 // ProviderConfig contains configuration for initializing a batch provider.
 type ProviderConfig struct {
 	// Provider is the cloud provider name ("gcp", "aws", "azure").
@@ -244,7 +454,7 @@ type ProviderConfig struct {
 
 	// ProviderOptions contains provider-specific configuration.
 	// Examples:
-	//   - GCP: empty (uses projectID and region)
+	//   - GCP: {"notification_topic": "projects/p/topics/t"} (optional; Pub/Sub topic for job state changes)
 	//   - AWS: {"account_id": "123456789", "job_queue": "my-queue"}
 	//   - Azure: {"subscription_id": "...", "resource_group": "..."}
 	ProviderOptions map[string]string
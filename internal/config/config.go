@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
 
 	batch "github.com/alphauslabs/jennah/internal/cloudexec"
 )
@@ -13,9 +14,18 @@ type Config struct {
 	// ServerPort is the port the worker listens on.
 	ServerPort string
 
+	// Environment names the deployment (e.g. "dev", "prod"). It is attached
+	// to every cloud resource as the jennah-env label.
+	Environment string
+
 	// BatchProvider configuration for cloud batch service (primary provider).
 	BatchProvider batch.ProviderConfig
 
+	// BatchRegions lists the Cloud Batch regions in preference order.
+	// The first entry is always BatchProvider.Region; later entries are
+	// failover regions used when the preferred region is out of capacity.
+	BatchRegions []string
+
 	// CloudRun configuration for Cloud Run Jobs provider (for SIMPLE/MEDIUM workloads).
 	CloudRun CloudRunConfig
 
@@ -40,6 +50,12 @@ type PubSubConfig struct {
 	// Example: "jennah-events-" → topic "jennah-events-<tenantId>".
 	// Defaults to "jennah-events-".
 	TopicPrefix string
+
+	// BatchStatusTopic is the topic ID Cloud Batch publishes job and task
+	// state changes to. When set, jobs are submitted with notifications and
+	// the worker subscribes to them, polling Cloud Batch only as a slow
+	// safety net. Independent of Enabled; empty disables notifications.
+	BatchStatusTopic string
 }
 
 // CloudRunConfig contains Cloud Run Jobs provider configuration.
@@ -56,6 +72,9 @@ type CloudRunConfig struct {
 	// If not set, defaults to BatchProvider.Region.
 	Region string
 
+	// Regions lists the Cloud Run regions in preference order, starting with Region.
+	Regions []string
+
 	// ServiceAccount is the GCP service account email for Cloud Run executions (optional).
 	// If not set, uses the default service account.
 	ServiceAccount string
@@ -83,7 +102,8 @@ type DatabaseConfig struct {
 // This follows the 12-factor app methodology for configuration.
 func LoadFromEnv() (*Config, error) {
 	config := &Config{
-		ServerPort: getEnvOrDefault("WORKER_PORT", "8081"),
+		ServerPort:  getEnvOrDefault("WORKER_PORT", "8081"),
+		Environment: getEnvOrDefault("JENNAH_ENV", "dev"),
 		BatchProvider: batch.ProviderConfig{
 			Provider:        getEnvOrDefault("BATCH_PROVIDER", "gcp"),
 			Region:          os.Getenv("BATCH_REGION"),
@@ -99,6 +119,13 @@ func LoadFromEnv() (*Config, error) {
 		},
 	}
 
+	// Load failover regions. BATCH_REGIONS may be used on its own, in which
+	// case its first entry becomes the primary region.
+	config.BatchRegions = regionList(config.BatchProvider.Region, os.Getenv("BATCH_REGIONS"))
+	if config.BatchProvider.Region == "" && len(config.BatchRegions) > 0 {
+		config.BatchProvider.Region = config.BatchRegions[0]
+	}
+
 	// Load Cloud Run Jobs configuration
 	config.CloudRun = CloudRunConfig{
 		Enabled: os.Getenv("CLOUD_RUN_ENABLED") == "true",
@@ -115,6 +142,12 @@ func LoadFromEnv() (*Config, error) {
 	} else {
 		config.CloudRun.Region = config.BatchProvider.Region
 	}
+	// CLOUD_RUN_REGIONS without CLOUD_RUN_REGION takes its primary from the list.
+	crRegions := regionList("", os.Getenv("CLOUD_RUN_REGIONS"))
+	if os.Getenv("CLOUD_RUN_REGION") == "" && len(crRegions) > 0 {
+		config.CloudRun.Region = crRegions[0]
+	}
+	config.CloudRun.Regions = regionList(config.CloudRun.Region, strings.Join(crRegions, ","))
 	// Load optional Cloud Run service account
 	if crServiceAccount := os.Getenv("CLOUD_RUN_SERVICE_ACCOUNT"); crServiceAccount != "" {
 		config.CloudRun.ServiceAccount = crServiceAccount
@@ -130,6 +163,10 @@ func LoadFromEnv() (*Config, error) {
 	} else {
 		config.PubSub.ProjectID = config.BatchProvider.ProjectID
 	}
+	if batchStatusTopic := os.Getenv("BATCH_STATUS_TOPIC"); batchStatusTopic != "" {
+		config.PubSub.BatchStatusTopic = batchStatusTopic
+		config.BatchProvider.ProviderOptions["notification_topic"] = fmt.Sprintf("projects/%s/topics/%s", config.PubSub.ProjectID, batchStatusTopic)
+	}
 
 	// Load provider-specific batch options
 	if awsAccountID := os.Getenv("AWS_ACCOUNT_ID"); awsAccountID != "" {
@@ -245,6 +282,22 @@ func getEnvOrDefault(key, defaultValue string) string {
 	return defaultValue
 }
 
+// regionList returns primary followed by the comma-separated regions in csv,
+// skipping blanks and duplicates.
+func regionList(primary, csv string) []string {
+	var regions []string
+	seen := make(map[string]bool)
+	for _, r := range append([]string{primary}, strings.Split(csv, ",")...) {
+		r = strings.TrimSpace(r)
+		if r == "" || seen[r] {
+			continue
+		}
+		seen[r] = true
+		regions = append(regions, r)
+	}
+	return regions
+}
+
 // getEnvAsInt returns the environment variable as an integer or a default if not set.
 func getEnvAsInt(key string, defaultValue int) int {
 	if value := os.Getenv(key); value != "" {
@@ -272,22 +325,38 @@ New (environment variables):
   BATCH_PROVIDER=gcp
   BATCH_PROJECT_ID=labs-169405
   BATCH_REGION=asia-northeast1
+  BATCH_REGIONS=asia-northeast1,asia-northeast2  # Optional; failover regions in preference order
   DB_PROVIDER=spanner
   DB_PROJECT_ID=labs-169405
   DB_INSTANCE=alphaus-dev
   DB_DATABASE=main
   WORKER_PORT=8081
+  JENNAH_ENV=dev   # Optional; jennah-env label on every cloud resource
 
 Cloud Run Jobs configuration (for SIMPLE/MEDIUM workloads):
   CLOUD_RUN_ENABLED=true
   CLOUD_RUN_PROJECT_ID=labs-169405  # Optional; defaults to BATCH_PROJECT_ID
   CLOUD_RUN_REGION=asia-northeast1   # Optional; defaults to BATCH_REGION
+  CLOUD_RUN_REGIONS=asia-northeast1,asia-northeast2  # Optional; failover regions in preference order
   CLOUD_RUN_SERVICE_ACCOUNT=optional-sa@project.iam.gserviceaccount.com  # Optional
 
 Pub/Sub notification configuration:
   PUBSUB_ENABLED=true
   PUBSUB_PROJECT_ID=labs-169405     # Optional; defaults to BATCH_PROJECT_ID
   PUBSUB_TOPIC_ID=jennah-job-events # Required when PUBSUB_ENABLED=true
+  BATCH_STATUS_TOPIC=jennah-batch-status  # Optional; Cloud Batch state change notifications
+  WORKER_STATUS_SAFETY_POLL_SECONDS=60    # Optional; Cloud Batch poll interval when notifications are on
+
+Provider retries and circuit breaking (worker):
+  PROVIDER_MAX_ATTEMPTS=4            # Optional; attempts per provider call
+  PROVIDER_RATE_LIMIT=20             # Optional; calls per second per regional provider
+  PROVIDER_BREAKER_THRESHOLD=5       # Optional; consecutive transient failures that open the breaker
+  PROVIDER_BREAKER_OPEN_SECONDS=30   # Optional; how long an open breaker fails fast
+
+Resource reconciliation (worker):
+  RECONCILE_INTERVAL_MINUTES=60   # Optional; 0 disables the periodic run
+  RECONCILE_ORPHAN_ACTION=none    # Optional; none, cancel or delete
+  RECONCILE_FAIL_MISSING=false    # Optional; fail active jobs whose resource is gone
 
 Example for AWS:
   BATCH_PROVIDER=aws
@@ -4,6 +4,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"sort"
+	"strings"
 
 	batch "github.com/alphauslabs/jennah/internal/cloudexec"
 )
@@ -13,6 +15,26 @@ type JobConfigFile struct {
 	DefaultResources     ResourceProfile            `json:"defaultResources"`
 	ResourceProfiles     map[string]ResourceProfile `json:"resourceProfiles"`
 	MachineTypeResources map[string]ResourceProfile `json:"machineTypeResources"`
+	PreemptionPolicy     PreemptionPolicy           `json:"preemptionPolicy"`
+	Accelerators         map[string]AcceleratorSpec `json:"accelerators"`
+}
+
+// AcceleratorSpec is a catalog entry for one GPU type jobs may request.
+// Catalog keys are GCP accelerator types, e.g. "nvidia-tesla-t4".
+type AcceleratorSpec struct {
+	// Counts lists the accelerators-per-VM values GCP supports for this type.
+	Counts []int64 `json:"counts"`
+}
+
+// PreemptionPolicy is the default resubmission policy for Spot jobs that
+// do not set one in their SubmitJobRequest.
+type PreemptionPolicy struct {
+	// MaxResubmits is how many times a preempted job is resubmitted.
+	MaxResubmits int32 `json:"maxResubmits"`
+
+	// StandardAfter switches resubmissions to STANDARD provisioning after
+	// this many preemptions. 0 keeps using Spot.
+	StandardAfter int32 `json:"standardAfter"`
 }
 
 // ResourceProfile defines resource requirements for a job.
@@ -59,6 +81,29 @@ func (c *JobConfigFile) GetResourceRequirements(profileName string) *batch.Resou
 	}
 }
 
+// ValidateAccelerator checks an accelerator type and per-VM count against the
+// accelerator catalog.
+func (c *JobConfigFile) ValidateAccelerator(acceleratorType string, count int64) error {
+	spec, ok := c.Accelerators[acceleratorType]
+	if !ok {
+		if len(c.Accelerators) == 0 {
+			return fmt.Errorf("accelerator %q requested but no accelerators are configured", acceleratorType)
+		}
+		known := make([]string, 0, len(c.Accelerators))
+		for t := range c.Accelerators {
+			known = append(known, t)
+		}
+		sort.Strings(known)
+		return fmt.Errorf("unknown accelerator type %q (available: %s)", acceleratorType, strings.Join(known, ", "))
+	}
+	for _, allowed := range spec.Counts {
+		if allowed == count {
+			return nil
+		}
+	}
+	return fmt.Errorf("accelerator %s does not support count %d (allowed: %v)", acceleratorType, count, spec.Counts)
+}
+
 // GetMachineTypeResources returns resource requirements for a machine type.
 // If machineType is empty or not found, returns nil.
 func (c *JobConfigFile) GetMachineTypeResources(machineType string) *batch.ResourceRequirements {
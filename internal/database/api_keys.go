@@ -0,0 +1,138 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"cloud.google.com/go/spanner"
+	"google.golang.org/api/iterator"
+	"google.golang.org/grpc/codes"
+)
+
+var apiKeyColumns = []string{
+	"TenantId", "KeyId", "Name", "SecretHash", "Salt", "Scopes",
+	"CreatedBy", "CreatedAt", "ExpiresAt", "LastUsedAt", "RevokedAt",
+}
+
+// InsertApiKey stores a new API key. CreatedAt is set to the commit time.
+func (c *Client) InsertApiKey(ctx context.Context, k *ApiKey) error {
+	_, err := c.client.Apply(ctx, []*spanner.Mutation{
+		spanner.Insert("ApiKeys", apiKeyColumns,
+			[]interface{}{
+				k.TenantId, k.KeyId, k.Name, k.SecretHash, k.Salt, k.Scopes,
+				k.CreatedBy, spanner.CommitTimestamp, k.ExpiresAt, nil, nil,
+			},
+		),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to insert API key: %w", err)
+	}
+	return nil
+}
+
+// GetApiKey returns the API key keyID of any tenant, or nil when there is
+// none.
+func (c *Client) GetApiKey(ctx context.Context, keyID string) (*ApiKey, error) {
+	stmt := spanner.Statement{
+		SQL: `SELECT ` + columnList(apiKeyColumns) + `
+		      FROM ApiKeys@{FORCE_INDEX=ApiKeysByKeyId}
+		      WHERE KeyId = @keyId`,
+		Params: map[string]interface{}{"keyId": keyID},
+	}
+	iter := c.client.Single().Query(ctx, stmt)
+	defer iter.Stop()
+
+	row, err := iter.Next()
+	if err == iterator.Done {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get API key: %w", err)
+	}
+	var k ApiKey
+	if err := row.ToStruct(&k); err != nil {
+		return nil, fmt.Errorf("failed to parse API key: %w", err)
+	}
+	return &k, nil
+}
+
+// ListApiKeys returns a tenant's API keys, newest first.
+func (c *Client) ListApiKeys(ctx context.Context, tenantID string) ([]*ApiKey, error) {
+	stmt := spanner.Statement{
+		SQL: `SELECT ` + columnList(apiKeyColumns) + `
+		      FROM ApiKeys
+		      WHERE TenantId = @tenantId
+		      ORDER BY CreatedAt DESC`,
+		Params: map[string]interface{}{"tenantId": tenantID},
+	}
+	iter := c.client.Single().Query(ctx, stmt)
+	defer iter.Stop()
+
+	var keys []*ApiKey
+	for {
+		row, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to list API keys: %w", err)
+		}
+		var k ApiKey
+		if err := row.ToStruct(&k); err != nil {
+			return nil, fmt.Errorf("failed to parse API key: %w", err)
+		}
+		keys = append(keys, &k)
+	}
+	return keys, nil
+}
+
+// RevokeApiKey marks a tenant's API key revoked and returns it. A key that
+// is already revoked keeps its original revocation time. It returns nil
+// when the tenant has no such key.
+func (c *Client) RevokeApiKey(ctx context.Context, tenantID, keyID string) (*ApiKey, error) {
+	var revoked *ApiKey
+	_, err := c.client.ReadWriteTransaction(ctx, func(ctx context.Context, txn *spanner.ReadWriteTransaction) error {
+		revoked = nil
+		row, err := txn.ReadRow(ctx, "ApiKeys", spanner.Key{tenantID, keyID}, apiKeyColumns)
+		if spanner.ErrCode(err) == codes.NotFound {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		var k ApiKey
+		if err := row.ToStruct(&k); err != nil {
+			return err
+		}
+		if k.RevokedAt == nil {
+			now := time.Now().UTC()
+			k.RevokedAt = &now
+			if err := txn.BufferWrite([]*spanner.Mutation{
+				spanner.Update("ApiKeys", []string{"TenantId", "KeyId", "RevokedAt"}, []interface{}{tenantID, keyID, now}),
+			}); err != nil {
+				return err
+			}
+		}
+		revoked = &k
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to revoke API key %s: %w", keyID, err)
+	}
+	return revoked, nil
+}
+
+// TouchApiKey records that an API key was used at usedAt.
+func (c *Client) TouchApiKey(ctx context.Context, tenantID, keyID string, usedAt time.Time) error {
+	_, err := c.client.Apply(ctx, []*spanner.Mutation{
+		spanner.Update("ApiKeys",
+			[]string{"TenantId", "KeyId", "LastUsedAt"},
+			[]interface{}{tenantID, keyID, usedAt},
+		),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to record use of API key %s: %w", keyID, err)
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"cloud.google.com/go/spanner"
+	"google.golang.org/api/iterator"
+)
+
+var auditEventColumns = []string{
+	"TenantId", "EventId", "OccurredAt", "Procedure", "ActorType", "ActorId",
+	"ActorEmail", "TargetType", "TargetId", "RequestJson", "ResultCode",
+	"ErrorMessage", "ClientIp", "UserAgent",
+}
+
+// InsertAuditEvent appends an event. OccurredAt is set to the commit time.
+// There is deliberately no way to update or delete events.
+func (c *Client) InsertAuditEvent(ctx context.Context, e *AuditEvent) error {
+	_, err := c.client.Apply(ctx, []*spanner.Mutation{
+		spanner.Insert("AuditEvents", auditEventColumns,
+			[]interface{}{
+				e.TenantId, e.EventId, spanner.CommitTimestamp, e.Procedure, e.ActorType, e.ActorId,
+				e.ActorEmail, e.TargetType, e.TargetId, e.RequestJson, e.ResultCode,
+				e.ErrorMessage, e.ClientIp, e.UserAgent,
+			},
+		),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to insert audit event: %w", err)
+	}
+	return nil
+}
+
+// ListAuditEvents returns up to limit of a tenant's events matching filter,
+// newest first.
+func (c *Client) ListAuditEvents(ctx context.Context, tenantID string, filter AuditFilter, limit int) ([]*AuditEvent, error) {
+	conditions := []string{"TenantId = @tenantId"}
+	params := map[string]interface{}{"tenantId": tenantID, "limit": int64(limit)}
+	if filter.Procedure != "" {
+		conditions = append(conditions, "(Procedure = @procedure OR ENDS_WITH(Procedure, @method))")
+		params["procedure"] = filter.Procedure
+		params["method"] = "/" + strings.TrimPrefix(filter.Procedure, "/")
+	}
+	if filter.ActorId != "" {
+		conditions = append(conditions, "ActorId = @actorId")
+		params["actorId"] = filter.ActorId
+	}
+	if filter.TargetId != "" {
+		conditions = append(conditions, "TargetId = @targetId")
+		params["targetId"] = filter.TargetId
+	}
+	if !filter.Since.IsZero() {
+		conditions = append(conditions, "OccurredAt >= @since")
+		params["since"] = filter.Since
+	}
+	if !filter.Until.IsZero() {
+		conditions = append(conditions, "OccurredAt < @until")
+		params["until"] = filter.Until
+	}
+	if filter.After != nil {
+		conditions = append(conditions, "(OccurredAt < @afterTime OR (OccurredAt = @afterTime AND EventId < @afterId))")
+		params["afterTime"] = filter.After.OccurredAt
+		params["afterId"] = filter.After.EventId
+	}
+
+	stmt := spanner.Statement{
+		SQL: `SELECT ` + columnList(auditEventColumns) + `
+		      FROM AuditEvents@{FORCE_INDEX=AuditEventsByTime}
+		      WHERE ` + strings.Join(conditions, " AND ") + `
+		      ORDER BY OccurredAt DESC, EventId DESC
+		      LIMIT @limit`,
+		Params: params,
+	}
+	iter := c.client.Single().Query(ctx, stmt)
+	defer iter.Stop()
+
+	var events []*AuditEvent
+	for {
+		row, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to list audit events: %w", err)
+		}
+		var e AuditEvent
+		if err := row.ToStruct(&e); err != nil {
+			return nil, fmt.Errorf("failed to parse audit event: %w", err)
+		}
+		events = append(events, &e)
+	}
+	return events, nil
+}
@@ -0,0 +1,381 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"cloud.google.com/go/spanner"
+	"google.golang.org/api/iterator"
+	"google.golang.org/grpc/codes"
+)
+
+// ErrTenantHasActiveJobs is returned by MergeTenant when the tenant to merge
+// still has jobs that have not finished.
+var ErrTenantHasActiveJobs = errors.New("tenant has jobs that have not finished")
+
+// mergeBatchSize is how many jobs MergeTenant moves per transaction, which
+// keeps each commit well below Spanner's mutation limit.
+const mergeBatchSize = 50
+
+var identityColumns = []string{"OAuthProvider", "OAuthUserId", "TenantId", "Email", "LinkedAt"}
+
+// GetIdentity returns the identity of an OAuth user, or nil when it is not
+// recorded.
+func (c *Client) GetIdentity(ctx context.Context, oauthProvider, oauthUserId string) (*Identity, error) {
+	row, err := c.client.Single().ReadRow(ctx, "Identities", spanner.Key{oauthProvider, oauthUserId}, identityColumns)
+	if spanner.ErrCode(err) == codes.NotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get identity: %w", err)
+	}
+	var identity Identity
+	if err := row.ToStruct(&identity); err != nil {
+		return nil, fmt.Errorf("failed to parse identity: %w", err)
+	}
+	return &identity, nil
+}
+
+// ListIdentities returns the identities linked to a tenant, oldest first.
+func (c *Client) ListIdentities(ctx context.Context, tenantID string) ([]*Identity, error) {
+	stmt := spanner.Statement{
+		SQL: `SELECT ` + columnList(identityColumns) + `
+		      FROM Identities@{FORCE_INDEX=IdentitiesByTenant}
+		      WHERE TenantId = @tenantId
+		      ORDER BY LinkedAt`,
+		Params: map[string]interface{}{"tenantId": tenantID},
+	}
+	iter := c.client.Single().Query(ctx, stmt)
+	defer iter.Stop()
+
+	var identities []*Identity
+	for {
+		row, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to list identities: %w", err)
+		}
+		var identity Identity
+		if err := row.ToStruct(&identity); err != nil {
+			return nil, fmt.Errorf("failed to parse identity: %w", err)
+		}
+		identities = append(identities, &identity)
+	}
+	return identities, nil
+}
+
+// LinkIdentities points each identity at tenantID. Identities already
+// recorded keep their LinkedAt unless they move to another tenant.
+func (c *Client) LinkIdentities(ctx context.Context, tenantID string, identities ...*Identity) error {
+	_, err := c.client.ReadWriteTransaction(ctx, func(ctx context.Context, txn *spanner.ReadWriteTransaction) error {
+		var mutations []*spanner.Mutation
+		for _, identity := range identities {
+			row, err := txn.ReadRow(ctx, "Identities", spanner.Key{identity.OAuthProvider, identity.OAuthUserId}, []string{"TenantId"})
+			if err != nil && spanner.ErrCode(err) != codes.NotFound {
+				return err
+			}
+			if err == nil {
+				var current string
+				if err := row.Columns(&current); err != nil {
+					return err
+				}
+				if current == tenantID {
+					continue
+				}
+			}
+			mutations = append(mutations, spanner.InsertOrUpdate("Identities", identityColumns,
+				[]interface{}{identity.OAuthProvider, identity.OAuthUserId, tenantID, identity.Email, spanner.CommitTimestamp},
+			))
+		}
+		return txn.BufferWrite(mutations)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to link identities to tenant %s: %w", tenantID, err)
+	}
+	return nil
+}
+
+// DeleteIdentity forgets an identity, so its next sign-in creates a new
+// personal tenant.
+func (c *Client) DeleteIdentity(ctx context.Context, oauthProvider, oauthUserId string) error {
+	_, err := c.client.Apply(ctx, []*spanner.Mutation{
+		spanner.Delete("Identities", spanner.Key{oauthProvider, oauthUserId}),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete identity: %w", err)
+	}
+	return nil
+}
+
+// ListOAuthUserIds returns the distinct IDs under which users of
+// oauthProvider are recorded in Tenants, Identities and Memberships.
+func (c *Client) ListOAuthUserIds(ctx context.Context, oauthProvider string) ([]string, error) {
+	stmt := spanner.Statement{
+		SQL: `SELECT OAuthUserId FROM Tenants WHERE OAuthProvider = @provider
+		      UNION DISTINCT
+		      SELECT OAuthUserId FROM Identities WHERE OAuthProvider = @provider
+		      UNION DISTINCT
+		      SELECT OAuthUserId FROM Memberships WHERE OAuthProvider = @provider`,
+		Params: map[string]interface{}{"provider": oauthProvider},
+	}
+	var ids []string
+	err := c.client.Single().Query(ctx, stmt).Do(func(row *spanner.Row) error {
+		var id string
+		if err := row.Columns(&id); err != nil {
+			return err
+		}
+		ids = append(ids, id)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list %s user IDs: %w", oauthProvider, err)
+	}
+	return ids, nil
+}
+
+// RekeyOAuthUser records the oauthProvider user known as from under the ID
+// to instead: their tenants, identity and memberships move in one
+// transaction.
+func (c *Client) RekeyOAuthUser(ctx context.Context, oauthProvider, from, to string) error {
+	_, err := c.client.ReadWriteTransaction(ctx, func(ctx context.Context, txn *spanner.ReadWriteTransaction) error {
+		_, err := txn.Update(ctx, spanner.Statement{
+			SQL: `UPDATE Tenants SET OAuthUserId = @to, UpdatedAt = PENDING_COMMIT_TIMESTAMP()
+			      WHERE OAuthProvider = @provider AND OAuthUserId = @from`,
+			Params: map[string]interface{}{"provider": oauthProvider, "from": from, "to": to},
+		})
+		if err != nil {
+			return err
+		}
+
+		var mutations []*spanner.Mutation
+		row, err := txn.ReadRow(ctx, "Identities", spanner.Key{oauthProvider, from}, identityColumns)
+		if err != nil && spanner.ErrCode(err) != codes.NotFound {
+			return err
+		}
+		if err == nil {
+			var identity Identity
+			if err := row.ToStruct(&identity); err != nil {
+				return err
+			}
+			mutations = append(mutations,
+				spanner.InsertOrUpdate("Identities", identityColumns,
+					[]interface{}{oauthProvider, to, identity.TenantId, identity.Email, identity.LinkedAt}),
+				spanner.Delete("Identities", spanner.Key{oauthProvider, from}),
+			)
+		}
+
+		stmt := spanner.Statement{
+			SQL: `SELECT ` + columnList(membershipColumns) + `
+			      FROM Memberships@{FORCE_INDEX=MembershipsByUser}
+			      WHERE OAuthProvider = @provider AND OAuthUserId = @from`,
+			Params: map[string]interface{}{"provider": oauthProvider, "from": from},
+		}
+		err = txn.Query(ctx, stmt).Do(func(row *spanner.Row) error {
+			var m Membership
+			if err := row.ToStruct(&m); err != nil {
+				return err
+			}
+			mutations = append(mutations,
+				spanner.InsertOrUpdate("Memberships", membershipColumns,
+					[]interface{}{m.TenantId, oauthProvider, to, m.Email, m.Role, m.CreatedAt}),
+				spanner.Delete("Memberships", spanner.Key{m.TenantId, oauthProvider, from}),
+			)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		return txn.BufferWrite(mutations)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to rekey %s user %s: %w", oauthProvider, from, err)
+	}
+	return nil
+}
+
+// MergeTenant moves the finished jobs (with their state transitions),
+// notifications, API keys and memberships of tenant from into tenant into,
+// points from's identities at into, and deletes from. Members of both keep
+// their role in into. It fails with ErrTenantHasActiveJobs, before or
+// part-way through moving, while from has jobs that have not finished.
+func (c *Client) MergeTenant(ctx context.Context, from, into string) (*MergeStats, error) {
+	active, err := c.countActiveJobs(ctx, from)
+	if err != nil {
+		return nil, err
+	}
+	if active > 0 {
+		return nil, ErrTenantHasActiveJobs
+	}
+
+	stats := &MergeStats{}
+	for {
+		moved, err := c.moveJobBatch(ctx, from, into)
+		if err != nil {
+			return nil, fmt.Errorf("failed to move jobs of tenant %s: %w", from, err)
+		}
+		stats.Jobs += moved
+		if moved < mergeBatchSize {
+			break
+		}
+	}
+
+	_, err = c.client.ReadWriteTransaction(ctx, func(ctx context.Context, txn *spanner.ReadWriteTransaction) error {
+		stats.Notifications = 0
+		var remaining int64
+		if err := txn.Query(ctx, spanner.Statement{
+			SQL:    `SELECT COUNT(*) FROM Jobs WHERE TenantId = @from`,
+			Params: map[string]interface{}{"from": from},
+		}).Do(func(row *spanner.Row) error { return row.Columns(&remaining) }); err != nil {
+			return err
+		}
+		if remaining > 0 {
+			return ErrTenantHasActiveJobs
+		}
+
+		var mutations []*spanner.Mutation
+		for _, t := range []struct {
+			table, sql string
+		}{
+			{"Notifications", `SELECT * FROM Notifications WHERE TenantId = @from`},
+			{"ApiKeys", `SELECT * FROM ApiKeys WHERE TenantId = @from`},
+			{"Memberships", `SELECT * FROM Memberships m WHERE TenantId = @from AND NOT EXISTS (
+			    SELECT 1 FROM Memberships o
+			    WHERE o.TenantId = @into AND o.OAuthProvider = m.OAuthProvider AND o.OAuthUserId = m.OAuthUserId)`},
+		} {
+			inserts, _, err := rekeyRows(ctx, txn, t.table, spanner.Statement{
+				SQL:    t.sql,
+				Params: map[string]interface{}{"from": from, "into": into},
+			}, into)
+			if err != nil {
+				return fmt.Errorf("failed to copy %s: %w", t.table, err)
+			}
+			if t.table == "Notifications" {
+				stats.Notifications = int64(len(inserts))
+			}
+			mutations = append(mutations, inserts...)
+		}
+
+		identities := txn.Query(ctx, spanner.Statement{
+			SQL:    `SELECT OAuthProvider, OAuthUserId FROM Identities@{FORCE_INDEX=IdentitiesByTenant} WHERE TenantId = @from`,
+			Params: map[string]interface{}{"from": from},
+		})
+		if err := identities.Do(func(row *spanner.Row) error {
+			var provider, userID string
+			if err := row.Columns(&provider, &userID); err != nil {
+				return err
+			}
+			mutations = append(mutations, spanner.Update("Identities",
+				[]string{"OAuthProvider", "OAuthUserId", "TenantId"}, []interface{}{provider, userID, into}))
+			return nil
+		}); err != nil {
+			return fmt.Errorf("failed to read identities: %w", err)
+		}
+
+		// Interleaved rows still under from (invitations, duplicate
+		// memberships) go with it.
+		mutations = append(mutations, spanner.Delete("Tenants", spanner.Key{from}))
+		return txn.BufferWrite(mutations)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to merge tenant %s into %s: %w", from, into, err)
+	}
+	return stats, nil
+}
+
+// countActiveJobs returns how many of a tenant's jobs have not finished.
+func (c *Client) countActiveJobs(ctx context.Context, tenantID string) (int64, error) {
+	stmt := spanner.Statement{
+		SQL: `SELECT COUNT(*) FROM Jobs
+		      WHERE TenantId = @tenantId AND Status NOT IN UNNEST(@finished)`,
+		Params: map[string]interface{}{
+			"tenantId": tenantID,
+			"finished": []string{JobStatusCompleted, JobStatusFailed, JobStatusCancelled},
+		},
+	}
+	var count int64
+	err := c.client.Single().Query(ctx, stmt).Do(func(row *spanner.Row) error {
+		return row.Columns(&count)
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to count active jobs: %w", err)
+	}
+	return count, nil
+}
+
+// moveJobBatch moves up to mergeBatchSize finished jobs and their state
+// transitions from one tenant to another and returns how many it moved.
+func (c *Client) moveJobBatch(ctx context.Context, from, into string) (int64, error) {
+	var moved int64
+	_, err := c.client.ReadWriteTransaction(ctx, func(ctx context.Context, txn *spanner.ReadWriteTransaction) error {
+		moved = 0
+		jobs, rows, err := rekeyRows(ctx, txn, "Jobs", spanner.Statement{
+			SQL: `SELECT * FROM Jobs
+			      WHERE TenantId = @from AND Status IN UNNEST(@finished)
+			      ORDER BY JobId LIMIT @limit`,
+			Params: map[string]interface{}{
+				"from":     from,
+				"finished": []string{JobStatusCompleted, JobStatusFailed, JobStatusCancelled},
+				"limit":    int64(mergeBatchSize),
+			},
+		}, into)
+		if err != nil || len(jobs) == 0 {
+			return err
+		}
+		jobIDs := make([]string, len(rows))
+		for i, row := range rows {
+			if err := row.ColumnByName("JobId", &jobIDs[i]); err != nil {
+				return err
+			}
+		}
+		transitions, _, err := rekeyRows(ctx, txn, "JobStateTransitions", spanner.Statement{
+			SQL:    `SELECT * FROM JobStateTransitions WHERE TenantId = @from AND JobId IN UNNEST(@jobIds)`,
+			Params: map[string]interface{}{"from": from, "jobIds": jobIDs},
+		}, into)
+		if err != nil {
+			return err
+		}
+
+		// Parents before children; deleting the old jobs removes their
+		// transitions too.
+		mutations := append(jobs, transitions...)
+		for _, id := range jobIDs {
+			mutations = append(mutations, spanner.Delete("Jobs", spanner.Key{from, id}))
+		}
+		moved = int64(len(jobIDs))
+		return txn.BufferWrite(mutations)
+	})
+	return moved, err
+}
+
+// rekeyRows runs stmt, which must select every column of table including
+// TenantId, and returns mutations inserting copies of the rows under tenant
+// into, along with the rows read.
+func rekeyRows(ctx context.Context, txn *spanner.ReadWriteTransaction, table string, stmt spanner.Statement, into string) ([]*spanner.Mutation, []*spanner.Row, error) {
+	var mutations []*spanner.Mutation
+	var rows []*spanner.Row
+	err := txn.Query(ctx, stmt).Do(func(row *spanner.Row) error {
+		cols := row.ColumnNames()
+		vals := make([]interface{}, len(cols))
+		for i, col := range cols {
+			if col == "TenantId" {
+				vals[i] = into
+				continue
+			}
+			var v spanner.GenericColumnValue
+			if err := row.Column(i, &v); err != nil {
+				return err
+			}
+			vals[i] = v
+		}
+		mutations = append(mutations, spanner.Insert(table, cols, vals))
+		rows = append(rows, row)
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return mutations, rows, nil
+}
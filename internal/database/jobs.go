@@ -2,13 +2,19 @@ package database
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
 	"cloud.google.com/go/spanner"
 	"google.golang.org/api/iterator"
+	"google.golang.org/grpc/codes"
 )
 
+// ErrJobExists is returned by InsertJobFull when the tenant already has a job
+// with the same ID, e.g. when the gateway retries a submission.
+var ErrJobExists = errors.New("job already exists")
+
 // InsertJob creates a new job with PENDING status
 func (c *Client) InsertJob(ctx context.Context, tenantID, jobID, imageUri string, commands []string) error {
 	_, err := c.client.Apply(ctx, []*spanner.Mutation{
@@ -32,6 +38,9 @@ func (c *Client) InsertJobFull(ctx context.Context, job *Job) error {
 				"BootDiskSizeGb", "UseSpotVms", "ServiceAccount", "ServiceTier",
 				"AssignedService", "MemoryMib", "CpuMillis", "MaxRunDurationSeconds",
 				"OwnerWorkerId", "PreferredWorkerId", "LeaseExpiresAt", "LastHeartbeatAt",
+				"MaxPreemptionResubmits", "StandardAfterPreemptions",
+				"AcceleratorType", "AcceleratorCount", "AcceleratorDriverVersion",
+				"VolumesJson", "RunnablesJson", "LabelsJson",
 			},
 			[]interface{}{
 				job.TenantId, job.JobId, job.Status, job.ImageUri, job.Commands,
@@ -41,9 +50,15 @@ func (c *Client) InsertJobFull(ctx context.Context, job *Job) error {
 				job.BootDiskSizeGb, job.UseSpotVms, job.ServiceAccount, job.ServiceTier,
 				job.AssignedService, job.MemoryMib, job.CpuMillis, job.MaxRunDurationSeconds,
 				job.OwnerWorkerId, job.PreferredWorkerId, job.LeaseExpiresAt, job.LastHeartbeatAt,
+				job.MaxPreemptionResubmits, job.StandardAfterPreemptions,
+				job.AcceleratorType, job.AcceleratorCount, job.AcceleratorDriverVersion,
+				job.VolumesJson, job.RunnablesJson, job.LabelsJson,
 			},
 		),
 	})
+	if spanner.ErrCode(err) == codes.AlreadyExists {
+		return fmt.Errorf("%w: %s", ErrJobExists, job.JobId)
+	}
 	return err
 }
 
@@ -51,7 +66,7 @@ func (c *Client) InsertJobFull(ctx context.Context, job *Job) error {
 func (c *Client) GetJob(ctx context.Context, tenantID, jobID string) (*Job, error) {
 	row, err := c.client.Single().ReadRow(ctx, "Jobs",
 		spanner.Key{tenantID, jobID},
-		[]string{"TenantId", "JobId", "Status", "ImageUri", "Commands", "CreatedAt", "UpdatedAt", "ScheduledAt", "StartedAt", "CompletedAt", "RetryCount", "MaxRetries", "ErrorMessage", "GcpBatchJobPath", "GcpBatchTaskGroup", "EnvVarsJson", "Name", "ResourceProfile", "MachineType", "BootDiskSizeGb", "UseSpotVms", "ServiceAccount", "ServiceTier", "AssignedService", "MemoryMib", "CpuMillis", "MaxRunDurationSeconds", "OwnerWorkerId", "PreferredWorkerId", "LeaseExpiresAt", "LastHeartbeatAt"},
+		[]string{"TenantId", "JobId", "Status", "ImageUri", "Commands", "CreatedAt", "UpdatedAt", "ScheduledAt", "StartedAt", "CompletedAt", "RetryCount", "MaxRetries", "ErrorMessage", "GcpBatchJobPath", "GcpBatchTaskGroup", "EnvVarsJson", "Name", "ResourceProfile", "MachineType", "BootDiskSizeGb", "UseSpotVms", "ServiceAccount", "ServiceTier", "AssignedService", "MemoryMib", "CpuMillis", "MaxRunDurationSeconds", "OwnerWorkerId", "PreferredWorkerId", "LeaseExpiresAt", "LastHeartbeatAt", "Region", "PreemptionCount", "MaxPreemptionResubmits", "StandardAfterPreemptions", "AttemptUseSpotVms", "AcceleratorType", "AcceleratorCount", "AcceleratorDriverVersion", "VolumesJson", "RunnablesJson", "LabelsJson", "RoutingReason"},
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get job: %w", err)
@@ -68,7 +83,7 @@ func (c *Client) GetJob(ctx context.Context, tenantID, jobID string) (*Job, erro
 // ListJobs returns all jobs for a tenant
 func (c *Client) ListJobs(ctx context.Context, tenantID string) ([]*Job, error) {
 	stmt := spanner.Statement{
-		SQL: `SELECT TenantId, JobId, Status, ImageUri, Commands, CreatedAt, UpdatedAt, ScheduledAt, StartedAt, CompletedAt, RetryCount, MaxRetries, ErrorMessage, GcpBatchJobPath, GcpBatchTaskGroup, EnvVarsJson, Name, ResourceProfile, MachineType, BootDiskSizeGb, UseSpotVms, ServiceAccount, ServiceTier, AssignedService, MemoryMib, CpuMillis, MaxRunDurationSeconds, OwnerWorkerId, PreferredWorkerId, LeaseExpiresAt, LastHeartbeatAt
+		SQL: `SELECT TenantId, JobId, Status, ImageUri, Commands, CreatedAt, UpdatedAt, ScheduledAt, StartedAt, CompletedAt, RetryCount, MaxRetries, ErrorMessage, GcpBatchJobPath, GcpBatchTaskGroup, EnvVarsJson, Name, ResourceProfile, MachineType, BootDiskSizeGb, UseSpotVms, ServiceAccount, ServiceTier, AssignedService, MemoryMib, CpuMillis, MaxRunDurationSeconds, OwnerWorkerId, PreferredWorkerId, LeaseExpiresAt, LastHeartbeatAt, Region, PreemptionCount, MaxPreemptionResubmits, StandardAfterPreemptions, AttemptUseSpotVms, AcceleratorType, AcceleratorCount, AcceleratorDriverVersion, VolumesJson, RunnablesJson, LabelsJson, RoutingReason
 		      FROM Jobs 
 		      WHERE TenantId = @tenantId 
 		      ORDER BY CreatedAt DESC`,
@@ -103,7 +118,7 @@ func (c *Client) ListJobs(ctx context.Context, tenantID string) ([]*Job, error)
 // ListJobsByStatus returns jobs for a tenant filtered by status
 func (c *Client) ListJobsByStatus(ctx context.Context, tenantID, status string) ([]*Job, error) {
 	stmt := spanner.Statement{
-		SQL: `SELECT TenantId, JobId, Status, ImageUri, Commands, CreatedAt, UpdatedAt, ScheduledAt, StartedAt, CompletedAt, RetryCount, MaxRetries, ErrorMessage, GcpBatchJobPath, GcpBatchTaskGroup, EnvVarsJson, Name, ResourceProfile, MachineType, BootDiskSizeGb, UseSpotVms, ServiceAccount, ServiceTier, AssignedService, MemoryMib, CpuMillis, MaxRunDurationSeconds, OwnerWorkerId, PreferredWorkerId, LeaseExpiresAt, LastHeartbeatAt
+		SQL: `SELECT TenantId, JobId, Status, ImageUri, Commands, CreatedAt, UpdatedAt, ScheduledAt, StartedAt, CompletedAt, RetryCount, MaxRetries, ErrorMessage, GcpBatchJobPath, GcpBatchTaskGroup, EnvVarsJson, Name, ResourceProfile, MachineType, BootDiskSizeGb, UseSpotVms, ServiceAccount, ServiceTier, AssignedService, MemoryMib, CpuMillis, MaxRunDurationSeconds, OwnerWorkerId, PreferredWorkerId, LeaseExpiresAt, LastHeartbeatAt, Region, PreemptionCount, MaxPreemptionResubmits, StandardAfterPreemptions, AttemptUseSpotVms, AcceleratorType, AcceleratorCount, AcceleratorDriverVersion, VolumesJson, RunnablesJson, LabelsJson, RoutingReason
 		      FROM Jobs@{FORCE_INDEX=JobsByStatus}
 		      WHERE TenantId = @tenantId AND Status = @status 
 		      ORDER BY CreatedAt DESC`,
@@ -150,12 +165,13 @@ func (c *Client) UpdateJobStatus(ctx context.Context, tenantID, jobID, status st
 	return nil
 }
 
-// UpdateJobStatusAndGcpBatchJobPath updates the status, GCP Batch job path, service tier, and assigned service of a job.
-func (c *Client) UpdateJobStatusAndGcpBatchJobPath(ctx context.Context, tenantID, jobID, status, gcpBatchJobPath, serviceTier, assignedService string) error {
+// UpdateJobStatusAndGcpBatchJobPath updates the status, GCP Batch job path, service tier, assigned service,
+// the reason routing chose that service, and the region the provider placed the job in.
+func (c *Client) UpdateJobStatusAndGcpBatchJobPath(ctx context.Context, tenantID, jobID, status, gcpBatchJobPath, serviceTier, assignedService, routingReason, region string) error {
 	_, err := c.client.Apply(ctx, []*spanner.Mutation{
 		spanner.Update("Jobs",
-			[]string{"TenantId", "JobId", "Status", "GcpBatchJobPath", "ServiceTier", "AssignedService", "UpdatedAt"},
-			[]any{tenantID, jobID, status, gcpBatchJobPath, serviceTier, assignedService, spanner.CommitTimestamp},
+			[]string{"TenantId", "JobId", "Status", "GcpBatchJobPath", "ServiceTier", "AssignedService", "RoutingReason", "Region", "UpdatedAt"},
+			[]any{tenantID, jobID, status, gcpBatchJobPath, serviceTier, assignedService, spanner.NullString{StringVal: routingReason, Valid: routingReason != ""}, spanner.NullString{StringVal: region, Valid: region != ""}, spanner.CommitTimestamp},
 		),
 	})
 	if err != nil {
@@ -164,6 +180,26 @@ func (c *Client) UpdateJobStatusAndGcpBatchJobPath(ctx context.Context, tenantID
 	return nil
 }
 
+// RecordPreemption stores the job's preemption count and the provisioning
+// model its next attempt will use, and adds the preempted attempt's resource
+// path to PreviousJobPaths. The requested UseSpotVms is left as is.
+func (c *Client) RecordPreemption(ctx context.Context, tenantID, jobID string, preemptionCount int64, attemptUseSpotVms bool, attemptPath string) error {
+	_, err := c.client.ReadWriteTransaction(ctx, func(ctx context.Context, txn *spanner.ReadWriteTransaction) error {
+		_, err := txn.Update(ctx, spanner.Statement{
+			SQL: `UPDATE Jobs SET PreemptionCount = @count, AttemptUseSpotVms = @spot,
+			             PreviousJobPaths = ARRAY_CONCAT(IFNULL(PreviousJobPaths, []), [@path]),
+			             UpdatedAt = PENDING_COMMIT_TIMESTAMP()
+			      WHERE TenantId = @tenant AND JobId = @job`,
+			Params: map[string]interface{}{"tenant": tenantID, "job": jobID, "count": preemptionCount, "spot": attemptUseSpotVms, "path": attemptPath},
+		})
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to record preemption: %w", err)
+	}
+	return nil
+}
+
 // CompleteJob marks a job as completed with a completion timestamp
 func (c *Client) CompleteJob(ctx context.Context, tenantID, jobID string) error {
 	now := time.Now()
@@ -253,7 +289,7 @@ func (c *Client) DeleteJob(ctx context.Context, tenantID, jobID string) error {
 // ListActiveJobs returns all active (non-terminal) jobs across tenants that have a cloud resource path.
 func (c *Client) ListActiveJobs(ctx context.Context) ([]*Job, error) {
 	stmt := spanner.Statement{
-		SQL: `SELECT TenantId, JobId, Status, ImageUri, Commands, CreatedAt, UpdatedAt, ScheduledAt, StartedAt, CompletedAt, RetryCount, MaxRetries, ErrorMessage, GcpBatchJobPath, GcpBatchTaskGroup, EnvVarsJson, Name, ResourceProfile, MachineType, BootDiskSizeGb, UseSpotVms, ServiceAccount, ServiceTier, AssignedService, MemoryMib, CpuMillis, MaxRunDurationSeconds, OwnerWorkerId, PreferredWorkerId, LeaseExpiresAt, LastHeartbeatAt
+		SQL: `SELECT TenantId, JobId, Status, ImageUri, Commands, CreatedAt, UpdatedAt, ScheduledAt, StartedAt, CompletedAt, RetryCount, MaxRetries, ErrorMessage, GcpBatchJobPath, GcpBatchTaskGroup, EnvVarsJson, Name, ResourceProfile, MachineType, BootDiskSizeGb, UseSpotVms, ServiceAccount, ServiceTier, AssignedService, MemoryMib, CpuMillis, MaxRunDurationSeconds, OwnerWorkerId, PreferredWorkerId, LeaseExpiresAt, LastHeartbeatAt, Region, PreemptionCount, MaxPreemptionResubmits, StandardAfterPreemptions, AttemptUseSpotVms, AcceleratorType, AcceleratorCount, AcceleratorDriverVersion, VolumesJson, RunnablesJson, LabelsJson, RoutingReason
 		      FROM Jobs
 		      WHERE Status IN (@pending, @scheduled, @running)
 		        AND GcpBatchJobPath IS NOT NULL
@@ -288,6 +324,77 @@ func (c *Client) ListActiveJobs(ctx context.Context) ([]*Job, error) {
 	return jobs, nil
 }
 
+// ListJobsCreatedSince returns up to limit jobs across tenants created at or
+// after since, most recent first.
+func (c *Client) ListJobsCreatedSince(ctx context.Context, since time.Time, limit int64) ([]*Job, error) {
+	stmt := spanner.Statement{
+		SQL: `SELECT TenantId, JobId, Status, ImageUri, Commands, CreatedAt, UpdatedAt, ScheduledAt, StartedAt, CompletedAt, RetryCount, MaxRetries, ErrorMessage, GcpBatchJobPath, GcpBatchTaskGroup, EnvVarsJson, Name, ResourceProfile, MachineType, BootDiskSizeGb, UseSpotVms, ServiceAccount, ServiceTier, AssignedService, MemoryMib, CpuMillis, MaxRunDurationSeconds, OwnerWorkerId, PreferredWorkerId, LeaseExpiresAt, LastHeartbeatAt, Region, PreemptionCount, MaxPreemptionResubmits, StandardAfterPreemptions, AttemptUseSpotVms, AcceleratorType, AcceleratorCount, AcceleratorDriverVersion, VolumesJson, RunnablesJson, LabelsJson, RoutingReason
+		      FROM Jobs
+		      WHERE CreatedAt >= @since
+		      ORDER BY CreatedAt DESC
+		      LIMIT @limit`,
+		Params: map[string]interface{}{
+			"since": since,
+			"limit": limit,
+		},
+	}
+
+	iter := c.client.Single().Query(ctx, stmt)
+	defer iter.Stop()
+
+	var jobs []*Job
+	for {
+		row, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to iterate jobs: %w", err)
+		}
+
+		var job Job
+		if err := row.ToStruct(&job); err != nil {
+			return nil, fmt.Errorf("failed to parse job: %w", err)
+		}
+		jobs = append(jobs, &job)
+	}
+
+	return jobs, nil
+}
+
+// ListJobsWithResources returns every job that records a cloud resource
+// path, with only the columns the reconciler needs: TenantId, JobId, Status,
+// GcpBatchJobPath, ServiceTier, AssignedService and UpdatedAt.
+func (c *Client) ListJobsWithResources(ctx context.Context) ([]*Job, error) {
+	stmt := spanner.Statement{
+		SQL: `SELECT TenantId, JobId, Status, GcpBatchJobPath, PreviousJobPaths, ServiceTier, AssignedService, UpdatedAt
+		      FROM Jobs
+		      WHERE GcpBatchJobPath IS NOT NULL`,
+	}
+
+	iter := c.client.Single().Query(ctx, stmt)
+	defer iter.Stop()
+
+	var jobs []*Job
+	for {
+		row, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to iterate jobs with resources: %w", err)
+		}
+
+		var job Job
+		if err := row.ToStruct(&job); err != nil {
+			return nil, fmt.Errorf("failed to parse job: %w", err)
+		}
+		jobs = append(jobs, &job)
+	}
+
+	return jobs, nil
+}
+
 // TryClaimOrRenewJobLease attempts to claim/renew ownership for an active job.
 // Returns true when caller becomes/continues owner.
 func (c *Client) TryClaimOrRenewJobLease(ctx context.Context, tenantID, jobID, workerID string, leaseUntil time.Time) (bool, error) {
@@ -306,18 +413,50 @@ func (c *Client) TryClaimOrRenewJobLease(ctx context.Context, tenantID, jobID, w
 			return fmt.Errorf("failed to parse job lease state: %w", err)
 		}
 
-		if status == JobStatusCompleted || status == JobStatusFailed || status == JobStatusCancelled {
+		now := time.Now().UTC()
+		if !leaseClaimable(status, ownerWorkerID, preferredWorkerID, leaseExpiresAt, workerID, now) {
 			return nil
 		}
 
-		now := time.Now().UTC()
-		isOwner := ownerWorkerID.Valid && ownerWorkerID.StringVal == workerID
-		leaseExpired := !leaseExpiresAt.Valid || leaseExpiresAt.Time.Before(now)
-		isUnowned := !ownerWorkerID.Valid || ownerWorkerID.StringVal == ""
-		preferredTakeover := preferredWorkerID.Valid && preferredWorkerID.StringVal == workerID && !isOwner
+		mutation := spanner.Update("Jobs",
+			[]string{"TenantId", "JobId", "OwnerWorkerId", "LeaseExpiresAt", "LastHeartbeatAt", "UpdatedAt"},
+			[]interface{}{tenantID, jobID, workerID, leaseUntil, now, spanner.CommitTimestamp},
+		)
+		if err := txn.BufferWrite([]*spanner.Mutation{mutation}); err != nil {
+			return fmt.Errorf("failed to buffer lease mutation: %w", err)
+		}
+		claimed = true
+		return nil
+	})
+
+	if err != nil {
+		return false, fmt.Errorf("failed to claim/renew lease: %w", err)
+	}
 
-		canClaim := isOwner || leaseExpired || isUnowned || preferredTakeover
-		if !canClaim {
+	return claimed, nil
+}
+
+// ClaimStalledSubmission takes the lease of a job recorded PENDING but never
+// submitted to a provider, once the lease of the worker that recorded it has
+// expired, as when that worker crashed in between. It returns true when
+// workerID now holds the lease and should resume the submission.
+func (c *Client) ClaimStalledSubmission(ctx context.Context, tenantID, jobID, workerID string, leaseUntil time.Time) (bool, error) {
+	claimed := false
+	_, err := c.client.ReadWriteTransaction(ctx, func(ctx context.Context, txn *spanner.ReadWriteTransaction) error {
+		row, err := txn.ReadRow(ctx, "Jobs", spanner.Key{tenantID, jobID}, []string{"Status", "GcpBatchJobPath", "LeaseExpiresAt"})
+		if err != nil {
+			return fmt.Errorf("failed to read job submission state: %w", err)
+		}
+
+		var status string
+		var path spanner.NullString
+		var leaseExpiresAt spanner.NullTime
+		if err := row.Columns(&status, &path, &leaseExpiresAt); err != nil {
+			return fmt.Errorf("failed to parse job submission state: %w", err)
+		}
+
+		now := time.Now().UTC()
+		if status != JobStatusPending || path.Valid || (leaseExpiresAt.Valid && !leaseExpiresAt.Time.Before(now)) {
 			return nil
 		}
 
@@ -333,8 +472,116 @@ func (c *Client) TryClaimOrRenewJobLease(ctx context.Context, tenantID, jobID, w
 	})
 
 	if err != nil {
-		return false, fmt.Errorf("failed to claim/renew lease: %w", err)
+		return false, fmt.Errorf("failed to claim stalled submission: %w", err)
 	}
 
 	return claimed, nil
 }
+
+// maxLeaseRenewalsPerTxn bounds the jobs renewed in one transaction so the
+// buffered mutations stay well inside Spanner's per-commit limit.
+const maxLeaseRenewalsPerTxn = 2000
+
+// RenewJobLeases claims or renews the leases of many active jobs, applying
+// the same rules as TryClaimOrRenewJobLease, in one read-write transaction
+// per maxLeaseRenewalsPerTxn jobs. It returns the jobs the caller owns
+// afterwards; jobs that are missing, terminal or held by another worker are
+// left out.
+func (c *Client) RenewJobLeases(ctx context.Context, workerID string, jobs []JobKey, leaseUntil time.Time) (map[JobKey]bool, error) {
+	owned := make(map[JobKey]bool, len(jobs))
+	for start := 0; start < len(jobs); start += maxLeaseRenewalsPerTxn {
+		end := min(start+maxLeaseRenewalsPerTxn, len(jobs))
+		chunkOwned, err := c.renewJobLeaseChunk(ctx, workerID, jobs[start:end], leaseUntil)
+		if err != nil {
+			return nil, err
+		}
+		for key := range chunkOwned {
+			owned[key] = true
+		}
+	}
+	return owned, nil
+}
+
+func (c *Client) renewJobLeaseChunk(ctx context.Context, workerID string, jobs []JobKey, leaseUntil time.Time) (map[JobKey]bool, error) {
+	keys := make([]spanner.KeySet, len(jobs))
+	for i, job := range jobs {
+		keys[i] = spanner.Key{job.TenantID, job.JobID}
+	}
+
+	var owned map[JobKey]bool
+	_, err := c.client.ReadWriteTransaction(ctx, func(ctx context.Context, txn *spanner.ReadWriteTransaction) error {
+		owned = make(map[JobKey]bool, len(jobs))
+		now := time.Now().UTC()
+
+		var mutations []*spanner.Mutation
+		iter := txn.Read(ctx, "Jobs", spanner.KeySets(keys...), []string{"TenantId", "JobId", "Status", "OwnerWorkerId", "PreferredWorkerId", "LeaseExpiresAt"})
+		err := iter.Do(func(row *spanner.Row) error {
+			var key JobKey
+			var status string
+			var ownerWorkerID spanner.NullString
+			var preferredWorkerID spanner.NullString
+			var leaseExpiresAt spanner.NullTime
+			if err := row.Columns(&key.TenantID, &key.JobID, &status, &ownerWorkerID, &preferredWorkerID, &leaseExpiresAt); err != nil {
+				return fmt.Errorf("failed to parse job lease state: %w", err)
+			}
+			if !leaseClaimable(status, ownerWorkerID, preferredWorkerID, leaseExpiresAt, workerID, now) {
+				return nil
+			}
+			mutations = append(mutations, spanner.Update("Jobs",
+				[]string{"TenantId", "JobId", "OwnerWorkerId", "LeaseExpiresAt", "LastHeartbeatAt", "UpdatedAt"},
+				[]interface{}{key.TenantID, key.JobID, workerID, leaseUntil, now, spanner.CommitTimestamp},
+			))
+			owned[key] = true
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("failed to read job lease state: %w", err)
+		}
+		if len(mutations) == 0 {
+			return nil
+		}
+		if err := txn.BufferWrite(mutations); err != nil {
+			return fmt.Errorf("failed to buffer lease mutations: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to renew leases: %w", err)
+	}
+	return owned, nil
+}
+
+// LeaseClaimable reports whether workerID could claim the job's lease,
+// judged from a previously read row. Claims re-check inside a transaction,
+// so this is only used to skip jobs that are clearly held elsewhere.
+func (j *Job) LeaseClaimable(workerID string, now time.Time) bool {
+	owner := spanner.NullString{Valid: j.OwnerWorkerId != nil}
+	if owner.Valid {
+		owner.StringVal = *j.OwnerWorkerId
+	}
+	preferred := spanner.NullString{Valid: j.PreferredWorkerId != nil}
+	if preferred.Valid {
+		preferred.StringVal = *j.PreferredWorkerId
+	}
+	expires := spanner.NullTime{Valid: j.LeaseExpiresAt != nil}
+	if expires.Valid {
+		expires.Time = *j.LeaseExpiresAt
+	}
+	return leaseClaimable(j.Status, owner, preferred, expires, workerID, now)
+}
+
+// leaseClaimable reports whether workerID may claim or renew the lease of a
+// job: the job must be active, and the worker must already own it, the lease
+// must be free or expired, or the worker must be the job's preferred worker.
+func leaseClaimable(status string, ownerWorkerID, preferredWorkerID spanner.NullString, leaseExpiresAt spanner.NullTime, workerID string, now time.Time) bool {
+	if status == JobStatusCompleted || status == JobStatusFailed || status == JobStatusCancelled {
+		return false
+	}
+
+	isOwner := ownerWorkerID.Valid && ownerWorkerID.StringVal == workerID
+	leaseExpired := !leaseExpiresAt.Valid || leaseExpiresAt.Time.Before(now)
+	isUnowned := !ownerWorkerID.Valid || ownerWorkerID.StringVal == ""
+	preferredTakeover := preferredWorkerID.Valid && preferredWorkerID.StringVal == workerID && !isOwner
+
+	return isOwner || leaseExpired || isUnowned || preferredTakeover
+}
@@ -10,41 +10,63 @@ type Tenant struct {
 	OAuthUserId   string    `spanner:"OAuthUserId"`
 	CreatedAt     time.Time `spanner:"CreatedAt"`
 	UpdatedAt     time.Time `spanner:"UpdatedAt"`
+	// Name is set for organizations and nil for a user's personal tenant.
+	// The OAuth columns of an organization record who created it.
+	Name *string `spanner:"Name"`
 }
 
 // Job represents a deployment job
 type Job struct {
-	TenantId              string     `spanner:"TenantId"`
-	JobId                 string     `spanner:"JobId"`
-	Status                string     `spanner:"Status"`
-	ImageUri              string     `spanner:"ImageUri"`
-	Commands              []string   `spanner:"Commands"`
-	CreatedAt             time.Time  `spanner:"CreatedAt"`
-	UpdatedAt             time.Time  `spanner:"UpdatedAt"`
-	ScheduledAt           *time.Time `spanner:"ScheduledAt"`
-	StartedAt             *time.Time `spanner:"StartedAt"`
-	CompletedAt           *time.Time `spanner:"CompletedAt"`
-	RetryCount            int64      `spanner:"RetryCount"`
-	MaxRetries            int64      `spanner:"MaxRetries"`
-	ErrorMessage          *string    `spanner:"ErrorMessage"`
-	GcpBatchJobPath       *string    `spanner:"GcpBatchJobPath"`
-	GcpBatchTaskGroup     *string    `spanner:"GcpBatchTaskGroup"`
-	EnvVarsJson           *string    `spanner:"EnvVarsJson"`
-	Name                  *string    `spanner:"Name"`
-	ResourceProfile       *string    `spanner:"ResourceProfile"`
-	MachineType           *string    `spanner:"MachineType"`
-	BootDiskSizeGb        *int64     `spanner:"BootDiskSizeGb"`
-	UseSpotVms            *bool      `spanner:"UseSpotVms"`
-	ServiceAccount        *string    `spanner:"ServiceAccount"`
-	ServiceTier           *string    `spanner:"ServiceTier"`
-	AssignedService       *string    `spanner:"AssignedService"`
-	MemoryMib             *int64     `spanner:"MemoryMib"`
-	CpuMillis             *int64     `spanner:"CpuMillis"`
-	MaxRunDurationSeconds *int64     `spanner:"MaxRunDurationSeconds"`
-	OwnerWorkerId         *string    `spanner:"OwnerWorkerId"`
-	PreferredWorkerId     *string    `spanner:"PreferredWorkerId"`
-	LeaseExpiresAt        *time.Time `spanner:"LeaseExpiresAt"`
-	LastHeartbeatAt       *time.Time `spanner:"LastHeartbeatAt"`
+	TenantId                 string     `spanner:"TenantId"`
+	JobId                    string     `spanner:"JobId"`
+	Status                   string     `spanner:"Status"`
+	ImageUri                 string     `spanner:"ImageUri"`
+	Commands                 []string   `spanner:"Commands"`
+	CreatedAt                time.Time  `spanner:"CreatedAt"`
+	UpdatedAt                time.Time  `spanner:"UpdatedAt"`
+	ScheduledAt              *time.Time `spanner:"ScheduledAt"`
+	StartedAt                *time.Time `spanner:"StartedAt"`
+	CompletedAt              *time.Time `spanner:"CompletedAt"`
+	RetryCount               int64      `spanner:"RetryCount"`
+	MaxRetries               int64      `spanner:"MaxRetries"`
+	ErrorMessage             *string    `spanner:"ErrorMessage"`
+	GcpBatchJobPath          *string    `spanner:"GcpBatchJobPath"`
+	GcpBatchTaskGroup        *string    `spanner:"GcpBatchTaskGroup"`
+	EnvVarsJson              *string    `spanner:"EnvVarsJson"`
+	Name                     *string    `spanner:"Name"`
+	ResourceProfile          *string    `spanner:"ResourceProfile"`
+	MachineType              *string    `spanner:"MachineType"`
+	BootDiskSizeGb           *int64     `spanner:"BootDiskSizeGb"`
+	UseSpotVms               *bool      `spanner:"UseSpotVms"`
+	ServiceAccount           *string    `spanner:"ServiceAccount"`
+	ServiceTier              *string    `spanner:"ServiceTier"`
+	AssignedService          *string    `spanner:"AssignedService"`
+	MemoryMib                *int64     `spanner:"MemoryMib"`
+	CpuMillis                *int64     `spanner:"CpuMillis"`
+	MaxRunDurationSeconds    *int64     `spanner:"MaxRunDurationSeconds"`
+	OwnerWorkerId            *string    `spanner:"OwnerWorkerId"`
+	PreferredWorkerId        *string    `spanner:"PreferredWorkerId"`
+	LeaseExpiresAt           *time.Time `spanner:"LeaseExpiresAt"`
+	LastHeartbeatAt          *time.Time `spanner:"LastHeartbeatAt"`
+	Region                   *string    `spanner:"Region"`
+	PreemptionCount          *int64     `spanner:"PreemptionCount"`
+	MaxPreemptionResubmits   *int64     `spanner:"MaxPreemptionResubmits"`
+	StandardAfterPreemptions *int64     `spanner:"StandardAfterPreemptions"`
+	AttemptUseSpotVms        *bool      `spanner:"AttemptUseSpotVms"`
+	AcceleratorType          *string    `spanner:"AcceleratorType"`
+	AcceleratorCount         *int64     `spanner:"AcceleratorCount"`
+	AcceleratorDriverVersion *string    `spanner:"AcceleratorDriverVersion"`
+	VolumesJson              *string    `spanner:"VolumesJson"`
+	RunnablesJson            *string    `spanner:"RunnablesJson"`
+	LabelsJson               *string    `spanner:"LabelsJson"`
+	RoutingReason            *string    `spanner:"RoutingReason"`
+	PreviousJobPaths         []string   `spanner:"PreviousJobPaths"`
+}
+
+// JobKey identifies a job by its primary key.
+type JobKey struct {
+	TenantID string
+	JobID    string
 }
 
 // JobStateTransition tracks state changes for audit trail
@@ -68,6 +90,40 @@ const (
 	JobStatusCancelled = "CANCELLED"
 )
 
+// Worker is a registered worker process. Gateways route to it while it is
+// ACTIVE and its heartbeat is recent.
+type Worker struct {
+	WorkerId        string    `spanner:"WorkerId"`
+	Address         string    `spanner:"Address"`
+	Capacity        int64     `spanner:"Capacity"`
+	Version         *string   `spanner:"Version"`
+	State           string    `spanner:"State"`
+	RegisteredAt    time.Time `spanner:"RegisteredAt"`
+	LastHeartbeatAt time.Time `spanner:"LastHeartbeatAt"`
+}
+
+// WorkerState constants
+const (
+	WorkerStateActive   = "ACTIVE"
+	WorkerStateDraining = "DRAINING"
+)
+
+// ApiKey is a tenant-scoped credential for automation. Only a salted hash of
+// the key's secret is stored.
+type ApiKey struct {
+	TenantId   string     `spanner:"TenantId"`
+	KeyId      string     `spanner:"KeyId"`
+	Name       string     `spanner:"Name"`
+	SecretHash []byte     `spanner:"SecretHash"`
+	Salt       []byte     `spanner:"Salt"`
+	Scopes     []string   `spanner:"Scopes"`
+	CreatedBy  string     `spanner:"CreatedBy"`
+	CreatedAt  time.Time  `spanner:"CreatedAt"`
+	ExpiresAt  *time.Time `spanner:"ExpiresAt"`
+	LastUsedAt *time.Time `spanner:"LastUsedAt"`
+	RevokedAt  *time.Time `spanner:"RevokedAt"`
+}
+
 // ServiceTier constants indicate which GCP service executes the job.
 // SIMPLE covers all lightweight jobs (Cloud Run Jobs).
 // COMPLEX covers heavy/GPU/long-running jobs (Cloud Batch).
@@ -75,3 +131,100 @@ const (
 	ServiceTierSimple  = "SIMPLE"  // Cloud Run Jobs
 	ServiceTierComplex = "COMPLEX" // Cloud Batch
 )
+
+// Membership gives an OAuth user a role in a tenant.
+type Membership struct {
+	TenantId      string    `spanner:"TenantId"`
+	OAuthProvider string    `spanner:"OAuthProvider"`
+	OAuthUserId   string    `spanner:"OAuthUserId"`
+	Email         string    `spanner:"Email"`
+	Role          string    `spanner:"Role"`
+	CreatedAt     time.Time `spanner:"CreatedAt"`
+}
+
+// RoleAdmin is the membership role that can manage a tenant's members.
+// Every tenant keeps at least one admin.
+const RoleAdmin = "admin"
+
+// UserOrganization is a tenant a user is a member of, with their role.
+type UserOrganization struct {
+	TenantId  string    `spanner:"TenantId"`
+	Name      *string   `spanner:"Name"`
+	Role      string    `spanner:"Role"`
+	CreatedAt time.Time `spanner:"CreatedAt"`
+}
+
+// Invitation invites an email address to join a tenant with a role. Email
+// is stored lowercased.
+type Invitation struct {
+	TenantId     string     `spanner:"TenantId"`
+	InvitationId string     `spanner:"InvitationId"`
+	Email        string     `spanner:"Email"`
+	Role         string     `spanner:"Role"`
+	InvitedBy    string     `spanner:"InvitedBy"`
+	CreatedAt    time.Time  `spanner:"CreatedAt"`
+	ExpiresAt    time.Time  `spanner:"ExpiresAt"`
+	AcceptedAt   *time.Time `spanner:"AcceptedAt"`
+}
+
+// Identity links a sign-in identity to the personal tenant it reaches.
+type Identity struct {
+	OAuthProvider string    `spanner:"OAuthProvider"`
+	OAuthUserId   string    `spanner:"OAuthUserId"`
+	TenantId      string    `spanner:"TenantId"`
+	Email         string    `spanner:"Email"`
+	LinkedAt      time.Time `spanner:"LinkedAt"`
+}
+
+// MergeStats counts what MergeTenant moved.
+type MergeStats struct {
+	Jobs          int64
+	Notifications int64
+}
+
+// AuditEvent records a call to a mutating RPC. Events are only ever
+// inserted.
+type AuditEvent struct {
+	TenantId     string    `spanner:"TenantId"`
+	EventId      string    `spanner:"EventId"`
+	OccurredAt   time.Time `spanner:"OccurredAt"`
+	Procedure    string    `spanner:"Procedure"`
+	ActorType    string    `spanner:"ActorType"`
+	ActorId      string    `spanner:"ActorId"`
+	ActorEmail   string    `spanner:"ActorEmail"`
+	TargetType   *string   `spanner:"TargetType"`
+	TargetId     *string   `spanner:"TargetId"`
+	RequestJson  string    `spanner:"RequestJson"`
+	ResultCode   string    `spanner:"ResultCode"`
+	ErrorMessage *string   `spanner:"ErrorMessage"`
+	ClientIp     string    `spanner:"ClientIp"`
+	UserAgent    string    `spanner:"UserAgent"`
+}
+
+// AuditFilter selects audit events. Zero fields match everything.
+type AuditFilter struct {
+	// Procedure is a full procedure or just its method name.
+	Procedure string
+	ActorId   string
+	TargetId  string
+	// Since is inclusive, Until exclusive.
+	Since time.Time
+	Until time.Time
+	// After continues a listing after this event, the last one of the
+	// previous page.
+	After *AuditCursor
+}
+
+// AuditCursor is the position of an event in a newest-first listing.
+type AuditCursor struct {
+	OccurredAt time.Time
+	EventId    string
+}
+
+// RateLimitBucket is a token bucket shared by the gateways. UpdatedAt is
+// zero for a bucket that has not been stored yet.
+type RateLimitBucket struct {
+	BucketKey string    `spanner:"BucketKey"`
+	Tokens    float64   `spanner:"Tokens"`
+	UpdatedAt time.Time `spanner:"UpdatedAt"`
+}
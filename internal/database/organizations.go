@@ -0,0 +1,347 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"cloud.google.com/go/spanner"
+	"google.golang.org/api/iterator"
+	"google.golang.org/grpc/codes"
+)
+
+// ErrLastAdmin is returned when a change would leave a tenant without an
+// admin.
+var ErrLastAdmin = errors.New("tenant must keep at least one admin")
+
+// ErrInvitationAccepted is returned by AcceptInvitation when the invitation
+// has already been used.
+var ErrInvitationAccepted = errors.New("invitation has already been accepted")
+
+var membershipColumns = []string{"TenantId", "OAuthProvider", "OAuthUserId", "Email", "Role", "CreatedAt"}
+
+var invitationColumns = []string{
+	"TenantId", "InvitationId", "Email", "Role", "InvitedBy", "CreatedAt", "ExpiresAt", "AcceptedAt",
+}
+
+// insertMembership returns the mutation adding m. CreatedAt is set to the
+// commit time.
+func insertMembership(m *Membership) *spanner.Mutation {
+	return spanner.Insert("Memberships", membershipColumns,
+		[]interface{}{m.TenantId, m.OAuthProvider, m.OAuthUserId, m.Email, m.Role, spanner.CommitTimestamp},
+	)
+}
+
+// InsertOrganization creates a named tenant with creator as its admin.
+func (c *Client) InsertOrganization(ctx context.Context, tenantID, name string, creator *Membership) error {
+	admin := *creator
+	admin.TenantId, admin.Role = tenantID, RoleAdmin
+	_, err := c.client.Apply(ctx, []*spanner.Mutation{
+		spanner.Insert("Tenants",
+			[]string{"TenantId", "UserEmail", "OAuthProvider", "OAuthUserId", "CreatedAt", "UpdatedAt", "Name"},
+			[]interface{}{tenantID, creator.Email, creator.OAuthProvider, creator.OAuthUserId, spanner.CommitTimestamp, spanner.CommitTimestamp, name},
+		),
+		insertMembership(&admin),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to insert organization: %w", err)
+	}
+	return nil
+}
+
+// GetMembership returns a user's membership of a tenant, or nil when they
+// are not a member.
+func (c *Client) GetMembership(ctx context.Context, tenantID, oauthProvider, oauthUserId string) (*Membership, error) {
+	row, err := c.client.Single().ReadRow(ctx, "Memberships",
+		spanner.Key{tenantID, oauthProvider, oauthUserId}, membershipColumns)
+	if spanner.ErrCode(err) == codes.NotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get membership: %w", err)
+	}
+	var m Membership
+	if err := row.ToStruct(&m); err != nil {
+		return nil, fmt.Errorf("failed to parse membership: %w", err)
+	}
+	return &m, nil
+}
+
+// ListMemberships returns a tenant's members, oldest first.
+func (c *Client) ListMemberships(ctx context.Context, tenantID string) ([]*Membership, error) {
+	stmt := spanner.Statement{
+		SQL: `SELECT ` + columnList(membershipColumns) + `
+		      FROM Memberships
+		      WHERE TenantId = @tenantId
+		      ORDER BY CreatedAt`,
+		Params: map[string]interface{}{"tenantId": tenantID},
+	}
+	iter := c.client.Single().Query(ctx, stmt)
+	defer iter.Stop()
+
+	var members []*Membership
+	for {
+		row, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to list memberships: %w", err)
+		}
+		var m Membership
+		if err := row.ToStruct(&m); err != nil {
+			return nil, fmt.Errorf("failed to parse membership: %w", err)
+		}
+		members = append(members, &m)
+	}
+	return members, nil
+}
+
+// ListUserOrganizations returns the tenants a user is a member of, oldest
+// first.
+func (c *Client) ListUserOrganizations(ctx context.Context, oauthProvider, oauthUserId string) ([]*UserOrganization, error) {
+	stmt := spanner.Statement{
+		SQL: `SELECT t.TenantId, t.Name, m.Role, t.CreatedAt
+		      FROM Memberships@{FORCE_INDEX=MembershipsByUser} m
+		      JOIN Tenants t ON t.TenantId = m.TenantId
+		      WHERE m.OAuthProvider = @provider AND m.OAuthUserId = @userId
+		      ORDER BY t.CreatedAt`,
+		Params: map[string]interface{}{"provider": oauthProvider, "userId": oauthUserId},
+	}
+	iter := c.client.Single().Query(ctx, stmt)
+	defer iter.Stop()
+
+	var orgs []*UserOrganization
+	for {
+		row, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to list organizations: %w", err)
+		}
+		var o UserOrganization
+		if err := row.ToStruct(&o); err != nil {
+			return nil, fmt.Errorf("failed to parse organization: %w", err)
+		}
+		orgs = append(orgs, &o)
+	}
+	return orgs, nil
+}
+
+// UpdateMembershipRole changes a member's role and returns the membership,
+// or nil when the user is not a member. Demoting the last admin fails with
+// ErrLastAdmin.
+func (c *Client) UpdateMembershipRole(ctx context.Context, tenantID, oauthProvider, oauthUserId, role string) (*Membership, error) {
+	var updated *Membership
+	_, err := c.client.ReadWriteTransaction(ctx, func(ctx context.Context, txn *spanner.ReadWriteTransaction) error {
+		updated = nil
+		m, err := readMembership(ctx, txn, tenantID, oauthProvider, oauthUserId)
+		if err != nil || m == nil {
+			return err
+		}
+		if m.Role == RoleAdmin && role != RoleAdmin {
+			if err := checkOtherAdmins(ctx, txn, tenantID); err != nil {
+				return err
+			}
+		}
+		m.Role = role
+		if err := txn.BufferWrite([]*spanner.Mutation{
+			spanner.Update("Memberships", []string{"TenantId", "OAuthProvider", "OAuthUserId", "Role"},
+				[]interface{}{tenantID, oauthProvider, oauthUserId, role}),
+		}); err != nil {
+			return err
+		}
+		updated = m
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update membership role: %w", err)
+	}
+	return updated, nil
+}
+
+// DeleteMembership removes a member and reports whether they were one.
+// Removing the last admin fails with ErrLastAdmin.
+func (c *Client) DeleteMembership(ctx context.Context, tenantID, oauthProvider, oauthUserId string) (bool, error) {
+	var deleted bool
+	_, err := c.client.ReadWriteTransaction(ctx, func(ctx context.Context, txn *spanner.ReadWriteTransaction) error {
+		deleted = false
+		m, err := readMembership(ctx, txn, tenantID, oauthProvider, oauthUserId)
+		if err != nil || m == nil {
+			return err
+		}
+		if m.Role == RoleAdmin {
+			if err := checkOtherAdmins(ctx, txn, tenantID); err != nil {
+				return err
+			}
+		}
+		if err := txn.BufferWrite([]*spanner.Mutation{
+			spanner.Delete("Memberships", spanner.Key{tenantID, oauthProvider, oauthUserId}),
+		}); err != nil {
+			return err
+		}
+		deleted = true
+		return nil
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to delete membership: %w", err)
+	}
+	return deleted, nil
+}
+
+func readMembership(ctx context.Context, txn *spanner.ReadWriteTransaction, tenantID, oauthProvider, oauthUserId string) (*Membership, error) {
+	row, err := txn.ReadRow(ctx, "Memberships", spanner.Key{tenantID, oauthProvider, oauthUserId}, membershipColumns)
+	if spanner.ErrCode(err) == codes.NotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var m Membership
+	if err := row.ToStruct(&m); err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// checkOtherAdmins returns ErrLastAdmin unless the tenant has more than one
+// admin.
+func checkOtherAdmins(ctx context.Context, txn *spanner.ReadWriteTransaction, tenantID string) error {
+	iter := txn.Query(ctx, spanner.Statement{
+		SQL:    `SELECT COUNT(*) FROM Memberships WHERE TenantId = @tenantId AND Role = @role`,
+		Params: map[string]interface{}{"tenantId": tenantID, "role": RoleAdmin},
+	})
+	defer iter.Stop()
+	row, err := iter.Next()
+	if err != nil {
+		return err
+	}
+	var admins int64
+	if err := row.Columns(&admins); err != nil {
+		return err
+	}
+	if admins <= 1 {
+		return ErrLastAdmin
+	}
+	return nil
+}
+
+// InsertInvitation stores a new invitation. CreatedAt is set to the commit
+// time.
+func (c *Client) InsertInvitation(ctx context.Context, inv *Invitation) error {
+	_, err := c.client.Apply(ctx, []*spanner.Mutation{
+		spanner.Insert("Invitations", invitationColumns,
+			[]interface{}{
+				inv.TenantId, inv.InvitationId, inv.Email, inv.Role, inv.InvitedBy,
+				spanner.CommitTimestamp, inv.ExpiresAt, nil,
+			},
+		),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to insert invitation: %w", err)
+	}
+	return nil
+}
+
+// GetInvitation returns the invitation invitationID of any tenant, or nil
+// when there is none.
+func (c *Client) GetInvitation(ctx context.Context, invitationID string) (*Invitation, error) {
+	stmt := spanner.Statement{
+		SQL: `SELECT ` + columnList(invitationColumns) + `
+		      FROM Invitations@{FORCE_INDEX=InvitationsByInvitationId}
+		      WHERE InvitationId = @invitationId`,
+		Params: map[string]interface{}{"invitationId": invitationID},
+	}
+	iter := c.client.Single().Query(ctx, stmt)
+	defer iter.Stop()
+
+	row, err := iter.Next()
+	if err == iterator.Done {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get invitation: %w", err)
+	}
+	var inv Invitation
+	if err := row.ToStruct(&inv); err != nil {
+		return nil, fmt.Errorf("failed to parse invitation: %w", err)
+	}
+	return &inv, nil
+}
+
+// ListPendingInvitations returns the invitations to email that are neither
+// accepted nor expired at now, newest first.
+func (c *Client) ListPendingInvitations(ctx context.Context, email string, now time.Time) ([]*Invitation, error) {
+	stmt := spanner.Statement{
+		SQL: `SELECT ` + columnList(invitationColumns) + `
+		      FROM Invitations@{FORCE_INDEX=InvitationsByEmail}
+		      WHERE Email = @email AND AcceptedAt IS NULL AND ExpiresAt > @now
+		      ORDER BY CreatedAt DESC`,
+		Params: map[string]interface{}{"email": email, "now": now},
+	}
+	iter := c.client.Single().Query(ctx, stmt)
+	defer iter.Stop()
+
+	var invitations []*Invitation
+	for {
+		row, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to list invitations: %w", err)
+		}
+		var inv Invitation
+		if err := row.ToStruct(&inv); err != nil {
+			return nil, fmt.Errorf("failed to parse invitation: %w", err)
+		}
+		invitations = append(invitations, &inv)
+	}
+	return invitations, nil
+}
+
+// AcceptInvitation marks an invitation accepted and makes member a member
+// of its tenant with the invited role, then returns the membership. A user
+// who is already a member keeps their current role. An invitation can only
+// be accepted once; later attempts fail with ErrInvitationAccepted.
+func (c *Client) AcceptInvitation(ctx context.Context, inv *Invitation, member *Membership) (*Membership, error) {
+	var accepted *Membership
+	_, err := c.client.ReadWriteTransaction(ctx, func(ctx context.Context, txn *spanner.ReadWriteTransaction) error {
+		accepted = nil
+		row, err := txn.ReadRow(ctx, "Invitations", spanner.Key{inv.TenantId, inv.InvitationId}, []string{"AcceptedAt"})
+		if err != nil {
+			return err
+		}
+		var acceptedAt spanner.NullTime
+		if err := row.Columns(&acceptedAt); err != nil {
+			return err
+		}
+		if acceptedAt.Valid {
+			return ErrInvitationAccepted
+		}
+
+		mutations := []*spanner.Mutation{
+			spanner.Update("Invitations", []string{"TenantId", "InvitationId", "AcceptedAt"},
+				[]interface{}{inv.TenantId, inv.InvitationId, spanner.CommitTimestamp}),
+		}
+		existing, err := readMembership(ctx, txn, inv.TenantId, member.OAuthProvider, member.OAuthUserId)
+		if err != nil {
+			return err
+		}
+		if existing != nil {
+			accepted = existing
+		} else {
+			m := *member
+			m.TenantId, m.Role, m.CreatedAt = inv.TenantId, inv.Role, time.Now().UTC()
+			mutations = append(mutations, insertMembership(&m))
+			accepted = &m
+		}
+		return txn.BufferWrite(mutations)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to accept invitation %s: %w", inv.InvitationId, err)
+	}
+	return accepted, nil
+}
@@ -0,0 +1,38 @@
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"cloud.google.com/go/spanner"
+	"google.golang.org/grpc/codes"
+)
+
+var rateLimitBucketColumns = []string{"BucketKey", "Tokens", "UpdatedAt"}
+
+// UpdateRateLimitBucket reads the bucket key, or a new one when it is not
+// stored, lets update change it and writes it back in one transaction.
+// update may run more than once when the transaction is retried.
+func (c *Client) UpdateRateLimitBucket(ctx context.Context, key string, update func(b *RateLimitBucket)) error {
+	_, err := c.client.ReadWriteTransaction(ctx, func(ctx context.Context, txn *spanner.ReadWriteTransaction) error {
+		b := RateLimitBucket{BucketKey: key}
+		row, err := txn.ReadRow(ctx, "RateLimitBuckets", spanner.Key{key}, rateLimitBucketColumns)
+		if err != nil && spanner.ErrCode(err) != codes.NotFound {
+			return err
+		}
+		if err == nil {
+			if err := row.ToStruct(&b); err != nil {
+				return err
+			}
+		}
+		update(&b)
+		return txn.BufferWrite([]*spanner.Mutation{
+			spanner.InsertOrUpdate("RateLimitBuckets", rateLimitBucketColumns,
+				[]interface{}{key, b.Tokens, b.UpdatedAt}),
+		})
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update rate limit bucket %s: %w", key, err)
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"cloud.google.com/go/spanner"
+	"google.golang.org/grpc/codes"
+)
+
+// TryAcquireTaskLease claims or renews the lease on a worker-wide periodic
+// task. It returns true when workerID holds the lease until leaseUntil: the
+// task had no lease, its lease expired, or workerID already held it.
+func (c *Client) TryAcquireTaskLease(ctx context.Context, taskName, workerID string, leaseUntil time.Time) (bool, error) {
+	acquired := false
+	_, err := c.client.ReadWriteTransaction(ctx, func(ctx context.Context, txn *spanner.ReadWriteTransaction) error {
+		acquired = false
+		row, err := txn.ReadRow(ctx, "TaskLeases", spanner.Key{taskName}, []string{"OwnerWorkerId", "LeaseExpiresAt"})
+		if err != nil && spanner.ErrCode(err) != codes.NotFound {
+			return fmt.Errorf("failed to read task lease: %w", err)
+		}
+		if err == nil {
+			var owner string
+			var expiresAt time.Time
+			if err := row.Columns(&owner, &expiresAt); err != nil {
+				return fmt.Errorf("failed to parse task lease: %w", err)
+			}
+			if owner != workerID && expiresAt.After(time.Now().UTC()) {
+				return nil
+			}
+		}
+
+		mutation := spanner.InsertOrUpdate("TaskLeases",
+			[]string{"TaskName", "OwnerWorkerId", "LeaseExpiresAt", "UpdatedAt"},
+			[]interface{}{taskName, workerID, leaseUntil, spanner.CommitTimestamp},
+		)
+		if err := txn.BufferWrite([]*spanner.Mutation{mutation}); err != nil {
+			return fmt.Errorf("failed to buffer task lease mutation: %w", err)
+		}
+		acquired = true
+		return nil
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to acquire lease on task %s: %w", taskName, err)
+	}
+	return acquired, nil
+}
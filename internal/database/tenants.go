@@ -23,13 +23,21 @@ func (c *Client) UpsertTenant(ctx context.Context, tenantID, userEmail, oauthPro
 	return nil
 }
 
-// InsertTenant creates a new tenant
+// InsertTenant creates a new personal tenant with its user as admin and
+// records the user's identity
 func (c *Client) InsertTenant(ctx context.Context, tenantID, userEmail, oauthProvider, oauthUserId string) error {
 	_, err := c.client.Apply(ctx, []*spanner.Mutation{
 		spanner.Insert("Tenants",
 			[]string{"TenantId", "UserEmail", "OAuthProvider", "OAuthUserId", "CreatedAt", "UpdatedAt"},
 			[]interface{}{tenantID, userEmail, oauthProvider, oauthUserId, spanner.CommitTimestamp, spanner.CommitTimestamp},
 		),
+		insertMembership(&Membership{
+			TenantId: tenantID, OAuthProvider: oauthProvider, OAuthUserId: oauthUserId,
+			Email: userEmail, Role: RoleAdmin,
+		}),
+		spanner.InsertOrUpdate("Identities", identityColumns,
+			[]interface{}{oauthProvider, oauthUserId, tenantID, userEmail, spanner.CommitTimestamp},
+		),
 	})
 	if err != nil {
 		return fmt.Errorf("failed to insert tenant: %w", err)
@@ -41,7 +49,7 @@ func (c *Client) InsertTenant(ctx context.Context, tenantID, userEmail, oauthPro
 func (c *Client) GetTenant(ctx context.Context, tenantID string) (*Tenant, error) {
 	row, err := c.client.Single().ReadRow(ctx, "Tenants",
 		spanner.Key{tenantID},
-		[]string{"TenantId", "UserEmail", "OAuthProvider", "OAuthUserId", "CreatedAt", "UpdatedAt"},
+		[]string{"TenantId", "UserEmail", "OAuthProvider", "OAuthUserId", "CreatedAt", "UpdatedAt", "Name"},
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get tenant: %w", err)
@@ -58,7 +66,7 @@ func (c *Client) GetTenant(ctx context.Context, tenantID string) (*Tenant, error
 // ListTenants returns all tenants
 func (c *Client) ListTenants(ctx context.Context) ([]*Tenant, error) {
 	stmt := spanner.Statement{
-		SQL: `SELECT TenantId, UserEmail, OAuthProvider, OAuthUserId, CreatedAt, UpdatedAt FROM Tenants ORDER BY CreatedAt DESC`,
+		SQL: `SELECT TenantId, UserEmail, OAuthProvider, OAuthUserId, CreatedAt, UpdatedAt, Name FROM Tenants ORDER BY CreatedAt DESC`,
 	}
 
 	iter := c.client.Single().Query(ctx, stmt)
@@ -84,12 +92,22 @@ func (c *Client) ListTenants(ctx context.Context) ([]*Tenant, error) {
 	return tenants, nil
 }
 
-// GetTenantByOAuth retrieves a tenant by OAuth provider and user ID
+// GetTenantByOAuth retrieves a user's personal tenant by OAuth provider and
+// user ID, following linked identities
 func (c *Client) GetTenantByOAuth(ctx context.Context, oauthProvider, oauthUserId string) (*Tenant, error) {
+	identity, err := c.GetIdentity(ctx, oauthProvider, oauthUserId)
+	if err != nil {
+		return nil, err
+	}
+	if identity != nil {
+		return c.GetTenant(ctx, identity.TenantId)
+	}
+
+	// Tenants created before identities were recorded
 	stmt := spanner.Statement{
-		SQL: `SELECT TenantId, UserEmail, OAuthProvider, OAuthUserId, CreatedAt, UpdatedAt 
+		SQL: `SELECT TenantId, UserEmail, OAuthProvider, OAuthUserId, CreatedAt, UpdatedAt, Name 
 		      FROM Tenants 
-		      WHERE OAuthProvider = @provider AND OAuthUserId = @userId 
+		      WHERE OAuthProvider = @provider AND OAuthUserId = @userId AND Name IS NULL 
 		      LIMIT 1`,
 		Params: map[string]interface{}{
 			"provider": oauthProvider,
@@ -0,0 +1,106 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"cloud.google.com/go/spanner"
+	"google.golang.org/api/iterator"
+	"google.golang.org/grpc/codes"
+)
+
+var workerColumns = []string{"WorkerId", "Address", "Capacity", "Version", "State", "RegisteredAt", "LastHeartbeatAt"}
+
+// RegisterWorker creates or replaces the registry row of worker w as ACTIVE.
+// A worker registers once on startup; a restarted worker with the same ID
+// becomes ACTIVE again.
+func (c *Client) RegisterWorker(ctx context.Context, w *Worker) error {
+	_, err := c.client.Apply(ctx, []*spanner.Mutation{
+		spanner.InsertOrUpdate("Workers", workerColumns,
+			[]interface{}{w.WorkerId, w.Address, w.Capacity, w.Version, WorkerStateActive, spanner.CommitTimestamp, spanner.CommitTimestamp},
+		),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to register worker %s: %w", w.WorkerId, err)
+	}
+	return nil
+}
+
+// HeartbeatWorker records that workerID is alive. It returns false when the
+// worker has no registry row (for example it was deleted by an operator), so
+// the caller can register again.
+func (c *Client) HeartbeatWorker(ctx context.Context, workerID string) (bool, error) {
+	_, err := c.client.Apply(ctx, []*spanner.Mutation{
+		spanner.Update("Workers",
+			[]string{"WorkerId", "LastHeartbeatAt"},
+			[]interface{}{workerID, spanner.CommitTimestamp},
+		),
+	})
+	if spanner.ErrCode(err) == codes.NotFound {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to heartbeat worker %s: %w", workerID, err)
+	}
+	return true, nil
+}
+
+// SetWorkerState sets the registry state of workerID, e.g. to
+// WorkerStateDraining when it shuts down.
+func (c *Client) SetWorkerState(ctx context.Context, workerID, state string) error {
+	_, err := c.client.Apply(ctx, []*spanner.Mutation{
+		spanner.Update("Workers",
+			[]string{"WorkerId", "State"},
+			[]interface{}{workerID, state},
+		),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to set worker %s state to %s: %w", workerID, state, err)
+	}
+	return nil
+}
+
+// GetWorker returns the registry row of workerID.
+func (c *Client) GetWorker(ctx context.Context, workerID string) (*Worker, error) {
+	row, err := c.client.Single().ReadRow(ctx, "Workers", spanner.Key{workerID}, workerColumns)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get worker %s: %w", workerID, err)
+	}
+
+	var w Worker
+	if err := row.ToStruct(&w); err != nil {
+		return nil, fmt.Errorf("failed to parse worker: %w", err)
+	}
+	return &w, nil
+}
+
+// ListWorkers returns every registered worker, whatever its state.
+func (c *Client) ListWorkers(ctx context.Context) ([]*Worker, error) {
+	iter := c.client.Single().Read(ctx, "Workers", spanner.AllKeys(), workerColumns)
+	defer iter.Stop()
+
+	var workers []*Worker
+	for {
+		row, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to iterate workers: %w", err)
+		}
+
+		var w Worker
+		if err := row.ToStruct(&w); err != nil {
+			return nil, fmt.Errorf("failed to parse worker: %w", err)
+		}
+		workers = append(workers, &w)
+	}
+	return workers, nil
+}
+
+// Alive reports whether the worker heartbeated within ttl of now. Draining
+// workers stay alive until they stop.
+func (w *Worker) Alive(now time.Time, ttl time.Duration) bool {
+	return now.Sub(w.LastHeartbeatAt) <= ttl
+}
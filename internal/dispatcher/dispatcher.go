@@ -2,13 +2,15 @@
 // based on the complexity classification from the router.
 //
 // It holds references to all configured providers (Cloud Tasks, Cloud Run Jobs,
-// Cloud Batch) and selects the correct one for each job submission.
+// Cloud Batch) and selects the correct one for each job submission. A service
+// may be backed by a ProviderPool to fail over between regions.
 package dispatcher
 
 import (
 	"context"
 	"fmt"
 	"log"
+	"sort"
 
 	batch "github.com/alphauslabs/jennah/internal/cloudexec"
 	"github.com/alphauslabs/jennah/internal/router"
@@ -71,6 +73,22 @@ func (d *Dispatcher) ProviderFor(svc router.AssignedService) (batch.Provider, er
 	return p, nil
 }
 
+// Candidates returns every registered provider with its capabilities, in a
+// stable order, for use with router.SelectProvider.
+func (d *Dispatcher) Candidates() []router.ProviderCandidate {
+	candidates := make([]router.ProviderCandidate, 0, len(d.providers))
+	for svc, p := range d.providers {
+		candidates = append(candidates, router.ProviderCandidate{
+			Service:      svc,
+			Capabilities: p.Capabilities(),
+		})
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].Service < candidates[j].Service
+	})
+	return candidates
+}
+
 // SubmitJob submits a job using the provider that matches assignedService.
 func (d *Dispatcher) SubmitJob(ctx context.Context, assignedService router.AssignedService, config batch.JobConfig) (*batch.JobResult, error) {
 	p, err := d.ProviderFor(assignedService)
@@ -0,0 +1,316 @@
+package dispatcher
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	batch "github.com/alphauslabs/jennah/internal/cloudexec"
+)
+
+// defaultRegionCooldown is how long it takes a region's capacity penalty to halve.
+const defaultRegionCooldown = 10 * time.Minute
+
+// PoolMember is one regional provider in a ProviderPool.
+type PoolMember struct {
+	// Region is the cloud region the provider submits to.
+	Region string
+
+	// Provider is the provider client configured for Region.
+	Provider batch.Provider
+}
+
+// ProviderPool runs a single service across several regions. It implements
+// batch.Provider, so it can be registered with the Dispatcher in place of a
+// single-region provider.
+//
+// SubmitJob tries regions in order of health, then configured preference.
+// When a region rejects the job with a capacity or quota error (see
+// batch.IsCapacityError), or the call provably never reached its API (see
+// batch.IsNotProcessed), the pool falls over to the next region. Any other
+// error, including timeouts where the region may have created the job, is
+// returned immediately so the job is never created twice. Job-scoped calls are routed to the region
+// named in the cloud resource path.
+type ProviderPool struct {
+	// members are kept in configured preference order; members[0] is primary.
+	members []PoolMember
+	health  *RegionHealth
+}
+
+// NewProviderPool creates a pool from members in preference order.
+// At least one member is required and regions must be unique.
+func NewProviderPool(members ...PoolMember) (*ProviderPool, error) {
+	if len(members) == 0 {
+		return nil, fmt.Errorf("dispatcher: provider pool needs at least one region")
+	}
+	seen := make(map[string]bool, len(members))
+	for _, m := range members {
+		if m.Region == "" || m.Provider == nil {
+			return nil, fmt.Errorf("dispatcher: provider pool member needs a region and a provider")
+		}
+		if seen[m.Region] {
+			return nil, fmt.Errorf("dispatcher: region %s listed twice in provider pool", m.Region)
+		}
+		seen[m.Region] = true
+	}
+	return &ProviderPool{
+		members: append([]PoolMember(nil), members...),
+		health:  NewRegionHealth(defaultRegionCooldown),
+	}, nil
+}
+
+// Regions returns the pool's regions in configured preference order.
+func (p *ProviderPool) Regions() []string {
+	regions := make([]string, len(p.members))
+	for i, m := range p.members {
+		regions[i] = m.Region
+	}
+	return regions
+}
+
+// Health returns the pool's region health tracker.
+func (p *ProviderPool) Health() *RegionHealth {
+	return p.health
+}
+
+// SubmitJob submits the job to the healthiest region, falling over to the
+// next region on capacity, quota or availability errors. The returned JobResult.Region is
+// the region that accepted the job.
+func (p *ProviderPool) SubmitJob(ctx context.Context, config batch.JobConfig) (*batch.JobResult, error) {
+	var capacityErrs []error
+	for _, m := range p.ordered() {
+		result, err := m.Provider.SubmitJob(ctx, config)
+		if err == nil {
+			p.health.RecordSuccess(m.Region)
+			if result.Region == "" {
+				result.Region = m.Region
+			}
+			if len(capacityErrs) > 0 {
+				log.Printf("ProviderPool: job %s placed in %s after %d region(s) ran out of capacity",
+					config.JobID, m.Region, len(capacityErrs))
+			}
+			return result, nil
+		}
+		if !batch.IsCapacityError(err) && !batch.IsNotProcessed(err) {
+			return nil, err
+		}
+
+		p.health.RecordCapacityError(m.Region)
+		log.Printf("ProviderPool: %s cannot take job %s, trying next region: %v", m.Region, config.JobID, err)
+		capacityErrs = append(capacityErrs, fmt.Errorf("%s: %w", m.Region, err))
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	return nil, fmt.Errorf("no region could take job %s: %w", config.JobID, errors.Join(capacityErrs...))
+}
+
+// GetJobStatus retrieves job status from the region that owns the job.
+func (p *ProviderPool) GetJobStatus(ctx context.Context, cloudResourcePath string) (batch.JobStatus, error) {
+	return p.memberFor(cloudResourcePath).Provider.GetJobStatus(ctx, cloudResourcePath)
+}
+
+// GetJobStatuses implements batch.StatusBatcher. Paths are grouped by region
+// and passed to members that support batched status reads; paths in other
+// regions are left for the caller to fetch with GetJobStatus.
+func (p *ProviderPool) GetJobStatuses(ctx context.Context, cloudResourcePaths []string) (map[string]batch.JobStatus, error) {
+	byRegion := make(map[string][]string)
+	for _, path := range cloudResourcePaths {
+		m := p.memberFor(path)
+		byRegion[m.Region] = append(byRegion[m.Region], path)
+	}
+
+	statuses := make(map[string]batch.JobStatus, len(cloudResourcePaths))
+	for _, m := range p.members {
+		paths := byRegion[m.Region]
+		batcher, ok := m.Provider.(batch.StatusBatcher)
+		if len(paths) == 0 || !ok {
+			continue
+		}
+		regionStatuses, err := batcher.GetJobStatuses(ctx, paths)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get job statuses in %s: %w", m.Region, err)
+		}
+		for path, status := range regionStatuses {
+			statuses[path] = status
+		}
+	}
+	return statuses, nil
+}
+
+// CancelJob cancels a job in the region that owns it.
+func (p *ProviderPool) CancelJob(ctx context.Context, cloudResourcePath string) error {
+	return p.memberFor(cloudResourcePath).Provider.CancelJob(ctx, cloudResourcePath)
+}
+
+// DeleteJob deletes a job from the region that owns it.
+func (p *ProviderPool) DeleteJob(ctx context.Context, cloudResourcePath string) error {
+	return p.memberFor(cloudResourcePath).Provider.DeleteJob(ctx, cloudResourcePath)
+}
+
+// ListJobs lists jobs across every region in the pool.
+func (p *ProviderPool) ListJobs(ctx context.Context) ([]string, error) {
+	var paths []string
+	for _, m := range p.members {
+		regionPaths, err := m.Provider.ListJobs(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list jobs in %s: %w", m.Region, err)
+		}
+		paths = append(paths, regionPaths...)
+	}
+	return paths, nil
+}
+
+// ListJobResources implements batch.ResourceLister across every region in
+// the pool. Members that cannot list resources fall back to ListJobs, with
+// unknown status and creation time.
+func (p *ProviderPool) ListJobResources(ctx context.Context) ([]batch.ListedJob, error) {
+	var jobs []batch.ListedJob
+	for _, m := range p.members {
+		if lister, ok := m.Provider.(batch.ResourceLister); ok {
+			regionJobs, err := lister.ListJobResources(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to list jobs in %s: %w", m.Region, err)
+			}
+			jobs = append(jobs, regionJobs...)
+			continue
+		}
+		paths, err := m.Provider.ListJobs(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list jobs in %s: %w", m.Region, err)
+		}
+		for _, path := range paths {
+			if batch.IsJennahJobPath(path) {
+				jobs = append(jobs, batch.ListedJob{Path: path, Status: batch.JobStatusUnknown})
+			}
+		}
+	}
+	return jobs, nil
+}
+
+// ServiceType returns the service type of the pooled providers.
+func (p *ProviderPool) ServiceType() string {
+	return p.members[0].Provider.ServiceType()
+}
+
+// Capabilities returns the primary region's capabilities. All members run
+// the same service, so their descriptors are expected to match.
+func (p *ProviderPool) Capabilities() batch.Capabilities {
+	return p.members[0].Provider.Capabilities()
+}
+
+// ordered returns members sorted by health penalty; ties keep preference order.
+func (p *ProviderPool) ordered() []PoolMember {
+	ordered := append([]PoolMember(nil), p.members...)
+	penalties := make(map[string]float64, len(ordered))
+	for _, m := range ordered {
+		penalties[m.Region] = p.health.Penalty(m.Region)
+	}
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return penalties[ordered[i].Region] < penalties[ordered[j].Region]
+	})
+	return ordered
+}
+
+// memberFor returns the member whose region appears in cloudResourcePath,
+// or the primary member when the path names no pooled region.
+func (p *ProviderPool) memberFor(cloudResourcePath string) PoolMember {
+	region := regionFromResourcePath(cloudResourcePath)
+	for _, m := range p.members {
+		if m.Region == region {
+			return m
+		}
+	}
+	return p.members[0]
+}
+
+// regionFromResourcePath extracts the location from a GCP resource path
+// such as "projects/p/locations/us-central1/jobs/j". It returns "" for
+// paths without a locations segment.
+func regionFromResourcePath(path string) string {
+	parts := strings.Split(path, "/")
+	for i := 0; i+1 < len(parts); i++ {
+		if parts[i] == "locations" {
+			return parts[i+1]
+		}
+	}
+	return ""
+}
+
+// RegionHealth scores regions by their recent capacity errors. Each capacity
+// error adds one to a region's penalty and a successful submission clears it.
+// Penalties halve every cooldown period, so a region that stops failing
+// gradually regains its configured preference.
+type RegionHealth struct {
+	mu       sync.Mutex
+	cooldown time.Duration
+	now      func() time.Time
+	scores   map[string]*regionScore
+}
+
+type regionScore struct {
+	penalty   float64
+	updatedAt time.Time
+}
+
+// NewRegionHealth creates a tracker whose penalties halve every cooldown.
+func NewRegionHealth(cooldown time.Duration) *RegionHealth {
+	return &RegionHealth{
+		cooldown: cooldown,
+		now:      time.Now,
+		scores:   make(map[string]*regionScore),
+	}
+}
+
+// RecordCapacityError adds a capacity error to region's penalty.
+func (h *RegionHealth) RecordCapacityError(region string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	now := h.now()
+	s, ok := h.scores[region]
+	if !ok {
+		s = &regionScore{}
+		h.scores[region] = s
+	}
+	s.penalty = h.decayed(s, now) + 1
+	s.updatedAt = now
+}
+
+// RecordSuccess clears region's penalty.
+func (h *RegionHealth) RecordSuccess(region string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	delete(h.scores, region)
+}
+
+// Penalty returns region's current penalty. Zero means healthy; higher
+// values mean more recent capacity errors.
+func (h *RegionHealth) Penalty(region string) float64 {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	s, ok := h.scores[region]
+	if !ok {
+		return 0
+	}
+	return h.decayed(s, h.now())
+}
+
+// decayed returns s's penalty after halving it once per elapsed cooldown.
+// Penalties below 0.5 are treated as fully recovered.
+func (h *RegionHealth) decayed(s *regionScore, now time.Time) float64 {
+	penalty := s.penalty
+	if h.cooldown > 0 {
+		for elapsed := now.Sub(s.updatedAt); elapsed >= h.cooldown && penalty >= 0.5; elapsed -= h.cooldown {
+			penalty /= 2
+		}
+	}
+	if penalty < 0.5 {
+		return 0
+	}
+	return penalty
+}
@@ -0,0 +1,266 @@
+package dispatcher
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	batch "github.com/alphauslabs/jennah/internal/cloudexec"
+)
+
+// fakeProvider is a batch.Provider whose SubmitJob returns a fixed error.
+type fakeProvider struct {
+	region    string
+	submitErr error
+	submits   int
+	statusFor []string
+}
+
+func (f *fakeProvider) SubmitJob(ctx context.Context, config batch.JobConfig) (*batch.JobResult, error) {
+	f.submits++
+	if f.submitErr != nil {
+		return nil, f.submitErr
+	}
+	return &batch.JobResult{
+		CloudResourcePath: "projects/p/locations/" + f.region + "/jobs/" + config.JobID,
+		InitialStatus:     batch.JobStatusPending,
+	}, nil
+}
+
+func (f *fakeProvider) GetJobStatus(ctx context.Context, path string) (batch.JobStatus, error) {
+	f.statusFor = append(f.statusFor, path)
+	return batch.JobStatusRunning, nil
+}
+
+func (f *fakeProvider) CancelJob(ctx context.Context, path string) error { return nil }
+func (f *fakeProvider) DeleteJob(ctx context.Context, path string) error { return nil }
+func (f *fakeProvider) ListJobs(ctx context.Context) ([]string, error)   { return nil, nil }
+func (f *fakeProvider) ServiceType() string                              { return batch.ServiceTypeCloudBatch }
+func (f *fakeProvider) Capabilities() batch.Capabilities                 { return batch.Capabilities{} }
+
+var errStockout = status.Error(codes.ResourceExhausted, "ZONE_RESOURCE_POOL_EXHAUSTED")
+
+func newTestPool(t *testing.T, providers ...*fakeProvider) *ProviderPool {
+	t.Helper()
+	members := make([]PoolMember, len(providers))
+	for i, p := range providers {
+		members[i] = PoolMember{Region: p.region, Provider: p}
+	}
+	pool, err := NewProviderPool(members...)
+	if err != nil {
+		t.Fatalf("NewProviderPool: %v", err)
+	}
+	return pool
+}
+
+func TestProviderPool_FailsOverOnCapacityError(t *testing.T) {
+	primary := &fakeProvider{region: "us-central1", submitErr: errStockout}
+	secondary := &fakeProvider{region: "us-east1"}
+	pool := newTestPool(t, primary, secondary)
+
+	result, err := pool.SubmitJob(context.Background(), batch.JobConfig{JobID: "jennah-a"})
+	if err != nil {
+		t.Fatalf("SubmitJob: %v", err)
+	}
+	if result.Region != "us-east1" {
+		t.Errorf("region: got %q, want us-east1", result.Region)
+	}
+	if primary.submits != 1 || secondary.submits != 1 {
+		t.Errorf("submits: primary=%d secondary=%d, want 1 each", primary.submits, secondary.submits)
+	}
+}
+
+func TestProviderPool_QuotaMessageIsCapacityError(t *testing.T) {
+	primary := &fakeProvider{region: "us-central1", submitErr: errors.New("failed to create GCP Batch job: Quota 'CPUS' exceeded")}
+	secondary := &fakeProvider{region: "us-east1"}
+	pool := newTestPool(t, primary, secondary)
+
+	if _, err := pool.SubmitJob(context.Background(), batch.JobConfig{JobID: "jennah-a"}); err != nil {
+		t.Fatalf("SubmitJob: %v", err)
+	}
+	if secondary.submits != 1 {
+		t.Errorf("quota error should fall over to the next region")
+	}
+}
+
+func TestProviderPool_OpenCircuitFailsOver(t *testing.T) {
+	open := &batch.ProviderError{Class: batch.ErrorClassRetryable, Op: "SubmitJob", Err: batch.ErrCircuitOpen}
+	primary := &fakeProvider{region: "us-central1", submitErr: open}
+	secondary := &fakeProvider{region: "us-east1"}
+	pool := newTestPool(t, primary, secondary)
+
+	if _, err := pool.SubmitJob(context.Background(), batch.JobConfig{JobID: "jennah-a"}); err != nil {
+		t.Fatalf("SubmitJob: %v", err)
+	}
+	if secondary.submits != 1 {
+		t.Errorf("a region whose breaker is open should fall over to the next region")
+	}
+}
+
+func TestProviderPool_UnavailableFailsOver(t *testing.T) {
+	primary := &fakeProvider{region: "us-central1", submitErr: status.Error(codes.Unavailable, "connection refused")}
+	secondary := &fakeProvider{region: "us-east1"}
+	pool := newTestPool(t, primary, secondary)
+
+	if _, err := pool.SubmitJob(context.Background(), batch.JobConfig{JobID: "jennah-a"}); err != nil {
+		t.Fatalf("SubmitJob: %v", err)
+	}
+	if secondary.submits != 1 {
+		t.Errorf("a region that never received the call should fall over to the next region")
+	}
+}
+
+func TestProviderPool_DeadlineExceededDoesNotFailOver(t *testing.T) {
+	// The primary may have created the job before the deadline hit; creating
+	// it again in another region would run it twice.
+	deadline := &batch.ProviderError{Class: batch.ErrorClassRetryable, Op: "SubmitJob", Err: status.Error(codes.DeadlineExceeded, "deadline exceeded")}
+	primary := &fakeProvider{region: "us-central1", submitErr: deadline}
+	secondary := &fakeProvider{region: "us-east1"}
+	pool := newTestPool(t, primary, secondary)
+
+	if _, err := pool.SubmitJob(context.Background(), batch.JobConfig{JobID: "jennah-a"}); err == nil {
+		t.Fatal("expected error")
+	}
+	if secondary.submits != 0 {
+		t.Errorf("secondary submits = %d, want 0 after an ambiguous failure", secondary.submits)
+	}
+}
+
+func TestProviderPool_PermanentErrorDoesNotFailOver(t *testing.T) {
+	primary := &fakeProvider{region: "us-central1", submitErr: status.Error(codes.InvalidArgument, "bad image")}
+	secondary := &fakeProvider{region: "us-east1"}
+	pool := newTestPool(t, primary, secondary)
+
+	if _, err := pool.SubmitJob(context.Background(), batch.JobConfig{JobID: "jennah-a"}); err == nil {
+		t.Fatal("expected error")
+	}
+	if secondary.submits != 0 {
+		t.Errorf("permanent error must not be retried in another region")
+	}
+}
+
+func TestProviderPool_AllRegionsExhausted(t *testing.T) {
+	pool := newTestPool(t,
+		&fakeProvider{region: "us-central1", submitErr: errStockout},
+		&fakeProvider{region: "us-east1", submitErr: errStockout},
+	)
+
+	_, err := pool.SubmitJob(context.Background(), batch.JobConfig{JobID: "jennah-a"})
+	if !batch.IsCapacityError(err) {
+		t.Fatalf("got %v, want a capacity error", err)
+	}
+	if !strings.Contains(err.Error(), "us-central1") || !strings.Contains(err.Error(), "us-east1") {
+		t.Errorf("error %q should name every region tried", err)
+	}
+}
+
+func TestProviderPool_DeprioritizesUnhealthyRegion(t *testing.T) {
+	primary := &fakeProvider{region: "us-central1", submitErr: errStockout}
+	secondary := &fakeProvider{region: "us-east1"}
+	pool := newTestPool(t, primary, secondary)
+	now := time.Unix(0, 0)
+	pool.health.now = func() time.Time { return now }
+
+	for i := 0; i < 3; i++ {
+		if _, err := pool.SubmitJob(context.Background(), batch.JobConfig{JobID: "jennah-a"}); err != nil {
+			t.Fatalf("SubmitJob: %v", err)
+		}
+	}
+	if primary.submits != 1 {
+		t.Errorf("primary tried %d times, want 1 (demoted after its capacity error)", primary.submits)
+	}
+
+	// Once the penalty has decayed the primary region is preferred again.
+	now = now.Add(2 * defaultRegionCooldown)
+	primary.submitErr = nil
+	result, err := pool.SubmitJob(context.Background(), batch.JobConfig{JobID: "jennah-b"})
+	if err != nil {
+		t.Fatalf("SubmitJob: %v", err)
+	}
+	if result.Region != "us-central1" {
+		t.Errorf("region after cooldown: got %q, want us-central1", result.Region)
+	}
+}
+
+func TestProviderPool_RoutesByResourcePathRegion(t *testing.T) {
+	primary := &fakeProvider{region: "us-central1"}
+	secondary := &fakeProvider{region: "us-east1"}
+	pool := newTestPool(t, primary, secondary)
+
+	path := "projects/p/locations/us-east1/jobs/jennah-a"
+	if _, err := pool.GetJobStatus(context.Background(), path); err != nil {
+		t.Fatalf("GetJobStatus: %v", err)
+	}
+	if len(secondary.statusFor) != 1 || len(primary.statusFor) != 0 {
+		t.Errorf("status lookup for %s went to the wrong region", path)
+	}
+
+	if _, err := pool.GetJobStatus(context.Background(), "arn:aws:batch:job/x"); err != nil {
+		t.Fatalf("GetJobStatus: %v", err)
+	}
+	if len(primary.statusFor) != 1 {
+		t.Errorf("paths without a pooled region should go to the primary region")
+	}
+}
+
+// fakeBatchingProvider adds batched status reads to fakeProvider.
+type fakeBatchingProvider struct {
+	*fakeProvider
+	batchedFor [][]string
+}
+
+func (f *fakeBatchingProvider) GetJobStatuses(ctx context.Context, paths []string) (map[string]batch.JobStatus, error) {
+	f.batchedFor = append(f.batchedFor, paths)
+	statuses := make(map[string]batch.JobStatus, len(paths))
+	for _, path := range paths {
+		statuses[path] = batch.JobStatusRunning
+	}
+	return statuses, nil
+}
+
+func TestProviderPool_GetJobStatusesGroupsByRegion(t *testing.T) {
+	primary := &fakeBatchingProvider{fakeProvider: &fakeProvider{region: "us-central1"}}
+	secondary := &fakeProvider{region: "us-east1"}
+	pool, err := NewProviderPool(
+		PoolMember{Region: primary.region, Provider: primary},
+		PoolMember{Region: secondary.region, Provider: secondary},
+	)
+	if err != nil {
+		t.Fatalf("NewProviderPool: %v", err)
+	}
+
+	paths := []string{
+		"projects/p/locations/us-central1/jobs/a",
+		"projects/p/locations/us-east1/jobs/b",
+		"projects/p/locations/us-central1/jobs/c",
+	}
+	statuses, err := pool.GetJobStatuses(context.Background(), paths)
+	if err != nil {
+		t.Fatalf("GetJobStatuses: %v", err)
+	}
+
+	if len(primary.batchedFor) != 1 || len(primary.batchedFor[0]) != 2 {
+		t.Errorf("primary batched reads = %v, want one read of its 2 jobs", primary.batchedFor)
+	}
+	if len(statuses) != 2 {
+		t.Errorf("got %d statuses, want 2", len(statuses))
+	}
+	if _, ok := statuses["projects/p/locations/us-east1/jobs/b"]; ok {
+		t.Error("job in a region without batched reads should be left to GetJobStatus")
+	}
+	if len(secondary.statusFor) != 0 {
+		t.Errorf("pool read %v individually; callers do that", secondary.statusFor)
+	}
+}
+
+func TestNewProviderPool_RejectsDuplicateRegions(t *testing.T) {
+	p := &fakeProvider{region: "us-central1"}
+	if _, err := NewProviderPool(PoolMember{Region: "us-central1", Provider: p}, PoolMember{Region: "us-central1", Provider: p}); err == nil {
+		t.Fatal("expected duplicate region error")
+	}
+}
@@ -3,10 +3,19 @@ package hashing
 import (
 	"crypto/sha256"
 	"encoding/binary"
+	"fmt"
+	"strings"
+	"sync"
 
 	"github.com/buraksezer/consistent"
 )
 
+// PartitionCount is the number of ring partitions keys are spread over.
+const PartitionCount = 71
+
+// maxWeight caps a member's weight; each unit of weight is a virtual member.
+const maxWeight = 8
+
 type Member string
 
 func (m Member) String() string {
@@ -21,33 +30,148 @@ func (h hasher) Sum64(data []byte) uint64 {
 	return binary.BigEndian.Uint64(out[:8])
 }
 
+// WeightedMember is a ring member with its share of the keys relative to the
+// other members. Weights below 1 count as 1 and above maxWeight as maxWeight.
+type WeightedMember struct {
+	Name   string
+	Weight int
+}
+
+// PartitionMove is a partition whose owner changed when the ring was rebuilt.
+// From or To is empty when the ring had, or has, no members.
+type PartitionMove struct {
+	Partition int
+	From      string
+	To        string
+}
+
 type Router struct {
-	ring *consistent.Consistent
+	mu      sync.RWMutex
+	ring    *consistent.Consistent
+	members []WeightedMember
 }
 
 func NewRouter(members []string) *Router {
+	weighted := make([]WeightedMember, 0, len(members))
+	for _, ip := range members {
+		weighted = append(weighted, WeightedMember{Name: ip, Weight: 1})
+	}
+	return NewWeightedRouter(weighted)
+}
+
+// NewWeightedRouter returns a router over members. A member of weight 1 hashes
+// exactly as it does with NewRouter.
+func NewWeightedRouter(members []WeightedMember) *Router {
+	return &Router{ring: newRing(members), members: append([]WeightedMember(nil), members...)}
+}
+
+func newRing(members []WeightedMember) *consistent.Consistent {
 	cfg := consistent.Config{
-		PartitionCount:    71,
+		PartitionCount:    PartitionCount,
 		ReplicationFactor: 20,
 		Load:              1.25,
 		Hasher:            hasher{},
 	}
 	c := consistent.New(nil, cfg)
 
-	for _, ip := range members {
-		c.Add(Member(ip))
+	weights := virtualWeights(members)
+	for i, m := range members {
+		c.Add(Member(m.Name))
+		for v := 1; v < weights[i]; v++ {
+			c.Add(Member(fmt.Sprintf("%s#%d", m.Name, v)))
+		}
+	}
+	return c
+}
+
+// virtualWeights returns how many virtual members each member gets. The ring
+// cannot place partitions when it has more members than partitions, so
+// weights are scaled down (to no less than 1) when their total is too high.
+func virtualWeights(members []WeightedMember) []int {
+	weights := make([]int, len(members))
+	total := 0
+	for i, m := range members {
+		weights[i] = min(max(m.Weight, 1), maxWeight)
+		total += weights[i]
+	}
+	if total <= PartitionCount {
+		return weights
 	}
+	for i := range weights {
+		weights[i] = max(weights[i]*PartitionCount/total, 1)
+	}
+	return weights
+}
 
-	return &Router{ring: c}
+// memberName maps a virtual member back to the member it belongs to.
+func memberName(m consistent.Member) string {
+	if m == nil {
+		return ""
+	}
+	name, _, _ := strings.Cut(m.String(), "#")
+	return name
+}
+
+// Update rebuilds the ring from members and returns the partitions whose
+// owner changed. Lookups see either the old or the new ring, never a mix.
+func (r *Router) Update(members []WeightedMember) []PartitionMove {
+	next := newRing(members)
+
+	r.mu.Lock()
+	prev := r.ring
+	r.ring = next
+	r.members = append([]WeightedMember(nil), members...)
+	r.mu.Unlock()
+
+	var moves []PartitionMove
+	for p := 0; p < PartitionCount; p++ {
+		from, to := memberName(prev.GetPartitionOwner(p)), memberName(next.GetPartitionOwner(p))
+		if from != to {
+			moves = append(moves, PartitionMove{Partition: p, From: from, To: to})
+		}
+	}
+	return moves
+}
+
+// Members returns the members the ring was last built from.
+func (r *Router) Members() []WeightedMember {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return append([]WeightedMember(nil), r.members...)
 }
 
 // GetWorkerIP returns the worker IP for the given routing key using consistent hashing.
 // The key should be unique per request to ensure even load distribution across workers.
 func (r *Router) GetWorkerIP(key string) string {
+	r.mu.RLock()
+	ring := r.ring
+	r.mu.RUnlock()
 
-	member := r.ring.LocateKey([]byte(key))
-	if member == nil {
-		return ""
+	return memberName(ring.LocateKey([]byte(key)))
+}
+
+// GetWorkerIPs returns every member in the order requests for key should try
+// them: the owner GetWorkerIP returns first, then its successors on the ring.
+func (r *Router) GetWorkerIPs(key string) []string {
+	r.mu.RLock()
+	ring := r.ring
+	r.mu.RUnlock()
+
+	owner := memberName(ring.LocateKey([]byte(key)))
+	if owner == "" {
+		return nil
+	}
+	closest, err := ring.GetClosestN([]byte(key), len(ring.GetMembers()))
+	if err != nil {
+		return []string{owner}
+	}
+	seen := map[string]bool{owner: true}
+	order := []string{owner}
+	for _, m := range closest {
+		if name := memberName(m); !seen[name] {
+			seen[name] = true
+			order = append(order, name)
+		}
 	}
-	return member.String()
+	return order
 }
@@ -0,0 +1,97 @@
+package hashing
+
+import (
+	"fmt"
+	"testing"
+)
+
+func owners(r *Router) map[string]int {
+	counts := make(map[string]int)
+	for p := 0; p < PartitionCount; p++ {
+		counts[memberName(r.ring.GetPartitionOwner(p))]++
+	}
+	return counts
+}
+
+func TestNewRouter_MatchesWeightOne(t *testing.T) {
+	members := []string{"10.0.0.1", "10.0.0.2", "10.0.0.3"}
+	plain := NewRouter(members)
+	weighted := NewWeightedRouter([]WeightedMember{{"10.0.0.1", 1}, {"10.0.0.2", 1}, {"10.0.0.3", 1}})
+	for i := 0; i < 200; i++ {
+		key := fmt.Sprintf("tenant-%d", i)
+		if plain.GetWorkerIP(key) != weighted.GetWorkerIP(key) {
+			t.Fatalf("key %s routed differently: %s vs %s", key, plain.GetWorkerIP(key), weighted.GetWorkerIP(key))
+		}
+	}
+}
+
+func TestRouter_WeightsAndVirtualMembers(t *testing.T) {
+	r := NewWeightedRouter([]WeightedMember{{"a:8081", 3}, {"b:8081", 1}})
+	counts := owners(r)
+	if len(counts) != 2 {
+		t.Fatalf("virtual members must map back to their member, got owners %v", counts)
+	}
+	if counts["a:8081"] <= counts["b:8081"] {
+		t.Errorf("heavier member should own more partitions: %v", counts)
+	}
+}
+
+func TestRouter_UpdateReportsMoves(t *testing.T) {
+	r := NewRouter([]string{"a", "b"})
+	before := owners(r)
+
+	moves := r.Update([]WeightedMember{{"a", 1}, {"b", 1}, {"c", 1}})
+	if len(moves) == 0 {
+		t.Fatal("adding a member should move partitions")
+	}
+	for _, m := range moves {
+		if m.From == m.To {
+			t.Errorf("bogus move %+v", m)
+		}
+	}
+	after := owners(r)
+	if after["c"] == 0 || before["c"] != 0 {
+		t.Errorf("c should own partitions only after the update: before %v after %v", before, after)
+	}
+	if got := len(r.Members()); got != 3 {
+		t.Errorf("members: got %d, want 3", got)
+	}
+
+	if moves := r.Update(r.Members()); len(moves) != 0 {
+		t.Errorf("rebuilding with the same members moved %d partition(s)", len(moves))
+	}
+
+	moves = r.Update(nil)
+	if len(moves) != PartitionCount || r.GetWorkerIP("x") != "" {
+		t.Errorf("empty ring: %d move(s), key routed to %q", len(moves), r.GetWorkerIP("x"))
+	}
+}
+
+func TestRouter_TooManyVirtualMembersScaledDown(t *testing.T) {
+	var members []WeightedMember
+	for i := 0; i < 20; i++ {
+		members = append(members, WeightedMember{Name: fmt.Sprintf("w%d", i), Weight: maxWeight})
+	}
+	// 160 virtual members cannot share 71 partitions; this must not panic.
+	r := NewWeightedRouter(members)
+	if r.GetWorkerIP("tenant") == "" {
+		t.Error("expected a member for the key")
+	}
+}
+
+func TestRouter_GetWorkerIPsFailoverOrder(t *testing.T) {
+	r := NewWeightedRouter([]WeightedMember{{"a", 2}, {"b", 1}, {"c", 3}})
+	for i := 0; i < 50; i++ {
+		key := fmt.Sprintf("job-%d", i)
+		order := r.GetWorkerIPs(key)
+		if len(order) != 3 {
+			t.Fatalf("key %s: got %v, want each member once", key, order)
+		}
+		if order[0] != r.GetWorkerIP(key) {
+			t.Fatalf("key %s: owner %s must come first, got %v", key, r.GetWorkerIP(key), order)
+		}
+	}
+	if got := NewRouter(nil).GetWorkerIPs("x"); got != nil {
+		t.Errorf("empty ring: got %v", got)
+	}
+}
@@ -2,12 +2,14 @@ package navigator
 
 import (
 	"fmt"
+	"path"
 	"strconv"
 	"strings"
 
 	jennahv1 "github.com/alphauslabs/jennah/gen/proto"
 	batch "github.com/alphauslabs/jennah/internal/cloudexec"
 	"github.com/alphauslabs/jennah/internal/config"
+	"github.com/alphauslabs/jennah/internal/router"
 )
 
 const (
@@ -30,6 +32,10 @@ const (
 //	boot_disk_size_gb    → BootDiskSizeGb  (default 50 if 0)
 //	use_spot_vms         → UseSpotVMs
 //	service_account      → ServiceAccount
+//	accelerator          → Accelerators + InstallGpuDrivers  (validated against the catalog)
+//	volumes              → Volumes  (validated by ResolveVolumes)
+//	runnables            → Runnables  (validated by ResolveRunnables)
+//	labels               → JobLabels  (validated by ResolveLabels; callers add Jennah's labels with JobLabels)
 //	name                 → Name  (also used in generateProviderJobID)
 //	jobID                → JobID (provider-compatible) + RequestID (idempotency)
 func buildJobConfig(
@@ -65,6 +71,30 @@ func buildJobConfig(
 		)
 	}
 
+	// ── Accelerators ──────────────────────────────────────────────────────────
+	accelerators, err := ResolveAccelerator(req.GetAccelerator(), cfg)
+	if err != nil {
+		return batch.JobConfig{}, err
+	}
+
+	// ── Volumes ───────────────────────────────────────────────────────────────
+	volumes, err := ResolveVolumes(req.GetVolumes())
+	if err != nil {
+		return batch.JobConfig{}, err
+	}
+
+	// ── Runnables ─────────────────────────────────────────────────────────────
+	runnables, err := ResolveRunnables(req)
+	if err != nil {
+		return batch.JobConfig{}, err
+	}
+
+	// ── Labels ────────────────────────────────────────────────────────────────
+	labels, err := ResolveLabels(req)
+	if err != nil {
+		return batch.JobConfig{}, err
+	}
+
 	// ── Provider-compatible job ID ────────────────────────────────────────────
 	// GCP Batch job IDs: alphanumeric + hyphens, ≤ 63 chars.
 	providerJobID := generateProviderJobID(jobID, req.GetName())
@@ -130,20 +160,196 @@ func buildJobConfig(
 		Commands: req.GetCommands(),
 		EnvVars:  envVars,
 
+		// Task steps
+		Runnables: runnables,
+
 		// Resources
 		Resources:      resources,
 		MachineType:    req.GetMachineType(),
 		BootDiskSizeGb: bootDisk,
 		UseSpotVMs:     req.GetUseSpotVms(),
+		Accelerators:   accelerators,
+
+		// Storage
+		Volumes: volumes,
+
+		// VM instance options
+		InstallGpuDrivers: accelerators != nil,
 
 		// Security & networking
 		ServiceAccount: req.GetServiceAccount(),
 
 		// Task group
 		TaskGroup: taskGroup,
+
+		// Metadata
+		JobLabels: labels,
 	}, nil
 }
 
+// ResolveAccelerator validates a requested accelerator against the catalog in
+// cfg and fills in the default count of 1. A nil cfg skips catalog checks.
+// It returns nil when no accelerator was requested.
+func ResolveAccelerator(a *jennahv1.Accelerator, cfg *config.JobConfigFile) (*batch.AcceleratorConfig, error) {
+	if a.GetType() == "" {
+		if a.GetCount() != 0 || a.GetDriverVersion() != "" {
+			return nil, fmt.Errorf("accelerator.type is required when accelerator is set")
+		}
+		return nil, nil
+	}
+	count := a.GetCount()
+	if count == 0 {
+		count = 1
+	}
+	if count < 0 {
+		return nil, fmt.Errorf("accelerator.count must be positive (got %d)", count)
+	}
+	if cfg != nil {
+		if err := cfg.ValidateAccelerator(a.GetType(), count); err != nil {
+			return nil, err
+		}
+	}
+	return &batch.AcceleratorConfig{
+		Type:          a.GetType(),
+		Count:         count,
+		DriverVersion: a.GetDriverVersion(),
+	}, nil
+}
+
+// ResolveVolumes validates the requested volumes and maps them onto
+// VolumeConfigs. Errors name the offending volume by index and description.
+func ResolveVolumes(volumes []*jennahv1.Volume) ([]batch.VolumeConfig, error) {
+	var out []batch.VolumeConfig
+	mountPaths := make(map[string]int, len(volumes))
+	for i, v := range volumes {
+		vc := router.VolumeFromProto(v)
+		vc.Bucket = strings.TrimPrefix(vc.Bucket, "gs://")
+		if msg := volumeProblem(v, vc); msg != "" {
+			return nil, fmt.Errorf("volume[%d] (%s): %s", i, vc, msg)
+		}
+		mountPath := path.Clean(vc.MountPath)
+		if prev, dup := mountPaths[mountPath]; dup {
+			return nil, fmt.Errorf("volume[%d] (%s): mount_path %s is already used by volume[%d]", i, vc, mountPath, prev)
+		}
+		mountPaths[mountPath] = i
+		vc.MountPath = mountPath
+		out = append(out, vc)
+	}
+	return out, nil
+}
+
+// volumeProblem returns why v cannot be mounted, or "" when it is valid.
+func volumeProblem(v *jennahv1.Volume, vc batch.VolumeConfig) string {
+	sources := 0
+	for _, set := range []bool{v.GetGcs() != nil, v.GetNfs() != nil, v.GetLocalSsd() != nil} {
+		if set {
+			sources++
+		}
+	}
+	switch {
+	case sources != 1:
+		return "exactly one of gcs, nfs or local_ssd must be set"
+	case vc.MountPath == "":
+		return "mount_path is required"
+	case !path.IsAbs(vc.MountPath) || strings.Contains(vc.MountPath, ":") || path.Clean(vc.MountPath) == "/":
+		return "mount_path must be an absolute path other than / and must not contain ':'"
+	}
+	switch vc.Type {
+	case batch.VolumeTypeGCS:
+		if vc.Bucket == "" {
+			return "gcs.bucket is required"
+		}
+	case batch.VolumeTypeNFS:
+		if vc.NFSServer == "" || vc.NFSPath == "" {
+			return "nfs.server and nfs.path are required"
+		}
+		if !path.IsAbs(vc.NFSPath) {
+			return "nfs.path must be an absolute export path"
+		}
+	case batch.VolumeTypeLocalSSD:
+		if vc.ReadOnly {
+			return "local SSD scratch cannot be read-only"
+		}
+		if vc.SizeGb < 0 || vc.SizeGb%batch.LocalSSDPartitionGb != 0 {
+			return fmt.Sprintf("local_ssd.size_gb must be a multiple of %d", batch.LocalSSDPartitionGb)
+		}
+	}
+	return ""
+}
+
+// ResolveRunnables validates the request's ordered task steps and maps them
+// onto RunnableConfigs. Errors name the offending step by index.
+func ResolveRunnables(req *jennahv1.SubmitJobRequest) ([]batch.RunnableConfig, error) {
+	steps := req.GetRunnables()
+	if len(steps) == 0 {
+		return nil, nil
+	}
+	if len(req.GetCommands()) > 0 {
+		return nil, fmt.Errorf("commands must be empty when runnables are set; put them on a container runnable")
+	}
+
+	out := make([]batch.RunnableConfig, 0, len(steps))
+	foreground := false
+	for i, r := range steps {
+		if msg := runnableProblem(r); msg != "" {
+			return nil, fmt.Errorf("runnable[%d] (%s): %s", i, runnableLabel(r), msg)
+		}
+		if !r.GetBarrier() && !r.GetBackground() {
+			foreground = true
+		}
+		out = append(out, batch.RunnableConfig{
+			Name:             r.GetName(),
+			ImageURI:         r.GetImageUri(),
+			Commands:         r.GetCommands(),
+			Script:           r.GetScript(),
+			Barrier:          r.GetBarrier(),
+			EnvVars:          r.GetEnvVars(),
+			Background:       r.GetBackground(),
+			IgnoreExitStatus: r.GetIgnoreExitStatus(),
+			AlwaysRun:        r.GetAlwaysRun(),
+		})
+	}
+	if !foreground {
+		return nil, fmt.Errorf("runnables: at least one container or script step must run in the foreground")
+	}
+	return out, nil
+}
+
+// runnableProblem returns why r is not a valid step, or "" when it is.
+func runnableProblem(r *jennahv1.Runnable) string {
+	container := r.GetImageUri() != "" || len(r.GetCommands()) > 0
+	switch {
+	case r.GetBarrier():
+		if container || r.GetScript() != "" || len(r.GetEnvVars()) > 0 {
+			return "a barrier cannot also set image_uri, commands, script or env_vars"
+		}
+		if r.GetName() == "" {
+			return "barrier requires a name"
+		}
+		if r.GetBackground() || r.GetIgnoreExitStatus() || r.GetAlwaysRun() {
+			return "background, ignore_exit_status and always_run do not apply to barriers"
+		}
+	case r.GetScript() != "" && container:
+		return "set either script or image_uri/commands, not both"
+	}
+	return ""
+}
+
+// runnableLabel describes a step for error messages.
+func runnableLabel(r *jennahv1.Runnable) string {
+	kind := "container"
+	switch {
+	case r.GetBarrier():
+		kind = "barrier"
+	case r.GetScript() != "":
+		kind = "script"
+	}
+	if r.GetName() != "" {
+		return kind + " " + r.GetName()
+	}
+	return kind
+}
+
 // generateProviderJobID produces a GCP Batch-compatible job ID (≤ 63 chars,
 // alphanumeric + hyphens only).
 //
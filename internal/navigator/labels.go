@@ -0,0 +1,104 @@
+package navigator
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	jennahv1 "github.com/alphauslabs/jennah/gen/proto"
+)
+
+// Labels Jennah attaches to every cloud resource so spend can be attributed
+// to tenants and jobs.
+const (
+	LabelTenant      = "jennah-tenant"
+	LabelJobID       = "jennah-job-id"
+	LabelServiceTier = "jennah-service-tier"
+	LabelEnv         = "jennah-env"
+)
+
+const (
+	// maxLabelLength is GCP's limit on label key and value length.
+	maxLabelLength = 63
+	// maxLabels is GCP's limit on labels per resource.
+	maxLabels = 64
+	// systemLabelCount is the number of labels Jennah sets itself.
+	systemLabelCount = 4
+)
+
+var (
+	labelKeyPattern   = regexp.MustCompile(`^[a-z][a-z0-9_-]*$`)
+	labelValuePattern = regexp.MustCompile(`^[a-z0-9_-]*$`)
+	labelInvalidChars = regexp.MustCompile(`[^a-z0-9_-]+`)
+)
+
+// ResolveLabels validates the request's custom labels against GCP's label
+// rules and returns a copy. Keys with the "jennah-" prefix are reserved for
+// Jennah's own labels and "goog" for Google's.
+func ResolveLabels(req *jennahv1.SubmitJobRequest) (map[string]string, error) {
+	labels := req.GetLabels()
+	if len(labels) == 0 {
+		return nil, nil
+	}
+	if len(labels) > maxLabels-systemLabelCount {
+		return nil, fmt.Errorf("labels: at most %d custom labels are allowed (got %d)", maxLabels-systemLabelCount, len(labels))
+	}
+
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	out := make(map[string]string, len(labels))
+	for _, k := range keys {
+		v := labels[k]
+		switch {
+		case k == "" || len(k) > maxLabelLength:
+			return nil, fmt.Errorf("label key %q: must be 1-%d characters", k, maxLabelLength)
+		case !labelKeyPattern.MatchString(k):
+			return nil, fmt.Errorf("label key %q: must start with a lowercase letter and contain only lowercase letters, digits, '_' and '-'", k)
+		case strings.HasPrefix(k, "jennah-") || strings.HasPrefix(k, "goog"):
+			return nil, fmt.Errorf("label key %q: the %q prefix is reserved", k, reservedPrefix(k))
+		case len(v) > maxLabelLength:
+			return nil, fmt.Errorf("label %q: value must be at most %d characters (got %d)", k, maxLabelLength, len(v))
+		case !labelValuePattern.MatchString(v):
+			return nil, fmt.Errorf("label %q: value %q may contain only lowercase letters, digits, '_' and '-'", k, v)
+		}
+		out[k] = v
+	}
+	return out, nil
+}
+
+func reservedPrefix(key string) string {
+	if strings.HasPrefix(key, "jennah-") {
+		return "jennah-"
+	}
+	return "goog"
+}
+
+// JobLabels returns the labels for a job's cloud resources: the custom
+// labels plus Jennah's tenant, job, service tier and environment labels,
+// sanitized to GCP's label rules.
+func JobLabels(custom map[string]string, tenantID, jobID, serviceTier, env string) map[string]string {
+	labels := make(map[string]string, len(custom)+systemLabelCount)
+	for k, v := range custom {
+		labels[k] = v
+	}
+	labels[LabelTenant] = SanitizeLabelValue(tenantID)
+	labels[LabelJobID] = SanitizeLabelValue(jobID)
+	labels[LabelServiceTier] = SanitizeLabelValue(serviceTier)
+	labels[LabelEnv] = SanitizeLabelValue(env)
+	return labels
+}
+
+// SanitizeLabelValue lowercases v, replaces runs of characters GCP does not
+// allow in label values with '_', and truncates it to 63 characters.
+func SanitizeLabelValue(v string) string {
+	v = labelInvalidChars.ReplaceAllString(strings.ToLower(v), "_")
+	if len(v) > maxLabelLength {
+		v = v[:maxLabelLength]
+	}
+	return v
+}
@@ -5,17 +5,19 @@
 //	SubmitJobRequest
 //	    ↓
 //	navigator.Navigate()          ← you are here
-//	    ├─ router.EvaluateJobComplexity()  — classify SIMPLE / MEDIUM / COMPLEX
 //	    ├─ buildJobConfig()                — translate all proto fields → JobConfig
+//	    ├─ router.PolicyChain.Evaluate()   — the single, authoritative routing decision
 //	    └─ NavigationPlan                  — complete, ready-to-execute plan
 //	         ↓
 //	GCP Cloud Tasks / Cloud Run Jobs / Cloud Batch
 //
-// The navigator is deliberately stateless (no I/O). It only transforms data so
-// it is easy to unit-test and safe to call from any goroutine.
+// The navigator is deliberately stateless. It only transforms data so it is
+// easy to unit-test and safe to call from any goroutine; the only I/O is what
+// the routing policies it is given perform (e.g. router.ClassifierPolicy).
 package navigator
 
 import (
+	"context"
 	"fmt"
 
 	jennahv1 "github.com/alphauslabs/jennah/gen/proto"
@@ -41,6 +43,9 @@ type NavigationPlan struct {
 	// ClassifyReason is a human-readable explanation of the routing decision.
 	ClassifyReason string
 
+	// RoutingPolicy names the routing policy that made the decision.
+	RoutingPolicy string
+
 	// ── Execution config ──────────────────────────────────────────────────────
 
 	// Config is the fully-populated JobConfig ready to pass to the batch provider
@@ -57,13 +62,26 @@ type NavigationPlan struct {
 // Navigate is the single entry point for the navigator/load-balancer.
 //
 // It accepts:
-//   - req   : the validated SubmitJobRequest from the gateway
-//   - jobID : a pre-generated UUID (used for idempotency + DB record linking)
-//   - cfg   : loaded job-config.json (resource profiles)
+//   - req        : the validated SubmitJobRequest from the gateway
+//   - jobID      : a pre-generated UUID (used for idempotency + DB record linking)
+//   - cfg        : loaded job-config.json (resource profiles)
+//   - candidates : the providers registered in the worker's Dispatcher
+//
+// The job is routed to the cheapest candidate whose capabilities fit the
+// resolved resources; if none fits, an error naming each rejected provider's
+// limit is returned.
 //
 // It returns a NavigationPlan with all fields populated, or an error if the
 // request cannot be mapped to a valid execution plan.
-func Navigate(req *jennahv1.SubmitJobRequest, jobID string, cfg *config.JobConfigFile) (*NavigationPlan, error) {
+func Navigate(req *jennahv1.SubmitJobRequest, jobID string, cfg *config.JobConfigFile, candidates []router.ProviderCandidate) (*NavigationPlan, error) {
+	return NavigateWithPolicies(context.Background(), req, "", jobID, cfg, candidates, router.DefaultPolicies())
+}
+
+// NavigateWithPolicies is Navigate with an explicit routing
+// policy chain. The chain is evaluated exactly once, against the resolved job
+// config, and the plan carries its decision unchanged. tenantID is made
+// available to policies that route per tenant.
+func NavigateWithPolicies(ctx context.Context, req *jennahv1.SubmitJobRequest, tenantID, jobID string, cfg *config.JobConfigFile, candidates []router.ProviderCandidate, policies router.PolicyChain) (*NavigationPlan, error) {
 	if req == nil {
 		return nil, fmt.Errorf("navigator: request must not be nil")
 	}
@@ -71,20 +89,30 @@ func Navigate(req *jennahv1.SubmitJobRequest, jobID string, cfg *config.JobConfi
 		return nil, fmt.Errorf("navigator: jobID must not be empty")
 	}
 
-	// Step 1 — Classify complexity and select target GCP service.
-	decision := router.EvaluateJobComplexity(req)
-
-	// Step 2 — Build the full JobConfig (field translation + resource resolution).
+	// Step 1 — Build the full JobConfig (field translation + resource resolution).
 	jobCfg, err := buildJobConfig(req, jobID, cfg)
 	if err != nil {
 		return nil, fmt.Errorf("navigator: failed to build job config: %w", err)
 	}
 
+	// Step 2 — Select the target service. The chain sees the resolved
+	// resources, so profile defaults count the same as explicit overrides.
+	decision := policies.Evaluate(ctx, router.RoutingInput{
+		Request:    req,
+		TenantID:   tenantID,
+		Workload:   router.WorkloadFromJobConfig(jobCfg),
+		Candidates: candidates,
+	})
+	if decision.AssignedService == router.AssignedServiceUnspecified {
+		return nil, fmt.Errorf("navigator: %s", decision.Reason)
+	}
+
 	// Step 3 — Assemble and return the navigation plan.
 	plan := &NavigationPlan{
 		Complexity:      decision.Complexity,
 		AssignedService: decision.AssignedService,
 		ClassifyReason:  decision.Reason,
+		RoutingPolicy:   decision.Policy,
 		Config:          jobCfg,
 		Summary: fmt.Sprintf(
 			"job=%s tier=%s service=%s image=%s",
@@ -97,3 +125,39 @@ func Navigate(req *jennahv1.SubmitJobRequest, jobID string, cfg *config.JobConfi
 
 	return plan, nil
 }
+
+// NavigateAssigned builds the plan for a job whose routing decision was made
+// on an earlier attempt, such as a resubmission after Spot preemption. The
+// policy chain is not evaluated; the plan keeps service and reason as stored,
+// so only what changed in req (e.g. the provisioning model) differs.
+func NavigateAssigned(req *jennahv1.SubmitJobRequest, jobID string, cfg *config.JobConfigFile, service router.AssignedService, reason string) (*NavigationPlan, error) {
+	if req == nil {
+		return nil, fmt.Errorf("navigator: request must not be nil")
+	}
+	if jobID == "" {
+		return nil, fmt.Errorf("navigator: jobID must not be empty")
+	}
+	if service == router.AssignedServiceUnspecified {
+		return nil, fmt.Errorf("navigator: job %s has no assigned service", jobID)
+	}
+
+	jobCfg, err := buildJobConfig(req, jobID, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("navigator: failed to build job config: %w", err)
+	}
+
+	complexity := router.ComplexityForService(service)
+	return &NavigationPlan{
+		Complexity:      complexity,
+		AssignedService: service,
+		ClassifyReason:  reason,
+		Config:          jobCfg,
+		Summary: fmt.Sprintf(
+			"job=%s tier=%s service=%s image=%s",
+			jobID,
+			complexity,
+			service,
+			req.GetImageUri(),
+		),
+	}, nil
+}
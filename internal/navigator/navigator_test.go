@@ -1,13 +1,26 @@
 package navigator
 
 import (
+	"context"
+	"fmt"
 	"strings"
 	"testing"
 
 	jennahv1 "github.com/alphauslabs/jennah/gen/proto"
+	batch "github.com/alphauslabs/jennah/internal/cloudexec"
+	"github.com/alphauslabs/jennah/internal/cloudexec/gcp"
+	"github.com/alphauslabs/jennah/internal/config"
 	"github.com/alphauslabs/jennah/internal/router"
 )
 
+// testCandidates are the GCP providers as the worker registers them.
+func testCandidates() []router.ProviderCandidate {
+	return []router.ProviderCandidate{
+		{Service: router.AssignedServiceCloudRunJob, Capabilities: gcp.CloudRunJobCapabilities},
+		{Service: router.AssignedServiceCloudBatch, Capabilities: gcp.CloudBatchCapabilities},
+	}
+}
+
 // ─── Navigate() ─────────────────────────────────────────────────────────────
 
 func TestNavigate_SimpleJob(t *testing.T) {
@@ -15,7 +28,7 @@ func TestNavigate_SimpleJob(t *testing.T) {
 		ImageUri: "gcr.io/google-samples/hello-app:1.0",
 		EnvVars:  map[string]string{"APP_NAME": "hello-world"},
 	}
-	plan, err := Navigate(req, "aaaaaaaa-0000-0000-0000-000000000001", nil)
+	plan, err := Navigate(req, "aaaaaaaa-0000-0000-0000-000000000001", nil, testCandidates())
 	if err != nil {
 		t.Fatalf("Navigate() error: %v", err)
 	}
@@ -51,7 +64,7 @@ func TestNavigate_MediumJob(t *testing.T) {
 			CpuMillis: 2000,
 		},
 	}
-	plan, err := Navigate(req, "bbbbbbbb-0000-0000-0000-000000000002", nil)
+	plan, err := Navigate(req, "bbbbbbbb-0000-0000-0000-000000000002", nil, testCandidates())
 	if err != nil {
 		t.Fatalf("Navigate() error: %v", err)
 	}
@@ -81,7 +94,7 @@ func TestNavigate_ComplexJob_MachineType(t *testing.T) {
 		UseSpotVms:     true,
 		ServiceAccount: "ml-sa@my-project.iam.gserviceaccount.com",
 	}
-	plan, err := Navigate(req, "cccccccc-0000-0000-0000-000000000003", nil)
+	plan, err := Navigate(req, "cccccccc-0000-0000-0000-000000000003", nil, testCandidates())
 	if err != nil {
 		t.Fatalf("Navigate() error: %v", err)
 	}
@@ -114,7 +127,7 @@ func TestNavigate_ComplexJob_HeavyResources(t *testing.T) {
 			MaxRunDurationSeconds: 7200,
 		},
 	}
-	plan, err := Navigate(req, "dddddddd-0000-0000-0000-000000000004", nil)
+	plan, err := Navigate(req, "dddddddd-0000-0000-0000-000000000004", nil, testCandidates())
 	if err != nil {
 		t.Fatalf("Navigate() error: %v", err)
 	}
@@ -127,7 +140,7 @@ func TestNavigate_ComplexJob_HeavyResources(t *testing.T) {
 }
 
 func TestNavigate_NilRequest(t *testing.T) {
-	_, err := Navigate(nil, "some-id", nil)
+	_, err := Navigate(nil, "some-id", nil, testCandidates())
 	if err == nil {
 		t.Error("expected error for nil request")
 	}
@@ -135,7 +148,7 @@ func TestNavigate_NilRequest(t *testing.T) {
 
 func TestNavigate_EmptyJobID(t *testing.T) {
 	req := &jennahv1.SubmitJobRequest{ImageUri: "alpine:latest"}
-	_, err := Navigate(req, "", nil)
+	_, err := Navigate(req, "", nil, testCandidates())
 	if err == nil {
 		t.Error("expected error for empty jobID")
 	}
@@ -146,7 +159,7 @@ func TestNavigate_InvalidBootDisk(t *testing.T) {
 		ImageUri:       "alpine:latest",
 		BootDiskSizeGb: 5, // below 10 GB minimum
 	}
-	_, err := Navigate(req, "eeeeeeee-0000-0000-0000-000000000005", nil)
+	_, err := Navigate(req, "eeeeeeee-0000-0000-0000-000000000005", nil, testCandidates())
 	if err == nil {
 		t.Error("expected error for boot_disk_size_gb < 10")
 	}
@@ -231,7 +244,7 @@ func TestResolveBuiltinProfile_OverrideApplied(t *testing.T) {
 
 func TestNavigate_TaskGroupDefaults(t *testing.T) {
 	req := &jennahv1.SubmitJobRequest{ImageUri: "alpine:latest"}
-	plan, err := Navigate(req, "ffffffff-0000-0000-0000-000000000006", nil)
+	plan, err := Navigate(req, "ffffffff-0000-0000-0000-000000000006", nil, testCandidates())
 	if err != nil {
 		t.Fatalf("Navigate() error: %v", err)
 	}
@@ -254,7 +267,7 @@ func TestNavigate_DistributedModeDefaultsTaskGroup(t *testing.T) {
 			"ENABLE_DISTRIBUTED_MODE": "true",
 		},
 	}
-	plan, err := Navigate(req, "hhhhhhhh-0000-0000-0000-000000000008", nil)
+	plan, err := Navigate(req, "hhhhhhhh-0000-0000-0000-000000000008", nil, testCandidates())
 	if err != nil {
 		t.Fatalf("Navigate() error: %v", err)
 	}
@@ -279,7 +292,7 @@ func TestNavigate_DistributedModeRespectsTaskHints(t *testing.T) {
 			"JENNAH_PARALLELISM":      "2",
 		},
 	}
-	plan, err := Navigate(req, "iiiiiiii-0000-0000-0000-000000000009", nil)
+	plan, err := Navigate(req, "iiiiiiii-0000-0000-0000-000000000009", nil, testCandidates())
 	if err != nil {
 		t.Fatalf("Navigate() error: %v", err)
 	}
@@ -303,7 +316,7 @@ func TestNavigate_EnvVarsAreCopied(t *testing.T) {
 		ImageUri: "alpine:latest",
 		EnvVars:  originalEnv,
 	}
-	plan, err := Navigate(req, "gggggggg-0000-0000-0000-000000000007", nil)
+	plan, err := Navigate(req, "gggggggg-0000-0000-0000-000000000007", nil, testCandidates())
 	if err != nil {
 		t.Fatalf("Navigate() error: %v", err)
 	}
@@ -319,7 +332,7 @@ func TestNavigate_EnvVarsAreCopied(t *testing.T) {
 func TestNavigate_RequestIDIsRawUUID(t *testing.T) {
 	uuid := "12345678-abcd-ef00-1234-abcdef012345"
 	req := &jennahv1.SubmitJobRequest{ImageUri: "alpine:latest"}
-	plan, err := Navigate(req, uuid, nil)
+	plan, err := Navigate(req, uuid, nil, testCandidates())
 	if err != nil {
 		t.Fatalf("Navigate() error: %v", err)
 	}
@@ -327,3 +340,365 @@ func TestNavigate_RequestIDIsRawUUID(t *testing.T) {
 		t.Errorf("RequestID: got %q, want %q", plan.Config.RequestID, uuid)
 	}
 }
+
+func TestNavigate_OnlyRegisteredProviders(t *testing.T) {
+	// A small job that would normally go to Cloud Run must use Cloud Batch
+	// when Cloud Run Jobs is not registered.
+	req := &jennahv1.SubmitJobRequest{ImageUri: "alpine:latest"}
+	onlyBatch := []router.ProviderCandidate{testCandidates()[1]}
+	plan, err := Navigate(req, "ffffffff-0000-0000-0000-000000000006", nil, onlyBatch)
+	if err != nil {
+		t.Fatalf("Navigate() error: %v", err)
+	}
+	if plan.AssignedService != router.AssignedServiceCloudBatch {
+		t.Errorf("service: got %s, want CLOUD_BATCH", plan.AssignedService)
+	}
+}
+
+func TestNavigate_NoProviderFits(t *testing.T) {
+	req := &jennahv1.SubmitJobRequest{ImageUri: "alpine:latest", MachineType: "n2-standard-8"}
+	onlyRun := []router.ProviderCandidate{testCandidates()[0]}
+	_, err := Navigate(req, "ffffffff-0000-0000-0000-000000000007", nil, onlyRun)
+	if err == nil || !strings.Contains(err.Error(), "machine_type") {
+		t.Errorf("expected machine_type exclusion error, got %v", err)
+	}
+}
+
+// ─── Accelerators ────────────────────────────────────────────────────────────
+
+func acceleratorCatalog() *config.JobConfigFile {
+	return &config.JobConfigFile{
+		DefaultResources: config.ResourceProfile{CPUMillis: 2000, MemoryMiB: 4096, MaxRunDurationSeconds: 3600},
+		Accelerators: map[string]config.AcceleratorSpec{
+			"nvidia-tesla-t4": {Counts: []int64{1, 2, 4}},
+		},
+	}
+}
+
+func TestNavigate_AcceleratorRoutesToBatch(t *testing.T) {
+	req := &jennahv1.SubmitJobRequest{
+		ImageUri:    "gcr.io/project/trainer:latest",
+		Accelerator: &jennahv1.Accelerator{Type: "nvidia-tesla-t4", DriverVersion: "535.104.05"},
+	}
+	plan, err := Navigate(req, "abababab-0000-0000-0000-000000000001", acceleratorCatalog(), testCandidates())
+	if err != nil {
+		t.Fatalf("Navigate() error: %v", err)
+	}
+	if plan.AssignedService != router.AssignedServiceCloudBatch {
+		t.Errorf("service: got %s, want CLOUD_BATCH", plan.AssignedService)
+	}
+	accel := plan.Config.Accelerators
+	if accel == nil || accel.Type != "nvidia-tesla-t4" || accel.Count != 1 || accel.DriverVersion != "535.104.05" {
+		t.Errorf("Accelerators: got %+v, want nvidia-tesla-t4 x1 with driver", accel)
+	}
+	if !plan.Config.InstallGpuDrivers {
+		t.Error("InstallGpuDrivers must be set for accelerator jobs")
+	}
+}
+
+func TestNavigate_AcceleratorValidatedAgainstCatalog(t *testing.T) {
+	cases := []struct {
+		name  string
+		accel *jennahv1.Accelerator
+		want  string
+	}{
+		{"unknown type", &jennahv1.Accelerator{Type: "nvidia-h200"}, "unknown accelerator type"},
+		{"unsupported count", &jennahv1.Accelerator{Type: "nvidia-tesla-t4", Count: 3}, "does not support count 3"},
+		{"count without type", &jennahv1.Accelerator{Count: 2}, "accelerator.type is required"},
+	}
+	for _, tc := range cases {
+		req := &jennahv1.SubmitJobRequest{ImageUri: "alpine:latest", Accelerator: tc.accel}
+		_, err := Navigate(req, "abababab-0000-0000-0000-000000000002", acceleratorCatalog(), testCandidates())
+		if err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("[%s] expected error containing %q, got %v", tc.name, tc.want, err)
+		}
+	}
+}
+
+// ─── Volumes ─────────────────────────────────────────────────────────────────
+
+func TestNavigate_VolumesMappedAndRouted(t *testing.T) {
+	req := &jennahv1.SubmitJobRequest{
+		ImageUri: "alpine:latest",
+		Volumes: []*jennahv1.Volume{
+			{MountPath: "/mnt/data/", ReadOnly: true, Gcs: &jennahv1.GcsVolume{Bucket: "gs://shared-data"}},
+			{MountPath: "/mnt/share", Nfs: &jennahv1.NfsVolume{Server: "10.0.0.2", Path: "/vol1"}},
+		},
+	}
+	plan, err := Navigate(req, "cdcdcdcd-0000-0000-0000-000000000001", nil, testCandidates())
+	if err != nil {
+		t.Fatalf("Navigate() error: %v", err)
+	}
+	if plan.AssignedService != router.AssignedServiceCloudRunJob {
+		t.Errorf("GCS/NFS volumes fit Cloud Run: got %s (%s)", plan.AssignedService, plan.ClassifyReason)
+	}
+	vols := plan.Config.Volumes
+	if len(vols) != 2 {
+		t.Fatalf("Volumes: got %d, want 2", len(vols))
+	}
+	if vols[0].Type != batch.VolumeTypeGCS || vols[0].Bucket != "shared-data" || vols[0].MountPath != "/mnt/data" || !vols[0].ReadOnly {
+		t.Errorf("gcs volume: got %+v", vols[0])
+	}
+	if vols[1].Type != batch.VolumeTypeNFS || vols[1].NFSServer != "10.0.0.2" || vols[1].NFSPath != "/vol1" {
+		t.Errorf("nfs volume: got %+v", vols[1])
+	}
+}
+
+func TestNavigate_LocalSSDRoutesToBatch(t *testing.T) {
+	req := &jennahv1.SubmitJobRequest{
+		ImageUri: "alpine:latest",
+		Volumes:  []*jennahv1.Volume{{MountPath: "/scratch", LocalSsd: &jennahv1.LocalSsdVolume{}}},
+	}
+	plan, err := Navigate(req, "cdcdcdcd-0000-0000-0000-000000000002", nil, testCandidates())
+	if err != nil {
+		t.Fatalf("Navigate() error: %v", err)
+	}
+	if plan.AssignedService != router.AssignedServiceCloudBatch {
+		t.Errorf("local SSD needs Cloud Batch: got %s", plan.AssignedService)
+	}
+	if got := plan.Config.Volumes[0].SizeGb; got != batch.LocalSSDPartitionGb {
+		t.Errorf("SizeGb: got %d, want default %d", got, batch.LocalSSDPartitionGb)
+	}
+}
+
+func TestNavigate_InvalidVolumesNameTheVolume(t *testing.T) {
+	cases := []struct {
+		name string
+		vol  *jennahv1.Volume
+		want string
+	}{
+		{"no source", &jennahv1.Volume{MountPath: "/mnt/x"}, "volume[1] (volume at /mnt/x): exactly one of"},
+		{"relative path", &jennahv1.Volume{MountPath: "data", Gcs: &jennahv1.GcsVolume{Bucket: "b"}}, "absolute path"},
+		{"duplicate path", &jennahv1.Volume{MountPath: "/mnt/data", Gcs: &jennahv1.GcsVolume{Bucket: "b"}}, "already used by volume[0]"},
+		{"nfs without server", &jennahv1.Volume{MountPath: "/mnt/nfs", Nfs: &jennahv1.NfsVolume{Path: "/vol1"}}, "nfs.server"},
+		{"odd ssd size", &jennahv1.Volume{MountPath: "/scratch", LocalSsd: &jennahv1.LocalSsdVolume{SizeGb: 100}}, "multiple of 375"},
+		{"read-only ssd", &jennahv1.Volume{MountPath: "/scratch", ReadOnly: true, LocalSsd: &jennahv1.LocalSsdVolume{}}, "cannot be read-only"},
+	}
+	for _, tc := range cases {
+		req := &jennahv1.SubmitJobRequest{
+			ImageUri: "alpine:latest",
+			Volumes: []*jennahv1.Volume{
+				{MountPath: "/mnt/data", Gcs: &jennahv1.GcsVolume{Bucket: "shared-data"}},
+				tc.vol,
+			},
+		}
+		_, err := Navigate(req, "cdcdcdcd-0000-0000-0000-000000000003", nil, testCandidates())
+		if err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("[%s] expected error containing %q, got %v", tc.name, tc.want, err)
+		}
+	}
+}
+
+// ─── Runnables ───────────────────────────────────────────────────────────────
+
+func TestNavigate_RunnablesRouteToBatch(t *testing.T) {
+	req := &jennahv1.SubmitJobRequest{
+		ImageUri: "gcr.io/project/etl:latest",
+		EnvVars:  map[string]string{"STAGE": "prod"},
+		Runnables: []*jennahv1.Runnable{
+			{Name: "fetch", Script: "gsutil cp gs://in/data.csv /tmp/"},
+			{Name: "metrics", ImageUri: "gcr.io/project/agent:1", Background: true},
+			{Name: "main", Commands: []string{"python", "etl.py"}},
+			{Name: "upload", Script: "gsutil cp /tmp/out gs://out/", AlwaysRun: true},
+		},
+	}
+	plan, err := Navigate(req, "efefefef-0000-0000-0000-000000000001", nil, testCandidates())
+	if err != nil {
+		t.Fatalf("Navigate() error: %v", err)
+	}
+	if plan.AssignedService != router.AssignedServiceCloudBatch {
+		t.Errorf("service: got %s, want CLOUD_BATCH", plan.AssignedService)
+	}
+	if !strings.Contains(plan.ClassifyReason, "CLOUD_RUN_JOB excluded: runnables not supported") {
+		t.Errorf("reason %q should explain why Cloud Run was excluded", plan.ClassifyReason)
+	}
+	steps := plan.Config.Runnables
+	if len(steps) != 4 {
+		t.Fatalf("Runnables: got %d, want 4", len(steps))
+	}
+	if steps[0].Script == "" || !steps[1].Background || steps[2].Commands[0] != "python" || !steps[3].AlwaysRun {
+		t.Errorf("steps not mapped in order: %+v", steps)
+	}
+}
+
+func TestNavigate_RunnablesRejectedWithoutBatch(t *testing.T) {
+	req := &jennahv1.SubmitJobRequest{
+		ImageUri:  "alpine:latest",
+		Runnables: []*jennahv1.Runnable{{Name: "main", Commands: []string{"true"}}},
+	}
+	onlyCloudRun := testCandidates()[:1]
+	_, err := Navigate(req, "efefefef-0000-0000-0000-000000000002", nil, onlyCloudRun)
+	if err == nil || !strings.Contains(err.Error(), "runnables not supported") {
+		t.Errorf("expected routing error naming runnables, got %v", err)
+	}
+}
+
+func TestNavigate_InvalidRunnables(t *testing.T) {
+	cases := []struct {
+		name string
+		req  *jennahv1.SubmitJobRequest
+		want string
+	}{
+		{"top-level commands", &jennahv1.SubmitJobRequest{
+			Commands:  []string{"run"},
+			Runnables: []*jennahv1.Runnable{{Commands: []string{"true"}}},
+		}, "commands must be empty"},
+		{"script and image", &jennahv1.SubmitJobRequest{
+			Runnables: []*jennahv1.Runnable{{Name: "x", Script: "echo", ImageUri: "alpine"}},
+		}, "runnable[0] (script x): set either script"},
+		{"unnamed barrier", &jennahv1.SubmitJobRequest{
+			Runnables: []*jennahv1.Runnable{{Commands: []string{"true"}}, {Barrier: true}},
+		}, "runnable[1] (barrier): barrier requires a name"},
+		{"only background", &jennahv1.SubmitJobRequest{
+			Runnables: []*jennahv1.Runnable{{Name: "sidecar", Background: true}},
+		}, "foreground"},
+	}
+	for _, tc := range cases {
+		tc.req.ImageUri = "alpine:latest"
+		_, err := Navigate(tc.req, "efefefef-0000-0000-0000-000000000003", nil, testCandidates())
+		if err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("[%s] expected error containing %q, got %v", tc.name, tc.want, err)
+		}
+	}
+}
+
+func TestNavigate_LabelsCarriedToConfig(t *testing.T) {
+	req := &jennahv1.SubmitJobRequest{
+		ImageUri: "alpine:latest",
+		Labels:   map[string]string{"cost-center": "cc-1234", "team": ""},
+	}
+	plan, err := Navigate(req, "efefefef-0000-0000-0000-000000000004", nil, testCandidates())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if plan.Config.JobLabels["cost-center"] != "cc-1234" || len(plan.Config.JobLabels) != 2 {
+		t.Errorf("labels not carried over: %v", plan.Config.JobLabels)
+	}
+}
+
+func TestNavigate_InvalidLabels(t *testing.T) {
+	cases := []struct {
+		name   string
+		labels map[string]string
+		want   string
+	}{
+		{"uppercase key", map[string]string{"CostCenter": "x"}, `label key "CostCenter": must start with a lowercase letter`},
+		{"digit first", map[string]string{"1team": "x"}, "must start with a lowercase letter"},
+		{"long key", map[string]string{strings.Repeat("k", 64): "x"}, "must be 1-63 characters"},
+		{"reserved jennah", map[string]string{"jennah-tenant": "x"}, `the "jennah-" prefix is reserved`},
+		{"reserved goog", map[string]string{"google-thing": "x"}, `the "goog" prefix is reserved`},
+		{"bad value", map[string]string{"team": "Data Eng"}, `label "team": value "Data Eng" may contain only`},
+		{"long value", map[string]string{"team": strings.Repeat("v", 64)}, "value must be at most 63 characters"},
+	}
+	for _, tc := range cases {
+		req := &jennahv1.SubmitJobRequest{ImageUri: "alpine:latest", Labels: tc.labels}
+		_, err := Navigate(req, "efefefef-0000-0000-0000-000000000005", nil, testCandidates())
+		if err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("[%s] expected error containing %q, got %v", tc.name, tc.want, err)
+		}
+	}
+
+	tooMany := make(map[string]string)
+	for i := 0; i < 61; i++ {
+		tooMany[fmt.Sprintf("l%d", i)] = "x"
+	}
+	if _, err := ResolveLabels(&jennahv1.SubmitJobRequest{Labels: tooMany}); err == nil || !strings.Contains(err.Error(), "at most 60") {
+		t.Errorf("expected a label count error, got %v", err)
+	}
+}
+
+func TestJobLabels(t *testing.T) {
+	labels := JobLabels(map[string]string{"cost-center": "cc-1234"},
+		"7F3A2C10-AAAA-4BBB-8CCC-DDDDDDDDDDDD", "efefefef-0000-0000-0000-000000000006", "COMPLEX", "Prod EU")
+
+	want := map[string]string{
+		"cost-center":         "cc-1234",
+		"jennah-tenant":       "7f3a2c10-aaaa-4bbb-8ccc-dddddddddddd",
+		"jennah-job-id":       "efefefef-0000-0000-0000-000000000006",
+		"jennah-service-tier": "complex",
+		"jennah-env":          "prod_eu",
+	}
+	if len(labels) != len(want) {
+		t.Fatalf("labels = %v, want %v", labels, want)
+	}
+	for k, v := range want {
+		if labels[k] != v {
+			t.Errorf("labels[%q] = %q, want %q", k, labels[k], v)
+		}
+	}
+}
+
+func TestSanitizeLabelValue(t *testing.T) {
+	if got := SanitizeLabelValue("a.b@c"); got != "a_b_c" {
+		t.Errorf("SanitizeLabelValue = %q, want a_b_c", got)
+	}
+	if got := SanitizeLabelValue(strings.Repeat("x", 70)); len(got) != 63 {
+		t.Errorf("sanitized value has %d characters, want 63", len(got))
+	}
+}
+
+// fixedPolicy always routes to one service.
+type fixedPolicy struct {
+	service router.AssignedService
+	seen    *router.Workload
+}
+
+func (fixedPolicy) Name() string { return "fixed" }
+
+func (p fixedPolicy) Decide(_ context.Context, in router.RoutingInput) (router.RoutingDecision, bool) {
+	*p.seen = in.Workload
+	return router.RoutingDecision{
+		Complexity:      router.ComplexityForService(p.service),
+		AssignedService: p.service,
+		Reason:          "pinned by test",
+	}, true
+}
+
+func TestNavigateWithPolicies_PlanCarriesChainDecision(t *testing.T) {
+	var seen router.Workload
+	req := &jennahv1.SubmitJobRequest{
+		ImageUri:        "gcr.io/google-samples/hello-app:1.0",
+		ResourceProfile: "large",
+	}
+	plan, err := NavigateWithPolicies(context.Background(), req, "tenant-1", "aaaaaaaa-0000-0000-0000-000000000037", nil,
+		testCandidates(), router.PolicyChain{fixedPolicy{service: router.AssignedServiceCloudBatch, seen: &seen}})
+	if err != nil {
+		t.Fatalf("NavigateWithPolicies() error: %v", err)
+	}
+	if plan.AssignedService != router.AssignedServiceCloudBatch {
+		t.Errorf("service: got %s, want CLOUD_BATCH", plan.AssignedService)
+	}
+	if plan.ClassifyReason != "pinned by test" || plan.RoutingPolicy != "fixed" {
+		t.Errorf("reason/policy: got %q/%q", plan.ClassifyReason, plan.RoutingPolicy)
+	}
+	// The chain sees the resolved profile, not the raw (empty) override.
+	if seen.CPUMillis != plan.Config.Resources.CPUMillis || seen.CPUMillis == 0 {
+		t.Errorf("policy saw CPUMillis=%d, want resolved %d", seen.CPUMillis, plan.Config.Resources.CPUMillis)
+	}
+}
+
+func TestNavigateAssigned_KeepsStoredService(t *testing.T) {
+	// A small job the chain would send to Cloud Run; the stored decision wins.
+	req := &jennahv1.SubmitJobRequest{
+		ImageUri:        "gcr.io/google-samples/hello-app:1.0",
+		ResourceProfile: "small",
+		UseSpotVms:      false,
+	}
+	plan, err := NavigateAssigned(req, "aaaaaaaa-0000-0000-0000-000000000028", nil, router.AssignedServiceCloudBatch, "stored reason")
+	if err != nil {
+		t.Fatalf("NavigateAssigned() error: %v", err)
+	}
+	if plan.AssignedService != router.AssignedServiceCloudBatch || plan.Complexity != router.ComplexityComplex {
+		t.Errorf("service/tier: got %s/%s, want CLOUD_BATCH/COMPLEX", plan.AssignedService, plan.Complexity)
+	}
+	if plan.ClassifyReason != "stored reason" {
+		t.Errorf("reason: got %q", plan.ClassifyReason)
+	}
+	if plan.Config.UseSpotVMs {
+		t.Error("UseSpotVMs: got true, want the request's false")
+	}
+
+	if _, err := NavigateAssigned(req, "aaaaaaaa-0000-0000-0000-000000000028", nil, router.AssignedServiceUnspecified, ""); err == nil {
+		t.Error("expected an error for a job without an assigned service")
+	}
+}
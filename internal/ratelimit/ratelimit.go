@@ -0,0 +1,388 @@
+// Package ratelimit limits how fast clients and tenants can call the gateway
+// with token buckets, and caps the size of what they send.
+package ratelimit
+
+import (
+	"container/list"
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"connectrpc.com/connect"
+
+	"github.com/alphauslabs/jennah/internal/audit"
+	"github.com/alphauslabs/jennah/internal/database"
+)
+
+// RetryAfterHeader tells a rate-limited client how many seconds to wait
+// before retrying.
+const RetryAfterHeader = "Retry-After"
+
+// maxIdleBuckets is how many buckets a MemoryStore holds before it drops the
+// least recently used ones that have refilled, which are the same as absent
+// ones.
+const maxIdleBuckets = 10000
+
+// Limit is a token bucket that refills at Rate tokens per second up to
+// Burst tokens. Each call takes one token. A zero Limit does not limit.
+type Limit struct {
+	Rate  float64
+	Burst int
+}
+
+// Unlimited reports whether the limit lets everything through.
+func (l Limit) Unlimited() bool {
+	return l.Rate <= 0
+}
+
+func (l Limit) String() string {
+	if l.Unlimited() {
+		return "unlimited"
+	}
+	return fmt.Sprintf("%g/s (burst %d)", l.Rate, l.Burst)
+}
+
+// take refills a bucket holding tokens as of updated and takes a token from
+// it. It returns the tokens left and, when the bucket was empty, how long
+// until it holds a token. A zero updated is a new, full bucket.
+func (l Limit) take(tokens float64, updated, now time.Time) (float64, time.Duration) {
+	tokens = l.refill(tokens, updated, now)
+	if tokens >= 1 {
+		return tokens - 1, 0
+	}
+	return tokens, time.Duration((1 - tokens) / l.Rate * float64(time.Second))
+}
+
+// refund refills a bucket holding tokens as of updated and gives back a
+// token taken from it, up to Burst.
+func (l Limit) refund(tokens float64, updated, now time.Time) float64 {
+	return math.Min(float64(l.Burst), l.refill(tokens, updated, now)+1)
+}
+
+// refill returns the tokens a bucket holding tokens as of updated holds at
+// now. A zero updated is a new, full bucket.
+func (l Limit) refill(tokens float64, updated, now time.Time) float64 {
+	burst := float64(l.Burst)
+	if updated.IsZero() {
+		return burst
+	}
+	if elapsed := now.Sub(updated).Seconds(); elapsed > 0 {
+		return math.Min(burst, tokens+elapsed*l.Rate)
+	}
+	return tokens
+}
+
+// ParseLimit parses "<rate>[:<burst>]", e.g. "5" or "0.5:10". The burst
+// defaults to the rate rounded up. "0" disables the limit.
+func ParseLimit(s string) (Limit, error) {
+	rateStr, burstStr, hasBurst := strings.Cut(strings.TrimSpace(s), ":")
+	rate, err := strconv.ParseFloat(rateStr, 64)
+	if err != nil || rate < 0 || math.IsInf(rate, 0) || math.IsNaN(rate) {
+		return Limit{}, fmt.Errorf("invalid rate %q: want a number of calls per second", rateStr)
+	}
+	if rate == 0 {
+		return Limit{}, nil
+	}
+	burst := int(math.Max(1, math.Ceil(rate)))
+	if hasBurst {
+		if burst, err = strconv.Atoi(burstStr); err != nil || burst < 1 {
+			return Limit{}, fmt.Errorf("invalid burst %q: want a positive integer", burstStr)
+		}
+	}
+	return Limit{Rate: rate, Burst: burst}, nil
+}
+
+// ParseRPCLimits parses comma-separated "<method>=<limit>" entries, e.g.
+// "SubmitJob=1:5,CancelJob=2".
+func ParseRPCLimits(s string) (map[string]Limit, error) {
+	limits := make(map[string]Limit)
+	for _, entry := range strings.Split(s, ",") {
+		if entry = strings.TrimSpace(entry); entry == "" {
+			continue
+		}
+		method, value, ok := strings.Cut(entry, "=")
+		method = strings.TrimSpace(method)
+		if !ok || method == "" {
+			return nil, fmt.Errorf("invalid entry %q: want <method>=<rate>[:<burst>]", entry)
+		}
+		limit, err := ParseLimit(value)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", method, err)
+		}
+		limits[method] = limit
+	}
+	return limits, nil
+}
+
+// Store holds token buckets.
+type Store interface {
+	// Take takes a token from the bucket key, returning how long until it
+	// holds one when it is empty.
+	Take(ctx context.Context, key string, limit Limit, now time.Time) (time.Duration, error)
+	// Refund gives back a token taken from the bucket key.
+	Refund(ctx context.Context, key string, limit Limit, now time.Time) error
+}
+
+type bucket struct {
+	key     string
+	tokens  float64
+	updated time.Time
+	limit   Limit
+}
+
+// full reports whether the bucket has refilled completely by now.
+func (b *bucket) full(now time.Time) bool {
+	return b.tokens+now.Sub(b.updated).Seconds()*b.limit.Rate >= float64(b.limit.Burst)
+}
+
+// MemoryStore keeps buckets in memory, so each gateway limits on its own.
+type MemoryStore struct {
+	mu      sync.Mutex
+	buckets map[string]*list.Element
+	// lru orders buckets from most to least recently used.
+	lru *list.List
+}
+
+// NewMemoryStore returns an empty MemoryStore.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{buckets: make(map[string]*list.Element), lru: list.New()}
+}
+
+// Take implements Store.
+func (s *MemoryStore) Take(_ context.Context, key string, limit Limit, now time.Time) (time.Duration, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	e, ok := s.buckets[key]
+	if ok {
+		s.lru.MoveToFront(e)
+	} else {
+		e = s.lru.PushFront(&bucket{key: key})
+		s.buckets[key] = e
+	}
+	b := e.Value.(*bucket)
+	var wait time.Duration
+	b.tokens, wait = limit.take(b.tokens, b.updated, now)
+	b.updated, b.limit = now, limit
+	s.evict(now)
+	return wait, nil
+}
+
+// Refund implements Store.
+func (s *MemoryStore) Refund(_ context.Context, key string, limit Limit, now time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	e, ok := s.buckets[key]
+	if !ok {
+		// An absent bucket is full.
+		return nil
+	}
+	b := e.Value.(*bucket)
+	b.tokens = limit.refund(b.tokens, b.updated, now)
+	b.updated, b.limit = now, limit
+	return nil
+}
+
+// evict drops least recently used buckets over maxIdleBuckets while they
+// have refilled. A bucket still refilling is kept, and the store grows past
+// the cap until it has.
+func (s *MemoryStore) evict(now time.Time) {
+	for s.lru.Len() > maxIdleBuckets {
+		e := s.lru.Back()
+		b := e.Value.(*bucket)
+		if !b.full(now) {
+			return
+		}
+		s.lru.Remove(e)
+		delete(s.buckets, b.key)
+	}
+}
+
+// BucketDB stores buckets shared by all gateways.
+type BucketDB interface {
+	UpdateRateLimitBucket(ctx context.Context, key string, update func(b *database.RateLimitBucket)) error
+}
+
+// SharedStore keeps buckets in the database so that all gateways draw from
+// the same ones. When the database cannot be reached it falls back to
+// limiting in memory rather than failing calls.
+type SharedStore struct {
+	db       BucketDB
+	fallback *MemoryStore
+}
+
+// NewSharedStore returns a SharedStore on db.
+func NewSharedStore(db BucketDB) *SharedStore {
+	return &SharedStore{db: db, fallback: NewMemoryStore()}
+}
+
+// Take implements Store.
+func (s *SharedStore) Take(ctx context.Context, key string, limit Limit, now time.Time) (time.Duration, error) {
+	var wait time.Duration
+	err := s.db.UpdateRateLimitBucket(ctx, key, func(b *database.RateLimitBucket) {
+		b.Tokens, wait = limit.take(b.Tokens, b.UpdatedAt, now)
+		b.UpdatedAt = now
+	})
+	if err != nil {
+		log.Printf("Warning: falling back to in-memory rate limiting: %v", err)
+		return s.fallback.Take(ctx, key, limit, now)
+	}
+	return wait, nil
+}
+
+// Refund implements Store.
+func (s *SharedStore) Refund(ctx context.Context, key string, limit Limit, now time.Time) error {
+	err := s.db.UpdateRateLimitBucket(ctx, key, func(b *database.RateLimitBucket) {
+		b.Tokens = limit.refund(b.Tokens, b.UpdatedAt, now)
+		b.UpdatedAt = now
+	})
+	if err != nil {
+		log.Printf("Warning: falling back to in-memory rate limiting: %v", err)
+		return s.fallback.Refund(ctx, key, limit, now)
+	}
+	return nil
+}
+
+// Limiter applies a limit to each client address before calls are
+// authenticated, then a limit to each tenant and further limits to some of
+// its RPCs.
+type Limiter struct {
+	store  Store
+	tenant Limit
+	rpcs   map[string]Limit
+	now    func() time.Time
+
+	// Client buckets stay in memory even with a SharedStore, so calls with
+	// forged credentials cannot make the gateway write to the database.
+	callers *MemoryStore
+	caller  Limit
+}
+
+// NewLimiter returns a Limiter whose tenant buckets live in store. caller
+// limits each client address; rpcs is keyed by method name, e.g.
+// "SubmitJob".
+func NewLimiter(store Store, tenant, caller Limit, rpcs map[string]Limit) *Limiter {
+	return &Limiter{store: store, tenant: tenant, rpcs: rpcs, now: time.Now, callers: NewMemoryStore(), caller: caller}
+}
+
+// AllowCaller takes a token for a call from a client address, whether or not
+// its credentials turn out to be valid. Calls are keyed by address rather
+// than token, so rotating forged tokens does not reach fresh buckets.
+func (l *Limiter) AllowCaller(ctx context.Context, clientIP string) error {
+	if l.caller.Unlimited() {
+		return nil
+	}
+	wait, err := l.callers.Take(ctx, "caller/"+clientIP, l.caller, l.now())
+	if err != nil {
+		return connect.NewError(connect.CodeInternal, err)
+	}
+	if wait > 0 {
+		return rateLimited(fmt.Sprintf("rate limit of %s exceeded for %s", l.caller, clientIP), wait)
+	}
+	return nil
+}
+
+// Allow takes a token for a call by a tenant, or returns a ResourceExhausted
+// error carrying RetryAfterHeader when one of its buckets is empty. A
+// rejected call gives back the tokens it took from the other buckets.
+func (l *Limiter) Allow(ctx context.Context, tenantID, procedure string) error {
+	method := procedure[strings.LastIndex(procedure, "/")+1:]
+	now := l.now()
+	checks := []bucketCheck{
+		{"tenant/" + tenantID, l.tenant},
+		{"tenant/" + tenantID + "/" + method, l.rpcs[method]},
+	}
+	for i, c := range checks {
+		if c.limit.Unlimited() {
+			continue
+		}
+		wait, err := l.store.Take(ctx, c.key, c.limit, now)
+		if err != nil {
+			l.refund(ctx, checks[:i], now)
+			return connect.NewError(connect.CodeInternal, err)
+		}
+		if wait > 0 {
+			l.refund(ctx, checks[:i], now)
+			return rateLimited(fmt.Sprintf("rate limit of %s exceeded for tenant %s", c.limit, tenantID), wait)
+		}
+	}
+	return nil
+}
+
+// bucketCheck is a bucket a call takes a token from.
+type bucketCheck struct {
+	key   string
+	limit Limit
+}
+
+// refund gives back the tokens a rejected call took from checks.
+func (l *Limiter) refund(ctx context.Context, checks []bucketCheck, now time.Time) {
+	for _, c := range checks {
+		if c.limit.Unlimited() {
+			continue
+		}
+		if err := l.store.Refund(ctx, c.key, c.limit, now); err != nil {
+			log.Printf("Warning: failed to refund rate limit token from %s: %v", c.key, err)
+		}
+	}
+}
+
+// rateLimited returns a ResourceExhausted error telling the client to retry
+// after wait.
+func rateLimited(msg string, wait time.Duration) error {
+	seconds := int(math.Ceil(wait.Seconds()))
+	cerr := connect.NewError(connect.CodeResourceExhausted, fmt.Errorf("%s; retry in %ds", msg, seconds))
+	cerr.Meta().Set(RetryAfterHeader, strconv.Itoa(seconds))
+	return cerr
+}
+
+type callKey struct{}
+
+// call is an intercepted call waiting to be charged to a tenant.
+type call struct {
+	limiter   *Limiter
+	procedure string
+	charged   bool
+}
+
+// Charge takes a token for the current call from tenantID's buckets once
+// the handler knows the tenant. Later charges of the same call, and charges
+// outside a limited call, do nothing.
+func Charge(ctx context.Context, tenantID string) error {
+	c, _ := ctx.Value(callKey{}).(*call)
+	if c == nil || c.charged {
+		return nil
+	}
+	c.charged = true
+	return c.limiter.Allow(ctx, tenantID, c.procedure)
+}
+
+// NewInterceptor takes a token from the bucket of the client's address
+// (see audit.ClientIP for trustedProxies), rejects requests over limits
+// before they reach a handler, and lets the handler Charge the call to a
+// tenant with limiter. A nil limiter only checks requests.
+func NewInterceptor(limiter *Limiter, limits RequestLimits, trustedProxies int) connect.UnaryInterceptorFunc {
+	return func(next connect.UnaryFunc) connect.UnaryFunc {
+		return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+			if req.Spec().IsClient {
+				return next(ctx, req)
+			}
+			if limiter != nil {
+				if err := limiter.AllowCaller(ctx, audit.ClientIP(req.Header(), req.Peer().Addr, trustedProxies)); err != nil {
+					return nil, err
+				}
+			}
+			if err := limits.Check(req.Any()); err != nil {
+				return nil, connect.NewError(connect.CodeInvalidArgument, err)
+			}
+			if limiter != nil {
+				ctx = context.WithValue(ctx, callKey{}, &call{limiter: limiter, procedure: req.Spec().Procedure})
+			}
+			return next(ctx, req)
+		}
+	}
+}
@@ -0,0 +1,249 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+
+	jennahv1 "github.com/alphauslabs/jennah/gen/proto"
+	"github.com/alphauslabs/jennah/gen/proto/jennahv1connect"
+	"github.com/alphauslabs/jennah/internal/database"
+)
+
+func TestParseLimit(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    Limit
+		wantErr bool
+	}{
+		{in: "5", want: Limit{Rate: 5, Burst: 5}},
+		{in: "0.5:10", want: Limit{Rate: 0.5, Burst: 10}},
+		{in: "0.2", want: Limit{Rate: 0.2, Burst: 1}},
+		{in: "0", want: Limit{}},
+		{in: "-1", wantErr: true},
+		{in: "fast", wantErr: true},
+		{in: "5:0", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := ParseLimit(tt.in)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParseLimit(%q) = %v, %v; want %v (error: %v)", tt.in, got, err, tt.want, tt.wantErr)
+		}
+	}
+
+	limits, err := ParseRPCLimits(" SubmitJob=1:5, CancelJob=2 ,")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(limits) != 2 || limits["SubmitJob"] != (Limit{Rate: 1, Burst: 5}) || limits["CancelJob"] != (Limit{Rate: 2, Burst: 2}) {
+		t.Errorf("ParseRPCLimits = %v", limits)
+	}
+	if _, err := ParseRPCLimits("SubmitJob"); err == nil {
+		t.Error("ParseRPCLimits accepted an entry without a limit")
+	}
+}
+
+func TestLimiter(t *testing.T) {
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	l := NewLimiter(NewMemoryStore(), Limit{Rate: 1, Burst: 3}, Limit{}, map[string]Limit{"SubmitJob": {Rate: 0.5, Burst: 1}})
+	l.now = func() time.Time { return now }
+	ctx := context.Background()
+	const list = "/jennah.v1.DeploymentService/ListJobs"
+	const submit = "/jennah.v1.DeploymentService/SubmitJob"
+
+	// The RPC limit applies on top of the tenant's.
+	if err := l.Allow(ctx, "tenant-a", submit); err != nil {
+		t.Fatalf("first submit: %v", err)
+	}
+	err := l.Allow(ctx, "tenant-a", submit)
+	if connect.CodeOf(err) != connect.CodeResourceExhausted {
+		t.Fatalf("second submit: got %v, want resource exhausted", err)
+	}
+	var cerr *connect.Error
+	if !errors.As(err, &cerr) || cerr.Meta().Get(RetryAfterHeader) != "2" {
+		t.Errorf("Retry-After = %q, want 2", cerr.Meta().Get(RetryAfterHeader))
+	}
+
+	// Only the allowed submit took from the tenant's burst of 3; the
+	// rejected one gave its token back.
+	for i := 0; i < 2; i++ {
+		if err := l.Allow(ctx, "tenant-a", list); err != nil {
+			t.Fatalf("list %d: %v", i+1, err)
+		}
+	}
+	if err := l.Allow(ctx, "tenant-a", list); connect.CodeOf(err) != connect.CodeResourceExhausted {
+		t.Fatalf("list over burst: got %v, want resource exhausted", err)
+	}
+	if err := l.Allow(ctx, "tenant-b", list); err != nil {
+		t.Errorf("other tenant: %v", err)
+	}
+
+	now = now.Add(time.Second)
+	if err := l.Allow(ctx, "tenant-a", list); err != nil {
+		t.Errorf("after refill: %v", err)
+	}
+}
+
+func TestMemoryStoreEviction(t *testing.T) {
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	ctx := context.Background()
+	limit := Limit{Rate: 1, Burst: 1}
+	s := NewMemoryStore()
+
+	s.Take(ctx, "hot", limit, now)
+	for i := 0; i < maxIdleBuckets; i++ {
+		s.Take(ctx, fmt.Sprintf("key-%d", i), limit, now)
+	}
+	// Every bucket is still refilling, so none is dropped.
+	if got := len(s.buckets); got != maxIdleBuckets+1 {
+		t.Fatalf("holds %d buckets, want %d", got, maxIdleBuckets+1)
+	}
+	if wait, _ := s.Take(ctx, "hot", limit, now); wait == 0 {
+		t.Error("the empty bucket was reset")
+	}
+
+	now = now.Add(2 * time.Second)
+	s.Take(ctx, "late", limit, now)
+	if got := len(s.buckets); got != maxIdleBuckets || s.lru.Len() != got {
+		t.Fatalf("holds %d buckets (%d in LRU order), want %d", got, s.lru.Len(), maxIdleBuckets)
+	}
+	if _, ok := s.buckets["key-0"]; ok {
+		t.Error("the least recently used bucket was kept")
+	}
+	if _, ok := s.buckets["hot"]; !ok {
+		t.Error("a recently used bucket was dropped")
+	}
+}
+
+type fakeBucketDB struct {
+	buckets map[string]database.RateLimitBucket
+	err     error
+}
+
+func (db *fakeBucketDB) UpdateRateLimitBucket(_ context.Context, key string, update func(b *database.RateLimitBucket)) error {
+	if db.err != nil {
+		return db.err
+	}
+	b := db.buckets[key]
+	b.BucketKey = key
+	update(&b)
+	db.buckets[key] = b
+	return nil
+}
+
+func TestSharedStore(t *testing.T) {
+	db := &fakeBucketDB{buckets: make(map[string]database.RateLimitBucket)}
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	limit := Limit{Rate: 1, Burst: 1}
+
+	// Two gateways draw from the same bucket.
+	a, b := NewSharedStore(db), NewSharedStore(db)
+	if wait, _ := a.Take(context.Background(), "tenant/x", limit, now); wait != 0 {
+		t.Fatalf("first take waits %s", wait)
+	}
+	if wait, _ := b.Take(context.Background(), "tenant/x", limit, now); wait != time.Second {
+		t.Errorf("second gateway waits %s, want 1s", wait)
+	}
+
+	// A refunded token can be taken again.
+	if err := b.Refund(context.Background(), "tenant/x", limit, now); err != nil {
+		t.Fatalf("refund: %v", err)
+	}
+	if wait, _ := a.Take(context.Background(), "tenant/x", limit, now); wait != 0 {
+		t.Errorf("take after refund waits %s", wait)
+	}
+
+	// Without the database each gateway limits on its own.
+	db.err = errors.New("spanner unavailable")
+	if wait, err := a.Take(context.Background(), "tenant/x", limit, now); wait != 0 || err != nil {
+		t.Errorf("fallback take = %s, %v", wait, err)
+	}
+}
+
+type fakeDeployments struct {
+	jennahv1connect.UnimplementedDeploymentServiceHandler
+}
+
+func (fakeDeployments) SubmitJob(ctx context.Context, req *connect.Request[jennahv1.SubmitJobRequest]) (*connect.Response[jennahv1.SubmitJobResponse], error) {
+	if err := Charge(ctx, "tenant-a"); err != nil {
+		return nil, err
+	}
+	// A second charge of the same call is free.
+	if err := Charge(ctx, "tenant-a"); err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(&jennahv1.SubmitJobResponse{JobId: "job-1"}), nil
+}
+
+func TestInterceptor(t *testing.T) {
+	limiter := NewLimiter(NewMemoryStore(), Limit{Rate: 0.1, Burst: 1}, Limit{}, nil)
+	path, handler := jennahv1connect.NewDeploymentServiceHandler(fakeDeployments{},
+		connect.WithReadMaxBytes(1024),
+		connect.WithInterceptors(NewInterceptor(limiter, RequestLimits{MaxEnvVars: 2, MaxCommands: 2}, 1)))
+	mux := http.NewServeMux()
+	mux.Handle(path, handler)
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	client := jennahv1connect.NewDeploymentServiceClient(srv.Client(), srv.URL)
+	submit := func(req *jennahv1.SubmitJobRequest) error {
+		_, err := client.SubmitJob(context.Background(), connect.NewRequest(req))
+		return err
+	}
+
+	if err := submit(&jennahv1.SubmitJobRequest{Commands: []string{"a", "b", "c"}}); connect.CodeOf(err) != connect.CodeInvalidArgument {
+		t.Errorf("too many commands: got %v, want invalid argument", err)
+	}
+	if err := submit(&jennahv1.SubmitJobRequest{ImageUri: string(make([]byte, 2048))}); connect.CodeOf(err) != connect.CodeResourceExhausted {
+		t.Errorf("oversized request: got %v, want resource exhausted", err)
+	}
+	if err := submit(&jennahv1.SubmitJobRequest{ImageUri: "app"}); err != nil {
+		t.Fatalf("first submit: %v", err)
+	}
+	err := submit(&jennahv1.SubmitJobRequest{ImageUri: "app"})
+	var cerr *connect.Error
+	if !errors.As(err, &cerr) || cerr.Code() != connect.CodeResourceExhausted || cerr.Meta().Get(RetryAfterHeader) != "10" {
+		t.Errorf("second submit: got %v, want resource exhausted with Retry-After 10", err)
+	}
+}
+
+func TestInterceptorLimitsCallersBeforeAuthentication(t *testing.T) {
+	// The handler never charges a tenant, as with a forged session.
+	limiter := NewLimiter(NewMemoryStore(), Limit{}, Limit{Rate: 0.1, Burst: 2}, nil)
+	path, handler := jennahv1connect.NewDeploymentServiceHandler(jennahv1connect.UnimplementedDeploymentServiceHandler{},
+		connect.WithInterceptors(NewInterceptor(limiter, RequestLimits{}, 1)))
+	mux := http.NewServeMux()
+	mux.Handle(path, handler)
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	client := jennahv1connect.NewDeploymentServiceClient(srv.Client(), srv.URL)
+	cancel := func(token, forwarded string) error {
+		req := connect.NewRequest(&jennahv1.CancelJobRequest{JobId: "job-1"})
+		req.Header().Set("Authorization", "Bearer "+token)
+		req.Header().Set("X-Forwarded-For", forwarded)
+		_, err := client.CancelJob(context.Background(), req)
+		return err
+	}
+
+	// A fresh forged token per call does not reach a fresh bucket.
+	for i, token := range []string{"forged-1", "forged-2"} {
+		if err := cancel(token, "203.0.113.7"); connect.CodeOf(err) != connect.CodeUnimplemented {
+			t.Fatalf("call %d: got %v, want it to reach the handler", i, err)
+		}
+	}
+	if err := cancel("forged-3", "203.0.113.7"); connect.CodeOf(err) != connect.CodeResourceExhausted {
+		t.Errorf("third call: got %v, want resource exhausted", err)
+	}
+	// Nor does a forged leading X-Forwarded-For entry.
+	if err := cancel("forged-4", "198.51.100.1, 203.0.113.7"); connect.CodeOf(err) != connect.CodeResourceExhausted {
+		t.Errorf("forged forwarded address: got %v, want resource exhausted", err)
+	}
+	if err := cancel("forged-5", "198.51.100.1"); connect.CodeOf(err) != connect.CodeUnimplemented {
+		t.Errorf("other client: got %v, want it to reach the handler", err)
+	}
+}
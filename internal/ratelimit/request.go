@@ -0,0 +1,57 @@
+package ratelimit
+
+import (
+	"fmt"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// RequestLimits caps what one request may carry. The total size of a
+// request is capped separately, with connect.WithReadMaxBytes. Zero fields
+// do not limit.
+type RequestLimits struct {
+	// MaxEnvVars caps each env_vars map, e.g. a job's or one of its
+	// runnables'.
+	MaxEnvVars int
+	// MaxCommands caps each commands list.
+	MaxCommands int
+}
+
+// Check returns an error describing the first limit msg exceeds, or nil.
+// Messages that are not protobuf messages are not checked.
+func (l RequestLimits) Check(msg any) error {
+	m, ok := msg.(proto.Message)
+	if !ok || m == nil {
+		return nil
+	}
+	return l.check(m.ProtoReflect(), "")
+}
+
+func (l RequestLimits) check(m protoreflect.Message, path string) error {
+	var err error
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		name := path + string(fd.Name())
+		switch {
+		case fd.IsMap():
+			if fd.Name() == "env_vars" && l.MaxEnvVars > 0 && v.Map().Len() > l.MaxEnvVars {
+				err = fmt.Errorf("%s has %d entries, at most %d are allowed", name, v.Map().Len(), l.MaxEnvVars)
+			}
+		case fd.IsList():
+			list := v.List()
+			if fd.Name() == "commands" && l.MaxCommands > 0 && list.Len() > l.MaxCommands {
+				err = fmt.Errorf("%s has %d entries, at most %d are allowed", name, list.Len(), l.MaxCommands)
+				break
+			}
+			if fd.Message() != nil {
+				for i := 0; i < list.Len() && err == nil; i++ {
+					err = l.check(list.Get(i).Message(), fmt.Sprintf("%s[%d].", name, i))
+				}
+			}
+		case fd.Message() != nil:
+			err = l.check(v.Message(), name+".")
+		}
+		return err == nil
+	})
+	return err
+}
@@ -0,0 +1,47 @@
+package ratelimit
+
+import (
+	"strings"
+	"testing"
+
+	jennahv1 "github.com/alphauslabs/jennah/gen/proto"
+)
+
+func TestRequestLimits(t *testing.T) {
+	limits := RequestLimits{MaxEnvVars: 2, MaxCommands: 1}
+	tests := []struct {
+		name    string
+		req     *jennahv1.SubmitJobRequest
+		wantErr string
+	}{
+		{"within limits", &jennahv1.SubmitJobRequest{
+			EnvVars:  map[string]string{"A": "1", "B": "2"},
+			Commands: []string{"run"},
+		}, ""},
+		{"job env vars", &jennahv1.SubmitJobRequest{
+			EnvVars: map[string]string{"A": "1", "B": "2", "C": "3"},
+		}, "env_vars has 3 entries"},
+		{"job commands", &jennahv1.SubmitJobRequest{
+			Commands: []string{"a", "b"},
+		}, "commands has 2 entries"},
+		{"runnable env vars", &jennahv1.SubmitJobRequest{
+			Runnables: []*jennahv1.Runnable{{}, {EnvVars: map[string]string{"A": "1", "B": "2", "C": "3"}}},
+		}, "runnables[1].env_vars"},
+	}
+	for _, tt := range tests {
+		err := limits.Check(tt.req)
+		if tt.wantErr == "" {
+			if err != nil {
+				t.Errorf("%s: %v", tt.name, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("%s: got %v, want %q", tt.name, err, tt.wantErr)
+		}
+	}
+
+	if err := (RequestLimits{}).Check(&jennahv1.SubmitJobRequest{Commands: make([]string, 1000)}); err != nil {
+		t.Errorf("zero limits: %v", err)
+	}
+}
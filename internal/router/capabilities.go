@@ -0,0 +1,180 @@
+package router
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	jennahv1 "github.com/alphauslabs/jennah/gen/proto"
+	batch "github.com/alphauslabs/jennah/internal/cloudexec"
+)
+
+// Workload is the shape of a job as seen by capability-based routing.
+// Zero-valued resource fields mean "not specified" and never exclude a provider.
+type Workload struct {
+	CPUMillis             int64
+	MemoryMiB             int64
+	MaxRunDurationSeconds int64
+	TaskCount             int64
+	MachineType           string
+	UseSpot               bool
+	GPU                   bool
+	Volumes               []batch.VolumeConfig
+	Runnables             int
+}
+
+// ProviderCandidate pairs a service tier with the capabilities of the provider
+// registered for it. Candidates come from the providers registered in the
+// worker's Dispatcher; the router has no built-in list.
+type ProviderCandidate struct {
+	Service      AssignedService
+	Capabilities batch.Capabilities
+}
+
+// capabilitiesFor returns the capabilities of the candidate for svc.
+func capabilitiesFor(candidates []ProviderCandidate, svc AssignedService) (batch.Capabilities, bool) {
+	for _, c := range candidates {
+		if c.Service == svc {
+			return c.Capabilities, true
+		}
+	}
+	return batch.Capabilities{}, false
+}
+
+// WorkloadFromJobConfig extracts the routing-relevant fields of a resolved JobConfig.
+func WorkloadFromJobConfig(cfg batch.JobConfig) Workload {
+	w := Workload{
+		TaskCount:   1,
+		MachineType: cfg.MachineType,
+		UseSpot:     cfg.UseSpotVMs,
+		GPU:         cfg.Accelerators != nil && cfg.Accelerators.Type != "",
+		Volumes:     cfg.Volumes,
+		Runnables:   len(cfg.Runnables),
+	}
+	if cfg.Resources != nil {
+		w.CPUMillis = cfg.Resources.CPUMillis
+		w.MemoryMiB = cfg.Resources.MemoryMiB
+		w.MaxRunDurationSeconds = cfg.Resources.MaxRunDurationSeconds
+	}
+	if cfg.TaskGroup != nil && cfg.TaskGroup.TaskCount > 0 {
+		w.TaskCount = cfg.TaskGroup.TaskCount
+	}
+	return w
+}
+
+// SelectProvider returns a decision for the cheapest candidate whose
+// capabilities fit w. Candidates are compared by CostRank; ties keep the
+// order in which they were supplied.
+//
+// The reason names the limit that excluded each cheaper provider. When no
+// candidate fits, AssignedService is AssignedServiceUnspecified and the reason
+// lists why every candidate was rejected.
+func SelectProvider(w Workload, candidates []ProviderCandidate) RoutingDecision {
+	ordered := make([]ProviderCandidate, len(candidates))
+	copy(ordered, candidates)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return ordered[i].Capabilities.CostRank < ordered[j].Capabilities.CostRank
+	})
+
+	var excluded []string
+	for _, c := range ordered {
+		if why := exclusionReason(w, c.Capabilities); why != "" {
+			excluded = append(excluded, fmt.Sprintf("%s excluded: %s", c.Service, why))
+			continue
+		}
+
+		reason := fmt.Sprintf("resources within %s limits (cheapest fitting provider)", c.Service)
+		if len(excluded) > 0 {
+			reason = strings.Join(excluded, "; ") + "; selected " + c.Service.String()
+		}
+		return RoutingDecision{
+			Complexity:      ComplexityForService(c.Service),
+			AssignedService: c.Service,
+			Reason:          reason,
+		}
+	}
+
+	if len(excluded) == 0 {
+		return RoutingDecision{Reason: "no providers registered"}
+	}
+	return RoutingDecision{
+		Reason: "no registered provider fits the job: " + strings.Join(excluded, "; "),
+	}
+}
+
+// ComplexityForService maps an execution service back to its complexity tier.
+// Cloud Run Jobs is the only SIMPLE service; anything heavier is COMPLEX.
+func ComplexityForService(svc AssignedService) ComplexityLevel {
+	switch svc {
+	case AssignedServiceUnspecified:
+		return ComplexityUnspecified
+	case AssignedServiceCloudRunJob:
+		return ComplexitySimple
+	default:
+		return ComplexityComplex
+	}
+}
+
+// exclusionReason returns why caps cannot run w, or "" when it fits.
+func exclusionReason(w Workload, caps batch.Capabilities) string {
+	switch {
+	case w.MachineType != "" && !caps.SupportsMachineType:
+		return "explicit machine_type not supported: " + w.MachineType
+	case w.GPU && !caps.SupportsGPU:
+		return "accelerators not supported"
+	case w.UseSpot && !caps.SupportsSpot:
+		return "spot provisioning not supported"
+	case w.Runnables > 0 && !caps.SupportsRunnables:
+		return fmt.Sprintf("runnables not supported (%d task steps requested; only a single container can run)", w.Runnables)
+	case exceedsLimit(w.CPUMillis, caps.MaxCPUMillis):
+		return fmt.Sprintf("cpu_millis %d exceeds max %d", w.CPUMillis, caps.MaxCPUMillis)
+	case exceedsLimit(w.MemoryMiB, caps.MaxMemoryMiB):
+		return fmt.Sprintf("memory_mib %d exceeds max %d", w.MemoryMiB, caps.MaxMemoryMiB)
+	case exceedsLimit(w.MaxRunDurationSeconds, caps.MaxRunDurationSeconds):
+		return fmt.Sprintf("max_run_duration_seconds %d exceeds max %d", w.MaxRunDurationSeconds, caps.MaxRunDurationSeconds)
+	case exceedsLimit(w.TaskCount, caps.MaxTaskCount):
+		return fmt.Sprintf("task_count %d exceeds max %d", w.TaskCount, caps.MaxTaskCount)
+	}
+	return unsupportedVolume(w.Volumes, caps)
+}
+
+// unsupportedVolume names the first volume caps cannot mount, or returns "".
+func unsupportedVolume(volumes []batch.VolumeConfig, caps batch.Capabilities) string {
+	for i, v := range volumes {
+		if !caps.SupportsVolume(v.Type) {
+			return fmt.Sprintf("volume[%d] (%s) not supported", i, v)
+		}
+	}
+	return ""
+}
+
+// VolumeFromProto maps a requested volume onto a VolumeConfig. The type is
+// taken from whichever source is set; callers validate the result.
+func VolumeFromProto(v *jennahv1.Volume) batch.VolumeConfig {
+	vc := batch.VolumeConfig{
+		MountPath: v.GetMountPath(),
+		ReadOnly:  v.GetReadOnly(),
+	}
+	switch {
+	case v.GetGcs() != nil:
+		vc.Type = batch.VolumeTypeGCS
+		vc.Bucket = v.GetGcs().GetBucket()
+	case v.GetNfs() != nil:
+		vc.Type = batch.VolumeTypeNFS
+		vc.NFSServer = v.GetNfs().GetServer()
+		vc.NFSPath = v.GetNfs().GetPath()
+	case v.GetLocalSsd() != nil:
+		vc.Type = batch.VolumeTypeLocalSSD
+		vc.SizeGb = v.GetLocalSsd().GetSizeGb()
+		if vc.SizeGb == 0 {
+			vc.SizeGb = batch.LocalSSDPartitionGb
+		}
+	}
+	return vc
+}
+
+// exceedsLimit reports whether value breaks limit. A zero limit is unlimited
+// and a zero value is unspecified; neither excludes a provider.
+func exceedsLimit(value, limit int64) bool {
+	return limit > 0 && exceedsThreshold(value, limit)
+}
@@ -0,0 +1,124 @@
+package router
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	batch "github.com/alphauslabs/jennah/internal/cloudexec"
+	"github.com/alphauslabs/jennah/internal/cloudexec/gcp"
+)
+
+// gcpCandidates are the GCP providers as the worker registers them.
+func gcpCandidates() []ProviderCandidate {
+	return []ProviderCandidate{
+		{Service: AssignedServiceCloudRunJob, Capabilities: gcp.CloudRunJobCapabilities},
+		{Service: AssignedServiceCloudBatch, Capabilities: gcp.CloudBatchCapabilities},
+	}
+}
+
+// Cloud Run Jobs limits, which bound the SIMPLE tier.
+var (
+	MediumCPUMillisMax   = gcp.CloudRunJobCapabilities.MaxCPUMillis
+	MediumMemoryMiBMax   = gcp.CloudRunJobCapabilities.MaxMemoryMiB
+	MediumDurationSecMax = gcp.CloudRunJobCapabilities.MaxRunDurationSeconds
+)
+
+// ---------------------------------------------------------------------------
+// SelectProvider
+// ---------------------------------------------------------------------------
+
+func TestSelectProvider_PrefersCheapestFit(t *testing.T) {
+	w := Workload{CPUMillis: 1000, MemoryMiB: 1024, MaxRunDurationSeconds: 600, TaskCount: 1}
+	got := SelectProvider(w, gcpCandidates())
+	assertTier(t, "small workload", got, ComplexitySimple, AssignedServiceCloudRunJob)
+}
+
+func TestSelectProvider_CandidateOrderDoesNotMatter(t *testing.T) {
+	candidates := gcpCandidates()
+	reversed := []ProviderCandidate{candidates[1], candidates[0]}
+	got := SelectProvider(Workload{TaskCount: 1}, reversed)
+	assertTier(t, "reversed candidates", got, ComplexitySimple, AssignedServiceCloudRunJob)
+}
+
+func TestSelectProvider_ReasonNamesExcludingLimit(t *testing.T) {
+	cases := []struct {
+		name string
+		w    Workload
+		want string
+	}{
+		{"memory", Workload{MemoryMiB: MediumMemoryMiBMax + 1}, "memory_mib"},
+		{"cpu", Workload{CPUMillis: MediumCPUMillisMax + 1}, "cpu_millis"},
+		{"duration", Workload{MaxRunDurationSeconds: MediumDurationSecMax + 1}, "max_run_duration_seconds"},
+		{"machine type", Workload{MachineType: "n2-standard-8"}, "machine_type"},
+		{"spot", Workload{UseSpot: true}, "spot"},
+		{"gpu", Workload{GPU: true}, "accelerators"},
+		{"runnables", Workload{Runnables: 3}, "runnables"},
+	}
+	for _, tc := range cases {
+		got := SelectProvider(tc.w, gcpCandidates())
+		assertTier(t, tc.name, got, ComplexityComplex, AssignedServiceCloudBatch)
+		if !strings.Contains(got.Reason, "CLOUD_RUN_JOB excluded") || !strings.Contains(got.Reason, tc.want) {
+			t.Errorf("[%s] reason %q should name CLOUD_RUN_JOB and %q", tc.name, got.Reason, tc.want)
+		}
+	}
+}
+
+func TestSelectProvider_FollowsReportedLimits(t *testing.T) {
+	// Raising the Cloud Run memory limit keeps a 16 GiB job on Cloud Run.
+	raised := gcpCandidates()
+	raised[0].Capabilities.MaxMemoryMiB = 32768
+	got := SelectProvider(Workload{MemoryMiB: 16384}, raised)
+	assertTier(t, "raised Cloud Run limit", got, ComplexitySimple, AssignedServiceCloudRunJob)
+}
+
+func TestSelectProvider_OnlyRegisteredProviders(t *testing.T) {
+	// With only Cloud Batch registered, even tiny jobs go to Cloud Batch.
+	onlyBatch := []ProviderCandidate{gcpCandidates()[1]}
+	got := SelectProvider(Workload{CPUMillis: 500}, onlyBatch)
+	assertTier(t, "batch only", got, ComplexityComplex, AssignedServiceCloudBatch)
+}
+
+func TestSelectProvider_NoFit(t *testing.T) {
+	onlyRun := []ProviderCandidate{{
+		Service:      AssignedServiceCloudRunJob,
+		Capabilities: batch.Capabilities{MaxMemoryMiB: 8192},
+	}}
+	got := SelectProvider(Workload{MemoryMiB: 65536}, onlyRun)
+	if got.AssignedService != AssignedServiceUnspecified {
+		t.Fatalf("service: got %s, want UNSPECIFIED", got.AssignedService)
+	}
+	if !strings.Contains(got.Reason, "memory_mib 65536 exceeds max 8192") {
+		t.Errorf("reason %q should explain the rejected limit", got.Reason)
+	}
+}
+
+func TestWorkloadFromJobConfig(t *testing.T) {
+	w := WorkloadFromJobConfig(batch.JobConfig{
+		MachineType:  "a2-highgpu-1g",
+		UseSpotVMs:   true,
+		Resources:    &batch.ResourceRequirements{CPUMillis: 2000, MemoryMiB: 4096, MaxRunDurationSeconds: 60},
+		TaskGroup:    &batch.TaskGroupConfig{TaskCount: 8},
+		Accelerators: &batch.AcceleratorConfig{Type: "nvidia-tesla-t4", Count: 1},
+	})
+	want := Workload{
+		CPUMillis: 2000, MemoryMiB: 4096, MaxRunDurationSeconds: 60,
+		TaskCount: 8, MachineType: "a2-highgpu-1g", UseSpot: true, GPU: true,
+	}
+	if !reflect.DeepEqual(w, want) {
+		t.Errorf("got %+v, want %+v", w, want)
+	}
+}
+
+func TestSelectProvider_VolumeSupport(t *testing.T) {
+	gcs := batch.VolumeConfig{Type: batch.VolumeTypeGCS, Bucket: "shared-data", MountPath: "/mnt/data"}
+	got := SelectProvider(Workload{Volumes: []batch.VolumeConfig{gcs}}, gcpCandidates())
+	assertTier(t, "gcs", got, ComplexitySimple, AssignedServiceCloudRunJob)
+
+	ssd := batch.VolumeConfig{Type: batch.VolumeTypeLocalSSD, SizeGb: 375, MountPath: "/scratch"}
+	got = SelectProvider(Workload{Volumes: []batch.VolumeConfig{gcs, ssd}}, gcpCandidates())
+	assertTier(t, "local ssd", got, ComplexityComplex, AssignedServiceCloudBatch)
+	if !strings.Contains(got.Reason, "volume[1] (local SSD (375 GB) at /scratch) not supported") {
+		t.Errorf("reason %q should name the unsupported volume", got.Reason)
+	}
+}
@@ -4,8 +4,14 @@
 // appropriate ComplexityLevel together with the GCP service that should execute
 // the workload:
 //
-//   - SIMPLE  → Cloud Run Jobs (no machine type, or resources within Cloud Run limits)
-//   - COMPLEX → Cloud Batch   (specific machine type, heavy resources, or long duration)
+//   - SIMPLE  → Cloud Run Jobs (the job fits within the Cloud Run provider's Capabilities)
+//   - COMPLEX → Cloud Batch   (specific machine type, spot, heavy resources, or long duration)
+//
+// Tier limits are not hard-coded here: each batch.Provider reports its
+// Capabilities and SelectProvider picks the cheapest one that fits.
+//
+// Routing is a PolicyChain evaluated once, by the worker, against the resolved
+// job config; the decision it returns is the one that is dispatched.
 package router
 
 import (
@@ -22,7 +28,7 @@ type ComplexityLevel int
 
 const (
 	ComplexityUnspecified ComplexityLevel = iota
-	// ComplexitySimple: no machine-type constraint; all resources within Cloud Run Jobs limits (≤4000 mCPU, ≤8192 MiB, ≤1 hr).
+	// ComplexitySimple: no machine-type constraint; all resources within Cloud Run Jobs capabilities.
 	ComplexitySimple
 	// ComplexityComplex: explicit machine type, heavy CPU/memory, or very long duration.
 	ComplexityComplex
@@ -64,48 +70,37 @@ func (a AssignedService) String() string {
 	}
 }
 
-// RoutingDecision is the output of EvaluateJobComplexity.
+// RoutingDecision is the output of a routing PolicyChain.
 type RoutingDecision struct {
 	Complexity      ComplexityLevel
 	AssignedService AssignedService
 	// Reason is a short human-readable explanation of why this tier was chosen.
 	Reason string
+	// Policy names the RoutingPolicy that made the decision.
+	Policy string
 }
 
-// Thresholds that define tier boundaries.
+// EvaluateJobComplexity inspects req and returns the routing decision against
+// candidates, the providers registered in a Dispatcher.
 //
-// These constants are exported so that callers (e.g. tests, dashboards) can
-// reference and override them without magic numbers.
-const (
-	// MediumCPUMillisMax is the maximum CPU (in milli-cores) for a SIMPLE job (above this → COMPLEX).
-	MediumCPUMillisMax int64 = 4000
-	// MediumMemoryMiBMax is the maximum memory (in MiB) for a SIMPLE job (above this → COMPLEX).
-	MediumMemoryMiBMax int64 = 8192
-	// MediumDurationSecMax is the maximum duration (in seconds) for a SIMPLE job (above this → COMPLEX).
-	MediumDurationSecMax int64 = 3600
-)
-
-// EvaluateJobComplexity inspects req and returns the routing decision.
-//
-// Decision logic (strictest check first):
+// Decision logic:
 //  1. If distributed workload processing is enabled → COMPLEX / Cloud Batch.
-//  2. If machine_type is set → COMPLEX / Cloud Batch.
-//  3. If cpu_millis > MediumCPUMillisMax, memory_mib > MediumMemoryMiBMax,
-//     or max_run_duration_seconds > MediumDurationSecMax → COMPLEX / Cloud Batch.
-//  4. Otherwise → SIMPLE / Cloud Run Jobs.
+//  2. Otherwise the cheapest provider whose Capabilities fit the requested
+//     machine type and resource overrides is selected (see SelectProvider).
 //
 // Zero-value resource fields are treated as "not specified" and do not push
 // the job into a higher tier on their own.
-func EvaluateJobComplexity(req *jennahv1.SubmitJobRequest) RoutingDecision {
-	// Extract resource values; fall back to zero when no override is provided.
-	var cpuMillis, memoryMiB, durationSec int64
-	if ro := req.GetResourceOverride(); ro != nil {
-		cpuMillis = ro.GetCpuMillis()
-		memoryMiB = ro.GetMemoryMib()
-		durationSec = ro.GetMaxRunDurationSeconds()
-	}
-	machineType := req.GetMachineType()
+func EvaluateJobComplexity(req *jennahv1.SubmitJobRequest, candidates []ProviderCandidate) RoutingDecision {
+	return DefaultPolicies().Evaluate(context.Background(), RoutingInput{
+		Request:    req,
+		Workload:   WorkloadFromRequest(req),
+		Candidates: candidates,
+	})
+}
 
+// RequiredRouting returns the decision for requests whose target service is
+// fixed regardless of provider capabilities, and false otherwise.
+func RequiredRouting(req *jennahv1.SubmitJobRequest) (RoutingDecision, bool) {
 	// --- Rule 0: Distributed Workload Processing → always COMPLEX ---
 	// DWP jobs require Cloud Batch for multi-instance task groups.
 	if dwpEnabled, reason := isDistributedModeEnabled(req.GetEnvVars()); dwpEnabled {
@@ -113,102 +108,53 @@ func EvaluateJobComplexity(req *jennahv1.SubmitJobRequest) RoutingDecision {
 			Complexity:      ComplexityComplex,
 			AssignedService: AssignedServiceCloudBatch,
 			Reason:          reason,
-		}
+		}, true
 	}
 
-	// --- Rule 1: explicit machine type → always COMPLEX ---
-	if machineType != "" {
-		return RoutingDecision{
-			Complexity:      ComplexityComplex,
-			AssignedService: AssignedServiceCloudBatch,
-			Reason:          "explicit machine_type requested: " + machineType,
-		}
-	}
-
-	// --- Rule 2: heavy resources → COMPLEX ---
-	if exceedsThreshold(cpuMillis, MediumCPUMillisMax) {
-		return RoutingDecision{
-			Complexity:      ComplexityComplex,
-			AssignedService: AssignedServiceCloudBatch,
-			Reason:          "cpu_millis exceeds medium threshold",
-		}
-	}
-	if exceedsThreshold(memoryMiB, MediumMemoryMiBMax) {
-		return RoutingDecision{
-			Complexity:      ComplexityComplex,
-			AssignedService: AssignedServiceCloudBatch,
-			Reason:          "memory_mib exceeds medium threshold",
-		}
-	}
-	if exceedsThreshold(durationSec, MediumDurationSecMax) {
+	// --- Rule 1: GPU accelerators → always COMPLEX ---
+	// Only Cloud Batch can attach accelerators to task VMs.
+	if accel := req.GetAccelerator(); accel.GetType() != "" {
 		return RoutingDecision{
 			Complexity:      ComplexityComplex,
 			AssignedService: AssignedServiceCloudBatch,
-			Reason:          "max_run_duration_seconds exceeds medium threshold",
-		}
-	}
-
-	// --- Rule 3: everything else → SIMPLE (Cloud Run Jobs) ---
-	return RoutingDecision{
-		Complexity:      ComplexitySimple,
-		AssignedService: AssignedServiceCloudRunJob,
-		Reason:          "no machine type, resources within Cloud Run Jobs limits",
+			Reason:          fmt.Sprintf("accelerator %s requested (GPU jobs run on Cloud Batch)", accel.GetType()),
+		}, true
 	}
+	return RoutingDecision{}, false
 }
 
-// EvaluateJobComplexityWithGemini classifies the job using Gemini via Vertex AI
-// (Application Default Credentials) and maps the result to a RoutingDecision.
-// It falls back to the deterministic EvaluateJobComplexity if the API call fails.
-func EvaluateJobComplexityWithGemini(ctx context.Context, req *jennahv1.SubmitJobRequest) RoutingDecision {
-	// Honor hard routing constraints before calling Gemini.
-	if dwpEnabled, reason := isDistributedModeEnabled(req.GetEnvVars()); dwpEnabled {
-		return RoutingDecision{
-			Complexity:      ComplexityComplex,
-			AssignedService: AssignedServiceCloudBatch,
-			Reason:          reason,
-		}
+// WorkloadFromRequest builds a Workload from the raw request fields only.
+// Unset resource overrides stay zero so they never exclude a provider.
+func WorkloadFromRequest(req *jennahv1.SubmitJobRequest) Workload {
+	w := Workload{
+		TaskCount:   1,
+		MachineType: req.GetMachineType(),
+		UseSpot:     req.GetUseSpotVms(),
+		GPU:         req.GetAccelerator().GetType() != "",
+		Runnables:   len(req.GetRunnables()),
 	}
-
-	var cpuMillis, memoryMiB, durationSec int64
-	if ro := req.GetResourceOverride(); ro != nil {
-		cpuMillis = ro.GetCpuMillis()
-		memoryMiB = ro.GetMemoryMib()
-		durationSec = ro.GetMaxRunDurationSeconds()
+	for _, v := range req.GetVolumes() {
+		w.Volumes = append(w.Volumes, VolumeFromProto(v))
 	}
-
-	classification, err := ClassifyWithGemini(ctx, "", cpuMillis, memoryMiB, durationSec, req.GetMachineType())
-	if err != nil {
-		// Fall back to deterministic classifier on any Gemini error.
-		fallback := EvaluateJobComplexity(req)
-		fallback.Reason = fmt.Sprintf("Gemini unavailable (%v); fallback: %s", err, fallback.Reason)
-		return fallback
+	if ro := req.GetResourceOverride(); ro != nil {
+		w.CPUMillis = ro.GetCpuMillis()
+		w.MemoryMiB = ro.GetMemoryMib()
+		w.MaxRunDurationSeconds = ro.GetMaxRunDurationSeconds()
 	}
+	return w
+}
 
-	switch classification.Complexity {
-	case "SIMPLE":
-		return RoutingDecision{
-			Complexity:      ComplexitySimple,
-			AssignedService: AssignedServiceCloudRunJob,
-			Reason:          classification.Reason,
-		}
-	case "MEDIUM":
-		// MEDIUM is collapsed into SIMPLE — both route to Cloud Run Jobs.
-		return RoutingDecision{
-			Complexity:      ComplexitySimple,
-			AssignedService: AssignedServiceCloudRunJob,
-			Reason:          classification.Reason,
-		}
-	case "COMPLEX":
-		return RoutingDecision{
-			Complexity:      ComplexityComplex,
-			AssignedService: AssignedServiceCloudBatch,
-			Reason:          classification.Reason,
-		}
-	default:
-		fallback := EvaluateJobComplexity(req)
-		fallback.Reason = fmt.Sprintf("Gemini returned unknown tier %q; fallback: %s", classification.Complexity, fallback.Reason)
-		return fallback
-	}
+// EvaluateJobComplexityWithGemini is EvaluateJobComplexity with a Vertex AI
+// Gemini ClassifierPolicy consulted between the hard requirements and the
+// capability check. Gemini's tier is only used when the suggested provider
+// can run the job.
+func EvaluateJobComplexityWithGemini(ctx context.Context, req *jennahv1.SubmitJobRequest, candidates []ProviderCandidate) RoutingDecision {
+	gemini := NewClassifierPolicy(NewVertexClassifierFromEnv(), ClassifierOptions{})
+	return PolicyChain{RequiredPolicy{}, gemini, CapabilityPolicy{}}.Evaluate(ctx, RoutingInput{
+		Request:    req,
+		Workload:   WorkloadFromRequest(req),
+		Candidates: candidates,
+	})
 }
 
 // exceedsThreshold returns true only when value is both non-zero and greater
@@ -27,21 +27,21 @@ func makeReq(machineType string, cpuMillis, memoryMiB, durationSec int64) *jenna
 func TestSimple_NoResources(t *testing.T) {
 	// A bare job with no resource overrides and no machine type.
 	req := &jennahv1.SubmitJobRequest{ImageUri: "gcr.io/project/echo:latest"}
-	got := EvaluateJobComplexity(req)
+	got := EvaluateJobComplexity(req, gcpCandidates())
 	assertTier(t, "no-resource job", got, ComplexitySimple, AssignedServiceCloudRunJob)
 }
 
 func TestSimple_LowResources(t *testing.T) {
 	// cpu=250m, mem=256MiB, duration=300s — all within SIMPLE bounds.
 	req := makeReq("", 250, 256, 300)
-	got := EvaluateJobComplexity(req)
+	got := EvaluateJobComplexity(req, gcpCandidates())
 	assertTier(t, "low-resource SIMPLE job", got, ComplexitySimple, AssignedServiceCloudRunJob)
 }
 
 func TestSimple_AtThreshold(t *testing.T) {
 	// Exactly on the COMPLEX threshold values should still be SIMPLE (not exceeding them).
 	req := makeReq("", MediumCPUMillisMax, MediumMemoryMiBMax, MediumDurationSecMax)
-	got := EvaluateJobComplexity(req)
+	got := EvaluateJobComplexity(req, gcpCandidates())
 	assertTier(t, "at-threshold SIMPLE job", got, ComplexitySimple, AssignedServiceCloudRunJob)
 }
 
@@ -51,7 +51,7 @@ func TestSimple_NilResourceOverride(t *testing.T) {
 		ImageUri:         "gcr.io/project/hello:latest",
 		ResourceOverride: nil,
 	}
-	got := EvaluateJobComplexity(req)
+	got := EvaluateJobComplexity(req, gcpCandidates())
 	assertTier(t, "nil resource-override SIMPLE job", got, ComplexitySimple, AssignedServiceCloudRunJob)
 }
 
@@ -62,28 +62,28 @@ func TestSimple_NilResourceOverride(t *testing.T) {
 func TestMedium_CPUExceedsSimple(t *testing.T) {
 	// cpu just above 500m but below 4000m → SIMPLE (Cloud Run Jobs).
 	req := makeReq("", 1000, 256, 300)
-	got := EvaluateJobComplexity(req)
+	got := EvaluateJobComplexity(req, gcpCandidates())
 	assertTier(t, "medium CPU job", got, ComplexitySimple, AssignedServiceCloudRunJob)
 }
 
 func TestMedium_MemoryExceedsSimple(t *testing.T) {
 	// memory just above 512MiB → SIMPLE (Cloud Run Jobs).
 	req := makeReq("", 250, 1024, 300)
-	got := EvaluateJobComplexity(req)
+	got := EvaluateJobComplexity(req, gcpCandidates())
 	assertTier(t, "medium memory job", got, ComplexitySimple, AssignedServiceCloudRunJob)
 }
 
 func TestMedium_DurationExceedsSimple(t *testing.T) {
 	// 30-minute job — above 10-min simple limit, within 1-hour medium limit → SIMPLE (Cloud Run Jobs).
 	req := makeReq("", 250, 256, 1800)
-	got := EvaluateJobComplexity(req)
+	got := EvaluateJobComplexity(req, gcpCandidates())
 	assertTier(t, "medium duration job", got, ComplexitySimple, AssignedServiceCloudRunJob)
 }
 
 func TestMedium_AtMediumThreshold(t *testing.T) {
 	// At medium thresholds — still SIMPLE (Cloud Run Jobs).
 	req := makeReq("", MediumCPUMillisMax, MediumMemoryMiBMax, MediumDurationSecMax)
-	got := EvaluateJobComplexity(req)
+	got := EvaluateJobComplexity(req, gcpCandidates())
 	assertTier(t, "at medium threshold", got, ComplexitySimple, AssignedServiceCloudRunJob)
 }
 
@@ -96,7 +96,7 @@ func TestComplex_ExplicitMachineType(t *testing.T) {
 	cases := []string{"e2-micro", "n1-standard-16", "a2-highgpu-1g", "custom-8-32768"}
 	for _, mt := range cases {
 		req := makeReq(mt, 0, 0, 0)
-		got := EvaluateJobComplexity(req)
+		got := EvaluateJobComplexity(req, gcpCandidates())
 		assertTier(t, "machine_type="+mt, got, ComplexityComplex, AssignedServiceCloudBatch)
 	}
 }
@@ -104,7 +104,7 @@ func TestComplex_ExplicitMachineType(t *testing.T) {
 func TestComplex_MachineTypeWithResources(t *testing.T) {
 	// Even if resources are tiny, machine_type drives the decision.
 	req := makeReq("e2-micro", 100, 128, 60)
-	got := EvaluateJobComplexity(req)
+	got := EvaluateJobComplexity(req, gcpCandidates())
 	assertTier(t, "e2-micro with tiny resources", got, ComplexityComplex, AssignedServiceCloudBatch)
 }
 
@@ -115,21 +115,21 @@ func TestComplex_MachineTypeWithResources(t *testing.T) {
 func TestComplex_CPUExceedsMedium(t *testing.T) {
 	// cpu > 4000m → COMPLEX.
 	req := makeReq("", 8000, 512, 300)
-	got := EvaluateJobComplexity(req)
+	got := EvaluateJobComplexity(req, gcpCandidates())
 	assertTier(t, "heavy CPU job", got, ComplexityComplex, AssignedServiceCloudBatch)
 }
 
 func TestComplex_MemoryExceedsMedium(t *testing.T) {
 	// memory > 8192MiB → COMPLEX.
 	req := makeReq("", 500, 16384, 300)
-	got := EvaluateJobComplexity(req)
+	got := EvaluateJobComplexity(req, gcpCandidates())
 	assertTier(t, "heavy memory job", got, ComplexityComplex, AssignedServiceCloudBatch)
 }
 
 func TestComplex_DurationExceedsMedium(t *testing.T) {
 	// 2-hour job → COMPLEX.
 	req := makeReq("", 500, 512, 7200)
-	got := EvaluateJobComplexity(req)
+	got := EvaluateJobComplexity(req, gcpCandidates())
 	assertTier(t, "long duration job", got, ComplexityComplex, AssignedServiceCloudBatch)
 }
 
@@ -143,7 +143,7 @@ func TestComplex_DWPEnabled(t *testing.T) {
 		ImageUri: "gcr.io/project/worker:latest",
 		EnvVars:  map[string]string{"ENABLE_DISTRIBUTED_MODE": "true"},
 	}
-	got := EvaluateJobComplexity(req)
+	got := EvaluateJobComplexity(req, gcpCandidates())
 	assertTier(t, "DWP enabled (no resources)", got, ComplexityComplex, AssignedServiceCloudBatch)
 }
 
@@ -156,7 +156,7 @@ func TestComplex_DWPWithSmallResources(t *testing.T) {
 			CpuMillis: 100, MemoryMib: 128, MaxRunDurationSeconds: 60,
 		},
 	}
-	got := EvaluateJobComplexity(req)
+	got := EvaluateJobComplexity(req, gcpCandidates())
 	assertTier(t, "DWP enabled (small resources)", got, ComplexityComplex, AssignedServiceCloudBatch)
 }
 
@@ -166,7 +166,7 @@ func TestSimple_DWPDisabledExplicitly(t *testing.T) {
 		ImageUri: "gcr.io/project/worker:latest",
 		EnvVars:  map[string]string{"ENABLE_DISTRIBUTED_MODE": "false"},
 	}
-	got := EvaluateJobComplexity(req)
+	got := EvaluateJobComplexity(req, gcpCandidates())
 	assertTier(t, "DWP disabled explicitly", got, ComplexitySimple, AssignedServiceCloudRunJob)
 }
 
@@ -176,7 +176,7 @@ func TestSimple_NoEnvVars(t *testing.T) {
 		ImageUri: "gcr.io/project/worker:latest",
 		EnvVars:  nil,
 	}
-	got := EvaluateJobComplexity(req)
+	got := EvaluateJobComplexity(req, gcpCandidates())
 	assertTier(t, "nil env vars", got, ComplexitySimple, AssignedServiceCloudRunJob)
 }
 
@@ -185,7 +185,7 @@ func TestComplex_DWPEnabled_CaseInsensitiveValueAndKey(t *testing.T) {
 		ImageUri: "gcr.io/project/worker:latest",
 		EnvVars:  map[string]string{"enable_distributed_mode": " TRUE "},
 	}
-	got := EvaluateJobComplexity(req)
+	got := EvaluateJobComplexity(req, gcpCandidates())
 	assertTier(t, "DWP enabled with mixed-case key/value", got, ComplexityComplex, AssignedServiceCloudBatch)
 }
 
@@ -194,7 +194,7 @@ func TestComplex_DWPEnabled_TaskCountHint(t *testing.T) {
 		ImageUri: "gcr.io/project/worker:latest",
 		EnvVars:  map[string]string{"JENNAH_TASK_COUNT": "4"},
 	}
-	got := EvaluateJobComplexity(req)
+	got := EvaluateJobComplexity(req, gcpCandidates())
 	assertTier(t, "DWP enabled via task_count hint", got, ComplexityComplex, AssignedServiceCloudBatch)
 }
 
@@ -203,7 +203,7 @@ func TestComplex_DWPEnabled_ParallelismHint(t *testing.T) {
 		ImageUri: "gcr.io/project/worker:latest",
 		EnvVars:  map[string]string{"JENNAH_PARALLELISM": "2"},
 	}
-	got := EvaluateJobComplexity(req)
+	got := EvaluateJobComplexity(req, gcpCandidates())
 	assertTier(t, "DWP enabled via parallelism hint", got, ComplexityComplex, AssignedServiceCloudBatch)
 }
 
@@ -212,7 +212,7 @@ func TestSimple_DWPTaskCountHint_OneTaskDoesNotForceComplex(t *testing.T) {
 		ImageUri: "gcr.io/project/worker:latest",
 		EnvVars:  map[string]string{"JENNAH_TASK_COUNT": "1"},
 	}
-	got := EvaluateJobComplexity(req)
+	got := EvaluateJobComplexity(req, gcpCandidates())
 	assertTier(t, "task_count=1 should remain SIMPLE", got, ComplexitySimple, AssignedServiceCloudRunJob)
 }
 
@@ -221,10 +221,38 @@ func TestComplex_DWPEnabled_WithGeminiPathShortCircuits(t *testing.T) {
 		ImageUri: "gcr.io/project/worker:latest",
 		EnvVars:  map[string]string{"JENNAH_TASK_COUNT": "3"},
 	}
-	got := EvaluateJobComplexityWithGemini(context.Background(), req)
+	got := EvaluateJobComplexityWithGemini(context.Background(), req, gcpCandidates())
 	assertTier(t, "gemini path must still force DWP to Cloud Batch", got, ComplexityComplex, AssignedServiceCloudBatch)
 }
 
+// ---------------------------------------------------------------------------
+// COMPLEX tier — GPU accelerators
+// ---------------------------------------------------------------------------
+
+func TestComplex_AcceleratorRequested(t *testing.T) {
+	// Accelerators force Cloud Batch even with tiny resources.
+	req := makeReq("", 1000, 512, 60)
+	req.Accelerator = &jennahv1.Accelerator{Type: "nvidia-tesla-t4", Count: 1}
+	got := EvaluateJobComplexity(req, gcpCandidates())
+	assertTier(t, "accelerator requested", got, ComplexityComplex, AssignedServiceCloudBatch)
+}
+
+func TestComplex_AcceleratorWithGeminiPathShortCircuits(t *testing.T) {
+	req := &jennahv1.SubmitJobRequest{
+		ImageUri:    "gcr.io/project/trainer:latest",
+		Accelerator: &jennahv1.Accelerator{Type: "nvidia-l4"},
+	}
+	got := EvaluateJobComplexityWithGemini(context.Background(), req, gcpCandidates())
+	assertTier(t, "gemini path must force accelerators to Cloud Batch", got, ComplexityComplex, AssignedServiceCloudBatch)
+}
+
+func TestSimple_EmptyAcceleratorDoesNotForceComplex(t *testing.T) {
+	req := makeReq("", 0, 0, 0)
+	req.Accelerator = &jennahv1.Accelerator{}
+	got := EvaluateJobComplexity(req, gcpCandidates())
+	assertTier(t, "empty accelerator block", got, ComplexitySimple, AssignedServiceCloudRunJob)
+}
+
 // ---------------------------------------------------------------------------
 // String helpers
 // ---------------------------------------------------------------------------
@@ -267,7 +295,7 @@ func TestReasonIsNeverEmpty(t *testing.T) {
 		makeReq("e2-micro", 0, 0, 0),
 	}
 	for _, req := range reqs {
-		d := EvaluateJobComplexity(req)
+		d := EvaluateJobComplexity(req, gcpCandidates())
 		if d.Reason == "" {
 			t.Errorf("EvaluateJobComplexity(%v) returned empty Reason", req)
 		}
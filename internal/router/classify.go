@@ -0,0 +1,272 @@
+package router
+
+import (
+	"container/list"
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	batch "github.com/alphauslabs/jennah/internal/cloudexec"
+)
+
+// Classification is a classifier's answer: a SIMPLE/MEDIUM/COMPLEX tier and
+// a one-sentence reason.
+type Classification struct {
+	Complexity string `json:"complexity"`
+	Reason     string `json:"reason"`
+}
+
+// ClassifierInput is the resource fingerprint a Classifier sees. The values
+// are the job's resolved resources.
+type ClassifierInput struct {
+	CPUMillis       int64
+	MemoryMiB       int64
+	DurationSeconds int64
+	MachineType     string
+	// SimpleLimits are the capabilities of the Cloud Run Jobs candidate,
+	// which LLM classifiers are given as the SIMPLE tier boundary. They come
+	// from the registered providers, so they are not part of the
+	// Fingerprint.
+	SimpleLimits batch.Capabilities
+}
+
+// classifierInputFor returns the normalized classifier input for w routed
+// against candidates.
+func classifierInputFor(w Workload, candidates []ProviderCandidate) ClassifierInput {
+	limits, _ := capabilitiesFor(candidates, AssignedServiceCloudRunJob)
+	return ClassifierInput{
+		CPUMillis:       w.CPUMillis,
+		MemoryMiB:       w.MemoryMiB,
+		DurationSeconds: w.MaxRunDurationSeconds,
+		MachineType:     strings.ToLower(strings.TrimSpace(w.MachineType)),
+		SimpleLimits:    limits,
+	}
+}
+
+// Fingerprint is the cache key for in: identical resources classify alike.
+func (in ClassifierInput) Fingerprint() string {
+	return fmt.Sprintf("cpu=%d;mem=%d;dur=%d;mt=%s", in.CPUMillis, in.MemoryMiB, in.DurationSeconds, in.MachineType)
+}
+
+// Classifier assigns a complexity tier to a job's resources.
+type Classifier interface {
+	Name() string
+	Classify(ctx context.Context, in ClassifierInput) (Classification, error)
+}
+
+// RulesClassifier is the deterministic classifier: the tier of the cheapest
+// candidate whose capabilities fit. It never fails and is the fallback for
+// the LLM classifiers.
+type RulesClassifier struct {
+	Candidates []ProviderCandidate
+}
+
+// Name implements Classifier.
+func (RulesClassifier) Name() string { return "rules" }
+
+// Classify implements Classifier.
+func (r RulesClassifier) Classify(_ context.Context, in ClassifierInput) (Classification, error) {
+	decision := SelectProvider(Workload{
+		CPUMillis:             in.CPUMillis,
+		MemoryMiB:             in.MemoryMiB,
+		MaxRunDurationSeconds: in.DurationSeconds,
+		MachineType:           in.MachineType,
+		TaskCount:             1,
+	}, r.Candidates)
+	if decision.AssignedService == AssignedServiceUnspecified {
+		return Classification{}, fmt.Errorf("%s", decision.Reason)
+	}
+	return Classification{Complexity: decision.Complexity.String(), Reason: decision.Reason}, nil
+}
+
+// ClassifierOptions configures a ClassifierPolicy.
+type ClassifierOptions struct {
+	// Timeout bounds each classifier call. Zero uses 5s.
+	Timeout time.Duration
+	// CacheSize is the number of fingerprints kept; zero disables the cache.
+	CacheSize int
+	// CacheTTL is how long a classification is reused. Zero uses 10m.
+	CacheTTL time.Duration
+	// Fallback answers when the classifier fails; nil defers to the next policy.
+	Fallback Classifier
+}
+
+// ClassifierStats counts ClassifierPolicy outcomes since start.
+type ClassifierStats struct {
+	Classifier string `json:"classifier"`
+	Calls      int64  `json:"calls"`
+	CacheHits  int64  `json:"cache_hits"`
+	Fallbacks  int64  `json:"fallbacks"`
+	Rejected   int64  `json:"rejected"`
+	// FallbackRate is Fallbacks over classifier calls (cache hits excluded).
+	FallbackRate float64 `json:"fallback_rate"`
+}
+
+// ClassifierPolicy routes jobs with a Classifier. Answers are cached by
+// resource fingerprint; a failed or timed-out call uses the fallback
+// classifier. Like every advisory policy, the tier is only used when the
+// matching provider can run the job.
+type ClassifierPolicy struct {
+	classifier Classifier
+	opts       ClassifierOptions
+	cache      *classificationCache
+
+	calls     atomic.Int64
+	cacheHits atomic.Int64
+	fallbacks atomic.Int64
+	rejected  atomic.Int64
+}
+
+// NewClassifierPolicy returns a policy consulting c.
+func NewClassifierPolicy(c Classifier, opts ClassifierOptions) *ClassifierPolicy {
+	if opts.Timeout <= 0 {
+		opts.Timeout = 5 * time.Second
+	}
+	if opts.CacheTTL <= 0 {
+		opts.CacheTTL = 10 * time.Minute
+	}
+	p := &ClassifierPolicy{classifier: c, opts: opts}
+	if opts.CacheSize > 0 {
+		p.cache = newClassificationCache(opts.CacheSize, opts.CacheTTL)
+	}
+	return p
+}
+
+// Name implements RoutingPolicy.
+func (p *ClassifierPolicy) Name() string { return p.classifier.Name() }
+
+// Stats returns the policy's counters.
+func (p *ClassifierPolicy) Stats() ClassifierStats {
+	s := ClassifierStats{
+		Classifier: p.classifier.Name(),
+		Calls:      p.calls.Load(),
+		CacheHits:  p.cacheHits.Load(),
+		Fallbacks:  p.fallbacks.Load(),
+		Rejected:   p.rejected.Load(),
+	}
+	if misses := s.Calls - s.CacheHits; misses > 0 {
+		s.FallbackRate = float64(s.Fallbacks) / float64(misses)
+	}
+	return s
+}
+
+// Decide implements RoutingPolicy.
+func (p *ClassifierPolicy) Decide(ctx context.Context, in RoutingInput) (RoutingDecision, bool) {
+	// Classifiers only see resource figures, so volume and runnable support
+	// is left to the capability check.
+	if len(in.Request.GetVolumes()) > 0 || len(in.Request.GetRunnables()) > 0 {
+		return RoutingDecision{}, false
+	}
+	p.calls.Add(1)
+
+	key := classifierInputFor(in.Workload, in.Candidates)
+	c, ok := p.cache.get(key.Fingerprint())
+	if ok {
+		p.cacheHits.Add(1)
+	} else {
+		var err error
+		c, err = p.classify(ctx, key)
+		if err != nil {
+			p.fallbacks.Add(1)
+			if p.opts.Fallback == nil {
+				return RoutingDecision{Reason: fmt.Sprintf("%s unavailable (%v)", p.classifier.Name(), err)}, false
+			}
+			fallback, ferr := p.opts.Fallback.Classify(ctx, key)
+			if ferr != nil {
+				return RoutingDecision{Reason: fmt.Sprintf("%s unavailable (%v); %s fallback failed (%v)", p.classifier.Name(), err, p.opts.Fallback.Name(), ferr)}, false
+			}
+			c = fallback
+			c.Reason = fmt.Sprintf("%s unavailable (%v); %s fallback: %s", p.classifier.Name(), err, p.opts.Fallback.Name(), fallback.Reason)
+		} else {
+			p.cache.put(key.Fingerprint(), c)
+		}
+	}
+
+	decision, ok := decisionFromTier(c.Complexity, c.Reason)
+	if !ok {
+		p.rejected.Add(1)
+		return RoutingDecision{Reason: fmt.Sprintf("returned unknown tier %q", c.Complexity)}, false
+	}
+	decision, ok = acceptIfFits(decision, in.Workload, in.Candidates)
+	if !ok {
+		p.rejected.Add(1)
+	}
+	return decision, ok
+}
+
+// classify calls the classifier with the per-call timeout.
+func (p *ClassifierPolicy) classify(ctx context.Context, in ClassifierInput) (Classification, error) {
+	ctx, cancel := context.WithTimeout(ctx, p.opts.Timeout)
+	defer cancel()
+	return p.classifier.Classify(ctx, in)
+}
+
+// classificationCache is a bounded LRU of classifications with a TTL.
+// A nil cache never hits.
+type classificationCache struct {
+	mu    sync.Mutex
+	size  int
+	ttl   time.Duration
+	now   func() time.Time
+	order *list.List // front = most recently used
+	items map[string]*list.Element
+}
+
+type cacheEntry struct {
+	key     string
+	value   Classification
+	expires time.Time
+}
+
+func newClassificationCache(size int, ttl time.Duration) *classificationCache {
+	return &classificationCache{
+		size:  size,
+		ttl:   ttl,
+		now:   time.Now,
+		order: list.New(),
+		items: make(map[string]*list.Element, size),
+	}
+}
+
+func (c *classificationCache) get(key string) (Classification, bool) {
+	if c == nil {
+		return Classification{}, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	el, ok := c.items[key]
+	if !ok {
+		return Classification{}, false
+	}
+	entry := el.Value.(*cacheEntry)
+	if c.now().After(entry.expires) {
+		c.order.Remove(el)
+		delete(c.items, key)
+		return Classification{}, false
+	}
+	c.order.MoveToFront(el)
+	return entry.value, true
+}
+
+func (c *classificationCache) put(key string, value Classification) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	expires := c.now().Add(c.ttl)
+	if el, ok := c.items[key]; ok {
+		el.Value = &cacheEntry{key: key, value: value, expires: expires}
+		c.order.MoveToFront(el)
+		return
+	}
+	c.items[key] = c.order.PushFront(&cacheEntry{key: key, value: value, expires: expires})
+	for c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.items, oldest.Value.(*cacheEntry).key)
+	}
+}
@@ -0,0 +1,212 @@
+package router
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	jennahv1 "github.com/alphauslabs/jennah/gen/proto"
+	batch "github.com/alphauslabs/jennah/internal/cloudexec"
+)
+
+// fakeClassifier answers with a fixed classification or error.
+type fakeClassifier struct {
+	answer Classification
+	err    error
+	delay  time.Duration
+	calls  atomic.Int64
+}
+
+func (f *fakeClassifier) Name() string { return "fake" }
+
+func (f *fakeClassifier) Classify(ctx context.Context, _ ClassifierInput) (Classification, error) {
+	f.calls.Add(1)
+	if f.delay > 0 {
+		select {
+		case <-time.After(f.delay):
+		case <-ctx.Done():
+			return Classification{}, ctx.Err()
+		}
+	}
+	return f.answer, f.err
+}
+
+func classifierInput(w Workload) RoutingInput {
+	return RoutingInput{Request: &jennahv1.SubmitJobRequest{}, Workload: w, Candidates: gcpCandidates()}
+}
+
+func TestHTTPClassifier_ChatCompletions(t *testing.T) {
+	var got chatRequest
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/chat/completions" {
+			t.Errorf("path = %s, want /v1/chat/completions", r.URL.Path)
+		}
+		if auth := r.Header.Get("Authorization"); auth != "Bearer secret" {
+			t.Errorf("Authorization = %q", auth)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("decode request: %v", err)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"choices":[{"message":{"role":"assistant","content":"` +
+			"```json\\n{\\\"complexity\\\": \\\"COMPLEX\\\", \\\"reason\\\": \\\"memory above 8192 MiB\\\"}\\n```" + `"}}]}`))
+	}))
+	defer srv.Close()
+
+	c := NewHTTPClassifier(srv.URL+"/v1/", "local-model", "secret", srv.Client())
+	limits := batch.Capabilities{MaxCPUMillis: 6000, MaxMemoryMiB: 12288, MaxRunDurationSeconds: 7200}
+	out, err := c.Classify(context.Background(), ClassifierInput{MemoryMiB: 16384, MachineType: "", SimpleLimits: limits})
+	if err != nil {
+		t.Fatalf("Classify: %v", err)
+	}
+	if out.Complexity != "COMPLEX" || out.Reason != "memory above 8192 MiB" {
+		t.Errorf("Classify() = %+v", out)
+	}
+	if got.Model != "local-model" || len(got.Messages) != 2 || got.Messages[0].Role != "system" {
+		t.Fatalf("request = %+v", got)
+	}
+	if !strings.Contains(got.Messages[1].Content, "Memory: 16384 MiB") {
+		t.Errorf("prompt = %q, want the job's memory", got.Messages[1].Content)
+	}
+	// The tier boundary comes from the registered Cloud Run provider.
+	if !strings.Contains(got.Messages[0].Content, "Memory: 12288 MiB or less") {
+		t.Errorf("system prompt does not carry the provider's memory limit")
+	}
+}
+
+func TestHTTPClassifier_Errors(t *testing.T) {
+	cases := []struct {
+		name    string
+		status  int
+		body    string
+		wantErr string
+	}{
+		{"server error", http.StatusBadGateway, "model loading", "502"},
+		{"no choices", http.StatusOK, `{"choices":[]}`, "no choices"},
+		{"not json", http.StatusOK, `{"choices":[{"message":{"content":"SIMPLE, I think"}}]}`, "failed to parse"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tc.status)
+				w.Write([]byte(tc.body))
+			}))
+			defer srv.Close()
+
+			_, err := NewHTTPClassifier(srv.URL, "m", "", srv.Client()).Classify(context.Background(), ClassifierInput{})
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Fatalf("Classify() error = %v, want it to contain %q", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestClassifierPolicy_CachesByFingerprint(t *testing.T) {
+	fake := &fakeClassifier{answer: Classification{Complexity: "SIMPLE", Reason: "small"}}
+	p := NewClassifierPolicy(fake, ClassifierOptions{CacheSize: 8})
+
+	w := Workload{TaskCount: 1, CPUMillis: 1000, MemoryMiB: 512, MachineType: ""}
+	for i := 0; i < 3; i++ {
+		got, ok := p.Decide(context.Background(), classifierInput(w))
+		if !ok {
+			t.Fatalf("Decide() deferred: %s", got.Reason)
+		}
+		assertTier(t, "cached", got, ComplexitySimple, AssignedServiceCloudRunJob)
+	}
+	w.MemoryMiB = 1024
+	p.Decide(context.Background(), classifierInput(w))
+
+	if n := fake.calls.Load(); n != 2 {
+		t.Errorf("classifier calls = %d, want 2 (one per fingerprint)", n)
+	}
+	if s := p.Stats(); s.Calls != 4 || s.CacheHits != 2 || s.Fallbacks != 0 {
+		t.Errorf("Stats() = %+v", s)
+	}
+}
+
+func TestClassifierPolicy_FallsBackOnErrorAndTimeout(t *testing.T) {
+	cases := []struct {
+		name string
+		fake *fakeClassifier
+	}{
+		{"error", &fakeClassifier{err: errors.New("quota exceeded")}},
+		{"timeout", &fakeClassifier{delay: time.Second, answer: Classification{Complexity: "SIMPLE"}}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			p := NewClassifierPolicy(tc.fake, ClassifierOptions{
+				Timeout:   20 * time.Millisecond,
+				CacheSize: 8,
+				Fallback:  RulesClassifier{Candidates: gcpCandidates()},
+			})
+			w := Workload{TaskCount: 1, MemoryMiB: MediumMemoryMiBMax * 2}
+			for i := 0; i < 2; i++ {
+				got, ok := p.Decide(context.Background(), classifierInput(w))
+				if !ok {
+					t.Fatalf("Decide() deferred: %s", got.Reason)
+				}
+				assertTier(t, "fallback", got, ComplexityComplex, AssignedServiceCloudBatch)
+				if !strings.Contains(got.Reason, "fake unavailable") || !strings.Contains(got.Reason, "rules fallback") {
+					t.Errorf("Reason = %q, want the failure and the fallback", got.Reason)
+				}
+			}
+			// Fallback answers are not cached, so a recovered classifier is used again.
+			if n := tc.fake.calls.Load(); n != 2 {
+				t.Errorf("classifier calls = %d, want 2", n)
+			}
+			if s := p.Stats(); s.Fallbacks != 2 || s.FallbackRate != 1 {
+				t.Errorf("Stats() = %+v, want every call to fall back", s)
+			}
+		})
+	}
+}
+
+func TestClassifierPolicy_RejectsSuggestionThatDoesNotFit(t *testing.T) {
+	fake := &fakeClassifier{answer: Classification{Complexity: "SIMPLE", Reason: "looks small"}}
+	p := NewClassifierPolicy(fake, ClassifierOptions{})
+	got, ok := p.Decide(context.Background(), classifierInput(Workload{TaskCount: 1, MachineType: "n2-standard-8"}))
+	if ok {
+		t.Fatalf("Decide() accepted %s for an explicit machine type", got.AssignedService)
+	}
+	if s := p.Stats(); s.Rejected != 1 {
+		t.Errorf("Rejected = %d, want 1", s.Rejected)
+	}
+}
+
+func TestClassificationCache_EvictsLeastRecentlyUsedAndExpires(t *testing.T) {
+	now := time.Unix(0, 0)
+	c := newClassificationCache(2, time.Minute)
+	c.now = func() time.Time { return now }
+
+	c.put("a", Classification{Complexity: "SIMPLE"})
+	c.put("b", Classification{Complexity: "SIMPLE"})
+	c.get("a") // b is now least recently used
+	c.put("c", Classification{Complexity: "COMPLEX"})
+	if _, ok := c.get("b"); ok {
+		t.Error("b survived eviction")
+	}
+	if _, ok := c.get("a"); !ok {
+		t.Error("a was evicted")
+	}
+
+	now = now.Add(2 * time.Minute)
+	if _, ok := c.get("c"); ok {
+		t.Error("c survived its TTL")
+	}
+}
+
+func TestRulesClassifier(t *testing.T) {
+	got, err := RulesClassifier{Candidates: gcpCandidates()}.Classify(context.Background(), ClassifierInput{CPUMillis: 16000})
+	if err != nil || got.Complexity != "COMPLEX" {
+		t.Fatalf("Classify() = %+v, %v; want COMPLEX", got, err)
+	}
+	if _, err := (RulesClassifier{Candidates: []ProviderCandidate{}}).Classify(context.Background(), ClassifierInput{}); err == nil {
+		t.Error("Classify() with no candidates succeeded")
+	}
+}
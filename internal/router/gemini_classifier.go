@@ -5,87 +5,164 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"strings"
+	"sync"
 
 	"google.golang.org/genai"
+
+	batch "github.com/alphauslabs/jennah/internal/cloudexec"
 )
 
-const geminiSystemInstruction = `You are a highly analytical and deterministic Cloud Infrastructure Job Router. You evaluate incoming computing workloads and make strict routing decisions without deviation.
+// classifierSystemInstructionTemplate is filled in with the Cloud Run Jobs
+// limits (CPU, memory, duration) by classifierSystemInstruction.
+const classifierSystemInstructionTemplate = `You are a highly analytical and deterministic Cloud Infrastructure Job Router. You evaluate incoming computing workloads and make strict routing decisions without deviation.
 
 Your system is receiving job payloads containing CPU (in mCPU), Memory (in MiB), Duration (in seconds), and Machine Type requests. You must classify the job's complexity into exactly one of TWO tiers based strictly on the following boundary rules:
 
 1. The SIMPLE Tier (Strict Logical AND):
 To be classified as SIMPLE, a job must stay under ALL of these thresholds simultaneously. If it breaks even one rule, it is rejected from the Simple tier:
-- CPU: 4000 mCPU or less.
-- Memory: 8192 MiB or less.
-- Duration: 3600 seconds or less.
+- CPU: %[1]d mCPU or less.
+- Memory: %[2]d MiB or less.
+- Duration: %[3]d seconds or less.
 - Machine Type: Must be left blank, empty, or null.
 
 2. The COMPLEX Tier (Logical OR / Tripwires):
 To be classified as COMPLEX, a job only needs to exceed ANY SINGLE upper threshold. Hitting just one of these wires instantly flags the job as Complex:
-- CPU: Greater than 4000 mCPU.
-- Memory: Greater than 8192 MiB.
-- Duration: Greater than 3600 seconds.
+- CPU: Greater than %[1]d mCPU.
+- Memory: Greater than %[2]d MiB.
+- Duration: Greater than %[3]d seconds.
 - Machine Type: If a specific Machine Type is explicitly requested (not blank), ignore CPU/Memory math and immediately force the job to be COMPLEX.
 
 You must output your decision strictly as a valid JSON object. Do not include markdown formatting, code fences, backticks, or any conversational text. Use this exact schema:
 {"complexity": "SIMPLE | COMPLEX", "reason": "Provide a brief, 1-sentence explanation of which rules were evaluated to reach this decision."}`
 
-// GeminiClassification is the JSON response returned by Gemini.
-type GeminiClassification struct {
-	Complexity string `json:"complexity"`
-	Reason     string `json:"reason"`
+// classifierSystemInstruction renders the system prompt shared by the LLM
+// classifiers using the limits reported by the Cloud Run provider, so the
+// prompt follows capability changes.
+func classifierSystemInstruction(limits batch.Capabilities) string {
+	return fmt.Sprintf(classifierSystemInstructionTemplate, limits.MaxCPUMillis, limits.MaxMemoryMiB, limits.MaxRunDurationSeconds)
 }
 
-// ClassifyWithGemini sends the job parameters to Gemini via Vertex AI and returns
-// the AI-determined complexity classification.
-// Uses Application Default Credentials — no API key required.
-// Project is read from the BATCH_PROJECT_ID or GCP_PROJECT environment variable.
-func ClassifyWithGemini(ctx context.Context, _ string, cpuMillis, memoryMiB, durationSec int64, machineType string) (*GeminiClassification, error) {
+// defaultGeminiModel is the Vertex AI model used when none is configured.
+const defaultGeminiModel = "gemini-2.0-flash-001"
+
+// classifierPrompt renders the per-job prompt for an LLM classifier.
+func classifierPrompt(in ClassifierInput) string {
+	machineType := in.MachineType
+	if machineType == "" {
+		machineType = `""`
+	}
+	return fmt.Sprintf(
+		`Evaluate this job -> CPU: %d mCPU, Memory: %d MiB, Duration: %d seconds, Machine Type: %s`,
+		in.CPUMillis, in.MemoryMiB, in.DurationSeconds, machineType,
+	)
+}
+
+// parseClassification decodes an LLM's JSON answer, tolerating a markdown
+// code fence around it.
+func parseClassification(raw string) (Classification, error) {
+	text := strings.TrimSpace(raw)
+	if strings.HasPrefix(text, "```") {
+		text = strings.TrimPrefix(text, "```json")
+		text = strings.TrimPrefix(text, "```")
+		text = strings.TrimSuffix(strings.TrimSpace(text), "```")
+	}
+	var c Classification
+	if err := json.Unmarshal([]byte(text), &c); err != nil {
+		return Classification{}, fmt.Errorf("failed to parse classifier response %q: %w", raw, err)
+	}
+	return c, nil
+}
+
+// VertexClassifier classifies jobs with Gemini on Vertex AI using
+// Application Default Credentials — no API key required. The client is
+// created on first use.
+type VertexClassifier struct {
+	project  string
+	location string
+	model    string
+
+	mu     sync.Mutex
+	client *genai.Client
+}
+
+// NewVertexClassifier returns a classifier for the given project and
+// location. An empty model uses gemini-2.0-flash-001.
+func NewVertexClassifier(project, location, model string) *VertexClassifier {
+	if model == "" {
+		model = defaultGeminiModel
+	}
+	return &VertexClassifier{project: project, location: location, model: model}
+}
+
+// NewVertexClassifierFromEnv reads the project from BATCH_PROJECT_ID or
+// GCP_PROJECT and the location from BATCH_REGION (default us-central1).
+func NewVertexClassifierFromEnv() *VertexClassifier {
 	project := os.Getenv("BATCH_PROJECT_ID")
 	if project == "" {
 		project = os.Getenv("GCP_PROJECT")
 	}
-	if project == "" {
-		return nil, fmt.Errorf("BATCH_PROJECT_ID or GCP_PROJECT must be set for Vertex AI")
-	}
-
 	location := os.Getenv("BATCH_REGION")
 	if location == "" {
 		location = "us-central1"
 	}
+	return NewVertexClassifier(project, location, "")
+}
 
-	client, err := genai.NewClient(ctx, &genai.ClientConfig{
-		Project:  project,
-		Location: location,
-		Backend:  genai.BackendVertexAI,
+// Name implements Classifier.
+func (v *VertexClassifier) Name() string { return "gemini" }
+
+// Classify implements Classifier.
+func (v *VertexClassifier) Classify(ctx context.Context, in ClassifierInput) (Classification, error) {
+	client, err := v.genaiClient(ctx)
+	if err != nil {
+		return Classification{}, err
+	}
+	result, err := client.Models.GenerateContent(ctx, v.model, genai.Text(classifierPrompt(in)), &genai.GenerateContentConfig{
+		SystemInstruction: genai.NewContentFromText(classifierSystemInstruction(in.SimpleLimits), genai.RoleUser),
 	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to create Vertex AI client: %w", err)
+		return Classification{}, fmt.Errorf("Gemini API call failed: %w", err)
 	}
+	return parseClassification(result.Text())
+}
 
-	machineTypeStr := machineType
-	if machineTypeStr == "" {
-		machineTypeStr = `""`
+func (v *VertexClassifier) genaiClient(ctx context.Context) (*genai.Client, error) {
+	if v.project == "" {
+		return nil, fmt.Errorf("BATCH_PROJECT_ID or GCP_PROJECT must be set for Vertex AI")
 	}
-
-	prompt := fmt.Sprintf(
-		`Evaluate this job -> CPU: %d mCPU, Memory: %d MiB, Duration: %d seconds, Machine Type: %s`,
-		cpuMillis, memoryMiB, durationSec, machineTypeStr,
-	)
-
-	result, err := client.Models.GenerateContent(ctx, "gemini-2.0-flash-001", genai.Text(prompt), &genai.GenerateContentConfig{
-		SystemInstruction: genai.NewContentFromText(geminiSystemInstruction, genai.RoleUser),
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if v.client != nil {
+		return v.client, nil
+	}
+	client, err := genai.NewClient(ctx, &genai.ClientConfig{
+		Project:  v.project,
+		Location: v.location,
+		Backend:  genai.BackendVertexAI,
 	})
 	if err != nil {
-		return nil, fmt.Errorf("Gemini API call failed: %w", err)
+		return nil, fmt.Errorf("failed to create Vertex AI client: %w", err)
 	}
+	v.client = client
+	return client, nil
+}
 
-	rawText := result.Text()
-
-	var classification GeminiClassification
-	if err := json.Unmarshal([]byte(rawText), &classification); err != nil {
-		return nil, fmt.Errorf("failed to parse Gemini response %q: %w", rawText, err)
+// ClassifyWithGemini sends the job parameters to Gemini via Vertex AI and returns
+// the AI-determined complexity classification.
+// Uses Application Default Credentials — no API key required.
+// Project is read from the BATCH_PROJECT_ID or GCP_PROJECT environment variable.
+// simpleLimits are the Cloud Run provider's capabilities.
+func ClassifyWithGemini(ctx context.Context, _ string, cpuMillis, memoryMiB, durationSec int64, machineType string, simpleLimits batch.Capabilities) (*Classification, error) {
+	c, err := NewVertexClassifierFromEnv().Classify(ctx, ClassifierInput{
+		CPUMillis:       cpuMillis,
+		MemoryMiB:       memoryMiB,
+		DurationSeconds: durationSec,
+		MachineType:     machineType,
+		SimpleLimits:    simpleLimits,
+	})
+	if err != nil {
+		return nil, err
 	}
-
-	return &classification, nil
+	return &c, nil
 }
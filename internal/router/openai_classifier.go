@@ -0,0 +1,96 @@
+package router
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// HTTPClassifier classifies jobs with any OpenAI-compatible chat completions
+// endpoint, e.g. a self-hosted model behind vLLM or Ollama.
+type HTTPClassifier struct {
+	baseURL string
+	model   string
+	apiKey  string
+	client  *http.Client
+}
+
+// NewHTTPClassifier returns a classifier posting to baseURL + "/chat/completions".
+// apiKey is sent as a bearer token when set; a nil client uses http.DefaultClient.
+func NewHTTPClassifier(baseURL, model, apiKey string, client *http.Client) *HTTPClassifier {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &HTTPClassifier{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		model:   model,
+		apiKey:  apiKey,
+		client:  client,
+	}
+}
+
+// Name implements Classifier.
+func (h *HTTPClassifier) Name() string { return "openai" }
+
+type chatMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type chatRequest struct {
+	Model       string        `json:"model"`
+	Messages    []chatMessage `json:"messages"`
+	Temperature float64       `json:"temperature"`
+}
+
+type chatResponse struct {
+	Choices []struct {
+		Message chatMessage `json:"message"`
+	} `json:"choices"`
+}
+
+// Classify implements Classifier.
+func (h *HTTPClassifier) Classify(ctx context.Context, in ClassifierInput) (Classification, error) {
+	body, err := json.Marshal(chatRequest{
+		Model: h.model,
+		Messages: []chatMessage{
+			{Role: "system", Content: classifierSystemInstruction(in.SimpleLimits)},
+			{Role: "user", Content: classifierPrompt(in)},
+		},
+	})
+	if err != nil {
+		return Classification{}, fmt.Errorf("failed to encode classifier request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, h.baseURL+"/chat/completions", bytes.NewReader(body))
+	if err != nil {
+		return Classification{}, fmt.Errorf("failed to create classifier request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if h.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+h.apiKey)
+	}
+
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return Classification{}, fmt.Errorf("classifier request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return Classification{}, fmt.Errorf("classifier returned %s: %s", resp.Status, strings.TrimSpace(string(msg)))
+	}
+
+	var out chatResponse
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return Classification{}, fmt.Errorf("failed to decode classifier response: %w", err)
+	}
+	if len(out.Choices) == 0 {
+		return Classification{}, fmt.Errorf("classifier returned no choices")
+	}
+	return parseClassification(out.Choices[0].Message.Content)
+}
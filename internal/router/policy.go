@@ -0,0 +1,114 @@
+package router
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	jennahv1 "github.com/alphauslabs/jennah/gen/proto"
+)
+
+// RoutingInput is what a RoutingPolicy sees when routing a job.
+type RoutingInput struct {
+	// Request is the job as submitted.
+	Request *jennahv1.SubmitJobRequest
+	// TenantID is the submitting tenant; empty when unknown.
+	TenantID string
+	// Workload is the job's resolved shape (resource profile applied).
+	Workload Workload
+	// Candidates are the providers the job may be dispatched to.
+	Candidates []ProviderCandidate
+}
+
+// RoutingPolicy is one step of a routing chain. Decide returns the decision
+// and true when the policy settles where the job runs. It returns false to
+// defer to the next policy; a non-empty Reason on a deferral is kept as a note
+// in the final decision's reason.
+type RoutingPolicy interface {
+	Name() string
+	Decide(ctx context.Context, in RoutingInput) (RoutingDecision, bool)
+}
+
+// PolicyChain evaluates policies in order; the first one that decides wins.
+type PolicyChain []RoutingPolicy
+
+// DefaultPolicies returns the deterministic chain: hard requirements first,
+// then the cheapest provider whose capabilities fit.
+func DefaultPolicies() PolicyChain {
+	return PolicyChain{RequiredPolicy{}, CapabilityPolicy{}}
+}
+
+// Evaluate runs the chain and returns the decision of the first policy that
+// decides. Decision.Policy names that policy. When no policy decides, the
+// decision has AssignedServiceUnspecified.
+func (c PolicyChain) Evaluate(ctx context.Context, in RoutingInput) RoutingDecision {
+	var notes []string
+	for _, p := range c {
+		decision, ok := p.Decide(ctx, in)
+		if !ok {
+			if decision.Reason != "" {
+				notes = append(notes, fmt.Sprintf("%s deferred: %s", p.Name(), decision.Reason))
+			}
+			continue
+		}
+		decision.Policy = p.Name()
+		if len(notes) > 0 {
+			decision.Reason = strings.Join(notes, "; ") + "; " + decision.Reason
+		}
+		return decision
+	}
+	notes = append(notes, "no routing policy decided")
+	return RoutingDecision{Reason: strings.Join(notes, "; ")}
+}
+
+// RequiredPolicy fixes the service for jobs that only one service can run
+// (distributed workloads, GPUs). See RequiredRouting.
+type RequiredPolicy struct{}
+
+// Name implements RoutingPolicy.
+func (RequiredPolicy) Name() string { return "required" }
+
+// Decide implements RoutingPolicy.
+func (RequiredPolicy) Decide(_ context.Context, in RoutingInput) (RoutingDecision, bool) {
+	return RequiredRouting(in.Request)
+}
+
+// CapabilityPolicy picks the cheapest candidate whose capabilities fit the
+// workload (see SelectProvider). It always decides, so it belongs last.
+type CapabilityPolicy struct{}
+
+// Name implements RoutingPolicy.
+func (CapabilityPolicy) Name() string { return "capability" }
+
+// Decide implements RoutingPolicy.
+func (CapabilityPolicy) Decide(_ context.Context, in RoutingInput) (RoutingDecision, bool) {
+	return SelectProvider(in.Workload, in.Candidates), true
+}
+
+// decisionFromTier maps a SIMPLE/MEDIUM/COMPLEX tier name to a decision.
+// MEDIUM is collapsed into SIMPLE — both route to Cloud Run Jobs.
+func decisionFromTier(tier, reason string) (RoutingDecision, bool) {
+	switch strings.ToUpper(tier) {
+	case "SIMPLE", "MEDIUM":
+		return RoutingDecision{Complexity: ComplexitySimple, AssignedService: AssignedServiceCloudRunJob, Reason: reason}, true
+	case "COMPLEX":
+		return RoutingDecision{Complexity: ComplexityComplex, AssignedService: AssignedServiceCloudBatch, Reason: reason}, true
+	default:
+		return RoutingDecision{}, false
+	}
+}
+
+// acceptIfFits returns decision when its service is among candidates and can
+// run w; otherwise it defers with the reason the suggestion was rejected.
+func acceptIfFits(decision RoutingDecision, w Workload, candidates []ProviderCandidate) (RoutingDecision, bool) {
+	for _, c := range candidates {
+		if c.Service != decision.AssignedService {
+			continue
+		}
+		if why := exclusionReason(w, c.Capabilities); why != "" {
+			return RoutingDecision{Reason: fmt.Sprintf("suggested %s but %s", decision.AssignedService, why)}, false
+		}
+		return decision, true
+	}
+	return RoutingDecision{Reason: fmt.Sprintf("suggested %s, which is not registered", decision.AssignedService)}, false
+}
@@ -0,0 +1,99 @@
+package router
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	jennahv1 "github.com/alphauslabs/jennah/gen/proto"
+)
+
+// stubPolicy returns a fixed answer and counts how often it was asked.
+type stubPolicy struct {
+	name     string
+	decision RoutingDecision
+	decides  bool
+	calls    int
+}
+
+func (p *stubPolicy) Name() string { return p.name }
+
+func (p *stubPolicy) Decide(context.Context, RoutingInput) (RoutingDecision, bool) {
+	p.calls++
+	return p.decision, p.decides
+}
+
+func TestPolicyChain_FirstDecidingPolicyWins(t *testing.T) {
+	first := &stubPolicy{name: "first", decision: RoutingDecision{Reason: "not sure"}}
+	second := &stubPolicy{name: "second", decides: true, decision: RoutingDecision{
+		Complexity: ComplexityComplex, AssignedService: AssignedServiceCloudBatch, Reason: "big job",
+	}}
+	third := &stubPolicy{name: "third", decides: true}
+
+	got := PolicyChain{first, second, third}.Evaluate(context.Background(), RoutingInput{})
+	assertTier(t, "second policy", got, ComplexityComplex, AssignedServiceCloudBatch)
+	if got.Policy != "second" {
+		t.Errorf("Policy = %q, want second", got.Policy)
+	}
+	if got.Reason != "first deferred: not sure; big job" {
+		t.Errorf("Reason = %q, want deferral note followed by the decision", got.Reason)
+	}
+	if first.calls != 1 || second.calls != 1 || third.calls != 0 {
+		t.Errorf("calls = %d/%d/%d, want 1/1/0", first.calls, second.calls, third.calls)
+	}
+}
+
+func TestPolicyChain_NoPolicyDecides(t *testing.T) {
+	got := PolicyChain{&stubPolicy{name: "only"}}.Evaluate(context.Background(), RoutingInput{})
+	if got.AssignedService != AssignedServiceUnspecified {
+		t.Errorf("AssignedService = %s, want UNSPECIFIED", got.AssignedService)
+	}
+	if got.Reason == "" {
+		t.Error("Reason is empty")
+	}
+}
+
+func TestDefaultPolicies_RequiredBeforeCapability(t *testing.T) {
+	req := &jennahv1.SubmitJobRequest{EnvVars: map[string]string{"ENABLE_DISTRIBUTED_MODE": "true"}}
+	got := DefaultPolicies().Evaluate(context.Background(), RoutingInput{
+		Request:    req,
+		Workload:   Workload{TaskCount: 1},
+		Candidates: gcpCandidates(),
+	})
+	assertTier(t, "distributed job", got, ComplexityComplex, AssignedServiceCloudBatch)
+	if got.Policy != "required" {
+		t.Errorf("Policy = %q, want required", got.Policy)
+	}
+
+	got = DefaultPolicies().Evaluate(context.Background(), RoutingInput{
+		Request:    &jennahv1.SubmitJobRequest{},
+		Workload:   Workload{TaskCount: 1},
+		Candidates: gcpCandidates(),
+	})
+	assertTier(t, "plain job", got, ComplexitySimple, AssignedServiceCloudRunJob)
+	if got.Policy != "capability" {
+		t.Errorf("Policy = %q, want capability", got.Policy)
+	}
+}
+
+func TestAcceptIfFits(t *testing.T) {
+	simple := RoutingDecision{Complexity: ComplexitySimple, AssignedService: AssignedServiceCloudRunJob, Reason: "small"}
+
+	if _, ok := acceptIfFits(simple, Workload{TaskCount: 1}, gcpCandidates()); !ok {
+		t.Error("fitting suggestion was rejected")
+	}
+
+	tooLong := Workload{TaskCount: 1, MaxRunDurationSeconds: MediumDurationSecMax + 1}
+	got, ok := acceptIfFits(simple, tooLong, gcpCandidates())
+	if ok {
+		t.Fatal("suggestion exceeding Cloud Run limits was accepted")
+	}
+	if !strings.Contains(got.Reason, "suggested CLOUD_RUN_JOB") {
+		t.Errorf("Reason = %q, want it to name the rejected suggestion", got.Reason)
+	}
+
+	batchOnly := []ProviderCandidate{gcpCandidates()[1]}
+	if _, ok := acceptIfFits(simple, Workload{TaskCount: 1}, batchOnly); ok {
+		t.Error("suggestion for an unregistered service was accepted")
+	}
+}
@@ -0,0 +1,67 @@
+// Package routertest runs tables of job requests against a routing rules
+// file, so rule changes can be reviewed with the routes they produce.
+package routertest
+
+import (
+	"context"
+	"testing"
+
+	jennahv1 "github.com/alphauslabs/jennah/gen/proto"
+	"github.com/alphauslabs/jennah/internal/router"
+)
+
+// Case is one request and the route it should take.
+type Case struct {
+	Name     string
+	Request  *jennahv1.SubmitJobRequest
+	TenantID string
+	// Workload overrides the shape derived from Request (e.g. to supply
+	// resolved profile resources); nil uses router.WorkloadFromRequest.
+	Workload *router.Workload
+	// Want is the service the job must be dispatched to.
+	Want router.AssignedService
+	// WantRule is the rule that must match; "" means no rule may match.
+	WantRule string
+}
+
+// RunRules loads the rules file at path and checks every case against the
+// worker's chain (hard requirements, the rules, then the capability check)
+// routing to candidates.
+func RunRules(t *testing.T, path string, candidates []router.ProviderCandidate, cases []Case) {
+	t.Helper()
+	set, err := router.LoadRules(path)
+	if err != nil {
+		t.Fatalf("LoadRules(%s): %v", path, err)
+	}
+	chain := router.PolicyChain{router.RequiredPolicy{}, router.NewRulesPolicy(set), router.CapabilityPolicy{}}
+
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			req := c.Request
+			if req == nil {
+				req = &jennahv1.SubmitJobRequest{}
+			}
+			in := router.RoutingInput{
+				Request:    req,
+				TenantID:   c.TenantID,
+				Workload:   router.WorkloadFromRequest(req),
+				Candidates: candidates,
+			}
+			if c.Workload != nil {
+				in.Workload = *c.Workload
+			}
+
+			got := chain.Evaluate(context.Background(), in)
+			if got.AssignedService != c.Want {
+				t.Errorf("routed to %s, want %s (reason: %s)", got.AssignedService, c.Want, got.Reason)
+			}
+			_, rule, matched := set.Match(in)
+			switch {
+			case c.WantRule == "" && matched:
+				t.Errorf("rule %q matched, want none", rule)
+			case c.WantRule != "" && rule != c.WantRule:
+				t.Errorf("matched rule %q, want %q", rule, c.WantRule)
+			}
+		})
+	}
+}
@@ -0,0 +1,293 @@
+package router
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"sync/atomic"
+	"text/template"
+	"time"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/ext"
+	"gopkg.in/yaml.v3"
+)
+
+// RulesFile is the on-disk form of the routing rules (YAML or JSON).
+//
+//	rules:
+//	  - name: big-memory
+//	    when: memory_mib > 16384
+//	    service: CLOUD_BATCH
+//	    reason: "{{.memory_mib}} MiB requested; large jobs run on Cloud Batch"
+//
+// Rules are evaluated in order and the first whose condition is true routes
+// the job. A rule's service is only used when that provider can run the job.
+type RulesFile struct {
+	Rules []RuleSpec `yaml:"rules" json:"rules"`
+}
+
+// RuleSpec is one routing rule.
+type RuleSpec struct {
+	// Name identifies the rule in reasons and logs; it must be unique.
+	Name string `yaml:"name" json:"name"`
+	// When is a CEL expression over the variables listed in ruleVariables
+	// that must evaluate to a bool.
+	When string `yaml:"when" json:"when"`
+	// Service is the target: CLOUD_RUN_JOB or CLOUD_BATCH.
+	Service string `yaml:"service" json:"service"`
+	// Reason is a text/template rendered with the same variables.
+	Reason string `yaml:"reason" json:"reason"`
+}
+
+// ruleVariables are the CEL variables available to rule conditions and
+// reason templates. Resource figures are the resolved values, so a resource
+// profile counts the same as an explicit override. The CEL strings extension
+// (lowerAscii, matches, ...) is enabled for env and image matching.
+var ruleVariables = []cel.EnvOption{
+	ext.Strings(),
+	cel.Variable("cpu_millis", cel.IntType),
+	cel.Variable("memory_mib", cel.IntType),
+	cel.Variable("duration_seconds", cel.IntType),
+	cel.Variable("task_count", cel.IntType),
+	cel.Variable("machine_type", cel.StringType),
+	cel.Variable("use_spot", cel.BoolType),
+	cel.Variable("gpu", cel.BoolType),
+	cel.Variable("volumes", cel.IntType),
+	cel.Variable("runnables", cel.IntType),
+	cel.Variable("image", cel.StringType),
+	cel.Variable("tenant", cel.StringType),
+	cel.Variable("env", cel.MapType(cel.StringType, cel.StringType)),
+	cel.Variable("labels", cel.MapType(cel.StringType, cel.StringType)),
+}
+
+// RuleSet is a validated, compiled set of routing rules.
+type RuleSet struct {
+	rules []compiledRule
+}
+
+type compiledRule struct {
+	name    string
+	service AssignedService
+	program cel.Program
+	reason  *template.Template
+}
+
+// LoadRules reads and compiles the rules file at path.
+func LoadRules(path string) (*RuleSet, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read routing rules: %w", err)
+	}
+	return ParseRules(data)
+}
+
+// ParseRules compiles rules from YAML (or JSON) data. Every condition is
+// type-checked and every reason template parsed, so a rule set that loads
+// cannot fail on a job later because of a typo.
+func ParseRules(data []byte) (*RuleSet, error) {
+	var file RulesFile
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse routing rules: %w", err)
+	}
+
+	env, err := cel.NewEnv(ruleVariables...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create rule environment: %w", err)
+	}
+
+	set := &RuleSet{}
+	seen := make(map[string]bool, len(file.Rules))
+	for i, spec := range file.Rules {
+		if spec.Name == "" {
+			return nil, fmt.Errorf("rule %d: name is required", i+1)
+		}
+		if seen[spec.Name] {
+			return nil, fmt.Errorf("rule %q: duplicate name", spec.Name)
+		}
+		seen[spec.Name] = true
+
+		service, ok := ParseAssignedService(spec.Service)
+		if !ok {
+			return nil, fmt.Errorf("rule %q: unknown service %q (want CLOUD_RUN_JOB or CLOUD_BATCH)", spec.Name, spec.Service)
+		}
+		if strings.TrimSpace(spec.When) == "" {
+			return nil, fmt.Errorf("rule %q: when is required", spec.Name)
+		}
+		ast, issues := env.Compile(spec.When)
+		if issues != nil && issues.Err() != nil {
+			return nil, fmt.Errorf("rule %q: invalid condition: %w", spec.Name, issues.Err())
+		}
+		if ast.OutputType() != cel.BoolType {
+			return nil, fmt.Errorf("rule %q: condition must be a bool, got %s", spec.Name, ast.OutputType())
+		}
+		program, err := env.Program(ast)
+		if err != nil {
+			return nil, fmt.Errorf("rule %q: %w", spec.Name, err)
+		}
+
+		reason := spec.Reason
+		if reason == "" {
+			reason = "matched routing rule " + spec.Name
+		}
+		tmpl, err := template.New(spec.Name).Option("missingkey=error").Parse(reason)
+		if err != nil {
+			return nil, fmt.Errorf("rule %q: invalid reason template: %w", spec.Name, err)
+		}
+
+		set.rules = append(set.rules, compiledRule{name: spec.Name, service: service, program: program, reason: tmpl})
+	}
+	return set, nil
+}
+
+// Len returns the number of rules in the set.
+func (s *RuleSet) Len() int {
+	if s == nil {
+		return 0
+	}
+	return len(s.rules)
+}
+
+// Match returns the decision of the first rule whose condition holds for in,
+// and false when none does. Errors evaluating a rule (e.g. a missing map key)
+// skip that rule.
+func (s *RuleSet) Match(in RoutingInput) (RoutingDecision, string, bool) {
+	if s == nil {
+		return RoutingDecision{}, "", false
+	}
+	vars := ruleActivation(in)
+	for _, r := range s.rules {
+		out, _, err := r.program.Eval(vars)
+		if err != nil {
+			log.Printf("Routing rule %q skipped: %v", r.name, err)
+			continue
+		}
+		if matched, ok := out.Value().(bool); !ok || !matched {
+			continue
+		}
+		var reason strings.Builder
+		if err := r.reason.Execute(&reason, vars); err != nil {
+			reason.Reset()
+			reason.WriteString("matched routing rule " + r.name)
+		}
+		return RoutingDecision{
+			Complexity:      ComplexityForService(r.service),
+			AssignedService: r.service,
+			Reason:          reason.String(),
+		}, r.name, true
+	}
+	return RoutingDecision{}, "", false
+}
+
+// ruleActivation returns the rule variables for in.
+func ruleActivation(in RoutingInput) map[string]any {
+	env := in.Request.GetEnvVars()
+	if env == nil {
+		env = map[string]string{}
+	}
+	labels := in.Request.GetLabels()
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	w := in.Workload
+	return map[string]any{
+		"cpu_millis":       w.CPUMillis,
+		"memory_mib":       w.MemoryMiB,
+		"duration_seconds": w.MaxRunDurationSeconds,
+		"task_count":       w.TaskCount,
+		"machine_type":     w.MachineType,
+		"use_spot":         w.UseSpot,
+		"gpu":              w.GPU,
+		"volumes":          int64(len(w.Volumes)),
+		"runnables":        int64(w.Runnables),
+		"image":            in.Request.GetImageUri(),
+		"tenant":           in.TenantID,
+		"env":              env,
+		"labels":           labels,
+	}
+}
+
+// RulesPolicy routes jobs with a declarative RuleSet. The set can be swapped
+// at runtime (see Watch); evaluation always uses a consistent snapshot.
+type RulesPolicy struct {
+	rules atomic.Pointer[RuleSet]
+}
+
+// NewRulesPolicy returns a policy evaluating set.
+func NewRulesPolicy(set *RuleSet) *RulesPolicy {
+	p := &RulesPolicy{}
+	p.rules.Store(set)
+	return p
+}
+
+// Name implements RoutingPolicy.
+func (p *RulesPolicy) Name() string { return "rules" }
+
+// Rules returns the rule set currently in use.
+func (p *RulesPolicy) Rules() *RuleSet { return p.rules.Load() }
+
+// Store replaces the rule set.
+func (p *RulesPolicy) Store(set *RuleSet) { p.rules.Store(set) }
+
+// Decide implements RoutingPolicy. A matching rule whose service cannot run
+// the job defers to the next policy.
+func (p *RulesPolicy) Decide(_ context.Context, in RoutingInput) (RoutingDecision, bool) {
+	decision, rule, ok := p.rules.Load().Match(in)
+	if !ok {
+		return RoutingDecision{}, false
+	}
+	decision, ok = acceptIfFits(decision, in.Workload, in.Candidates)
+	if !ok {
+		decision.Reason = fmt.Sprintf("rule %s %s", rule, decision.Reason)
+		return decision, false
+	}
+	decision.Reason = fmt.Sprintf("rule %s: %s", rule, decision.Reason)
+	return decision, true
+}
+
+// Watch reloads the rules from path every interval when the file changes,
+// until ctx is done. The first check always reloads, so edits made between
+// the initial load and the call are not missed. A file that fails validation
+// is logged and the rules in use are kept.
+func (p *RulesPolicy) Watch(ctx context.Context, path string, interval time.Duration) {
+	var lastMod time.Time
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		info, err := os.Stat(path)
+		if err != nil {
+			log.Printf("Routing rules %s unavailable, keeping current rules: %v", path, err)
+			continue
+		}
+		if info.ModTime().Equal(lastMod) {
+			continue
+		}
+		lastMod = info.ModTime()
+		set, err := LoadRules(path)
+		if err != nil {
+			log.Printf("Routing rules %s rejected, keeping current rules: %v", path, err)
+			continue
+		}
+		p.Store(set)
+		log.Printf("Reloaded %d routing rules from %s", set.Len(), path)
+	}
+}
+
+// ParseAssignedService parses the String form of an AssignedService.
+func ParseAssignedService(s string) (AssignedService, bool) {
+	switch strings.ToUpper(strings.TrimSpace(s)) {
+	case AssignedServiceCloudRunJob.String():
+		return AssignedServiceCloudRunJob, true
+	case AssignedServiceCloudBatch.String():
+		return AssignedServiceCloudBatch, true
+	default:
+		return AssignedServiceUnspecified, false
+	}
+}
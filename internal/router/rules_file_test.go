@@ -0,0 +1,54 @@
+package router_test
+
+import (
+	"testing"
+
+	jennahv1 "github.com/alphauslabs/jennah/gen/proto"
+	"github.com/alphauslabs/jennah/internal/cloudexec/gcp"
+	"github.com/alphauslabs/jennah/internal/router"
+	"github.com/alphauslabs/jennah/internal/router/routertest"
+)
+
+// TestShippedRoutingRules pins the routes of config/routing-rules.yaml.
+func TestShippedRoutingRules(t *testing.T) {
+	candidates := []router.ProviderCandidate{
+		{Service: router.AssignedServiceCloudRunJob, Capabilities: gcp.CloudRunJobCapabilities},
+		{Service: router.AssignedServiceCloudBatch, Capabilities: gcp.CloudBatchCapabilities},
+	}
+	routertest.RunRules(t, "../../config/routing-rules.yaml", candidates, []routertest.Case{
+		{
+			Name: "plain job",
+			Want: router.AssignedServiceCloudRunJob, WantRule: "",
+		},
+		{
+			Name:    "distributed mode flag",
+			Request: &jennahv1.SubmitJobRequest{EnvVars: map[string]string{"enable_distributed_mode": "Yes"}},
+			Want:    router.AssignedServiceCloudBatch, WantRule: "distributed",
+		},
+		{
+			Name:     "resolved task count",
+			Workload: &router.Workload{TaskCount: 4},
+			Want:     router.AssignedServiceCloudBatch, WantRule: "distributed",
+		},
+		{
+			Name:    "gpu",
+			Request: &jennahv1.SubmitJobRequest{Accelerator: &jennahv1.Accelerator{Type: "nvidia-l4", Count: 1}},
+			Want:    router.AssignedServiceCloudBatch, WantRule: "gpu",
+		},
+		{
+			Name:    "machine type",
+			Request: &jennahv1.SubmitJobRequest{MachineType: "n2-standard-8"},
+			Want:    router.AssignedServiceCloudBatch, WantRule: "machine-type",
+		},
+		{
+			Name:    "memory above the SIMPLE tier",
+			Request: &jennahv1.SubmitJobRequest{ResourceOverride: &jennahv1.ResourceOverride{MemoryMib: 16384}},
+			Want:    router.AssignedServiceCloudBatch, WantRule: "heavy",
+		},
+		{
+			Name:    "at the SIMPLE tier limits",
+			Request: &jennahv1.SubmitJobRequest{ResourceOverride: &jennahv1.ResourceOverride{CpuMillis: 4000, MemoryMib: 8192, MaxRunDurationSeconds: 3600}},
+			Want:    router.AssignedServiceCloudRunJob, WantRule: "",
+		},
+	})
+}
@@ -0,0 +1,144 @@
+package router
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	jennahv1 "github.com/alphauslabs/jennah/gen/proto"
+)
+
+func TestParseRules_RejectsInvalidRules(t *testing.T) {
+	cases := []struct {
+		name    string
+		yaml    string
+		wantErr string
+	}{
+		{"missing name", "rules: [{when: 'true', service: CLOUD_BATCH}]", "name is required"},
+		{"duplicate name", "rules: [{name: a, when: 'true', service: CLOUD_BATCH}, {name: a, when: 'true', service: CLOUD_BATCH}]", "duplicate name"},
+		{"unknown service", "rules: [{name: a, when: 'true', service: CLOUD_TASKS}]", "unknown service"},
+		{"missing condition", "rules: [{name: a, service: CLOUD_BATCH}]", "when is required"},
+		{"syntax error", "rules: [{name: a, when: 'memory_mib >', service: CLOUD_BATCH}]", "invalid condition"},
+		{"unknown variable", "rules: [{name: a, when: 'memory_gb > 1', service: CLOUD_BATCH}]", "invalid condition"},
+		{"non-bool condition", "rules: [{name: a, when: 'memory_mib + 1', service: CLOUD_BATCH}]", "must be a bool"},
+		{"bad template", "rules: [{name: a, when: 'true', service: CLOUD_BATCH, reason: '{{.memory_mib'}]", "invalid reason template"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := ParseRules([]byte(tc.yaml))
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Fatalf("ParseRules() error = %v, want it to contain %q", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestRuleSet_MatchFirstRuleAndRenderReason(t *testing.T) {
+	set, err := ParseRules([]byte(`
+rules:
+  - name: tenant-pinned
+    when: tenant == "acme" && labels["team"] == "ml"
+    service: CLOUD_BATCH
+    reason: "tenant {{.tenant}} pinned to Batch"
+  - name: big-memory
+    when: memory_mib > 4096
+    service: CLOUD_BATCH
+    reason: "{{.memory_mib}} MiB requested"
+  - name: images
+    when: image.matches("^gcr\\.io/trusted/")
+    service: CLOUD_RUN_JOB
+`))
+	if err != nil {
+		t.Fatalf("ParseRules: %v", err)
+	}
+
+	in := RoutingInput{
+		Request:  &jennahv1.SubmitJobRequest{ImageUri: "gcr.io/trusted/app", Labels: map[string]string{"team": "ml"}},
+		TenantID: "acme",
+		Workload: Workload{MemoryMiB: 8192},
+	}
+	got, rule, ok := set.Match(in)
+	if !ok || rule != "tenant-pinned" || got.Reason != "tenant acme pinned to Batch" {
+		t.Fatalf("Match() = %+v, %q, %v; want tenant-pinned", got, rule, ok)
+	}
+
+	in.TenantID = "other"
+	got, rule, _ = set.Match(in)
+	if rule != "big-memory" || got.Reason != "8192 MiB requested" {
+		t.Fatalf("Match() = %+v, %q; want big-memory", got, rule)
+	}
+
+	in.Workload.MemoryMiB = 1024
+	got, rule, _ = set.Match(in)
+	if rule != "images" || got.AssignedService != AssignedServiceCloudRunJob || got.Reason != "matched routing rule images" {
+		t.Fatalf("Match() = %+v, %q; want images", got, rule)
+	}
+
+	in.Request.ImageUri = "docker.io/app"
+	if _, rule, ok := set.Match(in); ok {
+		t.Fatalf("Match() matched %q, want no match", rule)
+	}
+}
+
+func TestRulesPolicy_DefersWhenTargetCannotRunJob(t *testing.T) {
+	set, err := ParseRules([]byte(`rules: [{name: run-everything, when: 'true', service: CLOUD_RUN_JOB}]`))
+	if err != nil {
+		t.Fatalf("ParseRules: %v", err)
+	}
+	chain := PolicyChain{NewRulesPolicy(set), CapabilityPolicy{}}
+	got := chain.Evaluate(context.Background(), RoutingInput{
+		Request:    &jennahv1.SubmitJobRequest{},
+		Workload:   Workload{TaskCount: 1, MemoryMiB: MediumMemoryMiBMax * 2},
+		Candidates: gcpCandidates(),
+	})
+	assertTier(t, "oversized job", got, ComplexityComplex, AssignedServiceCloudBatch)
+	if got.Policy != "capability" || !strings.Contains(got.Reason, "rules deferred: rule run-everything suggested CLOUD_RUN_JOB") {
+		t.Errorf("decision = %+v, want the capability policy with the rule's deferral noted", got)
+	}
+}
+
+func TestRulesPolicy_WatchReloadsValidChanges(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rules.yaml")
+	write := func(body string, mod time.Time) {
+		t.Helper()
+		if err := os.WriteFile(path, []byte(body), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chtimes(path, mod, mod); err != nil {
+			t.Fatal(err)
+		}
+	}
+	start := time.Now().Add(-time.Hour)
+	write(`rules: [{name: one, when: 'true', service: CLOUD_BATCH}]`, start)
+	set, err := LoadRules(path)
+	if err != nil {
+		t.Fatalf("LoadRules: %v", err)
+	}
+	policy := NewRulesPolicy(set)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go policy.Watch(ctx, path, 5*time.Millisecond)
+
+	waitFor := func(want int) {
+		t.Helper()
+		deadline := time.Now().Add(2 * time.Second)
+		for policy.Rules().Len() != want {
+			if time.Now().After(deadline) {
+				t.Fatalf("rule count = %d, want %d", policy.Rules().Len(), want)
+			}
+			time.Sleep(5 * time.Millisecond)
+		}
+	}
+
+	write(`rules: [{name: one, when: 'true', service: CLOUD_BATCH}, {name: two, when: 'gpu', service: CLOUD_BATCH}]`, start.Add(time.Minute))
+	waitFor(2)
+
+	// An invalid edit is rejected and the previous rules stay in effect.
+	write(`rules: [{name: broken, when: 'gpu >', service: CLOUD_BATCH}]`, start.Add(2*time.Minute))
+	time.Sleep(50 * time.Millisecond)
+	waitFor(2)
+}
@@ -0,0 +1,56 @@
+package svcauth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"connectrpc.com/connect"
+)
+
+// NewClientInterceptor signs every outgoing unary call for the tenant in its
+// TenantHeader.
+func NewClientInterceptor(signer *Signer) connect.UnaryInterceptorFunc {
+	return func(next connect.UnaryFunc) connect.UnaryFunc {
+		return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+			if req.Spec().IsClient {
+				token, err := signer.Sign(req.Header().Get(TenantHeader), req.Spec().Procedure)
+				if err != nil {
+					return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to sign request: %w", err))
+				}
+				req.Header().Set(TokenHeader, token)
+			}
+			return next(ctx, req)
+		}
+	}
+}
+
+// NewServerInterceptor rejects calls without a valid token, and calls whose
+// TenantHeader differs from the token's tenant. With allowUnsigned, calls
+// that carry no token at all are let through unchecked; that is only meant
+// for local development.
+func NewServerInterceptor(verifier *Verifier, allowUnsigned bool) connect.UnaryInterceptorFunc {
+	return func(next connect.UnaryFunc) connect.UnaryFunc {
+		return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+			if req.Spec().IsClient {
+				return next(ctx, req)
+			}
+			token := req.Header().Get(TokenHeader)
+			if token == "" && allowUnsigned {
+				return next(ctx, req)
+			}
+			claims, err := verifier.Verify(token, req.Spec().Procedure)
+			if err != nil {
+				log.Printf("Rejected %s from %s: %v", req.Spec().Procedure, req.Peer().Addr, err)
+				return nil, connect.NewError(connect.CodeUnauthenticated, err)
+			}
+			if tenantID := req.Header().Get(TenantHeader); tenantID != claims.TenantID {
+				log.Printf("Rejected %s from %s (request %s): tenant %q does not match token tenant %q",
+					req.Spec().Procedure, req.Peer().Addr, claims.RequestID, tenantID, claims.TenantID)
+				return nil, connect.NewError(connect.CodePermissionDenied, errors.New("tenant does not match service token"))
+			}
+			return next(ctx, req)
+		}
+	}
+}
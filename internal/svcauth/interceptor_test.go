@@ -0,0 +1,95 @@
+package svcauth
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+
+	jennahv1 "github.com/alphauslabs/jennah/gen/proto"
+	"github.com/alphauslabs/jennah/gen/proto/jennahv1connect"
+)
+
+type tenantEcho struct {
+	jennahv1connect.UnimplementedDeploymentServiceHandler
+}
+
+func (tenantEcho) GetJob(_ context.Context, req *connect.Request[jennahv1.GetJobRequest]) (*connect.Response[jennahv1.GetJobResponse], error) {
+	return connect.NewResponse(&jennahv1.GetJobResponse{Job: &jennahv1.Job{JobId: req.Msg.JobId, TenantId: req.Header().Get(TenantHeader)}}), nil
+}
+
+func newTestServer(t *testing.T, allowUnsigned bool) *httptest.Server {
+	t.Helper()
+	verifier, err := NewVerifier(testSecret)
+	if err != nil {
+		t.Fatal(err)
+	}
+	path, handler := jennahv1connect.NewDeploymentServiceHandler(tenantEcho{},
+		connect.WithInterceptors(NewServerInterceptor(verifier, allowUnsigned)))
+	mux := http.NewServeMux()
+	mux.Handle(path, handler)
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func getJob(client jennahv1connect.DeploymentServiceClient, tenantID string, header map[string]string) (*jennahv1.Job, error) {
+	req := connect.NewRequest(&jennahv1.GetJobRequest{JobId: "job-1"})
+	req.Header().Set(TenantHeader, tenantID)
+	for k, v := range header {
+		req.Header().Set(k, v)
+	}
+	resp, err := client.GetJob(context.Background(), req)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Msg.Job, nil
+}
+
+func TestInterceptors(t *testing.T) {
+	srv := newTestServer(t, false)
+	signer, err := NewSigner(testSecret, "gateway", 30*time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signed := jennahv1connect.NewDeploymentServiceClient(srv.Client(), srv.URL, connect.WithInterceptors(NewClientInterceptor(signer)))
+	unsigned := jennahv1connect.NewDeploymentServiceClient(srv.Client(), srv.URL)
+
+	job, err := getJob(signed, "tenant-a", nil)
+	if err != nil {
+		t.Fatalf("signed call failed: %v", err)
+	}
+	if job.TenantId != "tenant-a" {
+		t.Errorf("handler saw tenant %q, want tenant-a", job.TenantId)
+	}
+
+	if _, err := getJob(unsigned, "tenant-a", nil); connect.CodeOf(err) != connect.CodeUnauthenticated {
+		t.Errorf("unsigned call: got %v, want unauthenticated", err)
+	}
+
+	// A token captured from one request cannot be sent again, nor used to
+	// act for another tenant.
+	token, _ := signer.Sign("tenant-a", "/jennah.v1.DeploymentService/GetJob")
+	if _, err := getJob(unsigned, "tenant-b", map[string]string{TokenHeader: token}); connect.CodeOf(err) != connect.CodePermissionDenied {
+		t.Errorf("other tenant: got %v, want permission denied", err)
+	}
+	if _, err := getJob(unsigned, "tenant-a", map[string]string{TokenHeader: token}); connect.CodeOf(err) != connect.CodeUnauthenticated {
+		t.Errorf("replayed token: got %v, want unauthenticated", err)
+	}
+}
+
+func TestServerInterceptorAllowUnsigned(t *testing.T) {
+	srv := newTestServer(t, true)
+	unsigned := jennahv1connect.NewDeploymentServiceClient(srv.Client(), srv.URL)
+
+	if _, err := getJob(unsigned, "tenant-a", nil); err != nil {
+		t.Errorf("unsigned call rejected in development mode: %v", err)
+	}
+	// Tokens that are present are still checked.
+	if _, err := getJob(unsigned, "tenant-a", map[string]string{TokenHeader: "v1.e30.AAAA"}); connect.CodeOf(err) != connect.CodeUnauthenticated {
+		t.Errorf("invalid token: got %v, want unauthenticated", err)
+	}
+}
@@ -0,0 +1,204 @@
+// Package svcauth authenticates calls between the gateway and workers.
+//
+// The caller signs a short-lived token for every request with a shared
+// secret (HMAC-SHA256). The token names the tenant, the RPC it was issued
+// for and a random request ID, so a token cannot be used for another tenant
+// or procedure, and a worker accepts each request ID only once.
+package svcauth
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// TokenHeader carries the signed token of a request.
+const TokenHeader = "X-Jennah-Service-Token"
+
+// TenantHeader carries the tenant a request acts for. A verified request's
+// TenantHeader always equals the token's tenant.
+const TenantHeader = "X-Tenant-Id"
+
+// MinSecretLength is the minimum length of a signing secret in bytes.
+const MinSecretLength = 32
+
+// MaxTokenTTL is the longest lifetime a verifier accepts. It bounds how long
+// request IDs are remembered for replay detection.
+const MaxTokenTTL = 5 * time.Minute
+
+// clockSkew is how far the signer's clock may be ahead of or behind the
+// verifier's.
+const clockSkew = 10 * time.Second
+
+const tokenVersion = "v1"
+
+// Errors returned by Verify.
+var (
+	ErrMissingToken   = errors.New("service token is missing")
+	ErrMalformedToken = errors.New("service token is malformed")
+	ErrBadSignature   = errors.New("service token signature is invalid")
+	ErrExpiredToken   = errors.New("service token has expired")
+	ErrReplayedToken  = errors.New("service token was already used")
+	ErrWrongProcedure = errors.New("service token was issued for another procedure")
+)
+
+// Claims are the signed contents of a token.
+type Claims struct {
+	// Issuer names the signer (a gateway or a forwarding worker).
+	Issuer string `json:"iss"`
+	// TenantID is the tenant the request acts for; empty for admin calls.
+	TenantID string `json:"tid,omitempty"`
+	// RequestID is random and unique per token.
+	RequestID string `json:"rid"`
+	// Procedure is the full RPC name, e.g. /jennah.v1.DeploymentService/GetJob.
+	Procedure string `json:"proc"`
+	IssuedAt  int64  `json:"iat"`
+	ExpiresAt int64  `json:"exp"`
+}
+
+// Signer issues tokens.
+type Signer struct {
+	key    []byte
+	issuer string
+	ttl    time.Duration
+	now    func() time.Time
+}
+
+// NewSigner creates a signer that issues tokens valid for ttl.
+func NewSigner(secret, issuer string, ttl time.Duration) (*Signer, error) {
+	if len(secret) < MinSecretLength {
+		return nil, fmt.Errorf("service auth secret must be at least %d bytes", MinSecretLength)
+	}
+	if ttl <= 0 || ttl > MaxTokenTTL {
+		return nil, fmt.Errorf("service token TTL must be between 0 and %s, got %s", MaxTokenTTL, ttl)
+	}
+	return &Signer{key: []byte(secret), issuer: issuer, ttl: ttl, now: time.Now}, nil
+}
+
+// Sign returns a token for a call of procedure on behalf of tenantID.
+func (s *Signer) Sign(tenantID, procedure string) (string, error) {
+	var id [16]byte
+	if _, err := rand.Read(id[:]); err != nil {
+		return "", fmt.Errorf("failed to generate request ID: %w", err)
+	}
+	now := s.now()
+	payload, err := json.Marshal(Claims{
+		Issuer:    s.issuer,
+		TenantID:  tenantID,
+		RequestID: hex.EncodeToString(id[:]),
+		Procedure: procedure,
+		IssuedAt:  now.Unix(),
+		ExpiresAt: now.Add(s.ttl).Unix(),
+	})
+	if err != nil {
+		return "", err
+	}
+	signed := tokenVersion + "." + base64.RawURLEncoding.EncodeToString(payload)
+	return signed + "." + base64.RawURLEncoding.EncodeToString(mac(s.key, signed)), nil
+}
+
+// Verifier checks tokens and remembers the request IDs it accepted until
+// they expire.
+type Verifier struct {
+	keys [][]byte
+	now  func() time.Time
+
+	mu        sync.Mutex
+	seen      map[string]time.Time
+	nextPrune time.Time
+}
+
+// NewVerifier creates a verifier accepting tokens signed with any of
+// secrets. Listing the previous secret after the current one lets signers be
+// rotated one at a time.
+func NewVerifier(secrets ...string) (*Verifier, error) {
+	v := &Verifier{now: time.Now, seen: make(map[string]time.Time)}
+	for _, secret := range secrets {
+		if len(secret) < MinSecretLength {
+			return nil, fmt.Errorf("service auth secret must be at least %d bytes", MinSecretLength)
+		}
+		v.keys = append(v.keys, []byte(secret))
+	}
+	if len(v.keys) == 0 {
+		return nil, errors.New("at least one service auth secret is required")
+	}
+	return v, nil
+}
+
+// Verify checks token's signature and lifetime, that it was issued for
+// procedure and that it was not used before, and returns its claims.
+func (v *Verifier) Verify(token, procedure string) (*Claims, error) {
+	if token == "" {
+		return nil, ErrMissingToken
+	}
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 || parts[0] != tokenVersion {
+		return nil, ErrMalformedToken
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, ErrMalformedToken
+	}
+	signed := parts[0] + "." + parts[1]
+	valid := false
+	for _, key := range v.keys {
+		if hmac.Equal(sig, mac(key, signed)) {
+			valid = true
+			break
+		}
+	}
+	if !valid {
+		return nil, ErrBadSignature
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, ErrMalformedToken
+	}
+	var claims Claims
+	if err := json.Unmarshal(payload, &claims); err != nil || claims.RequestID == "" {
+		return nil, ErrMalformedToken
+	}
+
+	now := v.now()
+	issued, expires := time.Unix(claims.IssuedAt, 0), time.Unix(claims.ExpiresAt, 0)
+	if !now.Before(expires.Add(clockSkew)) {
+		return nil, ErrExpiredToken
+	}
+	if issued.After(now.Add(clockSkew)) || expires.Sub(issued) > MaxTokenTTL {
+		return nil, ErrMalformedToken
+	}
+	if claims.Procedure != procedure {
+		return nil, ErrWrongProcedure
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if now.After(v.nextPrune) {
+		for id, exp := range v.seen {
+			if now.After(exp) {
+				delete(v.seen, id)
+			}
+		}
+		v.nextPrune = now.Add(time.Minute)
+	}
+	if _, ok := v.seen[claims.RequestID]; ok {
+		return nil, ErrReplayedToken
+	}
+	v.seen[claims.RequestID] = expires.Add(clockSkew)
+	return &claims, nil
+}
+
+func mac(key []byte, signed string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(signed))
+	return h.Sum(nil)
+}
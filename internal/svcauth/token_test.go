@@ -0,0 +1,185 @@
+package svcauth
+
+import (
+	"encoding/base64"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+const (
+	testSecret   = "0123456789abcdef0123456789abcdef"
+	otherSecret  = "fedcba9876543210fedcba9876543210"
+	getJobMethod = "/jennah.v1.DeploymentService/GetJob"
+)
+
+func newTestPair(t *testing.T, now *time.Time) (*Signer, *Verifier) {
+	t.Helper()
+	signer, err := NewSigner(testSecret, "gateway", 30*time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	verifier, err := NewVerifier(testSecret)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer.now = func() time.Time { return *now }
+	verifier.now = func() time.Time { return *now }
+	return signer, verifier
+}
+
+func TestSignAndVerify(t *testing.T) {
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	signer, verifier := newTestPair(t, &now)
+
+	token, err := signer.Sign("tenant-a", getJobMethod)
+	if err != nil {
+		t.Fatal(err)
+	}
+	claims, err := verifier.Verify(token, getJobMethod)
+	if err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+	if claims.TenantID != "tenant-a" || claims.Issuer != "gateway" || claims.RequestID == "" {
+		t.Errorf("unexpected claims %+v", claims)
+	}
+	if got := time.Unix(claims.ExpiresAt, 0).Sub(time.Unix(claims.IssuedAt, 0)); got != 30*time.Second {
+		t.Errorf("lifetime = %s, want 30s", got)
+	}
+
+	other, _ := signer.Sign("tenant-a", getJobMethod)
+	if other == token {
+		t.Error("two tokens for the same call are identical")
+	}
+}
+
+func TestVerifyRejectsReplay(t *testing.T) {
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	signer, verifier := newTestPair(t, &now)
+
+	token, _ := signer.Sign("tenant-a", getJobMethod)
+	if _, err := verifier.Verify(token, getJobMethod); err != nil {
+		t.Fatalf("first use: %v", err)
+	}
+	now = now.Add(5 * time.Second)
+	if _, err := verifier.Verify(token, getJobMethod); !errors.Is(err, ErrReplayedToken) {
+		t.Errorf("second use: got %v, want %v", err, ErrReplayedToken)
+	}
+
+	// Pruning forgets request IDs only once their token has expired.
+	now = now.Add(2 * time.Minute)
+	if _, err := verifier.Verify(token, getJobMethod); !errors.Is(err, ErrExpiredToken) {
+		t.Errorf("after expiry: got %v, want %v", err, ErrExpiredToken)
+	}
+	fresh, _ := signer.Sign("tenant-a", getJobMethod)
+	if _, err := verifier.Verify(fresh, getJobMethod); err != nil {
+		t.Fatalf("fresh token: %v", err)
+	}
+	if len(verifier.seen) != 1 {
+		t.Errorf("expired request IDs kept: %v", verifier.seen)
+	}
+}
+
+func TestVerifyExpiry(t *testing.T) {
+	start := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		elapsed time.Duration
+		wantErr error
+	}{
+		{name: "fresh", elapsed: 0},
+		{name: "within ttl", elapsed: 29 * time.Second},
+		{name: "within clock skew", elapsed: 35 * time.Second},
+		{name: "expired", elapsed: 40 * time.Second, wantErr: ErrExpiredToken},
+		{name: "long expired", elapsed: time.Hour, wantErr: ErrExpiredToken},
+		{name: "issued in the future", elapsed: -time.Minute, wantErr: ErrMalformedToken},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now := start
+			signer, verifier := newTestPair(t, &now)
+			token, _ := signer.Sign("tenant-a", getJobMethod)
+			now = start.Add(tt.elapsed)
+			if _, err := verifier.Verify(token, getJobMethod); !errors.Is(err, tt.wantErr) {
+				t.Errorf("got %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestVerifyRejectsTampering(t *testing.T) {
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	signer, verifier := newTestPair(t, &now)
+	token, _ := signer.Sign("tenant-a", getJobMethod)
+	parts := strings.Split(token, ".")
+
+	// Re-encode the payload for another tenant, keeping the signature.
+	payload, _ := base64.RawURLEncoding.DecodeString(parts[1])
+	forged := strings.Replace(string(payload), `"tenant-a"`, `"tenant-b"`, 1)
+	otherTenant := parts[0] + "." + base64.RawURLEncoding.EncodeToString([]byte(forged)) + "." + parts[2]
+
+	// Re-sign with an unknown secret.
+	foreign, _ := NewSigner(otherSecret, "attacker", 30*time.Second)
+	foreign.now = signer.now
+	foreignToken, _ := foreign.Sign("tenant-a", getJobMethod)
+
+	sig, _ := base64.RawURLEncoding.DecodeString(parts[2])
+	sig[0] ^= 1
+	flipped := base64.RawURLEncoding.EncodeToString(sig)
+
+	tests := []struct {
+		name      string
+		token     string
+		procedure string
+		wantErr   error
+	}{
+		{name: "payload changed", token: otherTenant, procedure: getJobMethod, wantErr: ErrBadSignature},
+		{name: "signature changed", token: parts[0] + "." + parts[1] + "." + flipped, procedure: getJobMethod, wantErr: ErrBadSignature},
+		{name: "signature removed", token: parts[0] + "." + parts[1] + ".", procedure: getJobMethod, wantErr: ErrBadSignature},
+		{name: "other secret", token: foreignToken, procedure: getJobMethod, wantErr: ErrBadSignature},
+		{name: "other version", token: "v2." + parts[1] + "." + parts[2], procedure: getJobMethod, wantErr: ErrMalformedToken},
+		{name: "truncated", token: parts[0] + "." + parts[1], procedure: getJobMethod, wantErr: ErrMalformedToken},
+		{name: "empty", token: "", procedure: getJobMethod, wantErr: ErrMissingToken},
+		{name: "other procedure", token: token, procedure: "/jennah.v1.DeploymentService/DeleteJob", wantErr: ErrWrongProcedure},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := verifier.Verify(tt.token, tt.procedure); !errors.Is(err, tt.wantErr) {
+				t.Errorf("got %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	// None of the rejected attempts used up the genuine token.
+	if _, err := verifier.Verify(token, getJobMethod); err != nil {
+		t.Errorf("genuine token rejected after tampered attempts: %v", err)
+	}
+}
+
+func TestVerifierAcceptsPreviousSecret(t *testing.T) {
+	old, err := NewSigner(otherSecret, "gateway", 30*time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	verifier, err := NewVerifier(testSecret, otherSecret)
+	if err != nil {
+		t.Fatal(err)
+	}
+	token, _ := old.Sign("tenant-a", getJobMethod)
+	if _, err := verifier.Verify(token, getJobMethod); err != nil {
+		t.Errorf("token signed with previous secret rejected: %v", err)
+	}
+}
+
+func TestNewSignerValidates(t *testing.T) {
+	if _, err := NewSigner("short", "gateway", 30*time.Second); err == nil {
+		t.Error("short secret accepted")
+	}
+	if _, err := NewSigner(testSecret, "gateway", time.Hour); err == nil {
+		t.Error("TTL above MaxTokenTTL accepted")
+	}
+	if _, err := NewVerifier(); err == nil {
+		t.Error("verifier without secrets accepted")
+	}
+}
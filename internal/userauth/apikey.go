@@ -0,0 +1,89 @@
+package userauth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// APIKeyPrefix starts every API key, which tells them apart from session
+// tokens.
+const APIKeyPrefix = "jnh_"
+
+// API key scopes.
+const (
+	ScopeSubmit = "submit"
+	ScopeRead   = "read"
+	ScopeCancel = "cancel"
+)
+
+// AllScopes are the scopes a key can grant, and the default of a new key.
+var AllScopes = []string{ScopeSubmit, ScopeRead, ScopeCancel}
+
+// NewAPIKey is a freshly generated API key.
+type NewAPIKey struct {
+	// KeyID identifies the key; it is stored in plain text.
+	KeyID string
+	// Key is the full key handed to the user once.
+	Key string
+	// Salt and Hash are stored instead of the secret.
+	Salt []byte
+	Hash []byte
+}
+
+// GenerateAPIKey returns a new random key of the form jnh_<key ID>_<secret>.
+func GenerateAPIKey() (*NewAPIKey, error) {
+	var id [12]byte
+	var secret [32]byte
+	salt := make([]byte, 16)
+	for _, b := range [][]byte{id[:], secret[:], salt} {
+		if _, err := rand.Read(b); err != nil {
+			return nil, fmt.Errorf("failed to generate API key: %w", err)
+		}
+	}
+	keyID := hex.EncodeToString(id[:])
+	encoded := base64.RawURLEncoding.EncodeToString(secret[:])
+	return &NewAPIKey{
+		KeyID: keyID,
+		Key:   APIKeyPrefix + keyID + "_" + encoded,
+		Salt:  salt,
+		Hash:  HashAPIKeySecret(salt, encoded),
+	}, nil
+}
+
+// ParseAPIKey splits a key into its key ID and secret.
+func ParseAPIKey(key string) (keyID, secret string, ok bool) {
+	rest, ok := strings.CutPrefix(key, APIKeyPrefix)
+	if !ok {
+		return "", "", false
+	}
+	keyID, secret, ok = strings.Cut(rest, "_")
+	if !ok || keyID == "" || secret == "" {
+		return "", "", false
+	}
+	return keyID, secret, true
+}
+
+// HashAPIKeySecret returns the salted SHA-256 hash of a key's secret. Keys
+// carry 256 random bits, so a fast hash is enough.
+func HashAPIKeySecret(salt []byte, secret string) []byte {
+	h := sha256.New()
+	h.Write(salt)
+	h.Write([]byte(secret))
+	return h.Sum(nil)
+}
+
+// CheckAPIKeySecret reports whether secret matches a stored salt and hash.
+func CheckAPIKeySecret(salt, hash []byte, secret string) bool {
+	return subtle.ConstantTimeCompare(HashAPIKeySecret(salt, secret), hash) == 1
+}
+
+// ValidScope reports whether scope is one of AllScopes.
+func ValidScope(scope string) bool {
+	return slices.Contains(AllScopes, scope)
+}
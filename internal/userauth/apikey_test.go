@@ -0,0 +1,43 @@
+package userauth
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGenerateAndCheckAPIKey(t *testing.T) {
+	key, err := GenerateAPIKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(key.Key, APIKeyPrefix+key.KeyID+"_") {
+		t.Fatalf("key %q does not start with its key ID %q", key.Key, key.KeyID)
+	}
+	if strings.Contains(string(key.Hash), key.Key) {
+		t.Fatal("hash contains the key")
+	}
+
+	keyID, secret, ok := ParseAPIKey(key.Key)
+	if !ok || keyID != key.KeyID {
+		t.Fatalf("ParseAPIKey(%q) = %q, %q, %v", key.Key, keyID, secret, ok)
+	}
+	if !CheckAPIKeySecret(key.Salt, key.Hash, secret) {
+		t.Error("secret of the generated key does not match its hash")
+	}
+	if CheckAPIKeySecret(key.Salt, key.Hash, secret+"x") {
+		t.Error("wrong secret matches the hash")
+	}
+
+	other, _ := GenerateAPIKey()
+	if other.KeyID == key.KeyID || other.Key == key.Key {
+		t.Error("two generated keys are identical")
+	}
+}
+
+func TestParseAPIKeyRejectsMalformedKeys(t *testing.T) {
+	for _, key := range []string{"", "jnh_", "jnh_abc", "jnh__secret", "jnh_abc_", "xyz_abc_secret", "eyJhbGciOiJIUzI1NiJ9.e30.sig"} {
+		if _, _, ok := ParseAPIKey(key); ok {
+			t.Errorf("ParseAPIKey(%q) accepted a malformed key", key)
+		}
+	}
+}
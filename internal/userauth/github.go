@@ -0,0 +1,194 @@
+package userauth
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// DefaultGitHubAPIURL is the base URL of the GitHub REST API.
+const DefaultGitHubAPIURL = "https://api.github.com"
+
+// ErrTokenRejected is returned when the identity provider does not accept a
+// token.
+var ErrTokenRejected = errors.New("token was rejected by the identity provider")
+
+// ErrGitHubUserNotFound is returned by LookupGitHubUserID for logins GitHub
+// does not know.
+var ErrGitHubUserNotFound = errors.New("GitHub user not found")
+
+// GitHubOptions configure a GitHubVerifier.
+type GitHubOptions struct {
+	// APIURL is the base URL of the REST API; DefaultGitHubAPIURL when empty.
+	APIURL string
+	// ClientID and ClientSecret identify the gateway's OAuth app; only tokens
+	// issued to that app are accepted. Both are required.
+	ClientID     string
+	ClientSecret string
+	// HTTPClient calls the API; http.DefaultClient when nil.
+	HTTPClient *http.Client
+}
+
+// GitHubVerifier verifies GitHub OAuth access tokens by calling the API with
+// them.
+type GitHubVerifier struct {
+	opts GitHubOptions
+}
+
+// NewGitHubVerifier creates a GitHub token verifier.
+func NewGitHubVerifier(opts GitHubOptions) (*GitHubVerifier, error) {
+	if opts.ClientID == "" || opts.ClientSecret == "" {
+		return nil, errors.New("GitHub client ID and client secret are both required")
+	}
+	if opts.APIURL == "" {
+		opts.APIURL = DefaultGitHubAPIURL
+	}
+	opts.APIURL = strings.TrimRight(opts.APIURL, "/")
+	if opts.HTTPClient == nil {
+		opts.HTTPClient = http.DefaultClient
+	}
+	return &GitHubVerifier{opts: opts}, nil
+}
+
+// Verify checks that token is a live access token issued to the gateway's
+// OAuth app, and returns its user and primary verified email.
+func (v *GitHubVerifier) Verify(ctx context.Context, token string) (*Identity, error) {
+	if token == "" {
+		return nil, ErrMissingToken
+	}
+	if err := v.checkApplication(ctx, token); err != nil {
+		return nil, err
+	}
+
+	var user struct {
+		ID    int64  `json:"id"`
+		Login string `json:"login"`
+	}
+	if err := v.get(ctx, token, "/user", &user); err != nil {
+		return nil, err
+	}
+	if user.ID == 0 {
+		return nil, fmt.Errorf("%w: user has no ID", ErrInvalidClaims)
+	}
+
+	var emails []struct {
+		Email    string `json:"email"`
+		Primary  bool   `json:"primary"`
+		Verified bool   `json:"verified"`
+	}
+	if err := v.get(ctx, token, "/user/emails", &emails); err != nil {
+		return nil, err
+	}
+	for _, e := range emails {
+		if e.Primary && e.Verified {
+			// Logins can be renamed and reused by someone else; the numeric
+			// ID cannot.
+			return &Identity{Provider: "github", UserID: strconv.FormatInt(user.ID, 10), Email: e.Email}, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: no primary verified email", ErrInvalidClaims)
+}
+
+// checkApplication asks GitHub whether token belongs to the configured OAuth
+// app, so tokens issued to other apps cannot be replayed here.
+func (v *GitHubVerifier) checkApplication(ctx context.Context, token string) error {
+	body, err := json.Marshal(map[string]string{"access_token": token})
+	if err != nil {
+		return err
+	}
+	endpoint := v.opts.APIURL + "/applications/" + url.PathEscape(v.opts.ClientID) + "/token"
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.SetBasicAuth(v.opts.ClientID, v.opts.ClientSecret)
+	req.Header.Set("Content-Type", "application/json")
+	setGitHubHeaders(req)
+
+	resp, err := v.opts.HTTPClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to check GitHub token: %w", err)
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return nil
+	case http.StatusNotFound, http.StatusUnprocessableEntity:
+		return ErrTokenRejected
+	default:
+		return fmt.Errorf("GitHub token check returned %d", resp.StatusCode)
+	}
+}
+
+// IsGitHubUserID reports whether id is a numeric GitHub user ID rather than
+// a login, which identities were keyed by before.
+func IsGitHubUserID(id string) bool {
+	n, err := strconv.ParseInt(id, 10, 64)
+	return err == nil && n > 0
+}
+
+// LookupGitHubUserID returns the numeric ID of the user who currently holds
+// login. token is optional; it raises the API's rate limit. apiURL defaults to
+// DefaultGitHubAPIURL and client to http.DefaultClient.
+func LookupGitHubUserID(ctx context.Context, apiURL string, client *http.Client, token, login string) (string, error) {
+	if apiURL == "" {
+		apiURL = DefaultGitHubAPIURL
+	}
+	if client == nil {
+		client = http.DefaultClient
+	}
+	var user struct {
+		ID int64 `json:"id"`
+	}
+	if err := githubGet(ctx, client, strings.TrimRight(apiURL, "/"), token, "/users/"+url.PathEscape(login), &user); err != nil {
+		return "", err
+	}
+	if user.ID == 0 {
+		return "", fmt.Errorf("GitHub user %s has no ID", login)
+	}
+	return strconv.FormatInt(user.ID, 10), nil
+}
+
+func (v *GitHubVerifier) get(ctx context.Context, token, path string, out any) error {
+	return githubGet(ctx, v.opts.HTTPClient, v.opts.APIURL, token, path, out)
+}
+
+func githubGet(ctx context.Context, client *http.Client, apiURL, token, path string, out any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, apiURL+path, nil)
+	if err != nil {
+		return err
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	setGitHubHeaders(req)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to call GitHub %s: %w", path, err)
+	}
+	defer resp.Body.Close()
+	switch {
+	case resp.StatusCode == http.StatusUnauthorized:
+		return ErrTokenRejected
+	case resp.StatusCode == http.StatusNotFound && strings.HasPrefix(path, "/users/"):
+		return ErrGitHubUserNotFound
+	case resp.StatusCode != http.StatusOK:
+		return fmt.Errorf("GitHub %s returned %d", path, resp.StatusCode)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode GitHub %s: %w", path, err)
+	}
+	return nil
+}
+
+func setGitHubHeaders(req *http.Request) {
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+}
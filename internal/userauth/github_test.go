@@ -0,0 +1,127 @@
+package userauth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+const (
+	testGitHubToken  = "gho_valid"
+	testGitHubClient = "Iv1.jennah"
+	testGitHubSecret = "client-secret"
+)
+
+// newTestGitHubAPI serves the GitHub endpoints the verifier calls. Only
+// testGitHubToken is valid, and it belongs to testGitHubClient.
+func newTestGitHubAPI(t *testing.T) *httptest.Server {
+	t.Helper()
+	mux := http.NewServeMux()
+	authorized := func(next http.HandlerFunc) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Authorization") != "Bearer "+testGitHubToken {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			next(w, r)
+		}
+	}
+	mux.HandleFunc("GET /user", authorized(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]any{"id": 583231, "login": "octocat"})
+	}))
+	mux.HandleFunc("GET /users/{login}", func(w http.ResponseWriter, r *http.Request) {
+		if r.PathValue("login") != "octocat" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]any{"id": 583231, "login": "octocat"})
+	})
+	mux.HandleFunc("GET /user/emails", authorized(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode([]map[string]any{
+			{"email": "old@example.com", "primary": false, "verified": true},
+			{"email": "octocat@example.com", "primary": true, "verified": true},
+		})
+	}))
+	mux.HandleFunc("POST /applications/{client}/token", func(w http.ResponseWriter, r *http.Request) {
+		user, pass, _ := r.BasicAuth()
+		if user != testGitHubClient || pass != testGitHubSecret || r.PathValue("client") != testGitHubClient {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		var body struct {
+			AccessToken string `json:"access_token"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		if body.AccessToken != testGitHubToken {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`{}`))
+	})
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestGitHubVerify(t *testing.T) {
+	api := newTestGitHubAPI(t)
+	v, err := NewGitHubVerifier(GitHubOptions{APIURL: api.URL, ClientID: testGitHubClient, ClientSecret: testGitHubSecret})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	id, err := v.Verify(context.Background(), testGitHubToken)
+	if err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+	want := Identity{Provider: "github", UserID: "583231", Email: "octocat@example.com"}
+	if *id != want {
+		t.Errorf("identity = %+v, want %+v", id, want)
+	}
+
+	if _, err := v.Verify(context.Background(), "gho_forged"); !errors.Is(err, ErrTokenRejected) {
+		t.Errorf("forged token: err = %v, want ErrTokenRejected", err)
+	}
+}
+
+func TestGitHubVerifyChecksApplication(t *testing.T) {
+	api := newTestGitHubAPI(t)
+	v, err := NewGitHubVerifier(GitHubOptions{APIURL: api.URL, ClientID: testGitHubClient, ClientSecret: testGitHubSecret})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := v.Verify(context.Background(), testGitHubToken); err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+	if _, err := v.Verify(context.Background(), "gho_other_app"); !errors.Is(err, ErrTokenRejected) {
+		t.Errorf("token of another app: err = %v, want ErrTokenRejected", err)
+	}
+
+	for _, opts := range []GitHubOptions{{}, {ClientID: testGitHubClient}, {ClientSecret: testGitHubSecret}} {
+		if _, err := NewGitHubVerifier(opts); err == nil {
+			t.Errorf("NewGitHubVerifier(%+v) accepted an incomplete OAuth app", opts)
+		}
+	}
+}
+
+func TestLookupGitHubUserID(t *testing.T) {
+	api := newTestGitHubAPI(t)
+	id, err := LookupGitHubUserID(context.Background(), api.URL, nil, "", "octocat")
+	if err != nil {
+		t.Fatalf("LookupGitHubUserID: %v", err)
+	}
+	if id != "583231" || !IsGitHubUserID(id) {
+		t.Errorf("id = %q, want 583231", id)
+	}
+	if _, err := LookupGitHubUserID(context.Background(), api.URL, nil, "", "ghost"); !errors.Is(err, ErrGitHubUserNotFound) {
+		t.Errorf("unknown login: err = %v, want ErrGitHubUserNotFound", err)
+	}
+	for _, login := range []string{"octocat", "", "0", "-5", "12ab"} {
+		if IsGitHubUserID(login) {
+			t.Errorf("IsGitHubUserID(%q) = true", login)
+		}
+	}
+}
@@ -0,0 +1,250 @@
+package userauth
+
+import (
+	"context"
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultGoogleJWKSURL serves the keys Google signs ID tokens with.
+const DefaultGoogleJWKSURL = "https://www.googleapis.com/oauth2/v3/certs"
+
+// defaultGoogleIssuers are the iss values of Google ID tokens.
+var defaultGoogleIssuers = []string{"https://accounts.google.com", "accounts.google.com"}
+
+// jwksRefreshInterval bounds how often an unknown key ID makes the verifier
+// fetch the key set again.
+const jwksRefreshInterval = time.Minute
+
+// defaultJWKSMaxAge is how long a key set without Cache-Control is used.
+const defaultJWKSMaxAge = time.Hour
+
+// GoogleOptions configure a GoogleVerifier.
+type GoogleOptions struct {
+	// ClientIDs are the OAuth client IDs ID tokens must be issued to (aud).
+	ClientIDs []string
+	// JWKSURL serves the signing keys; DefaultGoogleJWKSURL when empty.
+	JWKSURL string
+	// Issuers are the accepted iss values; Google's when empty.
+	Issuers []string
+	// HTTPClient fetches the key set; http.DefaultClient when nil.
+	HTTPClient *http.Client
+}
+
+// GoogleVerifier verifies Google OIDC ID tokens.
+type GoogleVerifier struct {
+	opts GoogleOptions
+	now  func() time.Time
+
+	mu        sync.Mutex
+	keys      map[string]*rsa.PublicKey
+	expires   time.Time
+	lastFetch time.Time
+}
+
+// NewGoogleVerifier creates a verifier for ID tokens issued to one of
+// opts.ClientIDs.
+func NewGoogleVerifier(opts GoogleOptions) (*GoogleVerifier, error) {
+	if len(opts.ClientIDs) == 0 {
+		return nil, errors.New("at least one Google client ID is required")
+	}
+	if opts.JWKSURL == "" {
+		opts.JWKSURL = DefaultGoogleJWKSURL
+	}
+	if len(opts.Issuers) == 0 {
+		opts.Issuers = defaultGoogleIssuers
+	}
+	if opts.HTTPClient == nil {
+		opts.HTTPClient = http.DefaultClient
+	}
+	return &GoogleVerifier{opts: opts, now: time.Now}, nil
+}
+
+type idTokenHeader struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+}
+
+type idTokenClaims struct {
+	Issuer        string          `json:"iss"`
+	Subject       string          `json:"sub"`
+	Audience      audience        `json:"aud"`
+	IssuedAt      int64           `json:"iat"`
+	ExpiresAt     int64           `json:"exp"`
+	Email         string          `json:"email"`
+	EmailVerified json.RawMessage `json:"email_verified"`
+}
+
+// audience is the aud claim, which may be a string or a list of strings.
+type audience []string
+
+func (a *audience) UnmarshalJSON(data []byte) error {
+	var one string
+	if err := json.Unmarshal(data, &one); err == nil {
+		*a = audience{one}
+		return nil
+	}
+	var many []string
+	if err := json.Unmarshal(data, &many); err != nil {
+		return err
+	}
+	*a = many
+	return nil
+}
+
+// Verify checks an ID token's signature, issuer, audience and lifetime and
+// that its email is verified.
+func (v *GoogleVerifier) Verify(ctx context.Context, token string) (*Identity, error) {
+	if token == "" {
+		return nil, ErrMissingToken
+	}
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, ErrMalformedToken
+	}
+	var header idTokenHeader
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return nil, ErrMalformedToken
+	}
+	if header.Alg != "RS256" {
+		return nil, fmt.Errorf("%w: unsupported algorithm %q", ErrMalformedToken, header.Alg)
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, ErrMalformedToken
+	}
+	key, err := v.key(ctx, header.Kid)
+	if err != nil {
+		return nil, err
+	}
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	if err := rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], sig); err != nil {
+		return nil, ErrBadSignature
+	}
+
+	var claims idTokenClaims
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return nil, ErrMalformedToken
+	}
+	now := v.now()
+	if !now.Before(time.Unix(claims.ExpiresAt, 0).Add(clockSkew)) {
+		return nil, ErrExpiredToken
+	}
+	if time.Unix(claims.IssuedAt, 0).After(now.Add(clockSkew)) {
+		return nil, fmt.Errorf("%w: issued in the future", ErrInvalidClaims)
+	}
+	if !slices.Contains(v.opts.Issuers, claims.Issuer) {
+		return nil, fmt.Errorf("%w: unexpected issuer %q", ErrInvalidClaims, claims.Issuer)
+	}
+	if !slices.ContainsFunc(claims.Audience, func(aud string) bool { return slices.Contains(v.opts.ClientIDs, aud) }) {
+		return nil, fmt.Errorf("%w: token was issued to another client", ErrInvalidClaims)
+	}
+	if claims.Subject == "" || claims.Email == "" {
+		return nil, fmt.Errorf("%w: token has no subject or email", ErrInvalidClaims)
+	}
+	if verified := string(claims.EmailVerified); verified != "true" && verified != `"true"` {
+		return nil, fmt.Errorf("%w: email is not verified", ErrInvalidClaims)
+	}
+	return &Identity{Provider: "google", UserID: claims.Subject, Email: claims.Email}, nil
+}
+
+// key returns the signing key kid, fetching the key set when it is stale or
+// does not have kid.
+func (v *GoogleVerifier) key(ctx context.Context, kid string) (*rsa.PublicKey, error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	now := v.now()
+	if key, ok := v.keys[kid]; ok && now.Before(v.expires) {
+		return key, nil
+	}
+	if now.Sub(v.lastFetch) >= jwksRefreshInterval || now.After(v.expires) {
+		v.lastFetch = now
+		keys, maxAge, err := fetchJWKS(ctx, v.opts.HTTPClient, v.opts.JWKSURL)
+		if err != nil {
+			return nil, err
+		}
+		v.keys, v.expires = keys, now.Add(maxAge)
+	}
+	key, ok := v.keys[kid]
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown signing key %q", ErrBadSignature, kid)
+	}
+	return key, nil
+}
+
+// fetchJWKS reads the RSA keys of a JWK set and how long it may be cached.
+func fetchJWKS(ctx context.Context, client *http.Client, url string) (map[string]*rsa.PublicKey, time.Duration, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, 0, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to fetch signing keys: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, 0, fmt.Errorf("signing keys endpoint returned %d", resp.StatusCode)
+	}
+
+	var set struct {
+		Keys []struct {
+			Kid string `json:"kid"`
+			Kty string `json:"kty"`
+			N   string `json:"n"`
+			E   string `json:"e"`
+		} `json:"keys"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&set); err != nil {
+		return nil, 0, fmt.Errorf("failed to decode signing keys: %w", err)
+	}
+	keys := make(map[string]*rsa.PublicKey, len(set.Keys))
+	for _, k := range set.Keys {
+		if k.Kty != "RSA" {
+			continue
+		}
+		n, errN := base64.RawURLEncoding.DecodeString(k.N)
+		e, errE := base64.RawURLEncoding.DecodeString(k.E)
+		if errN != nil || errE != nil || len(e) == 0 || len(e) > 4 {
+			continue
+		}
+		keys[k.Kid] = &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}
+	}
+	return keys, jwksMaxAge(resp.Header.Get("Cache-Control")), nil
+}
+
+// jwksMaxAge returns the max-age of a Cache-Control header.
+func jwksMaxAge(cacheControl string) time.Duration {
+	for _, directive := range strings.Split(cacheControl, ",") {
+		name, value, _ := strings.Cut(strings.TrimSpace(directive), "=")
+		if strings.EqualFold(name, "max-age") {
+			if d, err := time.ParseDuration(value + "s"); err == nil && d > 0 {
+				return d
+			}
+		}
+	}
+	return defaultJWKSMaxAge
+}
+
+func decodeSegment(segment string, v any) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
@@ -0,0 +1,173 @@
+package userauth
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+const testClientID = "jennah.apps.googleusercontent.com"
+
+// testKeyServer serves a JWK set and signs ID tokens with its keys.
+type testKeyServer struct {
+	*httptest.Server
+	keys    map[string]*rsa.PrivateKey
+	fetches atomic.Int32
+}
+
+func newTestKeyServer(t *testing.T, kids ...string) *testKeyServer {
+	t.Helper()
+	ks := &testKeyServer{keys: make(map[string]*rsa.PrivateKey)}
+	for _, kid := range kids {
+		key, err := rsa.GenerateKey(rand.Reader, 2048)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ks.keys[kid] = key
+	}
+	ks.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ks.fetches.Add(1)
+		var set struct {
+			Keys []map[string]string `json:"keys"`
+		}
+		for kid, key := range ks.keys {
+			set.Keys = append(set.Keys, map[string]string{
+				"kid": kid,
+				"kty": "RSA",
+				"alg": "RS256",
+				"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+			})
+		}
+		w.Header().Set("Cache-Control", "public, max-age=600")
+		json.NewEncoder(w).Encode(set)
+	}))
+	t.Cleanup(ks.Close)
+	return ks
+}
+
+func (ks *testKeyServer) sign(t *testing.T, kid string, claims map[string]any) string {
+	t.Helper()
+	header, _ := json.Marshal(map[string]string{"alg": "RS256", "kid": kid, "typ": "JWT"})
+	payload, _ := json.Marshal(claims)
+	signed := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	digest := sha256.Sum256([]byte(signed))
+	sig, err := rsa.SignPKCS1v15(rand.Reader, ks.keys[kid], crypto.SHA256, digest[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signed + "." + base64.RawURLEncoding.EncodeToString(sig)
+}
+
+func validClaims(now time.Time) map[string]any {
+	return map[string]any{
+		"iss":            "https://accounts.google.com",
+		"aud":            testClientID,
+		"sub":            "1234567890",
+		"email":          "user@example.com",
+		"email_verified": true,
+		"iat":            now.Unix(),
+		"exp":            now.Add(time.Hour).Unix(),
+	}
+}
+
+func newTestGoogleVerifier(t *testing.T, ks *testKeyServer, now *time.Time) *GoogleVerifier {
+	t.Helper()
+	v, err := NewGoogleVerifier(GoogleOptions{ClientIDs: []string{testClientID}, JWKSURL: ks.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	v.now = func() time.Time { return *now }
+	return v
+}
+
+func TestGoogleVerify(t *testing.T) {
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	ks := newTestKeyServer(t, "k1")
+	v := newTestGoogleVerifier(t, ks, &now)
+
+	id, err := v.Verify(context.Background(), ks.sign(t, "k1", validClaims(now)))
+	if err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+	want := Identity{Provider: "google", UserID: "1234567890", Email: "user@example.com"}
+	if *id != want {
+		t.Errorf("identity = %+v, want %+v", id, want)
+	}
+
+	// The key set is cached.
+	if _, err := v.Verify(context.Background(), ks.sign(t, "k1", validClaims(now))); err != nil {
+		t.Fatal(err)
+	}
+	if n := ks.fetches.Load(); n != 1 {
+		t.Errorf("key set fetched %d times, want 1", n)
+	}
+}
+
+func TestGoogleVerifyRejectsInvalidTokens(t *testing.T) {
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	ks := newTestKeyServer(t, "k1")
+	v := newTestGoogleVerifier(t, ks, &now)
+
+	with := func(key string, value any) map[string]any {
+		c := validClaims(now)
+		c[key] = value
+		return c
+	}
+	for name, tc := range map[string]struct {
+		token string
+		want  error
+	}{
+		"expired":          {ks.sign(t, "k1", with("exp", now.Add(-time.Hour).Unix())), ErrExpiredToken},
+		"other audience":   {ks.sign(t, "k1", with("aud", "someone-else")), ErrInvalidClaims},
+		"other issuer":     {ks.sign(t, "k1", with("iss", "https://evil.example.com")), ErrInvalidClaims},
+		"unverified email": {ks.sign(t, "k1", with("email_verified", false)), ErrInvalidClaims},
+		"unknown key":      {"eyJhbGciOiJSUzI1NiIsImtpZCI6Im5vcGUifQ.e30.AA", ErrBadSignature},
+		"malformed":        {"not-a-jwt", ErrMalformedToken},
+	} {
+		if _, err := v.Verify(context.Background(), tc.token); !errors.Is(err, tc.want) {
+			t.Errorf("%s: err = %v, want %v", name, err, tc.want)
+		}
+	}
+
+	// A token signed by a key outside the set.
+	other := newTestKeyServer(t, "k1")
+	if _, err := v.Verify(context.Background(), other.sign(t, "k1", validClaims(now))); !errors.Is(err, ErrBadSignature) {
+		t.Errorf("foreign key: err = %v, want ErrBadSignature", err)
+	}
+}
+
+func TestGoogleVerifyRefetchesRotatedKeys(t *testing.T) {
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	ks := newTestKeyServer(t, "k1")
+	v := newTestGoogleVerifier(t, ks, &now)
+	if _, err := v.Verify(context.Background(), ks.sign(t, "k1", validClaims(now))); err != nil {
+		t.Fatal(err)
+	}
+
+	key, _ := rsa.GenerateKey(rand.Reader, 2048)
+	ks.keys["k2"] = key
+
+	// Within the refresh interval an unknown key is not fetched again.
+	if _, err := v.Verify(context.Background(), ks.sign(t, "k2", validClaims(now))); !errors.Is(err, ErrBadSignature) {
+		t.Errorf("err = %v, want ErrBadSignature", err)
+	}
+	now = now.Add(2 * time.Minute)
+	if _, err := v.Verify(context.Background(), ks.sign(t, "k2", validClaims(now))); err != nil {
+		t.Errorf("rotated key: %v", err)
+	}
+	if n := ks.fetches.Load(); n != 2 {
+		t.Errorf("key set fetched %d times, want 2", n)
+	}
+}
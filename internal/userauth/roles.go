@@ -0,0 +1,30 @@
+package userauth
+
+import "slices"
+
+// Organization roles, from least to most privileged.
+const (
+	RoleViewer    = "viewer"
+	RoleSubmitter = "submitter"
+	RoleAdmin     = "admin"
+)
+
+// roleScopes maps each role to the API key scopes it grants. Only admins
+// manage members, invitations and API keys, which no scope covers.
+var roleScopes = map[string][]string{
+	RoleViewer:    {ScopeRead},
+	RoleSubmitter: {ScopeRead, ScopeSubmit, ScopeCancel},
+	RoleAdmin:     {ScopeRead, ScopeSubmit, ScopeCancel},
+}
+
+// ValidRole reports whether role is a known organization role.
+func ValidRole(role string) bool {
+	_, ok := roleScopes[role]
+	return ok
+}
+
+// RoleAllows reports whether members with role may make calls that need
+// scope.
+func RoleAllows(role, scope string) bool {
+	return slices.Contains(roleScopes[role], scope)
+}
@@ -0,0 +1,26 @@
+package userauth
+
+import "testing"
+
+func TestRoleAllows(t *testing.T) {
+	tests := []struct {
+		role, scope string
+		want        bool
+	}{
+		{RoleViewer, ScopeRead, true},
+		{RoleViewer, ScopeSubmit, false},
+		{RoleViewer, ScopeCancel, false},
+		{RoleSubmitter, ScopeSubmit, true},
+		{RoleSubmitter, ScopeCancel, true},
+		{RoleAdmin, ScopeCancel, true},
+		{"owner", ScopeRead, false},
+	}
+	for _, tt := range tests {
+		if got := RoleAllows(tt.role, tt.scope); got != tt.want {
+			t.Errorf("RoleAllows(%q, %q) = %v, want %v", tt.role, tt.scope, got, tt.want)
+		}
+	}
+	if ValidRole("owner") || !ValidRole(RoleSubmitter) {
+		t.Error("ValidRole does not match the defined roles")
+	}
+}
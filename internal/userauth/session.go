@@ -0,0 +1,208 @@
+// Package userauth authenticates end users at the gateway.
+//
+// A user signs in with a token from an identity provider: a Google OIDC ID
+// token or a GitHub OAuth access token. The gateway verifies that token with
+// the provider and issues its own session token, an HS256 JWT, which the
+// user sends as "Authorization: Bearer <token>" on every later call.
+// Automation sends a tenant-scoped API key in the same header instead.
+package userauth
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// MinSecretLength is the minimum length of the session signing secret in
+// bytes.
+const MinSecretLength = 32
+
+// sessionIssuer is the iss claim of session tokens.
+const sessionIssuer = "jennah-gateway"
+
+// clockSkew is how far a token's issuer clock may be ahead of or behind the
+// gateway's.
+const clockSkew = 30 * time.Second
+
+// Errors returned when a token is rejected.
+var (
+	ErrMissingToken   = errors.New("token is missing")
+	ErrMalformedToken = errors.New("token is malformed")
+	ErrBadSignature   = errors.New("token signature is invalid")
+	ErrExpiredToken   = errors.New("token has expired")
+	ErrInvalidClaims  = errors.New("token claims are invalid")
+	ErrSessionTooOld  = errors.New("session has reached its maximum age; sign in again")
+)
+
+// Identity is a user verified by an identity provider.
+type Identity struct {
+	// Provider is "google" or "github".
+	Provider string
+	// UserID is the user's ID at the provider.
+	UserID string
+	// Email is verified by the provider.
+	Email string
+}
+
+// IdentityVerifier verifies a token issued by an identity provider.
+type IdentityVerifier interface {
+	Verify(ctx context.Context, token string) (*Identity, error)
+}
+
+// sessionClaims are the contents of a session token.
+type sessionClaims struct {
+	Issuer    string `json:"iss"`
+	Subject   string `json:"sub"`
+	Provider  string `json:"provider"`
+	Email     string `json:"email"`
+	IssuedAt  int64  `json:"iat"`
+	ExpiresAt int64  `json:"exp"`
+	// AuthTime is when the user last signed in with the identity provider.
+	// Refreshed tokens keep it, which bounds how long a session lives.
+	AuthTime int64 `json:"auth_time"`
+}
+
+// Sessions issues and verifies session tokens.
+type Sessions struct {
+	key    []byte
+	ttl    time.Duration
+	maxAge time.Duration
+	now    func() time.Time
+}
+
+// NewSessions creates a session issuer whose tokens are valid for ttl and
+// can be refreshed until maxAge after the user signed in.
+func NewSessions(secret string, ttl, maxAge time.Duration) (*Sessions, error) {
+	if len(secret) < MinSecretLength {
+		return nil, fmt.Errorf("session secret must be at least %d bytes", MinSecretLength)
+	}
+	if ttl <= 0 {
+		return nil, fmt.Errorf("session TTL must be positive, got %s", ttl)
+	}
+	if maxAge < ttl {
+		return nil, fmt.Errorf("session max age %s is shorter than the session TTL %s", maxAge, ttl)
+	}
+	return &Sessions{key: []byte(secret), ttl: ttl, maxAge: maxAge, now: time.Now}, nil
+}
+
+// Issue returns a session token for id and when it expires.
+func (s *Sessions) Issue(id *Identity) (string, time.Time, error) {
+	now := s.now()
+	return s.sign(id, now, now)
+}
+
+// Verify checks a session token and returns the identity it was issued for.
+func (s *Sessions) Verify(token string) (*Identity, error) {
+	claims, err := s.parse(token)
+	if err != nil {
+		return nil, err
+	}
+	if !s.now().Before(time.Unix(claims.ExpiresAt, 0).Add(clockSkew)) {
+		return nil, ErrExpiredToken
+	}
+	return claims.identity(), nil
+}
+
+// Refresh returns a new token for the identity of token. The token may have
+// expired, but not more than maxAge may have passed since the user signed
+// in.
+func (s *Sessions) Refresh(token string) (string, time.Time, error) {
+	claims, err := s.parse(token)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	authTime := time.Unix(claims.AuthTime, 0)
+	if s.now().Sub(authTime) > s.maxAge {
+		return "", time.Time{}, ErrSessionTooOld
+	}
+	return s.sign(claims.identity(), authTime, s.now())
+}
+
+func (s *Sessions) sign(id *Identity, authTime, now time.Time) (string, time.Time, error) {
+	expires := now.Add(s.ttl)
+	if limit := authTime.Add(s.maxAge); expires.After(limit) {
+		expires = limit
+	}
+	payload, err := json.Marshal(sessionClaims{
+		Issuer:    sessionIssuer,
+		Subject:   id.UserID,
+		Provider:  id.Provider,
+		Email:     id.Email,
+		IssuedAt:  now.Unix(),
+		ExpiresAt: expires.Unix(),
+		AuthTime:  authTime.Unix(),
+	})
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	signed := sessionHeader + "." + base64.RawURLEncoding.EncodeToString(payload)
+	return signed + "." + base64.RawURLEncoding.EncodeToString(hs256(s.key, signed)), expires, nil
+}
+
+// sessionHeader is the encoded JOSE header of every session token.
+var sessionHeader = base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`))
+
+// parse checks the signature and claims of token, but not its expiry.
+func (s *Sessions) parse(token string) (*sessionClaims, error) {
+	if token == "" {
+		return nil, ErrMissingToken
+	}
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 || parts[0] != sessionHeader {
+		return nil, ErrMalformedToken
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, ErrMalformedToken
+	}
+	if !hmac.Equal(sig, hs256(s.key, parts[0]+"."+parts[1])) {
+		return nil, ErrBadSignature
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, ErrMalformedToken
+	}
+	var claims sessionClaims
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return nil, ErrMalformedToken
+	}
+	if claims.Issuer != sessionIssuer || claims.Subject == "" || claims.Provider == "" || claims.Email == "" {
+		return nil, ErrInvalidClaims
+	}
+	if time.Unix(claims.IssuedAt, 0).After(s.now().Add(clockSkew)) {
+		return nil, ErrInvalidClaims
+	}
+	// Sessions issued before GitHub users were keyed by numeric ID name a
+	// login, which may since belong to someone else.
+	if claims.Provider == "github" && !IsGitHubUserID(claims.Subject) {
+		return nil, ErrInvalidClaims
+	}
+	return &claims, nil
+}
+
+func (c *sessionClaims) identity() *Identity {
+	return &Identity{Provider: c.Provider, UserID: c.Subject, Email: c.Email}
+}
+
+// BearerToken returns the token of an "Authorization: Bearer" header, or ""
+// when there is none.
+func BearerToken(header http.Header) string {
+	scheme, token, ok := strings.Cut(header.Get("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return ""
+	}
+	return strings.TrimSpace(token)
+}
+
+func hs256(key []byte, signed string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(signed))
+	return h.Sum(nil)
+}
@@ -0,0 +1,141 @@
+package userauth
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testSecret = "0123456789abcdef0123456789abcdef"
+
+func newTestSessions(t *testing.T, now *time.Time) *Sessions {
+	t.Helper()
+	sessions, err := NewSessions(testSecret, time.Hour, 24*time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sessions.now = func() time.Time { return *now }
+	return sessions
+}
+
+var testIdentity = &Identity{Provider: "github", UserID: "583231", Email: "octocat@example.com"}
+
+func TestSessionIssueAndVerify(t *testing.T) {
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	sessions := newTestSessions(t, &now)
+
+	token, expires, err := sessions.Issue(testIdentity)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := now.Add(time.Hour); !expires.Equal(want) {
+		t.Errorf("expires = %s, want %s", expires, want)
+	}
+	id, err := sessions.Verify(token)
+	if err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+	if *id != *testIdentity {
+		t.Errorf("identity = %+v, want %+v", id, testIdentity)
+	}
+
+	now = now.Add(2 * time.Hour)
+	if _, err := sessions.Verify(token); !errors.Is(err, ErrExpiredToken) {
+		t.Errorf("expired token: err = %v, want ErrExpiredToken", err)
+	}
+}
+
+func TestSessionVerifyRejectsTampering(t *testing.T) {
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	sessions := newTestSessions(t, &now)
+	token, _, _ := sessions.Issue(testIdentity)
+	parts := strings.Split(token, ".")
+
+	other, _, _ := sessions.Issue(&Identity{Provider: "github", UserID: "9001", Email: "m@example.com"})
+	swapped := parts[0] + "." + strings.Split(other, ".")[1] + "." + parts[2]
+	if _, err := sessions.Verify(swapped); !errors.Is(err, ErrBadSignature) {
+		t.Errorf("swapped payload: err = %v, want ErrBadSignature", err)
+	}
+
+	foreign, _ := NewSessions("fedcba9876543210fedcba9876543210", time.Hour, time.Hour)
+	foreignToken, _, _ := foreign.Issue(testIdentity)
+	if _, err := sessions.Verify(foreignToken); !errors.Is(err, ErrBadSignature) {
+		t.Errorf("other secret: err = %v, want ErrBadSignature", err)
+	}
+
+	none := "eyJhbGciOiJub25lIiwidHlwIjoiSldUIn0." + parts[1] + "."
+	if _, err := sessions.Verify(none); !errors.Is(err, ErrMalformedToken) {
+		t.Errorf("alg none: err = %v, want ErrMalformedToken", err)
+	}
+	if _, err := sessions.Verify(""); !errors.Is(err, ErrMissingToken) {
+		t.Errorf("empty: err = %v, want ErrMissingToken", err)
+	}
+}
+
+func TestSessionRejectsGitHubLogin(t *testing.T) {
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	sessions := newTestSessions(t, &now)
+	token, _, err := sessions.Issue(&Identity{Provider: "github", UserID: "octocat", Email: "octocat@example.com"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := sessions.Verify(token); !errors.Is(err, ErrInvalidClaims) {
+		t.Errorf("Verify: err = %v, want ErrInvalidClaims", err)
+	}
+	if _, _, err := sessions.Refresh(token); !errors.Is(err, ErrInvalidClaims) {
+		t.Errorf("Refresh: err = %v, want ErrInvalidClaims", err)
+	}
+}
+
+func TestSessionRefresh(t *testing.T) {
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	signedIn := now
+	sessions := newTestSessions(t, &now)
+	token, _, _ := sessions.Issue(testIdentity)
+
+	// An expired token can be refreshed within the maximum age.
+	now = now.Add(3 * time.Hour)
+	refreshed, expires, err := sessions.Refresh(token)
+	if err != nil {
+		t.Fatalf("Refresh: %v", err)
+	}
+	if want := now.Add(time.Hour); !expires.Equal(want) {
+		t.Errorf("expires = %s, want %s", expires, want)
+	}
+	if id, err := sessions.Verify(refreshed); err != nil || *id != *testIdentity {
+		t.Errorf("refreshed token: identity %+v, err %v", id, err)
+	}
+
+	// Refreshed tokens never outlive the maximum age.
+	now = signedIn.Add(23*time.Hour + 30*time.Minute)
+	_, expires, err = sessions.Refresh(refreshed)
+	if err != nil {
+		t.Fatalf("Refresh: %v", err)
+	}
+	if want := signedIn.Add(24 * time.Hour); !expires.Equal(want) {
+		t.Errorf("expires = %s, want %s", expires, want)
+	}
+
+	now = signedIn.Add(25 * time.Hour)
+	if _, _, err := sessions.Refresh(refreshed); !errors.Is(err, ErrSessionTooOld) {
+		t.Errorf("refresh after max age: err = %v, want ErrSessionTooOld", err)
+	}
+}
+
+func TestBearerToken(t *testing.T) {
+	for header, want := range map[string]string{
+		"Bearer abc":  "abc",
+		"bearer  abc": "abc",
+		"Basic abc":   "",
+		"abc":         "",
+		"":            "",
+	} {
+		h := http.Header{}
+		h.Set("Authorization", header)
+		if got := BearerToken(h); got != want {
+			t.Errorf("BearerToken(%q) = %q, want %q", header, got, want)
+		}
+	}
+}
@@ -36,6 +36,7 @@ WORKER_CLAIM_INTERVAL_SECONDS="3"
 CLOUD_TASKS_QUEUE_ID="jennah-simple"
 CLOUD_RUN_IMAGE_REGISTRY="gcr.io/$PROJECT_ID"
 SERVICE_ACCOUNT="gcp-sa-dev-interns@$PROJECT_ID.iam.gserviceaccount.com"
+SERVICE_AUTH_SECRET_NAME="jennah-service-auth-secret"
 IMAGE_TAG="${IMAGE_TAG:-latest}"
 IMAGE="asia-docker.pkg.dev/$PROJECT_ID/asia.gcr.io/jennah-worker:$IMAGE_TAG"
 
@@ -65,6 +66,7 @@ DOCKER_COMMAND='docker run -d \
   -e PUBSUB_TOPIC_PREFIX=jennah-events- \
   -e PUBSUB_PROJECT_ID='"$PROJECT_ID"' \
   -e CONSUMER_PUSH_URL='"$CONSUMER_PUSH_URL"' \
+  -e SERVICE_AUTH_SECRET="$(gcloud secrets versions access latest --secret='"$SERVICE_AUTH_SECRET_NAME"' --project='"$PROJECT_ID"')" \
   '"$IMAGE"' \
   serve'
 